@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// activity merge flags
+var (
+	amgTargets string // comma-separated ids
+	amgSince   string // since time (optional; default: whole journal)
+	amgFrom    string // comma-separated source activity names
+	amgTo      string // canonical activity name to set
+	amgNote    string // note to attach to amend events
+	amgDryRun  bool   // default true to avoid surprises
+)
+
+// mergedActivityMap holds source activity -> canonical activity, keys lower
+// case. It is loaded lazily from "activities.map" and refreshed when
+// activity merge persists a mapping.
+var mergedActivityMap configCache[map[string]string]
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Manage activity names",
+}
+
+// activityMergeCmd is the activity counterpart of customer-merge and
+// project-merge: it writes amend events setting the canonical activity on
+// matching entries and persists the mapping under "activities.map" for
+// completion and reports:
+//
+//	activities:
+//	  map:
+//	    dev: development
+//	    coding: development
+var activityMergeCmd = &cobra.Command{
+	Use:     "merge",
+	Short:   "Non-destructively merge activity names by writing amend events (append-only)",
+	Example: "  tt activity merge --from dev,coding --to development --dry-run=false",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		canonical := strings.TrimSpace(amgTo)
+		if canonical == "" {
+			cobra.CheckErr(fmt.Errorf("--to is required (target canonical activity)"))
+		}
+		sources := splitNames(amgFrom)
+		if len(sources) == 0 && amgTargets == "" {
+			cobra.CheckErr(fmt.Errorf("--from is required unless --targets is given"))
+		}
+
+		var targetIDs []string
+		origByID := map[string]string{}
+
+		if amgTargets != "" {
+			targetIDs = splitNames(amgTargets)
+		} else {
+			from := earliestJournalDay()
+			if amgSince != "" {
+				from = mustParseTimeLocal(amgSince)
+			}
+			ents, err := loadEntries(from, nowLocal())
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("failed loading entries for selection: %w", err))
+			}
+			for _, e := range ents {
+				if !containsFold(sources, e.Activity) || e.Activity == canonical {
+					continue
+				}
+				targetIDs = append(targetIDs, e.ID)
+				origByID[e.ID] = e.Activity
+			}
+		}
+
+		newMappings := map[string]string{}
+		for _, s := range sources {
+			if !strings.EqualFold(s, canonical) {
+				newMappings[strings.ToLower(s)] = canonical
+			}
+		}
+
+		if amgDryRun {
+			fmt.Printf("DRY RUN: would write %d amend event(s) setting activity -> %q\n", len(targetIDs), canonical)
+			for _, id := range targetIDs {
+				fmt.Printf("  - %s : %s -> %s\n", id, origByID[id], canonical)
+			}
+			if len(newMappings) > 0 {
+				fmt.Println("DRY RUN: would persist activity mappings:")
+				for _, s := range sortedKeysOf(newMappings) {
+					fmt.Printf("  - %q -> %q\n", s, newMappings[s])
+				}
+			}
+			return
+		}
+
+		if len(newMappings) > 0 {
+			if err := saveActivityMappings(newMappings); err != nil {
+				cmd.Printf("warning: failed to persist activity mapping to config: %v\n", err)
+			}
+		}
+
+		if len(targetIDs) == 0 {
+			cmd.Println("No entries to amend; mapping recorded.")
+			return
+		}
+		evs := make([]Event, 0, len(targetIDs))
+		for _, id := range targetIDs {
+			meta := map[string]string{}
+			if orig := origByID[id]; orig != "" {
+				meta["merged_from_activity"] = orig
+			}
+			evs = append(evs, Event{
+				ID:       IDGen(),
+				Type:     "amend",
+				TS:       Now(),
+				Ref:      id,
+				Note:     amgNote,
+				Activity: canonical,
+				Meta:     meta,
+			})
+		}
+		if err := writeEvents(evs); err != nil {
+			cobra.CheckErr(fmt.Errorf("failed to write amend events: %w", err))
+		}
+		cmd.Printf("Wrote %d amend event(s) setting activity -> %q\n", len(evs), canonical)
+	},
+}
+
+func init() {
+	activityMergeCmd.Flags().StringVar(&amgTargets, "targets", "", "comma-separated target entry ids to amend")
+	activityMergeCmd.Flags().StringVar(&amgSince, "since", "", "only entries since this time (default: the whole journal)")
+	activityMergeCmd.Flags().StringVar(&amgFrom, "from", "", "comma-separated source activity names to merge")
+	activityMergeCmd.Flags().StringVar(&amgTo, "to", "", "canonical activity name to set (required)")
+	activityMergeCmd.Flags().StringVar(&amgNote, "note", "", "note to append to each amend event")
+	activityMergeCmd.Flags().BoolVar(&amgDryRun, "dry-run", true, "perform a dry-run (default true); use --dry-run=false to actually write amend events")
+
+	activityCmd.AddCommand(activityMergeCmd)
+	rootCmd.AddCommand(activityCmd)
+}
+
+// saveActivityMappings merges mappings (lower-case source -> canonical) into
+// activities.map and reloads the in-memory map.
+func saveActivityMappings(mappings map[string]string) error {
+	merged := map[string]string{}
+	for s, t := range viper.GetStringMapString("activities.map") {
+		merged[strings.ToLower(s)] = t
+	}
+	for s, t := range mappings {
+		merged[s] = t
+	}
+	viper.Set("activities.map", merged)
+	mergedActivityMap.reset()
+	return saveViperConfig()
+}
+
+// readMergedActivityMap returns activities.map with lower-case keys.
+func readMergedActivityMap() map[string]string {
+	m := map[string]string{}
+	for s, t := range viper.GetStringMapString("activities.map") {
+		if s != "" && t != "" {
+			m[strings.ToLower(s)] = t
+		}
+	}
+	return m
+}
+
+// CanonicalActivity returns the canonical name of activity from
+// activities.map. Unknown activities are returned unchanged.
+func CanonicalActivity(activity string) string {
+	if activity == "" {
+		return activity
+	}
+	if c, ok := mergedActivityMap.get(readMergedActivityMap)[strings.ToLower(strings.TrimSpace(activity))]; ok {
+		return c
+	}
+	return activity
+}
+
+// Levels of activities.enforce; off and warn are shared with lint.
+const activityStrict = "strict"
+
+// activityEnforceLevel is activities.enforce: activities outside
+// activities.allowed are accepted silently ("off", the default), accepted
+// with a warning ("warn") or rejected ("strict") by start, switch, add and
+// the TUI form. Names are compared after activities.map, ignoring case;
+// an empty activity is always accepted.
+//
+//	activities:
+//	  allowed: [development, meeting, review, docs]
+//	  enforce: warn
+func activityEnforceLevel() string {
+	switch l := strings.ToLower(strings.TrimSpace(viper.GetString("activities.enforce"))); l {
+	case lintWarn, activityStrict:
+		return l
+	}
+	return lintOff
+}
+
+// checkActivity applies activities.enforce to activity. It returns a warning
+// for the warn level and an error for the strict level; both are empty when
+// the activity is allowed, the level is off or no list is configured.
+func checkActivity(activity string) (string, error) {
+	level := activityEnforceLevel()
+	activity = strings.TrimSpace(activity)
+	if level == lintOff || activity == "" {
+		return "", nil
+	}
+	allowed := viper.GetStringSlice("activities.allowed")
+	if len(allowed) == 0 || containsFold(allowed, CanonicalActivity(activity)) {
+		return "", nil
+	}
+	msg := fmt.Sprintf("activity %q is not in activities.allowed (%s)", activity, strings.Join(allowed, ", "))
+	if level == activityStrict {
+		return "", fmt.Errorf("%s", msg)
+	}
+	return msg, nil
+}
+
+// mustCheckActivity is checkActivity for the CLI: warnings go to stderr,
+// errors end the command.
+func mustCheckActivity(activity string) {
+	warn, err := checkActivity(activity)
+	cobra.CheckErr(err)
+	if warn != "" {
+		logger.Warn(warn)
+	}
+}
+
+// activityPolicy serves checkActivity to the TUI form.
+type activityPolicy struct{}
+
+func (activityPolicy) CheckActivity(activity string) (string, error) {
+	return checkActivity(activity)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestActivityMergeAmendsAndCanonicalizes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("activities.map", map[string]string{})
+	mergedActivityMap.reset()
+	oldFrom, oldTo, oldDry, oldNow := amgFrom, amgTo, amgDryRun, Now
+	t.Cleanup(func() {
+		amgFrom, amgTo, amgDryRun, Now = oldFrom, oldTo, oldDry, oldNow
+		viper.Set("timezone", "")
+		viper.Set("activities.map", map[string]string{})
+		mergedActivityMap.reset()
+	})
+
+	day := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return day.Add(20 * time.Hour) }
+	add := func(id, activity string, h int) Event {
+		st := day.Add(time.Duration(h) * time.Hour)
+		ev := NewAddEvent(id, "Acme", "web", activity, nil, "", nil, st, st.Add(time.Hour))
+		ev.TS = st
+		return ev
+	}
+	if err := writeEvents([]Event{
+		add("e1", "dev", 8),
+		add("e2", "Coding", 9),
+		add("e3", "meeting", 10),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	amgFrom, amgTo, amgDryRun = "dev,coding", "development", false
+	activityMergeCmd.Run(activityMergeCmd, nil)
+
+	ents, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, e := range ents {
+		got[e.ID] = e.Activity
+	}
+	want := map[string]string{"e1": "development", "e2": "development", "e3": "meeting"}
+	for id, a := range want {
+		if got[id] != a {
+			t.Errorf("entry %s activity = %q, want %q (all: %v)", id, got[id], a, got)
+		}
+	}
+	if m := viper.GetStringMapString("activities.map"); m["dev"] != "development" || m["coding"] != "development" {
+		t.Errorf("persisted activities.map = %v", m)
+	}
+	if got := CanonicalActivity("DEV"); got != "development" {
+		t.Errorf("CanonicalActivity(DEV) = %q", got)
+	}
+	if got := CanonicalActivity("meeting"); got != "meeting" {
+		t.Errorf("CanonicalActivity(meeting) = %q", got)
+	}
+}
+
+func TestCheckActivityLevels(t *testing.T) {
+	viper.Set("activities.allowed", []string{"development", "meeting"})
+	viper.Set("activities.map", map[string]string{"dev": "development"})
+	mergedActivityMap.reset()
+	t.Cleanup(func() {
+		viper.Set("activities.allowed", nil)
+		viper.Set("activities.enforce", "")
+		viper.Set("activities.map", map[string]string{})
+		mergedActivityMap.reset()
+	})
+
+	viper.Set("activities.enforce", "")
+	if warn, err := checkActivity("gaming"); warn != "" || err != nil {
+		t.Fatalf("off: got %q, %v", warn, err)
+	}
+
+	viper.Set("activities.enforce", "warn")
+	if warn, err := checkActivity("gaming"); warn == "" || err != nil {
+		t.Fatalf("warn: got %q, %v", warn, err)
+	}
+
+	viper.Set("activities.enforce", "strict")
+	if _, err := checkActivity("gaming"); err == nil {
+		t.Fatal("strict: want error for gaming")
+	}
+	for _, a := range []string{"Meeting", "dev", ""} {
+		if warn, err := checkActivity(a); warn != "" || err != nil {
+			t.Errorf("strict %q: got %q, %v", a, warn, err)
+		}
+	}
+}
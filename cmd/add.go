@@ -32,7 +32,11 @@ var addCmd = &cobra.Command{
 			if len(args) <= consumed {
 				cobra.CheckErr(fmt.Errorf("end time is required"))
 			}
-			en = mustParseTimeLocal(args[consumed])
+			t, _, n, err := ParseFlexibleRange(args[consumed:consumed+1], Now())
+			if err != nil || n != 1 || t.IsZero() {
+				cobra.CheckErr(newTimeParseError(args, Now(), errInvalidEndTime))
+			}
+			en = t
 			consumed++
 		}
 
@@ -49,8 +53,15 @@ var addCmd = &cobra.Command{
 			project = args[consumed+1]
 		}
 
+		mustCheckActivity(addActivity)
+
+		billable := addBillable
+		if !cmd.Flags().Changed("billable") {
+			billable = billableFor(customer, project)
+		}
+
 		id := IDGen()
-		ev := NewAddEvent(id, customer, project, addActivity, boolPtr(addBillable), addNote, addTags, st, en)
+		ev := NewAddEvent(id, customer, project, addActivity, boolPtr(billable), addNote, addTags, st, en)
 		if err := Writer.WriteEvent(ev); err != nil {
 			cobra.CheckErr(err)
 		}
@@ -60,7 +71,9 @@ var addCmd = &cobra.Command{
 
 func init() {
 	addCmd.Flags().StringVarP(&addActivity, "activity", "a", "", "activity (design, workshop, docs, travel, etc.)")
-	addCmd.Flags().BoolVarP(&addBillable, "billable", "b", true, "mark as billable (default true)")
+	addCmd.Flags().BoolVarP(&addBillable, "billable", "b", true, "mark as billable (default: billable_defaults rule, else true)")
 	addCmd.Flags().StringSliceVarP(&addTags, "tag", "t", []string{}, "tag(s)")
 	addCmd.Flags().StringVarP(&addNote, "note", "n", "", "note")
+	_ = addCmd.RegisterFlagCompletionFunc("activity", activityFlagCompletion)
+	_ = addCmd.RegisterFlagCompletionFunc("tag", tagFlagCompletion)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"sort"
@@ -23,8 +24,9 @@ type Alias struct {
 }
 
 var (
-	startAlias  string
-	switchAlias string
+	startAlias     string
+	switchAlias    string
+	aliasListGroup string
 )
 
 // aliasCmd is the top-level alias management command.
@@ -33,29 +35,55 @@ var aliasCmd = &cobra.Command{
 	Short: "Manage aliases (presets) for quick start/switch",
 }
 
-// aliasListCmd lists available aliases.
+// aliasListCmd lists available aliases, grouped by namespace ("acme/standup"
+// is alias standup in group acme).
 var aliasListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List defined aliases",
 	Run: func(cmd *cobra.Command, args []string) {
-		aliases := loadAliases()
+		aliases := aliasesInGroup(loadAliases(), aliasListGroup)
 		if len(aliases) == 0 {
+			if aliasListGroup != "" {
+				fmt.Printf("no aliases in group %q\n", aliasListGroup)
+				return
+			}
 			fmt.Println("no aliases defined")
 			return
 		}
-		// sorted output
+		// sorted output, one heading per group when there are groups
 		keys := make([]string, 0, len(aliases))
+		grouped := false
 		for k := range aliases {
 			keys = append(keys, k)
+			grouped = grouped || aliasGroup(k) != ""
 		}
-		sort.Strings(keys)
+		sort.Slice(keys, func(i, j int) bool {
+			gi, gj := aliasGroup(keys[i]), aliasGroup(keys[j])
+			if gi != gj {
+				return gi < gj
+			}
+			return keys[i] < keys[j]
+		})
+		last := "\x00"
 		for _, k := range keys {
+			if g := aliasGroup(k); grouped && g != last {
+				if g == "" {
+					fmt.Println("(ungrouped)")
+				} else {
+					fmt.Printf("%s/\n", g)
+				}
+				last = g
+			}
 			a := aliases[k]
 			bill := "auto"
 			if a.Billable != nil {
 				bill = strconv.FormatBool(*a.Billable)
 			}
-			fmt.Printf("%s: %s/%s [%s] billable=%s tags=%v note=%q\n", k, a.Customer, a.Project, a.Activity, bill, a.Tags, a.Note)
+			indent := ""
+			if grouped {
+				indent = "  "
+			}
+			fmt.Printf("%s%s: %s/%s [%s] billable=%s tags=%v note=%q\n", indent, k, a.Customer, a.Project, a.Activity, bill, a.Tags, a.Note)
 		}
 	},
 }
@@ -95,6 +123,9 @@ var aliasSetCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
+		if err := validateAliasName(name); err != nil {
+			cobra.CheckErr(err)
+		}
 		// We want to be able to distinguish whether billable flag was provided.
 		var billPtr *bool
 		if cmd.Flags().Changed("billable") {
@@ -134,6 +165,8 @@ func init() {
 	rootCmd.AddCommand(aliasCmd)
 	aliasCmd.AddCommand(aliasListCmd, aliasSetCmd, aliasRmCmd, aliasShowCmd)
 
+	aliasListCmd.Flags().StringVar(&aliasListGroup, "group", "", "only list aliases in this group (e.g. acme for acme/standup)")
+
 	// flags for alias set
 	aliasSetCmd.Flags().StringVar(&setCustomer, "customer", "", "customer")
 	aliasSetCmd.Flags().StringVar(&setProject, "project", "", "project")
@@ -143,12 +176,12 @@ func init() {
 	aliasSetCmd.Flags().StringVarP(&setNote, "note", "n", "", "note")
 
 	// Add alias flag to start and switch commands and set up pre-run handlers.
-	startCmd.Flags().StringVar(&startAlias, "alias", "", "use named alias to prefill fields (activity, billable, tags, note)")
-	switchCmd.Flags().StringVar(&switchAlias, "alias", "", "use named alias to prefill fields (activity, billable, tags, note)")
+	startCmd.Flags().StringVar(&startAlias, "alias", "", "use named alias to prefill fields (customer/project when not given, activity, billable, tags, note)")
+	switchCmd.Flags().StringVar(&switchAlias, "alias", "", "use named alias to prefill fields (customer/project when not given, activity, billable, tags, note)")
 
 	// PreRunE handlers apply alias values to flags so start/switch Run functions pick them up.
-	// We only override flag-backed values (activity, billable, tag, note). Customer/project positional
-	// args are not injected here to avoid surprising behaviour; users may still pass them on the command line.
+	// Customer/project are positional; Run fills them from the alias via aliasArgs
+	// only when they are absent, so explicit arguments always win.
 	startCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		if startAlias == "" {
 			return nil
@@ -191,7 +224,39 @@ func applyAliasToFlags(cmd *cobra.Command, a Alias) {
 	if a.Note != "" {
 		_ = cmd.Flags().Set("note", a.Note)
 	}
-	// NOTE: customer/project are positional args; we do not inject them here to avoid unexpected overrides.
+}
+
+// aliasArgs fills absent customer/project positionals from the named alias.
+// A positional customer different from the alias' keeps the alias project
+// out, since that project belongs to another customer.
+func aliasArgs(name string, args []string) []string {
+	if name == "" || len(args) >= 2 {
+		return args
+	}
+	a, ok := getAlias(name)
+	if !ok {
+		return args
+	}
+	switch {
+	case len(args) == 0 && a.Customer != "":
+		out := []string{a.Customer}
+		if a.Project != "" {
+			out = append(out, a.Project)
+		}
+		return out
+	case len(args) == 1 && a.Project != "" && (a.Customer == "" || strings.EqualFold(args[0], a.Customer)):
+		return []string{args[0], a.Project}
+	}
+	return args
+}
+
+// expandAliasShorthand rewrites `tt @name [flags]` to `tt start --alias name
+// [flags]`, so an alias is one word to type.
+func expandAliasShorthand(args []string) []string {
+	if len(args) == 0 || len(args[0]) < 2 || !strings.HasPrefix(args[0], "@") {
+		return args
+	}
+	return append([]string{"start", "--alias", args[0][1:]}, args[1:]...)
 }
 
 // --------- persistence helpers backed by viper config under "aliases" key ----------
@@ -199,7 +264,7 @@ func applyAliasToFlags(cmd *cobra.Command, a Alias) {
 // in-memory cache to ensure immediate visibility of aliases after setAlias/deleteAlias.
 // Tests and runtime callers can rely on loadAliases() reflecting recent changes without
 // requiring a disk read round-trip.
-var aliasesCache map[string]Alias
+var aliasesCache configCache[map[string]Alias]
 
 func configFilePath() string {
 	if cf := viper.ConfigFileUsed(); cf != "" {
@@ -215,15 +280,15 @@ func configFilePath() string {
 // from viper (in-memory config) and falls back to reading the conventional on-disk
 // config file ($HOME/.tt/config.yaml) if needed. The result is cached in aliasesCache.
 func loadAliases() map[string]Alias {
-	// Return a copy of cache if populated to avoid accidental mutation by callers.
-	if aliasesCache != nil {
-		copy := map[string]Alias{}
-		for k, v := range aliasesCache {
-			copy[k] = v
-		}
-		return copy
+	// Return a copy of the cache to avoid accidental mutation by callers.
+	if out := maps.Clone(aliasesCache.get(readAliases)); out != nil {
+		return out
 	}
+	return map[string]Alias{}
+}
 
+// readAliases reads the aliases for aliasesCache.
+func readAliases() map[string]Alias {
 	// Prefer the in-memory viper aliases if present
 	raw := viper.GetStringMap("aliases")
 	if len(raw) == 0 {
@@ -233,10 +298,16 @@ func loadAliases() map[string]Alias {
 			viper.SetConfigFile(cf)
 			viper.SetConfigType("yaml")
 			_ = viper.ReadInConfig()
+			_ = applyProfile() // re-reading drops the merged profile
 			raw = viper.GetStringMap("aliases")
 		}
 	}
+	return parseAliases(raw)
+}
 
+// parseAliases converts a raw "aliases" map (as read by viper or YAML) into
+// aliases. Entries that are not maps are skipped.
+func parseAliases(raw map[string]interface{}) map[string]Alias {
 	out := map[string]Alias{}
 	for k, v := range raw {
 		// v is likely map[string]interface{}
@@ -262,12 +333,15 @@ func loadAliases() map[string]Alias {
 					}
 				}
 			}
-			if tg, ok := mm["tags"].([]interface{}); ok {
+			switch tg := mm["tags"].(type) {
+			case []interface{}:
 				for _, iv := range tg {
 					if s, ok := iv.(string); ok {
 						a.Tags = append(a.Tags, s)
 					}
 				}
+			case []string:
+				a.Tags = append(a.Tags, tg...)
 			}
 			if n, ok := mm["note"].(string); ok {
 				a.Note = n
@@ -275,19 +349,7 @@ func loadAliases() map[string]Alias {
 			out[k] = a
 		}
 	}
-
-	// populate cache
-	aliasesCache = map[string]Alias{}
-	for k, v := range out {
-		aliasesCache[k] = v
-	}
-
-	// return a copy to the caller
-	copyOut := map[string]Alias{}
-	for k, v := range aliasesCache {
-		copyOut[k] = v
-	}
-	return copyOut
+	return out
 }
 
 func getAlias(name string) (Alias, bool) {
@@ -297,86 +359,66 @@ func getAlias(name string) (Alias, bool) {
 }
 
 func setAlias(name string, a Alias) error {
-	// Ensure we have current aliases in cache
 	aliases := loadAliases()
 	aliases[name] = a
-	// update cache with the new map
-	aliasesCache = map[string]Alias{}
-	for k, v := range aliases {
-		aliasesCache[k] = v
-	}
-
-	// convert back to map[string]any and set into viper
-	out := map[string]map[string]any{}
-	for k, v := range aliases {
-		m := map[string]any{}
-		if v.Customer != "" {
-			m["customer"] = v.Customer
-		}
-		if v.Project != "" {
-			m["project"] = v.Project
-		}
-		if v.Activity != "" {
-			m["activity"] = v.Activity
-		}
-		if v.Billable != nil {
-			m["billable"] = *v.Billable
-		}
-		if len(v.Tags) > 0 {
-			m["tags"] = v.Tags
-		}
-		if v.Note != "" {
-			m["note"] = v.Note
-		}
-		out[k] = m
-	}
-	viper.Set("aliases", out)
-	return saveViperConfig()
+	return storeAliases(aliases)
 }
 
 func deleteAlias(name string) error {
-	// Ensure cache is populated
 	aliases := loadAliases()
 	if _, ok := aliases[name]; !ok {
 		return fmt.Errorf("alias %q not found", name)
 	}
 	delete(aliases, name)
-	// update cache
-	aliasesCache = map[string]Alias{}
-	for k, v := range aliases {
-		aliasesCache[k] = v
-	}
+	return storeAliases(aliases)
+}
 
-	// rebuild map for viper persistence
-	out := map[string]map[string]any{}
+// storeAliases replaces the cache and the "aliases" config key with aliases
+// and persists the config.
+func storeAliases(aliases map[string]Alias) error {
+	aliasesCache.set(maps.Clone(aliases))
+	out := map[string]any{}
 	for k, v := range aliases {
-		m := map[string]any{}
-		if v.Customer != "" {
-			m["customer"] = v.Customer
-		}
-		if v.Project != "" {
-			m["project"] = v.Project
-		}
-		if v.Activity != "" {
-			m["activity"] = v.Activity
-		}
-		if v.Billable != nil {
-			m["billable"] = *v.Billable
-		}
-		if len(v.Tags) > 0 {
-			m["tags"] = v.Tags
-		}
-		if v.Note != "" {
-			m["note"] = v.Note
-		}
-		out[k] = m
+		out[k] = aliasConfigMap(v)
 	}
 	viper.Set("aliases", out)
 	return saveViperConfig()
 }
 
+// aliasConfigMap is the config (and export) form of a, omitting empty fields.
+func aliasConfigMap(a Alias) map[string]any {
+	m := map[string]any{}
+	if a.Customer != "" {
+		m["customer"] = a.Customer
+	}
+	if a.Project != "" {
+		m["project"] = a.Project
+	}
+	if a.Activity != "" {
+		m["activity"] = a.Activity
+	}
+	if a.Billable != nil {
+		m["billable"] = *a.Billable
+	}
+	if len(a.Tags) > 0 {
+		m["tags"] = a.Tags
+	}
+	if a.Note != "" {
+		m["note"] = a.Note
+	}
+	return m
+}
+
 // saveViperConfig tries to write the config back to the configured file, falling back to $HOME/.tt/config.yaml.
+// With an active profile the profile's values are not written over the base config.
 func saveViperConfig() error {
+	if activeProfile != "" {
+		v := viper.New()
+		if err := v.MergeConfigMap(persistedSettings()); err != nil {
+			return err
+		}
+		return v.WriteConfigAs(configFilePath())
+	}
 	// Try WriteConfig first (will fail if no config file yet)
 	if err := viper.WriteConfig(); err == nil {
 		return nil
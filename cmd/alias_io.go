@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	aliasExportGroup  string
+	aliasImportMerge  bool
+	aliasImportGroup  string
+	aliasImportDryRun bool
+)
+
+// aliasExportCmd writes aliases as YAML in the same shape as the "aliases"
+// config section, so a team can share a preset file:
+//
+//	aliases:
+//	  acme/standup:
+//	    customer: acme
+//	    project: internal
+//	    activity: meeting
+//	    billable: false
+var aliasExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Export aliases as YAML",
+	Example: "  tt alias export > aliases.yaml\n  tt alias export --group acme > acme.yaml",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportAliases(cmd.OutOrStdout(), aliasesInGroup(loadAliases(), aliasExportGroup))
+	},
+}
+
+// aliasImportCmd reads an exported alias file. Without --merge the aliases in
+// scope (all of them, or the --group) are replaced by the file's; with
+// --merge the file's aliases are added or updated and the rest are kept.
+var aliasImportCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Import aliases from a YAML file",
+	Example: `  tt alias import aliases.yaml --merge
+  tt alias import team.yaml --group acme   # imports standup as acme/standup`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var in io.Reader = cmd.InOrStdin()
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		incoming, err := readAliasFile(in)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		incoming, err = prefixAliasGroup(incoming, aliasImportGroup)
+		if err != nil {
+			return err
+		}
+		merged, summary := mergeAliases(loadAliases(), incoming, aliasImportGroup, aliasImportMerge)
+		out := cmd.OutOrStdout()
+		if aliasImportDryRun {
+			fmt.Fprintf(out, "DRY RUN: would import aliases: %s\n", summary)
+			return nil
+		}
+		if err := storeAliases(merged); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Imported aliases: %s\n", summary)
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasExportCmd, aliasImportCmd)
+	aliasExportCmd.Flags().StringVar(&aliasExportGroup, "group", "", "only export aliases in this group")
+	aliasImportCmd.Flags().BoolVar(&aliasImportMerge, "merge", false, "add/update aliases from the file and keep the others (default: replace)")
+	aliasImportCmd.Flags().StringVar(&aliasImportGroup, "group", "", "import into this group (names are prefixed with <group>/)")
+	aliasImportCmd.Flags().BoolVar(&aliasImportDryRun, "dry-run", false, "show what would change without saving")
+}
+
+// aliasGroup returns the namespace of an alias name: "acme" for
+// "acme/standup", "acme/team" for "acme/team/retro" and "" for "standup".
+func aliasGroup(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// aliasesInGroup returns the aliases in group or one of its subgroups; an
+// empty group selects all aliases.
+func aliasesInGroup(aliases map[string]Alias, group string) map[string]Alias {
+	group = strings.Trim(strings.ToLower(group), "/")
+	if group == "" {
+		return aliases
+	}
+	out := map[string]Alias{}
+	for k, a := range aliases {
+		if strings.HasPrefix(strings.ToLower(k), group+"/") {
+			out[k] = a
+		}
+	}
+	return out
+}
+
+// validateAliasName rejects names that cannot round-trip through the config
+// (viper splits keys on ".") or the @name shorthand.
+func validateAliasName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("alias name is empty")
+	case strings.ContainsAny(name, ". \t"):
+		return fmt.Errorf("alias name %q must not contain dots or spaces", name)
+	case strings.HasPrefix(name, "@"):
+		return fmt.Errorf("alias name %q must not start with @", name)
+	}
+	for _, seg := range strings.Split(name, "/") {
+		if seg == "" {
+			return fmt.Errorf("alias name %q has an empty group segment", name)
+		}
+	}
+	return nil
+}
+
+func exportAliases(w io.Writer, aliases map[string]Alias) error {
+	out := map[string]map[string]any{}
+	for k, a := range aliases {
+		out[k] = aliasConfigMap(a)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string]any{"aliases": out}); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// readAliasFile parses an exported alias file. A bare name -> alias map
+// (without the top-level "aliases" key) is accepted as well. Names are lower
+// cased, as the config stores them.
+func readAliasFile(r io.Reader) (map[string]Alias, error) {
+	var doc map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid alias file: %w", err)
+	}
+	raw := doc
+	if inner, ok := doc["aliases"].(map[string]interface{}); ok {
+		raw = inner
+	}
+	out := map[string]Alias{}
+	for k, a := range parseAliases(raw) {
+		name := strings.ToLower(k)
+		if err := validateAliasName(name); err != nil {
+			return nil, err
+		}
+		out[name] = a
+	}
+	return out, nil
+}
+
+// prefixAliasGroup moves aliases into group ("standup" -> "acme/standup").
+func prefixAliasGroup(aliases map[string]Alias, group string) (map[string]Alias, error) {
+	group = strings.Trim(strings.ToLower(group), "/")
+	if group == "" {
+		return aliases, nil
+	}
+	if err := validateAliasName(group); err != nil {
+		return nil, fmt.Errorf("invalid --group: %w", err)
+	}
+	out := map[string]Alias{}
+	for k, a := range aliases {
+		out[group+"/"+k] = a
+	}
+	return out, nil
+}
+
+// mergeAliases applies incoming to existing. Unless merge is set, existing
+// aliases in group (all when group is empty) that incoming lacks are removed.
+// The summary counts added, updated, unchanged and removed aliases.
+func mergeAliases(existing, incoming map[string]Alias, group string, merge bool) (map[string]Alias, string) {
+	out := map[string]Alias{}
+	for k, a := range existing {
+		out[k] = a
+	}
+	var added, updated, same, removed int
+	if !merge {
+		for k := range aliasesInGroup(existing, group) {
+			if _, ok := incoming[k]; !ok {
+				delete(out, k)
+				removed++
+			}
+		}
+	}
+	for k, a := range incoming {
+		old, ok := existing[k]
+		switch {
+		case !ok:
+			added++
+		case aliasEqual(old, a):
+			same++
+		default:
+			updated++
+		}
+		out[k] = a
+	}
+	return out, fmt.Sprintf("%d added, %d updated, %d unchanged, %d removed", added, updated, same, removed)
+}
+
+func aliasEqual(a, b Alias) bool {
+	if a.Customer != b.Customer || a.Project != b.Project || a.Activity != b.Activity || a.Note != b.Note {
+		return false
+	}
+	if (a.Billable == nil) != (b.Billable == nil) || (a.Billable != nil && *a.Billable != *b.Billable) {
+		return false
+	}
+	return strings.Join(a.Tags, "\x00") == strings.Join(b.Tags, "\x00")
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -182,3 +185,115 @@ func TestAliasTableDriven_Multiple(t *testing.T) {
 		t.Fatalf("alias %q unexpectedly missing after deletion of another", cases[2].name)
 	}
 }
+
+func TestAliasInjectsPositionalsAndShorthand(t *testing.T) {
+	setupTempHome(t)
+	aliasesCache.reset()
+	t.Cleanup(aliasesCache.reset)
+	if err := setAlias("acme-standup", Alias{Customer: "Acme", Project: "Internal", Activity: "meeting"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := setAlias("review", Alias{Project: "Reviews"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		alias string
+		args  []string
+		want  []string
+	}{
+		{"acme-standup", nil, []string{"Acme", "Internal"}},
+		{"acme-standup", []string{"acme"}, []string{"acme", "Internal"}},
+		{"acme-standup", []string{"Globex"}, []string{"Globex"}},
+		{"acme-standup", []string{"Globex", "Ops"}, []string{"Globex", "Ops"}},
+		{"review", []string{"Globex"}, []string{"Globex", "Reviews"}},
+		{"review", nil, nil},
+		{"missing", nil, nil},
+		{"", []string{"x"}, []string{"x"}},
+	}
+	for _, tc := range cases {
+		if got := aliasArgs(tc.alias, tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("aliasArgs(%q, %v) = %v, want %v", tc.alias, tc.args, got, tc.want)
+		}
+	}
+
+	shorthand := []struct{ in, want []string }{
+		{[]string{"@acme-standup", "-n", "daily"}, []string{"start", "--alias", "acme-standup", "-n", "daily"}},
+		{[]string{"@"}, []string{"@"}},
+		{[]string{"start", "@x"}, []string{"start", "@x"}},
+		{nil, nil},
+	}
+	for _, tc := range shorthand {
+		if got := expandAliasShorthand(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("expandAliasShorthand(%v) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestAliasExportImportGroups(t *testing.T) {
+	setupTempHome(t)
+	aliasesCache.reset()
+	t.Cleanup(aliasesCache.reset)
+	if err := storeAliases(map[string]Alias{
+		"acme/standup": {Customer: "acme", Project: "internal", Activity: "meeting", Billable: boolptr(false)},
+		"acme/dev":     {Customer: "acme", Project: "portal", Tags: []string{"code", "review"}},
+		"lunch":        {Customer: "me", Note: "break"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := exportAliases(&buf, aliasesInGroup(loadAliases(), "acme")); err != nil {
+		t.Fatal(err)
+	}
+	exported, err := readAliasFile(&buf)
+	if err != nil {
+		t.Fatalf("re-reading export: %v\n%s", err, buf.String())
+	}
+	if len(exported) != 2 || !reflect.DeepEqual(exported["acme/dev"], loadAliases()["acme/dev"]) ||
+		!aliasEqual(exported["acme/standup"], loadAliases()["acme/standup"]) {
+		t.Fatalf("round trip = %+v", exported)
+	}
+
+	file := "standup:\n  customer: globex\nRetro:\n  customer: globex\n  billable: true\n"
+	incoming, err := readAliasFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	incoming, err = prefixAliasGroup(incoming, "acme/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		merge   bool
+		want    []string
+		summary string
+	}{
+		{true, []string{"acme/dev", "acme/retro", "acme/standup", "lunch"}, "1 added, 1 updated, 0 unchanged, 0 removed"},
+		{false, []string{"acme/retro", "acme/standup", "lunch"}, "1 added, 1 updated, 0 unchanged, 1 removed"},
+	}
+	for _, tc := range cases {
+		got, summary := mergeAliases(loadAliases(), incoming, "acme", tc.merge)
+		var names []string
+		for k := range got {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, tc.want) || summary != tc.summary {
+			t.Errorf("merge=%v: %v (%s), want %v (%s)", tc.merge, names, summary, tc.want, tc.summary)
+		}
+		if got["acme/standup"].Customer != "globex" {
+			t.Errorf("merge=%v: file should win, got %+v", tc.merge, got["acme/standup"])
+		}
+	}
+
+	for _, bad := range []string{"", "a.b", "@x", "acme//x", "with space"} {
+		if validateAliasName(bad) == nil {
+			t.Errorf("validateAliasName(%q) should fail", bad)
+		}
+	}
+	if g := aliasGroup("acme/team/retro"); g != "acme/team" {
+		t.Errorf("aliasGroup = %q", g)
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +14,7 @@ var (
 	amendSelect    bool
 	amendStartStr  string
 	amendEndStr    string
+	amendAdjust    amendAdjustments
 	amendNote      string
 	amendCustomer  string
 	amendProject   string
@@ -26,7 +26,10 @@ var (
 // split command flags
 var (
 	splitLast      bool
+	splitSelect    bool
 	splitAtStr     string
+	splitAfter     string // duration from the entry start
+	splitRatio     string // left/right shares
 	splitLeftNote  string
 	splitRightNote string
 	splitCustomer  string
@@ -39,6 +42,7 @@ var (
 // merge command flags
 var (
 	mergeTargets   string // comma-separated ids
+	mergeSelect    bool
 	mergeSince     string // date/time lower bound
 	mergeCustomer  string
 	mergeProject   string
@@ -50,51 +54,46 @@ var (
 var amendCmd = &cobra.Command{
 	Use:   "amend [id]",
 	Short: "Create an amend event that updates an existing entry (append-only)",
-	Args:  cobra.MaximumNArgs(1),
+	Long: "Create an amend event that updates an existing entry (append-only).\n\n" +
+		"id is " + entryRefHelp + ". --extend, --shift and --start-delta adjust the entry's current bounds " +
+		"by a signed duration instead of setting them with --start/--end.",
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		var (
 			targetID string
+			target   *Entry // set when the entry is already loaded
 			err      error
 		)
 		switch {
 		case len(args) == 1:
-			targetID = args[0]
+			targetID, err = resolveEntryRef(args[0])
+			cobra.CheckErr(err)
 		case amendSelect:
-			entries, loadErr := loadRecentEntriesForAmend()
-			if loadErr != nil {
-				cobra.CheckErr(fmt.Errorf("failed to load entries for selection: %w", loadErr))
-			}
-			if len(entries) == 0 {
-				cobra.CheckErr(fmt.Errorf("no entries found to select; add an entry first"))
-			}
-			var entry *Entry
-			entry, err = selectEntryForAmend(entries)
-			if err != nil {
-				if errors.Is(err, errSelectionCancelled) {
-					fmt.Println("Selection cancelled")
-					return
-				}
-				cobra.CheckErr(fmt.Errorf("failed to run selector: %w", err))
-			}
-			if entry == nil {
-				fmt.Println("Selection cancelled")
+			picked, ok := pickEntries("amend", false)
+			if !ok {
 				return
 			}
-			targetID = entry.ID
+			targetID = picked[0].ID
+			target = &picked[0]
 		case amendLast:
-			var entry *Entry
-			entry, err = findMostRecentEntryForAmend()
+			target, err = findMostRecentEntryForAmend()
 			if err != nil {
 				cobra.CheckErr(err)
 			}
-			targetID = entry.ID
+			targetID = target.ID
 		default:
-			var entry *Entry
-			entry, err = findMostRecentEntryForAmend()
+			target, err = findMostRecentEntryForAmend()
 			if err != nil {
 				cobra.CheckErr(err)
 			}
-			targetID = entry.ID
+			targetID = target.ID
+		}
+
+		if amendStartStr != "" && (amendAdjust.Shift != "" || amendAdjust.StartDelta != "") {
+			cobra.CheckErr(fmt.Errorf("--start cannot be combined with --shift or --start-delta"))
+		}
+		if amendEndStr != "" && (amendAdjust.Shift != "" || amendAdjust.Extend != "") {
+			cobra.CheckErr(fmt.Errorf("--end cannot be combined with --shift or --extend"))
 		}
 
 		meta := map[string]string{}
@@ -106,6 +105,22 @@ var amendCmd = &cobra.Command{
 			ts := mustParseTimeLocal(amendEndStr)
 			meta["end"] = ts.Format(time.RFC3339)
 		}
+		if amendAdjust.any() {
+			// Relative adjustments start from the entry's current bounds.
+			if target == nil {
+				e, err := lookupEntry(targetID)
+				cobra.CheckErr(err)
+				target = &e
+			}
+			start, end, err := adjustBounds(*target, amendAdjust)
+			cobra.CheckErr(err)
+			if !start.Equal(target.Start) {
+				meta["start"] = start.Format(time.RFC3339)
+			}
+			if end != nil && !end.Equal(*target.End) {
+				meta["end"] = end.Format(time.RFC3339)
+			}
+		}
 
 		var billable *bool
 		if amendBillableF != "" {
@@ -139,11 +154,26 @@ var amendCmd = &cobra.Command{
 var splitCmd = &cobra.Command{
 	Use:   "split [id]",
 	Short: "Create a split event that splits an existing entry into two (append-only)",
-	Args:  cobra.MaximumNArgs(1),
+	Long: "Create a split event that splits an existing entry into two (append-only).\n\n" +
+		"id is " + entryRefHelp + ". The split point is --at (a time), --after (a duration from the entry start) " +
+		"or --ratio (shares of the entry's duration, e.g. 60/40).",
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		var targetID string
+		var (
+			targetID string
+			target   *Entry // set when the entry is already loaded
+		)
 		if len(args) == 1 {
-			targetID = args[0]
+			id, err := resolveEntryRef(args[0])
+			cobra.CheckErr(err)
+			targetID = id
+		} else if splitSelect {
+			picked, ok := pickEntries("split", false)
+			if !ok {
+				return
+			}
+			targetID = picked[0].ID
+			target = &picked[0]
 		} else if splitLast {
 			now := nowLocal()
 			from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -162,14 +192,28 @@ var splitCmd = &cobra.Command{
 				}
 			}
 			targetID = last.ID
+			target = &last
 		} else {
-			cobra.CheckErr(fmt.Errorf("either provide an id or --last"))
+			cobra.CheckErr(fmt.Errorf("either provide an id, --select or --last"))
 		}
 
-		if splitAtStr == "" {
-			cobra.CheckErr(fmt.Errorf("--at (split time) is required"))
+		var splitAt time.Time
+		switch given := nonEmptyCount(splitAtStr, splitAfter, splitRatio); {
+		case given != 1:
+			cobra.CheckErr(fmt.Errorf("exactly one of --at, --after or --ratio is required"))
+		case splitAtStr != "":
+			splitAt = mustParseTimeLocal(splitAtStr)
+		default:
+			// --after and --ratio are relative to the entry's current bounds.
+			if target == nil {
+				e, err := lookupEntry(targetID)
+				cobra.CheckErr(err)
+				target = &e
+			}
+			var err error
+			splitAt, err = splitPoint(*target, splitAfter, splitRatio)
+			cobra.CheckErr(err)
 		}
-		splitAt := mustParseTimeLocal(splitAtStr)
 
 		meta := map[string]string{
 			"split_at": splitAt.Format(time.RFC3339),
@@ -212,7 +256,12 @@ var splitCmd = &cobra.Command{
 var mergeCmd = &cobra.Command{
 	Use:   "merge",
 	Short: "Create a merge event that consolidates multiple entries into one (append-only)",
-	Args:  cobra.NoArgs,
+	Long: `Create a merge event that consolidates multiple entries into one (append-only).
+
+The targets are reconstructed first and the merged entry is shown before the
+event is written. Targets that cannot be found, or that belong to different
+customers or projects without a --customer/--project override, are refused.`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		meta := map[string]string{}
 
@@ -223,6 +272,20 @@ var mergeCmd = &cobra.Command{
 					targetIDs = append(targetIDs, id)
 				}
 			}
+			var err error
+			targetIDs, err = resolveEntryRefs(targetIDs)
+			cobra.CheckErr(err)
+		} else if mergeSelect {
+			picked, ok := pickEntries("merge", true)
+			if !ok {
+				return
+			}
+			if len(picked) < 2 {
+				cobra.CheckErr(fmt.Errorf("mark at least two entries to merge (space marks an entry)"))
+			}
+			for _, e := range picked {
+				targetIDs = append(targetIDs, e.ID)
+			}
 		} else if mergeSince != "" {
 			// load entries from since..today and filter by optional customer/project
 			from := mustParseTimeLocal(mergeSince)
@@ -241,7 +304,7 @@ var mergeCmd = &cobra.Command{
 				targetIDs = append(targetIDs, e.ID)
 			}
 		} else {
-			cobra.CheckErr(fmt.Errorf("either --targets or --since must be provided"))
+			cobra.CheckErr(fmt.Errorf("either --targets, --select or --since must be provided"))
 		}
 
 		if len(targetIDs) == 0 {
@@ -269,6 +332,9 @@ var mergeCmd = &cobra.Command{
 			Billable: billable,
 			Meta:     meta,
 		}
+		targets, merged, err := mergePreview(ev)
+		cobra.CheckErr(err)
+		renderMergePreview(cmd.OutOrStdout(), targets, merged)
 		if err := writeEvent(ev); err != nil {
 			cobra.CheckErr(fmt.Errorf("failed to write merge event: %w", err))
 		}
@@ -276,6 +342,17 @@ var mergeCmd = &cobra.Command{
 	},
 }
 
+// nonEmptyCount returns how many of vals are non-empty.
+func nonEmptyCount(vals ...string) int {
+	n := 0
+	for _, v := range vals {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
 func parseBoolFlag(s string) (*bool, error) {
 	if s == "" {
 		return nil, nil
@@ -298,16 +375,24 @@ func init() {
 	amendCmd.Flags().BoolVar(&amendSelect, "select", false, "choose an entry interactively when no id is provided")
 	amendCmd.Flags().StringVar(&amendStartStr, "start", "", "new start time (RFC3339 or human-friendly formats)")
 	amendCmd.Flags().StringVar(&amendEndStr, "end", "", "new end time (RFC3339 or human-friendly formats)")
+	amendCmd.Flags().StringVar(&amendAdjust.Extend, "extend", "", "move the end by a duration, e.g. 15m or -10m")
+	amendCmd.Flags().StringVar(&amendAdjust.Shift, "shift", "", "move start and end by a duration, e.g. 10m or -1h")
+	amendCmd.Flags().StringVar(&amendAdjust.StartDelta, "start-delta", "", "move the start by a duration, e.g. -5m")
 	amendCmd.Flags().StringVar(&amendNote, "note", "", "note to append to the entry")
 	amendCmd.Flags().StringVar(&amendCustomer, "customer", "", "customer override")
 	amendCmd.Flags().StringVar(&amendProject, "project", "", "project override")
 	amendCmd.Flags().StringVar(&amendActivity, "activity", "", "activity override")
 	amendCmd.Flags().StringVar(&amendBillableF, "billable", "", "set billable: true|false (empty leaves unchanged)")
 	amendCmd.Flags().StringSliceVar(&amendTags, "tag", []string{}, "replace tags (comma-separated)")
+	_ = amendCmd.RegisterFlagCompletionFunc("activity", activityFlagCompletion)
+	_ = amendCmd.RegisterFlagCompletionFunc("tag", tagFlagCompletion)
 
 	// split flags
 	splitCmd.Flags().BoolVar(&splitLast, "last", false, "split the last entry (instead of specifying an id)")
-	splitCmd.Flags().StringVar(&splitAtStr, "at", "", "split at time (RFC3339 or human-friendly formats) (required)")
+	splitCmd.Flags().BoolVar(&splitSelect, "select", false, "choose the entry interactively when no id is provided")
+	splitCmd.Flags().StringVar(&splitAtStr, "at", "", "split at time (RFC3339 or human-friendly formats)")
+	splitCmd.Flags().StringVar(&splitAfter, "after", "", "split this long after the entry start, e.g. 1h30m")
+	splitCmd.Flags().StringVar(&splitRatio, "ratio", "", "split the entry's duration by left/right shares, e.g. 60/40")
 	splitCmd.Flags().StringVar(&splitLeftNote, "left-note", "", "note for the left split")
 	splitCmd.Flags().StringVar(&splitRightNote, "right-note", "", "note for the right split")
 	splitCmd.Flags().StringVar(&splitCustomer, "customer", "", "customer override for split parts")
@@ -317,7 +402,8 @@ func init() {
 	splitCmd.Flags().StringSliceVar(&splitTags, "tag", []string{}, "replace tags for split parts")
 
 	// merge flags
-	mergeCmd.Flags().StringVar(&mergeTargets, "targets", "", "comma-separated target entry ids to merge")
+	mergeCmd.Flags().StringVar(&mergeTargets, "targets", "", "comma-separated target entries to merge: "+entryRefHelp)
+	mergeCmd.Flags().BoolVar(&mergeSelect, "select", false, "mark the entries to merge interactively")
 	mergeCmd.Flags().StringVar(&mergeSince, "since", "", "include entries since this time (RFC3339 or human-friendly)")
 	mergeCmd.Flags().StringVar(&mergeCustomer, "customer", "", "filter by customer when using --since or override customer for merged entry")
 	mergeCmd.Flags().StringVar(&mergeProject, "project", "", "filter by project when using --since or override project for merged entry")
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// amendAdjustments are the relative bound changes of tt amend; each is a
+// signed duration string and empty when not given.
+type amendAdjustments struct {
+	Extend     string // moves the end
+	Shift      string // moves start and end
+	StartDelta string // moves the start
+}
+
+func (a amendAdjustments) any() bool {
+	return a.Extend != "" || a.Shift != "" || a.StartDelta != ""
+}
+
+// adjustBounds applies a to the current bounds of e and returns the new
+// start and end. The end is nil for a running entry, which cannot be
+// extended; a shift then moves only its start.
+func adjustBounds(e Entry, a amendAdjustments) (time.Time, *time.Time, error) {
+	parse := func(flag, s string) (time.Duration, error) {
+		if s == "" {
+			return 0, nil
+		}
+		d, err := parseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		return d, nil
+	}
+	extend, err := parse("extend", a.Extend)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	shift, err := parse("shift", a.Shift)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	startDelta, err := parse("start-delta", a.StartDelta)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	start := e.Start.Add(shift + startDelta)
+	if e.End == nil {
+		if a.Extend != "" {
+			return time.Time{}, nil, fmt.Errorf("entry %s is still running; --extend needs an end", e.ID)
+		}
+		return start, nil, nil
+	}
+	end := e.End.Add(shift + extend)
+	if !start.Before(end) {
+		return time.Time{}, nil, fmt.Errorf("adjusted start %s is not before end %s",
+			start.In(parserLocation()).Format("15:04"), end.In(parserLocation()).Format("15:04"))
+	}
+	return start, &end, nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestAdjustBounds(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	start := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	e := Entry{ID: "e1", Start: start, End: &end}
+
+	for _, c := range []struct {
+		a                  amendAdjustments
+		wantStart, wantEnd string
+	}{
+		{amendAdjustments{Extend: "15m"}, "09:00", "10:15"},
+		{amendAdjustments{Extend: "-10m"}, "09:00", "09:50"},
+		{amendAdjustments{Shift: "10m"}, "09:10", "10:10"},
+		{amendAdjustments{StartDelta: "-5m"}, "08:55", "10:00"},
+		{amendAdjustments{Shift: "+1h", Extend: "30m"}, "10:00", "11:30"},
+	} {
+		s, en, err := adjustBounds(e, c.a)
+		if err != nil || s.Format("15:04") != c.wantStart || en.Format("15:04") != c.wantEnd {
+			t.Errorf("%+v: %v–%v, %v; want %s–%s", c.a, s, en, err, c.wantStart, c.wantEnd)
+		}
+	}
+
+	if _, _, err := adjustBounds(e, amendAdjustments{Extend: "-1h"}); err == nil || !strings.Contains(err.Error(), "not before end") {
+		t.Errorf("empty entry: err = %v", err)
+	}
+	if _, _, err := adjustBounds(e, amendAdjustments{Shift: "later"}); err == nil || !strings.Contains(err.Error(), "--shift") {
+		t.Errorf("bad duration: err = %v", err)
+	}
+
+	running := Entry{ID: "e2", Start: start}
+	if _, _, err := adjustBounds(running, amendAdjustments{Extend: "15m"}); err == nil {
+		t.Error("extending a running entry should fail")
+	}
+	if s, en, err := adjustBounds(running, amendAdjustments{Shift: "-15m"}); err != nil || en != nil || s.Format("15:04") != "08:45" {
+		t.Errorf("shift running: %v, %v, %v", s, en, err)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/list"
-	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"tt/internal/picker"
 )
 
-var errSelectionCancelled = errors.New("selection cancelled")
+// errSelectionCancelled is returned when the picker is left without a choice.
+var errSelectionCancelled = picker.ErrCancelled
 
 var (
 	nowLocalForAmend      = nowLocal
@@ -52,41 +54,51 @@ func findMostRecentEntryForAmend() (*Entry, error) {
 	return &entries[len(entries)-1], nil
 }
 
-func selectEntryForAmend(entries []Entry) (*Entry, error) {
+// pickEntries loads the recent entries and lets the user pick from them; ok
+// is false when the picker was cancelled, which has been reported already.
+func pickEntries(action string, multi bool) (picked []Entry, ok bool) {
+	entries, err := loadRecentEntriesForAmend()
+	if err != nil {
+		cobra.CheckErr(fmt.Errorf("failed to load entries for selection: %w", err))
+	}
 	if len(entries) == 0 {
-		return nil, fmt.Errorf("no entries available for selection")
+		cobra.CheckErr(fmt.Errorf("no entries found to select; add an entry first"))
+	}
+	picked, err = selectEntries(entries, action, multi)
+	if errors.Is(err, errSelectionCancelled) || (err == nil && len(picked) == 0) {
+		fmt.Println("Selection cancelled")
+		return nil, false
+	}
+	if err != nil {
+		cobra.CheckErr(fmt.Errorf("failed to run selector: %w", err))
 	}
+	return picked, true
+}
 
-	items := make([]list.Item, 0, len(entries))
+// selectEntries lets the user pick entries of the list with the shared
+// picker (internal/picker), latest first: one, or several with multi. action
+// names the command in the picker's title and help.
+func selectEntries(entries []Entry, action string, multi bool) ([]Entry, error) {
+	items := make([]picker.Item, 0, len(entries))
+	byID := make(map[string]*Entry, len(entries))
 	for i := len(entries) - 1; i >= 0; i-- {
-		// reverse chronological order (latest first)
-		items = append(items, entryItem{entry: &entries[i]})
+		it := entryItem{entry: &entries[i]}
+		items = append(items, picker.Item{ID: entries[i].ID, Title: it.Title(), Description: it.Description(), Filter: it.FilterValue()})
+		byID[entries[i].ID] = &entries[i]
 	}
-
-	delegate := list.NewDefaultDelegate()
-	delegate.SetSpacing(0)
-
-	l := list.New(items, delegate, 0, 0)
-	l.Title = "Select entry to amend"
-	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(true)
-	l.Styles.Title = l.Styles.Title.Padding(0, 1)
-
-	model := amendSelectModel{list: l}
-	program := tea.NewProgram(model, tea.WithAltScreen())
-	res, err := program.Run()
+	title := "Select entry to " + action
+	if multi {
+		title = "Select entries to " + action
+	}
+	ids, err := picker.Run(items, picker.Options{Title: title, Action: action, Multi: multi})
 	if err != nil {
 		return nil, err
 	}
-
-	final, ok := res.(amendSelectModel)
-	if !ok {
-		return nil, fmt.Errorf("unexpected model type from selector")
-	}
-	if final.cancelled || final.choice == nil {
-		return nil, errSelectionCancelled
+	out := make([]Entry, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, *byID[id])
 	}
-	return final.choice, nil
+	return out, nil
 }
 
 type entryItem struct {
@@ -135,42 +147,3 @@ func (e entryItem) FilterValue() string {
 	fields = append(fields, strings.Join(e.entry.Notes, " "))
 	return strings.ToLower(strings.Join(fields, " "))
 }
-
-type amendSelectModel struct {
-	list      list.Model
-	choice    *Entry
-	cancelled bool
-}
-
-func (m amendSelectModel) Init() tea.Cmd {
-	return nil
-}
-
-func (m amendSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		if msg.Width > 0 && msg.Height > 2 {
-			m.list.SetSize(msg.Width, msg.Height-2)
-		}
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
-			if item, ok := m.list.SelectedItem().(entryItem); ok {
-				m.choice = item.entry
-			}
-			return m, tea.Quit
-		case "q", "esc", "ctrl+c":
-			m.cancelled = true
-			return m, tea.Quit
-		}
-	}
-
-	var cmd tea.Cmd
-	m.list, cmd = m.list.Update(msg)
-	return m, cmd
-}
-
-func (m amendSelectModel) View() string {
-	instructions := "\nUse up/down to navigate, enter to amend, esc to cancel"
-	return m.list.View() + instructions
-}
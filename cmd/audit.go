@@ -31,7 +31,7 @@ var auditVerifyCmd = &cobra.Command{
 		_ = filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				// Walk-level error: print a helpful message and continue walking
-				fmt.Printf("WARN Walk error for %s: %v\n", path, err)
+				logger.Warn("walk error", "err", err, "path", path)
 				return nil
 			}
 			if info.IsDir() || !strings.HasSuffix(path, ".jsonl") {
@@ -72,7 +72,7 @@ changes so you can inspect before applying. To actually apply changes use --appl
 
 		_ = filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
-				fmt.Printf("WARN Walk error for %s: %v\n", path, err)
+				logger.Warn("walk error", "err", err, "path", path)
 				return nil
 			}
 			if info.IsDir() || !strings.HasSuffix(path, ".jsonl") {
@@ -138,14 +138,14 @@ func repairDay(path string, dryRun bool, apply bool, w io.Writer) (bool, bool, e
 	anchor := strings.TrimSpace(string(anchorBytes))
 	prev := ""
 	if anchor != "" {
-		fmt.Fprintf(w, "INFO: %s has anchor %s — will compare against end-of-chain\n", path, anchor)
+		logger.Info("anchor present, comparing against end-of-chain", "path", path, "anchor", anchor)
 	} else {
-		fmt.Fprintf(w, "INFO: %s has no anchor — starting prev empty\n", path)
+		logger.Info("no anchor, starting with an empty prev hash", "path", path)
 	}
 
 	// If file is empty, nothing to do
 	if len(origLines) == 0 {
-		fmt.Fprintf(w, "INFO: %s is empty, skipping\n", path)
+		logger.Info("empty journal file, skipping", "path", path)
 		return false, false, nil
 	}
 
@@ -224,7 +224,7 @@ func repairDay(path string, dryRun bool, apply bool, w io.Writer) (bool, bool, e
 				changed = true
 			} else {
 				// neither matched: the row is inconsistent (possibly edited). We'll still propose canonical rewrite.
-				fmt.Fprintf(w, "WARN: line %d in %s had stored hash %q that matches neither legacy nor canonical; proposing canonical rewrite\n", lineNum, path, e.Hash)
+				logger.Warn("stored hash matches neither legacy nor canonical; proposing canonical rewrite", "path", path, "line", lineNum, "hash", e.Hash)
 				e.PrevHash = prev
 				e.Hash = calcStruct
 				changed = true
@@ -391,7 +391,7 @@ func verifyDay(path string, w io.Writer) bool {
 	// Starting prev is empty; if anchor exists we will compare it against the end-of-chain
 	prev := ""
 	if anchor != "" {
-		fmt.Fprintf(w, "INFO: anchor present for %s: %s (will compare at end)\n", path, anchor)
+		logger.Info("anchor present, comparing at the end", "path", path, "anchor", anchor)
 	}
 
 	for i, raw := range lines {
@@ -454,7 +454,7 @@ func verifyDay(path string, w io.Writer) bool {
 			// OK
 		} else if e.Hash == calcLegacy {
 			// OK (legacy)
-			fmt.Fprintf(w, "INFO: legacy hash matched at %s:%d (id=%s)\n", path, lineNo, e.ID)
+			logger.Info("legacy hash matched", "path", path, "line", lineNo, "id", e.ID)
 		} else {
 			// Not matching either
 			fmt.Fprintf(w, "ERROR: hash mismatch at %s:%d (id=%s)\n", path, lineNo, e.ID)
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// billableRule looks up the billable default for a new entry of
+// customer/project in billable_defaults. A project rule ("customer/project")
+// wins over a customer rule; names are compared after customers.map and
+// projects.map, ignoring case. ok is false when no rule matches.
+//
+//	billable_defaults:
+//	  customer:
+//	    internal: false
+//	  project:
+//	    acme/presales: false
+func billableRule(customer, project string) (billable, ok bool) {
+	c := CanonicalCustomer(strings.TrimSpace(customer))
+	if c == "" {
+		return false, false
+	}
+	if p := CanonicalProject(c, strings.TrimSpace(project)); p != "" {
+		if b, ok := billableRuleIn("billable_defaults.project", c+"/"+p); ok {
+			return b, true
+		}
+	}
+	return billableRuleIn("billable_defaults.customer", c)
+}
+
+func billableRuleIn(key, name string) (bool, bool) {
+	for k, v := range viper.GetStringMap(key) {
+		if !strings.EqualFold(k, name) {
+			continue
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(fmt.Sprint(v)))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// billableFor is the billable flag of a new entry when -b was not given:
+// the matching billable_defaults rule, else billable.
+func billableFor(customer, project string) bool {
+	if b, ok := billableRule(customer, project); ok {
+		return b
+	}
+	return true
+}
+
+// billableDefaults serves billable_defaults to the TUI form.
+type billableDefaults struct{}
+
+func (billableDefaults) DefaultBillable(ctx context.Context, customer, project string) (bool, bool) {
+	return billableRule(customer, project)
+}
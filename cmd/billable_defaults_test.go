@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestBillableFor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("billable_defaults", map[string]interface{}{
+		"customer": map[string]interface{}{"internal": false, "acme": true},
+		"project":  map[string]interface{}{"acme/presales": false, "internal/hiring": "true"},
+	})
+	t.Cleanup(func() { viper.Set("billable_defaults", nil) })
+
+	cases := []struct {
+		customer, project string
+		want              bool
+	}{
+		{"Internal", "", false},
+		{"internal", "tooling", false},
+		{"internal", "hiring", true},
+		{"ACME", "Presales", false},
+		{"acme", "web", true},
+		{"other", "", true},
+		{"", "", true},
+	}
+	for _, c := range cases {
+		if got := billableFor(c.customer, c.project); got != c.want {
+			t.Errorf("billableFor(%q, %q) = %v; want %v", c.customer, c.project, got, c.want)
+		}
+	}
+	if _, ok := billableRule("other", "web"); ok {
+		t.Errorf("billableRule(other, web) matched; want no rule")
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+var closeoutYes bool
+
+var closeoutCmd = &cobra.Command{
+	Use:   "closeout",
+	Short: "Walk through today's entries at the end of the day",
+	Long: `Closeout is the evening check as a short dialog:
+
+  1. stop the running entry (asks first),
+  2. list untracked gaps (notify.gap_min, default 15 minutes) and overlaps,
+  3. ask for a note on each billable entry without one (empty skips),
+  4. print the daily summary.
+
+With --yes the running entry is stopped without asking and missing notes are
+only listed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := Now().In(parserLocation())
+		dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		// Load from yesterday so an entry running across midnight is included.
+		ents, err := loadEntries(dayStart.AddDate(0, 0, -1), now)
+		if err != nil {
+			return err
+		}
+		return runCloseout(cmd.InOrStdin(), cmd.OutOrStdout(), todayEntries(ents, dayStart, now), dayStart, now, closeoutYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(closeoutCmd)
+	closeoutCmd.Flags().BoolVarP(&closeoutYes, "yes", "y", false, "stop the running entry without asking and do not prompt for notes")
+}
+
+// runCloseout runs the close-out steps on the day's entries, reading answers
+// from in. Stops and notes are written as they are answered.
+func runCloseout(in io.Reader, w io.Writer, ents []todayEntry, dayStart, now time.Time, yes bool) error {
+	r := bufio.NewReader(in)
+	ask := func(prompt string) string {
+		fmt.Fprint(w, prompt)
+		s, _ := r.ReadString('\n')
+		return strings.TrimSpace(s)
+	}
+	loc := now.Location()
+
+	fmt.Fprintln(w, "1/4 Running entry")
+	running := -1
+	for i, e := range ents {
+		if e.End == nil {
+			running = i
+		}
+	}
+	if running < 0 {
+		fmt.Fprintln(w, "  Nothing running.")
+	} else {
+		e := &ents[running]
+		answer := "y"
+		if !yes {
+			answer = strings.ToLower(ask(fmt.Sprintf("  Stop %s (running since %s)? [Y/n] ",
+				entryLabel(e.Entry), e.Start.In(loc).Format("15:04"))))
+		}
+		if answer == "n" || answer == "no" {
+			fmt.Fprintln(w, "  Left running.")
+		} else {
+			if err := writeEvent(NewStopEvent(IDGen(), now)); err != nil {
+				return fmt.Errorf("failed to write stop event: %w", err)
+			}
+			end := now
+			e.End = &end
+			fmt.Fprintf(w, "  Stopped %s at %s.\n", entryLabel(e.Entry), now.Format("15:04"))
+		}
+	}
+
+	fmt.Fprintln(w, "2/4 Gaps and overlaps")
+	gapMin := viper.GetInt("notify.gap_min")
+	if gapMin <= 0 {
+		gapMin = 15
+	}
+	gaps := closeoutGaps(ents, dayStart, now, time.Duration(gapMin)*time.Minute)
+	overlaps := closeoutOverlaps(ents, dayStart, now)
+	for _, g := range gaps {
+		fmt.Fprintf(w, "  gap     %s–%s  %s untracked\n", g.From.In(loc).Format("15:04"), g.To.In(loc).Format("15:04"), fmtDuration(g.To.Sub(g.From)))
+	}
+	for _, o := range overlaps {
+		fmt.Fprintln(w, "  overlap "+o)
+	}
+	switch {
+	case len(gaps) == 0 && len(overlaps) == 0:
+		fmt.Fprintln(w, "  None.")
+	default:
+		fmt.Fprintln(w, "  Fill gaps with tt add, fix overlaps with tt amend.")
+	}
+
+	fmt.Fprintln(w, "3/4 Notes on billable entries")
+	missing := 0
+	for i := range ents {
+		e := &ents[i]
+		if !e.Billable || len(reporting.DedupeStrings(e.Notes)) > 0 {
+			continue
+		}
+		missing++
+		line := fmt.Sprintf("%s  %s", closeoutWindow(e.Entry, loc), entryLabel(e.Entry))
+		if yes {
+			fmt.Fprintf(w, "  missing note: %s (%s)\n", line, e.ID)
+			continue
+		}
+		note := ask(fmt.Sprintf("  Note for %s (empty skips): ", line))
+		if note == "" {
+			continue
+		}
+		ev := Event{ID: IDGen(), Type: "amend", TS: Now(), Ref: e.ID, Note: note}
+		if err := writeEvent(ev); err != nil {
+			return fmt.Errorf("failed to write amend event: %w", err)
+		}
+		e.Notes = append(e.Notes, note)
+	}
+	if missing == 0 {
+		fmt.Fprintln(w, "  All billable entries have notes.")
+	}
+
+	fmt.Fprintln(w, "4/4 Summary")
+	renderToday(w, ents, dayStart, now)
+	return nil
+}
+
+// closeoutWindow formats an entry's start and end (or "now") as 15:04–15:04.
+func closeoutWindow(e Entry, loc *time.Location) string {
+	end := "now"
+	if e.End != nil {
+		end = e.End.In(loc).Format("15:04")
+	}
+	return e.Start.In(loc).Format("15:04") + "–" + end
+}
+
+// closeoutSpan clips an entry to the day up to now.
+func closeoutSpan(e Entry, dayStart, now time.Time) (time.Time, time.Time) {
+	st, end := e.Start, now
+	if st.Before(dayStart) {
+		st = dayStart
+	}
+	if e.End != nil && e.End.Before(now) {
+		end = *e.End
+	}
+	return st, end
+}
+
+// closeoutGaps returns the untracked stretches of at least minGap between the
+// day's first start and last end. ents must be in start order.
+func closeoutGaps(ents []todayEntry, dayStart, now time.Time, minGap time.Duration) []timeGap {
+	var gaps []timeGap
+	var covered time.Time
+	for i, e := range ents {
+		st, end := closeoutSpan(e.Entry, dayStart, now)
+		if i > 0 && st.Sub(covered) >= minGap {
+			gaps = append(gaps, timeGap{covered, st})
+		}
+		if end.After(covered) {
+			covered = end
+		}
+	}
+	return gaps
+}
+
+// closeoutOverlaps describes each entry starting before an earlier one ends.
+// ents must be in start order.
+func closeoutOverlaps(ents []todayEntry, dayStart, now time.Time) []string {
+	var out []string
+	loc := now.Location()
+	last := -1 // the entry ending last so far
+	var covered time.Time
+	for i, e := range ents {
+		st, end := closeoutSpan(e.Entry, dayStart, now)
+		if last >= 0 && st.Before(covered) {
+			to := end
+			if covered.Before(to) {
+				to = covered
+			}
+			out = append(out, fmt.Sprintf("%s–%s  %s and %s", st.In(loc).Format("15:04"), to.In(loc).Format("15:04"),
+				entryLabel(ents[last].Entry), entryLabel(e.Entry)))
+		}
+		if end.After(covered) {
+			covered, last = end, i
+		}
+	}
+	return out
+}
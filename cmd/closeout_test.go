@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+func TestCloseout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("targets.daily_hours", 8)
+	oldNow, oldID := Now, IDGen
+	oldHeading, oldHours, oldWarn, oldReset := ansiHeading, ansiHours, ansiWarn, ansiReset
+	ansiHeading, ansiHours, ansiWarn, ansiReset = "", "", "", ""
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now, IDGen = oldNow, oldID
+		ansiHeading, ansiHours, ansiWarn, ansiReset = oldHeading, oldHours, oldWarn, oldReset
+	})
+	day := time.Date(2025, 10, 14, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	Now = func() time.Time { return at(17, 0) }
+	n := 0
+	IDGen = func() string { n++; return fmt.Sprintf("c%d", n) }
+	evs := []Event{
+		NewAddEvent("a1", "Acme", "Ops", "", boolPtr(true), "", nil, at(8, 0), at(9, 0)),
+		NewAddEvent("a2", "Acme", "Web", "", boolPtr(true), "reviewed PR", nil, at(8, 45), at(10, 0)),
+		NewAddEvent("a3", "Internal", "", "", boolPtr(false), "", nil, at(11, 0), at(12, 0)),
+		NewStartEvent("a4", "Acme", "Web", "", boolPtr(true), "", nil, at(13, 0)),
+	}
+	for i := range evs[:3] {
+		evs[i].TS = at(7, i) // added this morning
+	}
+	if err := writeEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := loadEntries(day, Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	in := strings.NewReader("\nstandup\n\n")
+	if err := runCloseout(in, &out, todayEntries(ents, day, Now()), day, Now(), false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Stop Acme/Web (running since 13:00)? [Y/n]",
+		"Stopped Acme/Web at 17:00.",
+		"gap     10:00–11:00  1h00m untracked",
+		"gap     12:00–13:00  1h00m untracked",
+		"overlap 08:45–09:00  Acme/Ops and Acme/Web",
+		"Note for 08:00–09:00  Acme/Ops (empty skips):",
+		"Note for 13:00–17:00  Acme/Web (empty skips):",
+		"08:00–09:00  1h00m  Acme/Ops  · standup",
+		"13:00–17:00  4h00m  Acme/Web\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("closeout output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	ents, err = loadEntries(day, Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range ents {
+		switch e.ID {
+		case "a1":
+			if notes := reporting.DedupeStrings(e.Notes); len(notes) != 1 || notes[0] != "standup" {
+				t.Errorf("a1 notes = %q; want the answered note", e.Notes)
+			}
+		case "a4":
+			if e.End == nil || !e.End.Equal(at(17, 0)) {
+				t.Errorf("a4 end = %v; want stopped at 17:00", e.End)
+			}
+		}
+	}
+}
+
+func TestCloseoutYesListsMissingNotes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	oldNow := Now
+	t.Cleanup(func() { viper.Set("timezone", ""); Now = oldNow })
+	day := time.Date(2025, 10, 14, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return day.Add(10 * time.Hour) }
+	ents := []todayEntry{{Entry: Entry{ID: "a1", Customer: "Acme", Start: day.Add(8 * time.Hour), Billable: true}, Min: 120}}
+
+	var out bytes.Buffer
+	if err := runCloseout(strings.NewReader(""), &out, ents, day, Now(), true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "?") {
+		t.Errorf("--yes prompted:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "missing note: 08:00–10:00  Acme (a1)") {
+		t.Errorf("missing note not listed:\n%s", out.String())
+	}
+}
@@ -1,5 +1,7 @@
 package cmd
 
+import "tt/internal/reporting"
+
 // Subtle ANSI color variables for consistent, shared styling across commands.
 // These are intentionally variables (not constants) so callers can disable or
 // re-enable coloring at runtime (e.g. when output is redirected or for tests).
@@ -52,6 +54,14 @@ func DisableColors() {
 	ansiOverlap = ""
 }
 
+// ansiPalette is the current palette for the report renderers.
+func ansiPalette() reporting.Palette {
+	return reporting.Palette{
+		Reset: ansiReset, Dim: ansiDim, Heading: ansiHeading, Label: ansiLabel,
+		Hours: ansiHours, Notes: ansiNotes, Warn: ansiWarn, Overlap: ansiOverlap,
+	}
+}
+
 // EnableColors restores the palette to the package defaults.
 func EnableColors() {
 	ansiReset = defaultAnsiReset
@@ -189,6 +189,18 @@ func TestMergeCommand_WritesMergeEventWithTargetsAndSince(t *testing.T) {
 	Writer = fw
 	defer func() { Writer = oldWriter }()
 
+	oldLoad := loadEntriesForRefFn
+	defer func() { loadEntriesForRefFn = oldLoad }()
+	loadEntriesForRefFn = func(from, to time.Time) ([]Entry, error) {
+		var ents []Entry
+		for i, id := range []string{"a", "b", "c"} {
+			start := time.Date(2025, 10, 12, 9+i, 0, 0, 0, time.UTC)
+			end := start.Add(45 * time.Minute)
+			ents = append(ents, Entry{ID: id, Start: start, End: &end, Customer: "acme", Project: "portal"})
+		}
+		return ents, nil
+	}
+
 	// Test explicit --targets
 	mergeTargets = "a,b,c"
 	mergeSince = ""
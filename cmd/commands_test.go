@@ -152,7 +152,7 @@ func TestFilterPrefixAndSort(t *testing.T) {
 func TestCompletionListsHonorDecisions(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 	viper.Reset()
-	mergedCustomerSet = nil
+	mergedCustomerSet.reset()
 
 	viper.Set("completion.allow.customers", []string{"Internal", "Acme"})
 	viper.Set("completion.ignore.customers", []string{"Interal"})
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -15,13 +19,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"tt/internal/index"
 	"tt/internal/journal"
+	"tt/internal/rounding"
 )
 
 // Event represents a single immutable journal event.
 type Event struct {
 	ID       string            `json:"id"`
-	Type     string            `json:"type"` // start|stop|add|amend|pause|resume|note
+	Type     string            `json:"type"` // start|stop|add|amend|pause|resume|note|expense|pin|follow-up|unpin|undo
 	TS       time.Time         `json:"ts"`
 	User     string            `json:"user,omitempty"`
 	Customer string            `json:"customer,omitempty"`
@@ -63,6 +69,44 @@ type Entry struct {
 	Billable bool
 	Notes    []string
 	Tags     []string
+	User     string             // recorded on shared journals; empty for legacy entries
+	Fields   map[string]string  // key=value fields from notes (see fields.go)
+	Pauses   []journal.Interval // paused spans (pause/resume events), excluded from the duration
+}
+
+// journalEntry converts e to the entry type of the internal packages.
+func (e Entry) journalEntry() journal.Entry {
+	return journal.Entry{
+		ID:       e.ID,
+		Start:    e.Start,
+		End:      e.End,
+		Customer: e.Customer,
+		Project:  e.Project,
+		Activity: e.Activity,
+		Billable: e.Billable,
+		Notes:    e.Notes,
+		Tags:     e.Tags,
+		User:     e.User,
+		Pauses:   e.Pauses,
+	}
+}
+
+// entryFromJournal converts a parsed journal entry, extracting note fields.
+func entryFromJournal(je journal.Entry) Entry {
+	return Entry{
+		ID:       je.ID,
+		Start:    je.Start,
+		End:      je.End,
+		Customer: je.Customer,
+		Project:  je.Project,
+		Activity: je.Activity,
+		Billable: je.Billable,
+		Notes:    je.Notes,
+		Tags:     je.Tags,
+		User:     je.User,
+		Fields:   parseNoteFields(je.Notes),
+		Pauses:   je.Pauses,
+	}
 }
 
 // journal path helpers -------------------------------------------------------
@@ -92,16 +136,91 @@ type EventWriter interface {
 	WriteEvent(e Event) error
 }
 
+// BatchEventWriter is implemented by writers that can persist many events in
+// one pass. Bulk commands write through writeEvents, which prefers it.
+type BatchEventWriter interface {
+	WriteEvents(evs []Event) error
+}
+
 // fileEventWriter is the default file-based EventWriter used by the CLI.
 type fileEventWriter struct{}
 
 // WriteEvent implements EventWriter by appending canonical JSON lines to the per-day journal file.
 func (fw *fileEventWriter) WriteEvent(e Event) error {
-	p := journalPathFor(e.TS)
-	prev := readLastHash(p)
-	e.PrevHash = prev
+	return fw.WriteEvents([]Event{e})
+}
 
-	cp := canonicalPayload{
+// WriteEvents appends events to their per-day journal files, chaining hashes
+// exactly as repeated WriteEvent calls would. Each day file is opened once,
+// its anchor read and written once, and encode buffers are reused across
+// events. Events for the same day keep their relative order. Events without a
+// user are stamped with currentUser() before hashing. The local pre-event and
+// post-event hooks run around the write (see event_hooks.go); a running TUI is
+// notified after it (see event_notice.go).
+func (fw *fileEventWriter) WriteEvents(evs []Event) error {
+	var order []string
+	byPath := map[string][]Event{}
+	user := currentUser()
+	stamped := make([]Event, 0, len(evs))
+	for _, e := range evs {
+		if e.User == "" {
+			e.User = user
+		}
+		stamped = append(stamped, e)
+	}
+	if err := runPreEventHooks(stamped); err != nil {
+		return err
+	}
+	for _, e := range stamped {
+		p := journalPathFor(e.TS)
+		if _, ok := byPath[p]; !ok {
+			order = append(order, p)
+		}
+		byPath[p] = append(byPath[p], e)
+	}
+
+	var payload, lines bytes.Buffer
+	canonEnc := json.NewEncoder(&payload)
+	lineEnc := json.NewEncoder(&lines)
+	for _, p := range order {
+		lines.Reset()
+		prev := readLastHash(p)
+		for _, e := range byPath[p] {
+			e.PrevHash = prev
+			payload.Reset()
+			if err := canonEnc.Encode(canonicalPayloadFor(e)); err != nil {
+				return err
+			}
+			// Encode appends a newline that json.Marshal (the hash basis) does not.
+			h := sha256.Sum256(bytes.TrimSuffix(payload.Bytes(), []byte("\n")))
+			e.Hash = hex.EncodeToString(h[:])
+			if err := lineEnc.Encode(e); err != nil {
+				return err
+			}
+			prev = e.Hash
+		}
+
+		f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(lines.Bytes()); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		writeLastHash(p, prev)
+	}
+	runPostEventHooks(stamped)
+	noticeEventWrite(stamped)
+	return nil
+}
+
+// canonicalPayloadFor returns the deterministic hash payload of an event.
+func canonicalPayloadFor(e Event) canonicalPayload {
+	return canonicalPayload{
 		ID:       e.ID,
 		Type:     e.Type,
 		TS:       e.TS.Format(time.RFC3339Nano),
@@ -115,21 +234,6 @@ func (fw *fileEventWriter) WriteEvent(e Event) error {
 		Ref:      e.Ref,
 		PrevHash: e.PrevHash,
 	}
-	j, _ := json.Marshal(cp)
-	h := sha256.Sum256(j)
-	e.Hash = hex.EncodeToString(h[:])
-
-	line, _ := json.Marshal(e)
-	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	if _, err := f.Write(append(line, '\n')); err != nil {
-		return err
-	}
-	writeLastHash(p, e.Hash)
-	return nil
 }
 
 // Writer is the package-level EventWriter in use. Tests may replace this with a fake.
@@ -157,7 +261,7 @@ func init() {
 			prev(cmd, args)
 		}
 		if err := sweepAutoStops(); err != nil {
-			fmt.Printf("WARN: sweep auto-stops failed: %v\n", err)
+			logger.Warn("sweep auto-stops failed", "err", err)
 		}
 	}
 }
@@ -187,7 +291,6 @@ func sweepAutoStops() error {
 		Path     string // source journal path
 	}
 	candidates := map[string]cand{} // keyed by start event ID
-	paths := map[string]struct{}{}  // set of journal paths to parse
 
 	// Scan the last N days for start events with auto_stop metadata.
 	const scanDays = 3
@@ -226,14 +329,13 @@ func sweepAutoStops() error {
 			if asTime.After(now) {
 				continue
 			}
-			// register candidate and remember path for parsing
+			// register candidate
 			candidates[ev.ID] = cand{
 				EventID:  ev.ID,
 				StartTS:  ev.TS,
 				AutoStop: asTime,
 				Path:     pth,
 			}
-			paths[pth] = struct{}{}
 		}
 		f.Close()
 	}
@@ -242,19 +344,22 @@ func sweepAutoStops() error {
 		return nil
 	}
 
-	// Parse each relevant journal file with the parser to reconstruct entries.
+	// Parse the scanned days oldest first in one stream, so a stop written on
+	// a later day still closes an entry started the day before.
 	// Build a map of entry id -> journal.Entry for quick lookup.
+	var days []string
+	for i := scanDays - 1; i >= 0; i-- {
+		days = append(days, journalPathFor(now.AddDate(0, 0, -i)))
+	}
 	entryMap := make(map[string]journal.Entry)
-	for pth := range paths {
-		ents, err := p.ParseFile(pth)
-		if err != nil {
-			// If parser fails for a path, skip it (we don't want sweep to be brittle).
-			continue
-		}
-		for _, e := range ents {
-			// store by ID; parser reconstructs entries with IDs derived from start events.
-			entryMap[e.ID] = e
-		}
+	ents, errc := p.ParseFilesStream(context.Background(), days)
+	for e := range ents {
+		// store by ID; parser reconstructs entries with IDs derived from start events.
+		entryMap[e.ID] = e
+	}
+	if err := <-errc; err != nil {
+		// we don't want sweep to be brittle: judge by what was read
+		logger.Debug("auto-stop sweep", "err", err)
 	}
 
 	// For each candidate, determine whether the reconstructed entry is still open.
@@ -268,8 +373,9 @@ func sweepAutoStops() error {
 		// If End is nil -> entry still open: write auto-stop
 		if ent.End == nil {
 			stopEv := NewStopEvent(IDGen(), c.AutoStop)
+			stopEv.User = ent.User // the entry may belong to another user of a shared journal
 			if err := Writer.WriteEvent(stopEv); err != nil {
-				fmt.Printf("WARN: failed to write auto-stop for start %s: %v\n", id, err)
+				logger.Warn("failed to write auto-stop", "err", err, "start", id)
 				// continue to next candidate
 			}
 		}
@@ -282,6 +388,20 @@ func sweepAutoStops() error {
 // Convenience wrapper to maintain backwards compatibility with callers that use writeEvent.
 func writeEvent(e Event) error { return Writer.WriteEvent(e) }
 
+// writeEvents persists events in order, in one batch when the Writer supports
+// it and event by event otherwise.
+func writeEvents(evs []Event) error {
+	if bw, ok := Writer.(BatchEventWriter); ok {
+		return bw.WriteEvents(evs)
+	}
+	for _, e := range evs {
+		if err := Writer.WriteEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // small helpers --------------------------------------------------------------
 
 func boolPtr(b bool) *bool { return &b }
@@ -350,56 +470,209 @@ func NewAddEvent(id, customer, project, activity string, billable *bool, note st
 // Materialize entries from events for a given date range
 // Refactored to use the internal/journal parser to centralize parsing logic.
 func loadEntries(from, to time.Time) ([]Entry, error) {
+	var entries []Entry
+	err := streamEntries(from, to, func(e Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+
+	// Ensure deterministic ordering across days
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+
+	return entries, err
+}
+
+// streamEntries calls fn for every entry in the day files from..to, one day
+// file at a time, so long-range aggregations do not materialize every entry.
+// Entries arrive in start order within a day file but not across files.
+// Returning an error from fn stops the stream and is returned.
+func streamEntries(from, to time.Time, fn func(Entry) error) error {
 	// Normalize to local day boundaries
 	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
 	to = time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, to.Location())
 
 	// Create a journal parser using configured timezone (falls back to Local inside the parser).
 	p := journal.NewParser(viper.GetString("timezone"))
+	if ix := entryIndex(); ix != nil {
+		p.Cache = ix
+		defer func() {
+			if err := ix.Close(); err != nil {
+				logger.Warn("entry index", "err", err)
+			}
+		}()
+	} else if cache := entryCache(); cache != nil {
+		p.Cache = cache
+		defer func() {
+			if err := cache.Flush(); err != nil {
+				logger.Warn("entry cache", "err", err)
+			}
+		}()
+	}
 
-	var entries []Entry
+	paths := journalPaths(from, to)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Missing/malformed files are skipped by the non-strict parser, preserving
+	// the previous loadEntries behaviour.
+	ents, errc := p.ParseFilesStream(ctx, paths)
+	for je := range ents {
+		if err := fn(entryFromJournal(je)); err != nil {
+			cancel()
+			for range ents {
+				// drain so the producer can observe cancellation and exit
+			}
+			return err
+		}
+	}
+	if err := <-errc; err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// journalPaths returns the day file paths for the days from..to, whether or
+// not they exist.
+func journalPaths(from, to time.Time) []string {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	var paths []string
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
-		pth := journalPathFor(d)
-		ents, err := p.ParseFile(pth)
+		paths = append(paths, filepath.Join(journalDirFor(d), d.Format("2006-01-02")+".jsonl"))
+	}
+	return paths
+}
+
+// journalSpan returns the days of the first and last day file in the
+// journal; ok is false when there are none.
+func journalSpan() (first, last time.Time, ok bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return first, last, false
+	}
+	paths, _ := filepath.Glob(filepath.Join(home, ".tt", "journal", "*", "*", "*.jsonl"))
+	for _, p := range paths {
+		d, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(filepath.Base(p), ".jsonl"), parserLocation())
 		if err != nil {
-			// Preserve previous behaviour of skipping missing/malformed files in non-strict mode.
 			continue
 		}
-		// Map journal.Entry -> cmd.Entry
-		for _, je := range ents {
-			e := Entry{
-				ID:       je.ID,
-				Start:    je.Start,
-				End:      je.End,
-				Customer: je.Customer,
-				Project:  je.Project,
-				Activity: je.Activity,
-				Billable: je.Billable,
-				Notes:    je.Notes,
-				Tags:     je.Tags,
-			}
-			entries = append(entries, e)
+		if !ok || d.Before(first) {
+			first = d
+		}
+		if !ok || d.After(last) {
+			last = d
 		}
+		ok = true
 	}
+	return first, last, ok
+}
 
-	// Ensure deterministic ordering across days
-	sort.Slice(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+// entryCache returns the binary entry cache under ~/.tt/cache/entries when
+// cache.entries is enabled in the config, or nil otherwise.
+func entryCache() *journal.EntryCache {
+	if !viper.GetBool("cache.entries") {
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return journal.NewEntryCache(filepath.Join(home, ".tt", "cache", "entries"))
+}
 
-	return entries, nil
+// entryIndex opens the entry index ~/.tt/cache/index.db when cache.index is
+// enabled in the config, or returns nil. It also returns nil while another
+// tt process holds the index, so loads fall back to parsing.
+func entryIndex() *index.Index {
+	if !viper.GetBool("cache.index") {
+		return nil
+	}
+	ix, err := index.Open(entryIndexPath(), 250*time.Millisecond)
+	if err != nil {
+		if !errors.Is(err, index.ErrBusy) {
+			logger.Warn("entry index", "err", err)
+		}
+		return nil
+	}
+	return ix
 }
 
+func entryIndexPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".tt", "cache", "index.db")
+}
+
+// durationMinutes is the entry's duration in whole minutes, for display.
+// Sums, rounding and exports go through entrySeconds instead.
 func durationMinutes(e Entry) int {
+	return int(entrySeconds(e) / 60)
+}
+
+// Precision values of the "precision" config key.
+const (
+	precisionMinutes = "minutes"
+	precisionSeconds = "seconds"
+)
+
+// durationPrecision reports the precision config key: "minutes" (default)
+// truncates every entry to whole minutes before it is summed, rounded or
+// exported, "seconds" keeps exact seconds so entries under a minute still
+// count and sums carry no per-entry truncation.
+func durationPrecision() string {
+	if strings.EqualFold(strings.TrimSpace(viper.GetString("precision")), precisionSeconds) {
+		return precisionSeconds
+	}
+	return precisionMinutes
+}
+
+// entrySeconds is the duration of a finished entry, less its pauses, at the
+// configured precision; running entries count 0.
+func entrySeconds(e Entry) int64 {
 	if e.End == nil {
 		return 0
 	}
-	d := e.End.Sub(e.Start)
-	return int(d.Minutes())
+	return truncatePrecision(int64((e.End.Sub(e.Start) - e.journalEntry().Paused(*e.End)) / time.Second))
+}
+
+// entryElapsed is how long e ran up to its end, or up to now while it is
+// running, less its pauses. Unlike entrySeconds it is not truncated.
+func entryElapsed(e Entry, now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	if !end.After(e.Start) {
+		return 0
+	}
+	return end.Sub(e.Start) - e.journalEntry().Paused(end)
+}
+
+// truncatePrecision truncates a duration in seconds to the configured
+// precision. It is applied once per entry, never to sums.
+func truncatePrecision(sec int64) int64 {
+	if sec <= 0 {
+		return 0
+	}
+	if durationPrecision() == precisionMinutes {
+		return sec / 60 * 60
+	}
+	return sec
+}
+
+// hoursOf converts seconds to hours with two decimals, as the exports send.
+func hoursOf(sec int64) float64 {
+	return math.Round(float64(sec)/3600*100) / 100
+}
+
+// fmtSecHHMM is fmtHHMM for a duration in seconds (truncated to minutes).
+func fmtSecHHMM(sec int64) string {
+	return fmtHHMM(int(sec / 60))
 }
 
 type Rounding struct {
 	Strategy     string // up|down|nearest
 	QuantumMin   int
-	MinimumEntry int // minimum billable per entry (minutes)
+	MinimumEntry int    // minimum billable per rounded unit (minutes)
+	Level        string // entry|aggregate: round every entry or each group total
 }
 
 func getRounding() Rounding {
@@ -408,49 +681,66 @@ func getRounding() Rounding {
 		q = 15
 	}
 	min := viper.GetInt("rounding.minimum_billable_min")
-	return Rounding{Strategy: viper.GetString("rounding.strategy"), QuantumMin: q, MinimumEntry: min}
+	level := viper.GetString("rounding.level")
+	if level == "" {
+		level = rounding.LevelEntry
+	}
+	return Rounding{Strategy: viper.GetString("rounding.strategy"), QuantumMin: q, MinimumEntry: min, Level: level}
 }
 
-func roundMinutes(min int, r Rounding) int {
-	if min <= 0 {
-		return 0
-	}
+// Policy is r as a policy of the shared rounding pipeline (internal/rounding).
+func (r Rounding) Policy() rounding.Policy {
 	q := r.QuantumMin
 	if q <= 0 {
 		q = 15
 	}
-	switch r.Strategy {
-	case "down":
-		min = (min / q) * q
-	case "nearest":
-		rem := min % q
-		// Use integer half (q/2) as threshold so odd quantum values behave deterministically
-		// (e.g. QuantumMin=15 => threshold 7, so 22 -> rounds up to 30 to match expectations)
-		if rem >= q/2 {
-			min = ((min / q) + 1) * q
-		} else {
-			min = (min / q) * q
-		}
-	default: // up
-		if min%q != 0 {
-			min = ((min / q) + 1) * q
-		}
+	return rounding.Policy{
+		Strategy:   r.Strategy,
+		QuantumSec: int64(q) * 60,
+		MinimumSec: int64(r.MinimumEntry) * 60,
+		Level:      r.Level,
 	}
-	if r.MinimumEntry > 0 && min < r.MinimumEntry {
-		min = r.MinimumEntry
-	}
-	return min
+}
+
+// roundMinutes rounds one unit (an entry, or a total at aggregate level) of
+// whole minutes. Group totals should go through a rounding.Tally so the
+// configured level applies.
+func roundMinutes(min int, r Rounding) int {
+	return int(r.Policy().Round(int64(min)*60) / 60)
 }
 
 func parseRangeFlags(today bool, week bool, rng string) (time.Time, time.Time) {
-	now := nowLocal()
+	// days and weekdays are those of the configured timezone
+	now := Now().In(parserLocation())
 	if rng != "" {
+		// A single period token ("lastweek", "q3", ...) covers its days; in
+		// A..B either side may be a period, contributing its first or last day.
+		if st, en, ok, err := resolvePeriodToken(rng, now, parserLocation()); ok {
+			cobra.CheckErr(err)
+			return st, en.AddDate(0, 0, -1)
+		}
 		parts := strings.Split(rng, "..")
 		if len(parts) != 2 {
-			cobra.CheckErr(fmt.Errorf("invalid --range; expected A..B"))
+			if w, ok := correctTimeWord(strings.ToLower(rng)); ok {
+				if _, _, period, _ := resolvePeriodToken(w, now, parserLocation()); period {
+					cobra.CheckErr(fmt.Errorf("invalid --range %q; did you mean %q?", rng, w))
+				}
+			}
+			cobra.CheckErr(fmt.Errorf("invalid --range; expected A..B or a period like lastweek, week:2025-W41, lastmonth, q3"))
+		}
+		from, to := time.Time{}, time.Time{}
+		if st, _, ok, err := resolvePeriodToken(parts[0], now, parserLocation()); ok {
+			cobra.CheckErr(err)
+			from = st
+		} else {
+			from = mustParseTimeLocal(parts[0])
+		}
+		if _, en, ok, err := resolvePeriodToken(parts[1], now, parserLocation()); ok {
+			cobra.CheckErr(err)
+			to = en.AddDate(0, 0, -1)
+		} else {
+			to = mustParseTimeLocal(parts[1])
 		}
-		from := mustParseTimeLocal(parts[0])
-		to := mustParseTimeLocal(parts[1])
 		return from, to
 	}
 	if today {
@@ -530,7 +820,7 @@ func LastOpenEntryAt(ts time.Time) (*Entry, error) {
 	var candidate *Entry
 	for i := range entries {
 		e := entries[i]
-		if e.Start.After(ts) {
+		if e.Start.After(ts) || !ownEntry(e) {
 			continue
 		}
 		if e.End == nil {
@@ -577,13 +867,7 @@ func FormatStopResultFromEntry(ent *Entry, stopTS time.Time) string {
 
 	startStr := formatTS(ent.Start)
 	stopStr := formatTS(stopTS)
-	durationMin := 0
-	if ent.End != nil {
-		durationMin = int(ent.End.Sub(ent.Start).Minutes())
-	} else {
-		durationMin = int(stopTS.Sub(ent.Start).Minutes())
-	}
-	dur := fmtHHMM(durationMin)
+	dur := fmtHHMM(int(entryElapsed(*ent, stopTS).Minutes()))
 
 	billStr := "false"
 	if ent.Billable {
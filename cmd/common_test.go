@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -95,6 +96,87 @@ func TestWriteEventAndReadLastHash(t *testing.T) {
 	}
 }
 
+func TestWriteEventsMatchesSequentialWrites(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	var evs []Event
+	for i := 0; i < 6; i++ {
+		// alternate between two days to exercise per-file chaining
+		ts := day.Add(time.Duration(i)*time.Hour).AddDate(0, 0, i%2)
+		evs = append(evs, Event{ID: fmt.Sprintf("b%d", i), Type: "note", TS: ts, Note: "<n>&"})
+	}
+
+	read := func() map[string]string {
+		out := map[string]string{}
+		for _, d := range []time.Time{day, day.AddDate(0, 0, 1)} {
+			p := journalPathFor(d)
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatalf("read journal: %v", err)
+			}
+			out[p] = string(b) + "|" + readLastHash(p)
+		}
+		return out
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	for _, e := range evs {
+		if err := writeEvent(e); err != nil {
+			t.Fatalf("writeEvent: %v", err)
+		}
+	}
+	sequential := read()
+
+	t.Setenv("HOME", t.TempDir())
+	if err := writeEvents(evs); err != nil {
+		t.Fatalf("writeEvents: %v", err)
+	}
+	batched := read()
+
+	if len(sequential) != len(batched) {
+		t.Fatalf("different file sets")
+	}
+	for p, want := range sequential {
+		// paths differ by HOME; compare by base name
+		for bp, got := range batched {
+			if filepath.Base(bp) == filepath.Base(p) && got != want {
+				t.Fatalf("batched output differs for %s:\n got: %s\nwant: %s", filepath.Base(p), got, want)
+			}
+		}
+	}
+}
+
+func benchmarkEvents(n int) []Event {
+	day := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	evs := make([]Event, n)
+	for i := range evs {
+		evs[i] = Event{ID: fmt.Sprintf("e%d", i), Type: "amend", TS: day.Add(time.Duration(i) * time.Second), Ref: "x", Customer: "Acme", Note: "bulk"}
+	}
+	return evs
+}
+
+func BenchmarkWriteEventSequential(b *testing.B) {
+	evs := benchmarkEvents(200)
+	b.Setenv("HOME", b.TempDir())
+	for i := 0; i < b.N; i++ {
+		for _, e := range evs {
+			if err := writeEvent(e); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkWriteEventsBatched(b *testing.B) {
+	evs := benchmarkEvents(200)
+	b.Setenv("HOME", b.TempDir())
+	for i := 0; i < b.N; i++ {
+		if err := writeEvents(evs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestLoadEntries_Reconstruction(t *testing.T) {
 	tmp := t.TempDir()
 	oldHome := os.Getenv("HOME")
@@ -142,6 +224,74 @@ func TestLoadEntries_Reconstruction(t *testing.T) {
 	}
 }
 
+func TestLoadEntries_WithEntryCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("cache.entries", true)
+	defer viper.Set("cache.entries", false)
+
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	if err := writeEvent(Event{ID: "c1", Type: "start", TS: day.Add(9 * time.Hour), Customer: "Acme"}); err != nil {
+		t.Fatalf("writeEvent: %v", err)
+	}
+	if err := writeEvent(Event{ID: "c2", Type: "stop", TS: day.Add(10 * time.Hour)}); err != nil {
+		t.Fatalf("writeEvent: %v", err)
+	}
+
+	first, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatalf("loadEntries: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), ".tt", "cache", "entries", "2025-03.gob")); err != nil {
+		t.Fatalf("expected entry cache to be written: %v", err)
+	}
+	second, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatalf("loadEntries (cached): %v", err)
+	}
+	if len(first) != 1 || len(second) != 1 || durationMinutes(second[0]) != 60 || second[0].Customer != "Acme" {
+		t.Fatalf("cached load differs: first=%+v second=%+v", first, second)
+	}
+}
+
+func TestLoadEntries_WithEntryIndex(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("cache.index", true)
+	defer viper.Set("cache.index", false)
+
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	if err := writeEvent(Event{ID: "i1", Type: "start", TS: day.Add(9 * time.Hour), Customer: "Acme"}); err != nil {
+		t.Fatalf("writeEvent: %v", err)
+	}
+	if err := writeEvent(Event{ID: "i2", Type: "stop", TS: day.Add(10 * time.Hour)}); err != nil {
+		t.Fatalf("writeEvent: %v", err)
+	}
+	if _, err := loadEntries(day, day.AddDate(0, 0, 6)); err != nil {
+		t.Fatalf("loadEntries: %v", err)
+	}
+	ix := entryIndex()
+	if ix == nil {
+		t.Fatal("entry index not opened")
+	}
+	if n := ix.Len(); n != 1 {
+		t.Fatalf("indexed %d day files; want 1", n)
+	}
+	ix.Close()
+
+	// A later event on the day is picked up: the file changed since indexed.
+	if err := writeEvent(Event{ID: "i3", Type: "amend", TS: day.Add(11 * time.Hour), Ref: "i1", Customer: "Globex"}); err != nil {
+		t.Fatalf("writeEvent: %v", err)
+	}
+	ents, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatalf("loadEntries (indexed): %v", err)
+	}
+	if len(ents) != 1 || ents[0].Customer != "Globex" || durationMinutes(ents[0]) != 60 {
+		t.Fatalf("indexed load = %+v", ents)
+	}
+}
+
 func TestDurationAndFmtHHMM(t *testing.T) {
 	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
 	end := start.Add(2*time.Hour + 45*time.Minute)
@@ -159,6 +309,41 @@ func TestDurationAndFmtHHMM(t *testing.T) {
 	}
 }
 
+func TestPrecisionSecondsVsMinutes(t *testing.T) {
+	t.Cleanup(func() { viper.Set("precision", "") })
+	at := func(h, m, sec int) time.Time { return time.Date(2025, 1, 1, h, m, sec, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	// three short entries: 2m40s, 2m40s and 45s
+	ents := []Entry{
+		{ID: "a", Start: at(9, 0, 0), End: end(at(9, 2, 40)), Customer: "Acme", Billable: true},
+		{ID: "b", Start: at(10, 0, 0), End: end(at(10, 2, 40)), Customer: "Acme", Billable: true},
+		{ID: "c", Start: at(11, 0, 0), End: end(at(11, 0, 45)), Customer: "Acme", Billable: true},
+	}
+	r := Rounding{Strategy: "up", QuantumMin: 1, Level: "aggregate"}
+
+	viper.Set("precision", "")
+	if got := entrySeconds(ents[0]); got != 120 {
+		t.Fatalf("minutes: entrySeconds = %d; want 120", got)
+	}
+	u := aggregateUsers(ents, r)
+	if len(u) != 1 || u[0].RawMin != 4 || u[0].RoundedMin != 4 {
+		t.Fatalf("minutes: users = %+v; want 4m raw and rounded", u)
+	}
+
+	viper.Set("precision", "seconds")
+	if got := entrySeconds(ents[0]); got != 160 {
+		t.Fatalf("seconds: entrySeconds = %d; want 160", got)
+	}
+	// 6m05s in total: truncated to minutes only for display, rounded up once.
+	u = aggregateUsers(ents, r)
+	if len(u) != 1 || u[0].RawMin != 6 || u[0].RoundedMin != 7 || u[0].BillableMin != 6 {
+		t.Fatalf("seconds: users = %+v; want 6m raw, 7m rounded", u)
+	}
+	if got := durationMinutes(ents[2]); got != 0 {
+		t.Fatalf("durationMinutes(45s) = %d; want 0", got)
+	}
+}
+
 func TestRoundMinutesVarieties(t *testing.T) {
 	// up strategy
 	r := Rounding{Strategy: "up", QuantumMin: 15, MinimumEntry: 0}
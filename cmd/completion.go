@@ -221,7 +221,7 @@ func customerCompletionList(decisions completionDecisions, aliasCustomer, prefix
 	entries := map[string]string{}
 	for _, name := range decisions.allowedCustomers() {
 		canonical := canonicalForCompletion(name)
-		if canonical == "" {
+		if canonical == "" || decisions.isCustomerArchived(canonical) {
 			continue
 		}
 		key := strings.ToLower(canonical)
@@ -246,7 +246,7 @@ func projectCompletionList(decisions completionDecisions, customer, aliasProject
 
 	appendProjects := func(list []string) {
 		for _, name := range list {
-			trimmed := strings.TrimSpace(name)
+			trimmed := strings.TrimSpace(CanonicalProject(customer, name))
 			if trimmed == "" {
 				continue
 			}
@@ -259,7 +259,9 @@ func projectCompletionList(decisions completionDecisions, customer, aliasProject
 		}
 	}
 
-	appendProjects(decisions.allowedProjects(customer))
+	if !decisions.isCustomerArchived(customer) {
+		appendProjects(decisions.allowedProjects(customer))
+	}
 	appendProjects(decisions.allowedProjects(""))
 
 	filtered := filterPrefixAndSort(base, prefix)
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// completionArchiveCmd archives long-dead customers so they stop appearing in
+// shell completion, the TUI form candidates, tt suggest, tt fav and the TUI
+// suggestions. Entries are untouched and still report as usual.
+var completionArchiveCmd = &cobra.Command{
+	Use:   "archive [customer...]",
+	Short: "Archive customers: no more completion or suggestions (no args: list archived)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		decisions := loadCompletionDecisions()
+		if len(args) == 0 {
+			names := decisions.archivedCustomerNames()
+			if len(names) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No archived customers.")
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), strings.Join(names, "\n"))
+			return nil
+		}
+		var changed []string
+		for _, name := range args {
+			if canonical := decisions.archiveCustomer(name); canonical != "" {
+				changed = append(changed, canonical)
+			}
+		}
+		if len(changed) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "Already archived.")
+			return nil
+		}
+		if err := decisions.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Archived: %s\n", strings.Join(changed, ", "))
+		return nil
+	},
+}
+
+var completionUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <customer...>",
+	Short: "Offer archived customers in completion and suggestions again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		decisions := loadCompletionDecisions()
+		var changed []string
+		for _, name := range args {
+			if decisions.unarchiveCustomer(name) {
+				changed = append(changed, strings.TrimSpace(name))
+			}
+		}
+		if len(changed) == 0 {
+			return fmt.Errorf("not archived: %s", strings.Join(args, ", "))
+		}
+		if err := decisions.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Unarchived: %s\n", strings.Join(changed, ", "))
+		return nil
+	},
+}
+
+func init() {
+	completionCmd.AddCommand(completionArchiveCmd, completionUnarchiveCmd)
+}
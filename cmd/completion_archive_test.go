@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCompletionArchiveCustomers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("completion.allow.customers", []string{"Acme", "Oldco"})
+	viper.Set("completion.archived.customers", nil)
+	viper.Set("customers.map", map[string]string{"old co": "Oldco"})
+	mergedCustomerSet.reset()
+	t.Cleanup(func() {
+		viper.Set("completion.allow.customers", nil)
+		viper.Set("completion.archived.customers", nil)
+		viper.Set("customers.map", nil)
+		mergedCustomerSet.reset()
+	})
+
+	var out bytes.Buffer
+	completionArchiveCmd.SetOut(&out)
+	completionUnarchiveCmd.SetOut(&out)
+	if err := completionArchiveCmd.RunE(completionArchiveCmd, []string{"old co"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := completionArchiveCmd.RunE(completionArchiveCmd, []string{"Oldco"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := completionArchiveCmd.RunE(completionArchiveCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "Archived: Oldco\nAlready archived.\nOldco\n" {
+		t.Fatalf("output = %q", got)
+	}
+
+	dec := loadCompletionDecisions()
+	if !dec.isCustomerArchived("OLDCO") || dec.isCustomerArchived("Acme") {
+		t.Fatalf("archived = %v", dec.archivedCustomerNames())
+	}
+	if got := customerCompletionList(dec, "", ""); len(got) != 1 || got[0] != "Acme" {
+		t.Fatalf("completion = %v", got)
+	}
+	if e := uiEntry(Entry{Customer: "Oldco"}, nil, dec); !e.Archived {
+		t.Fatal("TUI entry of an archived customer not marked")
+	}
+
+	out.Reset()
+	if err := completionUnarchiveCmd.RunE(completionUnarchiveCmd, []string{"Oldco"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := completionUnarchiveCmd.RunE(completionUnarchiveCmd, []string{"Oldco"}); err == nil || !strings.Contains(err.Error(), "not archived") {
+		t.Fatalf("second unarchive err = %v", err)
+	}
+	if got := customerCompletionList(loadCompletionDecisions(), "", ""); len(got) != 2 {
+		t.Fatalf("completion after unarchive = %v", got)
+	}
+}
@@ -13,6 +13,11 @@ type completionDecisions struct {
 
 	allowProjects  map[string]map[string]struct{}
 	ignoreProjects map[string]map[string]struct{}
+
+	// archivedCustomers are long-dead clients: they are left out of shell
+	// completion, TUI form candidates and suggestions, but their entries
+	// still report as usual.
+	archivedCustomers map[string]string
 }
 
 func loadCompletionDecisions() completionDecisions {
@@ -21,6 +26,8 @@ func loadCompletionDecisions() completionDecisions {
 		ignoreCustomers: toCustomerSet(viper.GetStringSlice("completion.ignore.customers")),
 		allowProjects:   toNestedSet(viper.GetStringMap("completion.allow.projects")),
 		ignoreProjects:  toNestedSet(viper.GetStringMap("completion.ignore.projects")),
+
+		archivedCustomers: toCustomerSet(viper.GetStringSlice("completion.archived.customers")),
 	}
 	return cd
 }
@@ -35,6 +42,46 @@ func (c completionDecisions) isCustomerIgnored(name string) bool {
 	return ok
 }
 
+// isCustomerArchived reports whether name, or its canonical name, is
+// archived.
+func (c completionDecisions) isCustomerArchived(name string) bool {
+	for _, n := range []string{name, canonicalForCompletion(name)} {
+		if _, ok := c.archivedCustomers[normalizeCustomerKey(n)]; ok && n != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveCustomer archives the canonical name of name and returns it, or ""
+// when it was archived already.
+func (c completionDecisions) archiveCustomer(name string) string {
+	canonical := canonicalForCompletion(name)
+	if canonical == "" || c.isCustomerArchived(canonical) {
+		return ""
+	}
+	c.archivedCustomers[normalizeCustomerKey(canonical)] = canonical
+	return canonical
+}
+
+// unarchiveCustomer removes name and its canonical name from the archive and
+// reports whether either was archived.
+func (c completionDecisions) unarchiveCustomer(name string) bool {
+	found := false
+	for _, n := range []string{name, canonicalForCompletion(name)} {
+		key := normalizeCustomerKey(n)
+		if _, ok := c.archivedCustomers[key]; ok && key != "" {
+			delete(c.archivedCustomers, key)
+			found = true
+		}
+	}
+	return found
+}
+
+func (c completionDecisions) archivedCustomerNames() []string {
+	return sortedValues(c.archivedCustomers)
+}
+
 func (c completionDecisions) allowCustomer(name string) {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
@@ -132,6 +179,7 @@ func (c completionDecisions) save() error {
 	viper.Set("completion.ignore.customers", c.ignoredCustomers())
 	viper.Set("completion.allow.projects", c.projectsForPersistence(c.allowProjects))
 	viper.Set("completion.ignore.projects", c.projectsForPersistence(c.ignoreProjects))
+	viper.Set("completion.archived.customers", c.archivedCustomerNames())
 	return saveViperConfig()
 }
 
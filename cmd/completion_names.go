@@ -37,15 +37,32 @@ type ProjectStats struct {
 	LastSeen  time.Time
 }
 
-// CompletionIndex aggregates customer and project observations from the journal.
+// ValueStats tracks occurrences of an activity or tag tied to a canonical customer.
+type ValueStats struct {
+	Name      string
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// CompletionIndex aggregates customer, project, activity and tag observations
+// from the journal.
 type CompletionIndex struct {
-	Customers map[string]*CustomerGroup           // canonical customer -> group
-	Projects  map[string]map[string]*ProjectStats // canonical customer -> project -> stats
+	Customers  map[string]*CustomerGroup           // canonical customer -> group
+	Projects   map[string]map[string]*ProjectStats // canonical customer -> project -> stats
+	Activities map[string]map[string]*ValueStats   // canonical customer -> activity -> stats
+	Tags       map[string]map[string]*ValueStats   // canonical customer -> tag -> stats
 }
 
 // BuildCompletionIndex scans the journal directory and aggregates customer/project
 // observations. If root is empty, the default $HOME/.tt/journal path is used.
 func BuildCompletionIndex(root string) (*CompletionIndex, error) {
+	return BuildCompletionIndexSince(root, time.Time{})
+}
+
+// BuildCompletionIndexSince is BuildCompletionIndex restricted to day files
+// dated on or after since. A zero since scans the whole journal.
+func BuildCompletionIndexSince(root string, since time.Time) (*CompletionIndex, error) {
 	if root == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -55,8 +72,10 @@ func BuildCompletionIndex(root string) (*CompletionIndex, error) {
 	}
 
 	idx := &CompletionIndex{
-		Customers: map[string]*CustomerGroup{},
-		Projects:  map[string]map[string]*ProjectStats{},
+		Customers:  map[string]*CustomerGroup{},
+		Projects:   map[string]map[string]*ProjectStats{},
+		Activities: map[string]map[string]*ValueStats{},
+		Tags:       map[string]map[string]*ValueStats{},
 	}
 
 	info, err := os.Stat(root)
@@ -81,6 +100,12 @@ func BuildCompletionIndex(root string) (*CompletionIndex, error) {
 		if !strings.HasSuffix(d.Name(), ".jsonl") {
 			return nil
 		}
+		if !since.IsZero() {
+			day, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(d.Name(), ".jsonl"), since.Location())
+			if err == nil && day.AddDate(0, 0, 1).Before(since) {
+				return nil
+			}
+		}
 		return idx.ingestJournal(path)
 	})
 	if walkErr != nil {
@@ -121,7 +146,16 @@ func (idx *CompletionIndex) ingestJournal(path string) error {
 
 		rawProject := strings.TrimSpace(ev.Project)
 		if rawProject != "" {
-			idx.addProjectObservation(canonicalCustomer, rawProject, ts)
+			idx.addProjectObservation(canonicalCustomer, CanonicalProject(canonicalCustomer, rawProject), ts)
+		}
+
+		if activity := strings.TrimSpace(ev.Activity); activity != "" {
+			addValueObservation(idx.Activities, canonicalCustomer, CanonicalActivity(activity), ts)
+		}
+		for _, tag := range ev.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				addValueObservation(idx.Tags, canonicalCustomer, tag, ts)
+			}
 		}
 	}
 
@@ -185,6 +219,65 @@ func (idx *CompletionIndex) addProjectObservation(canonicalCustomer, project str
 	}
 }
 
+func addValueObservation(m map[string]map[string]*ValueStats, canonicalCustomer, value string, ts time.Time) {
+	customerKey := canonicalCustomer
+	if customerKey == "" {
+		customerKey = "_uncategorized"
+	}
+	values, ok := m[customerKey]
+	if !ok {
+		values = map[string]*ValueStats{}
+		m[customerKey] = values
+	}
+	stats, ok := values[value]
+	if !ok {
+		stats = &ValueStats{Name: value, FirstSeen: ts, LastSeen: ts}
+		values[value] = stats
+	}
+	stats.Count++
+	if ts.Before(stats.FirstSeen) {
+		stats.FirstSeen = ts
+	}
+	if ts.After(stats.LastSeen) {
+		stats.LastSeen = ts
+	}
+}
+
+// SortedActivities returns the activities observed for the canonical
+// customer, or for all customers when canonicalCustomer is empty, most used
+// first.
+func (idx *CompletionIndex) SortedActivities(canonicalCustomer string) []string {
+	return sortedValueNames(idx.Activities, canonicalCustomer)
+}
+
+// SortedTags is SortedActivities for tags.
+func (idx *CompletionIndex) SortedTags(canonicalCustomer string) []string {
+	return sortedValueNames(idx.Tags, canonicalCustomer)
+}
+
+func sortedValueNames(m map[string]map[string]*ValueStats, canonicalCustomer string) []string {
+	counts := map[string]int{}
+	for customer, values := range m {
+		if canonicalCustomer != "" && !strings.EqualFold(customer, canonicalCustomer) {
+			continue
+		}
+		for name, st := range values {
+			counts[name] += st.Count
+		}
+	}
+	out := make([]string, 0, len(counts))
+	for name := range counts {
+		out = append(out, name)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if counts[out[i]] != counts[out[j]] {
+			return counts[out[i]] > counts[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
 // SortedCustomerCanonicals returns canonical customer names ordered lexicographically.
 func (idx *CompletionIndex) SortedCustomerCanonicals() []string {
 	out := make([]string, 0, len(idx.Customers))
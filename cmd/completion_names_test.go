@@ -12,7 +12,7 @@ import (
 
 func TestBuildCompletionIndex(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
-	mergedCustomerSet = nil
+	mergedCustomerSet.reset()
 
 	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 	later := now.Add(2 * time.Hour)
@@ -29,7 +29,7 @@ func TestBuildCompletionIndex(t *testing.T) {
 		{ID: "d", Type: "start", TS: now, Project: "General"},
 	}
 
-	mergedCustomerSet = map[string]string{"Interal": "Internal"}
+	mergedCustomerSet.set(map[string]string{"Interal": "Internal"})
 
 	path := filepath.Join(journalDir, "2024-05-01.jsonl")
 	f, err := os.Create(path)
@@ -139,3 +139,34 @@ func TestCompletionDecisionsLifecycle(t *testing.T) {
 		t.Fatalf("expected Typo still ignored")
 	}
 }
+
+func TestBuildCompletionIndexSinceSkipsOlderDays(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mergedCustomerSet.reset()
+
+	for _, tc := range []struct {
+		day      string
+		customer string
+	}{
+		{"2024-04-01", "Old"},
+		{"2024-05-01", "New"},
+	} {
+		dir := filepath.Join(os.Getenv("HOME"), ".tt", "journal", tc.day[:4], tc.day[5:7])
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir journal: %v", err)
+		}
+		line := `{"id":"x","type":"start","customer":"` + tc.customer + `"}` + "\n"
+		if err := os.WriteFile(filepath.Join(dir, tc.day+".jsonl"), []byte(line), 0o644); err != nil {
+			t.Fatalf("write journal: %v", err)
+		}
+	}
+
+	idx, err := BuildCompletionIndexSince("", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BuildCompletionIndexSince: %v", err)
+	}
+	got := idx.SortedCustomerCanonicals()
+	if len(got) != 1 || got[0] != "New" {
+		t.Fatalf("expected only New, got %v", got)
+	}
+}
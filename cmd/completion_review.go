@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,16 @@ const (
 	focusProjects
 )
 
+// reviewInput is what typed keys go to: list navigation, the filter prompt
+// (/) or the occurrence threshold prompt (m).
+type reviewInput int
+
+const (
+	inputNone reviewInput = iota
+	inputFilter
+	inputThreshold
+)
+
 type selectionAction int
 
 const (
@@ -58,7 +69,7 @@ func prepareReviewState(dec completionDecisions, idx *CompletionIndex) reviewSta
 		if canonical == "" {
 			continue
 		}
-		if dec.isCustomerAllowed(canonical) || dec.isCustomerIgnored(canonical) {
+		if dec.isCustomerAllowed(canonical) || dec.isCustomerIgnored(canonical) || dec.isCustomerArchived(canonical) {
 			continue
 		}
 		st.customers = append(st.customers, pendingCustomer{
@@ -81,6 +92,9 @@ func prepareReviewState(dec completionDecisions, idx *CompletionIndex) reviewSta
 		if customerKey == "_uncategorized" {
 			canonicalCustomer = ""
 		}
+		if dec.isCustomerArchived(canonicalCustomer) {
+			continue
+		}
 		display := canonicalForCompletion(canonicalCustomer)
 		if display == "" && canonicalCustomer == "" {
 			display = "(none)"
@@ -148,6 +162,9 @@ type reviewModel struct {
 	projCursor        int
 	selectedCustomers map[string]struct{}
 	selectedProjects  map[projectKey]struct{}
+	filter            string // case-insensitive substring narrowing both lists
+	input             reviewInput
+	inputBuf          string
 	status            string
 	width             int
 	height            int
@@ -182,6 +199,9 @@ func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 	case tea.KeyMsg:
+		if m.input != inputNone {
+			return m.updateInput(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			m.done = true
@@ -200,11 +220,186 @@ func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.applySelection(actionApprove)
 		case "i", "I":
 			return m.applySelection(actionIgnore)
+		case "s":
+			m.selectAllVisible()
+		case "v":
+			m.invertVisible()
+		case "/":
+			m.input, m.inputBuf = inputFilter, m.filter
+		case "m":
+			m.input, m.inputBuf = inputThreshold, ""
+		case "esc":
+			if m.filter != "" {
+				m.setFilter("")
+			}
+		}
+	}
+	return m, nil
+}
+
+// updateInput handles keys while a prompt is open. The filter applies as
+// you type; enter keeps it, esc clears it. The threshold prompt approves on
+// enter.
+func (m reviewModel) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.done = true
+		return m, tea.Quit
+	case tea.KeyEsc:
+		if m.input == inputFilter {
+			m.setFilter("")
+		}
+		m.input, m.inputBuf = inputNone, ""
+		return m, nil
+	case tea.KeyEnter:
+		input := m.input
+		m.input = inputNone
+		if input == inputThreshold {
+			n, err := strconv.Atoi(strings.TrimSpace(m.inputBuf))
+			if err != nil || n < 1 {
+				m.status = fmt.Sprintf("Not a positive number: %q", m.inputBuf)
+				return m, nil
+			}
+			m.approveAtLeast(n)
+		}
+		m.inputBuf = ""
+		return m, nil
+	case tea.KeyBackspace:
+		if r := []rune(m.inputBuf); len(r) > 0 {
+			m.inputBuf = string(r[:len(r)-1])
 		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.inputBuf += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	if m.input == inputFilter {
+		m.setFilter(m.inputBuf)
 	}
 	return m, nil
 }
 
+func (m *reviewModel) setFilter(f string) {
+	m.filter = f
+	m.custCursor, m.projCursor = 0, 0
+}
+
+func (m reviewModel) matchesFilter(fields ...string) bool {
+	if m.filter == "" {
+		return true
+	}
+	needle := strings.ToLower(m.filter)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleCustomers returns the customers matching the filter; cursors index
+// into this slice.
+func (m reviewModel) visibleCustomers() []pendingCustomer {
+	if m.filter == "" {
+		return m.state.customers
+	}
+	var out []pendingCustomer
+	for _, c := range m.state.customers {
+		if m.matchesFilter(append([]string{c.Canonical}, c.Variants...)...) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (m reviewModel) visibleProjects() []pendingProject {
+	if m.filter == "" {
+		return m.state.projects
+	}
+	var out []pendingProject
+	for _, p := range m.state.projects {
+		if m.matchesFilter(p.Project, p.CustomerDisplay) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// selectAllVisible selects every visible item of the focused list.
+func (m *reviewModel) selectAllVisible() {
+	switch m.focus {
+	case focusCustomers:
+		for _, c := range m.visibleCustomers() {
+			m.selectedCustomers[c.Canonical] = struct{}{}
+		}
+	case focusProjects:
+		for _, p := range m.visibleProjects() {
+			m.selectedProjects[projectKey{Customer: p.CustomerKey, Project: p.Project}] = struct{}{}
+		}
+	}
+}
+
+// invertVisible flips the selection of every visible item of the focused list.
+func (m *reviewModel) invertVisible() {
+	switch m.focus {
+	case focusCustomers:
+		for _, c := range m.visibleCustomers() {
+			if _, ok := m.selectedCustomers[c.Canonical]; ok {
+				delete(m.selectedCustomers, c.Canonical)
+			} else {
+				m.selectedCustomers[c.Canonical] = struct{}{}
+			}
+		}
+	case focusProjects:
+		for _, p := range m.visibleProjects() {
+			key := projectKey{Customer: p.CustomerKey, Project: p.Project}
+			if _, ok := m.selectedProjects[key]; ok {
+				delete(m.selectedProjects, key)
+			} else {
+				m.selectedProjects[key] = struct{}{}
+			}
+		}
+	}
+}
+
+// approveAtLeast approves the visible items of the focused list seen at least
+// n times, regardless of the current selection.
+func (m *reviewModel) approveAtLeast(n int) {
+	approved := 0
+	switch m.focus {
+	case focusCustomers:
+		for _, c := range m.visibleCustomers() {
+			if c.Total >= n {
+				m.decisions.allowCustomer(c.Canonical)
+				m.removeCustomer(c.Canonical)
+				approved++
+			}
+		}
+	case focusProjects:
+		for _, p := range m.visibleProjects() {
+			if p.Count >= n {
+				key := projectKey{Customer: p.CustomerKey, Project: p.Project}
+				m.decisions.allowProject(key.Customer, key.Project)
+				m.removeProject(key)
+				approved++
+			}
+		}
+	}
+	if approved == 0 {
+		m.status = fmt.Sprintf("Nothing with ≥%d occurrences", n)
+		return
+	}
+	if err := m.decisions.save(); err != nil {
+		m.status = fmt.Sprintf("failed to write config: %v", err)
+		return
+	}
+	noun := "customer(s)"
+	if m.focus == focusProjects {
+		noun = "project(s)"
+	}
+	m.status = fmt.Sprintf("Approved %d %s with ≥%d occurrences", approved, noun, n)
+}
+
 func (m *reviewModel) toggleFocus() {
 	if m.focus == focusCustomers {
 		m.focus = focusProjects
@@ -217,35 +412,35 @@ func (m *reviewModel) toggleFocus() {
 func (m *reviewModel) moveCursor(delta int) {
 	switch m.focus {
 	case focusCustomers:
-		if len(m.state.customers) == 0 {
-			return
+		if n := len(m.visibleCustomers()); n > 0 {
+			m.custCursor = clampIndex(m.custCursor+delta, n)
 		}
-		m.custCursor = clampIndex(m.custCursor+delta, len(m.state.customers))
 	case focusProjects:
-		if len(m.state.projects) == 0 {
-			return
+		if n := len(m.visibleProjects()); n > 0 {
+			m.projCursor = clampIndex(m.projCursor+delta, n)
 		}
-		m.projCursor = clampIndex(m.projCursor+delta, len(m.state.projects))
 	}
 }
 
 func (m *reviewModel) toggleSelection() {
 	switch m.focus {
 	case focusCustomers:
-		if len(m.state.customers) == 0 {
+		visible := m.visibleCustomers()
+		if len(visible) == 0 {
 			return
 		}
-		item := m.state.customers[m.custCursor]
+		item := visible[clampIndex(m.custCursor, len(visible))]
 		if _, ok := m.selectedCustomers[item.Canonical]; ok {
 			delete(m.selectedCustomers, item.Canonical)
 		} else {
 			m.selectedCustomers[item.Canonical] = struct{}{}
 		}
 	case focusProjects:
-		if len(m.state.projects) == 0 {
+		visible := m.visibleProjects()
+		if len(visible) == 0 {
 			return
 		}
-		item := m.state.projects[m.projCursor]
+		item := visible[clampIndex(m.projCursor, len(visible))]
 		key := projectKey{Customer: item.CustomerKey, Project: item.Project}
 		if _, ok := m.selectedProjects[key]; ok {
 			delete(m.selectedProjects, key)
@@ -332,12 +527,7 @@ func (m *reviewModel) removeCustomer(canonical string) {
 	}
 	m.state.customers = out
 	delete(m.selectedCustomers, canonical)
-	if m.custCursor >= len(m.state.customers) && m.custCursor > 0 {
-		m.custCursor = len(m.state.customers) - 1
-	}
-	if len(m.state.customers) == 0 {
-		m.custCursor = 0
-	}
+	m.custCursor = clampIndex(m.custCursor, len(m.visibleCustomers()))
 }
 
 func (m *reviewModel) removeProject(key projectKey) {
@@ -350,12 +540,7 @@ func (m *reviewModel) removeProject(key projectKey) {
 	}
 	m.state.projects = out
 	delete(m.selectedProjects, key)
-	if m.projCursor >= len(m.state.projects) && m.projCursor > 0 {
-		m.projCursor = len(m.state.projects) - 1
-	}
-	if len(m.state.projects) == 0 {
-		m.projCursor = 0
-	}
+	m.projCursor = clampIndex(m.projCursor, len(m.visibleProjects()))
 }
 
 func (m reviewModel) selectedCustomerKeys() []string {
@@ -367,11 +552,11 @@ func (m reviewModel) selectedCustomerKeys() []string {
 		sort.Strings(out)
 		return out
 	}
-	if len(m.state.customers) == 0 {
+	visible := m.visibleCustomers()
+	if len(visible) == 0 {
 		return nil
 	}
-	idx := clampIndex(m.custCursor, len(m.state.customers))
-	return []string{m.state.customers[idx].Canonical}
+	return []string{visible[clampIndex(m.custCursor, len(visible))].Canonical}
 }
 
 func (m reviewModel) selectedProjectKeys() []projectKey {
@@ -388,11 +573,11 @@ func (m reviewModel) selectedProjectKeys() []projectKey {
 		})
 		return out
 	}
-	if len(m.state.projects) == 0 {
+	visible := m.visibleProjects()
+	if len(visible) == 0 {
 		return nil
 	}
-	idx := clampIndex(m.projCursor, len(m.state.projects))
-	item := m.state.projects[idx]
+	item := visible[clampIndex(m.projCursor, len(visible))]
 	return []projectKey{{Customer: item.CustomerKey, Project: item.Project}}
 }
 
@@ -417,7 +602,16 @@ func (m reviewModel) View() string {
 	var b strings.Builder
 	b.WriteString(headerStyle.Render("Review completion suggestions"))
 	b.WriteString("\n")
-	b.WriteString("Tab switch • Space select • a approve • i ignore • q quit")
+	b.WriteString("Tab switch • Space select • s all • v invert • / filter • m approve ≥N • a approve • i ignore • q quit")
+	b.WriteString("\n")
+	switch {
+	case m.input == inputFilter:
+		b.WriteString("Filter: " + m.inputBuf + "█")
+	case m.input == inputThreshold:
+		b.WriteString("Approve all with at least N occurrences, N: " + m.inputBuf + "█")
+	case m.filter != "":
+		b.WriteString(fmt.Sprintf("Filter: %q (esc clears)", m.filter))
+	}
 	b.WriteString("\n\n")
 
 	b.WriteString(m.renderCustomers())
@@ -435,19 +629,28 @@ func (m reviewModel) View() string {
 
 func (m reviewModel) renderCustomers() string {
 	var b strings.Builder
+	visible := m.visibleCustomers()
 	header := fmt.Sprintf("Customers (%d pending)", len(m.state.customers))
+	if m.filter != "" {
+		header = fmt.Sprintf("Customers (%d of %d pending)", len(visible), len(m.state.customers))
+	}
 	if m.focus == focusCustomers {
 		header = focusStyle.Render(header)
 	}
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	if len(m.state.customers) == 0 {
+	if len(visible) == 0 {
 		b.WriteString("  (none)\n")
 		return b.String()
 	}
 
-	for i, item := range m.state.customers {
+	from, to := m.listWindow(len(visible), m.custCursor)
+	if from > 0 {
+		b.WriteString(fmt.Sprintf("    … %d more above\n", from))
+	}
+	for i := from; i < to; i++ {
+		item := visible[i]
 		pointer := " "
 		if m.focus == focusCustomers && i == m.custCursor {
 			pointer = cursorStyle.Render(">")
@@ -463,24 +666,36 @@ func (m reviewModel) renderCustomers() string {
 		line := fmt.Sprintf("%s %s %s (%d)%s", pointer, marker, item.Canonical, item.Total, variants)
 		b.WriteString("  " + line + "\n")
 	}
+	if to < len(visible) {
+		b.WriteString(fmt.Sprintf("    … %d more below\n", len(visible)-to))
+	}
 	return b.String()
 }
 
 func (m reviewModel) renderProjects() string {
 	var b strings.Builder
+	visible := m.visibleProjects()
 	header := fmt.Sprintf("Projects (%d pending)", len(m.state.projects))
+	if m.filter != "" {
+		header = fmt.Sprintf("Projects (%d of %d pending)", len(visible), len(m.state.projects))
+	}
 	if m.focus == focusProjects {
 		header = focusStyle.Render(header)
 	}
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	if len(m.state.projects) == 0 {
+	if len(visible) == 0 {
 		b.WriteString("  (none)\n")
 		return b.String()
 	}
 
-	for i, item := range m.state.projects {
+	from, to := m.listWindow(len(visible), m.projCursor)
+	if from > 0 {
+		b.WriteString(fmt.Sprintf("    … %d more above\n", from))
+	}
+	for i := from; i < to; i++ {
+		item := visible[i]
 		pointer := " "
 		if m.focus == focusProjects && i == m.projCursor {
 			pointer = cursorStyle.Render(">")
@@ -493,9 +708,33 @@ func (m reviewModel) renderProjects() string {
 		line := fmt.Sprintf("%s %s %s — %s (%d)", pointer, marker, item.CustomerDisplay, item.Project, item.Count)
 		b.WriteString("  " + line + "\n")
 	}
+	if to < len(visible) {
+		b.WriteString(fmt.Sprintf("    … %d more below\n", len(visible)-to))
+	}
 	return b.String()
 }
 
+// listWindow returns the [from, to) rows of an n-item list to render so the
+// cursor stays visible and both lists fit the terminal height. Without a
+// known height everything is shown.
+func (m reviewModel) listWindow(n, cursor int) (int, int) {
+	rows := (m.height - 12) / 2
+	if m.height <= 0 || n <= rows {
+		return 0, n
+	}
+	if rows < 3 {
+		rows = 3
+	}
+	from := cursor - rows/2
+	if from < 0 {
+		from = 0
+	}
+	if from+rows > n {
+		from = n - rows
+	}
+	return from, from + rows
+}
+
 var completionReviewCmd = &cobra.Command{
 	Use:   "review",
 	Short: "Interactively review observed customers and projects for completion",
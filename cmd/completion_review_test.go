@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+func TestReviewModelFilterAndBulkActions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	resetDecisions := func() {
+		viper.Set("completion.allow.customers", []string{})
+		viper.Set("completion.ignore.customers", []string{})
+		viper.Set("completion.allow.projects", map[string][]string{})
+		viper.Set("completion.ignore.projects", map[string][]string{})
+	}
+	resetDecisions()
+	t.Cleanup(resetDecisions)
+
+	state := reviewState{customers: []pendingCustomer{
+		{Canonical: "Acme", Total: 12},
+		{Canonical: "Acme Labs", Total: 4},
+		{Canonical: "Globex", Total: 9, Variants: []string{"globex inc"}},
+		{Canonical: "Initech", Total: 1},
+	}}
+	var model tea.Model = newReviewModel(loadCompletionDecisions(), state)
+	keys := func(ks ...string) {
+		for _, k := range ks {
+			var msg tea.KeyMsg
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			model, _ = model.Update(msg)
+		}
+	}
+	m := func() reviewModel { return model.(reviewModel) }
+	names := func(cs []pendingCustomer) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.Canonical)
+		}
+		return out
+	}
+
+	// filter by name or variant
+	keys("/", "a", "c", "m", "e", "enter")
+	if got := names(m().visibleCustomers()); !reflect.DeepEqual(got, []string{"Acme", "Acme Labs"}) {
+		t.Fatalf("filter acme = %v", got)
+	}
+	if !strings.Contains(m().View(), "Customers (2 of 4 pending)") {
+		t.Errorf("view should show filtered count:\n%s", m().View())
+	}
+	keys("esc", "/", "I", "N", "C", "enter")
+	if got := names(m().visibleCustomers()); !reflect.DeepEqual(got, []string{"Globex"}) {
+		t.Fatalf("filter on variant = %v", got)
+	}
+
+	// select all visible, then invert over the whole list
+	keys("esc", "s")
+	if len(m().selectedCustomers) != 4 {
+		t.Fatalf("select all = %v", m().selectedCustomers)
+	}
+	keys(" ", "v") // deselect Acme (cursor), invert -> only Acme selected
+	if got := m().selectedCustomerKeys(); !reflect.DeepEqual(got, []string{"Acme"}) {
+		t.Fatalf("after invert = %v", got)
+	}
+
+	// approve everything seen at least 5 times
+	keys("m", "5", "enter")
+	if got := names(m().state.customers); !reflect.DeepEqual(got, []string{"Acme Labs", "Initech"}) {
+		t.Fatalf("remaining after approve ≥5 = %v", got)
+	}
+	if got := viper.GetStringSlice("completion.allow.customers"); len(got) != 2 {
+		t.Errorf("persisted allow list = %v", got)
+	}
+	keys("m", "x", "enter")
+	if !strings.Contains(m().status, "Not a positive number") {
+		t.Errorf("status = %q", m().status)
+	}
+}
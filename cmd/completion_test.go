@@ -5,7 +5,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
@@ -21,7 +23,7 @@ func TestAliasFlagCompletion_FilteringAndList(t *testing.T) {
 	// Reset viper state and runtime cache to avoid cross-test pollution
 	viper.Reset()
 	viper.Set("aliases", nil)
-	aliasesCache = nil
+	aliasesCache.reset()
 
 	// Create some aliases via the public helper which persists via viper
 	b := true
@@ -51,3 +53,49 @@ func TestAliasFlagCompletion_FilteringAndList(t *testing.T) {
 		t.Fatalf("expected config file at %s, stat error: %v", cfg, err)
 	}
 }
+
+func TestActivityAndTagFlagCompletion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("completion.lookback_days", 0)
+	mergedCustomerSet.reset()
+	t.Cleanup(func() { viper.Set("timezone", ""); viper.Set("completion.lookback_days", nil) })
+
+	ts := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	if err := writeEvents([]Event{
+		NewStartEvent("1", "Acme", "Portal", "dev", nil, "", []string{"frontend", "urgent"}, ts),
+		NewStartEvent("2", "Acme", "Portal", "dev", nil, "", []string{"frontend"}, ts.Add(time.Hour)),
+		NewStartEvent("3", "Acme", "Portal", "design", nil, "", nil, ts.Add(2*time.Hour)),
+		NewStartEvent("4", "Globex", "Ops", "support", nil, "", []string{"oncall"}, ts.Add(3*time.Hour)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		fn         func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+		cmd        *cobra.Command
+		args       []string
+		toComplete string
+		want       []string
+	}{
+		{"activity for customer, most used first", activityFlagCompletion, startCmd, []string{"acme"}, "", []string{"dev", "design"}},
+		{"activity prefix", activityFlagCompletion, switchCmd, []string{"Acme"}, "des", []string{"design"}},
+		{"activity without customer", activityFlagCompletion, startCmd, nil, "", []string{"dev", "design", "support"}},
+		{"add customer is the third arg", activityFlagCompletion, addCmd, []string{"9", "10", "Globex"}, "", []string{"support"}},
+		{"unknown customer falls back to all", activityFlagCompletion, startCmd, []string{"Initech"}, "su", []string{"support"}},
+		{"tags for customer", tagFlagCompletion, startCmd, []string{"Acme"}, "", []string{"frontend", "urgent"}},
+		{"comma-separated tags skip given ones", tagFlagCompletion, startCmd, []string{"Acme"}, "frontend,", []string{"frontend,urgent"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, dir := tc.fn(tc.cmd, tc.args, tc.toComplete)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if dir&cobra.ShellCompDirectiveNoFileComp == 0 {
+				t.Errorf("directive %v should disable file completion", dir)
+			}
+		})
+	}
+}
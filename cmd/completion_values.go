@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Completion for --activity and --tag on start/switch/add/amend. Values come
+// from the completion index (what the journal has seen), narrowed to the
+// customer typed so far. Each command registers these next to its flags.
+
+// completionLookback bounds how much journal history --activity/--tag
+// completion scans: completion.lookback_days (default 365; <= 0 scans all).
+func completionLookback() time.Time {
+	days := 365
+	if viper.IsSet("completion.lookback_days") {
+		days = viper.GetInt("completion.lookback_days")
+	}
+	if days <= 0 {
+		return time.Time{}
+	}
+	return Now().AddDate(0, 0, -days)
+}
+
+// completionCustomer returns the canonical customer chosen on the command
+// line so far: the --customer flag (amend) or the positional customer
+// argument (start/switch: first, add: third).
+func completionCustomer(cmd *cobra.Command, args []string) string {
+	if cmd != nil {
+		if v, err := cmd.Flags().GetString("customer"); err == nil && strings.TrimSpace(v) != "" {
+			return canonicalForCompletion(v)
+		}
+	}
+	pos := 0
+	if cmd == addCmd {
+		pos = 2
+	}
+	if cmd == amendCmd || len(args) <= pos {
+		return ""
+	}
+	return canonicalForCompletion(args[pos])
+}
+
+func activityFlagCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	idx, err := BuildCompletionIndexSince("", completionLookback())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return valueCompletionList(idx.SortedActivities, completionCustomer(cmd, args), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// tagFlagCompletion completes the last element of a comma-separated --tag
+// value and skips tags already given.
+func tagFlagCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	idx, err := BuildCompletionIndexSince("", completionLookback())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	head, prefix := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		head, prefix = toComplete[:i+1], toComplete[i+1:]
+	}
+	given := map[string]bool{}
+	for _, t := range strings.Split(head, ",") {
+		given[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	var out []string
+	for _, t := range valueCompletionList(idx.SortedTags, completionCustomer(cmd, args), prefix) {
+		if !given[strings.ToLower(t)] {
+			out = append(out, head+t)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// valueCompletionList returns the values seen with customer (falling back to
+// all customers when there are none), most used first, filtered by prefix.
+func valueCompletionList(sorted func(string) []string, customer, prefix string) []string {
+	list := sorted(customer)
+	if len(list) == 0 && customer != "" {
+		list = sorted("")
+	}
+	lower := strings.ToLower(prefix)
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if strings.HasPrefix(strings.ToLower(v), lower) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import "sync"
+
+// Lookups derived from the config (aliases, customers.map, projects.map,
+// activities.map, customers.display) are built on first use and cached for
+// the whole process. Everything running in it shares them: the TUI, tt
+// serve's request handlers and parallel tests, so each cache guards its
+// value with a lock. Code that changes the config underneath them (profile
+// switches, merges, tests) calls reset, or resetConfigCaches for all.
+
+// configCache is one cached lookup. The zero value is empty.
+type configCache[T any] struct {
+	mu     sync.Mutex
+	loaded bool
+	val    T
+}
+
+// get returns the cached value, building it with load first if needed. load
+// runs without the lock held, as it may itself reset caches (re-reading the
+// config re-applies the profile). Callers must not modify the value.
+func (c *configCache[T]) get(load func() T) T {
+	c.mu.Lock()
+	if c.loaded {
+		defer c.mu.Unlock()
+		return c.val
+	}
+	c.mu.Unlock()
+
+	v := load()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.loaded {
+		c.val, c.loaded = v, true
+	}
+	return c.val
+}
+
+// set replaces the cached value, e.g. after storing it in the config.
+func (c *configCache[T]) set(v T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.val, c.loaded = v, true
+}
+
+// reset drops the cached value; the next get loads it again.
+func (c *configCache[T]) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero T
+	c.val, c.loaded = zero, false
+}
+
+// resetConfigCaches drops every config-derived cache.
+func resetConfigCaches() {
+	aliasesCache.reset()
+	mergedCustomerSet.reset()
+	mergedProjectMap.reset()
+	mergedActivityMap.reset()
+	customerDisplayMap.reset()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+// Display aliases (customers.display) shorten long customer names for the
+// screen: tt report, the table/markdown/html week report and the TUI show
+// "ACME" for "ACME Corporation GmbH" while the journal, exports and pushes
+// keep the full name. Unlike customers.map nothing is rewritten, and the
+// --customer filters accept the alias as well as the name.
+
+// customerDisplayMap caches customers.display by lower-cased (canonical)
+// customer name.
+var customerDisplayMap configCache[map[string]string]
+
+func readCustomerDisplayMap() map[string]string {
+	m := map[string]string{}
+	for name, alias := range viper.GetStringMapString("customers.display") {
+		if name != "" && alias != "" {
+			m[strings.ToLower(name)] = alias
+		}
+	}
+	return m
+}
+
+// DisplayCustomer returns the display alias of customer from
+// customers.display, looked up by the canonical name (customers.map) and
+// ignoring case. Customers without an alias are returned unchanged.
+func DisplayCustomer(customer string) string {
+	if customer == "" {
+		return customer
+	}
+	display := customerDisplayMap.get(readCustomerDisplayMap)
+	for _, n := range []string{customer, CanonicalCustomer(customer)} {
+		if alias, ok := display[strings.ToLower(strings.TrimSpace(n))]; ok {
+			return alias
+		}
+	}
+	return customer
+}
+
+// customerMatches reports whether filter names customer: its name, canonical
+// name or display alias, ignoring case and surrounding space.
+func customerMatches(filter, customer string) bool {
+	filter = strings.TrimSpace(filter)
+	for _, n := range []string{customer, CanonicalCustomer(customer), DisplayCustomer(customer)} {
+		if strings.EqualFold(filter, strings.TrimSpace(n)) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayWeekCustomers returns days with the customers of the groups
+// replaced by their display aliases. days is not modified.
+func displayWeekCustomers(days []reporting.Day) []reporting.Day {
+	out := make([]reporting.Day, len(days))
+	for i, d := range days {
+		d.Groups = append([]reporting.Group(nil), d.Groups...)
+		for j := range d.Groups {
+			d.Groups[j].Customer = DisplayCustomer(d.Groups[j].Customer)
+		}
+		out[i] = d
+	}
+	return out
+}
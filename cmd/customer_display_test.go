@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+func TestCustomerDisplayAliases(t *testing.T) {
+	viper.Set("customers.display", map[string]string{"ACME Corporation GmbH": "ACME"})
+	viper.Set("customers.map", map[string]string{"acme corp": "ACME Corporation GmbH"})
+	resetConfigCaches()
+	t.Cleanup(func() {
+		viper.Set("customers.display", nil)
+		viper.Set("customers.map", nil)
+		resetConfigCaches()
+	})
+
+	for in, want := range map[string]string{
+		"ACME Corporation GmbH": "ACME",
+		"acme corporation gmbh": "ACME",
+		"acme corp":             "ACME", // via customers.map
+		"Globex":                "Globex",
+		"":                      "",
+	} {
+		if got := DisplayCustomer(in); got != want {
+			t.Errorf("DisplayCustomer(%q) = %q; want %q", in, got, want)
+		}
+	}
+	for _, tc := range []struct {
+		filter, customer string
+		want             bool
+	}{
+		{"acme", "ACME Corporation GmbH", true},
+		{" ACME Corporation GmbH ", "ACME Corporation GmbH", true},
+		{"ACME", "acme corp", true},
+		{"acme", "Globex", false},
+	} {
+		if got := customerMatches(tc.filter, tc.customer); got != tc.want {
+			t.Errorf("customerMatches(%q, %q) = %v", tc.filter, tc.customer, got)
+		}
+	}
+
+	days := []reporting.Day{{Date: "2025-10-06", Groups: []reporting.Group{{Customer: "ACME Corporation GmbH", Project: "portal"}}}}
+	shown := displayWeekCustomers(days)
+	if shown[0].Groups[0].Label() != "ACME / portal" || days[0].Groups[0].Customer != "ACME Corporation GmbH" {
+		t.Fatalf("shown = %+v, days = %+v", shown, days)
+	}
+
+	k := aggKey{Customer: "ACME Corporation GmbH", Project: "portal"}
+	agg := map[aggKey]*aggVal{k: {RawMin: 60, RoundedMin: 60}}
+	if out := (reportOptions{}).formatGroups(agg, nil); !strings.Contains(out, "ACME / portal") || strings.Contains(out, "GmbH") {
+		t.Fatalf("report groups:\n%s", out)
+	}
+	if got := (reportOptions{Legal: true}).aggKeyLabel(k); got != "ACME Corporation GmbH / portal" {
+		t.Fatalf("--legal-names label = %q", got)
+	}
+}
@@ -20,8 +20,8 @@ var (
 )
 
 // mergedCustomerSet holds mapping source -> canonical loaded into memory for quick checks.
-// It is populated from viper ("customers.map") on init and when customer-merge is run.
-var mergedCustomerSet map[string]string
+// It is loaded from viper ("customers.map") on first use and refreshed when customer-merge is run.
+var mergedCustomerSet configCache[map[string]string]
 
 // customerMergeCmd implements a non-destructive merge of customers by writing amend events
 // that set the canonical customer on matching entries. It also persists the mapping into
@@ -153,8 +153,8 @@ var customerMergeCmd = &cobra.Command{
 			}
 		}
 
-		// Apply: write amend events
-		var written int
+		// Apply: write amend events in one batch
+		evs := make([]Event, 0, len(targetIDs))
 		for _, id := range targetIDs {
 			meta := map[string]string{}
 			if orig, ok := origByID[id]; ok && orig != "" {
@@ -169,12 +169,12 @@ var customerMergeCmd = &cobra.Command{
 				Customer: canonical,
 				Meta:     meta,
 			}
-			if err := writeEvent(ev); err != nil {
-				cobra.CheckErr(fmt.Errorf("failed to write amend event for %s: %w", id, err))
-			}
-			written++
+			evs = append(evs, ev)
+		}
+		if err := writeEvents(evs); err != nil {
+			cobra.CheckErr(fmt.Errorf("failed to write amend events: %w", err))
 		}
-		cmd.Printf("Wrote %d amend event(s) setting customer -> %q\n", written, canonical)
+		cmd.Printf("Wrote %d amend event(s) setting customer -> %q\n", len(evs), canonical)
 	},
 }
 
@@ -187,26 +187,24 @@ func init() {
 	customerMergeCmd.Flags().BoolVar(&cmDryRun, "dry-run", true, "perform a dry-run (default true); use --dry-run=false to actually write amend events")
 
 	rootCmd.AddCommand(customerMergeCmd)
-
-	// Initialize in-memory merged map from config so completion helpers can consult it.
-	loadMergedCustomerMapIntoMemory()
 }
 
-// loadMergedCustomerMapIntoMemory loads the "customers.map" key from viper into mergedCustomerSet
-// (a simple string->string map). It's safe to call multiple times.
+// loadMergedCustomerMapIntoMemory reloads mergedCustomerSet from the "customers.map" key.
+// It's safe to call multiple times.
 func loadMergedCustomerMapIntoMemory() {
-	m := viper.GetStringMapString("customers.map")
-	if m == nil {
-		mergedCustomerSet = map[string]string{}
-		return
-	}
-	mergedCustomerSet = map[string]string{}
-	for k, v := range m {
+	mergedCustomerSet.set(readMergedCustomerMap())
+}
+
+// readMergedCustomerMap returns "customers.map" as a source -> canonical map.
+func readMergedCustomerMap() map[string]string {
+	out := map[string]string{}
+	for k, v := range viper.GetStringMapString("customers.map") {
 		if k == "" || v == "" {
 			continue
 		}
-		mergedCustomerSet[k] = v
+		out[k] = v
 	}
+	return out
 }
 
 // IsCustomerMerged reports whether the provided customer name has been merged (is a source).
@@ -215,10 +213,7 @@ func IsCustomerMerged(name string) bool {
 	if name == "" {
 		return false
 	}
-	if mergedCustomerSet == nil {
-		loadMergedCustomerMapIntoMemory()
-	}
-	_, ok := mergedCustomerSet[name]
+	_, ok := mergedCustomerSet.get(readMergedCustomerMap)[name]
 	return ok
 }
 
@@ -228,10 +223,7 @@ func CanonicalCustomer(name string) string {
 	if name == "" {
 		return name
 	}
-	if mergedCustomerSet == nil {
-		loadMergedCustomerMapIntoMemory()
-	}
-	if c, ok := mergedCustomerSet[name]; ok && c != "" {
+	if c, ok := mergedCustomerSet.get(readMergedCustomerMap)[name]; ok && c != "" {
 		return c
 	}
 	return name
@@ -243,9 +235,6 @@ func FilterCustomersForCompletion(input []string) []string {
 	if len(input) == 0 {
 		return input
 	}
-	if mergedCustomerSet == nil {
-		loadMergedCustomerMapIntoMemory()
-	}
 	outSet := map[string]struct{}{}
 	for _, s := range input {
 		if s == "" {
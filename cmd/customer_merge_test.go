@@ -28,7 +28,7 @@ func preserveCustomerMergeGlobals(t *testing.T) func() {
 		cmNote = oldNote
 		cmDryRun = oldDry
 		// clear runtime merged map cache so other tests start fresh
-		mergedCustomerSet = nil
+		mergedCustomerSet.reset()
 	}
 }
 
@@ -88,8 +88,8 @@ func TestCustomerMerge_ApplyWritesAmendEventsAndFilterCompletion(t *testing.T) {
 	// Reset viper and runtime caches
 	viper.Reset()
 	viper.Set("aliases", nil)
-	aliasesCache = nil
-	mergedCustomerSet = nil
+	aliasesCache.reset()
+	mergedCustomerSet.reset()
 
 	// deterministic providers
 	oldNow := Now
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/idle"
+	"tt/internal/journal"
+)
+
+// daemonCmd watches the system idle time and pauses the running timer while
+// the user is away: once nothing was typed or clicked for daemon.idle_min it
+// writes a "pause" event dated to the last input, and a "resume" event dated
+// to the first input after it. The parser leaves paused time out of the
+// entry's duration. Like tt schedule run it runs in the foreground and is
+// meant to be kept alive by a service manager (systemd user unit, launchd
+// agent).
+//
+// Config (~/.tt/config.yaml):
+//
+//	daemon:
+//	  idle_min: 10         # pause after 10 minutes without input (default)
+//	  poll_sec: 30         # check every 30 seconds (default)
+//	  idle_command: ""     # tool printing the idle time in ms, instead of the platform's
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Pause the running timer while you are idle and resume it when you are back",
+	Long: "Watch the system idle time (xprintidle on X11, Mutter's IdleMonitor on GNOME Wayland, ioreg on macOS, " +
+		"or daemon.idle_command) and pause the running timer after daemon.idle_min minutes without input " +
+		"(default 10). The pause starts at the last input and ends at the next one, so the idle time is left " +
+		"out of the entry. Runs until interrupted; keep it alive with a systemd user unit or launchd agent.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := daemonIdleSource()
+		if err != nil {
+			return err
+		}
+		threshold, poll := daemonIdleThreshold(), daemonPollInterval()
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		fmt.Fprintf(cmd.OutOrStdout(), "Pausing the running timer after %s idle (checking every %s).\n", threshold, poll)
+		t := time.NewTicker(poll)
+		defer t.Stop()
+		for {
+			if err := checkIdle(ctx, cmd.OutOrStdout(), src, threshold); err != nil {
+				logger.Warn("daemon", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+}
+
+// daemonIdleSource is daemon.idle_command when set, else the platform's.
+func daemonIdleSource() (idle.Source, error) {
+	if c := strings.Fields(viper.GetString("daemon.idle_command")); len(c) > 0 {
+		return idle.Command{Name: c[0], Args: c[1:]}, nil
+	}
+	src, err := idle.Detect()
+	if err != nil {
+		return nil, fmt.Errorf("%w; set daemon.idle_command to a tool printing the idle time in milliseconds", err)
+	}
+	return src, nil
+}
+
+func daemonIdleThreshold() time.Duration {
+	if min := viper.GetInt("daemon.idle_min"); min > 0 {
+		return time.Duration(min) * time.Minute
+	}
+	return 10 * time.Minute
+}
+
+func daemonPollInterval() time.Duration {
+	if sec := viper.GetInt("daemon.poll_sec"); sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// checkIdle pauses or resumes the current user's running entry according to
+// the idle time reported by src.
+func checkIdle(ctx context.Context, w io.Writer, src idle.Source, threshold time.Duration) error {
+	idleFor, err := src.Idle(ctx)
+	if err != nil {
+		return err
+	}
+	now := Now()
+	// a timer may have been started yesterday
+	ents, err := loadEntries(now.AddDate(0, 0, -1), now)
+	if err != nil {
+		return err
+	}
+	for _, e := range ents {
+		if e.End != nil || !ownEntry(e) {
+			continue
+		}
+		typ, ts := idleTransition(e, idleFor, threshold, now)
+		if typ == "" {
+			return nil
+		}
+		if err := writeEvent(Event{ID: IDGen(), Type: typ, TS: ts, Ref: e.ID}); err != nil {
+			return err
+		}
+		loc := parserLocation()
+		if typ == "pause" {
+			fmt.Fprintf(w, "%s  Paused %s (idle since %s)\n", now.In(loc).Format("15:04"), entryLabel(e), ts.In(loc).Format("15:04"))
+		} else {
+			fmt.Fprintf(w, "%s  Resumed %s\n", now.In(loc).Format("15:04"), entryLabel(e))
+		}
+		return nil
+	}
+	return nil
+}
+
+// idleTransition returns the event tt daemon writes for the running entry e
+// and its time: "pause" at the last input once the user has been idle for
+// threshold, "resume" at the latest input when a paused entry sees input
+// again, and "" when nothing changes.
+func idleTransition(e Entry, idleFor, threshold time.Duration, now time.Time) (string, time.Time) {
+	since := now.Add(-idleFor)
+	if p := journal.OpenPause(e.Pauses); p != nil {
+		if idleFor >= threshold {
+			return "", time.Time{}
+		}
+		if since.Before(p.Start) {
+			since = p.Start
+		}
+		return "resume", since
+	}
+	if idleFor < threshold {
+		return "", time.Time{}
+	}
+	if since.Before(e.Start) {
+		since = e.Start
+	}
+	return "pause", since
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// fixedIdle is an idle source reporting a set idle time.
+type fixedIdle time.Duration
+
+func (f fixedIdle) Idle(context.Context) (time.Duration, error) { return time.Duration(f), nil }
+
+func TestDaemonPausesAndResumesOnIdle(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	start := time.Date(2025, 10, 8, 9, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = prevNow
+	})
+	if err := writeEvent(NewStartEvent("tt_a1", "acme", "portal", "", nil, "", nil, start)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	check := func(idleFor time.Duration) {
+		t.Helper()
+		if err := checkIdle(context.Background(), &out, fixedIdle(idleFor), 10*time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check(5 * time.Minute) // not idle long enough
+	check(15 * time.Minute)
+	now = now.Add(30 * time.Second)
+	check(15*time.Minute + 30*time.Second) // already paused
+	now = start.Add(90 * time.Minute)
+	check(20 * time.Second) // back at 10:29:40
+	if got := out.String(); got != "10:00  Paused acme/portal (idle since 09:45)\n10:30  Resumed acme/portal\n" {
+		t.Fatalf("output = %q", got)
+	}
+
+	ents, _ := loadEntries(start, now)
+	if len(ents) != 1 || len(ents[0].Pauses) != 1 {
+		t.Fatalf("entries = %+v", ents)
+	}
+	if err := writeEvent(NewStopEvent(IDGen(), start.Add(2*time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	ents, _ = loadEntries(start, now)
+	// 2h less the pause from 09:45:00 to 10:29:40
+	if got := entrySeconds(ents[0]); got != int64((2*time.Hour-44*time.Minute-40*time.Second)/time.Second)/60*60 {
+		t.Fatalf("entrySeconds = %d", got)
+	}
+}
+
+func TestDaemonPausesEntryStartedYesterday(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	start := time.Date(2025, 10, 7, 23, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 10, 8, 0, 30, 0, 0, time.UTC)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = prevNow
+	})
+	if err := writeEvent(NewStartEvent("tt_n1", "acme", "portal", "", nil, "", nil, start)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	for i := 0; i < 4; i++ {
+		if err := checkIdle(context.Background(), &out, fixedIdle(15*time.Minute+time.Duration(i)*30*time.Second), 10*time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(30 * time.Second)
+	}
+	if got := out.String(); got != "00:30  Paused acme/portal (idle since 00:15)\n" {
+		t.Fatalf("output after 4 idle polls = %q", got)
+	}
+	// the pause sits in the file of its own day, not beside the start
+	if b, err := os.ReadFile(journalPathFor(now)); err != nil || !strings.Contains(string(b), `"type":"pause"`) {
+		t.Fatalf("today's file = %q, %v", b, err)
+	}
+
+	now = time.Date(2025, 10, 8, 1, 0, 0, 0, time.UTC)
+	if err := checkIdle(context.Background(), &out, fixedIdle(0), 10*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	ents, err := loadEntries(start, now)
+	if err != nil || len(ents) != 1 || len(ents[0].Pauses) != 1 || ents[0].Pauses[0].End == nil ||
+		!ents[0].Pauses[0].Start.Equal(time.Date(2025, 10, 8, 0, 15, 0, 0, time.UTC)) || !ents[0].Pauses[0].End.Equal(now) {
+		t.Fatalf("entries = %+v, %v", ents, err)
+	}
+	if err := writeEvent(NewStopEvent("tt_s1", now.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if ents, err = loadEntries(start, now); err != nil || len(ents) != 1 || ents[0].End == nil || entrySeconds(ents[0]) != 8100 {
+		t.Fatalf("after stop: %+v, %v", ents, err)
+	}
+}
+
+func TestIdleTransition(t *testing.T) {
+	start := time.Date(2025, 10, 8, 9, 0, 0, 0, time.UTC)
+	now := start.Add(5 * time.Minute)
+	// Idle since before the timer started: the pause starts with the entry.
+	if typ, ts := idleTransition(Entry{Start: start}, 20*time.Minute, 10*time.Minute, now); typ != "pause" || !ts.Equal(start) {
+		t.Fatalf("pause = %s %v", typ, ts)
+	}
+	if typ, _ := idleTransition(Entry{Start: start}, time.Minute, 10*time.Minute, now); typ != "" {
+		t.Fatalf("active = %s", typ)
+	}
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/reporting"
+)
+
+// tt diff compares two snapshots of the timesheet, e.g. the export that was
+// submitted last month and the journal as it is now, to verify that
+// corrections or a sync did not change numbers that are already out. A
+// snapshot is an export file (tt query --entries --format json, or
+// tt report week --format json, which only has totals) or a range of the
+// live journal. Totals are compared in tracked (unrounded) seconds per day
+// and customer/project; entries are matched by ID.
+
+var (
+	diffBase       string
+	diffOther      string
+	diffBaseRange  string
+	diffOtherRange string
+	diffExitCode   bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two exports or ranges: totals per day and customer/project, added/removed/changed entries",
+	Long: `Compare two snapshots of the timesheet: totals per day and customer/project, and the entries
+added, removed or changed between them.
+
+A snapshot is an export file given with --base/--other, either
+  tt query --entries --format json > export.json    (entries and totals)
+  tt report week --format json > week.json          (totals only)
+or a range of the live journal given with --base-range/--other-range. Without
+--other and --other-range the base is compared with the live journal over the
+days the base covers:
+
+  tt diff --base submitted-2025-10.json
+  tt diff --base export1.json --other export2.json
+  tt diff --base-range week:2025-W41 --other-range week:2025-W42
+
+Totals are compared in tracked (unrounded) seconds; entries are matched by ID.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (diffBase == "") == (diffBaseRange == "") {
+			return fmt.Errorf("give one of --base or --base-range")
+		}
+		if diffOther != "" && diffOtherRange != "" {
+			return fmt.Errorf("give only one of --other and --other-range")
+		}
+		base, err := loadDiffSnapshot(diffBase, diffBaseRange)
+		if err != nil {
+			return err
+		}
+		var other diffSnapshot
+		if diffOther == "" && diffOtherRange == "" {
+			if base.from == "" {
+				return fmt.Errorf("%s is empty; give --other or --other-range", base.label)
+			}
+			from, _ := time.ParseInLocation("2006-01-02", base.from, parserLocation())
+			to, _ := time.ParseInLocation("2006-01-02", base.to, parserLocation())
+			other, err = journalSnapshot(from, to)
+		} else {
+			other, err = loadDiffSnapshot(diffOther, diffOtherRange)
+		}
+		if err != nil {
+			return err
+		}
+		if printSnapshotDiff(cmd.OutOrStdout(), base, other) && diffExitCode {
+			// Like diff(1): status 1 when the snapshots differ.
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffBase, "base", "", "base export file (tt query --entries --format json or tt report week --format json)")
+	diffCmd.Flags().StringVar(&diffOther, "other", "", "export file to compare with the base (default: the live journal over the base's days)")
+	diffCmd.Flags().StringVar(&diffBaseRange, "base-range", "", "use the live journal over A..B or a period like lastweek as the base")
+	diffCmd.Flags().StringVar(&diffOtherRange, "other-range", "", "compare with the live journal over A..B or a period like lastweek")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "exit with status 1 when the snapshots differ")
+}
+
+// diffKey names a total: the time of one customer/project on a day.
+type diffKey struct {
+	Date     string
+	Customer string
+	Project  string
+}
+
+// diffSnapshot is one side of tt diff.
+type diffSnapshot struct {
+	label    string
+	from, to string // first and last day with time, YYYY-MM-DD; empty when there is none
+	totals   map[diffKey]int64
+	entries  map[string]queryEntryJSON // nil for exports with totals only
+}
+
+// loadDiffSnapshot reads the export at path or, without one, the live
+// journal over rng.
+func loadDiffSnapshot(path, rng string) (diffSnapshot, error) {
+	if path == "" {
+		return journalSnapshot(parseRangeFlags(false, false, rng))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diffSnapshot{}, err
+	}
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		var rows []queryEntryJSON
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return diffSnapshot{}, fmt.Errorf("%s: %w", path, err)
+		}
+		return entrySnapshot(path, rows), nil
+	case bytes.HasPrefix(data, []byte("{")):
+		var week reporting.Week
+		if err := json.Unmarshal(data, &week); err != nil {
+			return diffSnapshot{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if week.SchemaVersion == 0 {
+			return diffSnapshot{}, fmt.Errorf("%s: not a tt report week JSON export", path)
+		}
+		return weekSnapshot(path, week), nil
+	}
+	return diffSnapshot{}, fmt.Errorf("%s: unrecognized export; want tt query --entries --format json or tt report week --format json", path)
+}
+
+// journalSnapshot returns the entries of the live journal from..to (whole
+// days).
+func journalSnapshot(from, to time.Time) (diffSnapshot, error) {
+	ents, err := loadEntries(from, to)
+	if err != nil {
+		return diffSnapshot{}, err
+	}
+	now := Now()
+	rows := make([]queryEntryJSON, 0, len(ents))
+	for _, e := range ents {
+		rows = append(rows, newQueryEntryJSON(e, now))
+	}
+	return entrySnapshot("journal "+from.Format("2006-01-02")+".."+to.Format("2006-01-02"), rows), nil
+}
+
+// entrySnapshot indexes rows by ID and sums their seconds by start day.
+func entrySnapshot(label string, rows []queryEntryJSON) diffSnapshot {
+	s := diffSnapshot{label: label, totals: map[diffKey]int64{}, entries: make(map[string]queryEntryJSON, len(rows))}
+	loc := parserLocation()
+	for _, r := range rows {
+		s.entries[r.ID] = r
+		s.addTotal(diffKey{r.Start.In(loc).Format("2006-01-02"), r.Customer, r.Project}, r.DurationSeconds)
+	}
+	return s
+}
+
+// weekSnapshot takes the tracked seconds of the report's groups.
+func weekSnapshot(label string, week reporting.Week) diffSnapshot {
+	s := diffSnapshot{label: label, totals: map[diffKey]int64{}}
+	for _, d := range week.Days {
+		for _, g := range d.Groups {
+			s.addTotal(diffKey{d.Date, g.Customer, g.Project}, g.SecondsRaw)
+		}
+	}
+	return s
+}
+
+func (s *diffSnapshot) addTotal(k diffKey, sec int64) {
+	s.totals[k] += sec
+	if s.from == "" || k.Date < s.from {
+		s.from = k.Date
+	}
+	if k.Date > s.to {
+		s.to = k.Date
+	}
+}
+
+// printSnapshotDiff writes the totals that differ and, when both snapshots
+// have entries, the added, removed and changed entries. It reports whether
+// anything differs.
+func printSnapshotDiff(w io.Writer, base, other diffSnapshot) bool {
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", base.label, other.label)
+	keys := map[diffKey]bool{}
+	for k := range base.totals {
+		keys[k] = true
+	}
+	for k := range other.totals {
+		keys[k] = true
+	}
+	var changed []diffKey
+	for k := range keys {
+		if base.totals[k] != other.totals[k] {
+			changed = append(changed, k)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		a, b := changed[i], changed[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Customer != b.Customer {
+			return a.Customer < b.Customer
+		}
+		return a.Project < b.Project
+	})
+	different := len(changed) > 0
+	if different {
+		fmt.Fprintf(w, "%sTotals (%d):%s\n", ansiHeading, len(changed), ansiReset)
+		for _, k := range changed {
+			label := k.Customer
+			if k.Project != "" {
+				label += "/" + k.Project
+			}
+			b, o := base.totals[k], other.totals[k]
+			fmt.Fprintf(w, "  %s  %-24s %7s → %-7s %s\n", k.Date, label, fmtSecHHMM(b), fmtSecHHMM(o), fmtSignedSeconds(o-b))
+		}
+	}
+
+	if base.entries == nil || other.entries == nil {
+		if !different {
+			fmt.Fprintln(w, "No differences.")
+		}
+		fmt.Fprintln(w, "(entries not compared: a week report export has totals only)")
+		return different
+	}
+	var lines []string
+	for _, id := range sortedEntryIDs(base.entries, other.entries) {
+		b, inBase := base.entries[id]
+		o, inOther := other.entries[id]
+		switch {
+		case !inBase:
+			lines = append(lines, "  + "+diffEntryLine(o))
+		case !inOther:
+			lines = append(lines, "  - "+diffEntryLine(b))
+		default:
+			if fields := changedEntryFields(b, o); len(fields) > 0 {
+				lines = append(lines, "  ~ "+diffEntryLine(o)+"  ("+strings.Join(fields, ", ")+")")
+			}
+		}
+	}
+	if len(lines) > 0 {
+		different = true
+		fmt.Fprintf(w, "%sEntries (%d):%s\n", ansiHeading, len(lines), ansiReset)
+		fmt.Fprintln(w, strings.Join(lines, "\n"))
+	}
+	if !different {
+		fmt.Fprintln(w, "No differences.")
+	}
+	return different
+}
+
+// sortedEntryIDs returns the IDs of both snapshots ordered by start time.
+func sortedEntryIDs(base, other map[string]queryEntryJSON) []string {
+	start := map[string]time.Time{}
+	for _, m := range []map[string]queryEntryJSON{base, other} {
+		for id, r := range m {
+			start[id] = r.Start
+		}
+	}
+	ids := make([]string, 0, len(start))
+	for id := range start {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !start[ids[i]].Equal(start[ids[j]]) {
+			return start[ids[i]].Before(start[ids[j]])
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// diffEntryLine describes an exported entry like tt pinned does.
+func diffEntryLine(r queryEntryJSON) string {
+	e := Entry{ID: r.ID, Start: r.Start, End: r.End, Customer: r.Customer, Project: r.Project}
+	return r.ID + "  " + entryWindowLine(e)
+}
+
+// changedEntryFields names the fields that differ between two versions of
+// an entry.
+func changedEntryFields(a, b queryEntryJSON) []string {
+	var out []string
+	if !a.Start.Equal(b.Start) {
+		out = append(out, "start")
+	}
+	if (a.End == nil) != (b.End == nil) || (a.End != nil && !a.End.Equal(*b.End)) {
+		out = append(out, "end")
+	}
+	for _, f := range []struct {
+		name string
+		same bool
+	}{
+		{"user", a.User == b.User},
+		{"customer", a.Customer == b.Customer},
+		{"project", a.Project == b.Project},
+		{"activity", a.Activity == b.Activity},
+		{"billable", a.Billable == b.Billable},
+		{"notes", reflect.DeepEqual(nonNil(a.Notes), nonNil(b.Notes))},
+		{"tags", reflect.DeepEqual(nonNil(a.Tags), nonNil(b.Tags))},
+	} {
+		if !f.same {
+			out = append(out, f.name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestDiffExportAgainstJournal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = prevNow
+		diffBase, diffOther, diffBaseRange, diffOtherRange = "", "", "", ""
+		queryEntries, queryRange = false, ""
+	})
+
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		NewAddEvent("tt_a1", "acme", "portal", "", boolPtr(true), "login", nil, day, day.Add(2*time.Hour)),
+		NewAddEvent("tt_b2", "globex", "", "", boolPtr(true), "", nil, day.Add(3*time.Hour), day.Add(4*time.Hour)),
+	} {
+		ev.TS = day.Add(8 * time.Hour)
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Export what was submitted.
+	var export bytes.Buffer
+	queryCmd.SetOut(&export)
+	queryEntries, queryRange = true, "2025-10-06T00:00..2025-10-06T23:59"
+	if err := queryCmd.RunE(queryCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "submitted.json")
+	if err := os.WriteFile(path, export.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	diffCmd.SetOut(&out)
+	diffBase = path
+	if err := diffCmd.RunE(diffCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No differences.") {
+		t.Fatalf("unchanged journal:\n%s", out.String())
+	}
+
+	// Later: tt_a1 gets longer, tt_b2 moves to another customer and a new
+	// entry is added.
+	later := day.Add(9 * time.Hour)
+	for _, ev := range []Event{
+		{ID: IDGen(), Type: "amend", TS: later, Ref: "tt_a1", Meta: map[string]string{"end": day.Add(150 * time.Minute).Format(time.RFC3339)}},
+		{ID: IDGen(), Type: "amend", TS: later, Ref: "tt_b2", Customer: "initech"},
+		NewAddEvent("tt_c3", "acme", "portal", "", boolPtr(true), "", nil, day.Add(5*time.Hour), day.Add(6*time.Hour)),
+	} {
+		ev.TS = later
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out.Reset()
+	if err := diffCmd.RunE(diffCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"Totals (3):",
+		"2025-10-06  acme/portal", "2h00m → 3h30m", "+1h30m00s",
+		"2025-10-06  globex", "1h00m → 0m", "-1h00m00s",
+		"2025-10-06  initech", "0m → 1h00m",
+		"Entries (3):",
+		"~ tt_a1  Oct 06 09:00–11:30", "(end)",
+		"~ tt_b2  Oct 06 12:00–13:00", "initech  (customer)",
+		"+ tt_c3  Oct 06 14:00–15:00",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("diff lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "tt_a1") > strings.Index(got, "tt_b2") || strings.Index(got, "tt_b2") > strings.Index(got, "tt_c3") {
+		t.Errorf("entries not in start order:\n%s", got)
+	}
+
+	// Compared the other way round, the new entry is removed.
+	base, _ := loadDiffSnapshot(path, "")
+	live, _ := loadDiffSnapshot("", "2025-10-06T00:00..2025-10-06T23:59")
+	out.Reset()
+	printSnapshotDiff(&out, live, base)
+	if !strings.Contains(out.String(), "- tt_c3  Oct 06 14:00–15:00") {
+		t.Errorf("reverse diff:\n%s", out.String())
+	}
+}
+
+func TestDiffWeekReportTotalsOnly(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	dir := t.TempDir()
+	write := func(name, seconds string) string {
+		p := filepath.Join(dir, name)
+		doc := `{"schema_version":1,"days":[{"date":"2025-10-06","groups":[{"customer":"acme","project":"portal","seconds":7200,"secondsRaw":` + seconds + `}]}]}`
+		if err := os.WriteFile(p, []byte(doc), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	base, err := loadDiffSnapshot(write("a.json", "7000"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := loadDiffSnapshot(write("b.json", "7300"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if !printSnapshotDiff(&out, base, other) {
+		t.Fatalf("no difference reported:\n%s", out.String())
+	}
+	if got := out.String(); !strings.Contains(got, "+5m00s") || !strings.Contains(got, "entries not compared") {
+		t.Fatalf("diff =\n%s", got)
+	}
+	if base.from != "2025-10-06" || base.to != "2025-10-06" {
+		t.Fatalf("span = %s..%s", base.from, base.to)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(bad, []byte("id,start\n"), 0o644)
+	if _, err := loadDiffSnapshot(bad, ""); err == nil || !strings.Contains(err.Error(), "unrecognized export") {
+		t.Fatalf("csv err = %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+var doctorRange string
+
+// doctorRule is one `tt doctor` check over the entries of the scanned range,
+// or with Files over its raw day files. Check or Files returns one line per
+// finding; none means the rule passed.
+type doctorRule struct {
+	Name  string
+	Desc  string
+	Check func(ents []Entry, loc *time.Location) []string
+	Files func(paths []string) []string
+}
+
+var doctorRules = []doctorRule{
+	{Name: "dst", Desc: "entries spanning a DST transition", Check: checkDSTEntries},
+	{Name: "tz", Desc: "invalid customer timezones", Check: checkCustomerTimezones},
+	{Name: "corr", Desc: "conflicting correction chains", Files: checkCorrectionConflicts},
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the journal and config for entries that need attention",
+	Long: `Doctor runs a set of checks over the journal (default: the last 365 days)
+and lists the entries each check flags. Findings are informational; nothing
+is changed.
+
+With --env it reports the environment instead: config file and profile,
+timezone resolution, journal size, entry cache freshness, queued pushes,
+leftover temp files, the last writing process, whether tt serve is
+listening, and whether each configured integration accepts its credentials
+(skip the latter with --no-network). Secrets are not printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorEnv {
+			runDoctorEnv(cmd.Context(), cmd.OutOrStdout(), !doctorNoNetwork)
+			return nil
+		}
+		var from, to time.Time
+		if doctorRange != "" {
+			from, to = parseRangeFlags(false, false, doctorRange)
+			noteUsageRange(cmd, from, to)
+		} else {
+			to = Now()
+			from = to.AddDate(-1, 0, 0)
+		}
+		ents, err := loadEntries(from, to)
+		if err != nil {
+			return err
+		}
+		runDoctor(cmd.OutOrStdout(), ents, journalPaths(from, to), parserLocation())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().StringVar(&doctorRange, "range", "", "range to check: A..B or a period like lastmonth (default: last 365 days)")
+}
+
+// runDoctor runs every rule and reports OK or the findings per rule. It
+// returns the number of findings.
+func runDoctor(w io.Writer, ents []Entry, paths []string, loc *time.Location) int {
+	n := 0
+	for _, r := range doctorRules {
+		var found []string
+		if r.Files != nil {
+			found = r.Files(paths)
+		} else {
+			found = r.Check(ents, loc)
+		}
+		if len(found) == 0 {
+			fmt.Fprintf(w, "OK   %-6s no %s\n", r.Name, r.Desc)
+			continue
+		}
+		fmt.Fprintf(w, "WARN %-6s %d %s\n", r.Name, len(found), r.Desc)
+		for _, f := range found {
+			fmt.Fprintf(w, "     %s\n", f)
+		}
+		n += len(found)
+	}
+	return n
+}
+
+// checkDSTEntries flags finished entries whose local UTC offset changes
+// between start and end. Their duration is absolute time, so it differs from
+// the wall-clock span (e.g. 01:30–03:30 on a spring-forward night is 1h).
+func checkDSTEntries(ents []Entry, loc *time.Location) []string {
+	var out []string
+	for _, e := range ents {
+		if e.End == nil {
+			continue
+		}
+		st, en := e.Start.In(loc), e.End.In(loc)
+		_, offStart := st.Zone()
+		_, offEnd := en.Zone()
+		if offStart == offEnd {
+			continue
+		}
+		wall := (en.Hour()*60 + en.Minute()) - (st.Hour()*60 + st.Minute())
+		wall += int(dayNumber(en)-dayNumber(st)) * 24 * 60
+		out = append(out, fmt.Sprintf("%s %s %s–%s %s: counted %s, wall clock %s (%s)",
+			e.ID, st.Format("2006-01-02"), st.Format("15:04 MST"), en.Format("15:04 MST"),
+			entryLabel(e), fmtHHMM(durationMinutes(e)), fmtHHMM(wall), fmtSignedOffset(offEnd-offStart)))
+	}
+	return out
+}
+
+// checkCustomerTimezones flags customers.<name>.timezone values that do not
+// load; reports would silently fall back to the default timezone.
+func checkCustomerTimezones(_ []Entry, _ *time.Location) []string {
+	var out []string
+	customers := viper.GetStringMap("customers")
+	names := make([]string, 0, len(customers))
+	for n := range customers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		tz := viper.GetString("customers." + n + ".timezone")
+		if tz == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			out = append(out, fmt.Sprintf("customers.%s.timezone %q: %v", n, tz, err))
+		}
+	}
+	return out
+}
+
+// checkCorrectionConflicts lists correction chains the parser resolves by
+// last-write-wins: amends leaving an entry ending before its start, and
+// corrections of an entry a split or merge already replaced.
+func checkCorrectionConflicts(paths []string) []string {
+	p := journal.NewParser(viper.GetString("timezone"))
+	var out []string
+	for _, path := range paths {
+		conflicts, err := p.CorrectionConflicts(path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				out = append(out, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			}
+			continue
+		}
+		for _, c := range conflicts {
+			out = append(out, fmt.Sprintf("%s %s", filepath.Base(path), c))
+		}
+	}
+	return out
+}
+
+// dayNumber is the calendar day of t's wall-clock date, for day differences
+// that ignore the zone offset.
+func dayNumber(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func entryLabel(e Entry) string {
+	if e.Project == "" {
+		return e.Customer
+	}
+	return e.Customer + "/" + e.Project
+}
+
+func fmtSignedOffset(sec int) string {
+	if sec < 0 {
+		return fmt.Sprintf("clocks back %dm", -sec/60)
+	}
+	return fmt.Sprintf("clocks forward %dm", sec/60)
+}
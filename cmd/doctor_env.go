@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/index"
+	"tt/internal/outbox"
+	"tt/internal/tui"
+)
+
+// tt doctor --env reports the environment tt runs in rather than the
+// journal's contents: where config and journal live, cache freshness,
+// timezone resolution, leftovers of interrupted writes, whether tt serve is
+// up, and whether the configured integrations answer. It is meant to be
+// pasted into support requests; secrets are never printed.
+
+var (
+	doctorEnv       bool
+	doctorNoNetwork bool
+)
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorEnv, "env", false, "report the environment (config, journal, cache, timezone, locks, daemons, integrations) instead of checking entries")
+	doctorCmd.Flags().BoolVar(&doctorNoNetwork, "no-network", false, "with --env, skip the integration connectivity checks")
+}
+
+// envCheck is one line of tt doctor --env: Status is OK, WARN or INFO.
+type envCheck struct {
+	Status string
+	Name   string
+	Detail string
+}
+
+func envOK(name, format string, a ...any) envCheck {
+	return envCheck{"OK", name, fmt.Sprintf(format, a...)}
+}
+
+func envWarn(name, format string, a ...any) envCheck {
+	return envCheck{"WARN", name, fmt.Sprintf(format, a...)}
+}
+
+func envInfo(name, format string, a ...any) envCheck {
+	return envCheck{"INFO", name, fmt.Sprintf(format, a...)}
+}
+
+// envProbeTimeout bounds each integration and daemon check.
+const envProbeTimeout = 5 * time.Second
+
+// runDoctorEnv prints every section of tt doctor --env and returns the
+// number of warnings. With network false the integrations are not contacted.
+func runDoctorEnv(ctx context.Context, w io.Writer, network bool) int {
+	home, _ := os.UserHomeDir()
+	root := filepath.Join(home, ".tt")
+	sections := []struct {
+		title  string
+		checks []envCheck
+	}{
+		{"Config", envConfigChecks()},
+		{"Journal", envJournalChecks(filepath.Join(root, "journal"))},
+		{"Cache and queues", envCacheChecks(filepath.Join(root, "journal"))},
+		{"Locks", envLockChecks(root, tui.DefaultNoticePath())},
+		{"Daemons", envDaemonChecks(ctx)},
+	}
+	if network {
+		sections = append(sections, struct {
+			title  string
+			checks []envCheck
+		}{"Integrations", envIntegrationChecks(ctx)})
+	}
+	warnings := 0
+	for i, s := range sections {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, s.title)
+		for _, c := range s.checks {
+			fmt.Fprintf(w, "%-4s %-13s %s\n", c.Status, c.Name, c.Detail)
+			if c.Status == "WARN" {
+				warnings++
+			}
+		}
+	}
+	return warnings
+}
+
+// envConfigChecks reports the config file, the active profile and how the
+// timezone resolves.
+func envConfigChecks() []envCheck {
+	var out []envCheck
+	path := configFilePath()
+	if _, err := os.Stat(path); err != nil {
+		out = append(out, envInfo("config", "%s (not found; using defaults)", path))
+	} else {
+		out = append(out, envOK("config", "%s", path))
+	}
+	if activeProfile != "" {
+		out = append(out, envInfo("profile", "%s", activeProfile))
+	} else {
+		out = append(out, envInfo("profile", "none"))
+	}
+	tz := viper.GetString("timezone")
+	now := Now()
+	system := now.In(time.Local).Format("MST -07:00")
+	switch loc, err := time.LoadLocation(tz); {
+	case tz == "":
+		out = append(out, envInfo("timezone", "not set; using the system zone (now %s)", system))
+	case err != nil:
+		out = append(out, envWarn("timezone", "%q does not load (%v); falling back to the system zone (now %s)", tz, err, system))
+	default:
+		out = append(out, envOK("timezone", "%s (now %s; system zone now %s)", loc, now.In(loc).Format("MST -07:00"), system))
+	}
+	if bad := checkCustomerTimezones(nil, nil); len(bad) > 0 {
+		out = append(out, envWarn("customer tz", "%s", strings.Join(bad, "; ")))
+	}
+	return out
+}
+
+// envJournalChecks reports the journal root and the number, size and span
+// of its day files.
+func envJournalChecks(dir string) []envCheck {
+	if _, err := os.Stat(dir); err != nil {
+		return []envCheck{envInfo("journal", "%s (no journal yet)", dir)}
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*", "*", "*.jsonl"))
+	var size int64
+	for _, p := range paths {
+		if fi, err := os.Stat(p); err == nil {
+			size += fi.Size()
+		}
+	}
+	out := []envCheck{envOK("journal", "%s", dir)}
+	if len(paths) == 0 {
+		return append(out, envInfo("day files", "none"))
+	}
+	first, last, _ := journalSpan()
+	return append(out, envInfo("day files", "%d, %s, %s..%s", len(paths), fmtBytes(size),
+		first.Format("2006-01-02"), last.Format("2006-01-02")))
+}
+
+// envCacheChecks reports the entry cache's freshness against the journal
+// and the push outbox.
+func envCacheChecks(journalDir string) []envCheck {
+	var out []envCheck
+	if c := entryCache(); c == nil {
+		out = append(out, envInfo("entry cache", "disabled (cache.entries)"))
+	} else {
+		paths, _ := filepath.Glob(filepath.Join(journalDir, "*", "*", "*.jsonl"))
+		st := c.Status(paths)
+		detail := fmt.Sprintf("%s: %d day files fresh, %d changed since cached, %d not cached", c.Dir, st.Fresh, st.Stale, st.Missing)
+		if st.Stale > 0 {
+			out = append(out, envInfo("entry cache", "%s (re-parsed on the next load)", detail))
+		} else {
+			out = append(out, envOK("entry cache", "%s", detail))
+		}
+	}
+	if viper.GetBool("cache.index") {
+		paths, _ := filepath.Glob(filepath.Join(journalDir, "*", "*", "*.jsonl"))
+		switch ix, err := index.Open(entryIndexPath(), 250*time.Millisecond); {
+		case errors.Is(err, index.ErrBusy):
+			out = append(out, envInfo("entry index", "%s: in use by another tt process", entryIndexPath()))
+		case err != nil:
+			out = append(out, envWarn("entry index", "%s: %v (delete it to rebuild)", entryIndexPath(), err))
+		default:
+			st := ix.Status(paths)
+			ix.Close()
+			detail := fmt.Sprintf("%s: %d day files fresh, %d changed since indexed, %d not indexed", entryIndexPath(), st.Fresh, st.Stale, st.Missing)
+			if st.Stale > 0 {
+				out = append(out, envInfo("entry index", "%s (re-parsed on the next load)", detail))
+			} else {
+				out = append(out, envOK("entry index", "%s", detail))
+			}
+		}
+	}
+	items, err := pushOutbox().List()
+	switch {
+	case err != nil:
+		out = append(out, envWarn("outbox", "%s: %v", pushOutbox().Dir, err))
+	case len(items) > 0:
+		out = append(out, envWarn("outbox", "%d pushes queued for retry (tt push status, tt push --flush)", len(items)))
+	default:
+		out = append(out, envOK("outbox", "empty"))
+	}
+	return out
+}
+
+// envLockChecks reports what may hold or have broken a write. tt appends to
+// the journal without a lock file, so it lists temp files left by
+// interrupted atomic writes and the last process that wrote events.
+func envLockChecks(root, noticePath string) []envCheck {
+	var leftovers []string
+	for _, pattern := range []string{
+		filepath.Join(outbox.DefaultDir(), ".item-*"),
+		filepath.Join(filepath.Dir(noticePath), ".notice-*"),
+		filepath.Join(root, "cache", "entries", "*.tmp"),
+	} {
+		m, _ := filepath.Glob(pattern)
+		leftovers = append(leftovers, m...)
+	}
+	var out []envCheck
+	if len(leftovers) > 0 {
+		out = append(out, envWarn("temp files", "%d left by interrupted writes (safe to delete): %s", len(leftovers), strings.Join(leftovers, ", ")))
+	} else {
+		out = append(out, envOK("temp files", "none"))
+	}
+	n, err := tui.ReadNotice(noticePath)
+	if err != nil {
+		return append(out, envInfo("last writer", "unknown (no write notice)"))
+	}
+	state := "exited"
+	switch {
+	case n.PID == os.Getpid():
+		state = "this process"
+	case processAlive(n.PID):
+		state = "running"
+	}
+	return append(out, envInfo("last writer", "pid %d (%s), %s %s at %s", n.PID, state, n.Type, n.ID,
+		n.Written.In(parserLocation()).Format("2006-01-02 15:04:05")))
+}
+
+// processAlive reports whether pid is a running process. On Windows, where
+// signal 0 is not available, only the lookup is checked.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+// envDaemonChecks reports whether tt serve answers on its address.
+func envDaemonChecks(ctx context.Context) []envCheck {
+	addr := viper.GetString("serve.addr")
+	if addr == "" {
+		addr = "127.0.0.1:7731"
+	}
+	d := net.Dialer{Timeout: envProbeTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return []envCheck{envInfo("serve", "not listening on %s", addr)}
+	}
+	conn.Close()
+	return []envCheck{envOK("serve", "listening on %s", addr)}
+}
+
+// envIntegrationChecks contacts every configured integration with a
+// read-only request that also validates its credentials. Nothing is posted.
+func envIntegrationChecks(ctx context.Context) []envCheck {
+	var out []envCheck
+	if h, _ := loadHarvestConfig(); h.AccountID != "" || viper.IsSet("harvest.token") {
+		out = append(out, envProbe(ctx, "harvest", http.MethodGet, strings.TrimRight(h.BaseURL, "/")+"/users/me", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+h.Token)
+			r.Header.Set("Harvest-Account-Id", h.AccountID)
+			r.Header.Set("User-Agent", "tt time tracker")
+		}))
+	}
+	if u := strings.TrimRight(viper.GetString("redmine.url"), "/"); u != "" {
+		key := configSecret("redmine.api_key")
+		if key == "" {
+			key = os.Getenv("REDMINE_API_KEY")
+		}
+		out = append(out, envProbe(ctx, "redmine", http.MethodGet, u+"/my/account.json", func(r *http.Request) {
+			r.Header.Set("X-Redmine-API-Key", key)
+		}))
+	}
+	if c := loadCalDAVConfig(); c.URL != "" {
+		out = append(out, envProbe(ctx, "caldav", http.MethodOptions, c.URL, func(r *http.Request) {
+			if c.Username != "" {
+				r.SetBasicAuth(c.Username, c.Password)
+			}
+		}))
+	}
+	if c := loadInvoiceNinjaConfig(); c.URL != "" {
+		out = append(out, envProbe(ctx, "invoice_ninja", http.MethodGet, c.URL+"/api/v1/ping", func(r *http.Request) {
+			r.Header.Set("X-API-TOKEN", c.Token)
+			r.Header.Set("X-Requested-With", "XMLHttpRequest")
+		}))
+	}
+	if hook := configSecret("notify.discord.webhook_url"); hook != "" {
+		// GET on a webhook returns its details without posting.
+		out = append(out, envProbe(ctx, "discord", http.MethodGet, hook, nil))
+	}
+	if hs := viper.GetString("notify.matrix.homeserver"); hs != "" {
+		token := configSecret("notify.matrix.token")
+		if token == "" {
+			token = os.Getenv("TT_MATRIX_TOKEN")
+		}
+		out = append(out, envProbe(ctx, "matrix", http.MethodGet, strings.TrimRight(hs, "/")+"/_matrix/client/v3/account/whoami", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}))
+	}
+	if len(out) == 0 {
+		out = append(out, envInfo("integrations", "none configured"))
+	}
+	return out
+}
+
+// envProbe sends one request and classifies the answer: 2xx and 3xx are OK,
+// 401/403 mean the credentials were rejected. URLs are printed without
+// their path, which may hold a secret (Discord webhooks).
+func envProbe(ctx context.Context, name, method, rawURL string, prepare func(*http.Request)) envCheck {
+	ctx, cancel := context.WithTimeout(ctx, envProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return envWarn(name, "bad URL: %v", err)
+	}
+	host := req.URL.Scheme + "://" + req.URL.Host
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return envWarn(name, "%s unreachable: %v", host, err)
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return envWarn(name, "%s: credentials rejected (HTTP %d)", host, resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return envWarn(name, "%s: HTTP %d", host, resp.StatusCode)
+	}
+	return envOK(name, "%s reachable (HTTP %d)", host, resp.StatusCode)
+}
+
+// fmtBytes formats n in B, KiB or MiB.
+func fmtBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
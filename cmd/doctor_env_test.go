@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRunDoctorEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(h int) time.Time { return time.Date(2025, 10, 6, h, 0, 0, 0, time.UTC) }
+	if err := writeEvents([]Event{NewStartEvent("s1", "Acme", "", "", nil, "", nil, at(9)), NewStopEvent("x1", at(10))}); err != nil {
+		t.Fatal(err)
+	}
+	// an interrupted outbox write
+	os.MkdirAll(filepath.Join(home, ".tt", "outbox"), 0o700)
+	os.WriteFile(filepath.Join(home, ".tt", "outbox", ".item-123"), nil, 0o600)
+
+	harvest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/users/me" || r.Header.Get("Authorization") != "Bearer tok" || r.Header.Get("Harvest-Account-Id") != "42" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer harvest.Close()
+	redmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer redmine.Close()
+	serve, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serve.Close()
+	cfg := map[string]string{
+		"harvest.base_url": harvest.URL + "/v2", "harvest.account_id": "42", "harvest.token": "tok",
+		"redmine.url": redmine.URL, "redmine.api_key": "secret-key", "serve.addr": serve.Addr().String(),
+	}
+	for k, v := range cfg {
+		viper.Set(k, v)
+	}
+	t.Cleanup(func() {
+		for k := range cfg {
+			viper.Set(k, "")
+		}
+	})
+
+	var out bytes.Buffer
+	warnings := runDoctorEnv(context.Background(), &out, true)
+	got := out.String()
+	for _, want := range []string{
+		"OK   timezone      UTC",
+		"INFO day files     1, ",
+		"2025-10-06..2025-10-06",
+		"WARN temp files    1 left by interrupted writes",
+		"INFO last writer   pid ",
+		"OK   serve         listening on " + serve.Addr().String(),
+		"OK   harvest       " + harvest.URL + " reachable (HTTP 200)",
+		"WARN redmine       " + redmine.URL + ": credentials rejected (HTTP 401)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q:\n%s", want, got)
+		}
+	}
+	if warnings != 2 {
+		t.Errorf("warnings = %d; want 2", warnings)
+	}
+	if strings.Contains(got, "secret-key") || strings.Contains(got, "tok\n") {
+		t.Errorf("output shows a secret:\n%s", got)
+	}
+
+	// --no-network leaves the integrations alone
+	out.Reset()
+	runDoctorEnv(context.Background(), &out, false)
+	if strings.Contains(out.String(), "Integrations") {
+		t.Errorf("integrations checked without network:\n%s", out.String())
+	}
+}
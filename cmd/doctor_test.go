@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestCheckDSTEntries(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	at := func(month time.Month, day, h, m int) time.Time {
+		return time.Date(2025, month, day, h, m, 0, 0, berlin)
+	}
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{ID: "spring", Start: at(3, 30, 1, 30), End: end(at(3, 30, 3, 30)), Customer: "Acme", Project: "web"},
+		{ID: "fall", Start: at(10, 26, 1, 0), End: end(at(10, 26, 4, 0)), Customer: "Acme"},
+		{ID: "plain", Start: at(10, 27, 9, 0), End: end(at(10, 27, 10, 0)), Customer: "Acme"},
+		{ID: "open", Start: at(10, 26, 1, 0), Customer: "Acme"},
+	}
+	found := checkDSTEntries(ents, berlin)
+	if len(found) != 2 {
+		t.Fatalf("findings = %q; want 2", found)
+	}
+	if !strings.HasPrefix(found[0], "spring 2025-03-30 01:30 CET–03:30 CEST Acme/web: counted 1h00m, wall clock 2h00m (clocks forward 60m)") {
+		t.Fatalf("spring finding = %q", found[0])
+	}
+	if !strings.Contains(found[1], "counted 4h00m, wall clock 3h00m (clocks back 60m)") {
+		t.Fatalf("fall finding = %q", found[1])
+	}
+
+	var buf bytes.Buffer
+	if n := runDoctor(&buf, ents[2:3], nil, berlin); n != 0 || !strings.HasPrefix(buf.String(), "OK   dst") {
+		t.Fatalf("runDoctor = %d, %q", n, buf.String())
+	}
+}
+
+func TestCheckCustomerTimezones(t *testing.T) {
+	viper.Set("customers.acme.timezone", "Europe/Berlin")
+	viper.Set("customers.globex.timezone", "Mars/Olympus")
+	t.Cleanup(func() {
+		viper.Set("customers.acme.timezone", "")
+		viper.Set("customers.globex.timezone", "")
+	})
+	found := checkCustomerTimezones(nil, time.UTC)
+	if len(found) != 1 || !strings.HasPrefix(found[0], `customers.globex.timezone "Mars/Olympus"`) {
+		t.Fatalf("findings = %q", found)
+	}
+}
+
+func TestCheckCorrectionConflicts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2025-08-01.jsonl")
+	lines := strings.Join([]string{
+		`{"id":"e1","type":"add","ts":"2025-08-01T09:00:00Z","ref":"2025-08-01T09:00:00Z..2025-08-01T10:00:00Z","customer":"Acme"}`,
+		`{"id":"m1","type":"merge","ts":"2025-08-01T12:00:00Z","meta":{"targets":"e1"}}`,
+		`{"id":"a1","type":"amend","ts":"2025-08-01T12:30:00Z","ref":"e1","note":"late"}`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found := checkCorrectionConflicts([]string{path, filepath.Join(dir, "missing.jsonl")})
+	want := "2025-08-01.jsonl e1: amend targets the entry after merge m1 replaced it (m1 → a1)"
+	if len(found) != 1 || found[0] != want {
+		t.Fatalf("findings = %q; want %q", found, want)
+	}
+}
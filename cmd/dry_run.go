@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// dryRun is the global --dry-run flag. Commands with their own --dry-run
+// (merges, alias import, push, notify, audit repair) keep theirs.
+var dryRun bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the events a command would append (JSON with computed hash) instead of writing them")
+}
+
+// previewEventWriter is the Writer under --dry-run: it prints each event as
+// the journal line the file writer would append, hash chain included, and
+// writes nothing.
+type previewEventWriter struct {
+	w     io.Writer
+	heads map[string]string // day file -> last previewed hash
+}
+
+func newPreviewEventWriter(w io.Writer) *previewEventWriter {
+	return &previewEventWriter{w: w, heads: map[string]string{}}
+}
+
+func (pw *previewEventWriter) WriteEvent(e Event) error {
+	return pw.WriteEvents([]Event{e})
+}
+
+// WriteEvents prints evs, chaining each day file's hashes from its current
+// anchor (or the previous previewed event) as fileEventWriter.WriteEvents
+// would.
+func (pw *previewEventWriter) WriteEvents(evs []Event) error {
+	user := currentUser()
+	for _, e := range evs {
+		if e.User == "" {
+			e.User = user
+		}
+		// journalPathFor would create the day directory
+		path := filepath.Join(journalDirFor(e.TS), e.TS.Format("2006-01-02")+".jsonl")
+		prev, ok := pw.heads[path]
+		if !ok {
+			prev = readLastHash(path)
+		}
+		e.PrevHash = prev
+		b, err := json.Marshal(canonicalPayloadFor(e))
+		if err != nil {
+			return err
+		}
+		h := sha256.Sum256(b)
+		e.Hash = hex.EncodeToString(h[:])
+		pw.heads[path] = e.Hash
+
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(pw.w, "dry-run: would append to %s:\n%s\n", path, line)
+	}
+	return nil
+}
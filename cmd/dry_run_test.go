@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestPreviewEventWriterMatchesFileWriter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("user", "alice")
+	t.Cleanup(func() { viper.Set("user", "") })
+	ts := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	fw := &fileEventWriter{}
+	if err := fw.WriteEvent(Event{ID: "e0", Type: "start", TS: ts, Customer: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	evs := []Event{
+		{ID: "e1", Type: "stop", TS: ts.Add(time.Hour)},
+		{ID: "e2", Type: "note", TS: ts.Add(2 * time.Hour), Note: "<b>&"},
+	}
+
+	var buf bytes.Buffer
+	if err := newPreviewEventWriter(&buf).WriteEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(journalDirFor(ts), "2025-10-14.jsonl")
+	before, _ := os.ReadFile(path)
+
+	if err := fw.WriteEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := os.ReadFile(path)
+	appended := strings.TrimPrefix(string(after), string(before))
+
+	var previewed []string
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.HasPrefix(l, "dry-run: would append to "+path) {
+			continue
+		}
+		previewed = append(previewed, l)
+	}
+	if got := strings.Join(previewed, "\n") + "\n"; got != appended {
+		t.Errorf("preview:\n%s\nfile writer appended:\n%s", got, appended)
+	}
+	if strings.Count(string(before), "\n") != 1 {
+		t.Fatalf("preview must not write; journal before the real write:\n%s", before)
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// entryRefLookbackDays bounds how far back entry references are resolved.
+const entryRefLookbackDays = 365
+
+// loadEntriesForRefFn loads the entries references are resolved against.
+var loadEntriesForRefFn = loadEntries
+
+// entryRefHelp documents the accepted references for command help.
+const entryRefHelp = "an entry id, a unique id prefix, last, last:<customer> or today:<n>"
+
+// resolveEntryRef resolves a reference given on the command line to an entry
+// ID. See resolveEntryRefIn for the accepted forms.
+func resolveEntryRef(ref string) (string, error) {
+	ids, err := resolveEntryRefs([]string{ref})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// resolveEntryRefs resolves each of refs (see resolveEntryRef), loading the
+// entries once.
+func resolveEntryRefs(refs []string) ([]string, error) {
+	now := Now().In(parserLocation())
+	ents, err := loadEntriesForRefFn(now.AddDate(0, 0, -entryRefLookbackDays), now)
+	if err != nil {
+		return nil, fmt.Errorf("loading entries to resolve ids: %w", err)
+	}
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := resolveEntryRefIn(ref, ents, now)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// resolveEntryRefIn resolves ref against the current user's entries:
+//
+//   - last: the most recently started entry;
+//   - last:<customer>: the most recently started entry of customer
+//     (canonical names or display aliases, case-insensitive);
+//   - today:<n>: the n-th entry started today (1-based, in start order);
+//   - an entry ID, or a prefix matching exactly one ID (with or without the
+//     tt_ prefix). A prefix matching several IDs is an error listing them.
+//
+// A reference matching nothing is returned unchanged, since full IDs of
+// entries outside the lookback are still valid targets.
+func resolveEntryRefIn(ref string, ents []Entry, now time.Time) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("empty entry reference")
+	}
+	var own []Entry
+	for _, e := range ents {
+		if ownEntry(e) {
+			own = append(own, e)
+		}
+	}
+	sort.SliceStable(own, func(i, j int) bool { return own[i].Start.Before(own[j].Start) })
+
+	sel, arg, hasArg := strings.Cut(ref, ":")
+	switch {
+	case ref == "last":
+		if len(own) == 0 {
+			return "", fmt.Errorf("last: no entries in the last %d days", entryRefLookbackDays)
+		}
+		return own[len(own)-1].ID, nil
+	case sel == "last" && hasArg:
+		for i := len(own) - 1; i >= 0; i-- {
+			if customerMatches(arg, own[i].Customer) || strings.EqualFold(CanonicalCustomer(own[i].Customer), CanonicalCustomer(arg)) {
+				return own[i].ID, nil
+			}
+		}
+		return "", fmt.Errorf("%s: no entries of customer %q in the last %d days", ref, arg, entryRefLookbackDays)
+	case sel == "today" && hasArg:
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("%s: want today:<n> with n >= 1", ref)
+		}
+		y, m, d := now.Date()
+		var today []Entry
+		for _, e := range own {
+			if sy, sm, sd := e.Start.In(now.Location()).Date(); sy == y && sm == m && sd == d {
+				today = append(today, e)
+			}
+		}
+		if n > len(today) {
+			return "", fmt.Errorf("%s: only %d entries today", ref, len(today))
+		}
+		return today[n-1].ID, nil
+	}
+
+	var matches []Entry
+	for _, e := range ents {
+		if e.ID == ref {
+			return ref, nil
+		}
+		if strings.HasPrefix(e.ID, ref) || strings.HasPrefix(strings.TrimPrefix(e.ID, "tt_"), ref) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ref, nil
+	case 1:
+		return matches[0].ID, nil
+	}
+	lines := make([]string, 0, len(matches))
+	for _, e := range matches {
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s", e.ID, e.Start.In(now.Location()).Format("2006-01-02 15:04"), entryLabel(e)))
+	}
+	return "", fmt.Errorf("id prefix %q is ambiguous; it matches:\n%s", ref, strings.Join(lines, "\n"))
+}
+
+// lookupEntry returns the entry with id as currently reconstructed, searching
+// the reference lookback.
+func lookupEntry(id string) (Entry, error) {
+	now := Now().In(parserLocation())
+	ents, err := loadEntriesForRefFn(now.AddDate(0, 0, -entryRefLookbackDays), now)
+	if err != nil {
+		return Entry{}, fmt.Errorf("loading entries: %w", err)
+	}
+	for _, e := range ents {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Entry{}, fmt.Errorf("entry %s not found in the last %d days", id, entryRefLookbackDays)
+}
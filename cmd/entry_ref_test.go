@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveEntryRef(t *testing.T) {
+	t.Cleanup(func() { viper.Set("user", "") })
+	now := time.Date(2025, 10, 14, 15, 0, 0, 0, time.UTC)
+	at := func(day, h int) time.Time { return time.Date(2025, 10, day, h, 0, 0, 0, time.UTC) }
+	ents := []Entry{
+		{ID: "tt_1760432400000000001", Start: at(14, 9), Customer: "Acme"},
+		{ID: "tt_1760432411000000002", Start: at(13, 9), Customer: "Globex"},
+		{ID: "tt_1760500000000000003", Start: at(14, 11), Customer: "Globex"},
+		{ID: "tt_1760500000000000004", Start: at(14, 13), Customer: "Acme", User: "bob"},
+	}
+	viper.Set("user", "alice")
+
+	for _, c := range []struct{ ref, want string }{
+		{"last", "tt_1760500000000000003"}, // bob's later entry is not ours
+		{"last:acme", "tt_1760432400000000001"},
+		{"last:GLOBEX", "tt_1760500000000000003"},
+		{"today:1", "tt_1760432400000000001"},
+		{"today:2", "tt_1760500000000000003"},
+		{"tt_1760432411000000002", "tt_1760432411000000002"},
+		{"tt_17604324000", "tt_1760432400000000001"},
+		{"1760500000000000004", "tt_1760500000000000004"},    // without tt_; other users' entries resolve by id
+		{"tt_1600000000000000000", "tt_1600000000000000000"}, // outside the lookback: passed through
+	} {
+		got, err := resolveEntryRefIn(c.ref, ents, now)
+		if err != nil || got != c.want {
+			t.Errorf("%s = %q, %v; want %q", c.ref, got, err, c.want)
+		}
+	}
+
+	_, err := resolveEntryRefIn("tt_17604324", ents, now)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), "tt_1760432411000000002  2025-10-13 09:00  Globex") {
+		t.Errorf("ambiguous prefix: err = %v", err)
+	}
+	for _, ref := range []string{"today:3", "today:0", "last:initech", ""} {
+		if _, err := resolveEntryRefIn(ref, ents, now); err == nil {
+			t.Errorf("%q resolved; want an error", ref)
+		}
+	}
+}
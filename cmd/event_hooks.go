@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// eventHookTimeout bounds each run of a local event hook.
+const eventHookTimeout = 10 * time.Second
+
+// Local event hooks are executables in hooks.dir (default ~/.tt/hooks) run by
+// the file writer with the hook payload (see hooks_schema.json) on stdin:
+//
+//   - pre-event runs before each event is appended; a non-zero exit vetoes
+//     the write (of the whole batch) and its stderr becomes the error.
+//   - post-event runs after the events were appended, with the effective
+//     entry in the payload; failures are reported but change nothing.
+const (
+	preEventHook  = "pre-event"
+	postEventHook = "post-event"
+)
+
+// eventHookPath returns the path of the named hook, or "" when it does not
+// exist or is not executable.
+func eventHookPath(name string) string {
+	dir := viper.GetString("hooks.dir")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".tt", "hooks")
+	}
+	p := filepath.Join(dir, name)
+	fi, err := os.Stat(p)
+	if err != nil || fi.IsDir() || fi.Mode()&0o111 == 0 {
+		return ""
+	}
+	return p
+}
+
+// runPreEventHooks runs the pre-event hook for each of evs and returns an
+// error for the first veto.
+func runPreEventHooks(evs []Event) error {
+	hook := eventHookPath(preEventHook)
+	if hook == "" {
+		return nil
+	}
+	for _, ev := range evs {
+		if err := runEventHook(hook, newHookPayload(ev, nil)); err != nil {
+			return fmt.Errorf("pre-event hook rejected %s event %s: %w", ev.Type, ev.ID, err)
+		}
+	}
+	return nil
+}
+
+// runPostEventHooks runs the post-event hook for each of evs, warning on
+// stderr when it fails.
+func runPostEventHooks(evs []Event) {
+	hook := eventHookPath(postEventHook)
+	if hook == "" {
+		return
+	}
+	for _, ev := range evs {
+		if err := runEventHook(hook, newHookPayload(ev, effectiveEntryFor(ev))); err != nil {
+			logger.Warn("post-event hook failed", "err", err, "event", ev.ID)
+		}
+	}
+}
+
+// runEventHook runs hook with payload as JSON on stdin. A non-zero exit is
+// an error carrying the hook's stderr.
+func runEventHook(hook string, payload hookPayload) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), eventHookTimeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, hook)
+	c.Stdin = bytes.NewReader(append(b, '\n'))
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestEventHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need a POSIX shell")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	hooks := filepath.Join(home, ".tt", "hooks")
+	if err := os.MkdirAll(hooks, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	seen := filepath.Join(home, "seen.jsonl")
+	script := func(name, body string) {
+		if err := os.WriteFile(filepath.Join(hooks, name), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// no billable starts on weekends
+	script("pre-event", `payload=$(cat)
+case "$payload" in
+  *'"type":"start"'*'"ts":"2025-10-11'*) echo "no billable work on weekends" >&2; exit 1 ;;
+esac`)
+	script("post-event", "cat >> "+seen)
+
+	fw := &fileEventWriter{}
+	sat := time.Date(2025, 10, 11, 9, 0, 0, 0, time.UTC)
+	err := fw.WriteEvents([]Event{
+		{ID: "ok", Type: "note", TS: sat, Note: "fine"},
+		{ID: "veto", Type: "start", TS: sat, Customer: "acme"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "pre-event hook rejected start event veto: no billable work on weekends") {
+		t.Fatalf("veto error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(journalDirFor(sat), "2025-10-11.jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("a vetoed batch must not be written (stat: %v)", err)
+	}
+
+	mon := time.Date(2025, 10, 13, 9, 0, 0, 0, time.UTC)
+	if err := fw.WriteEvent(Event{ID: "e1", Type: "start", TS: mon, Customer: "acme"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	b, err := os.ReadFile(seen)
+	if err != nil {
+		t.Fatalf("post-event hook did not run: %v", err)
+	}
+	if !strings.Contains(string(b), `"event":{"id":"e1","type":"start"`) || !strings.Contains(string(b), `"entry":{"id":"e1"`) {
+		t.Errorf("post-event payload = %s", b)
+	}
+}
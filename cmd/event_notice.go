@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	ui "tt/internal/tui"
+)
+
+// noticeEventWrite tells a running TUI about a completed write by replacing
+// the notice touch file (see ui.TouchFileNotifier). Failures only warn: the
+// TUI still picks the write up through its journal watch.
+func noticeEventWrite(evs []Event) {
+	if len(evs) == 0 {
+		return
+	}
+	last := evs[len(evs)-1]
+	n := ui.EventNotice{
+		ID:      last.ID,
+		Type:    last.Type,
+		Summary: eventNoticeSummary(evs),
+		Count:   len(evs),
+		PID:     os.Getpid(),
+		Written: Now(),
+	}
+	if err := ui.WriteNotice(ui.DefaultNoticePath(), n); err != nil {
+		logger.Warn("event notice", "err", err)
+	}
+}
+
+// eventNoticeSummary describes a write in a few words, e.g. "start
+// acme/web" or "3 events (last: stop)".
+func eventNoticeSummary(evs []Event) string {
+	last := evs[len(evs)-1]
+	parts := []string{last.Type}
+	if last.Customer != "" || last.Project != "" {
+		parts = append(parts, entryLabel(Entry{Customer: last.Customer, Project: last.Project}))
+	}
+	if last.Ref != "" {
+		parts = append(parts, last.Ref)
+	}
+	s := strings.Join(parts, " ")
+	if len(evs) > 1 {
+		return fmt.Sprintf("%d events (last: %s)", len(evs), s)
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	ui "tt/internal/tui"
+)
+
+var (
+	eventsSince  string
+	eventsFollow bool
+	eventsJSON   bool
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Print raw journal events, optionally following new ones as they are appended",
+	Long: `Events prints the raw events of the journal day files since a point in
+time (default: the start of today), in file order. With --follow it keeps
+running and prints events as they are appended, by any tt process, across
+day files. --json prints each event's JSON line unchanged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseEventsSince(eventsSince, Now().In(parserLocation()))
+		if err != nil {
+			return err
+		}
+		w := cmd.OutOrStdout()
+		tail := newEventTail(since)
+		emit := func(line []byte, ev Event) { printEvent(w, line, ev, eventsJSON) }
+		if err := tail.read(journalPaths(since, Now().In(parserLocation())), emit); err != nil {
+			return err
+		}
+		if !eventsFollow {
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		changes := ui.NewFSNotifyJournalWatch("", 100*time.Millisecond).Changes(ctx)
+		// the watcher may be unavailable; polling keeps following anyway
+		poll := time.NewTicker(2 * time.Second)
+		defer poll.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case _, ok := <-changes:
+				if !ok {
+					changes = nil
+				}
+			case <-poll.C:
+			}
+			if err := tail.read(journalPaths(since, Now().In(parserLocation())), emit); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "events since a duration ago (1h, 90m) or a time (default: start of today)")
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "keep running and print events as they are appended")
+	eventsCmd.Flags().BoolVar(&eventsJSON, "json", false, "print the raw JSON line of each event")
+}
+
+// parseEventsSince resolves --since: a duration before now, a time, or the
+// start of now's day when empty.
+func parseEventsSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	if d, err := parseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := mustParseTimeFlexible(s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: want a duration like 1h or a time", s)
+	}
+	return t, nil
+}
+
+// eventTail reads the complete lines appended to day files since the last
+// read, remembering an offset per file.
+type eventTail struct {
+	since   time.Time
+	offsets map[string]int64
+}
+
+func newEventTail(since time.Time) *eventTail {
+	return &eventTail{since: since, offsets: map[string]int64{}}
+}
+
+// read calls fn for each new event at or after since in paths, in file
+// order. Missing files are skipped; a partially written last line is left
+// for the next read. Lines that do not decode are passed with a zero Event.
+func (t *eventTail) read(paths []string, fn func(line []byte, ev Event)) error {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		off := t.offsets[path]
+		if _, err := f.Seek(off, io.SeekStart); err != nil {
+			f.Close()
+			return err
+		}
+		r := bufio.NewReader(f)
+		for {
+			line, err := r.ReadBytes('\n')
+			if err != nil {
+				break // EOF, possibly mid-line: re-read that line next time
+			}
+			off += int64(len(line))
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			var ev Event
+			if json.Unmarshal(line, &ev) == nil && ev.TS.Before(t.since) {
+				continue
+			}
+			fn(line, ev)
+		}
+		f.Close()
+		t.offsets[path] = off
+	}
+	return nil
+}
+
+// printEvent writes line unchanged with asJSON, else a one-line summary.
+func printEvent(w io.Writer, line []byte, ev Event, asJSON bool) {
+	if asJSON || ev.ID == "" {
+		fmt.Fprintf(w, "%s\n", line)
+		return
+	}
+	fmt.Fprintln(w, formatEvent(ev))
+}
+
+// formatEvent summarizes an event: time, type and ID, then its non-empty
+// fields.
+func formatEvent(ev Event) string {
+	parts := []string{ev.TS.In(parserLocation()).Format("2006-01-02 15:04:05"), fmt.Sprintf("%-6s", ev.Type), ev.ID}
+	if ev.Customer != "" || ev.Project != "" {
+		parts = append(parts, entryLabel(Entry{Customer: ev.Customer, Project: ev.Project}))
+	}
+	if ev.Activity != "" {
+		parts = append(parts, ev.Activity)
+	}
+	if ev.Billable != nil {
+		if *ev.Billable {
+			parts = append(parts, "billable")
+		} else {
+			parts = append(parts, "non-billable")
+		}
+	}
+	if ev.Ref != "" {
+		parts = append(parts, "ref="+ev.Ref)
+	}
+	keys := make([]string, 0, len(ev.Meta))
+	for k := range ev.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"="+ev.Meta[k])
+	}
+	if len(ev.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(ev.Tags, ","))
+	}
+	if ev.Note != "" {
+		parts = append(parts, fmt.Sprintf("%q", ev.Note))
+	}
+	if ev.User != "" {
+		parts = append(parts, "by "+ev.User)
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestEventTail(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	dir := t.TempDir()
+	day1 := filepath.Join(dir, "2025-10-13.jsonl")
+	day2 := filepath.Join(dir, "2025-10-14.jsonl")
+	write := func(path, s string) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(day1, `{"id":"old","type":"start","ts":"2025-10-13T08:00:00Z"}`+"\n"+
+		`{"id":"e1","type":"start","ts":"2025-10-13T23:30:00Z","customer":"acme","project":"web","billable":true,"note":"late"}`+"\n")
+	write(day2, `{"id":"e2","type":"stop","ts":"2025-10-14T00:15:00Z","user":"alice"}`+"\n"+`{"id":"e3","ty`)
+
+	tail := newEventTail(time.Date(2025, 10, 13, 23, 0, 0, 0, time.UTC))
+	var got []string
+	collect := func(line []byte, ev Event) { got = append(got, ev.ID) }
+	paths := []string{day1, day2, filepath.Join(dir, "missing.jsonl")}
+	if err := tail.read(paths, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "e1 e2" {
+		t.Fatalf("first read = %v", got)
+	}
+
+	// the partial line completes and a new event follows
+	got = nil
+	write(day2, `pe":"amend","ts":"2025-10-14T00:20:00Z","ref":"e1","meta":{"end":"2025-10-14T00:10:00Z"}}`+"\n")
+	if err := tail.read(paths, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "e3" {
+		t.Fatalf("second read = %v", got)
+	}
+
+	var buf bytes.Buffer
+	tail = newEventTail(time.Time{})
+	if err := tail.read([]string{day1, day2}, func(line []byte, ev Event) { printEvent(&buf, line, ev, false) }); err != nil {
+		t.Fatal(err)
+	}
+	want := `2025-10-13 08:00:00  start   old
+2025-10-13 23:30:00  start   e1  acme/web  billable  "late"
+2025-10-14 00:15:00  stop    e2  by alice
+2025-10-14 00:20:00  amend   e3  ref=e1  end=2025-10-14T00:10:00Z
+`
+	if buf.String() != want {
+		t.Errorf("pretty:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestParseEventsSince(t *testing.T) {
+	now := time.Date(2025, 10, 14, 15, 30, 0, 0, time.UTC)
+	for s, want := range map[string]string{
+		"":    "2025-10-14 00:00",
+		"1h":  "2025-10-14 14:30",
+		"90m": "2025-10-14 14:00",
+	} {
+		got, err := parseEventsSince(s, now)
+		if err != nil || got.Format("2006-01-02 15:04") != want {
+			t.Errorf("%q = %v, %v; want %s", s, got, err, want)
+		}
+	}
+	if _, err := parseEventsSince("whenever", now); err == nil {
+		t.Error("expected an error for an invalid --since")
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+// Expenses are reimbursable costs (travel, licences, hardware) recorded in
+// the journal as "expense" events next to the time entries. They are not
+// entries: time reports ignore them, while tt report expenses lists them and
+// tt push invoice-ninja adds a customer's billable expenses to the invoice.
+// The amount is kept in the event's ref ("49.90 EUR") so the hash chain
+// covers it.
+
+var (
+	expCustomer string
+	expProject  string
+	expDate     string
+	expBillable bool
+	expTags     []string
+)
+
+var expenseCmd = &cobra.Command{
+	Use:   "expense",
+	Short: "Record reimbursable expenses next to tracked time",
+}
+
+var expenseAddCmd = &cobra.Command{
+	Use:   "add <amount> <description>",
+	Short: "Record an expense, e.g. tt expense add 49.90EUR \"train ticket\" --customer acme",
+	Long: `Record an expense. The amount may carry an ISO currency code before or
+after it (49.90EUR, "49,90 EUR", "EUR 49.90"); without one the billing
+currency (rates.currency, default EUR) is used. --date books it on an
+earlier day (at 12:00).`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, currency, err := journal.ParseAmount(args[0])
+		if err != nil {
+			return err
+		}
+		if currency == "" {
+			currency = rateCurrency()
+		}
+		ts := Now()
+		if expDate != "" {
+			d, err := time.ParseInLocation("2006-01-02", expDate, parserLocation())
+			if err != nil {
+				return fmt.Errorf("invalid --date %q: want YYYY-MM-DD", expDate)
+			}
+			ts = d.Add(12 * time.Hour)
+		}
+		billable := expBillable
+		if !cmd.Flags().Changed("billable") {
+			billable = billableFor(expCustomer, expProject)
+		}
+		ev := NewExpenseEvent(IDGen(), expCustomer, expProject, boolPtr(billable), amount, currency, strings.Join(args[1:], " "), expTags, ts)
+		if err := writeEvent(ev); err != nil {
+			return err
+		}
+		label := entryLabel(Entry{Customer: ev.Customer, Project: ev.Project})
+		if label == "" {
+			label = "(no customer)"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Expense %s: %s %s %q\n", ev.ID, ev.Ref, label, ev.Note)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(expenseCmd)
+	expenseCmd.AddCommand(expenseAddCmd)
+	expenseAddCmd.Flags().StringVarP(&expCustomer, "customer", "c", "", "customer to bill")
+	expenseAddCmd.Flags().StringVarP(&expProject, "project", "p", "", "project")
+	expenseAddCmd.Flags().StringVar(&expDate, "date", "", "day of the expense, YYYY-MM-DD (default: today)")
+	expenseAddCmd.Flags().BoolVarP(&expBillable, "billable", "b", true, "reimbursable by the customer (default: billable_defaults rule, else true)")
+	expenseAddCmd.Flags().StringSliceVarP(&expTags, "tag", "t", []string{}, "tag(s)")
+	_ = expenseAddCmd.RegisterFlagCompletionFunc("tag", tagFlagCompletion)
+}
+
+// NewExpenseEvent returns the event recording an expense of amount in
+// currency on ts.
+func NewExpenseEvent(id, customer, project string, billable *bool, amount float64, currency, description string, tags []string, ts time.Time) Event {
+	return Event{
+		ID:       id,
+		Type:     "expense",
+		TS:       ts,
+		Customer: customer,
+		Project:  project,
+		Billable: billable,
+		Note:     description,
+		Tags:     tags,
+		Ref:      journal.FormatAmount(amount, currency),
+	}
+}
+
+// loadExpenses returns the expenses dated from..to (whole days), ordered by
+// date. Missing day files are skipped.
+func loadExpenses(from, to time.Time) ([]journal.Expense, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, to.Location())
+	p := journal.NewParser(viper.GetString("timezone"))
+	var out []journal.Expense
+	for _, path := range journalPaths(from, to) {
+		exps, err := p.ParseExpenseFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		for _, x := range exps {
+			if !x.Date.Before(from) && !x.Date.After(to) {
+				out = append(out, x)
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+func TestExpenseAddAndReport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		expCustomer, expProject, expDate, expBillable = "", "", "", true
+	})
+
+	var out bytes.Buffer
+	expenseAddCmd.SetOut(&out)
+	expCustomer, expProject, expDate, expBillable = "acme", "portal", "2025-10-06", true
+	if err := expenseAddCmd.RunE(expenseAddCmd, []string{"49.90EUR", "train", "ticket"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `49.90 EUR acme/portal "train ticket"`) {
+		t.Fatalf("output = %q", out.String())
+	}
+	expProject, expDate = "", "2025-10-07"
+	if err := expenseAddCmd.RunE(expenseAddCmd, []string{"EUR 12,5", "parking"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := expenseAddCmd.RunE(expenseAddCmd, []string{"twelve", "x"}); err == nil {
+		t.Fatal("invalid amount accepted")
+	}
+
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	exps, err := loadExpenses(day, day.AddDate(0, 0, 6))
+	if err != nil || len(exps) != 2 {
+		t.Fatalf("loadExpenses = %+v, %v", exps, err)
+	}
+	if exps[0].Date != day.Add(12*time.Hour) || exps[1].Amount != 12.5 {
+		t.Fatalf("expenses = %+v", exps)
+	}
+	if ents, _ := loadEntries(day, day.AddDate(0, 0, 6)); len(ents) != 0 {
+		t.Fatalf("expenses became entries: %+v", ents)
+	}
+
+	out.Reset()
+	printExpenseReport(&out, exps, "en")
+	for _, want := range []string{"train ticket", "€49.90", "€62.40"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInvoiceExpenseItems(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	exps := []journal.Expense{
+		{ID: "x1", Date: at, Amount: 49.9, Currency: "EUR", Customer: "Acme", Description: "train ticket", Billable: true},
+		{ID: "x2", Date: at, Amount: 12, Currency: "EUR", Customer: "acme", Description: "lunch"},
+		{ID: "x3", Date: at, Amount: 80, Currency: "EUR", Customer: "globex", Billable: true},
+	}
+	items, err := invoiceExpenseItems(exps, "acme")
+	if err != nil || len(items) != 1 {
+		t.Fatalf("items = %+v, %v", items, err)
+	}
+	if it := items[0]; !it.Expense || it.Amount() != 49.9 || it.Notes != "2025-10-06 — expense: train ticket" {
+		t.Fatalf("item = %+v", it)
+	}
+	exps = append(exps, journal.Expense{ID: "x4", Date: at, Amount: 30, Currency: "CHF", Customer: "acme", Billable: true})
+	if _, err := invoiceExpenseItems(exps, "acme"); err == nil || !strings.Contains(err.Error(), "x4 (30.00 CHF)") {
+		t.Fatalf("currency err = %v", err)
+	}
+}
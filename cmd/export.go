@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportToday bool
+	exportWeek  bool
+	exportRange string
+	exportOut   string
+)
+
+// exportCmd groups exporters that convert journal entries into formats other
+// tools can import. Subcommands share the range and output flags.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export entries for other tools (csv, toggl-csv, clockify-csv, org, daily-note, tempo)",
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.PersistentFlags().BoolVar(&exportToday, "today", false, "today only")
+	exportCmd.PersistentFlags().BoolVar(&exportWeek, "week", false, "this week (Mon..Sun)")
+	exportCmd.PersistentFlags().StringVar(&exportRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	exportCmd.PersistentFlags().StringVarP(&exportOut, "out", "o", "", "write to file instead of stdout")
+}
+
+// exportEntries loads the finished entries selected by the export range
+// flags, pseudonymized with --anonymize.
+func exportEntries(cmd *cobra.Command) ([]Entry, time.Time, time.Time, error) {
+	from, to := parseRangeFlags(exportToday, exportWeek, exportRange)
+	noteUsageRange(cmd, from, to)
+	ents, err := finishedEntries(from, to)
+	if err != nil {
+		return nil, from, to, err
+	}
+	return ents, from, to, anonymizeExport(ents)
+}
+
+// finishedEntries loads entries for from..to and drops running ones: exports
+// and pushes to other systems need a fixed duration.
+func finishedEntries(from, to time.Time) ([]Entry, error) {
+	ents, err := loadEntries(from, to)
+	if err != nil {
+		return nil, err
+	}
+	out := ents[:0]
+	for _, e := range ents {
+		if e.End != nil {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// exportWriter opens --out (or returns stdout). The returned close func must
+// be called once writing is done; its error reports failed writes to disk.
+func exportWriter(cmd *cobra.Command) (io.Writer, func() error, error) {
+	if exportOut == "" || exportOut == "-" {
+		return cmd.OutOrStdout(), func() error { return nil }, nil
+	}
+	f, err := os.Create(exportOut)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/journal"
+)
+
+// tt export --anonymize replaces customers, projects, activities, notes, tags
+// and users with pseudonyms, so journals can be shared in bug reports. On
+// its own it copies the raw journal (all events, with timestamps, IDs and
+// correction chains intact, hashes recomputed) into --out; with a subcommand
+// it pseudonymizes the exported entries.
+//
+// Pseudonyms are an HMAC of the original value: the same value always maps to
+// the same pseudonym within an export. Set export.anonymize_key (a secret,
+// keyring: references work) to keep them stable across exports; without it a
+// random key is used per run.
+
+var exportAnonymize bool
+
+func init() {
+	exportCmd.PersistentFlags().BoolVar(&exportAnonymize, "anonymize", false, "replace customers, projects, activities, notes, tags and users with stable pseudonyms; without a subcommand, copy the raw journal to --out DIR")
+	exportCmd.Args = cobra.NoArgs
+	exportCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !exportAnonymize {
+			return cmd.Help()
+		}
+		if exportOut == "" || exportOut == "-" {
+			return errors.New("tt export --anonymize writes a journal directory; pass --out DIR")
+		}
+		a, err := newAnonymizer()
+		if err != nil {
+			return err
+		}
+		from, to, ok := journalSpan()
+		if exportToday || exportWeek || exportRange != "" {
+			from, to = parseRangeFlags(exportToday, exportWeek, exportRange)
+			noteUsageRange(cmd, from, to)
+			ok = true
+		}
+		if !ok {
+			return errors.New("the journal is empty")
+		}
+		return exportAnonymizedJournal(cmd.ErrOrStderr(), expandHome(exportOut), journalPaths(from, to), a)
+	}
+}
+
+// anonymizer maps values to pseudonyms.
+type anonymizer struct {
+	key []byte
+}
+
+// newAnonymizer keys pseudonyms with export.anonymize_key, or a random key.
+func newAnonymizer() (anonymizer, error) {
+	if k := configSecret("export.anonymize_key"); k != "" {
+		return anonymizer{key: []byte(k)}, nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return anonymizer{}, err
+	}
+	return anonymizer{key: key}, nil
+}
+
+// name returns the pseudonym of s as "<kind>-<8 hex digits>". Blank values
+// stay as they are, so empty notes or projects remain empty.
+func (a anonymizer) name(kind, s string) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+	m := hmac.New(sha256.New, a.key)
+	m.Write([]byte(kind))
+	m.Write([]byte{0})
+	m.Write([]byte(s))
+	return kind + "-" + hex.EncodeToString(m.Sum(nil)[:4])
+}
+
+func (a anonymizer) names(kind string, in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = a.name(kind, s)
+	}
+	return out
+}
+
+// structuralMeta are meta keys whose values are times, IDs or periods the
+// parser and commands rely on; they are kept.
+var structuralMeta = map[string]bool{
+	"start": true, "end": true, "split_at": true, "target": true, "targets": true,
+	"auto_stop": true, "period": true, "from": true, "to": true, "seconds": true,
+}
+
+// event pseudonymizes ev's names and free text. Type, timestamps, IDs, refs
+// and structural meta are kept; the hash is left to the caller.
+func (a anonymizer) event(ev Event) Event {
+	ev.User = a.name("user", ev.User)
+	ev.Customer = a.name("customer", ev.Customer)
+	ev.Project = a.name("project", ev.Project)
+	ev.Activity = a.name("activity", ev.Activity)
+	ev.Note = a.name("note", ev.Note)
+	ev.Tags = a.names("tag", ev.Tags)
+	if ev.Meta != nil {
+		meta := make(map[string]string, len(ev.Meta))
+		for k, v := range ev.Meta {
+			switch {
+			case structuralMeta[k]:
+				meta[k] = v
+			case strings.HasSuffix(k, "note"):
+				meta[k] = a.name("note", v)
+			default:
+				meta[k] = a.name("value", v)
+			}
+		}
+		ev.Meta = meta
+	}
+	return ev
+}
+
+// entry pseudonymizes an exported entry like event does; note field keys
+// are kept, their values replaced.
+func (a anonymizer) entry(e Entry) Entry {
+	e.User = a.name("user", e.User)
+	e.Customer = a.name("customer", e.Customer)
+	e.Project = a.name("project", e.Project)
+	e.Activity = a.name("activity", e.Activity)
+	e.Notes = a.names("note", e.Notes)
+	e.Tags = a.names("tag", e.Tags)
+	if e.Fields != nil {
+		fields := make(map[string]string, len(e.Fields))
+		for k, v := range e.Fields {
+			fields[k] = a.name("value", v)
+		}
+		e.Fields = fields
+	}
+	return e
+}
+
+// anonymizeExport pseudonymizes ents in place when --anonymize is set.
+func anonymizeExport(ents []Entry) error {
+	if !exportAnonymize {
+		return nil
+	}
+	a, err := newAnonymizer()
+	if err != nil {
+		return err
+	}
+	for i := range ents {
+		ents[i] = a.entry(ents[i])
+	}
+	return nil
+}
+
+// exportAnonymizedJournal writes the pseudonymized events of the day files
+// in paths to dir, in the journal layout (YYYY/MM/YYYY-MM-DD.jsonl) with
+// recomputed hash chains and anchors, so tt and tt audit verify accept it.
+// Lines that do not decode cannot be anonymized and are left out. dir must
+// not exist yet or be empty.
+func exportAnonymizedJournal(w io.Writer, dir string, paths []string, a anonymizer) error {
+	if des, err := os.ReadDir(dir); err == nil && len(des) > 0 {
+		return fmt.Errorf("--out %s is not empty", dir)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	var days, events, skipped int
+	var lines bytes.Buffer
+	for _, path := range paths {
+		lines.Reset()
+		enc := json.NewEncoder(&lines)
+		prev, n := "", 0
+		var encErr error
+		err := newEventTail(time.Time{}).read([]string{path}, func(_ []byte, ev Event) {
+			if ev.ID == "" {
+				skipped++
+				return
+			}
+			ev = a.event(ev)
+			ev.PrevHash = prev
+			ev.Hash = journal.EventHash(journal.Event(ev))
+			prev = ev.Hash
+			if err := enc.Encode(ev); err != nil && encErr == nil {
+				encErr = err
+			}
+			n++
+		})
+		if err == nil {
+			err = encErr
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+		day := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+		out := filepath.Join(dir, filepath.Base(filepath.Dir(filepath.Dir(path))), filepath.Base(filepath.Dir(path)), day+".jsonl")
+		if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, lines.Bytes(), 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out+".hash", []byte(prev), 0o644); err != nil {
+			return err
+		}
+		days++
+		events += n
+	}
+	fmt.Fprintf(w, "Wrote %d anonymized events in %d day files to %s.\n", events, days, dir)
+	if skipped > 0 {
+		fmt.Fprintf(w, "Left out %d lines that are not valid events.\n", skipped)
+	}
+	return nil
+}
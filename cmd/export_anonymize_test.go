@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+func TestExportAnonymizedJournal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Set("timezone", "UTC")
+	viper.Set("export.anonymize_key", "k1")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("export.anonymize_key", "")
+	})
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 6, h, m, 0, 0, time.UTC) }
+
+	evs := []Event{
+		NewStartEvent("s1", "Acme Corp", "Secret Launch", "dev", boolPtr(true), "call with Jane", []string{"nda"}, at(9, 0)),
+		NewStopEvent("x1", at(11, 0)),
+		NewStartEvent("s2", "Acme Corp", "Secret Launch", "dev", boolPtr(true), "", nil, at(11, 0)),
+		NewStopEvent("x2", at(12, 0)),
+		{ID: "a1", Type: "amend", TS: at(13, 0), Ref: "s1", Note: "budget talk", Meta: map[string]string{"end": at(10, 30).Format(time.RFC3339)}},
+		{ID: "m1", Type: "merge", TS: at(13, 5), Meta: map[string]string{"targets": "s1,s2"}},
+	}
+	if err := writeEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(home, ".tt", "journal", "2025", "10", "2025-10-06.jsonl")
+	// an undecodable line cannot be anonymized and is left out
+	f, _ := os.OpenFile(src, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString("{broken Acme Corp\n")
+	f.Close()
+
+	a, err := newAnonymizer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "anon")
+	var log bytes.Buffer
+	if err := exportAnonymizedJournal(&log, out, []string{src}, a); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(log.String(), "Wrote 6 anonymized events in 1 day files") || !strings.Contains(log.String(), "Left out 1 lines") {
+		t.Fatalf("summary = %q", log.String())
+	}
+
+	dst := filepath.Join(out, "2025", "10", "2025-10-06.jsonl")
+	raw, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"Acme", "Secret", "Jane", "budget", "nda", `"dev"`} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("anonymized journal still contains %q:\n%s", secret, raw)
+		}
+	}
+	var verify bytes.Buffer
+	if !verifyDay(dst, &verify) {
+		t.Fatalf("anonymized journal does not verify:\n%s", verify.String())
+	}
+
+	// the correction chain survives: one merged entry 09:00-12:00 with both notes
+	want, err := journal.NewParser("UTC").ParseFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := journal.NewParser("UTC").ParseFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || len(want) != 1 || !got[0].Start.Equal(want[0].Start) || !got[0].End.Equal(*want[0].End) || len(got[0].Notes) != len(want[0].Notes) {
+		t.Fatalf("entries = %+v; want the structure of %+v", got, want)
+	}
+	if got[0].Customer != a.name("customer", "Acme Corp") || !strings.HasPrefix(got[0].Project, "project-") {
+		t.Fatalf("pseudonyms = %q / %q", got[0].Customer, got[0].Project)
+	}
+
+	// a configured key keeps pseudonyms stable across exports; an existing
+	// export is not overwritten
+	b, _ := newAnonymizer()
+	if b.name("customer", "Acme Corp") != got[0].Customer {
+		t.Error("pseudonym changed between runs with the same key")
+	}
+	if err := exportAnonymizedJournal(&log, out, []string{src}, a); err == nil {
+		t.Error("exported into a non-empty directory")
+	}
+}
+
+func TestAnonymizeExportEntries(t *testing.T) {
+	exportAnonymize = true
+	t.Cleanup(func() { exportAnonymize = false })
+	ents := []Entry{
+		{ID: "e1", Customer: "Acme", Project: "Web", Notes: []string{"ticket=ABC-1", ""}, Tags: []string{"nda"}, Fields: map[string]string{"ticket": "ABC-1"}},
+		{ID: "e2", Customer: "Acme", Project: ""},
+	}
+	if err := anonymizeExport(ents); err != nil {
+		t.Fatal(err)
+	}
+	if ents[0].Customer != ents[1].Customer || !strings.HasPrefix(ents[0].Customer, "customer-") || ents[1].Project != "" {
+		t.Fatalf("customers = %q, %q; project %q", ents[0].Customer, ents[1].Customer, ents[1].Project)
+	}
+	if ents[0].ID != "e1" || ents[0].Notes[1] != "" || strings.Contains(ents[0].Notes[0], "ABC") || !strings.HasPrefix(ents[0].Fields["ticket"], "value-") || ents[0].Tags[0] == "nda" {
+		t.Fatalf("entry = %+v", ents[0])
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	exportCSVColumns   string
+	exportCSVDelimiter string
+	exportCSVPreset    string
+	exportCSVNoHeader  bool
+	exportCSVSave      string
+)
+
+const defaultCSVColumns = "date,start,end,customer,project,activity,hours_decimal,notes"
+
+// exportCSVCmd writes entries with a caller-chosen column layout. Layouts can
+// be saved as presets in the config:
+//
+//	export:
+//	  csv:
+//	    presets:
+//	      acme:
+//	        columns: [date, customer, project, hours_decimal, notes]
+//	        delimiter: ";"
+//
+// Explicit --columns/--delimiter flags override the preset, and --save-preset
+// stores the effective layout under a name for later runs.
+var exportCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Export entries as CSV with configurable columns",
+	Long:  "Export entries as CSV. Columns: " + strings.Join(csvColumnNames(), ", ") + ", and field:<key> for a key=value note field.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		columns, delim := defaultCSVColumns, ","
+		if exportCSVPreset != "" {
+			p, err := loadCSVPreset(exportCSVPreset)
+			if err != nil {
+				return err
+			}
+			if p.Columns != "" {
+				columns = p.Columns
+			}
+			if p.Delimiter != "" {
+				delim = p.Delimiter
+			}
+		}
+		if cmd.Flags().Changed("columns") {
+			columns = exportCSVColumns
+		}
+		if cmd.Flags().Changed("delimiter") {
+			delim = exportCSVDelimiter
+		}
+
+		cols, err := parseCSVColumns(columns)
+		if err != nil {
+			return err
+		}
+		comma, err := parseCSVDelimiter(delim)
+		if err != nil {
+			return err
+		}
+		if exportCSVSave != "" {
+			if err := saveCSVPreset(exportCSVSave, cols, delim); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Saved csv preset %q.\n", strings.ToLower(exportCSVSave))
+		}
+		ents, _, _, err := exportEntries(cmd)
+		if err != nil {
+			return err
+		}
+		w, closeOut, err := exportWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if err := writeColumnCSV(w, ents, cols, comma, !exportCSVNoHeader); err != nil {
+			closeOut()
+			return err
+		}
+		return closeOut()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportCSVCmd)
+	exportCSVCmd.Flags().StringVar(&exportCSVColumns, "columns", defaultCSVColumns, "comma-separated column list")
+	exportCSVCmd.Flags().StringVar(&exportCSVDelimiter, "delimiter", ",", "field delimiter (single character, or \\t for tab)")
+	exportCSVCmd.Flags().StringVar(&exportCSVPreset, "preset", "", "use columns/delimiter saved under export.csv.presets.<name>")
+	exportCSVCmd.Flags().StringVar(&exportCSVSave, "save-preset", "", "save the effective columns/delimiter as preset <name>")
+	exportCSVCmd.Flags().BoolVar(&exportCSVNoHeader, "no-header", false, "omit the header row")
+}
+
+// csvColumns maps column names to value extractors. Times use the configured
+// timezone; rounded columns apply the rounding config.
+var csvColumns = map[string]func(Entry) string{
+	"id":       func(e Entry) string { return e.ID },
+	"date":     func(e Entry) string { return e.Start.In(parserLocation()).Format("2006-01-02") },
+	"start":    func(e Entry) string { return e.Start.In(parserLocation()).Format("15:04") },
+	"end":      func(e Entry) string { return e.End.In(parserLocation()).Format("15:04") },
+	"customer": func(e Entry) string { return e.Customer },
+	"project":  func(e Entry) string { return e.Project },
+	"activity": func(e Entry) string { return e.Activity },
+	"billable": func(e Entry) string { return strconv.FormatBool(e.Billable) },
+	"minutes":  func(e Entry) string { return strconv.Itoa(durationMinutes(e)) },
+	"seconds":  func(e Entry) string { return strconv.FormatInt(entrySeconds(e), 10) },
+	"duration": func(e Entry) string {
+		m := durationMinutes(e)
+		return fmt.Sprintf("%d:%02d", m/60, m%60)
+	},
+	"hours_decimal": func(e Entry) string {
+		return strconv.FormatFloat(hoursOf(entrySeconds(e)), 'f', 2, 64)
+	},
+	"minutes_rounded": func(e Entry) string {
+		return strconv.FormatInt(customerPolicy(getRounding().Policy(), e.Customer).Round(entrySeconds(e))/60, 10)
+	},
+	"hours_rounded": func(e Entry) string {
+		return strconv.FormatFloat(hoursOf(customerPolicy(getRounding().Policy(), e.Customer).Round(entrySeconds(e))), 'f', 2, 64)
+	},
+	"notes":  exportDescription,
+	"tags":   func(e Entry) string { return strings.Join(e.Tags, ", ") },
+	"fields": func(e Entry) string { return fieldsString(e.Fields) },
+}
+
+// csvFieldPrefix selects a single note field as a column, e.g. field:ticket.
+const csvFieldPrefix = "field:"
+
+func csvValue(col string, e Entry) string {
+	if key, ok := strings.CutPrefix(col, csvFieldPrefix); ok {
+		return e.Fields[key]
+	}
+	return csvColumns[col](e)
+}
+
+func csvColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for n := range csvColumns {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseCSVColumns(s string) ([]string, error) {
+	var cols []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if key, ok := strings.CutPrefix(c, csvFieldPrefix); ok {
+			if key == "" {
+				return nil, fmt.Errorf("column %q: want %s<key>", c, csvFieldPrefix)
+			}
+		} else if _, ok := csvColumns[c]; !ok {
+			return nil, fmt.Errorf("unknown column %q (valid: %s, %s<key>)", c, strings.Join(csvColumnNames(), ", "), csvFieldPrefix)
+		}
+		cols = append(cols, c)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return cols, nil
+}
+
+func parseCSVDelimiter(s string) (rune, error) {
+	if s == `\t` || s == "tab" {
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid --delimiter %q: want a single character", s)
+	}
+	return r, nil
+}
+
+type csvPreset struct {
+	Columns   string
+	Delimiter string
+}
+
+// loadCSVPreset reads export.csv.presets.<name>. columns may be a YAML list
+// or a comma-separated string.
+func loadCSVPreset(name string) (csvPreset, error) {
+	key := "export.csv.presets." + strings.ToLower(name)
+	if !viper.IsSet(key) {
+		return csvPreset{}, fmt.Errorf("csv preset %q not found under export.csv.presets", name)
+	}
+	return csvPreset{
+		Columns:   strings.Join(viper.GetStringSlice(key+".columns"), ","),
+		Delimiter: viper.GetString(key + ".delimiter"),
+	}, nil
+}
+
+func saveCSVPreset(name string, cols []string, delim string) error {
+	key := "export.csv.presets." + strings.ToLower(name)
+	viper.Set(key+".columns", cols)
+	viper.Set(key+".delimiter", delim)
+	return saveViperConfig()
+}
+
+func writeColumnCSV(w io.Writer, ents []Entry, cols []string, comma rune, header bool) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if header {
+		cw.Write(cols)
+	}
+	row := make([]string, len(cols))
+	for _, e := range ents {
+		for i, c := range cols {
+			row[i] = csvValue(c, e)
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
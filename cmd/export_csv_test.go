@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriteColumnCSV(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("rounding.quantum_min", 15)
+	viper.Set("rounding.strategy", "up")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("rounding.quantum_min", nil)
+		viper.Set("rounding.strategy", nil)
+	})
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(100 * time.Minute)
+	ents := []Entry{{ID: "e1", Start: start, End: &end, Customer: "Acme", Project: "Portal", Notes: []string{"a; b", "c"},
+		Fields: map[string]string{"ticket": "ABC-1", "location": "onsite"}}}
+
+	cases := []struct {
+		name    string
+		columns string
+		delim   string
+		header  bool
+		want    string
+	}{
+		{"semicolon", "date,start,end,customer,project,hours_decimal,notes", ";", true,
+			"date;start;end;customer;project;hours_decimal;notes\n2025-10-06;09:00;10:40;Acme;Portal;1.67;\"a; b; c\"\n"},
+		{"tab no header", "id, duration ,minutes_rounded,hours_rounded", `\t`, false,
+			"e1\t1:40\t105\t1.75\n"},
+		{"note fields", "id,field:ticket,field:missing,fields", ",", true,
+			"id,field:ticket,field:missing,fields\ne1,ABC-1,,location=onsite; ticket=ABC-1\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cols, err := parseCSVColumns(tc.columns)
+			if err != nil {
+				t.Fatalf("parseCSVColumns: %v", err)
+			}
+			comma, err := parseCSVDelimiter(tc.delim)
+			if err != nil {
+				t.Fatalf("parseCSVDelimiter: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeColumnCSV(&buf, ents, cols, comma, tc.header); err != nil {
+				t.Fatalf("writeColumnCSV: %v", err)
+			}
+			if buf.String() != tc.want {
+				t.Fatalf("got %q, want %q", buf.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVColumnsAndDelimiterErrors(t *testing.T) {
+	if _, err := parseCSVColumns("date,bogus"); err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+	if _, err := parseCSVColumns(" , "); err == nil {
+		t.Fatal("expected error for empty column list")
+	}
+	for _, d := range []string{"", ";;", `"`} {
+		if _, err := parseCSVDelimiter(d); err == nil {
+			t.Fatalf("expected error for delimiter %q", d)
+		}
+	}
+}
+
+func TestCSVPresetRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Cleanup(func() { viper.Set("export.csv.presets.client", nil) })
+
+	if _, err := loadCSVPreset("client"); err == nil {
+		t.Fatal("expected error for missing preset")
+	}
+	if err := saveCSVPreset("Client", []string{"date", "hours_decimal"}, ";"); err != nil {
+		t.Fatalf("saveCSVPreset: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".tt", "config.yaml")); err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	p, err := loadCSVPreset("client")
+	if err != nil {
+		t.Fatalf("loadCSVPreset: %v", err)
+	}
+	if p.Columns != "date,hours_decimal" || p.Delimiter != ";" {
+		t.Fatalf("unexpected preset: %+v", p)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	dailyNoteDate     string
+	dailyNoteTemplate string
+	dailyNoteMarker   string
+)
+
+// exportDailyNoteCmd renders one day as Markdown for journaling tools such as
+// Obsidian. With --marker the note is kept inside a marked block of an
+// existing file, so re-running updates the block instead of appending again.
+var exportDailyNoteCmd = &cobra.Command{
+	Use:   "daily-note",
+	Short: "Export a day's entries and notes as a Markdown daily note",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day := nowLocal()
+		if dailyNoteDate != "" && dailyNoteDate != "today" {
+			t, err := time.ParseInLocation("2006-01-02", dailyNoteDate, parserLocation())
+			if err != nil {
+				return fmt.Errorf("invalid --date %q: want YYYY-MM-DD or today", dailyNoteDate)
+			}
+			day = t
+		}
+		tmplPath := dailyNoteTemplate
+		if tmplPath == "" {
+			tmplPath = viper.GetString("export.daily_note.template")
+		}
+		marker := dailyNoteMarker
+		if marker == "" {
+			marker = viper.GetString("export.daily_note.marker")
+		}
+
+		ents, err := loadEntries(day, day)
+		if err != nil {
+			return err
+		}
+		if err := anonymizeExport(ents); err != nil {
+			return err
+		}
+		note, err := renderDailyNote(day, ents, Now(), expandHome(tmplPath))
+		if err != nil {
+			return err
+		}
+
+		out := expandHome(exportOut)
+		if out == "" || out == "-" {
+			_, err := cmd.OutOrStdout().Write(note)
+			return err
+		}
+		if marker != "" {
+			return upsertMarkedBlock(out, marker, note)
+		}
+		if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(out, note, 0o644)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportDailyNoteCmd)
+	exportDailyNoteCmd.Flags().StringVar(&dailyNoteDate, "date", "today", "day to export (YYYY-MM-DD or today)")
+	exportDailyNoteCmd.Flags().StringVar(&dailyNoteTemplate, "template", "", "Go text/template file (config: export.daily_note.template)")
+	exportDailyNoteCmd.Flags().StringVar(&dailyNoteMarker, "marker", "", "update the <!-- tt:NAME --> block in --out instead of overwriting it (config: export.daily_note.marker)")
+}
+
+const defaultDailyNoteTemplate = `## Time tracking — {{.Date.Format "Mon 2006-01-02"}}
+
+Total: {{.Total}}
+{{range .Projects}}- {{.Label}}: {{hhmm .Dur}}
+{{end}}
+{{range .Entries}}- {{.Start.Format "15:04"}}–{{.End.Format "15:04"}} {{.Customer}}{{if .Project}} / {{.Project}}{{end}}{{if .Activity}} ({{.Activity}}){{end}} — {{.Duration}}
+{{range .Notes}}  - {{.}}
+{{end}}{{end}}`
+
+// dailyNoteData is the template context. Entries are in the configured
+// timezone; running entries end at the time of export.
+type dailyNoteData struct {
+	Date     time.Time
+	Total    string
+	Projects []projectTotal
+	Gaps     []timeGap
+	Entries  []dailyNoteEntry
+	Notes    []string
+}
+
+type dailyNoteEntry struct {
+	Entry
+	End      time.Time
+	Running  bool
+	Duration string
+}
+
+func renderDailyNote(day time.Time, ents []Entry, now time.Time, tmplPath string) ([]byte, error) {
+	src := defaultDailyNoteTemplate
+	if tmplPath != "" {
+		b, err := os.ReadFile(tmplPath)
+		if err != nil {
+			return nil, err
+		}
+		src = string(b)
+	}
+	tmpl, err := template.New("daily-note").Funcs(template.FuncMap{
+		"hhmm": fmtDuration,
+		"join": strings.Join,
+	}).Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("daily-note template: %w", err)
+	}
+
+	gap := viper.GetInt("notify.gap_min")
+	if gap <= 0 {
+		gap = 15
+	}
+	loc := parserLocation()
+	sum := buildDailySummary(ents, day, now, time.Duration(gap)*time.Minute)
+	data := dailyNoteData{Date: day, Total: fmtDuration(sum.Total), Projects: sum.Projects, Gaps: sum.Gaps}
+	for _, e := range ents {
+		de := dailyNoteEntry{Entry: e, End: now, Running: e.End == nil}
+		if e.End != nil {
+			de.End = *e.End
+		}
+		de.Start = e.Start.In(loc)
+		de.End = de.End.In(loc)
+		de.Duration = fmtDuration(entryElapsed(e, now))
+		data.Entries = append(data.Entries, de)
+		data.Notes = append(data.Notes, e.Notes...)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("daily-note template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// upsertMarkedBlock replaces the content between <!-- tt:NAME --> and
+// <!-- /tt:NAME --> in path, appending the block when the file or the
+// markers do not exist yet.
+func upsertMarkedBlock(path, name string, content []byte) error {
+	begin := "<!-- tt:" + name + " -->"
+	end := "<!-- /tt:" + name + " -->"
+	block := begin + "\n" + strings.TrimRight(string(content), "\n") + "\n" + end
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s := string(existing)
+	if i := strings.Index(s, begin); i >= 0 {
+		j := strings.Index(s[i:], end)
+		if j < 0 {
+			return fmt.Errorf("%s: found %s without closing %s", path, begin, end)
+		}
+		s = s[:i] + block + s[i+j+len(end):]
+	} else {
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		if s != "" {
+			s += "\n"
+		}
+		s += block + "\n"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(s), 0o644)
+}
+
+// expandHome replaces a leading ~/ with the user's home directory.
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return p
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRenderDailyNote(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	day := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	start := day.Add(9 * time.Hour)
+	end := start.Add(90 * time.Minute)
+	ents := []Entry{
+		{Start: start, End: &end, Customer: "Acme", Project: "Portal", Activity: "dev", Notes: []string{"login page"}},
+		{Start: day.Add(14 * time.Hour), Customer: "Globex"},
+	}
+	now := day.Add(15 * time.Hour)
+
+	got, err := renderDailyNote(day, ents, now, "")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := `## Time tracking — Fri 2025-10-10
+
+Total: 2h30m
+- Acme / Portal: 1h30m
+- Globex: 1h00m
+
+- 09:00–10:30 Acme / Portal (dev) — 1h30m
+  - login page
+- 14:00–15:00 Globex — 1h00m
+`
+	if string(got) != want {
+		t.Fatalf("default template mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+
+	tmpl := filepath.Join(t.TempDir(), "note.tmpl")
+	os.WriteFile(tmpl, []byte(`{{.Total}} | {{join .Notes ", "}}{{range .Entries}}{{if .Running}} | running: {{.Customer}}{{end}}{{end}}`), 0o644)
+	got, err = renderDailyNote(day, ents, now, tmpl)
+	if err != nil {
+		t.Fatalf("render custom: %v", err)
+	}
+	if string(got) != "2h30m | login page | running: Globex" {
+		t.Fatalf("custom template: %q", got)
+	}
+}
+
+func TestUpsertMarkedBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes", "2025-10-10.md")
+
+	// Missing file: created with the block.
+	if err := upsertMarkedBlock(path, "time", []byte("first\n")); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// Existing file with own content: block is appended once, then replaced.
+	b, _ := os.ReadFile(path)
+	os.WriteFile(path, append([]byte("# Friday\nmorning thoughts\n\n"), b...), 0o644)
+	if err := upsertMarkedBlock(path, "time", []byte("second\n")); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	b, _ = os.ReadFile(path)
+	want := "# Friday\nmorning thoughts\n\n<!-- tt:time -->\nsecond\n<!-- /tt:time -->\n"
+	if string(b) != want {
+		t.Fatalf("got %q, want %q", b, want)
+	}
+
+	other := filepath.Join(t.TempDir(), "other.md")
+	os.WriteFile(other, []byte("no trailing newline"), 0o644)
+	if err := upsertMarkedBlock(other, "time", []byte("x")); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	b, _ = os.ReadFile(other)
+	if !strings.HasPrefix(string(b), "no trailing newline\n\n<!-- tt:time -->\nx\n") {
+		t.Fatalf("append result: %q", b)
+	}
+
+	broken := filepath.Join(t.TempDir(), "broken.md")
+	os.WriteFile(broken, []byte("<!-- tt:time -->\nno end"), 0o644)
+	if err := upsertMarkedBlock(broken, "time", []byte("x")); err == nil {
+		t.Fatal("expected error for unterminated block")
+	}
+}
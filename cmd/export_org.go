@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// exportOrgCmd writes an Org-mode outline: one heading per day, one per
+// customer below it, and one per entry with a CLOCK line in its LOGBOOK
+// drawer, so org-clock reports over the archive match tt's numbers.
+var exportOrgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Export entries as Org-mode headings with CLOCK drawers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ents, _, _, err := exportEntries(cmd)
+		if err != nil {
+			return err
+		}
+		w, closeOut, err := exportWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if err := writeOrg(w, ents); err != nil {
+			closeOut()
+			return err
+		}
+		return closeOut()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportOrgCmd)
+}
+
+// writeOrg renders entries (sorted by start) as an Org outline.
+func writeOrg(w io.Writer, ents []Entry) error {
+	bw := bufio.NewWriter(w)
+	loc := parserLocation()
+	day, customer := "", "\x00"
+	for _, e := range ents {
+		start, end := e.Start.In(loc), e.End.In(loc)
+		if d := start.Format("2006-01-02 Mon"); d != day {
+			day, customer = d, "\x00"
+			fmt.Fprintf(bw, "* %s\n", d)
+		}
+		if e.Customer != customer {
+			customer = e.Customer
+			name := customer
+			if name == "" {
+				name = "(no customer)"
+			}
+			fmt.Fprintf(bw, "** %s\n", name)
+		}
+
+		title := e.Project
+		if e.Activity != "" {
+			if title != "" {
+				title += " — "
+			}
+			title += e.Activity
+		}
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Fprintf(bw, "*** %s%s\n", title, orgTags(e.Tags))
+		fmt.Fprintln(bw, "    :LOGBOOK:")
+		min := int(end.Sub(start).Minutes())
+		fmt.Fprintf(bw, "    CLOCK: [%s]--[%s] => %2d:%02d\n", orgTimestamp(start), orgTimestamp(end), min/60, min%60)
+		fmt.Fprintln(bw, "    :END:")
+		for _, n := range e.Notes {
+			fmt.Fprintf(bw, "    - %s\n", n)
+		}
+	}
+	return bw.Flush()
+}
+
+func orgTimestamp(t time.Time) string {
+	return t.Format("2006-01-02 Mon 15:04")
+}
+
+// orgTags renders tags as a trailing ":a:b:" tag list. Characters Org does
+// not allow in tags are replaced with underscores.
+func orgTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	clean := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '@', r == '#', r == '%':
+				return r
+			}
+			return '_'
+		}, t)
+		if t != "" {
+			clean = append(clean, t)
+		}
+	}
+	if len(clean) == 0 {
+		return ""
+	}
+	return "  :" + strings.Join(clean, ":") + ":"
+}
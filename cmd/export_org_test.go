@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriteOrg(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	at := func(d, h, m int) time.Time { return time.Date(2025, 10, d, h, m, 0, 0, time.UTC) }
+	mk := func(start, end time.Time, cust, proj, act string, tags []string, notes ...string) Entry {
+		return Entry{Start: start, End: &end, Customer: cust, Project: proj, Activity: act, Tags: tags, Notes: notes}
+	}
+	ents := []Entry{
+		mk(at(6, 9, 0), at(6, 10, 30), "Acme", "Portal", "dev", []string{"task:ab-12", "ops"}, "login page"),
+		mk(at(6, 11, 0), at(6, 11, 20), "Acme", "", "call", nil),
+		mk(at(6, 13, 0), at(6, 14, 0), "Globex", "Site", "", nil),
+		mk(at(7, 9, 0), at(7, 19, 5), "Acme", "Portal", "", nil),
+	}
+	var buf bytes.Buffer
+	if err := writeOrg(&buf, ents); err != nil {
+		t.Fatalf("writeOrg: %v", err)
+	}
+	want := `* 2025-10-06 Mon
+** Acme
+*** Portal — dev  :task_ab_12:ops:
+    :LOGBOOK:
+    CLOCK: [2025-10-06 Mon 09:00]--[2025-10-06 Mon 10:30] =>  1:30
+    :END:
+    - login page
+*** call
+    :LOGBOOK:
+    CLOCK: [2025-10-06 Mon 11:00]--[2025-10-06 Mon 11:20] =>  0:20
+    :END:
+** Globex
+*** Site
+    :LOGBOOK:
+    CLOCK: [2025-10-06 Mon 13:00]--[2025-10-06 Mon 14:00] =>  1:00
+    :END:
+* 2025-10-07 Tue
+** Acme
+*** Portal
+    :LOGBOOK:
+    CLOCK: [2025-10-07 Tue 09:00]--[2025-10-07 Tue 19:05] => 10:05
+    :END:
+`
+	if buf.String() != want {
+		t.Fatalf("org output mismatch\n--- got ---\n%s\n--- want ---\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportTempoFrom    string
+	exportTempoTo      string
+	exportTempoSplitBy string
+	exportTempoOutDir  string
+	exportTempoRaw     bool
+)
+
+// exportTempoCmd writes Tempo worklog files for a longer range, one per week
+// (or month), like tt report week --export-tempo does for a single week. A
+// manifest.json in the output directory records every file written, when,
+// and its checksum; later runs update the entries of the periods they
+// rewrite.
+var exportTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Export Tempo worklogs, one file per week or month, with a manifest",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := parseRangeFlags(exportToday, exportWeek, exportRange)
+		noteUsageRange(cmd, from, to)
+		loc := parserLocation()
+		if exportTempoFrom != "" || exportTempoTo != "" {
+			if exportTempoFrom == "" || exportTempoTo == "" {
+				return errors.New("--from and --to go together")
+			}
+			var err error
+			if from, err = time.ParseInLocation("2006-01-02", exportTempoFrom, loc); err != nil {
+				return fmt.Errorf("invalid --from %q: want YYYY-MM-DD", exportTempoFrom)
+			}
+			if to, err = time.ParseInLocation("2006-01-02", exportTempoTo, loc); err != nil {
+				return fmt.Errorf("invalid --to %q: want YYYY-MM-DD", exportTempoTo)
+			}
+		}
+		periods, err := tempoPeriods(from, to, exportTempoSplitBy)
+		if err != nil {
+			return err
+		}
+		ents, err := finishedEntries(from, to)
+		if err != nil {
+			return err
+		}
+		if err := anonymizeExport(ents); err != nil {
+			return err
+		}
+		return exportTempoPeriods(cmd.OutOrStdout(), ents, periods, exportTempoOutDir, exportTempoSplitBy, exportTempoRaw)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportTempoCmd)
+	exportTempoCmd.Flags().StringVar(&exportTempoFrom, "from", "", "first day YYYY-MM-DD (with --to; overrides --range/--week)")
+	exportTempoCmd.Flags().StringVar(&exportTempoTo, "to", "", "last day YYYY-MM-DD")
+	exportTempoCmd.Flags().StringVar(&exportTempoSplitBy, "split-by", "week", "one file per week|month|none")
+	exportTempoCmd.Flags().StringVar(&exportTempoOutDir, "out-dir", ".", "directory for the files and manifest.json")
+	exportTempoCmd.Flags().BoolVar(&exportTempoRaw, "tempo-raw", false, "use the tracked (unrounded) seconds")
+}
+
+// tempoPeriod is one file of a Tempo export.
+type tempoPeriod struct {
+	Label    string // 2025-W41, 2025-10, or 2025-10-01_2025-12-31
+	From, To time.Time
+}
+
+// tempoPeriods splits the days from..to into ISO weeks, calendar months or
+// one period; the first and last period are cut to the range.
+func tempoPeriods(from, to time.Time, splitBy string) ([]tempoPeriod, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location())
+	if to.Before(from) {
+		return nil, fmt.Errorf("empty range %s..%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	var out []tempoPeriod
+	for start := from; !start.After(to); {
+		var end time.Time
+		var label string
+		switch splitBy {
+		case "week":
+			end = start.AddDate(0, 0, (7-int(start.Weekday()))%7) // Sunday
+			label = fmtWeekLabel(start, end)
+		case "month":
+			end = time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, start.Location())
+			label = start.Format("2006-01")
+		case "none", "":
+			end = to
+			label = from.Format("2006-01-02") + "_" + to.Format("2006-01-02")
+		default:
+			return nil, fmt.Errorf("unknown --split-by %q (want week|month|none)", splitBy)
+		}
+		if end.After(to) {
+			end = to
+		}
+		out = append(out, tempoPeriod{Label: label, From: start, To: end})
+		start = end.AddDate(0, 0, 1)
+	}
+	return out, nil
+}
+
+// tempoManifest lists the files of a Tempo export directory.
+type tempoManifest struct {
+	SplitBy string              `json:"split_by"`
+	Raw     bool                `json:"raw"`
+	Files   []tempoManifestFile `json:"files"`
+}
+
+type tempoManifestFile struct {
+	Period     string    `json:"period"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	File       string    `json:"file"`
+	Worklogs   int       `json:"worklogs"`
+	Seconds    int64     `json:"seconds"`
+	SHA256     string    `json:"sha256"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// exportTempoPeriods writes a Tempo file per period with entries to dir and
+// updates dir/manifest.json. Billable groups without notes are checked per
+// period as for --export-tempo; with lint.missing_notes: error the export
+// stops at the first such period.
+func exportTempoPeriods(w io.Writer, ents []Entry, periods []tempoPeriod, dir, splitBy string, raw bool) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	var m tempoManifest
+	if b, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(b, &m); err != nil {
+			return fmt.Errorf("%s: %w", manifestPath, err)
+		}
+	}
+	m.SplitBy, m.Raw = splitBy, raw
+	policy := getRounding().Policy()
+	written := 0
+	for _, p := range periods {
+		agg := newWeekAggregator(p.From.Location(), policy, Now().UTC())
+		end := p.To.AddDate(0, 0, 1)
+		for _, e := range ents {
+			if e.Start.Before(end) && e.End.After(p.From) {
+				_ = agg.add(e)
+			}
+		}
+		days := agg.Days(p.From, p.To, "de", 80)
+		var logs int
+		var seconds int64
+		for _, d := range days {
+			for _, g := range d.Groups {
+				s := g.Seconds
+				if raw {
+					s = g.SecondsRaw
+				}
+				if s > 0 {
+					logs++
+					seconds += s
+				}
+			}
+		}
+		if logs == 0 {
+			fmt.Fprintf(w, "%s: no entries\n", p.Label)
+			continue
+		}
+		if err := checkMissingNotes(w, "tempo export "+p.Label, weekMissingNotes(days)); err != nil {
+			return err
+		}
+		name := p.Label + "-tempo.json"
+		path := filepath.Join(dir, name)
+		if err := writeTempoExport(path, days, raw); err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(b)
+		f := tempoManifestFile{
+			Period:     p.Label,
+			From:       p.From.Format("2006-01-02"),
+			To:         p.To.Format("2006-01-02"),
+			File:       name,
+			Worklogs:   logs,
+			Seconds:    seconds,
+			SHA256:     hex.EncodeToString(sum[:]),
+			ExportedAt: Now().UTC().Truncate(time.Second),
+		}
+		replaced := false
+		for i := range m.Files {
+			if m.Files[i].File == name {
+				m.Files[i], replaced = f, true
+			}
+		}
+		if !replaced {
+			m.Files = append(m.Files, f)
+		}
+		written++
+		fmt.Fprintf(w, "%s: %d worklogs, %s → %s\n", p.Label, logs, fmtSecHHMM(seconds), path)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].From < m.Files[j].From })
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(manifestPath, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Wrote %d file(s); manifest %s\n", written, manifestPath)
+	return nil
+}
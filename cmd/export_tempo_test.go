@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestTempoPeriods(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	labels := func(ps []tempoPeriod) string {
+		var s []string
+		for _, p := range ps {
+			s = append(s, p.Label+"="+p.From.Format("01-02")+".."+p.To.Format("01-02"))
+		}
+		return strings.Join(s, " ")
+	}
+	cases := []struct {
+		split string
+		from  time.Time
+		to    time.Time
+		want  string
+	}{
+		{"week", day(10, 1), day(10, 14), "2025-W40=10-01..10-05 2025-W41=10-06..10-12 2025-W42=10-13..10-14"},
+		{"week", day(10, 6), day(10, 12), "2025-W41=10-06..10-12"},
+		{"month", day(9, 15), day(11, 2), "2025-09=09-15..09-30 2025-10=10-01..10-31 2025-11=11-01..11-02"},
+		{"none", day(9, 15), day(11, 2), "2025-09-15_2025-11-02=09-15..11-02"},
+	}
+	for _, c := range cases {
+		ps, err := tempoPeriods(c.from, c.to, c.split)
+		if err != nil {
+			t.Fatalf("%s: %v", c.split, err)
+		}
+		if got := labels(ps); got != c.want {
+			t.Errorf("%s %s..%s:\n got %s\nwant %s", c.split, c.from.Format("01-02"), c.to.Format("01-02"), got, c.want)
+		}
+	}
+	if _, err := tempoPeriods(day(10, 1), day(10, 2), "day"); err == nil {
+		t.Error("unknown split accepted")
+	}
+	if _, err := tempoPeriods(day(10, 2), day(10, 1), "week"); err == nil {
+		t.Error("reversed range accepted")
+	}
+}
+
+func TestExportTempoPeriods(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(d, h int) time.Time { return time.Date(2025, 10, d, h, 0, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{ID: "a", Start: at(6, 9), End: end(at(6, 11)), Customer: "Acme", Project: "web", Billable: true, Notes: []string{"login"}},
+		{ID: "b", Start: at(14, 9), End: end(at(14, 10)), Customer: "Acme", Project: "api", Billable: true, Notes: []string{"deploy"}},
+	}
+	periods, err := tempoPeriods(at(1, 0), at(19, 0), "week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "exports")
+	var out bytes.Buffer
+	if err := exportTempoPeriods(&out, ents, periods, dir, "week", false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"2025-W40: no entries", "2025-W41: 1 worklogs, 2h00m", "2025-W42: 1 worklogs, 1h00m", "Wrote 2 file(s)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	readManifest := func() tempoManifest {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m tempoManifest
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	m := readManifest()
+	if m.SplitBy != "week" || len(m.Files) != 2 || m.Files[0].File != "2025-W41-tempo.json" || m.Files[1].Seconds != 3600 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2025-W42-tempo.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-exporting one week replaces its manifest entry and keeps the other.
+	ents[1].End = end(at(14, 12))
+	out.Reset()
+	if err := exportTempoPeriods(&out, ents, periods[2:], dir, "week", false); err != nil {
+		t.Fatal(err)
+	}
+	m2 := readManifest()
+	if len(m2.Files) != 2 || m2.Files[0] != m.Files[0] || m2.Files[1].Seconds != 3*3600 || m2.Files[1].SHA256 == m.Files[1].SHA256 {
+		t.Fatalf("manifest after re-run = %+v", m2)
+	}
+}
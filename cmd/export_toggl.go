@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Toggl Track and Clockify both import time entries from CSV. tt maps its
+// model onto theirs as customer → Client, project → Project, activity → Task,
+// notes → Description and tags → Tags. The user's email (export.email in the
+// config) is included because both importers use it to pick the workspace
+// member; it may be left empty for single-user workspaces.
+
+var exportTogglCmd = &cobra.Command{
+	Use:   "toggl-csv",
+	Short: "Export entries as a Toggl Track import CSV",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCSVExport(cmd, writeTogglCSV)
+	},
+}
+
+var exportClockifyCmd = &cobra.Command{
+	Use:   "clockify-csv",
+	Short: "Export entries as a Clockify import CSV",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCSVExport(cmd, writeClockifyCSV)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportTogglCmd, exportClockifyCmd)
+}
+
+func runCSVExport(cmd *cobra.Command, write func(io.Writer, []Entry, string) error) error {
+	ents, _, _, err := exportEntries(cmd)
+	if err != nil {
+		return err
+	}
+	w, closeOut, err := exportWriter(cmd)
+	if err != nil {
+		return err
+	}
+	if err := write(w, ents, viper.GetString("export.email")); err != nil {
+		closeOut()
+		return err
+	}
+	return closeOut()
+}
+
+// writeTogglCSV writes entries in Toggl Track's CSV import layout. Dates and
+// times are in the configured timezone; Toggl interprets them in the
+// importing user's profile timezone.
+func writeTogglCSV(w io.Writer, ents []Entry, email string) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Email", "Client", "Project", "Task", "Description", "Billable", "Start date", "Start time", "Duration", "Tags"})
+	for _, e := range ents {
+		start := e.Start.In(parserLocation())
+		cw.Write([]string{
+			email,
+			e.Customer,
+			e.Project,
+			e.Activity,
+			exportDescription(e),
+			yesNo(e.Billable),
+			start.Format("2006-01-02"),
+			start.Format("15:04:05"),
+			fmtClock(entryElapsed(e, *e.End)),
+			strings.Join(e.Tags, ", "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeClockifyCSV writes entries in Clockify's CSV import layout. Clockify
+// asks for the date and time format during import; choose YYYY-MM-DD and 24h.
+func writeClockifyCSV(w io.Writer, ents []Entry, email string) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Project", "Client", "Description", "Task", "Email", "Tags", "Billable", "Start Date", "Start Time", "End Date", "End Time", "Duration (h)", "Duration (decimal)"})
+	for _, e := range ents {
+		loc := parserLocation()
+		start, end := e.Start.In(loc), e.End.In(loc)
+		d := end.Sub(start)
+		cw.Write([]string{
+			e.Project,
+			e.Customer,
+			exportDescription(e),
+			e.Activity,
+			email,
+			strings.Join(e.Tags, ", "),
+			yesNo(e.Billable),
+			start.Format("2006-01-02"),
+			start.Format("15:04:05"),
+			end.Format("2006-01-02"),
+			end.Format("15:04:05"),
+			fmtClock(d),
+			fmt.Sprintf("%.2f", d.Hours()),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportDescription joins an entry's notes into a single description line.
+func exportDescription(e Entry) string {
+	return strings.Join(e.Notes, "; ")
+}
+
+// fmtClock formats d as HH:MM:SS, the duration format both importers accept.
+func fmtClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int64(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestCSVExports(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90*time.Minute + 15*time.Second)
+	ents := []Entry{{
+		ID: "e1", Start: start, End: &end,
+		Customer: "Acme", Project: "Portal", Activity: "dev", Billable: true,
+		Notes: []string{"login page", "review, fixes"}, Tags: []string{"frontend", "urgent"},
+	}}
+
+	cases := []struct {
+		name   string
+		write  func(*bytes.Buffer) error
+		header []string
+		row    []string
+	}{
+		{
+			name:   "toggl",
+			write:  func(b *bytes.Buffer) error { return writeTogglCSV(b, ents, "me@example.com") },
+			header: []string{"Email", "Client", "Project", "Task", "Description", "Billable", "Start date", "Start time", "Duration", "Tags"},
+			row:    []string{"me@example.com", "Acme", "Portal", "dev", "login page; review, fixes", "Yes", "2025-10-06", "09:00:00", "01:30:15", "frontend, urgent"},
+		},
+		{
+			name:   "clockify",
+			write:  func(b *bytes.Buffer) error { return writeClockifyCSV(b, ents, "") },
+			header: []string{"Project", "Client", "Description", "Task", "Email", "Tags", "Billable", "Start Date", "Start Time", "End Date", "End Time", "Duration (h)", "Duration (decimal)"},
+			row:    []string{"Portal", "Acme", "login page; review, fixes", "dev", "", "frontend, urgent", "Yes", "2025-10-06", "09:00:00", "2025-10-06", "10:30:15", "01:30:15", "1.50"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tc.write(&buf); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			recs, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("csv output does not parse: %v", err)
+			}
+			if len(recs) != 2 {
+				t.Fatalf("want header + 1 row, got %d records", len(recs))
+			}
+			for i, want := range [][]string{tc.header, tc.row} {
+				if got := recs[i]; len(got) != len(want) {
+					t.Fatalf("record %d: got %q, want %q", i, got, want)
+				} else {
+					for j := range want {
+						if got[j] != want[j] {
+							t.Errorf("record %d col %q: got %q, want %q", i, tc.header[j], got[j], want[j])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExportEntriesSkipsRunning(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", ""); exportRange = "" })
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	evs := []Event{
+		NewStartEvent("s1", "Acme", "Portal", "dev", boolPtr(true), "", nil, start),
+		NewStopEvent("s2", start.Add(time.Hour)),
+		NewStartEvent("s3", "Acme", "Portal", "dev", boolPtr(true), "", nil, start.Add(2*time.Hour)),
+	}
+	for _, ev := range evs {
+		if err := Writer.WriteEvent(ev); err != nil {
+			t.Fatalf("write event: %v", err)
+		}
+	}
+
+	exportRange = "2025-10-06T00:00..2025-10-06T23:59"
+	ents, _, _, err := exportEntries(exportTogglCmd)
+	if err != nil {
+		t.Fatalf("exportEntries: %v", err)
+	}
+	if len(ents) != 1 || ents[0].End == nil {
+		t.Fatalf("want only the finished entry, got %+v", ents)
+	}
+}
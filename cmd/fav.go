@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/suggest"
+)
+
+var favLimit int
+
+// favCmd lists the most used customer/project/activity combos, numbered the
+// same way as the TUI suggestions, so `tt start 2` starts the second one.
+var favCmd = &cobra.Command{
+	Use:   "fav",
+	Short: "List favorite combos (most frequent, then most recent) by number",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		favs, err := favorites()
+		if err != nil {
+			return err
+		}
+		printFavorites(cmd.OutOrStdout(), favs, favLimit)
+		return nil
+	},
+}
+
+var favStartCmd = &cobra.Command{
+	Use:   "start <n>",
+	Short: "Start the Nth favorite (same as tt start <n>)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isFavoriteNumber(args[0]) {
+			cobra.CheckErr(fmt.Errorf("want a favorite number, got %q (see tt fav)", args[0]))
+		}
+		startCmd.Run(startCmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(favCmd)
+	favCmd.AddCommand(favStartCmd)
+	favCmd.Flags().IntVarP(&favLimit, "limit", "n", 9, "number of favorites to show")
+}
+
+// favorites ranks the combos tracked within the suggest lookback, leaving
+// out archived customers.
+func favorites() ([]suggest.Ranked, error) {
+	now := Now()
+	cfg := suggestConfig()
+	ents, err := loadEntries(now.Add(-cfg.Lookback), now)
+	if err != nil {
+		return nil, err
+	}
+	decisions := loadCompletionDecisions()
+	uses := make([]suggest.Use, 0, len(ents))
+	for _, e := range ents {
+		if decisions.isCustomerArchived(e.Customer) {
+			continue
+		}
+		uses = append(uses, suggest.Use{
+			Combo: suggest.Combo{Customer: e.Customer, Project: e.Project, Activity: e.Activity, Billable: e.Billable},
+			Start: e.Start,
+		})
+	}
+	return cfg.Rank(uses, now), nil
+}
+
+// isFavoriteNumber reports whether a lone start argument selects a favorite.
+func isFavoriteNumber(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// favoriteAt returns the nth (1-based) favorite.
+func favoriteAt(n int) (suggest.Combo, error) {
+	favs, err := favorites()
+	if err != nil {
+		return suggest.Combo{}, err
+	}
+	if n < 1 || n > len(favs) {
+		return suggest.Combo{}, fmt.Errorf("no favorite #%d (%d available, see tt fav)", n, len(favs))
+	}
+	return favs[n-1].Combo, nil
+}
+
+func printFavorites(w io.Writer, favs []suggest.Ranked, limit int) {
+	if len(favs) == 0 {
+		fmt.Fprintln(w, "No favorites yet (nothing tracked in the last 30 days).")
+		return
+	}
+	if limit > 0 && len(favs) > limit {
+		favs = favs[:limit]
+	}
+	for i, f := range favs {
+		bill := ""
+		if !f.Billable {
+			bill = "  non-billable"
+		}
+		fmt.Fprintf(w, "%2d  %s / %s [%s]  %dx, last %s%s\n", i+1, f.Customer, f.Project, f.Activity,
+			f.Count, f.LastSeen.In(parserLocation()).Format("2006-01-02"), bill)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestFavoritesAndStartByNumber(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	oldNow, oldWriter, oldActivity, oldBillable := Now, Writer, startActivity, startBillable
+	Now = func() time.Time { return now }
+	t.Cleanup(func() { Now, Writer, startActivity, startBillable = oldNow, oldWriter, oldActivity, oldBillable })
+
+	day := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	add := func(id, c, p, a string, billable bool, d, h int) Event {
+		st := day.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour)
+		ev := NewAddEvent(id, c, p, a, boolPtr(billable), "", nil, st, st.Add(time.Hour))
+		ev.TS = st
+		return ev
+	}
+	if err := writeEvents([]Event{
+		add("e1", "Acme", "Portal", "dev", true, 0, 9),
+		add("e2", "Globex", "Ops", "support", false, 0, 11),
+		add("e3", "Acme", "Portal", "dev", true, 1, 9),
+		add("e4", "Globex", "Ops", "support", false, 1, 11),
+		add("e5", "Acme", "Portal", "dev", true, 2, 9),
+		add("e6", "Initech", "Web", "", true, 2, 10),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	favs, err := favorites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printFavorites(&buf, favs, 2)
+	out := buf.String()
+	if !strings.Contains(out, " 1  Acme / Portal [dev]  3x") || !strings.Contains(out, " 2  Globex / Ops [support]  2x") {
+		t.Errorf("fav output:\n%s", out)
+	}
+	if strings.Contains(out, "Initech") {
+		t.Errorf("--limit 2 should hide the third favorite:\n%s", out)
+	}
+
+	fw := &simpleFakeEventWriter{}
+	Writer = fw
+	startActivity, startBillable = "", true
+	startCmd.Run(&cobra.Command{}, []string{"2"})
+	if len(fw.events) != 1 {
+		t.Fatalf("want 1 event, got %d", len(fw.events))
+	}
+	ev := fw.events[0]
+	if ev.Customer != "Globex" || ev.Project != "Ops" || ev.Activity != "support" || *ev.Billable {
+		t.Errorf("tt start 2 wrote %+v", ev)
+	}
+
+	startActivity = "review"
+	favStartCmd.Run(favStartCmd, []string{"1"})
+	if ev := fw.events[1]; ev.Customer != "Acme" || ev.Activity != "review" || !*ev.Billable {
+		t.Errorf("tt fav start 1 -a review wrote %+v", ev)
+	}
+}
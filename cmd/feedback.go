@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// stopFeedbackEnabled reports feedback.on_stop: when set, `tt stop` and the
+// TUI stop print a mini-summary of the day after stopping.
+//
+//	feedback:
+//	  on_stop: true
+//	targets:
+//	  daily_hours: 8
+func stopFeedbackEnabled() bool {
+	return viper.GetBool("feedback.on_stop")
+}
+
+// dailyTargetMinutes is targets.daily_hours in minutes (default 8h); 0 or
+// less disables the target.
+func dailyTargetMinutes() int {
+	h := 8.0
+	if viper.IsSet("targets.daily_hours") {
+		h = viper.GetFloat64("targets.daily_hours")
+	}
+	if h <= 0 {
+		return 0
+	}
+	return int(h * 60)
+}
+
+// stopSummary is the feedback shown after stopping an entry.
+type stopSummary struct {
+	EntryID  string
+	EntryMin int
+	TodayMin int // tracked today up to the stop, including the entry
+	Target   int // daily target in minutes, 0 when disabled
+	NoNote   bool
+}
+
+// buildStopSummary summarises the day up to at. stoppedID selects the entry
+// just stopped; "" picks the entry that ended last.
+func buildStopSummary(stoppedID string, at time.Time) (stopSummary, error) {
+	loc := parserLocation()
+	at = at.In(loc)
+	dayStart := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
+	ents, err := loadEntries(dayStart.AddDate(0, 0, -1), at)
+	if err != nil {
+		return stopSummary{}, err
+	}
+	s := stopSummary{Target: dailyTargetMinutes()}
+	var stopped *Entry
+	for i, e := range ents {
+		end := at
+		if e.End != nil && e.End.Before(at) {
+			end = *e.End
+		}
+		st := e.Start
+		if st.Before(dayStart) {
+			st = dayStart
+		}
+		if end.After(st) {
+			s.TodayMin += int(end.Sub(st).Minutes())
+		}
+		if e.End == nil {
+			continue
+		}
+		if (stoppedID != "" && e.ID == stoppedID) || (stoppedID == "" && (stopped == nil || e.End.After(*stopped.End))) {
+			stopped = &ents[i]
+		}
+	}
+	if stopped != nil {
+		s.EntryID = stopped.ID
+		s.EntryMin = durationMinutes(*stopped)
+		s.NoNote = len(stopped.Notes) == 0
+	}
+	return s, nil
+}
+
+// lines renders the summary for the CLI.
+func (s stopSummary) lines() []string {
+	day := "Today: " + fmtHHMM(s.TodayMin)
+	if s.EntryID != "" {
+		day = "Entry: " + fmtHHMM(s.EntryMin) + " · " + day
+	}
+	switch {
+	case s.Target == 0:
+	case s.TodayMin >= s.Target:
+		day += fmt.Sprintf(" · daily target %s reached (+%s)", fmtHHMM(s.Target), fmtHHMM(s.TodayMin-s.Target))
+	default:
+		day += fmt.Sprintf(" · %s left to the %s target", fmtHHMM(s.Target-s.TodayMin), fmtHHMM(s.Target))
+	}
+	out := []string{day}
+	if s.NoNote && s.EntryID != "" {
+		out = append(out, fmt.Sprintf("No note on this entry yet: tt amend %s --note \"...\"", s.EntryID))
+	}
+	return out
+}
+
+// stopFeedback serves the same summary to the TUI on one status line.
+type stopFeedback struct{}
+
+func (stopFeedback) StopSummary(ctx context.Context) string {
+	if !stopFeedbackEnabled() {
+		return ""
+	}
+	s, err := buildStopSummary("", Now())
+	if err != nil {
+		return ""
+	}
+	line := s.lines()[0]
+	if s.NoNote && s.EntryID != "" {
+		line += " · no note yet"
+	}
+	return line
+}
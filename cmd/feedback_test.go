@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestStopSummary(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("feedback.on_stop", false)
+		viper.Set("targets.daily_hours", 8)
+	})
+	day := time.Date(2025, 10, 14, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	if err := writeEvents([]Event{
+		NewStartEvent("y1", "Acme", "Ops", "", boolPtr(true), "", nil, day.Add(-3*time.Hour)), // yesterday: not counted
+		NewStopEvent("y2", day.Add(-time.Hour)),
+		NewStartEvent("e1", "Acme", "Ops", "", boolPtr(true), "standup", nil, at(8, 0)),
+		NewStopEvent("e2", at(9, 0)),
+		NewStartEvent("a1", "Acme", "Portal", "dev", boolPtr(true), "login page", nil, at(9, 0)),
+		NewStopEvent("a2", at(12, 0)),
+		NewStartEvent("b1", "Acme", "Portal", "review", boolPtr(true), "", nil, at(13, 0)),
+		NewStopEvent("b2", at(14, 30)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		id      string
+		target  float64
+		want    stopSummary
+		wantOut []string
+	}{
+		{"last entry, target left", "", 8, stopSummary{EntryID: "b1", EntryMin: 90, TodayMin: 330, Target: 480, NoNote: true},
+			[]string{"Entry: 1h30m · Today: 5h30m · 2h30m left to the 8h00m target", `No note on this entry yet: tt amend b1 --note "..."`}},
+		{"by id, target reached", "a1", 5, stopSummary{EntryID: "a1", EntryMin: 180, TodayMin: 330, Target: 300},
+			[]string{"Entry: 3h00m · Today: 5h30m · daily target 5h00m reached (+30m)"}},
+		{"target disabled", "a1", 0, stopSummary{EntryID: "a1", EntryMin: 180, TodayMin: 330},
+			[]string{"Entry: 3h00m · Today: 5h30m"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Set("targets.daily_hours", tc.target)
+			got, err := buildStopSummary(tc.id, at(15, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("summary = %+v, want %+v", got, tc.want)
+			}
+			if out := got.lines(); !reflect.DeepEqual(out, tc.wantOut) {
+				t.Errorf("lines = %q, want %q", out, tc.wantOut)
+			}
+		})
+	}
+
+	old := Now
+	Now = func() time.Time { return at(15, 0) }
+	t.Cleanup(func() { Now = old })
+	viper.Set("targets.daily_hours", 8)
+	if s := (stopFeedback{}).StopSummary(context.Background()); s != "" {
+		t.Errorf("feedback off should be silent, got %q", s)
+	}
+	viper.Set("feedback.on_stop", true)
+	if s := (stopFeedback{}).StopSummary(context.Background()); !strings.HasPrefix(s, "Entry: 1h30m") || !strings.HasSuffix(s, "no note yet") {
+		t.Errorf("TUI summary = %q", s)
+	}
+}
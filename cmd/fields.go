@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Structured fields in notes: whitespace-separated key=value tokens such as
+//
+//	tt note "ticket=ABC-123 location=onsite call with ops"
+//
+// are collected into Entry.Fields. Keys are case-insensitive (stored lower
+// case) and start with a letter; values run to the next whitespace unless
+// double-quoted (key="two words"). A key repeated in a later note wins, so an
+// amend note can correct a field.
+
+var noteFieldRe = regexp.MustCompile(`(?:^|\s)([A-Za-z][A-Za-z0-9_.-]*)=("[^"]*"|[^\s"]+)`)
+
+// parseNoteFields extracts key=value fields from notes, or returns nil when
+// there are none.
+func parseNoteFields(notes []string) map[string]string {
+	var fields map[string]string
+	for _, n := range notes {
+		for _, m := range noteFieldRe.FindAllStringSubmatch(n, -1) {
+			if fields == nil {
+				fields = map[string]string{}
+			}
+			fields[strings.ToLower(m[1])] = strings.Trim(m[2], `"`)
+		}
+	}
+	return fields
+}
+
+// fieldsString renders fields as "k=v; k2=v2" sorted by key.
+func fieldsString(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+fields[k])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// whereClause is one --where condition: key=value, key!=value, or a bare key
+// that must be present.
+type whereClause struct {
+	Key, Value string
+	Negate     bool
+	Exists     bool
+}
+
+// parseWhere parses --where flags. Values compare case-insensitively.
+func parseWhere(specs []string) ([]whereClause, error) {
+	var out []whereClause
+	for _, s := range specs {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		var c whereClause
+		switch {
+		case strings.Contains(s, "!="):
+			k, v, _ := strings.Cut(s, "!=")
+			c = whereClause{Key: k, Value: v, Negate: true}
+		case strings.Contains(s, "="):
+			k, v, _ := strings.Cut(s, "=")
+			c = whereClause{Key: k, Value: v}
+		default:
+			c = whereClause{Key: s, Exists: true}
+		}
+		c.Key = strings.ToLower(strings.TrimSpace(c.Key))
+		c.Value = strings.TrimSpace(c.Value)
+		if c.Key == "" {
+			return nil, fmt.Errorf("invalid --where %q: want key=value, key!=value or key", s)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (c whereClause) matches(e Entry) bool {
+	v, ok := e.Fields[c.Key]
+	switch {
+	case c.Exists:
+		return ok
+	case c.Negate:
+		return !ok || !strings.EqualFold(v, c.Value)
+	default:
+		return ok && strings.EqualFold(v, c.Value)
+	}
+}
+
+// filterWhere keeps entries matching every clause.
+func filterWhere(ents []Entry, clauses []whereClause) []Entry {
+	if len(clauses) == 0 {
+		return ents
+	}
+	out := ents[:0:0]
+	for _, e := range ents {
+		if matchesWhere(e, clauses) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func matchesWhere(e Entry, clauses []whereClause) bool {
+	for _, c := range clauses {
+		if !c.matches(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// whereFlagHelp is shared by the --where flags on listing and report commands.
+const whereFlagHelp = "only entries whose note fields match key=value, key!=value or key (repeatable; AND)"
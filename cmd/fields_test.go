@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNoteFields(t *testing.T) {
+	cases := []struct {
+		notes []string
+		want  map[string]string
+	}{
+		{[]string{"plain note"}, nil},
+		{[]string{"ticket=ABC-123 location=onsite call with ops"}, map[string]string{"ticket": "ABC-123", "location": "onsite"}},
+		{[]string{`Room="Big Hall" ok`}, map[string]string{"room": "Big Hall"}},
+		{[]string{"ticket=A-1", "fixed typo ticket=A-2"}, map[string]string{"ticket": "A-2"}},
+		{[]string{"see https://x.example/?a=b and 1=2 or =x"}, nil},
+	}
+	for _, tc := range cases {
+		if got := parseNoteFields(tc.notes); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseNoteFields(%q) = %v, want %v", tc.notes, got, tc.want)
+		}
+	}
+}
+
+func TestFilterWhere(t *testing.T) {
+	ents := []Entry{
+		{ID: "a", Fields: map[string]string{"ticket": "ABC-123", "location": "onsite"}},
+		{ID: "b", Fields: map[string]string{"ticket": "XYZ-9"}},
+		{ID: "c"},
+	}
+	cases := []struct {
+		where []string
+		want  []string
+	}{
+		{nil, []string{"a", "b", "c"}},
+		{[]string{"ticket=abc-123"}, []string{"a"}},
+		{[]string{"ticket"}, []string{"a", "b"}},
+		{[]string{"location!=onsite"}, []string{"b", "c"}},
+		{[]string{"ticket", "location!=onsite"}, []string{"b"}},
+	}
+	for _, tc := range cases {
+		clauses, err := parseWhere(tc.where)
+		if err != nil {
+			t.Fatalf("parseWhere(%q): %v", tc.where, err)
+		}
+		var got []string
+		for _, e := range filterWhere(ents, clauses) {
+			got = append(got, e.ID)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("--where %q = %v, want %v", tc.where, got, tc.want)
+		}
+	}
+	if _, err := parseWhere([]string{"=x"}); err == nil {
+		t.Error("want error for missing key")
+	}
+}
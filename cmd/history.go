@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+	"tt/internal/reporting"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "List the base event and every correction of an entry with before/after snapshots",
+	Long: `History replays the day file holding an entry and lists the event that
+created it and every amend, split and merge affecting it, its ancestors or the
+entries that replaced it, in the order the parser applies them. Corrections
+the parser skipped are marked.
+
+id is ` + entryRefHelp + ".",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveEntryRef(args[0])
+		if err != nil {
+			return err
+		}
+		now := Now().In(parserLocation())
+		paths := journalPaths(now.AddDate(0, 0, -entryRefLookbackDays), now)
+		// newest first: recent entries are the ones usually looked up
+		sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+		steps, err := journal.NewParser(viper.GetString("timezone")).Explain(paths, id)
+		if err != nil {
+			return fmt.Errorf("history of %s: %w", id, err)
+		}
+		renderHistory(os.Stdout, steps)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+// renderHistory prints one block per step: the event, then the snapshots
+// before and after it.
+func renderHistory(w io.Writer, steps []journal.HistoryStep) {
+	loc := parserLocation()
+	for i, st := range steps {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		ev := st.Event
+		head := fmt.Sprintf("%s  %-6s %s", ev.TS.In(loc).Format("2006-01-02 15:04"), ev.Type, ev.ID)
+		if d := historyEventDetail(ev); d != "" {
+			head += "  " + d
+		}
+		if ev.User != "" {
+			head += "  by " + ev.User
+		}
+		fmt.Fprintln(w, head)
+		for j, e := range st.Before {
+			fmt.Fprintf(w, "  %-7s %s\n", historyLabel("before", j), historySnapshot(e))
+		}
+		if len(st.After) == 0 {
+			fmt.Fprintln(w, "  (skipped by the parser: no effect)")
+		}
+		for j, e := range st.After {
+			fmt.Fprintf(w, "  %-7s %s\n", historyLabel("after", j), historySnapshot(e))
+		}
+	}
+}
+
+func historyLabel(label string, i int) string {
+	if i > 0 {
+		return ""
+	}
+	return label
+}
+
+// historyEventDetail summarizes what a correction event asks for.
+func historyEventDetail(ev journal.Event) string {
+	loc := parserLocation()
+	var parts []string
+	switch ev.Type {
+	case "amend":
+		for _, k := range []string{"start", "end"} {
+			if t, err := time.Parse(time.RFC3339, ev.Meta[k]); err == nil {
+				parts = append(parts, k+" "+t.In(loc).Format("15:04"))
+			}
+		}
+	case "split":
+		if t, err := time.Parse(time.RFC3339, ev.Meta["split_at"]); err == nil {
+			parts = append(parts, "at "+t.In(loc).Format("15:04"))
+		}
+	case "merge":
+		parts = append(parts, "targets "+ev.Meta["targets"])
+	default:
+		return "" // base events are described by their snapshot
+	}
+	if ev.Customer != "" || ev.Project != "" {
+		parts = append(parts, "→ "+entryLabel(Entry{Customer: ev.Customer, Project: ev.Project}))
+	}
+	if ev.Note != "" {
+		parts = append(parts, fmt.Sprintf("note %q", ev.Note))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// historySnapshot formats an entry state on one line.
+func historySnapshot(je journal.Entry) string {
+	e := entryFromJournal(je)
+	line := fmt.Sprintf("%s  %s", e.ID, entryWindowLine(e))
+	if e.Activity != "" {
+		line += "  " + e.Activity
+	}
+	if e.Billable {
+		line += "  billable"
+	}
+	if notes := reporting.DedupeStrings(e.Notes); len(notes) > 0 {
+		line += fmt.Sprintf("  %q", strings.Join(notes, " • "))
+	}
+	return line
+}
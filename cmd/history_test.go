@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+func TestRenderHistory(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(h, m int) time.Time { return time.Date(2025, 8, 1, h, m, 0, 0, time.UTC) }
+	end := func(h, m int) *time.Time { v := at(h, m); return &v }
+	base := journal.Entry{ID: "e1", Start: at(9, 0), End: end(11, 0), Customer: "Acme", Billable: true}
+	amended := base
+	amended.End = end(11, 30)
+	amended.Notes = []string{"late"}
+	left, right := amended, amended
+	left.ID, left.End = "s1.L", end(10, 0)
+	right.ID, right.Start = "s1.R", at(10, 0)
+
+	steps := []journal.HistoryStep{
+		{Event: journal.Event{ID: "e1", Type: "add", TS: at(9, 0)}, After: []journal.Entry{base}},
+		{Event: journal.Event{ID: "a1", Type: "amend", TS: at(14, 0), Note: "late", Meta: map[string]string{"end": "2025-08-01T11:30:00Z"}},
+			Before: []journal.Entry{base}, After: []journal.Entry{amended}},
+		{Event: journal.Event{ID: "s1", Type: "split", TS: at(14, 5), User: "alice", Meta: map[string]string{"split_at": "2025-08-01T10:00:00Z"}},
+			Before: []journal.Entry{amended}, After: []journal.Entry{left, right}},
+		{Event: journal.Event{ID: "a4", Type: "amend", TS: at(14, 25), Ref: "e1", Customer: "Globex"}},
+	}
+	var buf bytes.Buffer
+	renderHistory(&buf, steps)
+	want := `2025-08-01 09:00  add    e1
+  after   e1  Aug 01 09:00–11:00   2h00m  Acme  billable
+
+2025-08-01 14:00  amend  a1  end 11:30, note "late"
+  before  e1  Aug 01 09:00–11:00   2h00m  Acme  billable
+  after   e1  Aug 01 09:00–11:30   2h30m  Acme  billable  "late"
+
+2025-08-01 14:05  split  s1  at 10:00  by alice
+  before  e1  Aug 01 09:00–11:30   2h30m  Acme  billable  "late"
+  after   s1.L  Aug 01 09:00–10:00   1h00m  Acme  billable  "late"
+          s1.R  Aug 01 10:00–11:30   1h30m  Acme  billable  "late"
+
+2025-08-01 14:25  amend  a4  → Globex
+  (skipped by the parser: no effect)
+`
+	if buf.String() != want {
+		t.Errorf("history:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
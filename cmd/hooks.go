@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// hookSchemaVersion is bumped only for incompatible payload changes; adding
+// optional fields keeps the version.
+const hookSchemaVersion = 1
+
+//go:embed hooks_schema.json
+var hookPayloadSchema []byte
+
+var hooksSchemaExample bool
+
+// hooksCmd groups commands around hooks: programs and webhooks that receive
+// a JSON payload for every journal event.
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Hook and webhook payload tools",
+}
+
+var hooksSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the hook payload (or --example payload)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !hooksSchemaExample {
+			_, err := cmd.OutOrStdout().Write(hookPayloadSchema)
+			return err
+		}
+		ts := time.Date(2025, 10, 6, 10, 30, 0, 0, time.UTC)
+		start := ts.Add(-90 * time.Minute)
+		ev := NewStopEvent("tt_1759746600000000000", ts)
+		entry := Entry{ID: "tt_1759741200000000000", Start: start, End: &ts, Customer: "Acme", Project: "Portal",
+			Activity: "dev", Billable: true, Notes: []string{"login page"}, Tags: []string{"frontend"}}
+		b, err := json.MarshalIndent(newHookPayload(ev, &entry), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(b))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksSchemaCmd)
+	hooksSchemaCmd.Flags().BoolVar(&hooksSchemaExample, "example", false, "print an example payload instead of the schema")
+}
+
+// hookPayload is the stable JSON document described by hooks_schema.json.
+type hookPayload struct {
+	SchemaVersion int        `json:"schema_version"`
+	Event         hookEvent  `json:"event"`
+	Entry         *hookEntry `json:"entry"`
+}
+
+type hookEvent struct {
+	ID       string            `json:"id"`
+	Type     string            `json:"type"`
+	TS       time.Time         `json:"ts"`
+	User     string            `json:"user,omitempty"`
+	Customer string            `json:"customer,omitempty"`
+	Project  string            `json:"project,omitempty"`
+	Activity string            `json:"activity,omitempty"`
+	Billable *bool             `json:"billable,omitempty"`
+	Note     string            `json:"note,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Ref      string            `json:"ref,omitempty"`
+	Meta     map[string]string `json:"meta,omitempty"`
+}
+
+type hookEntry struct {
+	ID              string     `json:"id"`
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end"`
+	Running         bool       `json:"running"`
+	Customer        string     `json:"customer"`
+	Project         string     `json:"project"`
+	Activity        string     `json:"activity"`
+	Billable        bool       `json:"billable"`
+	Notes           []string   `json:"notes"`
+	Tags            []string   `json:"tags"`
+	DurationSeconds int64      `json:"duration_seconds"`
+}
+
+// newHookPayload builds the payload for ev. entry is the effective entry
+// after ev was applied, or nil.
+func newHookPayload(ev Event, entry *Entry) hookPayload {
+	p := hookPayload{
+		SchemaVersion: hookSchemaVersion,
+		Event: hookEvent{
+			ID: ev.ID, Type: ev.Type, TS: ev.TS, User: ev.User,
+			Customer: ev.Customer, Project: ev.Project, Activity: ev.Activity, Billable: ev.Billable,
+			Note: ev.Note, Tags: ev.Tags, Ref: ev.Ref, Meta: ev.Meta,
+		},
+	}
+	if entry == nil {
+		return p
+	}
+	dur := int64(entryElapsed(*entry, ev.TS) / time.Second)
+	p.Entry = &hookEntry{
+		ID: entry.ID, Start: entry.Start, End: entry.End, Running: entry.End == nil,
+		Customer: entry.Customer, Project: entry.Project, Activity: entry.Activity, Billable: entry.Billable,
+		Notes: nonNil(entry.Notes), Tags: nonNil(entry.Tags), DurationSeconds: dur,
+	}
+	return p
+}
+
+// effectiveEntryFor reconstructs the entry ev affected, after ev was
+// written: the entry it created (start, add), the entry it refers to (amend,
+// note with ref), or the entry it ended (stop).
+func effectiveEntryFor(ev Event) *Entry {
+	ents, err := loadEntries(ev.TS.AddDate(0, 0, -1), ev.TS)
+	if err != nil {
+		return nil
+	}
+	for i := len(ents) - 1; i >= 0; i-- {
+		e := ents[i]
+		switch {
+		case e.ID == ev.ID, ev.Ref != "" && e.ID == ev.Ref:
+			return &e
+		case ev.Type == "stop" && e.End != nil && e.End.Equal(ev.TS):
+			return &e
+		case ev.Type == "note" && ev.Ref == "" && e.End == nil:
+			return &e
+		}
+	}
+	return nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TestHookPayloadMatchesSchema keeps the Go payload types and the published
+// schema in step: every emitted key must be declared, every required key
+// emitted.
+func TestHookPayloadMatchesSchema(t *testing.T) {
+	type object struct {
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	var root object
+	if err := json.Unmarshal(hookPayloadSchema, &root); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	var evSchema, entrySchema object
+	if err := json.Unmarshal(root.Properties["event"], &evSchema); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(root.Properties["entry"], &entrySchema); err != nil {
+		t.Fatal(err)
+	}
+
+	hooksSchemaExample = true
+	t.Cleanup(func() { hooksSchemaExample = false })
+	var buf bytes.Buffer
+	hooksSchemaCmd.SetOut(&buf)
+	if err := hooksSchemaCmd.RunE(hooksSchemaCmd, nil); err != nil {
+		t.Fatalf("schema --example: %v", err)
+	}
+	var payload struct {
+		Event map[string]json.RawMessage `json:"event"`
+		Entry map[string]json.RawMessage `json:"entry"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("example is not valid JSON: %v", err)
+	}
+
+	check := func(name string, got map[string]json.RawMessage, s object) {
+		for k := range got {
+			if _, ok := s.Properties[k]; !ok {
+				t.Errorf("%s.%s emitted but not in schema", name, k)
+			}
+		}
+		for _, k := range s.Required {
+			if _, ok := got[k]; !ok {
+				t.Errorf("%s.%s required by schema but not emitted", name, k)
+			}
+		}
+	}
+	check("event", payload.Event, evSchema)
+	check("entry", payload.Entry, entrySchema)
+}
+
+func TestEffectiveEntryFor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	startEv := NewStartEvent("s1", "Acme", "Portal", "dev", boolPtr(true), "kickoff", nil, start)
+	stopEv := NewStopEvent("s2", start.Add(45*time.Minute))
+	if err := Writer.WriteEvent(startEv); err != nil {
+		t.Fatal(err)
+	}
+
+	running := newHookPayload(startEv, effectiveEntryFor(startEv))
+	if running.Entry == nil || !running.Entry.Running || running.Entry.ID != "s1" || running.Entry.DurationSeconds != 0 {
+		t.Fatalf("start payload entry = %+v", running.Entry)
+	}
+
+	if err := Writer.WriteEvent(stopEv); err != nil {
+		t.Fatal(err)
+	}
+	stopped := newHookPayload(stopEv, effectiveEntryFor(stopEv))
+	if stopped.Entry == nil || stopped.Entry.Running || stopped.Entry.ID != "s1" {
+		t.Fatalf("stop payload entry = %+v", stopped.Entry)
+	}
+	if stopped.Entry.DurationSeconds != 45*60 {
+		t.Errorf("duration = %d, want %d", stopped.Entry.DurationSeconds, 45*60)
+	}
+	if stopped.Event.Type != "stop" || stopped.SchemaVersion != hookSchemaVersion {
+		t.Errorf("event = %+v", stopped.Event)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar (RFC 5545) rendering shared by the served calendar feed and the
+// CalDAV push. Each finished entry becomes one VEVENT whose UID is the entry
+// ID, so calendar clients treat corrected entries as updates, not new events.
+
+const icalProdID = "-//tt//time tracker//EN"
+
+// writeICalendar writes a VCALENDAR containing one VEVENT per entry.
+func writeICalendar(w io.Writer, name string, ents []Entry, stamp time.Time) error {
+	lw := &icalWriter{w: w}
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + icalProdID)
+	lw.line("CALSCALE:GREGORIAN")
+	if name != "" {
+		lw.line("X-WR-CALNAME:" + icalText(name))
+	}
+	for _, e := range ents {
+		writeVEvent(lw, e, stamp)
+	}
+	lw.line("END:VCALENDAR")
+	return lw.err
+}
+
+// icalEventObject renders a single entry as a standalone calendar object, the
+// unit a CalDAV server stores per resource.
+func icalEventObject(e Entry, stamp time.Time) string {
+	var b strings.Builder
+	writeICalendar(&b, "", []Entry{e}, stamp)
+	return b.String()
+}
+
+func writeVEvent(lw *icalWriter, e Entry, stamp time.Time) {
+	lw.line("BEGIN:VEVENT")
+	lw.line("UID:" + icalText(e.ID))
+	lw.line("DTSTAMP:" + icalTime(stamp))
+	lw.line("DTSTART:" + icalTime(e.Start))
+	if e.End != nil {
+		lw.line("DTEND:" + icalTime(*e.End))
+	}
+	lw.line("SUMMARY:" + icalText(icalSummary(e)))
+	var desc []string
+	if len(e.Notes) > 0 {
+		desc = append(desc, strings.Join(e.Notes, "\n"))
+	}
+	if e.Billable {
+		desc = append(desc, "billable")
+	}
+	if len(desc) > 0 {
+		lw.line("DESCRIPTION:" + icalText(strings.Join(desc, "\n\n")))
+	}
+	if len(e.Tags) > 0 {
+		tags := make([]string, len(e.Tags))
+		for i, t := range e.Tags {
+			tags[i] = icalText(t)
+		}
+		lw.line("CATEGORIES:" + strings.Join(tags, ","))
+	}
+	lw.line("TRANSP:TRANSPARENT")
+	lw.line("END:VEVENT")
+}
+
+// icalSummary reads "Customer / Project (activity)", omitting empty parts.
+func icalSummary(e Entry) string {
+	s := e.Customer
+	if e.Project != "" {
+		if s != "" {
+			s += " / "
+		}
+		s += e.Project
+	}
+	if e.Activity != "" {
+		if s == "" {
+			return e.Activity
+		}
+		s += " (" + e.Activity + ")"
+	}
+	if s == "" {
+		return "tt entry"
+	}
+	return s
+}
+
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icalText escapes a TEXT property value.
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icalText(s string) string { return icalEscaper.Replace(s) }
+
+// icalWriter writes CRLF-terminated content lines folded at 75 octets.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (lw *icalWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	_, lw.err = io.WriteString(lw.w, b.String())
+}
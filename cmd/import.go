@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// importCmd groups integrations that bring data or events from other tools
+// into the journal.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Integrations that feed other tools into tt (task-hooks)",
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/billing"
+)
+
+var (
+	invoiceCustomer   string
+	invoiceRange      string
+	invoiceFormat     string
+	invoiceOut        string
+	invoiceNumber     string
+	invoiceTax        float64
+	invoiceLocale     string
+	invoiceNoExpenses bool
+	invoiceRatesLoc   string
+)
+
+// invoiceCmd groups the invoice commands: tt invoice create renders one
+// customer's billable time and expenses over a range as an invoice (JSON,
+// Markdown or HTML), priced with the rates config (see rates.go) after
+// rounding, like tt push invoice-ninja; tt invoice rates shows which rate
+// applies.
+//
+// Config (~/.tt/config.yaml):
+//
+//	invoice:
+//	  tax_percent: 19       # default --tax
+//	  due_days: 14          # payment term (default 14; 0 leaves out the due date)
+//	  issuer: |             # sender block at the top
+//	    Jane Doe · Consulting
+//	    Main St 1, 12345 Berlin
+var invoiceCmd = &cobra.Command{
+	Use:   "invoice",
+	Short: "Generate invoices from billable time and the configured rates",
+}
+
+var invoiceCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Render a customer's billable time and expenses as an invoice",
+	Long: "Build an invoice for --customer over --range (default lastmonth): one section per project with a line " +
+		"item per day (hours after rounding, as in tt report), billable expenses in their own section, subtotals, " +
+		"tax (--tax or invoice.tax_percent) and the total. Rates come from the rates config; a project without a " +
+		"rate stops the invoice.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if invoiceCustomer == "" {
+			return fmt.Errorf("--customer is required")
+		}
+		from, to := parseRangeFlags(false, false, invoiceRange)
+		noteUsageRange(cmd, from, to)
+		tax := viper.GetFloat64("invoice.tax_percent")
+		if cmd.Flags().Changed("tax") {
+			tax = invoiceTax
+		}
+		inv, err := buildInvoice(invoiceCustomer, from, to, tax, !invoiceNoExpenses)
+		if err != nil {
+			return err
+		}
+		if inv.Empty() {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No billable time or expenses for this customer in the selected range.")
+			return nil
+		}
+		w := cmd.OutOrStdout()
+		if invoiceOut != "" && invoiceOut != "-" {
+			f, err := os.Create(invoiceOut)
+			if err != nil {
+				return err
+			}
+			if err := billing.Render(f, inv, invoiceFormat); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Wrote invoice %s (%s) to %s\n", inv.Number, inv.FormatMoney(inv.Total), invoiceOut)
+			return nil
+		}
+		return billing.Render(w, inv, invoiceFormat)
+	},
+}
+
+var invoiceRatesCmd = &cobra.Command{
+	Use:   "rates [customer [project]]",
+	Short: "List the configured hourly rates, or show which one applies",
+	Args:  cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rates := configRates()
+		if len(args) > 0 {
+			project := ""
+			if len(args) == 2 {
+				project = args[1]
+			}
+			printRateResolution(cmd.OutOrStdout(), rates, args[0], project, invoiceRatesLoc)
+			return nil
+		}
+		printRates(cmd.OutOrStdout(), rates, invoiceRatesLoc)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(invoiceCmd)
+	invoiceCmd.AddCommand(invoiceCreateCmd, invoiceRatesCmd)
+	f := invoiceCreateCmd.Flags()
+	f.StringVar(&invoiceCustomer, "customer", "", "customer to invoice (required)")
+	f.StringVar(&invoiceRange, "range", "lastmonth", "range A..B or a period like lastmonth, month:2025-10, q3")
+	f.StringVar(&invoiceFormat, "format", "markdown", "Output format: "+strings.Join(billing.Formats, "|"))
+	f.StringVar(&invoiceOut, "out", "", "write the invoice to this file instead of stdout")
+	f.StringVar(&invoiceNumber, "number", "", "invoice number (default CUSTOMER-YYYY-MM of the range start)")
+	f.Float64Var(&invoiceTax, "tax", 0, "tax in percent of the net amount (default invoice.tax_percent)")
+	f.StringVar(&invoiceLocale, "locale", "de", "Locale for amounts: de|en")
+	f.BoolVar(&invoiceNoExpenses, "no-expenses", false, "leave out billable expenses")
+	invoiceRatesCmd.Flags().StringVar(&invoiceRatesLoc, "locale", "de", "Locale for amounts: de|en")
+}
+
+// buildInvoice prices customer's finished billable entries (and, with
+// expenses, billable expenses) between the days from and to.
+func buildInvoice(customer string, from, to time.Time, tax float64, expenses bool) (billing.Invoice, error) {
+	ents, err := finishedEntries(from, to)
+	if err != nil {
+		return billing.Invoice{}, err
+	}
+	items, err := invoiceLineItems(ents, customer, getRounding())
+	if err != nil {
+		return billing.Invoice{}, err
+	}
+	if expenses {
+		exps, err := loadExpenses(from, to)
+		if err != nil {
+			return billing.Invoice{}, err
+		}
+		expItems, err := invoiceExpenseItems(exps, customer)
+		if err != nil {
+			return billing.Invoice{}, err
+		}
+		items = append(items, expItems...)
+	}
+	// the customer as spelled in the journal, not as typed
+	for _, e := range ents {
+		if strings.EqualFold(e.Customer, customer) {
+			customer = e.Customer
+			break
+		}
+	}
+
+	issued := Now().In(parserLocation())
+	inv := billing.Invoice{
+		Number:   invoiceNumber,
+		Customer: customer,
+		Issuer:   viper.GetString("invoice.issuer"),
+		Issued:   issued.Format("2006-01-02"),
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Currency: rateCurrency(),
+		Locale:   invoiceLocale,
+	}
+	if inv.Number == "" {
+		inv.Number = strings.ToUpper(customer) + "-" + from.Format("2006-01")
+	}
+	due := 14
+	if viper.IsSet("invoice.due_days") {
+		due = viper.GetInt("invoice.due_days")
+	}
+	if due > 0 {
+		inv.Due = issued.AddDate(0, 0, due).Format("2006-01-02")
+	}
+	// time by project, then the expenses
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Expense != items[j].Expense {
+			return !items[i].Expense
+		}
+		if items[i].Expense {
+			return items[i].Date < items[j].Date
+		}
+		return items[i].Project < items[j].Project
+	})
+	for _, li := range items {
+		switch {
+		case li.Expense:
+			inv.Add("Expenses", billing.Line{Date: li.Date, Description: li.Desc, Quantity: 1, Rate: li.Rate})
+		default:
+			title := li.Project
+			if title == "" {
+				title = "Time"
+			}
+			inv.Add(title, billing.Line{Date: li.Date, Description: li.Desc, Quantity: li.Hours, Unit: "h", Rate: li.Rate})
+		}
+	}
+	inv.Finish(tax)
+	return inv, nil
+}
+
+// printRates lists the rates hierarchy: the default, then each customer's
+// rate and project rates.
+func printRates(w io.Writer, r billing.Rates, locale string) {
+	money := func(v float64) string { return billing.FormatMoney(v, r.CurrencyOrDefault(), locale) + "/h" }
+	fmt.Fprintf(w, "%sRates (%s):%s\n", ansiHeading, r.CurrencyOrDefault(), ansiReset)
+	if r.Default == nil && len(r.Customers) == 0 {
+		fmt.Fprintln(w, "  none configured (rates.default, rates.customers.<customer>)")
+		return
+	}
+	if r.Default != nil {
+		fmt.Fprintf(w, "  %-28s %14s\n", "default", money(*r.Default))
+	}
+	names := make([]string, 0, len(r.Customers))
+	for c := range r.Customers {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+	for _, c := range names {
+		cr := r.Customers[c]
+		if cr.Rate != nil {
+			fmt.Fprintf(w, "  %-28s %14s\n", c, money(*cr.Rate))
+		}
+		projects := make([]string, 0, len(cr.Projects))
+		for p := range cr.Projects {
+			projects = append(projects, p)
+		}
+		sort.Strings(projects)
+		for _, p := range projects {
+			fmt.Fprintf(w, "  %-28s %14s\n", c+"/"+p, money(cr.Projects[p]))
+		}
+	}
+}
+
+// printRateResolution shows the rate for customer/project and the level it
+// comes from.
+func printRateResolution(w io.Writer, r billing.Rates, customer, project, locale string) {
+	label := customer
+	if project != "" {
+		label += "/" + project
+	}
+	rate, src := r.Resolve(customer, project)
+	if src == "" {
+		fmt.Fprintf(w, "%s: no rate (set rates.default or rates.customers.%s)\n", label, strings.ToLower(customer))
+		return
+	}
+	fmt.Fprintf(w, "%s: %s/h (%s rate)\n", label, billing.FormatMoney(rate, r.CurrencyOrDefault(), locale), src)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestInvoiceCreate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("rates.default", 90)
+	viper.Set("rates.customers.acme.projects.portal", 140)
+	viper.Set("rounding.quantum_min", 15)
+	viper.Set("rounding.strategy", "up")
+	viper.Set("invoice.tax_percent", 19)
+	prevNow := Now
+	Now = func() time.Time { return time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("rates", nil)
+		viper.Set("rounding.quantum_min", nil)
+		viper.Set("rounding.strategy", nil)
+		viper.Set("invoice", nil)
+		Now = prevNow
+		invoiceCustomer, invoiceRange, invoiceFormat, invoiceNumber = "", "lastmonth", "markdown", ""
+		expCustomer, expProject, expDate, expBillable = "", "", "", true
+	})
+
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	yes, no := true, false
+	track := func(id, project string, start time.Time, min int, billable *bool, note string) {
+		t.Helper()
+		if err := writeEvent(NewStartEvent(id, "Acme", project, "", billable, note, nil, start)); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeEvent(NewStopEvent(id+"s", start.Add(time.Duration(min)*time.Minute))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	track("tt_1", "portal", day, 50, &yes, "login")                       // 1h
+	track("tt_2", "ops", day.Add(2*time.Hour), 20, &yes, "backup")        // 0.5h at the default rate
+	track("tt_3", "portal", day.AddDate(0, 0, 1), 30, &yes, "deploy")     // 0.5h
+	track("tt_4", "portal", day.Add(4*time.Hour), 60, &no, "internal")    // not billable
+	track("tt_5", "portal", day.AddDate(0, 1, 0), 60, &yes, "next month") // outside lastmonth
+	expenseAddCmd.SetOut(&bytes.Buffer{})
+	expCustomer, expProject, expDate, expBillable = "acme", "", "2025-10-08", true
+	if err := expenseAddCmd.RunE(expenseAddCmd, []string{"49.90EUR", "train"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	invoiceCreateCmd.SetOut(&out)
+	invoiceCustomer = "acme"
+	if err := invoiceCreateCmd.RunE(invoiceCreateCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	// ops 45,00 + portal 210,00 + expenses 49,90 = 304,90 net, 57,93 tax
+	for _, want := range []string{
+		"# Invoice ACME-2025-10",
+		"**Customer:** Acme",
+		"**Period:** 2025-10-01 – 2025-10-31",
+		"**Due:** 2025-11-17",
+		"## ops\n",
+		"| 2025-10-06 | login | 1.00h | 140,00 € | 140,00 € |",
+		"| | **Subtotal portal** | 1.50h | | **210,00 €** |",
+		"| 2025-10-08 | train | 1.00 | 49,90 € | 49,90 € |",
+		"**Net:** 304,90 €",
+		"**Tax (19%):** 57,93 €",
+		"**Total:** 362,83 €",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("invoice lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "internal") || strings.Contains(out.String(), "next month") {
+		t.Errorf("invoice has non-billable or out-of-range time:\n%s", out.String())
+	}
+
+	// A project without a rate stops the invoice.
+	viper.Set("rates.default", nil)
+	if err := invoiceCreateCmd.RunE(invoiceCreateCmd, nil); err == nil || !strings.Contains(err.Error(), "ops") {
+		t.Fatalf("missing rate err = %v", err)
+	}
+}
+
+func TestInvoiceRates(t *testing.T) {
+	viper.Set("rates.currency", "EUR")
+	viper.Set("rates.default", 90)
+	viper.Set("rates.customers.acme.rate", 120)
+	viper.Set("rates.customers.acme.projects.portal", 140)
+	t.Cleanup(func() { viper.Set("rates", nil) })
+
+	var out bytes.Buffer
+	printRates(&out, configRates(), "de")
+	want := "Rates (EUR):\n" +
+		"  default                           90,00 €/h\n" +
+		"  acme                             120,00 €/h\n" +
+		"  acme/portal                      140,00 €/h\n"
+	if got := stripANSI(out.String()); got != want {
+		t.Fatalf("rates =\n%s\nwant\n%s", got, want)
+	}
+
+	out.Reset()
+	printRateResolution(&out, configRates(), "Acme", "intranet", "en")
+	if got := out.String(); got != "Acme/intranet: €120.00/h (customer rate)\n" {
+		t.Fatalf("resolution = %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Issue references in notes and tags.
+//
+// Recognized forms:
+//   - https://github.com/owner/repo/issues/123 (or /pull/123)
+//   - https://gitlab.example.com/group/project/-/issues/123 (or /merge_requests/123)
+//   - #123, resolved against issues.repos.<customer> or issues.default_repo
+//     ("github:owner/repo" or "gitlab:group/project")
+//
+// Titles are fetched from the GitHub/GitLab APIs (tokens optional; needed for
+// private repositories) and cached in ~/.tt/cache/issues.json.
+
+type issueRef struct {
+	Provider string // github | gitlab
+	Repo     string // owner/repo or GitLab project path
+	Kind     string // issues | pull | merge_requests
+	Number   int
+}
+
+// Key identifies the issue across entries, e.g. "github:acme/portal#12".
+func (r issueRef) Key() string {
+	sep := "#"
+	if r.Kind == "merge_requests" {
+		sep = "!"
+	}
+	return r.Provider + ":" + r.Repo + sep + strconv.Itoa(r.Number)
+}
+
+var (
+	githubIssueURLRe = regexp.MustCompile(`https?://github\.com/([\w.-]+/[\w.-]+)/(issues|pull)/(\d+)`)
+	gitlabIssueURLRe = regexp.MustCompile(`https?://[\w.-]+(?::\d+)?/([\w.-]+(?:/[\w.-]+)+)/-/(issues|merge_requests)/(\d+)`)
+	bareIssueRe      = regexp.MustCompile(`\B#(\d+)\b`)
+	anyIssueRe       = regexp.MustCompile(githubIssueURLRe.String() + `|` + gitlabIssueURLRe.String() + `|` + bareIssueRe.String())
+)
+
+// parseIssueRef parses one match of anyIssueRe. Bare #N references resolve to
+// the repository configured for customer and fail when none is configured.
+func parseIssueRef(s, customer string) (issueRef, bool) {
+	if m := githubIssueURLRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[3])
+		return issueRef{Provider: "github", Repo: m[1], Kind: m[2], Number: n}, true
+	}
+	if m := gitlabIssueURLRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[3])
+		return issueRef{Provider: "gitlab", Repo: m[1], Kind: m[2], Number: n}, true
+	}
+	if m := bareIssueRe.FindStringSubmatch(s); m != nil {
+		provider, repo, ok := issueRepoFor(customer)
+		if !ok {
+			return issueRef{}, false
+		}
+		n, _ := strconv.Atoi(m[1])
+		return issueRef{Provider: provider, Repo: repo, Kind: "issues", Number: n}, true
+	}
+	return issueRef{}, false
+}
+
+// issueRepoFor returns the repository bare #N references of customer point to.
+func issueRepoFor(customer string) (provider, repo string, ok bool) {
+	spec := ""
+	if customer != "" {
+		spec = viper.GetString("issues.repos." + strings.ToLower(customer))
+	}
+	if spec == "" {
+		spec = viper.GetString("issues.default_repo")
+	}
+	provider, repo, found := strings.Cut(spec, ":")
+	if !found || repo == "" || (provider != "github" && provider != "gitlab") {
+		return "", "", false
+	}
+	return provider, repo, true
+}
+
+// entryIssueRefs returns the distinct issues referenced by an entry's notes
+// and tags, in order of first appearance.
+func entryIssueRefs(e Entry) []issueRef {
+	var refs []issueRef
+	seen := map[string]bool{}
+	texts := append(append([]string{}, e.Notes...), e.Tags...)
+	for _, t := range texts {
+		for _, m := range anyIssueRe.FindAllString(t, -1) {
+			ref, ok := parseIssueRef(m, e.Customer)
+			if !ok || seen[ref.Key()] {
+				continue
+			}
+			seen[ref.Key()] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// annotateIssueNotes returns e with known issue titles appended after each
+// reference in its notes: "fix #12" → `fix #12 "Login fails"`.
+func annotateIssueNotes(e Entry, title func(issueRef) string) Entry {
+	if len(e.Notes) == 0 {
+		return e
+	}
+	notes := make([]string, len(e.Notes))
+	for i, n := range e.Notes {
+		notes[i] = anyIssueRe.ReplaceAllStringFunc(n, func(m string) string {
+			ref, ok := parseIssueRef(m, e.Customer)
+			if !ok {
+				return m
+			}
+			if t := title(ref); t != "" && !strings.Contains(n, t) {
+				return m + ` "` + t + `"`
+			}
+			return m
+		})
+	}
+	e.Notes = notes
+	return e
+}
+
+// issueResolver fetches and caches issue titles. Lookup failures are cached
+// for the lifetime of the resolver only, so a later run retries them.
+type issueResolver struct {
+	cachePath string
+	titles    map[string]string
+	failed    map[string]bool
+	dirty     bool
+	offline   bool
+
+	githubAPI   string
+	githubToken string
+	gitlabURL   string
+	gitlabToken string
+	http        *http.Client
+}
+
+func newIssueResolver(offline bool) *issueResolver {
+	home, _ := os.UserHomeDir()
+	r := &issueResolver{
+		cachePath:   filepath.Join(home, ".tt", "cache", "issues.json"),
+		titles:      map[string]string{},
+		failed:      map[string]bool{},
+		offline:     offline,
+		githubAPI:   viper.GetString("issues.github.api_url"),
+		githubToken: configSecret("issues.github.token"),
+		gitlabURL:   viper.GetString("issues.gitlab.url"),
+		gitlabToken: configSecret("issues.gitlab.token"),
+		http:        &http.Client{Timeout: 10 * time.Second},
+	}
+	if r.githubAPI == "" {
+		r.githubAPI = "https://api.github.com"
+	}
+	if r.githubToken == "" {
+		r.githubToken = os.Getenv("GITHUB_TOKEN")
+	}
+	if r.gitlabURL == "" {
+		r.gitlabURL = "https://gitlab.com"
+	}
+	if r.gitlabToken == "" {
+		r.gitlabToken = os.Getenv("GITLAB_TOKEN")
+	}
+	if b, err := os.ReadFile(r.cachePath); err == nil {
+		_ = json.Unmarshal(b, &r.titles)
+	}
+	return r
+}
+
+// Title returns the cached or fetched title, or "" when unknown.
+func (r *issueResolver) Title(ref issueRef) string {
+	key := ref.Key()
+	if t, ok := r.titles[key]; ok {
+		return t
+	}
+	if r.offline || r.failed[key] {
+		return ""
+	}
+	t, err := r.fetch(context.Background(), ref)
+	if err != nil || t == "" {
+		r.failed[key] = true
+		return ""
+	}
+	r.titles[key] = t
+	r.dirty = true
+	return t
+}
+
+func (r *issueResolver) fetch(ctx context.Context, ref issueRef) (string, error) {
+	var u, header, token string
+	switch ref.Provider {
+	case "github":
+		// The issues endpoint also answers for pull requests.
+		u = fmt.Sprintf("%s/repos/%s/issues/%d", strings.TrimRight(r.githubAPI, "/"), ref.Repo, ref.Number)
+		header, token = "Authorization", r.githubToken
+		if token != "" {
+			token = "Bearer " + token
+		}
+	case "gitlab":
+		u = fmt.Sprintf("%s/api/v4/projects/%s/%s/%d", strings.TrimRight(r.gitlabURL, "/"), url.PathEscape(ref.Repo), ref.Kind, ref.Number)
+		header, token = "PRIVATE-TOKEN", r.gitlabToken
+	default:
+		return "", fmt.Errorf("unknown issue provider %q", ref.Provider)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set(header, token)
+	}
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	var body struct {
+		Title string `json:"title"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	return body.Title, nil
+}
+
+// Save persists newly fetched titles.
+func (r *issueResolver) Save() error {
+	if !r.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.cachePath), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r.titles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.cachePath, b, 0o644); err != nil {
+		return err
+	}
+	r.dirty = false
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestEntryIssueRefs(t *testing.T) {
+	viper.Set("issues.default_repo", "github:acme/portal")
+	viper.Set("issues.repos.globex", "gitlab:globex/site")
+	t.Cleanup(func() {
+		viper.Set("issues.default_repo", "")
+		viper.Set("issues.repos.globex", "")
+	})
+
+	cases := []struct {
+		name string
+		e    Entry
+		want []string
+	}{
+		{"github url and bare", Entry{Customer: "Acme", Notes: []string{"see https://github.com/acme/api/pull/7 and #12"}},
+			[]string{"github:acme/api#7", "github:acme/portal#12"}},
+		{"gitlab url and tag", Entry{Customer: "Acme", Notes: []string{"https://git.example.com/grp/sub/proj/-/merge_requests/3"}, Tags: []string{"#12"}},
+			[]string{"gitlab:grp/sub/proj!3", "github:acme/portal#12"}},
+		{"customer repo", Entry{Customer: "Globex", Notes: []string{"fix #5, again #5"}}, []string{"gitlab:globex/site#5"}},
+		{"not a ref", Entry{Customer: "Acme", Notes: []string{"color#333 and C#"}}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, r := range entryIssueRefs(tc.e) {
+				got = append(got, r.Key())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Fatalf("refs = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	viper.Set("issues.default_repo", "")
+	if refs := entryIssueRefs(Entry{Customer: "Acme", Notes: []string{"#12"}}); len(refs) != 0 {
+		t.Fatalf("bare refs need a configured repo, got %v", refs)
+	}
+}
+
+func TestIssueResolverAndReport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.EscapedPath() {
+		case "/repos/acme/portal/issues/12":
+			if r.Header.Get("Authorization") != "Bearer gh" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"title":"Login fails on Safari"}`))
+		case "/api/v4/projects/globex%2Fsite/issues/5":
+			w.Write([]byte(`{"title":"Broken footer"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	viper.Set("issues.default_repo", "github:acme/portal")
+	viper.Set("issues.repos.globex", "gitlab:globex/site")
+	viper.Set("issues.github.api_url", srv.URL)
+	viper.Set("issues.github.token", "gh")
+	viper.Set("issues.gitlab.url", srv.URL)
+	t.Cleanup(func() {
+		for _, k := range []string{"issues.default_repo", "issues.repos.globex", "issues.github.api_url", "issues.github.token", "issues.gitlab.url"} {
+			viper.Set(k, "")
+		}
+	})
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	mk := func(cust string, min int, note string) Entry {
+		end := start.Add(time.Duration(min) * time.Minute)
+		return Entry{Customer: cust, Start: start, End: &end, Notes: []string{note}}
+	}
+	ents := []Entry{
+		mk("Acme", 60, "fix #12"),
+		mk("Acme", 31, "#12 and #99"), // split 16/15; #99 does not resolve
+		mk("Globex", 45, "footer #5"),
+		mk("Acme", 20, "meeting"),
+	}
+
+	res := newIssueResolver(false)
+	annotated := annotateIssueNotes(ents[0], res.Title)
+	if annotated.Notes[0] != `fix #12 "Login fails on Safari"` || ents[0].Notes[0] != "fix #12" {
+		t.Fatalf("annotate: got %q (original %q)", annotated.Notes[0], ents[0].Notes[0])
+	}
+
+	var out bytes.Buffer
+	printIssueReport(&out, ents, res.Title)
+	got := out.String()
+	for _, want := range []string{
+		"   1h16m  github:acme/portal#12  Login fails on Safari",
+		"     45m  gitlab:globex/site#5  Broken footer",
+		"     15m  github:acme/portal#99  \n",
+		"TOTAL: 2h16m across 3 issue(s); unlinked: 20m",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if err := res.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// A new resolver answers from the cache without network access.
+	before := calls
+	cached := newIssueResolver(true)
+	if cached.Title(issueRef{Provider: "gitlab", Repo: "globex/site", Kind: "issues", Number: 5}) != "Broken footer" || calls != before {
+		t.Fatalf("expected cached title without requests (calls %d → %d)", before, calls)
+	}
+}
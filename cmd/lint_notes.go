@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+// Levels of lint.missing_notes.
+const (
+	lintOff   = "off"
+	lintWarn  = "warn"
+	lintError = "error"
+)
+
+// missingNotesLevel is lint.missing_notes: billable groups without notes are
+// listed in report week and checked before the Tempo export and the Harvest
+// push ("warn", the default), block those exports ("error") or are ignored
+// ("off"). Clients tend to reject invoice lines without a description.
+//
+//	lint:
+//	  missing_notes: warn
+func missingNotesLevel() string {
+	switch l := strings.ToLower(strings.TrimSpace(viper.GetString("lint.missing_notes"))); l {
+	case lintOff, lintError:
+		return l
+	}
+	return lintWarn
+}
+
+// weekMissingNotes labels the billable day groups of a week report whose
+// merged notes are empty.
+func weekMissingNotes(days []reporting.Day) []string {
+	if missingNotesLevel() == lintOff {
+		return nil
+	}
+	var out []string
+	for _, d := range days {
+		for _, g := range d.Groups {
+			if !g.Billable || strings.TrimSpace(g.NotesMerged) != "" {
+				continue
+			}
+			label := d.Date + " " + g.Customer
+			if g.Project != "" {
+				label += " / " + g.Project
+			}
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// checkMissingNotes is the pre-push check for target: it warns about the
+// groups listed in missing, or fails with lint.missing_notes: error.
+func checkMissingNotes(w io.Writer, target string, missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	switch missingNotesLevel() {
+	case lintOff:
+		return nil
+	case lintError:
+		return fmt.Errorf("%s: %d billable group(s) without notes: %s (add notes, or set lint.missing_notes: warn)",
+			target, len(missing), strings.Join(missing, "; "))
+	}
+	fmt.Fprintf(w, "warning: %s: %d billable group(s) without notes:\n", target, len(missing))
+	for _, m := range missing {
+		fmt.Fprintf(w, "  - %s\n", m)
+	}
+	return nil
+}
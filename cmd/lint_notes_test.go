@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/rounding"
+)
+
+func TestWeekMissingNotes(t *testing.T) {
+	t.Cleanup(func() { viper.Set("lint.missing_notes", "") })
+	at := func(h int) time.Time { return time.Date(2025, 10, 6, h, 0, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	agg := newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 60}, at(18))
+	for _, e := range []Entry{
+		{ID: "a", Start: at(9), End: end(at(10)), Customer: "Acme", Project: "web", Billable: true},
+		{ID: "b", Start: at(10), End: end(at(11)), Customer: "Acme", Project: "api", Billable: true, Notes: []string{"deploy"}},
+		{ID: "c", Start: at(11), End: end(at(12)), Customer: "Internal"},
+	} {
+		_ = agg.add(e)
+	}
+	days := agg.Days(at(0), at(0), "en", 0)
+
+	missing := weekMissingNotes(days)
+	if len(missing) != 1 || missing[0] != "2025-10-06 Acme / web" {
+		t.Fatalf("missing = %q", missing)
+	}
+	var buf bytes.Buffer
+	if err := checkMissingNotes(&buf, "tempo export", missing); err != nil || !strings.Contains(buf.String(), "1 billable group(s) without notes") {
+		t.Fatalf("warn: err = %v, out = %q", err, buf.String())
+	}
+
+	viper.Set("lint.missing_notes", "error")
+	if err := checkMissingNotes(&buf, "tempo export", missing); err == nil {
+		t.Fatal("error level did not fail")
+	}
+	viper.Set("lint.missing_notes", "off")
+	if got := weekMissingNotes(days); got != nil {
+		t.Fatalf("off: missing = %q", got)
+	}
+}
+
+func TestPushHarvestMissingNotesBlocks(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("lint.missing_notes", "error")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("lint.missing_notes", "")
+	})
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	cfg := harvestConfig{Mapping: []harvestMapping{{Customer: "Acme", ProjectID: 1, TaskID: 10}}}
+	ents := []Entry{{ID: "e1", Start: start, End: &end, Customer: "Acme", Billable: true}}
+
+	var out bytes.Buffer
+	err := pushHarvest(context.Background(), &out, cfg, ents, start, end, false)
+	if err == nil || !strings.Contains(err.Error(), "e1 2025-10-06 Acme/") {
+		t.Fatalf("err = %v", err)
+	}
+	out.Reset()
+	if err := pushHarvest(context.Background(), &out, cfg, ents, start, end, true); err != nil || !strings.Contains(out.String(), "would fail") {
+		t.Fatalf("dry run: err = %v, out = %q", err, out.String())
+	}
+}
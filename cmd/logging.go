@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Warnings and diagnostics (failed sweeps, unreadable day files, audit
+// details) go through a leveled logger on stderr rather than into the
+// command's output, so reports piped to a file stay clean. The terminal shows
+// warnings and errors; -v adds info, -vv debug. With log.file the records are
+// also appended, with timestamps, to that file at log.level (default info).
+//
+//	log:
+//	  file: ~/.tt/tt.log
+//	  level: info   # debug | info | warn | error
+
+var verbosity int
+
+// logger is the CLI's logger; initLogging configures it from -v and the
+// config once they are read.
+var logger = slog.New(newConsoleHandler(os.Stderr, slog.LevelWarn))
+
+// logFile is the open log.file, closed when the logger is reconfigured.
+var logFile *os.File
+
+func init() {
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "log more on stderr: -v info, -vv debug (config: log.file, log.level)")
+}
+
+// initLogging sets up logger from -v, log.file and log.level and makes it the
+// default for slog and the standard log package. A log file that cannot be
+// opened is reported on stderr and left out.
+func initLogging() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+	handlers := []slog.Handler{newConsoleHandler(os.Stderr, verbosityLevel(verbosity))}
+	if path := viper.GetString("log.file"); path != "" {
+		level := slog.LevelInfo
+		if s := viper.GetString("log.level"); s != "" {
+			if err := level.UnmarshalText([]byte(s)); err != nil {
+				fmt.Fprintf(os.Stderr, "WARN: log.level: %v\n", err)
+				level = slog.LevelInfo
+			}
+		}
+		f, err := openLogFile(expandHome(path))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: log.file: %v\n", err)
+		} else {
+			logFile = f
+			handlers = append(handlers, slog.NewTextHandler(f, &slog.HandlerOptions{Level: level}))
+		}
+	}
+	if len(handlers) == 1 {
+		logger = slog.New(handlers[0])
+	} else {
+		logger = slog.New(teeHandler(handlers))
+	}
+	// Packages that log through slog's default or the standard log package
+	// end up here too; plain log lines are warnings.
+	slog.SetDefault(logger)
+	log.SetOutput(slog.NewLogLogger(logger.Handler(), slog.LevelWarn).Writer())
+}
+
+// verbosityLevel is the stderr level for -v repeated n times.
+func verbosityLevel(n int) slog.Level {
+	switch {
+	case n >= 2:
+		return slog.LevelDebug
+	case n == 1:
+		return slog.LevelInfo
+	}
+	return slog.LevelWarn
+}
+
+func openLogFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+}
+
+// consoleHandler writes one line per record the way tt always printed its
+// warnings: "WARN: sweep auto-stops failed: <err> start=tt_1". An err
+// attribute follows the message; other attributes come after it as
+// key=value.
+type consoleHandler struct {
+	w     io.Writer
+	level slog.Leveler
+	mu    *sync.Mutex
+	attrs []slog.Attr
+}
+
+func newConsoleHandler(w io.Writer, level slog.Leveler) *consoleHandler {
+	return &consoleHandler{w: w, level: level, mu: &sync.Mutex{}}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Level.String())
+	b.WriteString(": ")
+	b.WriteString(r.Message)
+	var errText string
+	var rest []string
+	add := func(a slog.Attr) bool {
+		if a.Equal(slog.Attr{}) {
+			return true
+		}
+		if a.Key == "err" {
+			errText = a.Value.String()
+			return true
+		}
+		rest = append(rest, a.Key+"="+fmt.Sprint(a.Value.Resolve().Any()))
+		return true
+	}
+	for _, a := range h.attrs {
+		add(a)
+	}
+	r.Attrs(add)
+	if errText != "" {
+		b.WriteString(": " + errText)
+	}
+	for _, kv := range rest {
+		b.WriteString(" " + kv)
+	}
+	b.WriteByte('\n')
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &c
+}
+
+// WithGroup is not used by tt; groups are flattened.
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// teeHandler sends each record to every handler that takes its level.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
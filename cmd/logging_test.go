@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// captureLog sends logger's records at level and above to the returned
+// buffer for the rest of the test.
+func captureLog(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := logger
+	logger = slog.New(newConsoleHandler(&buf, level))
+	t.Cleanup(func() { logger = prev })
+	return &buf
+}
+
+func TestConsoleHandlerFormat(t *testing.T) {
+	buf := captureLog(t, slog.LevelWarn)
+	logger.Info("hidden")
+	logger.With("start", "tt_1").Warn("failed to write auto-stop", "err", errors.New("disk full"), "n", 2)
+	logger.Error("plain")
+	want := "WARN: failed to write auto-stop: disk full start=tt_1 n=2\nERROR: plain\n"
+	if buf.String() != want {
+		t.Fatalf("log =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestInitLoggingVerbosityAndFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	prevLogger, prevVerbosity, prevDefault := logger, verbosity, slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(prevDefault)
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+		viper.Set("log", nil)
+		if logFile != nil {
+			logFile.Close()
+			logFile = nil
+		}
+		logger, verbosity = prevLogger, prevVerbosity
+	})
+
+	for n, want := range map[int]slog.Level{0: slog.LevelWarn, 1: slog.LevelInfo, 3: slog.LevelDebug} {
+		verbosity = n
+		initLogging()
+		if !logger.Enabled(context.Background(), want) || (want > slog.LevelDebug && logger.Enabled(context.Background(), want-4)) {
+			t.Errorf("-v x%d: stderr level is not %v", n, want)
+		}
+	}
+
+	verbosity = 0
+	viper.Set("log", map[string]any{"file": "~/.tt/tt.log", "level": "debug"})
+	initLogging()
+	logger.Debug("sweep", "files", 3)
+	logger.Warn("failed to load some entries", "err", errors.New("bad line"))
+	log.Printf("journal watch: %v", errors.New("too many files"))
+	slog.Info("from the default logger")
+	b, err := os.ReadFile(filepath.Join(home, ".tt", "tt.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"level=DEBUG msg=sweep files=3", `level=WARN msg="failed to load some entries" err="bad line"`,
+		`level=WARN msg="journal watch: too many files"`, `level=INFO msg="from the default logger"`, "time="} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("log file lacks %q:\n%s", want, b)
+		}
+	}
+}
@@ -9,6 +9,9 @@ import (
 var (
 	lsToday bool
 	lsRange string
+	lsUsers []string
+	lsWhere []string
+	lsOpen  openMode
 )
 
 var lsCmd = &cobra.Command{
@@ -16,28 +19,47 @@ var lsCmd = &cobra.Command{
 	Short: "List entries for a period (default today)",
 	Run: func(cmd *cobra.Command, args []string) {
 		from, to := parseRangeFlags(lsToday, false, lsRange)
+		noteUsageRange(cmd, from, to)
 		entries, _ := loadEntries(from, to)
+		where, err := parseWhere(lsWhere)
+		cobra.CheckErr(err)
+		entries = filterWhere(filterUsers(entries, lsUsers), where)
+		// Without --include-open running entries are listed as running.
+		open := openEntries{}
+		if cmd.Flags().Changed("include-open") {
+			entries, open, err = applyOpenMode(entries, lsOpen, Now())
+			cobra.CheckErr(err)
+		}
+		w := cmd.OutOrStdout()
 		if len(entries) == 0 {
-			fmt.Println("No entries.")
+			fmt.Fprintln(w, "No entries.")
+			printOpenNote(w, open)
 			return
 		}
-		fmt.Printf("Range: %s..%s\n\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		fmt.Fprintf(w, "Range: %s..%s\n\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
 		for _, e := range entries {
 			end := "running"
 			if e.End != nil {
 				end = e.End.Format("15:04")
 			}
-			fmt.Printf("%s  %s-%s  %-8s  %-20s  %-20s  billable=%v  %s\n",
+			fmt.Fprintf(w, "%s  %s-%s  %-8s  %-20s  %-20s  billable=%v  %s\n",
 				e.Start.Format("2006-01-02"), e.Start.Format("15:04"), end,
 				e.Activity, e.Customer, e.Project, e.Billable, fmtHHMM(durationMinutes(e)))
+			if !ownEntry(e) {
+				fmt.Fprintf(w, "    user: %s\n", e.User)
+			}
 			if len(e.Notes) > 0 {
-				fmt.Printf("    notes: %v\n", e.Notes)
+				fmt.Fprintf(w, "    notes: %v\n", e.Notes)
 			}
 		}
+		printOpenNote(w, open)
 	},
 }
 
 func init() {
 	lsCmd.Flags().BoolVar(&lsToday, "today", false, "today only")
 	lsCmd.Flags().StringVar(&lsRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	lsCmd.Flags().StringSliceVar(&lsUsers, "user", nil, userFlagHelp)
+	lsCmd.Flags().StringArrayVar(&lsWhere, "where", nil, whereFlagHelp)
+	addIncludeOpenFlag(lsCmd, &lsOpen)
 }
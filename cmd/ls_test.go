@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestLsIncludeOpen(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	oldNow := Now
+	Now = func() time.Time { return day.Add(3 * time.Hour) }
+	f := lsCmd.Flags().Lookup("include-open")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = oldNow
+		lsRange, lsOpen, f.Changed = "", "", false
+		lsCmd.SetOut(nil)
+	})
+	for _, ev := range []Event{
+		{ID: "e1", Type: "start", TS: day, Customer: "Acme"},
+		{ID: "s1", Type: "stop", TS: day.Add(time.Hour)},
+		{ID: "e2", Type: "start", TS: day.Add(2 * time.Hour), Customer: "Globex"},
+	} {
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lsRange = "2025-10-06T00:00..2025-10-06T23:59"
+	ls := func(mode string) string {
+		t.Helper()
+		if mode != "" {
+			if err := lsCmd.Flags().Set("include-open", mode); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var buf bytes.Buffer
+		lsCmd.SetOut(&buf)
+		lsCmd.Run(lsCmd, nil)
+		return buf.String()
+	}
+
+	// without the flag the running entry is listed as running
+	if out := ls(""); !strings.Contains(out, "11:00-running") || !strings.Contains(out, "Acme") {
+		t.Fatalf("default:\n%s", out)
+	}
+	if out := ls("skip"); strings.Contains(out, "Globex") || !strings.Contains(out, "1 running entry not counted") {
+		t.Fatalf("skip:\n%s", out)
+	}
+	if out := ls("now"); !strings.Contains(out, "11:00-12:00") || !strings.Contains(out, "1h00m") ||
+		!strings.Contains(out, "counted up to 12:00 (e2)") {
+		t.Fatalf("now:\n%s", out)
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"tt/internal/journal"
+	"tt/internal/reporting"
+)
+
+// mergePreview reconstructs the targets of the merge event ev from the
+// current entries and returns them with the entry the parser will build from
+// them. Targets not found within the reference lookback and conflicting
+// customers or projects without an override are errors, so nothing is written
+// that the parser would skip.
+func mergePreview(ev Event) ([]Entry, Entry, error) {
+	now := Now().In(parserLocation())
+	ents, err := loadEntriesForRefFn(now.AddDate(0, 0, -entryRefLookbackDays), now)
+	if err != nil {
+		return nil, Entry{}, fmt.Errorf("loading entries for merge preview: %w", err)
+	}
+	return mergePreviewIn(ev, ents)
+}
+
+// mergePreviewIn is mergePreview against the given entries.
+func mergePreviewIn(ev Event, ents []Entry) ([]Entry, Entry, error) {
+	byID := make(map[string]Entry, len(ents))
+	for _, e := range ents {
+		byID[e.ID] = e
+	}
+	var (
+		targets []Entry
+		missing []string
+	)
+	for _, id := range strings.Split(ev.Meta["targets"], ",") {
+		if e, ok := byID[id]; ok {
+			targets = append(targets, e)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, Entry{}, fmt.Errorf("merge targets not found in the last %d days: %s", entryRefLookbackDays, strings.Join(missing, ", "))
+	}
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].Start.Before(targets[j].Start) })
+
+	jt := make([]journal.Entry, 0, len(targets))
+	for _, e := range targets {
+		jt = append(jt, e.journalEntry())
+	}
+	merged, err := journal.MergeEntries(journal.Event{
+		ID:       ev.ID,
+		Customer: ev.Customer,
+		Project:  ev.Project,
+		Activity: ev.Activity,
+		Billable: ev.Billable,
+		Note:     ev.Note,
+	}, jt)
+	if errors.Is(err, journal.ErrMergeConflict) {
+		return targets, Entry{}, fmt.Errorf("refusing to merge: %w; pass --customer/--project to choose the merged value", err)
+	}
+	if err != nil {
+		return targets, Entry{}, err
+	}
+	return targets, entryFromJournal(merged), nil
+}
+
+// renderMergePreview prints the targets and the merged entry replacing them.
+func renderMergePreview(w io.Writer, targets []Entry, merged Entry) {
+	fmt.Fprintln(w, "Merging:")
+	for _, e := range targets {
+		fmt.Fprintf(w, "  %s  %s\n", e.ID, entryWindowLine(e))
+	}
+	fmt.Fprintf(w, "Into:\n  %s\n", entryWindowLine(merged))
+	meta := []string{}
+	if merged.Activity != "" {
+		meta = append(meta, "activity "+merged.Activity)
+	}
+	if merged.Billable {
+		meta = append(meta, "billable")
+	} else {
+		meta = append(meta, "not billable")
+	}
+	if len(merged.Tags) > 0 {
+		meta = append(meta, "tags "+strings.Join(reporting.DedupeStrings(merged.Tags), ","))
+	}
+	fmt.Fprintf(w, "  %s\n", strings.Join(meta, " · "))
+	if notes := reporting.DedupeStrings(merged.Notes); len(notes) > 0 {
+		fmt.Fprintf(w, "  notes: %s\n", reporting.MergeNotes(notes, 0))
+	}
+}
+
+// entryWindowLine formats an entry's window, duration and label.
+func entryWindowLine(e Entry) string {
+	loc := parserLocation()
+	start := e.Start.In(loc)
+	end := "…"
+	if e.End != nil {
+		stop := e.End.In(loc)
+		end = stop.Format("15:04")
+		if stop.Format("2006-01-02") != start.Format("2006-01-02") {
+			end = stop.Format("Jan 02 15:04")
+		}
+	}
+	return fmt.Sprintf("%s–%s  %6s  %s", start.Format("Jan 02 15:04"), end, fmtHHMM(durationMinutes(e)), entryLabel(e))
+}
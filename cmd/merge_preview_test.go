@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestMergePreview(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(h, m int) *time.Time {
+		v := time.Date(2025, 10, 14, h, m, 0, 0, time.UTC)
+		return &v
+	}
+	ents := []Entry{
+		{ID: "b", Start: *at(10, 30), End: at(11, 15), Customer: "Acme", Project: "web", Notes: []string{"review"}, Tags: []string{"x"}},
+		{ID: "a", Start: *at(9, 0), End: at(10, 0), Customer: "Acme", Activity: "dev", Notes: []string{"fix"}},
+		{ID: "c", Start: *at(12, 0), End: at(13, 0), Customer: "Globex", Project: "api", Billable: true},
+	}
+
+	ev := Event{ID: "m1", Note: "consolidated", Meta: map[string]string{"targets": "b,a"}}
+	targets, merged, err := mergePreviewIn(ev, ents)
+	if err != nil {
+		t.Fatalf("mergePreviewIn: %v", err)
+	}
+	var buf bytes.Buffer
+	renderMergePreview(&buf, targets, merged)
+	want := `Merging:
+  a  Oct 14 09:00–10:00   1h00m  Acme
+  b  Oct 14 10:30–11:15     45m  Acme/web
+Into:
+  Oct 14 09:00–11:15   2h15m  Acme/web
+  activity dev · not billable · tags x
+  notes: fix • review • consolidated
+`
+	if buf.String() != want {
+		t.Errorf("preview:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	ev.Meta["targets"] = "a,c"
+	if _, _, err := mergePreviewIn(ev, ents); err == nil || !strings.Contains(err.Error(), "customers Acme, Globex") {
+		t.Errorf("conflict error = %v", err)
+	}
+	ev.Customer, ev.Project = "Acme", "web"
+	if _, merged, err := mergePreviewIn(ev, ents); err != nil || merged.Customer != "Acme" || !merged.Billable {
+		t.Errorf("override: %+v, %v", merged, err)
+	}
+
+	ev.Meta["targets"] = "a,zz"
+	if _, _, err := mergePreviewIn(ev, ents); err == nil || !strings.Contains(err.Error(), "zz") {
+		t.Errorf("missing target error = %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/rounding"
+)
+
+// customerPolicy returns policy with the customer's minimum billable time per
+// rounded unit when customers.<name>.minimum_billable_min is set; otherwise
+// rounding.minimum_billable_min applies.
+//
+//	customers:
+//	  acme:
+//	    minimum_billable_min: 30
+func customerPolicy(policy rounding.Policy, customer string) rounding.Policy {
+	c := strings.ToLower(strings.TrimSpace(customer))
+	if c == "" {
+		return policy
+	}
+	if key := "customers." + c + ".minimum_billable_min"; viper.IsSet(key) {
+		policy.MinimumSec = int64(viper.GetInt(key)) * 60
+	}
+	return policy
+}
+
+// minimumLabel describes a unit (an entry, or a group total at aggregate
+// level) that policy raised to its minimum.
+func minimumLabel(what string, raw int64, policy rounding.Policy) string {
+	return fmt.Sprintf("%s: %s raised to the %s minimum", what, fmtSecHHMM(raw), fmtSecHHMM(policy.MinimumSec))
+}
+
+// printMinimumApplied lists the units billed at the minimum so rounded totals
+// can be told apart from tracked time.
+func printMinimumApplied(w io.Writer, labels []string) {
+	if len(labels) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%sMinimum applied:%s %d\n", ansiHeading, ansiReset, len(labels))
+	for _, l := range labels {
+		fmt.Fprintf(w, "  - %s\n", l)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"sort"
+
+	"tt/internal/billing"
+	"tt/internal/reporting"
+)
+
+// Monetary values for tt report --money and tt report week --money: billable
+// time times the rate from the rates config (rateFor), or one ad-hoc rate
+// for everything with --rate-override. Non-billable time has no value;
+// billable time without a rate is listed as unpriced instead of counted as 0.
+
+// moneyRate returns the hourly rate for customer/project: override when it
+// is positive, the configured rate otherwise.
+func moneyRate(customer, project string, override float64) (float64, bool) {
+	if override > 0 {
+		return override, true
+	}
+	return rateFor(customer, project)
+}
+
+// priceWeek sets the amount of every billable group of doc that has a rate
+// and the week's Money, formatted in numbers; the rounded seconds are priced.
+func priceWeek(doc *reporting.Week, override float64, locale string, numbers reporting.Locale) {
+	m := &reporting.Money{Currency: rateCurrency(), Locale: locale, Unpriced: []string{}, Format: numbers}
+	unpriced := map[string]bool{}
+	for i := range doc.Days {
+		for j := range doc.Days[i].Groups {
+			g := &doc.Days[i].Groups[j]
+			if !g.Billable {
+				continue
+			}
+			rate, ok := moneyRate(g.Customer, g.Project, override)
+			if !ok {
+				unpriced[g.Label()] = true
+				continue
+			}
+			amount := roundCents(float64(g.Seconds) / 3600 * rate)
+			g.Amount = &amount
+			m.Amount += amount
+		}
+	}
+	for l := range unpriced {
+		m.Unpriced = append(m.Unpriced, l)
+	}
+	sort.Strings(m.Unpriced)
+	m.Amount = roundCents(m.Amount)
+	doc.Money = m
+}
+
+// fmtMoney formats amount in the billing currency for locale.
+func fmtMoney(amount float64, locale string) string {
+	return reporting.FormatMoney(amount, rateCurrency(), locale)
+}
+
+func roundCents(v float64) float64 { return billing.RoundCents(v) }
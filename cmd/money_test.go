@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+func TestPriceWeek(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("rates", map[string]interface{}{
+		"currency":  "EUR",
+		"customers": map[string]interface{}{"acme": map[string]interface{}{"rate": 100, "projects": map[string]interface{}{"portal": 120}}},
+	})
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("rates", nil)
+	})
+
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 13, h, m, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	policy := rounding.Policy{QuantumSec: 900, Level: rounding.LevelEntry, Strategy: "up"}
+	agg := newWeekAggregator(time.UTC, policy, at(20, 0))
+	for _, e := range []Entry{
+		{ID: "e1", Start: at(9, 0), End: end(at(10, 25)), Customer: "acme", Project: "portal", Billable: true}, // 1.5h rounded
+		{ID: "e2", Start: at(11, 0), End: end(at(12, 0)), Customer: "acme", Billable: true},
+		{ID: "e3", Start: at(13, 0), End: end(at(14, 0)), Customer: "globex", Billable: true},
+		{ID: "e4", Start: at(14, 0), End: end(at(15, 0)), Customer: "acme", Project: "intern"},
+	} {
+		if err := agg.add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	days := agg.Days(at(0, 0), at(23, 0), "en", 80)
+	doc := newWeekReportDoc(at(0, 0), at(23, 0), "UTC", days, 0, 0, openEntries{}, policy, reporting.Issues{})
+	priceWeek(&doc, 0, "en", reporting.Locales["en"])
+
+	amounts := map[string]float64{}
+	for _, g := range doc.Days[0].Groups {
+		if g.Amount != nil {
+			amounts[g.Label()] = *g.Amount
+		}
+	}
+	if len(amounts) != 2 || amounts["acme / portal"] != 180 || amounts["acme"] != 100 {
+		t.Fatalf("amounts = %v", amounts)
+	}
+	if doc.Money.Amount != 280 || strings.Join(doc.Money.Unpriced, ",") != "globex" {
+		t.Fatalf("money = %+v", doc.Money)
+	}
+	if got := doc.FormatMoney(doc.Money.Amount); got != "€280.00" {
+		t.Fatalf("formatted = %q", got)
+	}
+
+	priceWeek(&doc, 50, "de", reporting.Locales["de"])
+	if doc.Money.Amount != 175 || len(doc.Money.Unpriced) != 0 {
+		t.Fatalf("override money = %+v", doc.Money)
+	}
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	notifyDate   string
+	notifyDryRun bool
+)
+
+// notifyCmd groups messages tt posts to chat services.
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Post summaries to chat services (Matrix, Discord, Slack) or by email",
+}
+
+// notifyDailyCmd posts an end-of-day summary: total hours, a per-project
+// breakdown and the untracked gaps between entries.
+//
+// Config (~/.tt/config.yaml):
+//
+//	notify:
+//	  gap_min: 15                  # smallest gap reported as untracked
+//	  discord:
+//	    webhook_url: https://discord.com/api/webhooks/...
+//	  slack:
+//	    webhook_url: https://hooks.slack.com/services/...
+//	  email:
+//	    to: [me@example.org]       # sent via the submit.email SMTP settings
+//	  matrix:
+//	    homeserver: https://matrix.example.org
+//	    room_id: "!abc:example.org"
+//	    token: "..."               # or TT_MATRIX_TOKEN in the environment
+//
+// Run it from `tt schedule run` (or cron) to get the summary every evening.
+var notifyDailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Post the daily summary to the configured Matrix room / Discord or Slack webhook / email",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day := nowLocal()
+		if notifyDate != "" && notifyDate != "today" {
+			t, err := time.ParseInLocation("2006-01-02", notifyDate, parserLocation())
+			if err != nil {
+				return fmt.Errorf("invalid --date %q: want YYYY-MM-DD or today", notifyDate)
+			}
+			day = t
+		}
+		return postDailySummary(cmd.Context(), cmd.OutOrStdout(), day, notifyDryRun)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyDailyCmd)
+	notifyDailyCmd.Flags().StringVar(&notifyDate, "date", "today", "day to summarize (YYYY-MM-DD or today)")
+	notifyDailyCmd.Flags().BoolVar(&notifyDryRun, "dry-run", false, "print the summary instead of posting it")
+}
+
+// postDailySummary builds the summary for day and posts it to every
+// configured target. With no target configured it is printed instead.
+func postDailySummary(ctx context.Context, out io.Writer, day time.Time, dryRun bool) error {
+	ents, err := loadEntries(day, day)
+	if err != nil {
+		return err
+	}
+	gap := viper.GetInt("notify.gap_min")
+	if gap <= 0 {
+		gap = 15
+	}
+	text := buildDailySummary(ents, day, Now(), time.Duration(gap)*time.Minute).Text()
+	return postNotice(ctx, out, "daily summary", day.Format("2006-01-02"), text, dryRun)
+}
+
+// postNotice posts text, the what of period, to every configured target.
+// With no target configured it is printed instead. Failed posts that may
+// succeed later are queued for retry.
+func postNotice(ctx context.Context, out io.Writer, what, period, text string, dryRun bool) error {
+	targets := notifyTargets()
+	if dryRun || len(targets) == 0 {
+		if !dryRun {
+			fmt.Fprintf(out, "No notify target configured (notify.discord / notify.matrix / notify.slack / notify.email); printing %s.\n", what)
+		}
+		fmt.Fprintln(out, text)
+		return nil
+	}
+	for _, t := range targets {
+		err := t.post(ctx, text)
+		if err != nil && !retryablePushError(err) {
+			return fmt.Errorf("notify %s: %w", t.name, err)
+		}
+		if err != nil {
+			// a summary that arrives late still beats none (see outbox.go)
+			if qerr := queuePush("notify", t.name+":"+period, what+" "+period+" to "+t.name, queuedNotice{Target: t.name, Text: text}, err); qerr != nil {
+				return qerr
+			}
+			fmt.Fprintf(out, "Queued %s for %s (%v).\n", what, t.name, err)
+			continue
+		}
+		fmt.Fprintf(out, "Posted %s to %s.\n", what, t.name)
+	}
+	return nil
+}
+
+type dailySummary struct {
+	Day      time.Time
+	Total    time.Duration
+	Projects []projectTotal
+	Gaps     []timeGap
+}
+
+type projectTotal struct {
+	Label string
+	Dur   time.Duration
+}
+
+type timeGap struct {
+	From, To time.Time
+}
+
+// buildDailySummary totals entries of a single day. Running entries count up
+// to now. Gaps are the untracked stretches of at least minGap between the
+// first start and the last end of the day.
+func buildDailySummary(ents []Entry, day, now time.Time, minGap time.Duration) dailySummary {
+	s := dailySummary{Day: day}
+	byProject := map[string]time.Duration{}
+	type span struct{ start, end time.Time }
+	var spans []span
+	for _, e := range ents {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		if !end.After(e.Start) {
+			continue
+		}
+		d := entryElapsed(e, now)
+		s.Total += d
+		label := e.Customer
+		if e.Project != "" {
+			label += " / " + e.Project
+		}
+		byProject[label] += d
+		spans = append(spans, span{e.Start, end})
+	}
+	for label, d := range byProject {
+		s.Projects = append(s.Projects, projectTotal{label, d})
+	}
+	sort.Slice(s.Projects, func(i, j int) bool {
+		if s.Projects[i].Dur != s.Projects[j].Dur {
+			return s.Projects[i].Dur > s.Projects[j].Dur
+		}
+		return s.Projects[i].Label < s.Projects[j].Label
+	})
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	var covered time.Time
+	for i, sp := range spans {
+		if i > 0 && sp.start.Sub(covered) >= minGap {
+			s.Gaps = append(s.Gaps, timeGap{covered, sp.start})
+		}
+		if sp.end.After(covered) {
+			covered = sp.end
+		}
+	}
+	return s
+}
+
+// Text renders the summary as a short markdown message; both Matrix and
+// Discord render the bullet list.
+func (s dailySummary) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**tt — %s**: %s tracked\n", s.Day.Format("Mon 2006-01-02"), fmtDuration(s.Total))
+	if len(s.Projects) == 0 {
+		b.WriteString("No entries.")
+		return b.String()
+	}
+	for _, p := range s.Projects {
+		fmt.Fprintf(&b, "• %s: %s\n", p.Label, fmtDuration(p.Dur))
+	}
+	if len(s.Gaps) == 0 {
+		b.WriteString("No untracked gaps.")
+		return b.String()
+	}
+	gaps := make([]string, len(s.Gaps))
+	for i, g := range s.Gaps {
+		gaps[i] = fmt.Sprintf("%s–%s (%s)", g.From.Format("15:04"), g.To.Format("15:04"), fmtDuration(g.To.Sub(g.From)))
+	}
+	b.WriteString("Untracked gaps: " + strings.Join(gaps, ", "))
+	return b.String()
+}
+
+// notifyTarget posts a text message to one chat service.
+type notifyTarget struct {
+	name string
+	post func(ctx context.Context, text string) error
+}
+
+func notifyTargets() []notifyTarget {
+	var targets []notifyTarget
+	if hook := configSecret("notify.discord.webhook_url"); hook != "" {
+		targets = append(targets, notifyTarget{"discord", func(ctx context.Context, text string) error {
+			return postJSON(ctx, http.MethodPost, hook, "", map[string]string{"content": text})
+		}})
+	}
+	if hook := configSecret("notify.slack.webhook_url"); hook != "" {
+		targets = append(targets, notifyTarget{"slack", func(ctx context.Context, text string) error {
+			return postJSON(ctx, http.MethodPost, hook, "", map[string]string{"text": text})
+		}})
+	}
+	if to := viper.GetStringSlice("notify.email.to"); len(to) > 0 {
+		targets = append(targets, notifyTarget{"email", func(_ context.Context, text string) error {
+			return mailNotice(to, text)
+		}})
+	}
+	if hs := viper.GetString("notify.matrix.homeserver"); hs != "" {
+		room := viper.GetString("notify.matrix.room_id")
+		token := configSecret("notify.matrix.token")
+		if token == "" {
+			token = os.Getenv("TT_MATRIX_TOKEN")
+		}
+		targets = append(targets, notifyTarget{"matrix", func(ctx context.Context, text string) error {
+			txn := fmt.Sprintf("tt-%d", Now().UnixNano())
+			u := strings.TrimRight(hs, "/") + "/_matrix/client/v3/rooms/" + url.PathEscape(room) +
+				"/send/m.room.message/" + txn
+			return postJSON(ctx, http.MethodPut, u, token, map[string]string{"msgtype": "m.text", "body": text})
+		}})
+	}
+	return targets
+}
+
+// postJSON sends body as JSON and fails on non-2xx responses.
+func postJSON(ctx context.Context, method, u, bearer string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &httpStatusError{Code: resp.StatusCode, Msg: fmt.Sprintf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestBuildDailySummary(t *testing.T) {
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	mk := func(cust, proj string, start, end time.Time) Entry {
+		return Entry{Customer: cust, Project: proj, Start: start, End: &end}
+	}
+	ents := []Entry{
+		mk("Acme", "Portal", at(9, 0), at(12, 0)),
+		mk("Acme", "Portal", at(12, 45), at(14, 0)), // 45m lunch gap
+		mk("Globex", "", at(14, 5), at(15, 0)),      // 5m gap: below threshold
+		{Customer: "Globex", Start: at(16, 0)},      // running until now; 1h gap
+	}
+	s := buildDailySummary(ents, day, at(17, 0), 15*time.Minute)
+
+	if s.Total != 6*time.Hour+10*time.Minute {
+		t.Fatalf("total = %v, want 6h10m", s.Total)
+	}
+	if len(s.Projects) != 2 || s.Projects[0].Label != "Acme / Portal" || s.Projects[0].Dur != 4*time.Hour+15*time.Minute {
+		t.Fatalf("unexpected projects: %+v", s.Projects)
+	}
+	if len(s.Gaps) != 2 || !s.Gaps[0].From.Equal(at(12, 0)) || !s.Gaps[1].To.Equal(at(16, 0)) {
+		t.Fatalf("unexpected gaps: %+v", s.Gaps)
+	}
+	text := s.Text()
+	for _, want := range []string{"6h10m tracked", "• Acme / Portal: 4h15m", "• Globex: 1h55m", "12:00–12:45 (45m)", "15:00–16:00 (1h00m)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("summary missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestPostDailySummaryTargets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		NewStartEvent("s1", "Acme", "Portal", "dev", boolPtr(true), "", nil, day),
+		NewStopEvent("s2", day.Add(time.Hour)),
+	} {
+		if err := writeEvent(ev); err != nil {
+			t.Fatalf("writeEvent: %v", err)
+		}
+	}
+
+	got := map[string]map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/webhook":
+			got["discord"] = body
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/"):
+			if r.Header.Get("Authorization") != "Bearer mtok" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			got["matrix"] = body
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	viper.Set("notify.discord.webhook_url", srv.URL+"/webhook")
+	viper.Set("notify.matrix.homeserver", srv.URL)
+	viper.Set("notify.matrix.room_id", "!room:example.org")
+	viper.Set("notify.matrix.token", "mtok")
+	t.Cleanup(func() {
+		for _, k := range []string{"notify.discord.webhook_url", "notify.matrix.homeserver", "notify.matrix.room_id", "notify.matrix.token"} {
+			viper.Set(k, "")
+		}
+	})
+
+	var out bytes.Buffer
+	if err := postDailySummary(context.Background(), &out, day, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(got) != 0 || !strings.Contains(out.String(), "1h00m tracked") {
+		t.Fatalf("dry run posted or printed wrong summary: %v\n%s", got, out.String())
+	}
+
+	if err := postDailySummary(context.Background(), &out, day, false); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if !strings.Contains(got["discord"]["content"], "• Acme / Portal: 1h00m") {
+		t.Fatalf("discord payload: %v", got["discord"])
+	}
+	if got["matrix"]["msgtype"] != "m.text" || !strings.Contains(got["matrix"]["body"], "1h00m tracked") {
+		t.Fatalf("matrix payload: %v", got["matrix"])
+	}
+}
+
+func TestNextDailyRun(t *testing.T) {
+	loc := time.UTC
+	cases := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2025, 10, 6, 9, 0, 0, 0, loc), time.Date(2025, 10, 6, 18, 0, 0, 0, loc)},
+		{time.Date(2025, 10, 6, 18, 0, 0, 0, loc), time.Date(2025, 10, 6, 18, 0, 0, 0, loc)},
+		{time.Date(2025, 10, 6, 18, 0, 1, 0, loc), time.Date(2025, 10, 7, 18, 0, 0, 0, loc)},
+		{time.Date(2025, 12, 31, 23, 0, 0, 0, loc), time.Date(2026, 1, 1, 18, 0, 0, 0, loc)},
+	}
+	for _, tc := range cases {
+		if got := nextDailyRun(tc.now, "18:00"); !got.Equal(tc.want) {
+			t.Errorf("nextDailyRun(%v) = %v, want %v", tc.now, got, tc.want)
+		}
+	}
+}
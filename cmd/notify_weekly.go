@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyWeek       string
+	notifyWeekDryRun bool
+)
+
+// notifyWeeklyCmd posts the weekly digest: hours, top projects, working
+// days with untracked time and pending completion reviews, so the week can
+// be fixed before it is submitted. tt schedule run posts it every week at
+// schedule.weekly_digest (e.g. "fri 16:00"); it uses the notify targets of
+// tt notify daily.
+var notifyWeeklyCmd = &cobra.Command{
+	Use:   "weekly",
+	Short: "Post the weekly digest (hours, top projects, untracked days, pending reviews)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loc := parserLocation()
+		year, week := Now().In(loc).ISOWeek()
+		if notifyWeek != "" {
+			y, w, err := parseISOWeek(notifyWeek)
+			if err != nil {
+				return fmt.Errorf("invalid --week %q: %v", notifyWeek, err)
+			}
+			year, week = y, w
+		}
+		from, to := isoWeekRange(year, week, loc)
+		return postWeeklyDigest(cmd.Context(), cmd.OutOrStdout(), from, to, notifyWeekDryRun)
+	},
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyWeeklyCmd)
+	notifyWeeklyCmd.Flags().StringVar(&notifyWeek, "week", "", "ISO week, e.g. 2025-W41 (default: the current week)")
+	notifyWeeklyCmd.Flags().BoolVar(&notifyWeekDryRun, "dry-run", false, "print the digest instead of posting it")
+}
+
+// postWeeklyDigest builds the digest of the week from..to and posts it like
+// the daily summary.
+func postWeeklyDigest(ctx context.Context, out io.Writer, from, to time.Time, dryRun bool) error {
+	ents, err := loadEntries(from, to)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	if idx, err := BuildCompletionIndex(""); err == nil {
+		st := prepareReviewState(loadCompletionDecisions(), idx)
+		pending = len(st.customers) + len(st.projects)
+	}
+	own := ents[:0]
+	for _, e := range ents {
+		if ownEntry(e) {
+			own = append(own, e)
+		}
+	}
+	d := buildWeeklyDigest(own, from, to, Now(), loadWorkingHours(), pending)
+	year, week := from.ISOWeek()
+	return postNotice(ctx, out, "weekly digest", fmt.Sprintf("%04d-W%02d", year, week), d.Text(), dryRun)
+}
+
+// weeklyDigestTop is the number of projects the digest names.
+const weeklyDigestTop = 5
+
+type weeklyDigest struct {
+	From, To time.Time
+	Total    time.Duration
+	Projects []projectTotal // longest first
+	Short    []coverageDay  // past working days with less tracked than expected
+	Pending  int            // customers and projects awaiting tt completion review
+}
+
+// buildWeeklyDigest totals the week's entries; running entries count up to
+// now. Only days before now can be short.
+func buildWeeklyDigest(ents []Entry, from, to, now time.Time, hours map[time.Weekday]time.Duration, pending int) weeklyDigest {
+	d := weeklyDigest{From: from, To: to, Pending: pending}
+	loc := parserLocation()
+	byProject := map[string]time.Duration{}
+	byDay := map[string]time.Duration{}
+	for _, e := range ents {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		if !end.After(e.Start) {
+			continue
+		}
+		dur := entryElapsed(e, now)
+		d.Total += dur
+		label := e.Customer
+		if e.Project != "" {
+			label += " / " + e.Project
+		}
+		byProject[label] += dur
+		byDay[e.Start.In(loc).Format("2006-01-02")] += dur
+	}
+	for label, dur := range byProject {
+		d.Projects = append(d.Projects, projectTotal{label, dur})
+	}
+	sort.Slice(d.Projects, func(i, j int) bool {
+		if d.Projects[i].Dur != d.Projects[j].Dur {
+			return d.Projects[i].Dur > d.Projects[j].Dur
+		}
+		return d.Projects[i].Label < d.Projects[j].Label
+	})
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		want := hours[day.Weekday()]
+		got := byDay[day.Format("2006-01-02")]
+		if want > 0 && got < want && day.AddDate(0, 0, 1).Before(now) {
+			d.Short = append(d.Short, coverageDay{Day: day, Tracked: got, Expected: want})
+		}
+	}
+	return d
+}
+
+// Text renders the digest as a short markdown message.
+func (d weeklyDigest) Text() string {
+	var b strings.Builder
+	year, week := d.From.ISOWeek()
+	fmt.Fprintf(&b, "**tt — week %04d-W%02d**: %s tracked\n", year, week, fmtDuration(d.Total))
+	for i, p := range d.Projects {
+		if i == weeklyDigestTop {
+			fmt.Fprintf(&b, "• and %d more\n", len(d.Projects)-weeklyDigestTop)
+			break
+		}
+		fmt.Fprintf(&b, "• %s: %s\n", p.Label, fmtDuration(p.Dur))
+	}
+	if len(d.Projects) == 0 {
+		b.WriteString("No entries.\n")
+	}
+	if len(d.Short) == 0 {
+		b.WriteString("No untracked working time.\n")
+	} else {
+		short := make([]string, len(d.Short))
+		for i, s := range d.Short {
+			short[i] = fmt.Sprintf("%s (%s of %s)", s.Day.Format("Mon 01-02"), fmtDuration(s.Tracked), fmtDuration(s.Expected))
+		}
+		b.WriteString("Untracked working time: " + strings.Join(short, ", ") + "\n")
+	}
+	if d.Pending > 0 {
+		fmt.Fprintf(&b, "Pending completion reviews: %d (tt completion review)\n", d.Pending)
+	}
+	if len(d.Short) > 0 || d.Pending > 0 {
+		b.WriteString("Fix it now, before the week goes stale.")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// parseWeeklyAt parses schedule.weekly_digest: "fri 16:00", or "16:00" for
+// Fridays.
+func parseWeeklyAt(s string) (time.Weekday, string, error) {
+	day, hhmm, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		day, hhmm = "fri", day
+	}
+	if _, err := time.Parse("15:04", strings.TrimSpace(hhmm)); err != nil {
+		return 0, "", fmt.Errorf("want [weekday] HH:MM, got %q", s)
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if name := strings.ToLower(d.String()); strings.ToLower(day) == name || strings.ToLower(day) == name[:3] {
+			return d, strings.TrimSpace(hhmm), nil
+		}
+	}
+	return 0, "", fmt.Errorf("want [weekday] HH:MM, got %q", s)
+}
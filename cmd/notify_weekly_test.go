@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestBuildWeeklyDigest(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	mon := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	at := func(day, h int) time.Time { return mon.AddDate(0, 0, day).Add(time.Duration(h) * time.Hour) }
+	mk := func(cust, proj string, start time.Time, h int) Entry {
+		end := start.Add(time.Duration(h) * time.Hour)
+		return Entry{Customer: cust, Project: proj, Start: start, End: &end}
+	}
+	var ents []Entry
+	for day := 0; day < 4; day++ {
+		if day != 1 {
+			ents = append(ents, mk("Acme", "Portal", at(day, 9), 8))
+		}
+	}
+	for i, p := range []string{"a", "b", "c", "d", "e", "f"} {
+		ents = append(ents, mk("Globex", p, at(1, 9+i), 1))
+	}
+	hours := map[time.Weekday]time.Duration{time.Monday: 8 * time.Hour, time.Tuesday: 8 * time.Hour, time.Wednesday: 8 * time.Hour, time.Thursday: 8 * time.Hour, time.Friday: 8 * time.Hour}
+	d := buildWeeklyDigest(ents, mon, at(6, 0), at(4, 16), hours, 2)
+
+	if d.Total != 30*time.Hour || d.Projects[0].Label != "Acme / Portal" || len(d.Short) != 1 {
+		t.Fatalf("digest = %+v", d)
+	}
+	text := d.Text()
+	for _, want := range []string{"week 2025-W41**: 30h00m tracked", "• Acme / Portal: 24h00m", "• and 2 more", "Untracked working time: Tue 10-07 (6h00m of 8h00m)", "Pending completion reviews: 2", "before the week goes stale"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("digest lacks %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Fri") {
+		t.Errorf("the running Friday counted as short:\n%s", text)
+	}
+}
+
+func TestParseWeeklyAt(t *testing.T) {
+	for in, want := range map[string]string{"fri 16:00": "Friday 16:00", "16:30": "Friday 16:30", "Monday 08:00": "Monday 08:00"} {
+		d, at, err := parseWeeklyAt(in)
+		if err != nil || d.String()+" "+at != want {
+			t.Errorf("parseWeeklyAt(%q) = %v %s %v; want %s", in, d, at, err, want)
+		}
+	}
+	for _, in := range []string{"", "fri", "someday 16:00", "fri 25:00"} {
+		if _, _, err := parseWeeklyAt(in); err == nil {
+			t.Errorf("parseWeeklyAt(%q) accepted", in)
+		}
+	}
+}
+
+func TestPostNoticeSlackAndEmail(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var slack map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&slack)
+	}))
+	defer srv.Close()
+	var mail string
+	orig := submitSendMail
+	submitSendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		mail = addr + " " + from + " " + strings.Join(to, ",") + "\n" + string(msg)
+		return nil
+	}
+	viper.Set("notify.slack.webhook_url", srv.URL)
+	viper.Set("notify.email.to", []string{"me@example.org"})
+	viper.Set("submit.email.smtp", "smtp.example.org:587")
+	viper.Set("submit.email.from", "tt@example.org")
+	t.Cleanup(func() {
+		submitSendMail = orig
+		for _, k := range []string{"notify.slack.webhook_url", "notify.email.to", "submit.email.smtp", "submit.email.from"} {
+			viper.Set(k, nil)
+		}
+	})
+
+	var out bytes.Buffer
+	if err := postNotice(context.Background(), &out, "weekly digest", "2025-W41", "**tt — week 2025-W41**: 30h00m tracked\n• Acme: 30h00m", false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(slack["text"], "30h00m tracked") {
+		t.Fatalf("slack payload = %v", slack)
+	}
+	if !strings.Contains(mail, "smtp.example.org:587 tt@example.org me@example.org") || !strings.Contains(mail, "Subject: tt — week 2025-W41: 30h00m tracked\r\n") {
+		t.Fatalf("mail = %q", mail)
+	}
+	if !strings.Contains(out.String(), "Posted weekly digest to slack.") || !strings.Contains(out.String(), "Posted weekly digest to email.") {
+		t.Fatalf("output = %q", out.String())
+	}
+}
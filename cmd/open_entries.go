@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Modes of --include-open: what reports do with running entries.
+const (
+	openSkip  = "skip"  // leave them out (default)
+	openNow   = "now"   // count them up to now; totals are provisional
+	openError = "error" // refuse to report while an entry in range runs
+)
+
+// openMode is the --include-open flag value shared by the reports.
+type openMode string
+
+func (m *openMode) String() string {
+	if *m == "" {
+		return openSkip
+	}
+	return string(*m)
+}
+
+func (m *openMode) Set(s string) error {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case openSkip, openNow, openError:
+		*m = openMode(v)
+		return nil
+	case "true":
+		*m = openNow
+		return nil
+	case "false":
+		*m = openSkip
+		return nil
+	}
+	return fmt.Errorf("want now, skip or error")
+}
+
+func (m *openMode) Type() string { return "mode" }
+
+// addIncludeOpenFlag registers --include-open[=now|skip|error] on cmd; the
+// bare flag means now.
+func addIncludeOpenFlag(cmd *cobra.Command, m *openMode) {
+	f := cmd.Flags().VarPF(m, "include-open", "", "running entries: skip (default), now (count up to now, totals marked provisional) or error")
+	f.NoOptDefVal = openNow
+}
+
+// openEntries is what a report did with the running entries in its range.
+type openEntries struct {
+	Mode openMode
+	IDs  []string  // running entries seen
+	At   time.Time // end used for them in now mode
+}
+
+// Provisional reports whether totals include still running entries.
+func (o openEntries) Provisional() bool {
+	return o.Mode == openNow && len(o.IDs) > 0
+}
+
+// applyOpenMode resolves the running entries of ents according to m:
+// counted up to now, dropped, or an error naming them.
+func applyOpenMode(ents []Entry, m openMode, now time.Time) ([]Entry, openEntries, error) {
+	o := openEntries{Mode: openMode(m.String()), At: now}
+	out := ents[:0:0]
+	for _, e := range ents {
+		if e.End != nil {
+			out = append(out, e)
+			continue
+		}
+		o.IDs = append(o.IDs, e.ID)
+		if o.Mode == openNow && now.After(e.Start) {
+			end := now
+			e.End = &end
+			out = append(out, e)
+		}
+	}
+	if err := o.Err(); err != nil {
+		return nil, o, err
+	}
+	return out, o, nil
+}
+
+// Err names the running entries when the mode is error and there are any.
+func (o openEntries) Err() error {
+	if o.Mode == openError && len(o.IDs) > 0 {
+		return fmt.Errorf("running entries in range: %s (stop them, or pass --include-open=now|skip)", strings.Join(o.IDs, ", "))
+	}
+	return nil
+}
+
+// printOpenNote marks totals as provisional when running entries were
+// counted, or mentions the skipped ones.
+func printOpenNote(w io.Writer, o openEntries) {
+	switch {
+	case len(o.IDs) == 0:
+	case o.Provisional():
+		fmt.Fprintf(w, "%sProvisional:%s totals include %d running entr%s counted up to %s (%s)\n",
+			ansiHeading, ansiReset, len(o.IDs), plural(len(o.IDs), "y", "ies"), o.At.In(parserLocation()).Format("15:04"), strings.Join(o.IDs, ", "))
+	default:
+		fmt.Fprintf(w, "Note: %d running entr%s not counted (--include-open to count up to now)\n", len(o.IDs), plural(len(o.IDs), "y", "ies"))
+	}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"tt/internal/rounding"
+)
+
+func TestApplyOpenMode(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	end := now.Add(-2 * time.Hour)
+	ents := []Entry{
+		{ID: "done", Start: now.Add(-3 * time.Hour), End: &end},
+		{ID: "run", Start: now.Add(-30 * time.Minute)},
+	}
+
+	var m openMode
+	got, o, err := applyOpenMode(ents, m, now)
+	if err != nil || len(got) != 1 || o.Provisional() || len(o.IDs) != 1 {
+		t.Fatalf("skip: %v %+v %v", got, o, err)
+	}
+
+	if err := m.Set("now"); err != nil {
+		t.Fatal(err)
+	}
+	got, o, err = applyOpenMode(ents, m, now)
+	if err != nil || len(got) != 2 || !o.Provisional() || durationMinutes(got[1]) != 30 {
+		t.Fatalf("now: %v %+v %v", got, o, err)
+	}
+	if ents[1].End != nil {
+		t.Fatal("applyOpenMode modified the input entry")
+	}
+	var buf bytes.Buffer
+	printOpenNote(&buf, o)
+	if !strings.Contains(buf.String(), "1 running entry counted up to") || !strings.Contains(buf.String(), "(run)") {
+		t.Fatalf("note = %q", buf.String())
+	}
+
+	_ = m.Set("error")
+	if _, _, err := applyOpenMode(ents, m, now); err == nil || !strings.Contains(err.Error(), "run") {
+		t.Fatalf("error mode: err = %v", err)
+	}
+	if err := m.Set("sometimes"); err == nil {
+		t.Fatal("Set accepted an unknown mode")
+	}
+}
+
+func TestWeekAggregator_OpenEntriesProvisional(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	agg := newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 60}, now)
+	agg.open = openNow
+	_ = agg.add(Entry{ID: "run", Start: now.Add(-time.Hour), Customer: "Acme"})
+	days := agg.Days(now, now, "en", 0)
+	if days[0].DaySeconds != 3600 || !containsString(days[0].Flags, "provisional") {
+		t.Fatalf("day = %+v", days[0])
+	}
+	if len(agg.openIDs) != 1 || len(agg.badEntries) != 0 {
+		t.Fatalf("openIDs = %v, badEntries = %v", agg.openIDs, agg.badEntries)
+	}
+}
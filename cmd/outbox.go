@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/outbox"
+)
+
+// Pushes that fail for reasons that may pass (no network, timeouts, 408, 429,
+// 500 and 502-504 responses) are queued in the outbox (~/.tt/outbox) instead of
+// failing the whole push. `tt push --flush` and `tt schedule run` retry them;
+// `tt push status` lists them. Other failures (bad credentials, validation
+// errors) still fail the push, since retrying cannot fix them.
+
+var pushFlush bool
+
+var pushStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List the pushes queued in the outbox for a retry",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		items, err := pushOutbox().List()
+		if err != nil {
+			return err
+		}
+		renderOutbox(cmd.OutOrStdout(), items, Now())
+		return nil
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushStatusCmd)
+	pushCmd.Flags().BoolVar(&pushFlush, "flush", false, "retry every push queued in the outbox now")
+	pushCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !pushFlush {
+			return cmd.Help()
+		}
+		_, failed, err := flushOutbox(cmd.Context(), cmd.OutOrStdout(), Now(), true)
+		if err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d queued push(es) still failing (see tt push status)", failed)
+		}
+		return nil
+	}
+}
+
+// pushOutbox is the outbox of the current user.
+func pushOutbox() outbox.Store { return outbox.Store{Dir: outbox.DefaultDir()} }
+
+// httpStatusError is a non-2xx response of a remote system.
+type httpStatusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *httpStatusError) Error() string { return e.Msg }
+
+// newHTTPStatusError reads (part of) the response body into the error
+// "<prefix>: <status>: <body>".
+func newHTTPStatusError(prefix string, resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &httpStatusError{Code: resp.StatusCode, Msg: fmt.Sprintf("%s: %s: %s", prefix, resp.Status, strings.TrimSpace(string(msg)))}
+}
+
+// retryablePushError reports whether a push failed in a way a later retry
+// may fix: transport errors and 408, 429, 500, 502, 503 or 504 responses.
+func retryablePushError(err error) bool {
+	var se *httpStatusError
+	if errors.As(err, &se) {
+		switch se.Code {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var ue *url.Error
+	return errors.As(err, &ue)
+}
+
+// queuePush puts a failed push into the outbox under kind:id; it replaces an
+// older item for the same thing.
+func queuePush(kind, id, label string, payload interface{}, cause error) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := Now()
+	it := outbox.Item{Key: kind + ":" + id, Kind: kind, Label: label, Payload: b, Queued: now}
+	it.Failed(now, cause)
+	if err := pushOutbox().Put(it); err != nil {
+		return fmt.Errorf("queue %s push: %w", kind, err)
+	}
+	return nil
+}
+
+// unqueuePush drops a queued item once the same thing was pushed directly.
+func unqueuePush(kind, id string) {
+	if err := pushOutbox().Remove(kind + ":" + id); err != nil {
+		logger.Warn("outbox", "err", err)
+	}
+}
+
+// queuedNote is "queued n, " for the push summary lines when n > 0.
+func queuedNote(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("queued %d, ", n)
+}
+
+// outboxSenders resend the payload of each kind of queued item.
+var outboxSenders = map[string]func(ctx context.Context, payload json.RawMessage) error{
+	"harvest": sendQueuedHarvest,
+	"redmine": sendQueuedRedmine,
+	"tempo":   sendQueuedTempo,
+	"caldav":  sendQueuedCalDAV,
+	"notify":  sendQueuedNotify,
+}
+
+// outboxSendInterval spaces the sends of a flush so a backlog does not run
+// into the remote rate limits.
+var outboxSendInterval = 500 * time.Millisecond
+
+// flushOutbox retries queued items: all of them with force, else only those
+// whose backoff has passed. Sent items are removed, failed ones rescheduled.
+// After a retryable failure the remaining items of that kind wait for the
+// next flush instead of failing the same way.
+func flushOutbox(ctx context.Context, w io.Writer, now time.Time, force bool) (sent, failed int, err error) {
+	store := pushOutbox()
+	items, err := store.List()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(items) == 0 {
+		if force {
+			fmt.Fprintln(w, "Outbox empty.")
+		}
+		return 0, 0, nil
+	}
+	down := map[string]bool{}
+	first := true
+	for _, it := range items {
+		if down[it.Kind] || (!force && !it.Due(now)) {
+			continue
+		}
+		send, ok := outboxSenders[it.Kind]
+		if !ok {
+			fmt.Fprintf(w, "skipped  %s  (unknown kind %q)\n", it.Label, it.Kind)
+			continue
+		}
+		if !first {
+			select {
+			case <-ctx.Done():
+				return sent, failed, ctx.Err()
+			case <-time.After(outboxSendInterval):
+			}
+		}
+		first = false
+		if serr := send(ctx, it.Payload); serr != nil {
+			failed++
+			it.Failed(Now(), serr)
+			if err := store.Put(it); err != nil {
+				return sent, failed, err
+			}
+			if retryablePushError(serr) {
+				down[it.Kind] = true
+			}
+			fmt.Fprintf(w, "failed   %s  %s (attempt %d, next try %s)\n", it.Label, serr, it.Attempts, it.NextTry.In(parserLocation()).Format("15:04"))
+			continue
+		}
+		sent++
+		if err := store.Remove(it.Key); err != nil {
+			return sent, failed, err
+		}
+		fmt.Fprintf(w, "sent     %s\n", it.Label)
+	}
+	fmt.Fprintf(w, "Sent %d, failed %d, queued %d.\n", sent, failed, len(items)-sent)
+	return sent, failed, nil
+}
+
+// renderOutbox lists queued items with their attempts and next retry.
+func renderOutbox(w io.Writer, items []outbox.Item, now time.Time) {
+	if len(items) == 0 {
+		fmt.Fprintln(w, "Outbox empty.")
+		return
+	}
+	loc := parserLocation()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tQUEUED\tATTEMPTS\tNEXT TRY\tITEM")
+	for _, it := range items {
+		next := it.NextTry.In(loc).Format("Jan 02 15:04")
+		if it.Due(now) {
+			next = "due"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", it.Kind, it.Queued.In(loc).Format("Jan 02 15:04"), it.Attempts, next, it.Label)
+		if it.LastError != "" {
+			fmt.Fprintf(tw, "\t\t\t\t  last error: %s\n", it.LastError)
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "%d queued; retry with tt push --flush.\n", len(items))
+}
+
+// sendQueuedHarvest creates a queued Harvest time entry unless an earlier
+// attempt got through after all.
+func sendQueuedHarvest(ctx context.Context, payload json.RawMessage) error {
+	var te harvestTimeEntry
+	if err := json.Unmarshal(payload, &te); err != nil {
+		return err
+	}
+	cfg, err := loadHarvestConfig()
+	if err != nil {
+		return err
+	}
+	client := newHarvestClient(cfg)
+	if ref := te.ExternalReference; ref != nil {
+		day, err := time.Parse("2006-01-02", te.SpentDate)
+		if err != nil {
+			return err
+		}
+		existing, err := client.externalRefs(ctx, day, day)
+		if err != nil {
+			return err
+		}
+		if existing[ref.ID] {
+			return nil
+		}
+	}
+	return client.createTimeEntry(ctx, te)
+}
+
+// sendQueuedRedmine creates a queued Redmine time entry unless an earlier
+// attempt got through after all.
+func sendQueuedRedmine(ctx context.Context, payload json.RawMessage) error {
+	var te redmineTimeEntry
+	if err := json.Unmarshal(payload, &te); err != nil {
+		return err
+	}
+	cfg, err := loadRedmineConfig()
+	if err != nil {
+		return err
+	}
+	client := &redmineClient{baseURL: cfg.URL, apiKey: cfg.APIKey, http: &http.Client{Timeout: 30 * time.Second}}
+	for _, cf := range te.CustomFields {
+		if cf.ID != cfg.EntryIDField || cf.Value == "" {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", te.SpentOn)
+		if err != nil {
+			return err
+		}
+		existing, err := client.entryIDs(ctx, cfg.EntryIDField, day, day)
+		if err != nil {
+			return err
+		}
+		if existing[cf.Value] {
+			return nil
+		}
+	}
+	return client.createTimeEntry(ctx, te)
+}
+
+// queuedCalDAVEvent is a calendar object whose PUT failed.
+type queuedCalDAVEvent struct {
+	EntryID string `json:"entry_id"`
+	ICS     string `json:"ics"`
+}
+
+func sendQueuedCalDAV(ctx context.Context, payload json.RawMessage) error {
+	var ev queuedCalDAVEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return err
+	}
+	_, err := putCalDAVEvent(ctx, &http.Client{Timeout: 30 * time.Second}, loadCalDAVConfig(), ev.EntryID, ev.ICS)
+	return err
+}
+
+// queuedNotice is a chat message whose post failed.
+type queuedNotice struct {
+	Target string `json:"target"`
+	Text   string `json:"text"`
+}
+
+func sendQueuedNotify(ctx context.Context, payload json.RawMessage) error {
+	var n queuedNotice
+	if err := json.Unmarshal(payload, &n); err != nil {
+		return err
+	}
+	for _, t := range notifyTargets() {
+		if t.name == n.Target {
+			return t.post(ctx, n.Text)
+		}
+	}
+	return fmt.Errorf("notify target %s is no longer configured", n.Target)
+}
+
+// outboxRetryInterval is push.retry_min (default 5): how often `tt schedule
+// run` retries the outbox; 0 or less turns the retries off.
+func outboxRetryInterval() time.Duration {
+	if !viper.IsSet("push.retry_min") {
+		return 5 * time.Minute
+	}
+	return time.Duration(viper.GetInt("push.retry_min")) * time.Minute
+}
+
+// runOutboxRetries flushes the due outbox items every interval until ctx is
+// canceled.
+func runOutboxRetries(ctx context.Context, w io.Writer, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, _, err := flushOutbox(ctx, w, Now(), false); err != nil && ctx.Err() == nil {
+				logger.Warn("schedule: outbox", "err", err)
+			}
+		}
+	}
+}
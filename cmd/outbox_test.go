@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRetryablePushError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&httpStatusError{Code: http.StatusServiceUnavailable}, true},
+		{&httpStatusError{Code: http.StatusTooManyRequests}, true},
+		{&httpStatusError{Code: http.StatusUnauthorized}, false},
+		{&httpStatusError{Code: http.StatusInsufficientStorage}, false},
+		{&url.Error{Op: "Post", URL: "https://example.org", Err: errors.New("no route to host")}, true},
+		{errors.New("harvest.mapping[0]: customer required"), false},
+	}
+	for _, tc := range cases {
+		if got := retryablePushError(tc.err); got != tc.want {
+			t.Errorf("retryablePushError(%v) = %v; want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+// TestHarvestPushQueuesAndFlushes pushes while Harvest answers 503, then
+// flushes the outbox once it is back.
+func TestHarvestPushQueuesAndFlushes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	oldNow, oldInterval := Now, outboxSendInterval
+	outboxSendInterval = 0
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("harvest", nil)
+		Now, outboxSendInterval = oldNow, oldInterval
+	})
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return day.Add(10 * time.Hour) }
+
+	var (
+		mu      sync.Mutex
+		down    = true
+		created []harvestTimeEntry
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if down {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"time_entries":[],"next_page":null}`))
+		case http.MethodPost:
+			var te harvestTimeEntry
+			json.NewDecoder(r.Body).Decode(&te)
+			created = append(created, te)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer srv.Close()
+	viper.Set("harvest.base_url", srv.URL)
+	viper.Set("harvest.account_id", "42")
+	viper.Set("harvest.token", "tok")
+	viper.Set("harvest.mapping", []map[string]interface{}{{"customer": "Acme", "project_id": 1, "task_id": 10}})
+	cfg, err := loadHarvestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	end := day.Add(time.Hour)
+	ents := []Entry{{ID: "e1", Customer: "Acme", Start: day, End: &end, Notes: []string{"work"}}}
+	var out bytes.Buffer
+	if err := pushHarvest(context.Background(), &out, cfg, ents, day, day, false); err != nil {
+		t.Fatalf("push while down: %v", err)
+	}
+	if !strings.Contains(out.String(), "Created 0, queued 1, already pushed 0, unmapped 0.") {
+		t.Fatalf("push output:\n%s", out.String())
+	}
+
+	out.Reset()
+	items, err := pushOutbox().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderOutbox(&out, items, Now())
+	if !strings.Contains(out.String(), "harvest") || !strings.Contains(out.String(), "last error: harvest: list time entries") ||
+		!strings.Contains(out.String(), "1 queued") {
+		t.Fatalf("status:\n%s", out.String())
+	}
+
+	// still down: the flush keeps the item and backs off
+	out.Reset()
+	if sent, failed, err := flushOutbox(context.Background(), &out, Now(), true); err != nil || sent != 0 || failed != 1 {
+		t.Fatalf("flush while down = %d sent, %d failed, %v", sent, failed, err)
+	}
+	items, _ = pushOutbox().List()
+	if len(items) != 1 || items[0].Attempts != 2 {
+		t.Fatalf("items after failed flush = %+v", items)
+	}
+	if sent, _, _ := flushOutbox(context.Background(), &out, Now(), false); sent != 0 {
+		t.Fatal("a scheduled flush retried before the backoff passed")
+	}
+
+	mu.Lock()
+	down = false
+	mu.Unlock()
+	out.Reset()
+	if sent, failed, err := flushOutbox(context.Background(), &out, Now(), true); err != nil || sent != 1 || failed != 0 {
+		t.Fatalf("flush = %d sent, %d failed, %v\n%s", sent, failed, err, out.String())
+	}
+	if len(created) != 1 || created[0].ExternalReference.ID != "e1" {
+		t.Fatalf("created = %+v", created)
+	}
+	if items, _ := pushOutbox().List(); len(items) != 0 {
+		t.Fatalf("outbox not emptied: %+v", items)
+	}
+}
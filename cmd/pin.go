@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+// Pins and follow-up flags are reminders for corrections that cannot be made
+// right now (a missing ticket number, a question to the customer). They are
+// "pin", "follow-up" and "unpin" events naming the entry in their ref; they
+// do not change the entry, so reports ignore them. tt pinned lists what is
+// still marked and the TUI shows a badge on marked entries.
+
+var pinFollowUp bool
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <id> [reason]",
+	Short: "Pin an entry, or flag it as needing follow-up, until it is unpinned",
+	Long: "Pin an entry so it shows up in tt pinned until tt unpin. With --follow-up the entry is flagged " +
+		"as waiting on something, e.g. tt pin today:2 --follow-up \"missing ticket number\".\n\n" +
+		"id is " + entryRefHelp + ".",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveEntryRef(args[0])
+		if err != nil {
+			return err
+		}
+		kind := journal.MarkPin
+		if pinFollowUp {
+			kind = journal.MarkFollowUp
+		}
+		ev := NewMarkEvent(IDGen(), kind, id, strings.Join(args[1:], " "), Now())
+		if err := writeEvent(ev); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", markTitle(kind), id)
+		return nil
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <id>",
+	Short: "Clear the pin or follow-up flag of an entry",
+	Long:  "Clear the pin or follow-up flag of an entry.\n\nid is " + entryRefHelp + ".",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := resolveEntryRef(args[0])
+		if err != nil {
+			return err
+		}
+		marks, err := activeMarks()
+		if err != nil {
+			return err
+		}
+		if _, ok := marks[id]; !ok {
+			return fmt.Errorf("entry %s is not pinned", id)
+		}
+		if err := writeEvent(NewMarkEvent(IDGen(), journal.MarkClear, id, "", Now())); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Unpinned %s\n", id)
+		return nil
+	},
+}
+
+var pinnedCmd = &cobra.Command{
+	Use:   "pinned",
+	Short: "List pinned entries and entries flagged for follow-up",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := Now().In(parserLocation())
+		from := now.AddDate(0, 0, -entryRefLookbackDays)
+		marks, err := loadMarks(from, now)
+		if err != nil {
+			logger.Warn("failed to load some marks", "err", err)
+		}
+		ents, err := loadEntries(from, now)
+		if err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		printPinned(cmd.OutOrStdout(), journal.ActiveMarks(marks), ents)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd, unpinCmd, pinnedCmd)
+	pinCmd.Flags().BoolVar(&pinFollowUp, "follow-up", false, "flag the entry as waiting on something instead of pinning it")
+}
+
+// NewMarkEvent returns the event setting (or, for journal.MarkClear,
+// clearing) the mark kind on entry id.
+func NewMarkEvent(id, kind, entryID, reason string, ts time.Time) Event {
+	return Event{ID: id, Type: kind, TS: ts, Ref: entryID, Note: reason}
+}
+
+// loadMarks returns the mark events recorded from..to (whole days).
+// Missing day files are skipped.
+func loadMarks(from, to time.Time) ([]journal.Mark, error) {
+	p := journal.NewParser(viper.GetString("timezone"))
+	var out []journal.Mark
+	for _, path := range journalPaths(from, to) {
+		marks, err := p.ParseMarkFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, marks...)
+	}
+	return out, nil
+}
+
+// activeMarks returns the marks currently set on entries, by entry ID,
+// looking back as far as entry references are resolved.
+func activeMarks() (map[string]journal.Mark, error) {
+	now := Now().In(parserLocation())
+	marks, err := loadMarks(now.AddDate(0, 0, -entryRefLookbackDays), now)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]journal.Mark{}
+	for _, m := range journal.ActiveMarks(marks) {
+		out[m.EntryID] = m
+	}
+	return out, nil
+}
+
+// markTitle names a mark kind for output.
+func markTitle(kind string) string {
+	if kind == journal.MarkFollowUp {
+		return "Follow-up"
+	}
+	return "Pinned"
+}
+
+// markBadge is the short label of a mark shown next to an entry, with the
+// reason when there is one.
+func markBadge(m journal.Mark) string {
+	badge := strings.ToLower(markTitle(m.Kind))
+	if m.Reason != "" {
+		badge += ": " + m.Reason
+	}
+	return badge
+}
+
+// printPinned lists the follow-up flags, then the pins, oldest first, each
+// with the entry it marks. Marks of entries that no longer exist (replaced by
+// a split or merge, or older than the lookback) are listed by ID.
+func printPinned(w io.Writer, marks []journal.Mark, ents []Entry) {
+	if len(marks) == 0 {
+		fmt.Fprintln(w, "Nothing pinned.")
+		return
+	}
+	loc := parserLocation()
+	byID := make(map[string]Entry, len(ents))
+	for _, e := range ents {
+		byID[e.ID] = e
+	}
+	for _, kind := range []string{journal.MarkFollowUp, journal.MarkPin} {
+		var lines []string
+		for _, m := range marks {
+			if m.Kind != kind {
+				continue
+			}
+			line := m.EntryID + "  (entry not found)"
+			if e, ok := byID[m.EntryID]; ok {
+				line = e.ID + "  " + entryWindowLine(e)
+			}
+			line += "  since " + m.TS.In(loc).Format("Jan 02")
+			if m.Reason != "" {
+				line += fmt.Sprintf("  %q", m.Reason)
+			}
+			lines = append(lines, "  "+line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s%s (%d):%s\n", ansiHeading, markTitle(kind), len(lines), ansiReset)
+		fmt.Fprintln(w, strings.Join(lines, "\n"))
+	}
+}
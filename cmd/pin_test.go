@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+func TestPinFollowUpAndUnpin(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = prevNow
+		pinFollowUp = false
+	})
+
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		NewAddEvent("tt_a1", "acme", "portal", "", boolPtr(true), "login", nil, day, day.Add(2*time.Hour)),
+		NewAddEvent("tt_b2", "globex", "", "", boolPtr(true), "", nil, day.Add(3*time.Hour), day.Add(4*time.Hour)),
+	} {
+		ev.TS = day.Add(8 * time.Hour)
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	pinCmd.SetOut(&out)
+	unpinCmd.SetOut(&out)
+	pinFollowUp = true
+	if err := pinCmd.RunE(pinCmd, []string{"b2", "missing", "ticket", "number"}); err != nil {
+		t.Fatal(err)
+	}
+	pinFollowUp = false
+	if err := pinCmd.RunE(pinCmd, []string{"a1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "Follow-up tt_b2\nPinned tt_a1\n" {
+		t.Fatalf("output = %q", got)
+	}
+
+	marks, err := activeMarks()
+	if err != nil || len(marks) != 2 {
+		t.Fatalf("activeMarks = %+v, %v", marks, err)
+	}
+	if got := uiEntry(Entry{ID: "tt_b2"}, marks, completionDecisions{}).Mark; got != "follow-up: missing ticket number" {
+		t.Fatalf("badge = %q", got)
+	}
+	if ents, _ := loadEntries(day, now); len(ents) != 2 || len(ents[1].Notes) != 1 {
+		t.Fatalf("marks changed entries: %+v", ents)
+	}
+
+	ents, _ := loadEntries(day, now)
+	all, _ := loadMarks(day, now)
+	out.Reset()
+	printPinned(&out, journal.ActiveMarks(all), ents)
+	for _, want := range []string{"Follow-up (1):", `tt_b2  Oct 06 12:00–13:00`, `"missing ticket number"`, "Pinned (1):", "tt_a1  Oct 06 09:00–11:00", "since Oct 08"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("pinned lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), "Follow-up") > strings.Index(out.String(), "Pinned") {
+		t.Errorf("follow-ups should come first:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := unpinCmd.RunE(unpinCmd, []string{"a1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := unpinCmd.RunE(unpinCmd, []string{"a1"}); err == nil || !strings.Contains(err.Error(), "not pinned") {
+		t.Fatalf("second unpin err = %v", err)
+	}
+	if marks, _ := activeMarks(); len(marks) != 1 {
+		t.Fatalf("after unpin = %+v", marks)
+	}
+
+	out.Reset()
+	printPinned(&out, nil, nil)
+	if out.String() != "Nothing pinned.\n" {
+		t.Fatalf("empty = %q", out.String())
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// profileName is the --profile flag; TT_PROFILE and the "profile" config key
+// are the fallbacks.
+var profileName string
+
+// activeProfile is the profile applied at startup ("" when none), and
+// profileBase holds the config values its keys replaced (flattened key ->
+// value, nil when the key was unset) so saving the config keeps them.
+var (
+	activeProfile string
+	profileBase   map[string]any
+)
+
+// profileCmd lists the configured profiles. A profile overrides any config
+// key for the invocation, resolved at startup:
+//
+//	profiles:
+//	  work:
+//	    timezone: Europe/Berlin
+//	    rounding:
+//	      quantum_min: 15
+//	    rates:
+//	      default: 95
+//	  side:
+//	    rounding:
+//	      quantum_min: 6
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "List config profiles (select with --profile or TT_PROFILE)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names := profileNames()
+		if len(names) == 0 {
+			cmd.Println("no profiles defined (profiles.<name> in config)")
+			return
+		}
+		for _, n := range names {
+			mark := " "
+			if n == activeProfile {
+				mark = "*"
+			}
+			cmd.Printf("%s %s (%d overrides)\n", mark, n, len(flattenConfig(viper.GetStringMap("profiles."+n), "")))
+		}
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the keys a profile overrides (default: the active profile)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := activeProfile
+		if len(args) == 1 {
+			name = strings.ToLower(args[0])
+		}
+		if name == "" {
+			return fmt.Errorf("no active profile; pass a name")
+		}
+		if !viper.IsSet("profiles." + name) {
+			return fmt.Errorf("profile %q not defined", name)
+		}
+		flat := flattenConfig(viper.GetStringMap("profiles."+name), "")
+		keys := make([]string, 0, len(flat))
+		for k := range flat {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		cmd.Printf("profile %s:\n", name)
+		for _, k := range keys {
+			cmd.Printf("  %s = %v\n", k, flat[k])
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "config profile to apply (default: $TT_PROFILE or the \"profile\" config key)")
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileShowCmd)
+}
+
+// resolveProfile returns the requested profile: --profile, then TT_PROFILE,
+// then the "profile" config key.
+func resolveProfile() string {
+	for _, p := range []string{profileName, os.Getenv("TT_PROFILE"), viper.GetString("profile")} {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+// applyProfile merges profiles.<name> over the config, so every viper lookup
+// (rounding, timezone, rates, aliases, hooks, ...) sees the profile's value.
+// It is idempotent and re-run after the config is re-read.
+func applyProfile() error {
+	name := resolveProfile()
+	if name == "" {
+		return nil
+	}
+	if !viper.IsSet("profiles." + name) {
+		return fmt.Errorf("profile %q not defined (add profiles.%s to the config)", name, name)
+	}
+	overrides := viper.GetStringMap("profiles." + name)
+	if name != activeProfile || profileBase == nil {
+		profileBase = map[string]any{}
+		for k := range flattenConfig(overrides, "") {
+			if viper.InConfig(k) {
+				profileBase[k] = viper.Get(k)
+			} else {
+				profileBase[k] = nil
+			}
+		}
+	}
+	activeProfile = name
+	resetConfigCaches()
+	return viper.MergeConfigMap(overrides)
+}
+
+// persistedSettings is what saveViperConfig writes: all settings with the
+// active profile's values swapped back for the base ones, unless they were
+// changed during this invocation.
+func persistedSettings() map[string]any {
+	all := viper.AllSettings()
+	if activeProfile == "" {
+		return all
+	}
+	overrides := flattenConfig(viper.GetStringMap("profiles."+activeProfile), "")
+	for k, base := range profileBase {
+		cur, ok := configPath(all, k)
+		if !ok || !reflect.DeepEqual(cur, overrides[k]) {
+			continue
+		}
+		setConfigPath(all, k, base)
+	}
+	return all
+}
+
+func profileNames() []string {
+	var out []string
+	for n := range viper.GetStringMap("profiles") {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// flattenConfig returns the leaf values of a nested config map by dotted key.
+func flattenConfig(m map[string]any, prefix string) map[string]any {
+	out := map[string]any{}
+	for k, v := range m {
+		key := strings.ToLower(prefix + k)
+		if sub, ok := v.(map[string]any); ok && len(sub) > 0 {
+			for sk, sv := range flattenConfig(sub, key+".") {
+				out[sk] = sv
+			}
+			continue
+		}
+		out[key] = v
+	}
+	return out
+}
+
+func configPath(m map[string]any, key string) (any, bool) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts[:len(parts)-1] {
+		sub, ok := m[p].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		m = sub
+	}
+	v, ok := m[parts[len(parts)-1]]
+	return v, ok
+}
+
+// setConfigPath sets key in m, deleting it when v is nil.
+func setConfigPath(m map[string]any, key string, v any) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts[:len(parts)-1] {
+		sub, ok := m[p].(map[string]any)
+		if !ok {
+			if v == nil {
+				return
+			}
+			sub = map[string]any{}
+			m[p] = sub
+		}
+		m = sub
+	}
+	if v == nil {
+		delete(m, parts[len(parts)-1])
+		return
+	}
+	m[parts[len(parts)-1]] = v
+}
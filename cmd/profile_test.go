@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestApplyProfileOverridesAndKeepsBaseOnSave(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TT_PROFILE", "")
+	viper.Reset()
+	cfg := filepath.Join(home, ".tt", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(cfg), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	base := `timezone: UTC
+profile: side
+rounding:
+  strategy: up
+  quantum_min: 5
+aliases:
+  lunch:
+    customer: me
+profiles:
+  work:
+    timezone: Europe/Berlin
+    rounding:
+      quantum_min: 15
+    rates:
+      default: 95
+    aliases:
+      acme/standup:
+        customer: acme
+  side:
+    rounding:
+      quantum_min: 6
+`
+	if err := os.WriteFile(cfg, []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(cfg)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		profileName, activeProfile, profileBase = "", "", nil
+		aliasesCache.reset()
+		viper.Reset()
+	})
+
+	// the config key selects "side"; --profile wins over it
+	if err := applyProfile(); err != nil || activeProfile != "side" || viper.GetInt("rounding.quantum_min") != 6 {
+		t.Fatalf("config profile: active=%q quantum=%d err=%v", activeProfile, viper.GetInt("rounding.quantum_min"), err)
+	}
+	viper.Reset()
+	viper.SetConfigFile(cfg)
+	_ = viper.ReadInConfig()
+	profileName, profileBase = "work", nil
+	if err := applyProfile(); err != nil {
+		t.Fatal(err)
+	}
+	checks := map[string]any{
+		"timezone":             "Europe/Berlin",
+		"rounding.quantum_min": 15,
+		"rounding.strategy":    "up",
+		"rates.default":        95,
+	}
+	for k, want := range checks {
+		if got := viper.Get(k); got != want {
+			t.Errorf("%s = %v, want %v", k, got, want)
+		}
+	}
+	aliases := loadAliases()
+	if aliases["lunch"].Customer != "me" || aliases["acme/standup"].Customer != "acme" {
+		t.Errorf("aliases = %+v", aliases)
+	}
+
+	// saving keeps the base values and the new setting
+	if err := setAlias("retro", Alias{Customer: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	saved := viper.New()
+	saved.SetConfigFile(cfg)
+	if err := saved.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if saved.GetString("timezone") != "UTC" || saved.GetInt("rounding.quantum_min") != 5 || saved.IsSet("rates.default") {
+		t.Errorf("profile values leaked into base config:\n%v", saved.AllSettings())
+	}
+	if saved.IsSet("aliases.acme/standup") || saved.GetString("aliases.retro.customer") != "acme" {
+		t.Errorf("saved aliases = %v", saved.Get("aliases"))
+	}
+	if saved.GetInt("profiles.work.rounding.quantum_min") != 15 {
+		t.Error("profiles section must be kept")
+	}
+
+	profileName = "missing"
+	if err := applyProfile(); err == nil {
+		t.Error("unknown profile should fail")
+	}
+}
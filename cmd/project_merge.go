@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// project-merge flags
+var (
+	pmTargets  string // comma-separated ids
+	pmSince    string // since time (optional; default: whole journal)
+	pmFrom     string // comma-separated source project names
+	pmTo       string // canonical project name to set
+	pmCustomer string // customer the mapping applies to (optional; default: all customers)
+	pmNote     string // note to attach to amend events
+	pmDryRun   bool   // default true to avoid surprises
+)
+
+// projectMapAnyCustomer is the projects.map key for mappings that apply to
+// every customer.
+const projectMapAnyCustomer = "*"
+
+// mergedProjectMap holds customer -> source project -> canonical project, all
+// keys lower case (viper lower-cases nested keys anyway). It is loaded lazily
+// from "projects.map" and refreshed when project-merge persists a mapping.
+var mergedProjectMap configCache[map[string]map[string]string]
+
+// projectMergeCmd is the project-level counterpart of customer-merge: it
+// writes amend events setting the canonical project on matching entries and
+// persists the mapping under "projects.map" for completion and reports:
+//
+//	projects:
+//	  map:
+//	    acme:
+//	      web: portal
+//	      website: portal
+var projectMergeCmd = &cobra.Command{
+	Use:   "project-merge",
+	Short: "Non-destructively merge project names by writing amend events (append-only)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		canonical := strings.TrimSpace(pmTo)
+		if canonical == "" {
+			cobra.CheckErr(fmt.Errorf("--to is required (target canonical project)"))
+		}
+		sources := splitNames(pmFrom)
+		if len(sources) == 0 && pmTargets == "" {
+			cobra.CheckErr(fmt.Errorf("--from is required unless --targets is given"))
+		}
+		customer := strings.TrimSpace(pmCustomer)
+
+		var targetIDs []string
+		origByID := map[string]string{}
+
+		if pmTargets != "" {
+			targetIDs = splitNames(pmTargets)
+		} else {
+			from := earliestJournalDay()
+			if pmSince != "" {
+				from = mustParseTimeLocal(pmSince)
+			}
+			ents, err := loadEntries(from, nowLocal())
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("failed loading entries for selection: %w", err))
+			}
+			for _, e := range ents {
+				if customer != "" && !strings.EqualFold(CanonicalCustomer(e.Customer), customer) {
+					continue
+				}
+				if !containsFold(sources, e.Project) || e.Project == canonical {
+					continue
+				}
+				targetIDs = append(targetIDs, e.ID)
+				origByID[e.ID] = e.Project
+			}
+		}
+
+		newMappings := map[string]string{}
+		for _, s := range sources {
+			if !strings.EqualFold(s, canonical) {
+				newMappings[strings.ToLower(s)] = canonical
+			}
+		}
+		scope := customer
+		if scope == "" {
+			scope = "all customers"
+		}
+
+		if pmDryRun {
+			fmt.Printf("DRY RUN: would write %d amend event(s) setting project -> %q (%s)\n", len(targetIDs), canonical, scope)
+			for _, id := range targetIDs {
+				fmt.Printf("  - %s : %s -> %s\n", id, origByID[id], canonical)
+			}
+			if len(newMappings) > 0 {
+				fmt.Println("DRY RUN: would persist project mappings:")
+				for _, s := range sortedKeysOf(newMappings) {
+					fmt.Printf("  - %q -> %q\n", s, newMappings[s])
+				}
+			}
+			return
+		}
+
+		if len(newMappings) > 0 {
+			if err := saveProjectMappings(customer, newMappings); err != nil {
+				cmd.Printf("warning: failed to persist project mapping to config: %v\n", err)
+			}
+		}
+
+		if len(targetIDs) == 0 {
+			cmd.Printf("No entries to amend; mapping recorded for %s.\n", scope)
+			return
+		}
+		evs := make([]Event, 0, len(targetIDs))
+		for _, id := range targetIDs {
+			meta := map[string]string{}
+			if orig := origByID[id]; orig != "" {
+				meta["merged_from_project"] = orig
+			}
+			evs = append(evs, Event{
+				ID:      IDGen(),
+				Type:    "amend",
+				TS:      Now(),
+				Ref:     id,
+				Note:    pmNote,
+				Project: canonical,
+				Meta:    meta,
+			})
+		}
+		if err := writeEvents(evs); err != nil {
+			cobra.CheckErr(fmt.Errorf("failed to write amend events: %w", err))
+		}
+		cmd.Printf("Wrote %d amend event(s) setting project -> %q\n", len(evs), canonical)
+	},
+}
+
+func init() {
+	projectMergeCmd.Flags().StringVar(&pmTargets, "targets", "", "comma-separated target entry ids to amend")
+	projectMergeCmd.Flags().StringVar(&pmSince, "since", "", "only entries since this time (default: the whole journal)")
+	projectMergeCmd.Flags().StringVar(&pmFrom, "from", "", "comma-separated source project names to merge")
+	projectMergeCmd.Flags().StringVar(&pmTo, "to", "", "canonical project name to set (required)")
+	projectMergeCmd.Flags().StringVar(&pmCustomer, "customer", "", "only merge projects of this customer (default: all customers)")
+	projectMergeCmd.Flags().StringVar(&pmNote, "note", "", "note to append to each amend event")
+	projectMergeCmd.Flags().BoolVar(&pmDryRun, "dry-run", true, "perform a dry-run (default true); use --dry-run=false to actually write amend events")
+
+	rootCmd.AddCommand(projectMergeCmd)
+}
+
+// saveProjectMappings merges mappings (lower-case source -> canonical) into
+// projects.map.<customer> and reloads the in-memory map.
+func saveProjectMappings(customer string, mappings map[string]string) error {
+	key := strings.ToLower(strings.TrimSpace(customer))
+	if key == "" {
+		key = projectMapAnyCustomer
+	}
+	existing := viper.GetStringMapString("projects.map." + key)
+	merged := map[string]string{}
+	for s, t := range existing {
+		merged[strings.ToLower(s)] = t
+	}
+	for s, t := range mappings {
+		merged[s] = t
+	}
+	all := viper.GetStringMap("projects.map")
+	if all == nil {
+		all = map[string]interface{}{}
+	}
+	all[key] = merged
+	viper.Set("projects.map", all)
+	mergedProjectMap.reset()
+	return saveViperConfig()
+}
+
+func loadMergedProjectMap() map[string]map[string]string {
+	out := map[string]map[string]string{}
+	for cust := range viper.GetStringMap("projects.map") {
+		m := map[string]string{}
+		for s, t := range viper.GetStringMapString("projects.map." + cust) {
+			if s != "" && t != "" {
+				m[strings.ToLower(s)] = t
+			}
+		}
+		out[strings.ToLower(cust)] = m
+	}
+	return out
+}
+
+// CanonicalProject returns the canonical name of project for customer using
+// projects.map: a mapping for the (canonical) customer wins over one for all
+// customers. Unknown projects are returned unchanged.
+func CanonicalProject(customer, project string) string {
+	if project == "" {
+		return project
+	}
+	projects := mergedProjectMap.get(loadMergedProjectMap)
+	src := strings.ToLower(strings.TrimSpace(project))
+	if customer != "" {
+		if c, ok := projects[strings.ToLower(CanonicalCustomer(customer))][src]; ok {
+			return c
+		}
+	}
+	if c, ok := projects[projectMapAnyCustomer][src]; ok {
+		return c
+	}
+	return project
+}
+
+// earliestJournalDay returns the first day with a journal file, or today when
+// the journal is empty.
+func earliestJournalDay() time.Time {
+	home, _ := os.UserHomeDir()
+	root := filepath.Join(home, ".tt", "journal")
+	loc := parserLocation()
+	first := func(dir string, dirs bool) string {
+		ents, _ := os.ReadDir(dir)
+		for _, e := range ents { // ReadDir sorts by name
+			if e.IsDir() == dirs && (dirs || strings.HasSuffix(e.Name(), ".jsonl")) {
+				return e.Name()
+			}
+		}
+		return ""
+	}
+	year := first(root, true)
+	month := first(filepath.Join(root, year), true)
+	day := first(filepath.Join(root, year, month), false)
+	if year == "" || month == "" || day == "" {
+		return nowLocal()
+	}
+	t, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(day, ".jsonl"), loc)
+	if err != nil {
+		return nowLocal()
+	}
+	return t
+}
+
+func splitNames(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeysOf(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
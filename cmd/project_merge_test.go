@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestProjectMergeAmendsAndCanonicalizes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("projects.map", map[string]interface{}{})
+	mergedProjectMap.reset()
+	mergedCustomerSet.reset()
+	oldFrom, oldTo, oldCustomer, oldDry, oldNow := pmFrom, pmTo, pmCustomer, pmDryRun, Now
+	t.Cleanup(func() {
+		pmFrom, pmTo, pmCustomer, pmDryRun, Now = oldFrom, oldTo, oldCustomer, oldDry, oldNow
+		viper.Set("timezone", "")
+		viper.Set("projects.map", nil)
+		mergedProjectMap.reset()
+	})
+
+	day := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return day.Add(20 * time.Hour) }
+	add := func(id, c, p string, h int) Event {
+		st := day.Add(time.Duration(h) * time.Hour)
+		ev := NewAddEvent(id, c, p, "dev", nil, "", nil, st, st.Add(time.Hour))
+		ev.TS = st
+		return ev
+	}
+	if err := writeEvents([]Event{
+		add("e1", "Acme", "web", 8),
+		add("e2", "Acme", "Website", 9),
+		add("e3", "Acme", "portal", 10),
+		add("e4", "Globex", "web", 11),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	pmFrom, pmTo, pmCustomer, pmDryRun = "web,website", "portal", "acme", false
+	projectMergeCmd.Run(projectMergeCmd, nil)
+
+	ents, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, e := range ents {
+		got[e.ID] = e.Project
+	}
+	want := map[string]string{"e1": "portal", "e2": "portal", "e3": "portal", "e4": "web"}
+	for id, p := range want {
+		if got[id] != p {
+			t.Errorf("entry %s project = %q, want %q (all: %v)", id, got[id], p, got)
+		}
+	}
+
+	if m := viper.GetStringMapString("projects.map.acme"); m["web"] != "portal" || m["website"] != "portal" {
+		t.Errorf("persisted projects.map.acme = %v", m)
+	}
+	cases := []struct{ customer, project, want string }{
+		{"Acme", "WEB", "portal"},
+		{"acme", "website", "portal"},
+		{"Globex", "web", "web"},
+		{"", "web", "web"},
+	}
+	for _, tc := range cases {
+		if got := CanonicalProject(tc.customer, tc.project); got != tc.want {
+			t.Errorf("CanonicalProject(%q, %q) = %q, want %q", tc.customer, tc.project, got, tc.want)
+		}
+	}
+
+	// a mapping without --customer applies to every customer
+	if err := saveProjectMappings("", map[string]string{"ops": "operations"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := CanonicalProject("Globex", "Ops"); got != "operations" {
+		t.Errorf("global mapping: got %q", got)
+	}
+
+	dec := completionDecisions{allowProjects: map[string]map[string]struct{}{"acme": {"web": {}, "portal": {}}}}
+	if got := projectCompletionList(dec, "acme", "", ""); len(got) != 1 || got[0] != "portal" {
+		t.Errorf("completion should offer only the canonical project, got %v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushToday  bool
+	pushWeek   bool
+	pushRange  string
+	pushDryRun bool
+)
+
+// pushCmd groups integrations that send tracked time to remote systems.
+// Subcommands share the range flags and --dry-run, which lists what would be
+// sent without writing anything remotely.
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Push tracked time to remote systems (harvest, redmine, tempo, caldav, invoice-ninja)",
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+	pushCmd.PersistentFlags().BoolVar(&pushToday, "today", false, "today only")
+	pushCmd.PersistentFlags().BoolVar(&pushWeek, "week", false, "this week (Mon..Sun)")
+	pushCmd.PersistentFlags().StringVar(&pushRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	pushCmd.PersistentFlags().BoolVar(&pushDryRun, "dry-run", false, "list what would be pushed without sending anything")
+}
+
+// pushEntries loads the finished entries selected by the push range flags
+// of cmd.
+func pushEntries(cmd *cobra.Command) ([]Entry, time.Time, time.Time, error) {
+	from, to := parseRangeFlags(pushToday, pushWeek, pushRange)
+	noteUsageRange(cmd, from, to)
+	ents, err := finishedEntries(from, to)
+	return ents, from, to, err
+}
+
+// bestMapping returns the index of the most specific of n mapping rules that
+// matches e, or -1. Customer must match; project and activity, when set on a
+// rule, must match as well and make the rule more specific. Comparisons are
+// case-insensitive.
+func bestMapping(n int, rule func(i int) (customer, project, activity string), e Entry) int {
+	best, bestScore := -1, -1
+	for i := 0; i < n; i++ {
+		customer, project, activity := rule(i)
+		if !strings.EqualFold(customer, e.Customer) {
+			continue
+		}
+		score := 0
+		if project != "" {
+			if !strings.EqualFold(project, e.Project) {
+				continue
+			}
+			score += 2
+		}
+		if activity != "" {
+			if !strings.EqualFold(activity, e.Activity) {
+				continue
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
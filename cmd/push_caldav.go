@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pushCalDAVCmd publishes entries as VEVENTs to a CalDAV collection.
+//
+// Config (~/.tt/config.yaml):
+//
+//	caldav:
+//	  url: https://dav.example.com/calendars/me/tt/   # collection URL
+//	  username: me
+//	  password: "..."     # or TT_CALDAV_PASSWORD in the environment
+//
+// Each entry is stored at <url>/<entry ID>.ics with UID = entry ID. Pushing
+// again after an amend/split overwrites the same resource, so the calendar
+// shows the corrected event instead of a duplicate.
+var pushCalDAVCmd = &cobra.Command{
+	Use:   "caldav",
+	Short: "Create or update calendar events on a CalDAV collection",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := loadCalDAVConfig()
+		if cfg.URL == "" {
+			return fmt.Errorf("caldav.url must be configured")
+		}
+		ents, _, _, err := pushEntries(cmd)
+		if err != nil {
+			return err
+		}
+		return pushCalDAV(cmd.Context(), cmd.OutOrStdout(), cfg, ents, pushDryRun)
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushCalDAVCmd)
+}
+
+type calDAVConfig struct {
+	URL      string
+	Username string
+	Password string
+}
+
+func loadCalDAVConfig() calDAVConfig {
+	cfg := calDAVConfig{
+		URL:      viper.GetString("caldav.url"),
+		Username: viper.GetString("caldav.username"),
+		Password: configSecret("caldav.password"),
+	}
+	if cfg.Password == "" {
+		cfg.Password = os.Getenv("TT_CALDAV_PASSWORD")
+	}
+	return cfg
+}
+
+// calDAVResource returns the resource URL for an entry within the collection.
+func (c calDAVConfig) calDAVResource(id string) string {
+	return strings.TrimRight(c.URL, "/") + "/" + url.PathEscape(id) + ".ics"
+}
+
+// pushCalDAV puts one event per entry. Events that fail retryably are queued
+// in the outbox (see outbox.go).
+func pushCalDAV(ctx context.Context, out io.Writer, cfg calDAVConfig, ents []Entry, dryRun bool) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	stamp := Now()
+	created, updated, queued := 0, 0, 0
+	for _, e := range ents {
+		href := cfg.calDAVResource(e.ID)
+		if dryRun {
+			fmt.Fprintf(out, "would put  %s  %s  %s\n", e.Start.Format("2006-01-02 15:04"), icalSummary(e), href)
+			continue
+		}
+		ics := icalEventObject(e, stamp)
+		code, err := putCalDAVEvent(ctx, client, cfg, e.ID, ics)
+		if err != nil && !retryablePushError(err) {
+			return fmt.Errorf("caldav: entry %s: %w", e.ID, err)
+		}
+		if err != nil {
+			label := fmt.Sprintf("%s %s (%s)", e.Start.Format("2006-01-02 15:04"), icalSummary(e), e.ID)
+			if qerr := queuePush("caldav", e.ID, label, queuedCalDAVEvent{EntryID: e.ID, ICS: ics}, err); qerr != nil {
+				return qerr
+			}
+			queued++
+			fmt.Fprintf(out, "queued   %s  %s  (%v)\n", e.Start.Format("2006-01-02 15:04"), icalSummary(e), err)
+			continue
+		}
+		unqueuePush("caldav", e.ID)
+		if code == http.StatusCreated {
+			created++
+			fmt.Fprintf(out, "created  %s  %s\n", e.Start.Format("2006-01-02 15:04"), icalSummary(e))
+		} else {
+			updated++
+			fmt.Fprintf(out, "updated  %s  %s\n", e.Start.Format("2006-01-02 15:04"), icalSummary(e))
+		}
+	}
+	if dryRun {
+		fmt.Fprintf(out, "Would put %d events.\n", len(ents))
+		return nil
+	}
+	if queued > 0 {
+		fmt.Fprintf(out, "Created %d, updated %d, queued %d.\n", created, updated, queued)
+		return nil
+	}
+	fmt.Fprintf(out, "Created %d, updated %d.\n", created, updated)
+	return nil
+}
+
+// putCalDAVEvent stores the calendar object ics of entry id and returns the
+// response status.
+func putCalDAVEvent(ctx context.Context, client *http.Client, cfg calDAVConfig, id, ics string) (int, error) {
+	href := cfg.calDAVResource(id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, href, strings.NewReader(ics))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if cfg.Username != "" || cfg.Password != "" {
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return resp.StatusCode, newHTTPStatusError("PUT "+href, resp)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPushCalDAVUpdatesByEntryID(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the outbox lives there
+	var mu sync.Mutex
+	store := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "me" || p != "pw" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		_, existed := store[r.URL.Path]
+		store[r.URL.Path] = string(body)
+		if existed {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	cfg := calDAVConfig{URL: srv.URL + "/cal/tt/", Username: "me", Password: "pw"}
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	e := Entry{ID: "e1", Start: start, End: &end, Customer: "Acme"}
+
+	var dry bytes.Buffer
+	if err := pushCalDAV(context.Background(), &dry, cfg, []Entry{e}, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(store) != 0 || !strings.Contains(dry.String(), "/cal/tt/e1.ics") {
+		t.Fatalf("dry run wrote or mis-listed: store=%v out=%s", store, dry.String())
+	}
+
+	var out bytes.Buffer
+	if err := pushCalDAV(context.Background(), &out, cfg, []Entry{e}, false); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	// A corrected entry keeps its ID and must replace the same resource.
+	later := end.Add(30 * time.Minute)
+	e.End = &later
+	if err := pushCalDAV(context.Background(), &out, cfg, []Entry{e}, false); err != nil {
+		t.Fatalf("second push: %v", err)
+	}
+	if !strings.Contains(out.String(), "Created 1, updated 0.") || !strings.Contains(out.String(), "Created 0, updated 1.") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if len(store) != 1 {
+		t.Fatalf("want a single resource, got %v", store)
+	}
+	got := store["/cal/tt/e1.ics"]
+	if !strings.Contains(got, "UID:e1\r\n") || !strings.Contains(got, "DTEND:20251006T103000Z\r\n") {
+		t.Fatalf("resource not updated:\n%s", got)
+	}
+}
+
+func TestPushCalDAVReportsServerErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the outbox lives there
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusInsufficientStorage)
+	}))
+	defer srv.Close()
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	err := pushCalDAV(context.Background(), io.Discard, calDAVConfig{URL: srv.URL}, []Entry{{ID: "e1", Start: start, End: &end}}, false)
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Fatalf("want server error surfaced, got %v", err)
+	}
+}
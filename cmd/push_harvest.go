@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pushHarvestRounded bool
+
+// pushHarvestCmd creates Harvest time entries for tracked entries.
+//
+// Config (~/.tt/config.yaml):
+//
+//	harvest:
+//	  account_id: "123456"
+//	  token: "..."            # or HARVEST_ACCESS_TOKEN in the environment
+//	  mapping:
+//	    - customer: Acme      # required
+//	      project: Portal     # optional; narrows the match
+//	      activity: meeting   # optional; narrows the match
+//	      project_id: 111
+//	      task_id: 222
+//
+// Each pushed entry carries external_reference.id = entry ID, and entries whose
+// ID is already referenced in Harvest are skipped, so re-running a push over
+// the same range does not create duplicates.
+var pushHarvestCmd = &cobra.Command{
+	Use:   "harvest",
+	Short: "Create Harvest time entries via the Harvest v2 API",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadHarvestConfig()
+		if err != nil {
+			return err
+		}
+		if !pushDryRun && (cfg.AccountID == "" || cfg.Token == "") {
+			return fmt.Errorf("harvest.account_id and harvest.token (or HARVEST_ACCESS_TOKEN) must be configured")
+		}
+		ents, from, to, err := pushEntries(cmd)
+		if err != nil {
+			return err
+		}
+		return pushHarvest(cmd.Context(), cmd.OutOrStdout(), cfg, ents, from, to, pushDryRun)
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushHarvestCmd)
+	pushHarvestCmd.Flags().BoolVar(&pushHarvestRounded, "rounded", false, "send hours rounded with the configured rounding rules")
+}
+
+const harvestDefaultBaseURL = "https://api.harvestapp.com/v2"
+
+// harvestExternalGroup tags every external_reference written by tt.
+const harvestExternalGroup = "tt"
+
+type harvestMapping struct {
+	Customer  string `mapstructure:"customer"`
+	Project   string `mapstructure:"project"`
+	Activity  string `mapstructure:"activity"`
+	ProjectID int64  `mapstructure:"project_id"`
+	TaskID    int64  `mapstructure:"task_id"`
+}
+
+type harvestConfig struct {
+	BaseURL   string
+	AccountID string
+	Token     string
+	Mapping   []harvestMapping
+}
+
+func loadHarvestConfig() (harvestConfig, error) {
+	cfg := harvestConfig{
+		BaseURL:   viper.GetString("harvest.base_url"),
+		AccountID: viper.GetString("harvest.account_id"),
+		Token:     configSecret("harvest.token"),
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = harvestDefaultBaseURL
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("HARVEST_ACCESS_TOKEN")
+	}
+	if err := viper.UnmarshalKey("harvest.mapping", &cfg.Mapping); err != nil {
+		return cfg, fmt.Errorf("harvest.mapping: %w", err)
+	}
+	for i, m := range cfg.Mapping {
+		if m.Customer == "" || m.ProjectID == 0 || m.TaskID == 0 {
+			return cfg, fmt.Errorf("harvest.mapping[%d]: customer, project_id and task_id are required", i)
+		}
+	}
+	return cfg, nil
+}
+
+// mappingFor returns the most specific mapping matching e.
+func (c harvestConfig) mappingFor(e Entry) (harvestMapping, bool) {
+	i := bestMapping(len(c.Mapping), func(i int) (string, string, string) {
+		m := c.Mapping[i]
+		return m.Customer, m.Project, m.Activity
+	}, e)
+	if i < 0 {
+		return harvestMapping{}, false
+	}
+	return c.Mapping[i], true
+}
+
+type harvestExternalRef struct {
+	ID      string `json:"id"`
+	GroupID string `json:"group_id,omitempty"`
+}
+
+type harvestTimeEntry struct {
+	ProjectID         int64               `json:"project_id"`
+	TaskID            int64               `json:"task_id"`
+	SpentDate         string              `json:"spent_date"`
+	Hours             float64             `json:"hours"`
+	Notes             string              `json:"notes,omitempty"`
+	ExternalReference *harvestExternalRef `json:"external_reference,omitempty"`
+}
+
+// harvestWorklog pairs a tt entry with the Harvest time entry built for it.
+type harvestWorklog struct {
+	Entry Entry
+	Time  harvestTimeEntry
+}
+
+type harvestPlan struct {
+	Create   []harvestWorklog
+	Existing []Entry // already referenced in Harvest
+	Unmapped []Entry // no mapping for customer/project/activity
+}
+
+// planHarvest decides what to create. existing holds the entry IDs already
+// referenced by Harvest time entries (nil when not checked).
+func planHarvest(cfg harvestConfig, ents []Entry, existing map[string]bool, rounded bool) harvestPlan {
+	var plan harvestPlan
+	r := getRounding()
+	loc := parserLocation()
+	for _, e := range ents {
+		if existing[e.ID] {
+			plan.Existing = append(plan.Existing, e)
+			continue
+		}
+		m, ok := cfg.mappingFor(e)
+		if !ok {
+			plan.Unmapped = append(plan.Unmapped, e)
+			continue
+		}
+		sec := entrySeconds(e)
+		if rounded {
+			sec = customerPolicy(r.Policy(), e.Customer).Round(sec)
+		}
+		plan.Create = append(plan.Create, harvestWorklog{
+			Entry: e,
+			Time: harvestTimeEntry{
+				ProjectID:         m.ProjectID,
+				TaskID:            m.TaskID,
+				SpentDate:         e.Start.In(customerLocation(e.Customer, loc)).Format("2006-01-02"),
+				Hours:             hoursOf(sec),
+				Notes:             exportDescription(e),
+				ExternalReference: &harvestExternalRef{ID: e.ID, GroupID: harvestExternalGroup},
+			},
+		})
+	}
+	return plan
+}
+
+// pushHarvest checks which entries already exist in Harvest, then creates the
+// rest. In dry-run mode nothing is created; existing entries are still looked
+// up when credentials are available so the listing matches a real run.
+// Entries that fail retryably, or all of them while Harvest is unreachable,
+// are queued in the outbox (see outbox.go).
+func pushHarvest(ctx context.Context, out io.Writer, cfg harvestConfig, ents []Entry, from, to time.Time, dryRun bool) error {
+	var existing map[string]bool
+	var offline error
+	client := newHarvestClient(cfg)
+	if cfg.AccountID != "" && cfg.Token != "" {
+		var err error
+		if existing, err = client.externalRefs(ctx, from, to); err != nil {
+			if dryRun || !retryablePushError(err) {
+				return err
+			}
+			offline = err
+			fmt.Fprintf(out, "harvest unreachable (%v); queuing the entries for a retry\n", err)
+		}
+	} else {
+		fmt.Fprintln(out, "harvest credentials not configured; not checking for already pushed entries")
+	}
+
+	plan := planHarvest(cfg, ents, existing, pushHarvestRounded)
+	var missing []string
+	for _, w := range plan.Create {
+		if w.Entry.Billable && strings.TrimSpace(w.Time.Notes) == "" {
+			missing = append(missing, fmt.Sprintf("%s %s %s/%s", w.Entry.ID, w.Time.SpentDate, w.Entry.Customer, w.Entry.Project))
+		}
+	}
+	if err := checkMissingNotes(out, "harvest", missing); err != nil && !dryRun {
+		return err
+	} else if err != nil {
+		fmt.Fprintf(out, "would fail: %v\n", err)
+	}
+	for _, e := range plan.Unmapped {
+		fmt.Fprintf(out, "unmapped  %s  %s/%s/%s  (no harvest.mapping entry)\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.Activity)
+	}
+	for _, e := range plan.Existing {
+		fmt.Fprintf(out, "exists    %s  %s/%s  id=%s\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.ID)
+	}
+
+	created, queued := 0, 0
+	for _, w := range plan.Create {
+		verb := "create"
+		if dryRun {
+			verb = "would create"
+		} else {
+			err := offline
+			if err == nil {
+				err = client.createTimeEntry(ctx, w.Time)
+			}
+			if err != nil && !retryablePushError(err) {
+				return fmt.Errorf("harvest: entry %s: %w", w.Entry.ID, err)
+			}
+			if err != nil {
+				label := fmt.Sprintf("%s %.2fh %s (%s)", w.Time.SpentDate, w.Time.Hours, entryLabel(w.Entry), w.Entry.ID)
+				if qerr := queuePush("harvest", w.Entry.ID, label, w.Time, err); qerr != nil {
+					return qerr
+				}
+				queued++
+				fmt.Fprintf(out, "queued  %s  %s/%s  id=%s  (%v)\n", w.Time.SpentDate, w.Entry.Customer, w.Entry.Project, w.Entry.ID, err)
+				continue
+			}
+			unqueuePush("harvest", w.Entry.ID)
+		}
+		created++
+		fmt.Fprintf(out, "%s  %s  %.2fh  project=%d task=%d  %s/%s  %s\n", verb, w.Time.SpentDate, w.Time.Hours,
+			w.Time.ProjectID, w.Time.TaskID, w.Entry.Customer, w.Entry.Project, w.Time.Notes)
+	}
+
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	fmt.Fprintf(out, "%s %d, %salready pushed %d, unmapped %d.\n", verb, created, queuedNote(queued), len(plan.Existing), len(plan.Unmapped))
+	return nil
+}
+
+// harvestClient is a minimal Harvest v2 API client.
+type harvestClient struct {
+	baseURL   string
+	accountID string
+	token     string
+	http      *http.Client
+}
+
+func newHarvestClient(cfg harvestConfig) *harvestClient {
+	return &harvestClient{
+		baseURL:   strings.TrimRight(cfg.BaseURL, "/"),
+		accountID: cfg.AccountID,
+		token:     cfg.Token,
+		http:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *harvestClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Harvest-Account-Id", c.accountID)
+	req.Header.Set("User-Agent", "tt time tracker")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return newHTTPStatusError(method+" "+path, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// externalRefs returns the external_reference IDs written by tt on Harvest
+// time entries spent between from and to (inclusive, by date).
+func (c *harvestClient) externalRefs(ctx context.Context, from, to time.Time) (map[string]bool, error) {
+	refs := map[string]bool{}
+	q := url.Values{}
+	q.Set("from", from.Format("2006-01-02"))
+	q.Set("to", to.Format("2006-01-02"))
+	for page := 1; page > 0; {
+		q.Set("page", strconv.Itoa(page))
+		var resp struct {
+			TimeEntries []struct {
+				ExternalReference *harvestExternalRef `json:"external_reference"`
+			} `json:"time_entries"`
+			NextPage *int `json:"next_page"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/time_entries?"+q.Encode(), nil, &resp); err != nil {
+			return nil, fmt.Errorf("harvest: list time entries: %w", err)
+		}
+		for _, te := range resp.TimeEntries {
+			if ref := te.ExternalReference; ref != nil && ref.GroupID == harvestExternalGroup {
+				refs[ref.ID] = true
+			}
+		}
+		page = 0
+		if resp.NextPage != nil {
+			page = *resp.NextPage
+		}
+	}
+	return refs, nil
+}
+
+func (c *harvestClient) createTimeEntry(ctx context.Context, te harvestTimeEntry) error {
+	return c.do(ctx, http.MethodPost, "/time_entries", te, nil)
+}
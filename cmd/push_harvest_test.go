@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestHarvestMappingFor(t *testing.T) {
+	cfg := harvestConfig{Mapping: []harvestMapping{
+		{Customer: "Acme", ProjectID: 1, TaskID: 10},
+		{Customer: "acme", Project: "Portal", ProjectID: 2, TaskID: 20},
+		{Customer: "Acme", Project: "Portal", Activity: "meeting", ProjectID: 2, TaskID: 21},
+	}}
+	cases := []struct {
+		name     string
+		e        Entry
+		wantTask int64
+		wantOK   bool
+	}{
+		{"customer only", Entry{Customer: "ACME", Project: "Other"}, 10, true},
+		{"project beats customer", Entry{Customer: "Acme", Project: "portal", Activity: "dev"}, 20, true},
+		{"activity narrows", Entry{Customer: "Acme", Project: "Portal", Activity: "Meeting"}, 21, true},
+		{"unmapped", Entry{Customer: "Globex"}, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, ok := cfg.mappingFor(tc.e)
+			if ok != tc.wantOK || m.TaskID != tc.wantTask {
+				t.Fatalf("mappingFor(%+v) = task %d ok=%v, want task %d ok=%v", tc.e, m.TaskID, ok, tc.wantTask, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestPushHarvestSkipsExistingReferences(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the outbox lives there
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	var created []harvestTimeEntry
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" || r.Header.Get("Harvest-Account-Id") != "42" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/time_entries":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"time_entries":[{"external_reference":{"id":"e1","group_id":"tt"}}],"next_page":2}`))
+				return
+			}
+			w.Write([]byte(`{"time_entries":[{"external_reference":{"id":"e2","group_id":"other"}}],"next_page":null}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/time_entries":
+			var te harvestTimeEntry
+			if err := json.NewDecoder(r.Body).Decode(&te); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			created = append(created, te)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := harvestConfig{BaseURL: srv.URL, AccountID: "42", Token: "tok", Mapping: []harvestMapping{
+		{Customer: "Acme", ProjectID: 1, TaskID: 10},
+	}}
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	mk := func(id, cust string, start time.Time, d time.Duration) Entry {
+		end := start.Add(d)
+		return Entry{ID: id, Customer: cust, Start: start, End: &end, Notes: []string{"work"}}
+	}
+	ents := []Entry{
+		mk("e1", "Acme", day, time.Hour),                       // already pushed
+		mk("e2", "Acme", day.Add(2*time.Hour), 90*time.Minute), // other group: still created
+		mk("e3", "Globex", day.Add(4*time.Hour), time.Hour),    // unmapped
+	}
+
+	var dry bytes.Buffer
+	if err := pushHarvest(context.Background(), &dry, cfg, ents, day, day, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("dry run created %d entries", len(created))
+	}
+	if !strings.Contains(dry.String(), "would create  2025-10-06  1.50h  project=1 task=10") ||
+		!strings.Contains(dry.String(), "Would create 1, already pushed 1, unmapped 1.") {
+		t.Fatalf("unexpected dry-run output:\n%s", dry.String())
+	}
+
+	var out bytes.Buffer
+	if err := pushHarvest(context.Background(), &out, cfg, ents, day, day, false); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("want 1 created entry, got %d", len(created))
+	}
+	got := created[0]
+	if got.ExternalReference == nil || got.ExternalReference.ID != "e2" || got.ExternalReference.GroupID != "tt" ||
+		got.Hours != 1.5 || got.SpentDate != "2025-10-06" || got.ProjectID != 1 || got.TaskID != 10 || got.Notes != "work" {
+		t.Fatalf("unexpected time entry: %+v", got)
+	}
+}
+
+func TestPlanHarvestCustomerTimezone(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("customers.acme.timezone", "Asia/Tokyo")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("customers.acme.timezone", "")
+	})
+	start := time.Date(2025, 10, 6, 20, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	cfg := harvestConfig{Mapping: []harvestMapping{{Customer: "Acme", ProjectID: 1, TaskID: 10}, {Customer: "Globex", ProjectID: 2, TaskID: 20}}}
+	plan := planHarvest(cfg, []Entry{
+		{ID: "a", Start: start, End: &end, Customer: "Acme"},
+		{ID: "g", Start: start, End: &end, Customer: "Globex"},
+	}, nil, false)
+	if len(plan.Create) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	// 20:00 UTC is already the next morning in Tokyo.
+	if got := plan.Create[0].Time.SpentDate; got != "2025-10-07" {
+		t.Fatalf("Acme spent_date = %s; want 2025-10-07", got)
+	}
+	if got := plan.Create[1].Time.SpentDate; got != "2025-10-06" {
+		t.Fatalf("Globex spent_date = %s; want 2025-10-06", got)
+	}
+}
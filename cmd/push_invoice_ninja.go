@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+var pushInvoiceCustomer string
+
+// pushInvoiceNinjaCmd creates a draft invoice in Invoice Ninja (v5 API) for
+// one customer's billable time, one line item per project and day, priced
+// with the configured rates (see rates.go). Quantities use the rounding
+// config, matching `tt report`. The customer's billable expenses (tt expense
+// add) follow as one line item each.
+//
+// Config (~/.tt/config.yaml):
+//
+//	invoice_ninja:
+//	  url: https://invoicing.co         # or your self-hosted instance
+//	  token: "..."                      # or INVOICE_NINJA_TOKEN in the environment
+//	  clients:
+//	    acme: "VolejRejNm"              # Invoice Ninja client ID per customer
+var pushInvoiceNinjaCmd = &cobra.Command{
+	Use:   "invoice-ninja",
+	Short: "Create a draft Invoice Ninja invoice for a customer's billable time",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pushInvoiceCustomer == "" {
+			return fmt.Errorf("--customer is required")
+		}
+		cfg := loadInvoiceNinjaConfig()
+		clientID := cfg.Clients[strings.ToLower(pushInvoiceCustomer)]
+		if !pushDryRun {
+			if cfg.URL == "" || cfg.Token == "" {
+				return fmt.Errorf("invoice_ninja.url and invoice_ninja.token (or INVOICE_NINJA_TOKEN) must be configured")
+			}
+			if clientID == "" {
+				return fmt.Errorf("no Invoice Ninja client configured for %q (invoice_ninja.clients)", pushInvoiceCustomer)
+			}
+		}
+		ents, from, to, err := pushEntries(cmd)
+		if err != nil {
+			return err
+		}
+		items, err := invoiceLineItems(ents, pushInvoiceCustomer, getRounding())
+		if err != nil {
+			return err
+		}
+		exps, err := loadExpenses(from, to)
+		if err != nil {
+			return err
+		}
+		expItems, err := invoiceExpenseItems(exps, pushInvoiceCustomer)
+		if err != nil {
+			return err
+		}
+		items = append(items, expItems...)
+		return pushInvoiceNinja(cmd.Context(), cmd.OutOrStdout(), cfg, clientID, items, pushDryRun)
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushInvoiceNinjaCmd)
+	pushInvoiceNinjaCmd.Flags().StringVar(&pushInvoiceCustomer, "customer", "", "customer to invoice (required)")
+}
+
+type invoiceNinjaConfig struct {
+	URL     string
+	Token   string
+	Clients map[string]string // lower-case customer -> client ID
+}
+
+func loadInvoiceNinjaConfig() invoiceNinjaConfig {
+	cfg := invoiceNinjaConfig{
+		URL:     strings.TrimRight(viper.GetString("invoice_ninja.url"), "/"),
+		Token:   configSecret("invoice_ninja.token"),
+		Clients: map[string]string{},
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("INVOICE_NINJA_TOKEN")
+	}
+	for k, v := range viper.GetStringMapString("invoice_ninja.clients") {
+		cfg.Clients[strings.ToLower(k)] = v
+	}
+	return cfg
+}
+
+// invoiceLineItem is one project-day of billable time, or one expense
+// (Hours 1, Rate the amount).
+type invoiceLineItem struct {
+	Date    string
+	Project string
+	Notes   string // date, project and Desc, for systems with a single text
+	Desc    string // merged entry notes, or the expense description
+	Hours   float64
+	Rate    float64
+	Expense bool
+}
+
+func (li invoiceLineItem) Amount() float64 { return math.Round(li.Hours*li.Rate*100) / 100 }
+
+// invoiceLineItems groups a customer's finished billable entries by project
+// and day. Every project needs a rate; missing rates are reported together.
+func invoiceLineItems(ents []Entry, customer string, r Rounding) ([]invoiceLineItem, error) {
+	type key struct{ date, project string }
+	tallies := map[key]*rounding.Tally{}
+	policy := customerPolicy(r.Policy(), customer)
+	notes := map[key][]string{}
+	loc := parserLocation()
+	for _, e := range ents {
+		if !e.Billable || !strings.EqualFold(e.Customer, customer) {
+			continue
+		}
+		sec := entrySeconds(e)
+		if sec <= 0 {
+			continue
+		}
+		k := key{e.Start.In(customerLocation(e.Customer, loc)).Format("2006-01-02"), e.Project}
+		if tallies[k] == nil {
+			tallies[k] = &rounding.Tally{}
+		}
+		tallies[k].Add(policy, sec)
+		for _, n := range e.Notes {
+			notes[k] = append(notes[k], reporting.NormalizeNote(n))
+		}
+	}
+
+	var items []invoiceLineItem
+	var missing []string
+	for k, t := range tallies {
+		rounded := t.Rounded(policy)
+		rate, ok := rateFor(customer, k.project)
+		if !ok {
+			missing = append(missing, k.project)
+			continue
+		}
+		desc := k.date
+		if k.project != "" {
+			desc += " — " + k.project
+		}
+		merged := reporting.MergeNotes(reporting.DedupeStrings(notes[k]), 0)
+		if merged != "" {
+			desc += ": " + merged
+		}
+		items = append(items, invoiceLineItem{
+			Date:    k.date,
+			Project: k.project,
+			Notes:   desc,
+			Desc:    merged,
+			Hours:   hoursOf(rounded),
+			Rate:    rate,
+		})
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no rate configured for %s project(s) %s (set rates.default or rates.customers.<customer>)",
+			customer, strings.Join(reporting.DedupeStrings(missing), ", "))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Date != items[j].Date {
+			return items[i].Date < items[j].Date
+		}
+		return items[i].Project < items[j].Project
+	})
+	return items, nil
+}
+
+// invoiceExpenseItems returns a line item per billable expense of customer.
+// Expenses in another currency than the invoice (rates.currency) are
+// reported together.
+func invoiceExpenseItems(exps []journal.Expense, customer string) ([]invoiceLineItem, error) {
+	var items []invoiceLineItem
+	var foreign []string
+	loc := parserLocation()
+	for _, x := range exps {
+		if !x.Billable || !strings.EqualFold(x.Customer, customer) {
+			continue
+		}
+		if !strings.EqualFold(x.Currency, rateCurrency()) {
+			foreign = append(foreign, x.ID+" ("+journal.FormatAmount(x.Amount, x.Currency)+")")
+			continue
+		}
+		date := x.Date.In(customerLocation(x.Customer, loc)).Format("2006-01-02")
+		desc := date + " — expense"
+		if x.Project != "" {
+			desc += " " + x.Project
+		}
+		if x.Description != "" {
+			desc += ": " + x.Description
+		}
+		items = append(items, invoiceLineItem{Date: date, Project: x.Project, Notes: desc, Desc: x.Description, Hours: 1, Rate: x.Amount, Expense: true})
+	}
+	if len(foreign) > 0 {
+		return nil, fmt.Errorf("expenses not in %s cannot go on the invoice: %s", rateCurrency(), strings.Join(foreign, ", "))
+	}
+	return items, nil
+}
+
+func pushInvoiceNinja(ctx context.Context, out io.Writer, cfg invoiceNinjaConfig, clientID string, items []invoiceLineItem, dryRun bool) error {
+	if len(items) == 0 {
+		fmt.Fprintln(out, "No billable time or expenses for this customer in the selected range.")
+		return nil
+	}
+	total := 0.0
+	for _, li := range items {
+		if li.Expense {
+			fmt.Fprintf(out, "%7s   %8s   %9.2f  %s\n", "", "", li.Amount(), li.Notes)
+		} else {
+			fmt.Fprintf(out, "%6.2fh × %8.2f = %9.2f  %s\n", li.Hours, li.Rate, li.Amount(), li.Notes)
+		}
+		total += li.Amount()
+	}
+	fmt.Fprintf(out, "Total: %.2f %s (%d line items)\n", total, rateCurrency(), len(items))
+	if dryRun {
+		fmt.Fprintln(out, "Dry run: no invoice created.")
+		return nil
+	}
+
+	type lineItem struct {
+		ProductKey string  `json:"product_key"`
+		Notes      string  `json:"notes"`
+		Cost       float64 `json:"cost"`
+		Quantity   float64 `json:"quantity"`
+	}
+	body := struct {
+		ClientID  string     `json:"client_id"`
+		Date      string     `json:"date"`
+		LineItems []lineItem `json:"line_items"`
+	}{ClientID: clientID, Date: Now().Format("2006-01-02")}
+	for _, li := range items {
+		key := li.Project
+		switch {
+		case li.Expense:
+			key = "expense"
+		case key == "":
+			key = "time"
+		}
+		body.LineItems = append(body.LineItems, lineItem{ProductKey: key, Notes: li.Notes, Cost: li.Rate, Quantity: li.Hours})
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL+"/api/v1/invoices", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-TOKEN", cfg.Token)
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("invoice-ninja: create invoice: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var created struct {
+		Data struct {
+			ID     string `json:"id"`
+			Number string `json:"number"`
+		} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&created)
+	fmt.Fprintf(out, "Created draft invoice %s (id %s).\n", created.Data.Number, created.Data.ID)
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRateFor(t *testing.T) {
+	viper.Set("rates.default", 90)
+	viper.Set("rates.customers.acme.rate", 120)
+	viper.Set("rates.customers.acme.projects.portal", 140)
+	t.Cleanup(func() { viper.Set("rates", nil) })
+
+	cases := []struct {
+		customer, project string
+		want              float64
+	}{
+		{"ACME", "Portal", 140},
+		{"Acme", "Other", 120},
+		{"Globex", "Portal", 90},
+	}
+	for _, tc := range cases {
+		if got, ok := rateFor(tc.customer, tc.project); !ok || got != tc.want {
+			t.Errorf("rateFor(%q,%q) = %v,%v want %v", tc.customer, tc.project, got, ok, tc.want)
+		}
+	}
+}
+
+func TestPushInvoiceNinja(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("rates.customers.acme.rate", 100)
+	viper.Set("rates.customers.acme.projects.portal", 150)
+	viper.Set("rounding.quantum_min", 15)
+	viper.Set("rounding.strategy", "up")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("rates", nil)
+		viper.Set("rounding.quantum_min", nil)
+		viper.Set("rounding.strategy", nil)
+	})
+
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	mk := func(cust, proj string, start time.Time, min int, billable bool, note string) Entry {
+		end := start.Add(time.Duration(min) * time.Minute)
+		return Entry{Customer: cust, Project: proj, Start: start, End: &end, Billable: billable, Notes: []string{note}}
+	}
+	ents := []Entry{
+		mk("Acme", "Portal", day, 50, true, "login"), // rounds to 60
+		mk("Acme", "Portal", day.Add(2*time.Hour), 30, true, "review"),
+		mk("Acme", "", day.Add(4*time.Hour), 60, true, "call"),
+		mk("Acme", "Portal", day.AddDate(0, 0, 1), 60, true, "deploy"),
+		mk("Acme", "Portal", day.Add(6*time.Hour), 60, false, "internal"), // not billable
+		mk("Globex", "Site", day, 60, true, "other customer"),
+	}
+	items, err := invoiceLineItems(ents, "acme", getRounding())
+	if err != nil {
+		t.Fatalf("line items: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("want 3 line items, got %+v", items)
+	}
+	if it := items[1]; it.Project != "Portal" || it.Hours != 1.5 || it.Rate != 150 || it.Notes != "2025-10-06 — Portal: login • review" {
+		t.Fatalf("unexpected line item: %+v", it)
+	}
+	if it := items[0]; it.Project != "" || it.Rate != 100 {
+		t.Fatalf("customer-rate item: %+v", it)
+	}
+
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/invoices" || r.Header.Get("X-API-TOKEN") != "tok" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"data":{"id":"inv1","number":"0042"}}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	cfg := invoiceNinjaConfig{URL: srv.URL, Token: "tok"}
+	if err := pushInvoiceNinja(context.Background(), &out, cfg, "client1", items, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if got != nil || !strings.Contains(out.String(), "Total: 475.00 EUR (3 line items)") {
+		t.Fatalf("dry run: sent=%v output:\n%s", got, out.String())
+	}
+	if err := pushInvoiceNinja(context.Background(), &out, cfg, "client1", items, false); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	lines, _ := got["line_items"].([]interface{})
+	if got["client_id"] != "client1" || len(lines) != 3 || !strings.Contains(out.String(), "Created draft invoice 0042") {
+		t.Fatalf("unexpected request %v / output:\n%s", got, out.String())
+	}
+
+	viper.Set("rates", nil)
+	if _, err := invoiceLineItems(ents, "acme", getRounding()); err == nil || !strings.Contains(err.Error(), "Portal") {
+		t.Fatalf("want missing-rate error naming the project, got %v", err)
+	}
+}
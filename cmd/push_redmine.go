@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+// pushRedmineCmd creates Redmine time entries for tracked entries.
+//
+// Config (~/.tt/config.yaml):
+//
+//	redmine:
+//	  url: https://redmine.example.com
+//	  api_key: "..."             # or REDMINE_API_KEY in the environment
+//	  entry_id_field: 7          # custom field (time entry) storing the tt entry ID
+//	  default_activity_id: 9
+//	  activities:                # tt activity -> Redmine activity enumeration ID
+//	    dev: 9
+//	    meeting: 10
+//	  projects:
+//	    - customer: Acme         # required
+//	      project: Portal        # optional; narrows the match
+//	      project_id: 12
+//
+// The entry ID custom field makes pushes idempotent: entries whose ID already
+// appears on a Redmine time entry in the range are skipped.
+var pushRedmineCmd = &cobra.Command{
+	Use:   "redmine",
+	Short: "Create Redmine time entries via the REST API",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadRedmineConfig()
+		if err != nil {
+			return err
+		}
+		if !pushDryRun && (cfg.URL == "" || cfg.APIKey == "") {
+			return fmt.Errorf("redmine.url and redmine.api_key (or REDMINE_API_KEY) must be configured")
+		}
+		ents, from, to, err := pushEntries(cmd)
+		if err != nil {
+			return err
+		}
+		return pushRedmine(cmd.Context(), cmd.OutOrStdout(), cfg, ents, from, to, pushDryRun)
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushRedmineCmd)
+}
+
+// redmineCommentLimit keeps comments within the column size of older
+// Redmine versions (255 characters).
+const redmineCommentLimit = 255
+
+type redmineProject struct {
+	Customer  string `mapstructure:"customer"`
+	Project   string `mapstructure:"project"`
+	ProjectID int    `mapstructure:"project_id"`
+}
+
+type redmineConfig struct {
+	URL               string
+	APIKey            string
+	EntryIDField      int
+	DefaultActivityID int
+	Activities        map[string]int
+	Projects          []redmineProject
+}
+
+func loadRedmineConfig() (redmineConfig, error) {
+	cfg := redmineConfig{
+		URL:               strings.TrimRight(viper.GetString("redmine.url"), "/"),
+		APIKey:            configSecret("redmine.api_key"),
+		EntryIDField:      viper.GetInt("redmine.entry_id_field"),
+		DefaultActivityID: viper.GetInt("redmine.default_activity_id"),
+		Activities:        map[string]int{},
+	}
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("REDMINE_API_KEY")
+	}
+	if cfg.EntryIDField == 0 {
+		return cfg, fmt.Errorf("redmine.entry_id_field must name the custom field that stores tt entry IDs")
+	}
+	for name, v := range viper.GetStringMap("redmine.activities") {
+		id, err := strconv.Atoi(fmt.Sprint(v))
+		if err != nil {
+			return cfg, fmt.Errorf("redmine.activities.%s: want an activity ID, got %v", name, v)
+		}
+		cfg.Activities[strings.ToLower(name)] = id
+	}
+	if err := viper.UnmarshalKey("redmine.projects", &cfg.Projects); err != nil {
+		return cfg, fmt.Errorf("redmine.projects: %w", err)
+	}
+	for i, p := range cfg.Projects {
+		if p.Customer == "" || p.ProjectID == 0 {
+			return cfg, fmt.Errorf("redmine.projects[%d]: customer and project_id are required", i)
+		}
+	}
+	return cfg, nil
+}
+
+type redmineCustomField struct {
+	ID    int    `json:"id"`
+	Value string `json:"value"`
+}
+
+type redmineTimeEntry struct {
+	ProjectID    int                  `json:"project_id"`
+	SpentOn      string               `json:"spent_on"`
+	Hours        float64              `json:"hours"`
+	ActivityID   int                  `json:"activity_id,omitempty"`
+	Comments     string               `json:"comments,omitempty"`
+	CustomFields []redmineCustomField `json:"custom_fields,omitempty"`
+}
+
+type redmineWorklog struct {
+	Entry Entry
+	Time  redmineTimeEntry
+}
+
+type redminePlan struct {
+	Create   []redmineWorklog
+	Existing []Entry
+	Unmapped []Entry // no project or activity mapping
+}
+
+func planRedmine(cfg redmineConfig, ents []Entry, existing map[string]bool) redminePlan {
+	var plan redminePlan
+	loc := parserLocation()
+	for _, e := range ents {
+		if existing[e.ID] {
+			plan.Existing = append(plan.Existing, e)
+			continue
+		}
+		i := bestMapping(len(cfg.Projects), func(i int) (string, string, string) {
+			return cfg.Projects[i].Customer, cfg.Projects[i].Project, ""
+		}, e)
+		activity, ok := cfg.Activities[strings.ToLower(e.Activity)]
+		if !ok {
+			activity = cfg.DefaultActivityID
+		}
+		if i < 0 || activity == 0 {
+			plan.Unmapped = append(plan.Unmapped, e)
+			continue
+		}
+		plan.Create = append(plan.Create, redmineWorklog{
+			Entry: e,
+			Time: redmineTimeEntry{
+				ProjectID:    cfg.Projects[i].ProjectID,
+				SpentOn:      e.Start.In(customerLocation(e.Customer, loc)).Format("2006-01-02"),
+				Hours:        hoursOf(entrySeconds(e)),
+				ActivityID:   activity,
+				Comments:     redmineComments(e.Notes),
+				CustomFields: []redmineCustomField{{ID: cfg.EntryIDField, Value: e.ID}},
+			},
+		})
+	}
+	return plan
+}
+
+// redmineComments merges an entry's notes the same way reports do and
+// truncates them to the comment column size.
+func redmineComments(notes []string) string {
+	norm := make([]string, 0, len(notes))
+	for _, n := range notes {
+		norm = append(norm, reporting.NormalizeNote(n))
+	}
+	s := reporting.MergeNotes(reporting.DedupeStrings(norm), 0)
+	if r := []rune(s); len(r) > redmineCommentLimit {
+		s = string(r[:redmineCommentLimit-1]) + "…"
+	}
+	return s
+}
+
+func pushRedmine(ctx context.Context, out io.Writer, cfg redmineConfig, ents []Entry, from, to time.Time, dryRun bool) error {
+	client := &redmineClient{baseURL: cfg.URL, apiKey: cfg.APIKey, http: &http.Client{Timeout: 30 * time.Second}}
+	var existing map[string]bool
+	var offline error
+	if cfg.URL != "" && cfg.APIKey != "" {
+		var err error
+		if existing, err = client.entryIDs(ctx, cfg.EntryIDField, from, to); err != nil {
+			if dryRun || !retryablePushError(err) {
+				return err
+			}
+			offline = err
+			fmt.Fprintf(out, "redmine unreachable (%v); queuing the entries for a retry\n", err)
+		}
+	} else {
+		fmt.Fprintln(out, "redmine credentials not configured; not checking for already pushed entries")
+	}
+
+	plan := planRedmine(cfg, ents, existing)
+	for _, e := range plan.Unmapped {
+		fmt.Fprintf(out, "unmapped  %s  %s/%s/%s  (no redmine project or activity mapping)\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.Activity)
+	}
+	for _, e := range plan.Existing {
+		fmt.Fprintf(out, "exists    %s  %s/%s  id=%s\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.ID)
+	}
+	created, queued := 0, 0
+	for _, w := range plan.Create {
+		verb := "create"
+		if dryRun {
+			verb = "would create"
+		} else {
+			err := offline
+			if err == nil {
+				err = client.createTimeEntry(ctx, w.Time)
+			}
+			if err != nil && !retryablePushError(err) {
+				return fmt.Errorf("redmine: entry %s: %w", w.Entry.ID, err)
+			}
+			if err != nil {
+				label := fmt.Sprintf("%s %.2fh %s (%s)", w.Time.SpentOn, w.Time.Hours, entryLabel(w.Entry), w.Entry.ID)
+				if qerr := queuePush("redmine", w.Entry.ID, label, w.Time, err); qerr != nil {
+					return qerr
+				}
+				queued++
+				fmt.Fprintf(out, "queued  %s  %s/%s  id=%s  (%v)\n", w.Time.SpentOn, w.Entry.Customer, w.Entry.Project, w.Entry.ID, err)
+				continue
+			}
+			unqueuePush("redmine", w.Entry.ID)
+		}
+		created++
+		fmt.Fprintf(out, "%s  %s  %.2fh  project=%d activity=%d  %s/%s  %s\n", verb, w.Time.SpentOn, w.Time.Hours,
+			w.Time.ProjectID, w.Time.ActivityID, w.Entry.Customer, w.Entry.Project, w.Time.Comments)
+	}
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	fmt.Fprintf(out, "%s %d, %salready pushed %d, unmapped %d.\n", verb, created, queuedNote(queued), len(plan.Existing), len(plan.Unmapped))
+	return nil
+}
+
+// redmineClient is a minimal Redmine REST API client.
+type redmineClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func (c *redmineClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Redmine-API-Key", c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return newHTTPStatusError(method+" "+path, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// entryIDs returns the tt entry IDs stored in field on the current user's
+// Redmine time entries spent between from and to.
+func (c *redmineClient) entryIDs(ctx context.Context, field int, from, to time.Time) (map[string]bool, error) {
+	ids := map[string]bool{}
+	q := url.Values{}
+	q.Set("user_id", "me")
+	q.Set("from", from.Format("2006-01-02"))
+	q.Set("to", to.Format("2006-01-02"))
+	q.Set("limit", "100")
+	for offset := 0; ; {
+		q.Set("offset", strconv.Itoa(offset))
+		var resp struct {
+			TimeEntries []struct {
+				CustomFields []redmineCustomField `json:"custom_fields"`
+			} `json:"time_entries"`
+			TotalCount int `json:"total_count"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/time_entries.json?"+q.Encode(), nil, &resp); err != nil {
+			return nil, fmt.Errorf("redmine: list time entries: %w", err)
+		}
+		for _, te := range resp.TimeEntries {
+			for _, cf := range te.CustomFields {
+				if cf.ID == field && cf.Value != "" {
+					ids[cf.Value] = true
+				}
+			}
+		}
+		offset += len(resp.TimeEntries)
+		if len(resp.TimeEntries) == 0 || offset >= resp.TotalCount {
+			return ids, nil
+		}
+	}
+}
+
+func (c *redmineClient) createTimeEntry(ctx context.Context, te redmineTimeEntry) error {
+	return c.do(ctx, http.MethodPost, "/time_entries.json", map[string]redmineTimeEntry{"time_entry": te}, nil)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadRedmineConfig(t *testing.T) {
+	viper.Set("redmine.entry_id_field", 7)
+	viper.Set("redmine.activities", map[string]interface{}{"Dev": 9})
+	viper.Set("redmine.projects", []map[string]interface{}{{"customer": "Acme", "project_id": 12}})
+	t.Cleanup(func() {
+		viper.Set("redmine.entry_id_field", 0)
+		viper.Set("redmine.activities", nil)
+		viper.Set("redmine.projects", nil)
+	})
+
+	cfg, err := loadRedmineConfig()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.EntryIDField != 7 || cfg.Activities["dev"] != 9 || len(cfg.Projects) != 1 || cfg.Projects[0].ProjectID != 12 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	viper.Set("redmine.entry_id_field", 0)
+	if _, err := loadRedmineConfig(); err == nil {
+		t.Fatal("expected error without entry_id_field")
+	}
+}
+
+func TestPushRedmineDedupesByCustomField(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // the outbox lives there
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+
+	var created []redmineTimeEntry
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Redmine-API-Key") != "key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/time_entries.json":
+			if r.URL.Query().Get("offset") == "0" {
+				w.Write([]byte(`{"time_entries":[{"custom_fields":[{"id":7,"value":"e1"}]}],"total_count":2}`))
+				return
+			}
+			w.Write([]byte(`{"time_entries":[{"custom_fields":[{"id":8,"value":"e2"}]}],"total_count":2}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/time_entries.json":
+			var body struct {
+				TimeEntry redmineTimeEntry `json:"time_entry"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			created = append(created, body.TimeEntry)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := redmineConfig{
+		URL: srv.URL, APIKey: "key", EntryIDField: 7,
+		Activities: map[string]int{"meeting": 10},
+		Projects: []redmineProject{
+			{Customer: "Acme", ProjectID: 12},
+			{Customer: "Acme", Project: "Portal", ProjectID: 13},
+		},
+	}
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	mk := func(id, cust, proj, act string, notes ...string) Entry {
+		end := day.Add(45 * time.Minute)
+		return Entry{ID: id, Customer: cust, Project: proj, Activity: act, Start: day, End: &end, Notes: notes}
+	}
+	ents := []Entry{
+		mk("e1", "Acme", "Portal", "meeting"),                                 // already pushed
+		mk("e2", "Acme", "Portal", "meeting", "standup", "standup", "review"), // other field: created
+		mk("e3", "Acme", "Portal", "dev"),                                     // no activity mapping or default
+	}
+
+	var out bytes.Buffer
+	if err := pushRedmine(context.Background(), &out, cfg, ents, day, day, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(created) != 0 || !strings.Contains(out.String(), "Would create 1, already pushed 1, unmapped 1.") {
+		t.Fatalf("dry run: created=%v output:\n%s", created, out.String())
+	}
+
+	if err := pushRedmine(context.Background(), &out, cfg, ents, day, day, false); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("want 1 created entry, got %d", len(created))
+	}
+	got := created[0]
+	if got.ProjectID != 13 || got.ActivityID != 10 || got.Hours != 0.75 || got.SpentOn != "2025-10-06" ||
+		got.Comments != "standup • review" || len(got.CustomFields) != 1 || got.CustomFields[0] != (redmineCustomField{ID: 7, Value: "e2"}) {
+		t.Fatalf("unexpected time entry: %+v", got)
+	}
+}
+
+func TestRedmineCommentsTruncated(t *testing.T) {
+	c := redmineComments([]string{strings.Repeat("é", 300)})
+	if n := len([]rune(c)); n != redmineCommentLimit || !strings.HasSuffix(c, "…") {
+		t.Fatalf("comment length %d, want %d ending in ellipsis", n, redmineCommentLimit)
+	}
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+var pushTempoRounded bool
+
+// pushTempoCmd uploads tracked entries as Tempo Cloud worklogs.
+//
+// Config (~/.tt/config.yaml):
+//
+//	tempo:
+//	  token: "..."               # or TEMPO_API_TOKEN in the environment
+//	  account_id: "5b10a2844c20165700ede21g"  # Jira account ID of the author
+//	  mapping:
+//	    - customer: Acme         # required
+//	      project: Portal        # optional; narrows the match
+//	      activity: meeting      # optional; narrows the match
+//	      issue_key: PORT-12
+//	      issue_id: 10042        # numeric Jira issue ID, required by API v4
+//
+// Tempo has no field for a foreign reference, so each uploaded worklog is
+// recorded in the journal as an "exported" event (ref = entry ID, meta
+// target=tempo and the worklog ID). Entries with such an event are skipped,
+// so re-running a push over the same range does not create duplicates; undo
+// the event (tt undo) to push the entry again.
+var pushTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Upload worklogs to Tempo Cloud and record their IDs in the journal",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadTempoConfig()
+		if err != nil {
+			return err
+		}
+		if !pushDryRun && (cfg.Token == "" || cfg.AccountID == "") {
+			return fmt.Errorf("tempo.token (or TEMPO_API_TOKEN) and tempo.account_id must be configured")
+		}
+		ents, from, _, err := pushEntries(cmd)
+		if err != nil {
+			return err
+		}
+		return pushTempo(cmd.Context(), cmd.OutOrStdout(), cfg, ents, from, pushDryRun)
+	},
+}
+
+func init() {
+	pushCmd.AddCommand(pushTempoCmd)
+	pushTempoCmd.Flags().BoolVar(&pushTempoRounded, "rounded", false, "send durations rounded with the configured rounding rules")
+}
+
+const tempoDefaultBaseURL = "https://api.tempo.io/4"
+
+// tempoExportTarget is the meta target of the exported events of tt push
+// tempo.
+const tempoExportTarget = "tempo"
+
+type tempoMapping struct {
+	Customer string `mapstructure:"customer"`
+	Project  string `mapstructure:"project"`
+	Activity string `mapstructure:"activity"`
+	IssueKey string `mapstructure:"issue_key"`
+	IssueID  int64  `mapstructure:"issue_id"`
+}
+
+type tempoConfig struct {
+	BaseURL   string
+	Token     string
+	AccountID string
+	Mapping   []tempoMapping
+}
+
+func loadTempoConfig() (tempoConfig, error) {
+	cfg := tempoConfig{
+		BaseURL:   viper.GetString("tempo.base_url"),
+		Token:     configSecret("tempo.token"),
+		AccountID: viper.GetString("tempo.account_id"),
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = tempoDefaultBaseURL
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("TEMPO_API_TOKEN")
+	}
+	if err := viper.UnmarshalKey("tempo.mapping", &cfg.Mapping); err != nil {
+		return cfg, fmt.Errorf("tempo.mapping: %w", err)
+	}
+	for i, m := range cfg.Mapping {
+		if m.Customer == "" || (m.IssueKey == "" && m.IssueID == 0) {
+			return cfg, fmt.Errorf("tempo.mapping[%d]: customer and issue_key or issue_id are required", i)
+		}
+	}
+	return cfg, nil
+}
+
+// mappingFor returns the most specific mapping matching e.
+func (c tempoConfig) mappingFor(e Entry) (tempoMapping, bool) {
+	i := bestMapping(len(c.Mapping), func(i int) (string, string, string) {
+		m := c.Mapping[i]
+		return m.Customer, m.Project, m.Activity
+	}, e)
+	if i < 0 {
+		return tempoMapping{}, false
+	}
+	return c.Mapping[i], true
+}
+
+// tempoWorklog is the body of POST /worklogs. API v4 takes issueId; the
+// older v3 (base_url https://api.tempo.io/core/3) takes issueKey.
+type tempoWorklog struct {
+	IssueID          int64  `json:"issueId,omitempty"`
+	IssueKey         string `json:"issueKey,omitempty"`
+	TimeSpentSeconds int64  `json:"timeSpentSeconds"`
+	StartDate        string `json:"startDate"`
+	StartTime        string `json:"startTime"`
+	Description      string `json:"description"`
+	AuthorAccountID  string `json:"authorAccountId"`
+}
+
+// tempoUpload pairs a tt entry with the worklog built for it; it is also the
+// payload of a queued upload.
+type tempoUpload struct {
+	EntryID string       `json:"entry_id"`
+	Entry   Entry        `json:"-"`
+	Worklog tempoWorklog `json:"worklog"`
+}
+
+type tempoPlan struct {
+	Create   []tempoUpload
+	Existing []Entry // already recorded as exported to Tempo
+	Unmapped []Entry // no mapping for customer/project/activity
+}
+
+// planTempo decides what to upload. exported maps the entry IDs already
+// uploaded to their worklog IDs.
+func planTempo(cfg tempoConfig, ents []Entry, exported map[string]string, rounded bool) tempoPlan {
+	var plan tempoPlan
+	r := getRounding()
+	loc := parserLocation()
+	for _, e := range ents {
+		if _, ok := exported[e.ID]; ok {
+			plan.Existing = append(plan.Existing, e)
+			continue
+		}
+		m, ok := cfg.mappingFor(e)
+		if !ok {
+			plan.Unmapped = append(plan.Unmapped, e)
+			continue
+		}
+		sec := entrySeconds(e)
+		if rounded {
+			sec = customerPolicy(r.Policy(), e.Customer).Round(sec)
+		}
+		start := e.Start.In(customerLocation(e.Customer, loc))
+		plan.Create = append(plan.Create, tempoUpload{
+			EntryID: e.ID,
+			Entry:   e,
+			Worklog: tempoWorklog{
+				IssueID:          m.IssueID,
+				IssueKey:         m.IssueKey,
+				TimeSpentSeconds: sec,
+				StartDate:        start.Format("2006-01-02"),
+				StartTime:        start.Format("15:04:05"),
+				Description:      exportDescription(e),
+				AuthorAccountID:  cfg.AccountID,
+			},
+		})
+	}
+	return plan
+}
+
+// issue names the worklog's issue for output lines.
+func (w tempoWorklog) issue() string {
+	if w.IssueKey != "" {
+		return w.IssueKey
+	}
+	return strconv.FormatInt(w.IssueID, 10)
+}
+
+// pushTempo uploads the entries not yet exported to Tempo and records each
+// worklog ID as an exported event right after its upload. In dry-run mode
+// nothing is uploaded or written. Uploads that fail retryably are queued in
+// the outbox (see outbox.go), and once Tempo is unreachable the rest are
+// queued without trying; the event is written when a retry succeeds.
+func pushTempo(ctx context.Context, out io.Writer, cfg tempoConfig, ents []Entry, from time.Time, dryRun bool) error {
+	exported, err := tempoExported(from)
+	if err != nil {
+		return err
+	}
+	plan := planTempo(cfg, ents, exported, pushTempoRounded)
+	var missing []string
+	for _, u := range plan.Create {
+		if u.Entry.Billable && strings.TrimSpace(u.Worklog.Description) == "" {
+			missing = append(missing, fmt.Sprintf("%s %s %s/%s", u.EntryID, u.Worklog.StartDate, u.Entry.Customer, u.Entry.Project))
+		}
+	}
+	if err := checkMissingNotes(out, "tempo", missing); err != nil && !dryRun {
+		return err
+	} else if err != nil {
+		fmt.Fprintf(out, "would fail: %v\n", err)
+	}
+	for _, e := range plan.Unmapped {
+		fmt.Fprintf(out, "unmapped  %s  %s/%s/%s  (no tempo.mapping entry)\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.Activity)
+	}
+	for _, e := range plan.Existing {
+		fmt.Fprintf(out, "exists    %s  %s/%s  id=%s  worklog=%s\n", e.Start.Format("2006-01-02 15:04"), e.Customer, e.Project, e.ID, exported[e.ID])
+	}
+
+	client := newTempoClient(cfg)
+	var offline error
+	created, queued := 0, 0
+	for _, u := range plan.Create {
+		verb := "create"
+		worklogID := ""
+		if dryRun {
+			verb = "would create"
+		} else {
+			err := offline
+			if err == nil {
+				worklogID, err = client.createWorklog(ctx, u.Worklog)
+			}
+			if err != nil && !retryablePushError(err) {
+				return fmt.Errorf("tempo: entry %s: %w", u.EntryID, err)
+			}
+			if err != nil {
+				label := fmt.Sprintf("%s %s %s %s (%s)", u.Worklog.StartDate, fmtSecHHMM(u.Worklog.TimeSpentSeconds), u.Worklog.issue(), entryLabel(u.Entry), u.EntryID)
+				if qerr := queuePush("tempo", u.EntryID, label, u, err); qerr != nil {
+					return qerr
+				}
+				queued++
+				offline = err
+				fmt.Fprintf(out, "queued  %s  %s/%s  id=%s  (%v)\n", u.Worklog.StartDate, u.Entry.Customer, u.Entry.Project, u.EntryID, err)
+				continue
+			}
+			unqueuePush("tempo", u.EntryID)
+			if err := recordTempoExport(u, worklogID); err != nil {
+				return err
+			}
+		}
+		created++
+		fmt.Fprintf(out, "%s  %s %s  %s  issue=%s  %s/%s  %s", verb, u.Worklog.StartDate, u.Worklog.StartTime, fmtSecHHMM(u.Worklog.TimeSpentSeconds),
+			u.Worklog.issue(), u.Entry.Customer, u.Entry.Project, u.Worklog.Description)
+		if worklogID != "" {
+			fmt.Fprintf(out, "  worklog=%s", worklogID)
+		}
+		fmt.Fprintln(out)
+	}
+
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	fmt.Fprintf(out, "%s %d, %salready pushed %d, unmapped %d.\n", verb, created, queuedNote(queued), len(plan.Existing), len(plan.Unmapped))
+	return nil
+}
+
+// recordTempoExport appends the exported event of an uploaded worklog.
+func recordTempoExport(u tempoUpload, worklogID string) error {
+	ev := Event{ID: IDGen(), Type: "exported", TS: Now(), Ref: u.EntryID, Meta: map[string]string{
+		"target":     tempoExportTarget,
+		"worklog_id": worklogID,
+		"issue":      u.Worklog.issue(),
+		"seconds":    strconv.FormatInt(u.Worklog.TimeSpentSeconds, 10),
+	}}
+	if err := writeEvent(ev); err != nil {
+		return fmt.Errorf("tempo: worklog %s for entry %s was created but recording it failed: %w", worklogID, u.EntryID, err)
+	}
+	return nil
+}
+
+// tempoExported maps the entry IDs with an exported event for Tempo to their
+// worklog IDs, looking at the day files from since to today; undone events
+// do not count.
+func tempoExported(since time.Time) (map[string]string, error) {
+	var evs []Event
+	err := newEventTail(since).read(journalPaths(since, Now().In(parserLocation())), func(_ []byte, ev Event) {
+		if (ev.Type == "exported" && ev.Meta["target"] == tempoExportTarget) || ev.Type == journal.UndoType {
+			evs = append(evs, ev)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]string{}
+	for _, ev := range dropUndone(evs) {
+		out[ev.Ref] = ev.Meta["worklog_id"]
+	}
+	return out, nil
+}
+
+// sendQueuedTempo uploads a queued worklog unless the entry was exported
+// since, and records it.
+func sendQueuedTempo(ctx context.Context, payload json.RawMessage) error {
+	var u tempoUpload
+	if err := json.Unmarshal(payload, &u); err != nil {
+		return err
+	}
+	day, err := time.ParseInLocation("2006-01-02", u.Worklog.StartDate, parserLocation())
+	if err != nil {
+		return err
+	}
+	// the start date is in the customer's timezone, the day files in ours
+	exported, err := tempoExported(day.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+	if _, ok := exported[u.EntryID]; ok {
+		return nil
+	}
+	cfg, err := loadTempoConfig()
+	if err != nil {
+		return err
+	}
+	id, err := newTempoClient(cfg).createWorklog(ctx, u.Worklog)
+	if err != nil {
+		return err
+	}
+	return recordTempoExport(u, id)
+}
+
+// tempoClient is a minimal Tempo Cloud REST API client.
+type tempoClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newTempoClient(cfg tempoConfig) *tempoClient {
+	return &tempoClient{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		token:   cfg.Token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *tempoClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", "tt time tracker")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return newHTTPStatusError(method+" "+path, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// createWorklog posts w and returns the new worklog's ID.
+func (c *tempoClient) createWorklog(ctx context.Context, w tempoWorklog) (string, error) {
+	var resp struct {
+		TempoWorklogID json.Number `json:"tempoWorklogId"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/worklogs", w, &resp); err != nil {
+		return "", err
+	}
+	if resp.TempoWorklogID == "" {
+		return "", fmt.Errorf("tempo: POST /worklogs: no tempoWorklogId in the response")
+	}
+	return resp.TempoWorklogID.String(), nil
+}
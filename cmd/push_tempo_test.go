@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestPushTempoRecordsWorklogIDs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // journal and outbox live there
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	oldNow, oldID := Now, IDGen
+	Now = func() time.Time { return day.Add(10 * time.Hour) }
+	n := 0
+	IDGen = func() string { n++; return fmt.Sprintf("x%d", n) }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now, IDGen = oldNow, oldID
+	})
+
+	var created []tempoWorklog
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/worklogs" {
+			http.NotFound(w, r)
+			return
+		}
+		var wl tempoWorklog
+		if err := json.NewDecoder(r.Body).Decode(&wl); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created = append(created, wl)
+		fmt.Fprintf(w, `{"tempoWorklogId": %d}`, 700+len(created))
+	}))
+	defer srv.Close()
+
+	cfg := tempoConfig{BaseURL: srv.URL, Token: "tok", AccountID: "acc-1", Mapping: []tempoMapping{
+		{Customer: "Acme", IssueKey: "ACME-1", IssueID: 10001},
+		{Customer: "Acme", Project: "Portal", IssueKey: "PORT-12", IssueID: 10042},
+	}}
+	mk := func(id, cust, project string, start time.Time, d time.Duration) Entry {
+		end := start.Add(d)
+		return Entry{ID: id, Customer: cust, Project: project, Start: start, End: &end, Notes: []string{"work"}}
+	}
+	ents := []Entry{
+		mk("e1", "Acme", "Portal", day, time.Hour),
+		mk("e2", "Acme", "Other", day.Add(2*time.Hour), 90*time.Minute),
+		mk("e3", "Globex", "", day.Add(4*time.Hour), time.Hour), // unmapped
+	}
+
+	var dry bytes.Buffer
+	if err := pushTempo(context.Background(), &dry, cfg, ents, day, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(created) != 0 || !strings.Contains(dry.String(), "Would create 2, already pushed 0, unmapped 1.") {
+		t.Fatalf("dry run created %d worklogs:\n%s", len(created), dry.String())
+	}
+
+	var out bytes.Buffer
+	if err := pushTempo(context.Background(), &out, cfg, ents, day, false); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("want 2 worklogs, got %d", len(created))
+	}
+	want := tempoWorklog{IssueID: 10042, IssueKey: "PORT-12", TimeSpentSeconds: 3600, StartDate: "2025-10-06",
+		StartTime: "09:00:00", Description: "work", AuthorAccountID: "acc-1"}
+	if created[0] != want {
+		t.Fatalf("worklog = %+v, want %+v", created[0], want)
+	}
+	if !strings.Contains(out.String(), "create  2025-10-06 11:00:00  1h30m  issue=ACME-1") || !strings.Contains(out.String(), "worklog=702") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+
+	exported, err := tempoExported(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 2 || exported["e1"] != "701" || exported["e2"] != "702" {
+		t.Fatalf("exported = %v", exported)
+	}
+
+	// a second push over the same range uploads nothing
+	out.Reset()
+	if err := pushTempo(context.Background(), &out, cfg, ents, day, false); err != nil {
+		t.Fatalf("re-push: %v", err)
+	}
+	if len(created) != 2 || !strings.Contains(out.String(), "Created 0, already pushed 2, unmapped 1.") ||
+		!strings.Contains(out.String(), "id=e1  worklog=701") {
+		t.Fatalf("re-push created %d worklogs:\n%s", len(created), out.String())
+	}
+
+	// undoing the exported event makes the entry pushable again
+	if err := writeEvent(Event{ID: IDGen(), Type: "undo", TS: Now(), Ref: "x1"}); err != nil {
+		t.Fatal(err)
+	}
+	if exported, err = tempoExported(day); err != nil || len(exported) != 1 || exported["e2"] != "702" {
+		t.Fatalf("after undo exported = %v, %v", exported, err)
+	}
+}
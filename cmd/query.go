@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/query"
+)
+
+var (
+	queryEntries bool
+	queryRange   string
+	queryFormat  string
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [expression]",
+	Short: "Filter raw events or effective entries with an expression",
+	Long: `Query prints the raw journal events (or, with --entries, the effective
+entries after corrections) matching a filter expression, as JSON or CSV:
+
+  tt query 'type=amend and ts>2025-10-01 and customer~acme'
+  tt query --entries 'billable and not note and duration>=2h' --format csv
+  tt query --range lastmonth 'tags=urgent or meta.split_at'
+
+Conditions compare a field with a value: = != (case-insensitive; on tags:
+has the tag), ~ !~ (case-insensitive regular expression), < <= > >= (times
+and durations). A bare field tests that it is set. Combine conditions with
+and, or, not and parentheses; quote values with spaces. Dates stand for the
+whole day. Without --range the whole journal is searched.
+
+Event fields: ` + strings.Join(queryEventSchema.Names(), ", ") + `
+Entry fields: ` + strings.Join(queryEntrySchema.Names(), ", "),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if queryFormat != "json" && queryFormat != "csv" {
+			return fmt.Errorf("invalid --format %q: want json or csv", queryFormat)
+		}
+		schema := queryEventSchema
+		if queryEntries {
+			schema = queryEntrySchema
+		}
+		expr, err := query.Parse(strings.Join(args, " "), schema, parserLocation())
+		if err != nil {
+			return err
+		}
+		from, to, ok := queryRangeDays(queryRange)
+		if !ok {
+			return writeQueryResult(cmd.OutOrStdout(), nil, nil) // empty journal
+		}
+		if queryRange != "" {
+			noteUsageRange(cmd, from, to)
+		}
+		if queryEntries {
+			ents, err := queryMatchingEntries(expr, from, to, Now())
+			if err != nil {
+				return err
+			}
+			return writeQueryResult(cmd.OutOrStdout(), nil, ents)
+		}
+		evs, err := queryMatchingEvents(expr, from, to)
+		if err != nil {
+			return err
+		}
+		return writeQueryResult(cmd.OutOrStdout(), evs, nil)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.Flags().BoolVar(&queryEntries, "entries", false, "filter the effective entries instead of the raw events")
+	queryCmd.Flags().StringVar(&queryRange, "range", "", "only the days A..B or a period like lastweek (default: the whole journal)")
+	queryCmd.Flags().StringVar(&queryFormat, "format", "json", "output format: json or csv")
+}
+
+// queryEventSchema are the fields of raw events.
+var queryEventSchema = query.Schema{
+	"id": query.String, "type": query.String, "ts": query.Time, "user": query.String,
+	"customer": query.String, "project": query.String, "activity": query.String,
+	"billable": query.Bool, "note": query.String, "tags": query.List, "ref": query.String,
+	"meta.*": query.String, "hash": query.String, "prev_hash": query.String,
+}
+
+// queryEntrySchema are the fields of effective entries; note matches any of
+// the notes, field.<key> a key=value note field.
+var queryEntrySchema = query.Schema{
+	"id": query.String, "start": query.Time, "end": query.Time, "duration": query.Duration,
+	"running": query.Bool, "user": query.String, "customer": query.String, "project": query.String,
+	"activity": query.String, "billable": query.Bool, "note": query.List, "tags": query.List,
+	"field.*": query.String,
+}
+
+// eventRecord exposes ev to query expressions.
+func eventRecord(ev Event) query.Record {
+	return func(field string) any {
+		switch field {
+		case "id":
+			return ev.ID
+		case "type":
+			return ev.Type
+		case "ts":
+			return ev.TS
+		case "user":
+			return ev.User
+		case "customer":
+			return ev.Customer
+		case "project":
+			return ev.Project
+		case "activity":
+			return ev.Activity
+		case "billable":
+			if ev.Billable == nil {
+				return nil
+			}
+			return *ev.Billable
+		case "note":
+			return ev.Note
+		case "tags":
+			return ev.Tags
+		case "ref":
+			return ev.Ref
+		case "hash":
+			return ev.Hash
+		case "prev_hash":
+			return ev.PrevHash
+		}
+		if key, ok := strings.CutPrefix(field, "meta."); ok {
+			return ev.Meta[key]
+		}
+		return nil
+	}
+}
+
+// entryRecord exposes e to query expressions; a running entry lasts until
+// now.
+func entryRecord(e Entry, now time.Time) query.Record {
+	return func(field string) any {
+		switch field {
+		case "id":
+			return e.ID
+		case "start":
+			return e.Start
+		case "end":
+			if e.End == nil {
+				return nil
+			}
+			return *e.End
+		case "duration":
+			return time.Duration(queryEntrySeconds(e, now)) * time.Second
+		case "running":
+			return e.End == nil
+		case "user":
+			return e.User
+		case "customer":
+			return e.Customer
+		case "project":
+			return e.Project
+		case "activity":
+			return e.Activity
+		case "billable":
+			return e.Billable
+		case "note":
+			return nonEmptyNotes(e.Notes)
+		case "tags":
+			return e.Tags
+		}
+		if key, ok := strings.CutPrefix(field, "field."); ok {
+			return e.Fields[key]
+		}
+		return nil
+	}
+}
+
+func nonEmptyNotes(notes []string) []string {
+	var out []string
+	for _, n := range notes {
+		if strings.TrimSpace(n) != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func queryEntrySeconds(e Entry, now time.Time) int64 {
+	if e.End == nil {
+		return int64(entryElapsed(e, now) / time.Second)
+	}
+	return entrySeconds(e)
+}
+
+// queryRangeDays resolves --range, or else spans the whole journal; ok is
+// false for an empty journal.
+func queryRangeDays(rng string) (from, to time.Time, ok bool) {
+	if rng != "" {
+		from, to = parseRangeFlags(false, false, rng)
+		return from, to, true
+	}
+	return journalSpan()
+}
+
+// queryMatchingEvents returns the events of the day files from..to matching
+// expr, in file order. Lines that do not decode are skipped.
+func queryMatchingEvents(expr *query.Expr, from, to time.Time) ([]Event, error) {
+	var out []Event
+	err := newEventTail(time.Time{}).read(journalPaths(from, to), func(_ []byte, ev Event) {
+		if ev.ID != "" && expr.Match(eventRecord(ev)) {
+			out = append(out, ev)
+		}
+	})
+	return out, err
+}
+
+// queryMatchingEntries returns the entries of from..to matching expr, by
+// start.
+func queryMatchingEntries(expr *query.Expr, from, to, now time.Time) ([]Entry, error) {
+	ents, err := loadEntries(from, to)
+	if err != nil {
+		return nil, err
+	}
+	out := []Entry{}
+	for _, e := range ents {
+		if expr.Match(entryRecord(e, now)) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// queryEntryJSON is the JSON form of an entry in tt query output.
+type queryEntryJSON struct {
+	ID              string            `json:"id"`
+	Start           time.Time         `json:"start"`
+	End             *time.Time        `json:"end"`
+	Running         bool              `json:"running"`
+	User            string            `json:"user,omitempty"`
+	Customer        string            `json:"customer"`
+	Project         string            `json:"project"`
+	Activity        string            `json:"activity"`
+	Billable        bool              `json:"billable"`
+	Notes           []string          `json:"notes"`
+	Tags            []string          `json:"tags"`
+	Fields          map[string]string `json:"fields,omitempty"`
+	DurationSeconds int64             `json:"duration_seconds"`
+}
+
+// newQueryEntryJSON returns the JSON form of e; running entries count up to
+// now.
+func newQueryEntryJSON(e Entry, now time.Time) queryEntryJSON {
+	return queryEntryJSON{
+		ID: e.ID, Start: e.Start, End: e.End, Running: e.End == nil, User: e.User,
+		Customer: e.Customer, Project: e.Project, Activity: e.Activity, Billable: e.Billable,
+		Notes: nonNil(nonEmptyNotes(e.Notes)), Tags: nonNil(e.Tags), Fields: e.Fields,
+		DurationSeconds: queryEntrySeconds(e, now),
+	}
+}
+
+// writeQueryResult writes the events, or the entries, in --format.
+func writeQueryResult(w io.Writer, evs []Event, ents []Entry) error {
+	if queryFormat == "csv" {
+		return writeQueryCSV(w, evs, ents)
+	}
+	var v any = evs
+	if evs == nil {
+		v = []Event{}
+	}
+	if queryEntries {
+		out := make([]queryEntryJSON, 0, len(ents))
+		for _, e := range ents {
+			out = append(out, newQueryEntryJSON(e, Now()))
+		}
+		v = out
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeQueryCSV(w io.Writer, evs []Event, ents []Entry) error {
+	cw := csv.NewWriter(w)
+	loc := parserLocation()
+	if queryEntries {
+		cw.Write([]string{"id", "start", "end", "duration_seconds", "user", "customer", "project", "activity", "billable", "notes", "tags"})
+		for _, e := range ents {
+			end := ""
+			if e.End != nil {
+				end = e.End.In(loc).Format(time.RFC3339)
+			}
+			cw.Write([]string{
+				e.ID, e.Start.In(loc).Format(time.RFC3339), end, strconv.FormatInt(queryEntrySeconds(e, Now()), 10), e.User,
+				e.Customer, e.Project, e.Activity, strconv.FormatBool(e.Billable),
+				strings.Join(nonEmptyNotes(e.Notes), "; "), strings.Join(e.Tags, ", "),
+			})
+		}
+	} else {
+		cw.Write([]string{"id", "type", "ts", "user", "customer", "project", "activity", "billable", "note", "tags", "ref", "meta"})
+		for _, ev := range evs {
+			billable := ""
+			if ev.Billable != nil {
+				billable = strconv.FormatBool(*ev.Billable)
+			}
+			cw.Write([]string{
+				ev.ID, ev.Type, ev.TS.In(loc).Format(time.RFC3339), ev.User, ev.Customer, ev.Project, ev.Activity,
+				billable, ev.Note, strings.Join(ev.Tags, ", "), ev.Ref, fieldsString(ev.Meta),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/query"
+)
+
+func TestQueryEventsAndEntries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+
+	evs := []Event{
+		NewStartEvent("s1", "Acme", "Web", "", boolPtr(true), "", []string{"urgent"}, at(1, 9, 0)),
+		NewStopEvent("x1", at(1, 12, 0)),
+		NewStartEvent("s2", "Globex", "Ops", "", boolPtr(false), "patching", nil, at(2, 9, 0)),
+		NewStopEvent("x2", at(2, 9, 45)),
+		{ID: "a1", Type: "amend", TS: at(2, 10, 0), Ref: "s2", Customer: "Globex", Meta: map[string]string{"end": at(2, 10, 0).Format(time.RFC3339)}},
+		{ID: "a2", Type: "amend", TS: at(1, 18, 0), Ref: "s1", Note: "release"},
+	}
+	if err := writeEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+
+	from, to, ok := queryRangeDays("")
+	if !ok || !from.Equal(at(1, 0, 0)) || !to.Equal(at(2, 0, 0)) {
+		t.Fatalf("journal range = %v..%v (%v)", from, to, ok)
+	}
+	parse := func(schema query.Schema, src string) *query.Expr {
+		t.Helper()
+		e, err := query.Parse(src, schema, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+
+	got, err := queryMatchingEvents(parse(queryEventSchema, "type=amend and ts>2025-10-01 and customer~glob"), from, to)
+	if err != nil || len(got) != 1 || got[0].ID != "a1" {
+		t.Fatalf("amend events = %+v, %v", got, err)
+	}
+	got, _ = queryMatchingEvents(parse(queryEventSchema, "type=amend and meta.end"), from, to)
+	if len(got) != 1 || got[0].ID != "a1" {
+		t.Fatalf("amends with an end = %+v", got)
+	}
+
+	ents, err := queryMatchingEntries(parse(queryEntrySchema, "duration>=1h"), from, to, at(3, 0, 0))
+	if err != nil || len(ents) != 2 {
+		t.Fatalf("entries of 1h+ = %+v, %v", ents, err)
+	}
+	ents, _ = queryMatchingEntries(parse(queryEntrySchema, "billable and note~release and tags=URGENT"), from, to, at(3, 0, 0))
+	if len(ents) != 1 || ents[0].ID != "s1" {
+		t.Fatalf("billable release entries = %+v", ents)
+	}
+
+	queryEntries, queryFormat = true, "json"
+	t.Cleanup(func() { queryEntries, queryFormat = false, "json" })
+	var out bytes.Buffer
+	if err := writeQueryResult(&out, nil, ents); err != nil {
+		t.Fatal(err)
+	}
+	var doc []queryEntryJSON
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil || len(doc) != 1 || doc[0].DurationSeconds != 3*3600 || strings.Join(doc[0].Notes, ",") != "release" {
+		t.Fatalf("json = %s (%v)", out.String(), err)
+	}
+
+	queryEntries, queryFormat = false, "csv"
+	out.Reset()
+	if err := writeQueryResult(&out, got, nil); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if len(lines) != 3 || lines[0] != "id,type,ts,user,customer,project,activity,billable,note,tags,ref,meta" ||
+		!strings.HasPrefix(lines[1], "a1,amend,2025-10-02T10:00:00Z,") || !strings.HasSuffix(lines[1], ",Globex,,,,,,s2,end=2025-10-02T10:00:00Z") {
+		t.Fatalf("csv =\n%s", out.String())
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/billing"
+)
+
+// Hourly rates for billing integrations.
+//
+// Config (~/.tt/config.yaml):
+//
+//	rates:
+//	  currency: EUR
+//	  default: 90
+//	  customers:
+//	    acme:
+//	      rate: 120
+//	      projects:
+//	        portal: 140
+//
+// The most specific rate wins: customer project, then customer, then default
+// (see billing.Rates).
+
+// configRates reads the rates config. It walks the flattened keys rather
+// than unmarshalling "rates", so a profile overriding a single rate keeps
+// the others.
+func configRates() billing.Rates {
+	r := billing.Rates{Currency: viper.GetString("rates.currency"), Customers: map[string]billing.CustomerRates{}}
+	for _, k := range viper.AllKeys() {
+		parts := strings.Split(k, ".")
+		if parts[0] != "rates" || viper.Get(k) == nil {
+			continue
+		}
+		v := viper.GetFloat64(k)
+		switch {
+		case len(parts) == 2 && parts[1] == "default":
+			r.Default = &v
+		case len(parts) == 4 && parts[1] == "customers" && parts[3] == "rate":
+			c := r.Customers[parts[2]]
+			c.Rate = &v
+			r.Customers[parts[2]] = c
+		case len(parts) == 5 && parts[1] == "customers" && parts[3] == "projects":
+			c := r.Customers[parts[2]]
+			if c.Projects == nil {
+				c.Projects = map[string]float64{}
+			}
+			c.Projects[parts[4]] = v
+			r.Customers[parts[2]] = c
+		}
+	}
+	return r
+}
+
+// rateFor returns the hourly rate for customer/project and whether any rate
+// is configured for it.
+func rateFor(customer, project string) (float64, bool) {
+	return configRates().Rate(customer, project)
+}
+
+// rateCurrency returns the configured billing currency (default EUR).
+func rateCurrency() string {
+	return configRates().CurrencyOrDefault()
+}
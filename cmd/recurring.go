@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Recurring entries are config-defined blocks of time that happen on a
+// schedule, such as a daily standup:
+//
+//	recurring:
+//	  standup:
+//	    start: "09:30"
+//	    end: "09:45"
+//	    days: [mon, tue, wed, thu, fri]   # default; also "daily", "weekdays", "weekend"
+//	    customer: acme
+//	    project: internal
+//	    activity: meeting
+//	    billable: false
+//
+// The sweep that runs before every command (and therefore when the schedule
+// daemon starts) materializes each occurrence that has ended within the last
+// few days as an `add` event. The event carries meta["recurring"] =
+// "<name>@<date>", so an occurrence is written at most once even if the entry
+// is later amended or deleted.
+
+// recurringMetaKey marks add events written for a recurring rule.
+const recurringMetaKey = "recurring"
+
+// recurringScanDays is how far back missed occurrences are filled in.
+const recurringScanDays = 3
+
+type recurringRule struct {
+	Name        string   `mapstructure:"-"`
+	Start       string   `mapstructure:"start"`
+	End         string   `mapstructure:"end"`
+	Days        []string `mapstructure:"days"`
+	Customer    string   `mapstructure:"customer"`
+	Project     string   `mapstructure:"project"`
+	Activity    string   `mapstructure:"activity"`
+	Billable    *bool    `mapstructure:"billable"`
+	Note        string   `mapstructure:"note"`
+	Tags        []string `mapstructure:"tags"`
+	Paused      bool     `mapstructure:"paused"`
+	PausedUntil string   `mapstructure:"paused_until"` // YYYY-MM-DD, inclusive
+}
+
+// loadRecurringRules reads the recurring config sorted by name.
+func loadRecurringRules() ([]recurringRule, error) {
+	names := make([]string, 0)
+	for name := range viper.GetStringMap("recurring") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rules := make([]recurringRule, 0, len(names))
+	for _, name := range names {
+		var r recurringRule
+		if err := viper.UnmarshalKey("recurring."+name, &r); err != nil {
+			return nil, fmt.Errorf("recurring.%s: %w", name, err)
+		}
+		r.Name = name
+		if _, _, err := r.times(time.Now()); err != nil {
+			return nil, fmt.Errorf("recurring.%s: %w", name, err)
+		}
+		if _, err := r.weekdays(); err != nil {
+			return nil, fmt.Errorf("recurring.%s: %w", name, err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// times returns the occurrence of r on day.
+func (r recurringRule) times(day time.Time) (time.Time, time.Time, error) {
+	st, err := time.Parse("15:04", r.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("start: want HH:MM, got %q", r.Start)
+	}
+	en, err := time.Parse("15:04", r.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("end: want HH:MM, got %q", r.End)
+	}
+	if !en.After(st) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end %s must be after start %s", r.End, r.Start)
+	}
+	loc := day.Location()
+	return time.Date(day.Year(), day.Month(), day.Day(), st.Hour(), st.Minute(), 0, 0, loc),
+		time.Date(day.Year(), day.Month(), day.Day(), en.Hour(), en.Minute(), 0, 0, loc), nil
+}
+
+// weekdays resolves Days; an empty list means Monday to Friday.
+func (r recurringRule) weekdays() (map[time.Weekday]bool, error) {
+	days := r.Days
+	if len(days) == 0 {
+		days = []string{"weekdays"}
+	}
+	set := map[time.Weekday]bool{}
+	for _, d := range days {
+		switch strings.ToLower(strings.TrimSpace(d)) {
+		case "daily", "all":
+			for w := time.Sunday; w <= time.Saturday; w++ {
+				set[w] = true
+			}
+		case "weekdays":
+			for w := time.Monday; w <= time.Friday; w++ {
+				set[w] = true
+			}
+		case "weekend":
+			set[time.Saturday], set[time.Sunday] = true, true
+		default:
+			wd := weekdayFromString(d)
+			if wd == nil {
+				return nil, fmt.Errorf("unknown day %q", d)
+			}
+			set[*wd] = true
+		}
+	}
+	return set, nil
+}
+
+// pausedOn reports whether r is paused on day.
+func (r recurringRule) pausedOn(day time.Time) bool {
+	if !r.Paused {
+		return false
+	}
+	if r.PausedUntil == "" {
+		return true
+	}
+	return day.Format("2006-01-02") <= r.PausedUntil
+}
+
+func (r recurringRule) activeOn(day time.Time) bool {
+	days, err := r.weekdays()
+	return err == nil && days[day.Weekday()] && !r.pausedOn(day)
+}
+
+func recurringMarker(name string, day time.Time) string {
+	return name + "@" + day.Format("2006-01-02")
+}
+
+// sweepRecurring writes add events for recurring occurrences that ended
+// within the last recurringScanDays days and were not written before.
+func sweepRecurring() error {
+	rules, err := loadRecurringRules()
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	loc := parserLocation()
+	now := Now().In(loc)
+	var evs []Event
+	for i := recurringScanDays - 1; i >= 0; i-- {
+		d := now.AddDate(0, 0, -i)
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		seen := recurringMarkersIn(journalPathFor(day))
+		for _, r := range rules {
+			if !r.activeOn(day) {
+				continue
+			}
+			st, en, _ := r.times(day)
+			marker := recurringMarker(r.Name, day)
+			if en.After(now) || seen[marker] {
+				continue
+			}
+			ev := NewAddEvent(IDGen(), r.Customer, r.Project, r.Activity, boolPtr(fmtBillable(r.Billable)), r.Note, r.Tags, st, en)
+			ev.TS = st // file under the occurrence's day so the marker is found there
+			ev.Meta = map[string]string{recurringMetaKey: marker}
+			evs = append(evs, ev)
+		}
+	}
+	if len(evs) == 0 {
+		return nil
+	}
+	return writeEvents(evs)
+}
+
+// recurringMarkersIn returns the recurring markers present in a journal file.
+func recurringMarkersIn(path string) map[string]bool {
+	seen := map[string]bool{}
+	f, err := os.Open(path)
+	if err != nil {
+		return seen
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var ev Event
+		if json.Unmarshal(sc.Bytes(), &ev) != nil {
+			continue
+		}
+		if m := ev.Meta[recurringMetaKey]; m != "" {
+			seen[m] = true
+		}
+	}
+	return seen
+}
+
+func init() {
+	// run after the auto-stop sweep installed in common.go
+	prev := rootCmd.PersistentPreRun
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if prev != nil {
+			prev(cmd, args)
+		}
+		if err := sweepRecurring(); err != nil {
+			logger.Warn("sweep recurring entries failed", "err", err)
+		}
+	}
+}
+
+var recurringUntil string
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "List and pause recurring entries defined in the config",
+}
+
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring entries and their next occurrence",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := loadRecurringRules()
+		if err != nil {
+			return err
+		}
+		printRecurringRules(cmd.OutOrStdout(), rules, Now().In(parserLocation()))
+		return nil
+	},
+}
+
+var recurringPauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Pause a recurring entry (indefinitely or --until a date)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if err := requireRecurringRule(name); err != nil {
+			return err
+		}
+		until := ""
+		if recurringUntil != "" {
+			d, err := time.ParseInLocation("2006-01-02", recurringUntil, parserLocation())
+			if err != nil {
+				return fmt.Errorf("invalid --until %q: want YYYY-MM-DD", recurringUntil)
+			}
+			until = d.Format("2006-01-02")
+		}
+		viper.Set("recurring."+name+".paused", true)
+		viper.Set("recurring."+name+".paused_until", until)
+		if err := saveViperConfig(); err != nil {
+			return err
+		}
+		if until != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Paused %s until %s.\n", name, until)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Paused %s.\n", name)
+		}
+		return nil
+	},
+}
+
+var recurringResumeCmd = &cobra.Command{
+	Use:   "resume <name>",
+	Short: "Resume a paused recurring entry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if err := requireRecurringRule(name); err != nil {
+			return err
+		}
+		viper.Set("recurring."+name+".paused", false)
+		viper.Set("recurring."+name+".paused_until", "")
+		if err := saveViperConfig(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Resumed %s.\n", name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recurringCmd)
+	recurringCmd.AddCommand(recurringListCmd, recurringPauseCmd, recurringResumeCmd)
+	recurringPauseCmd.Flags().StringVar(&recurringUntil, "until", "", "pause through this date (YYYY-MM-DD, inclusive)")
+}
+
+func requireRecurringRule(name string) error {
+	if !viper.IsSet("recurring." + name) {
+		return fmt.Errorf("no recurring entry %q (see tt recurring list)", name)
+	}
+	return nil
+}
+
+// nextRecurring returns the next occurrence start of r after now, looking a
+// week ahead (longer pauses have none).
+func nextRecurring(r recurringRule, now time.Time) (time.Time, bool) {
+	for i := 0; i <= 7; i++ {
+		d := now.AddDate(0, 0, i)
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, now.Location())
+		if !r.activeOn(day) {
+			continue
+		}
+		if st, _, err := r.times(day); err == nil && st.After(now) {
+			return st, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func printRecurringRules(w io.Writer, rules []recurringRule, now time.Time) {
+	if len(rules) == 0 {
+		fmt.Fprintln(w, "No recurring entries (configure them under `recurring:` in the config).")
+		return
+	}
+	for _, r := range rules {
+		days := "weekdays"
+		if len(r.Days) > 0 {
+			days = strings.Join(r.Days, ",")
+		}
+		status := "active"
+		switch {
+		case r.Paused && r.PausedUntil != "":
+			status = "paused until " + r.PausedUntil
+		case r.Paused:
+			status = "paused"
+		}
+		next := "-"
+		if t, ok := nextRecurring(r, now); ok {
+			next = t.Format("Mon 2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%-16s %s-%s  %-20s %s / %s [%s]  %s  next: %s\n",
+			r.Name, r.Start, r.End, days, r.Customer, r.Project, r.Activity, status, next)
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestSweepRecurringMaterializesOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("recurring", map[string]interface{}{
+		"standup": map[string]interface{}{
+			"start": "09:30", "end": "09:45",
+			"customer": "acme", "project": "internal", "activity": "meeting", "billable": false,
+		},
+		"review": map[string]interface{}{
+			"start": "16:00", "end": "17:00", "days": []string{"tue"},
+			"customer": "acme", "project": "internal",
+		},
+	})
+	t.Cleanup(func() { viper.Set("timezone", ""); viper.Set("recurring", nil) })
+
+	// Tuesday 10:00: standup done Mon+Tue (Sun skipped), review not yet ended.
+	now := time.Date(2025, 10, 14, 10, 0, 0, 0, time.UTC)
+	oldNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() { Now = oldNow })
+
+	for i := 0; i < 2; i++ { // a second sweep must not duplicate
+		if err := sweepRecurring(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	from := time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC)
+	ents, err := loadEntries(from, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ents) != 2 {
+		t.Fatalf("want 2 standups, got %+v", ents)
+	}
+	for _, e := range ents {
+		if e.Start.Hour() != 9 || e.Start.Minute() != 30 || durationMinutes(e) != 15 || e.Billable {
+			t.Errorf("unexpected standup entry %+v", e)
+		}
+	}
+
+	// Paused through Wednesday: Wednesday's standup is skipped, Tuesday's review is written.
+	viper.Set("recurring.standup.paused", true)
+	viper.Set("recurring.standup.paused_until", "2025-10-15")
+	now = time.Date(2025, 10, 15, 18, 0, 0, 0, time.UTC)
+	if err := sweepRecurring(); err != nil {
+		t.Fatal(err)
+	}
+	ents, _ = loadEntries(from, now)
+	if len(ents) != 3 || ents[2].Start.Hour() != 16 || !ents[2].Billable {
+		t.Fatalf("want standups plus Tuesday review, got %+v", ents)
+	}
+
+	rules, err := loadRecurringRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printRecurringRules(&buf, rules, now)
+	out := buf.String()
+	if !strings.Contains(out, "paused until 2025-10-15") || !strings.Contains(out, "next: Thu 2025-10-16 09:30") {
+		t.Errorf("list output:\n%s", out)
+	}
+	if !strings.Contains(out, "next: Tue 2025-10-21 16:00") {
+		t.Errorf("review next occurrence missing:\n%s", out)
+	}
+}
+
+func TestRecurringRuleValidation(t *testing.T) {
+	bad := []recurringRule{
+		{Start: "9:30x", End: "10:00"},
+		{Start: "10:00", End: "09:00"},
+	}
+	for _, r := range bad {
+		if _, _, err := r.times(time.Now()); err == nil {
+			t.Errorf("times(%+v) = nil error", r)
+		}
+	}
+	if _, err := (recurringRule{Days: []string{"mon", "funday"}}).weekdays(); err == nil {
+		t.Error("unknown day accepted")
+	}
+	days, err := recurringRule{Days: []string{"weekend", "wed"}}.weekdays()
+	if err != nil || len(days) != 3 || !days[time.Sunday] || !days[time.Wednesday] {
+		t.Errorf("weekdays = %v, %v", days, err)
+	}
+}
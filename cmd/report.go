@@ -2,142 +2,368 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-)
+	"github.com/spf13/viper"
 
-var (
-	repToday    bool
-	repWeek     bool
-	repRange    string
-	repBy       string
-	repDetailed bool
+	"tt/internal/reporting"
+	"tt/internal/rounding"
 )
 
+// reportOptions is the state of one tt report run: its flags, or what a
+// caller such as a test sets. A run reads nothing else but the config, so
+// several can run at once. Unlike the other commands, whose flags are
+// package-level variables, tt report can therefore be called concurrently.
+type reportOptions struct {
+	Today, Week  bool
+	Range        string
+	By           string // comma-separated group fields
+	Detailed     bool
+	Issues       bool
+	Users        []string
+	Where        []string
+	ShowRounding bool
+	Open         openMode
+	Money        bool
+	Rate         float64 // with Money: price all billable time at this rate
+	Locale       string
+	Legal        bool // full customer names instead of display aliases
+}
+
+// reportFlags holds tt report's flags.
+var reportFlags = reportOptions{}
+
 type aggKey struct {
 	Customer, Project, Activity string
 	Billable                    bool
 }
 
-type aggVal struct{ RawMin, RoundedMin int }
+type aggVal struct {
+	RawMin, RoundedMin int   // whole minutes of RawSec and the rounded total
+	RawSec             int64 // tracked seconds at the configured precision
+	tally              rounding.Tally
+	policy             rounding.Policy // the customer's policy (customerPolicy); the base one for mixed groups
+	value              float64         // with --money: raw billable hours times their rates
+	priced             bool            // value has a priced entry
+	Amount             *float64        // with --money: value scaled to the rounded total
+}
 
 var reportCmd = &cobra.Command{
 	Use:   "report",
 	Short: "Summarize entries (billable-ready)",
 	Run: func(cmd *cobra.Command, args []string) {
-		from, to := parseRangeFlags(repToday, repWeek, repRange)
-		entries, err := loadEntries(from, to)
-		if err != nil {
-			// preserve previous behaviour of continuing on parse errors, but surface a message
-			fmt.Printf("Warning: failed to load some entries: %v\n", err)
+		o := reportFlags
+		from, to := parseRangeFlags(o.Today, o.Week, o.Range)
+		noteUsageRange(cmd, from, to)
+		cobra.CheckErr(o.run(cmd.OutOrStdout(), from, to))
+	},
+}
+
+// run reports the entries of the days from..to to w. The day files are
+// streamed into a reportAggregator, so a month or a year aggregates one day
+// file at a time.
+func (o reportOptions) run(w io.Writer, from, to time.Time) error {
+	where, err := parseWhere(o.Where)
+	if err != nil {
+		return err
+	}
+	r := getRounding()
+	a := newReportAggregator(o, where, r.Policy(), Now())
+	if o.Issues || viper.GetBool("issues.resolve") {
+		a.issues = newIssueResolver(false)
+	}
+	if err := streamEntries(from, to, a.add); err != nil {
+		// continue on parse errors, but surface them
+		logger.Warn("failed to load some entries", "err", err)
+	}
+	if a.issues != nil {
+		if err := a.issues.Save(); err != nil {
+			logger.Warn("failed to save issue title cache", "err", err)
+		}
+	}
+	open := a.open
+	if err := open.Err(); err != nil {
+		return err
+	}
+	if a.loaded == 0 {
+		fmt.Fprintln(w, "No entries.")
+		return nil
+	}
+	agg, considered, minimumApplied, unpriced, groupEntries := a.agg, a.considered, a.minimumApplied, a.unpriced, a.groupEntries
+
+	// Each group is rounded exactly once, per entry or as a total (rounding.level).
+	totalRounded := 0
+	var totalAmount float64
+	var groupsAtMinimum []string
+	for k, v := range agg {
+		v.RawMin = int(v.RawSec / 60)
+		v.RoundedMin = int(v.tally.Rounded(v.policy) / 60)
+		totalRounded += v.RoundedMin
+		if v.priced {
+			// rounding scales the value like the hours
+			amount := roundCents(v.value * float64(v.tally.Rounded(v.policy)) / float64(v.RawSec))
+			v.Amount = &amount
+			totalAmount += amount
 		}
-		if len(entries) == 0 {
-			fmt.Println("No entries.")
-			return
+		if v.policy.Aggregate() && v.policy.Bumped(v.RawSec) {
+			groupsAtMinimum = append(groupsAtMinimum, minimumLabel(o.aggKeyLabel(k), v.RawSec, v.policy))
 		}
+	}
+	sort.Strings(groupsAtMinimum)
+	minimumApplied = append(minimumApplied, groupsAtMinimum...)
+	totalRaw := int(a.totalRawSec / 60)
 
-		// Rounding config
-		r := getRounding()
+	// Header / summary (colorized)
+	// Labels use `ansiHeading`, numeric/emphasized values use `ansiHours` for clear hierarchy.
+	fmt.Fprintf(w, "%sReport Range:%s %s → %s   TZ: %s\n",
+		ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"), time.Now().Location())
+	fmt.Fprintf(w, "%sLoaded entries:%s %s%d%s   Considered (finished): %s%d%s   Rounding: strategy=%s quantum=%d minimum=%d level=%s precision=%s\n\n",
+		ansiHeading, ansiReset,
+		ansiHours, a.loaded, ansiReset,
+		ansiHours, considered, ansiReset,
+		r.Strategy, r.QuantumMin, r.MinimumEntry, r.Level, durationPrecision())
 
-		// Determine grouping fields
-		by := strings.Split(repBy, ",")
-		useBy := map[string]bool{}
-		for _, f := range by {
-			if f != "" {
-				useBy[strings.TrimSpace(f)] = true
-			}
+	if considered == 0 {
+		fmt.Fprintln(w, "No finished entries in the selected range.")
+		return nil
+	}
+
+	// Sort keys for deterministic output
+	keys := make([]aggKey, 0, len(agg))
+	for k := range agg {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		// customer, project, activity lexical
+		if keys[i].Customer != keys[j].Customer {
+			return keys[i].Customer < keys[j].Customer
+		}
+		if keys[i].Project != keys[j].Project {
+			return keys[i].Project < keys[j].Project
 		}
+		return keys[i].Activity < keys[j].Activity
+	})
 
-		// Aggregation structures
-		agg := map[aggKey]*aggVal{}
-		groupEntries := map[aggKey][]Entry{}
-		totalRaw, totalRounded := 0, 0
-		considered := 0
-
-		for _, e := range entries {
-			min := durationMinutes(e)
-			// skip running or zero-length entries for reporting
-			if min <= 0 {
-				continue
-			}
-			considered++
-			rmin := roundMinutes(min, r)
-			k := aggKey{}
-			if useBy["customer"] {
-				k.Customer = e.Customer
-			}
-			if useBy["project"] {
-				k.Project = e.Project
-			}
-			if useBy["activity"] {
-				k.Activity = e.Activity
-			}
-			if useBy["billable"] {
-				k.Billable = e.Billable
-			}
-			if _, ok := agg[k]; !ok {
-				agg[k] = &aggVal{}
-			}
-			agg[k].RawMin += min
-			agg[k].RoundedMin += rmin
-			totalRaw += min
-			totalRounded += rmin
+	// Print groups using helper for consistent week/day formatting
+	fmt.Fprint(w, o.formatGroups(agg, groupEntries))
+
+	// Overall total (emphasized)
+	// Use heading color for the label and hours color for the numeric totals.
+	fmt.Fprintf(w, "%sTOTAL:%s %s%s%s raw → %s%s%s rounded (+%dm)\n",
+		ansiHeading, ansiReset,
+		ansiHours, fmtHHMM(totalRaw), ansiReset,
+		ansiHours, fmtHHMM(totalRounded), ansiReset,
+		totalRounded-totalRaw)
+	if o.Money {
+		printMoneyTotal(w, totalAmount, unpriced, o.Locale)
+	}
+	printOpenNote(w, open)
+	printMinimumApplied(w, minimumApplied)
 
-			// store entry for detailed output
-			groupEntries[k] = append(groupEntries[k], e)
+	if o.ShowRounding {
+		rows := make([]roundingRow, 0, len(keys))
+		for _, k := range keys {
+			rows = append(rows, roundingRow{Label: o.aggKeyLabel(k), Raw: agg[k].RawSec, Rounded: agg[k].tally.Rounded(agg[k].policy)})
 		}
+		fmt.Fprintln(w)
+		printRoundingBreakdown(w, fmt.Sprintf("level=%s strategy=%s quantum=%dm minimum=%dm", r.Level, r.Strategy, r.QuantumMin, r.MinimumEntry), rows)
+	}
+	return nil
+}
+
+// reportAggregator folds the entries of a tt report run into its groups one
+// at a time. Besides the totals it keeps only the notes the output shows: per
+// entry with --detailed, otherwise each distinct note of a group once.
+type reportAggregator struct {
+	o      reportOptions
+	where  []whereClause
+	useBy  map[string]bool // group fields of --by
+	policy rounding.Policy
+	issues *issueResolver // with --issue-titles: annotates the notes
+
+	agg            map[aggKey]*aggVal
+	groupEntries   map[aggKey][]Entry        // start and notes of the entries per group
+	notesSeen      map[aggKey]map[string]int // index in groupEntries of each normalized note, without --detailed
+	open           openEntries
+	loaded         int // entries passing the filters and --include-open
+	considered     int // finished entries of them with a positive duration
+	totalRawSec    int64
+	minimumApplied []string
+	unpriced       map[string]bool
+}
+
+func newReportAggregator(o reportOptions, where []whereClause, policy rounding.Policy, now time.Time) *reportAggregator {
+	useBy := map[string]bool{}
+	for _, f := range strings.Split(o.By, ",") {
+		if f != "" {
+			useBy[strings.TrimSpace(f)] = true
+		}
+	}
+	return &reportAggregator{
+		o:            o,
+		where:        where,
+		useBy:        useBy,
+		policy:       policy,
+		agg:          map[aggKey]*aggVal{},
+		groupEntries: map[aggKey][]Entry{},
+		notesSeen:    map[aggKey]map[string]int{},
+		open:         openEntries{Mode: openMode(o.Open.String()), At: now},
+		unpriced:     map[string]bool{},
+	}
+}
 
-		// Header / summary (colorized)
-		// Labels use `ansiHeading`, numeric/emphasized values use `ansiHours` for clear hierarchy.
-		fmt.Printf("%sReport Range:%s %s → %s   TZ: %s\n",
-			ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"), time.Now().Location())
-		fmt.Printf("%sLoaded entries:%s %s%d%s   Considered (finished): %s%d%s   Rounding: strategy=%s quantum=%d minimum=%d\n\n",
-			ansiHeading, ansiReset,
-			ansiHours, len(entries), ansiReset,
-			ansiHours, considered, ansiReset,
-			r.Strategy, r.QuantumMin, r.MinimumEntry)
-
-		if considered == 0 {
-			fmt.Println("No finished entries in the selected range.")
-			return
+// add filters one entry and folds it into its group. It never fails; the
+// error return lets it be used directly as a streamEntries callback.
+func (a *reportAggregator) add(e Entry) error {
+	if len(filterUsers([]Entry{e}, a.o.Users)) == 0 || !matchesWhere(e, a.where) {
+		return nil
+	}
+	if e.End == nil {
+		a.open.IDs = append(a.open.IDs, e.ID)
+		if a.open.Mode != openNow || !a.open.At.After(e.Start) {
+			return nil
 		}
+		end := a.open.At
+		e.End = &end
+	}
+	a.loaded++
+	if a.issues != nil {
+		e = annotateIssueNotes(e, a.issues.Title)
+	}
 
-		// Sort keys for deterministic output
-		keys := make([]aggKey, 0, len(agg))
-		for k := range agg {
-			keys = append(keys, k)
+	sec := entrySeconds(e)
+	// skip zero-length entries for reporting
+	if sec <= 0 {
+		return nil
+	}
+	a.considered++
+	k := aggKey{}
+	if a.useBy["customer"] {
+		k.Customer = e.Customer
+	}
+	if a.useBy["project"] {
+		k.Project = CanonicalProject(e.Customer, e.Project)
+	}
+	if a.useBy["activity"] {
+		k.Activity = CanonicalActivity(e.Activity)
+	}
+	if a.useBy["billable"] {
+		k.Billable = e.Billable
+	}
+	p := customerPolicy(a.policy, e.Customer)
+	if _, ok := a.agg[k]; !ok {
+		a.agg[k] = &aggVal{policy: p}
+	} else if a.agg[k].policy != p {
+		a.agg[k].policy = a.policy
+	}
+	a.agg[k].RawSec += sec
+	if a.o.Money && e.Billable {
+		project := CanonicalProject(e.Customer, e.Project)
+		if rate, ok := moneyRate(e.Customer, project, a.o.Rate); ok {
+			a.agg[k].value += float64(sec) / 3600 * rate
+			a.agg[k].priced = true
+		} else {
+			a.unpriced[a.o.aggKeyLabel(aggKey{Customer: e.Customer, Project: project})] = true
 		}
-		sort.Slice(keys, func(i, j int) bool {
-			// customer, project, activity lexical
-			if keys[i].Customer != keys[j].Customer {
-				return keys[i].Customer < keys[j].Customer
-			}
-			if keys[i].Project != keys[j].Project {
-				return keys[i].Project < keys[j].Project
+	}
+	a.agg[k].tally.Add(p, sec)
+	if !p.Aggregate() && p.Bumped(sec) {
+		a.minimumApplied = append(a.minimumApplied, minimumLabel(
+			fmt.Sprintf("%s %s %s", e.ID, e.Start.In(parserLocation()).Format("2006-01-02"), entryLabel(e)), sec, p))
+	}
+	a.totalRawSec += sec
+	a.addNotes(k, e)
+	return nil
+}
+
+// addNotes keeps the notes of e for the output of group k. Without
+// --detailed each distinct note is kept once, dated by the earliest entry
+// it appears on, since the stream is not in start order across day files.
+func (a *reportAggregator) addNotes(k aggKey, e Entry) {
+	if a.o.Detailed {
+		if len(e.Notes) > 0 {
+			a.groupEntries[k] = append(a.groupEntries[k], Entry{ID: e.ID, Start: e.Start, Notes: e.Notes})
+		}
+		return
+	}
+	seen := a.notesSeen[k]
+	if seen == nil {
+		seen = map[string]int{}
+		a.notesSeen[k] = seen
+	}
+	for _, n := range e.Notes {
+		norm := reporting.NormalizeNote(n)
+		if norm == "" {
+			continue
+		}
+		if i, ok := seen[norm]; ok {
+			if e.Start.Before(a.groupEntries[k][i].Start) {
+				a.groupEntries[k][i].Start = e.Start
 			}
-			return keys[i].Activity < keys[j].Activity
-		})
-
-		// Print groups using helper for consistent week/day formatting
-		fmt.Print(formatGroups(agg, groupEntries, repDetailed))
-
-		// Overall total (emphasized)
-		// Use heading color for the label and hours color for the numeric totals.
-		fmt.Printf("%sTOTAL:%s %s%s%s raw → %s%s%s rounded (+%dm)\n",
-			ansiHeading, ansiReset,
-			ansiHours, fmtHHMM(totalRaw), ansiReset,
-			ansiHours, fmtHHMM(totalRounded), ansiReset,
-			totalRounded-totalRaw)
-	},
+			continue
+		}
+		seen[norm] = len(a.groupEntries[k])
+		a.groupEntries[k] = append(a.groupEntries[k], Entry{ID: e.ID, Start: e.Start, Notes: []string{n}})
+	}
+}
+
+// aggKeyLabel names a report group: "Customer / Project [activity]", with
+// the customer's display alias unless --legal-names is given.
+func (o reportOptions) aggKeyLabel(k aggKey) string {
+	label := o.reportCustomer(k.Customer)
+	if k.Project != "" {
+		label += " / " + k.Project
+	}
+	if k.Activity != "" {
+		label += " [" + k.Activity + "]"
+	}
+	if label == "" {
+		label = "(all)"
+	}
+	return label
+}
+
+// reportCustomer is customer as tt report shows it: its display alias
+// (customers.display), or the name itself with --legal-names.
+func (o reportOptions) reportCustomer(customer string) string {
+	if o.Legal {
+		return customer
+	}
+	return DisplayCustomer(customer)
+}
+
+// printMoneyTotal prints the total amount and the billable groups without a
+// rate.
+func printMoneyTotal(w io.Writer, total float64, unpriced map[string]bool, locale string) {
+	fmt.Fprintf(w, "%sAMOUNT:%s %s%s%s\n", ansiHeading, ansiReset, ansiHours, fmtMoney(roundCents(total), locale), ansiReset)
+	if len(unpriced) == 0 {
+		return
+	}
+	labels := make([]string, 0, len(unpriced))
+	for l := range unpriced {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	fmt.Fprintf(w, "No rate (not included): %s\n", strings.Join(labels, ", "))
 }
 
 func init() {
-	reportCmd.Flags().BoolVar(&repToday, "today", false, "today only")
-	reportCmd.Flags().BoolVar(&repWeek, "week", false, "this week (Mon..Sun)")
-	reportCmd.Flags().StringVar(&repRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
-	reportCmd.Flags().StringVar(&repBy, "by", "customer,project,activity", "group by fields (comma-separated)")
-	reportCmd.Flags().BoolVar(&repDetailed, "detailed", false, "detailed report including per-entry notes and times")
+	reportCmd.Flags().BoolVar(&reportFlags.Today, "today", false, "today only")
+	reportCmd.Flags().BoolVar(&reportFlags.Week, "week", false, "this week (Mon..Sun)")
+	reportCmd.Flags().StringVar(&reportFlags.Range, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	reportCmd.Flags().StringVar(&reportFlags.By, "by", "customer,project,activity", "group by fields (comma-separated)")
+	reportCmd.Flags().BoolVar(&reportFlags.Detailed, "detailed", false, "detailed report including per-entry notes and times")
+	reportCmd.Flags().BoolVar(&reportFlags.Issues, "issue-titles", false, "append GitHub/GitLab issue titles to #123 and issue URL references in notes (config: issues.resolve)")
+	reportCmd.Flags().StringSliceVar(&reportFlags.Users, "user", nil, userFlagHelp)
+	reportCmd.Flags().StringArrayVar(&reportFlags.Where, "where", nil, whereFlagHelp)
+	reportCmd.Flags().BoolVar(&reportFlags.ShowRounding, "show-rounding", false, "print raw vs rounded seconds and the delta per group and in total")
+	reportCmd.Flags().BoolVar(&reportFlags.Money, "money", false, "show the value of billable time per group and in total (config: rates)")
+	reportCmd.Flags().Float64Var(&reportFlags.Rate, "rate-override", 0, "with --money: price all billable time at this hourly rate instead of the configured rates")
+	reportCmd.Flags().StringVar(&reportFlags.Locale, "locale", "de", "Locale for amounts: de|en")
+	reportCmd.Flags().BoolVar(&reportFlags.Legal, "legal-names", false, "show full customer names instead of their display aliases (customers.display)")
+	addIncludeOpenFlag(reportCmd, &reportFlags.Open)
 }
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+var (
+	rcToday bool
+	rcWeek  bool
+	rcRange string
+	rcUsers []string
+	rcOpen  openMode
+)
+
+// reportCoverageCmd measures tracking habits: tracked time per day against
+// the working hours, entries tracked live against entries added afterwards,
+// and how long after the fact entries get corrected.
+//
+// Config (~/.tt/config.yaml):
+//
+//	working_hours:    # expected hours per weekday (default: 8 Mon–Fri)
+//	  fri: 6
+//	  sat: 0
+var reportCoverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Tracking hygiene: tracked vs. working hours, retroactive adds, correction latency",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := parseRangeFlags(rcToday, rcWeek, rcRange)
+		noteUsageRange(cmd, from, to)
+		entries, err := loadEntries(from, to)
+		if err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		entries, open, err := applyOpenMode(entries, rcOpen, Now())
+		if err != nil {
+			return err
+		}
+		cov := coverageStats{Expected: loadWorkingHours()}
+		cov.addEntries(filterUsers(entries, rcUsers), from, to)
+		p := journal.NewParser(viper.GetString("timezone"))
+		for _, path := range journalPaths(from, to) {
+			rec, err := p.RecordingFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				logger.Warn("unreadable journal file", "err", err, "path", path)
+				continue
+			}
+			cov.addRecording(rec, rcUsers)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%sCoverage:%s %s → %s\n\n", ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		cov.print(cmd.OutOrStdout())
+		printOpenNote(cmd.OutOrStdout(), open)
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportCoverageCmd)
+	reportCoverageCmd.Flags().BoolVar(&rcToday, "today", false, "today only")
+	reportCoverageCmd.Flags().BoolVar(&rcWeek, "week", false, "this week (Mon..Sun)")
+	reportCoverageCmd.Flags().StringVar(&rcRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	reportCoverageCmd.Flags().StringSliceVar(&rcUsers, "user", nil, userFlagHelp)
+	addIncludeOpenFlag(reportCoverageCmd, &rcOpen)
+}
+
+// loadWorkingHours returns the expected working time per weekday from
+// working_hours.<mon..sun>; unset days keep the default of 8h Monday to
+// Friday.
+func loadWorkingHours() map[time.Weekday]time.Duration {
+	out := map[time.Weekday]time.Duration{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d != time.Saturday && d != time.Sunday {
+			out[d] = 8 * time.Hour
+		}
+		key := "working_hours." + strings.ToLower(d.String()[:3])
+		if viper.IsSet(key) {
+			out[d] = time.Duration(viper.GetFloat64(key) * float64(time.Hour))
+		}
+	}
+	return out
+}
+
+// coverageDay is the tracked and expected time of one day.
+type coverageDay struct {
+	Day               time.Time
+	Tracked, Expected time.Duration
+}
+
+// coverageStats collects the figures of tt report coverage.
+type coverageStats struct {
+	Expected    map[time.Weekday]time.Duration
+	Days        []coverageDay
+	Live, Retro int
+	Corrections int
+	Delay       time.Duration // summed correction delays
+}
+
+// addEntries sets up the days from..to and books the finished entries on
+// the day they start.
+func (c *coverageStats) addEntries(ents []Entry, from, to time.Time) {
+	loc := parserLocation()
+	index := map[string]int{}
+	for d := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !d.After(to); d = d.AddDate(0, 0, 1) {
+		index[d.Format("2006-01-02")] = len(c.Days)
+		c.Days = append(c.Days, coverageDay{Day: d, Expected: c.Expected[d.Weekday()]})
+	}
+	for _, e := range ents {
+		if i, ok := index[e.Start.In(loc).Format("2006-01-02")]; ok {
+			c.Days[i].Tracked += time.Duration(entrySeconds(e)) * time.Second
+		}
+	}
+}
+
+// addRecording counts the live and retroactive entries and the corrections
+// of one day file, for users (all when empty).
+func (c *coverageStats) addRecording(rec journal.Recording, users []string) {
+	conv := func(jes []journal.Entry) []Entry {
+		out := make([]Entry, 0, len(jes))
+		for _, je := range jes {
+			out = append(out, entryFromJournal(je))
+		}
+		return filterUsers(out, users)
+	}
+	c.Live += len(conv(rec.Live))
+	c.Retro += len(conv(rec.Retro))
+	for _, k := range rec.Corrections {
+		if len(filterUsers([]Entry{{User: k.Event.User}}, users)) == 0 {
+			continue
+		}
+		c.Corrections++
+		c.Delay += k.Delay
+	}
+}
+
+// Score is the share of the expected time that was tracked; a day counts at
+// most its expected time. ok is false when no time was expected.
+func (c coverageStats) Score() (pct int, tracked, expected time.Duration, ok bool) {
+	for _, d := range c.Days {
+		expected += d.Expected
+		tracked += min(d.Tracked, d.Expected)
+	}
+	if expected <= 0 {
+		return 0, tracked, expected, false
+	}
+	return int(100 * tracked / expected), tracked, expected, true
+}
+
+func (c coverageStats) print(w io.Writer) {
+	for _, d := range c.Days {
+		pct := ""
+		if d.Expected > 0 {
+			pct = fmt.Sprintf("%4d%%", int(100*d.Tracked/d.Expected))
+		}
+		fmt.Fprintf(w, "  %s  %s%7s%s / %-6s %s\n", d.Day.Format("Mon 2006-01-02"), ansiHours, fmtDuration(d.Tracked), ansiReset, fmtDuration(d.Expected), pct)
+	}
+	fmt.Fprintln(w)
+	if pct, tracked, expected, ok := c.Score(); ok {
+		fmt.Fprintf(w, "%sCoverage score:%s %s%d%%%s (%s of %s working hours tracked)\n", ansiHeading, ansiReset, ansiHours, pct, ansiReset, fmtDuration(tracked), fmtDuration(expected))
+	} else {
+		fmt.Fprintf(w, "%sCoverage score:%s no working hours in range\n", ansiHeading, ansiReset)
+	}
+	share := ""
+	if n := c.Live + c.Retro; n > 0 {
+		share = fmt.Sprintf(" (%d%% retroactive)", 100*c.Retro/n)
+	}
+	fmt.Fprintf(w, "%sEntries:%s %d tracked live, %d added afterwards%s\n", ansiHeading, ansiReset, c.Live, c.Retro, share)
+	if c.Corrections == 0 {
+		fmt.Fprintf(w, "%sCorrections:%s none\n", ansiHeading, ansiReset)
+		return
+	}
+	avg := c.Delay / time.Duration(c.Corrections)
+	fmt.Fprintf(w, "%sCorrections:%s %d, on average %s after the entry ended\n", ansiHeading, ansiReset, c.Corrections, fmtDuration(avg.Round(time.Minute)))
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+)
+
+func TestCoverageStats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("working_hours.fri", 4)
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("working_hours", nil)
+	})
+
+	mon := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	at := func(day, h, m int) time.Time {
+		return mon.AddDate(0, 0, day).Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	evs := []Event{
+		NewStartEvent("e1", "acme", "portal", "", boolPtr(true), "", nil, at(0, 9, 0)),
+		NewStopEvent("x1", at(0, 15, 0)),
+		NewAddEvent("e2", "acme", "", "", boolPtr(true), "call", nil, at(4, 9, 0), at(4, 14, 0)),
+		{ID: "a1", Type: "amend", TS: at(0, 17, 0), Ref: "e1", Note: "login"},
+	}
+	evs[2].TS = at(4, 18, 0)
+	if err := writeEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+
+	from, to := mon, at(6, 23, 59)
+	ents, err := loadEntries(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cov := coverageStats{Expected: loadWorkingHours()}
+	cov.addEntries(ents, from, to)
+	p := journal.NewParser("UTC")
+	for _, path := range journalPaths(from, to) {
+		rec, err := p.RecordingFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		cov.addRecording(rec, nil)
+	}
+
+	// Mon 6h of 8h, Tue–Thu nothing of 8h, Fri 5h counted as the 4h expected
+	pct, tracked, expected, ok := cov.Score()
+	if !ok || expected != 36*time.Hour || tracked != 10*time.Hour || pct != 27 {
+		t.Fatalf("score = %d%% %v/%v %v", pct, tracked, expected, ok)
+	}
+	if cov.Live != 1 || cov.Retro != 1 || cov.Corrections != 1 || cov.Delay != 2*time.Hour {
+		t.Fatalf("stats = %+v", cov)
+	}
+	var out bytes.Buffer
+	cov.print(&out)
+	for _, want := range []string{"Fri 2025-10-10", "125%", "1 tracked live, 1 added afterwards (50% retroactive)", "1, on average 2h00m after the entry ended"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	cov = coverageStats{Expected: loadWorkingHours()}
+	cov.addRecording(journal.Recording{Live: []journal.Entry{{ID: "e9", User: "bob"}}}, []string{"ana"})
+	if cov.Live != 0 {
+		t.Fatalf("user filter ignored: %+v", cov)
+	}
+}
+
+func TestCoverageIncludeOpen(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC) // Monday
+	oldNow := Now
+	Now = func() time.Time { return day.Add(3 * time.Hour) }
+	f := reportCoverageCmd.Flags().Lookup("include-open")
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = oldNow
+		rcRange, rcOpen, f.Changed = "", "", false
+		reportCoverageCmd.SetOut(nil)
+	})
+	if err := writeEvent(Event{ID: "e1", Type: "start", TS: day, Customer: "Acme"}); err != nil {
+		t.Fatal(err)
+	}
+	rcRange = "2025-10-06T00:00..2025-10-06T23:59"
+	coverage := func(mode string) string {
+		t.Helper()
+		if err := reportCoverageCmd.Flags().Set("include-open", mode); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		reportCoverageCmd.SetOut(&buf)
+		if err := reportCoverageCmd.RunE(reportCoverageCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+		return stripANSI(buf.String())
+	}
+
+	if out := coverage("skip"); !strings.Contains(out, "0m / 8h00m") || !strings.Contains(out, "1 running entry not counted") {
+		t.Fatalf("skip:\n%s", out)
+	}
+	if out := coverage("now"); !strings.Contains(out, "3h00m / 8h00m") || !strings.Contains(out, "counted up to 12:00 (e1)") {
+		t.Fatalf("now:\n%s", out)
+	}
+	if err := reportCoverageCmd.Flags().Set("include-open", "error"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reportCoverageCmd.RunE(reportCoverageCmd, nil); err == nil || !strings.Contains(err.Error(), "e1") {
+		t.Fatalf("error mode: %v", err)
+	}
+}
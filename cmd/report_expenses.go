@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/journal"
+	"tt/internal/reporting"
+)
+
+var (
+	reToday    bool
+	reWeek     bool
+	reRange    string
+	reCustomer string
+	reUsers    []string
+	reLocale   string
+)
+
+// reportExpensesCmd lists the expenses of a range with totals per customer
+// and currency.
+var reportExpensesCmd = &cobra.Command{
+	Use:   "expenses",
+	Short: "List expenses (tt expense add) with totals per customer",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := parseRangeFlags(reToday, reWeek, reRange)
+		noteUsageRange(cmd, from, to)
+		exps, err := loadExpenses(from, to)
+		if err != nil {
+			logger.Warn("failed to load some expenses", "err", err)
+		}
+		exps = filterExpenses(exps, reCustomer, reUsers)
+		fmt.Fprintf(cmd.OutOrStdout(), "%sExpenses:%s %s → %s\n\n", ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		printExpenseReport(cmd.OutOrStdout(), exps, reLocale)
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportExpensesCmd)
+	reportExpensesCmd.Flags().BoolVar(&reToday, "today", false, "today only")
+	reportExpensesCmd.Flags().BoolVar(&reWeek, "week", false, "this week (Mon..Sun)")
+	reportExpensesCmd.Flags().StringVar(&reRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	reportExpensesCmd.Flags().StringVar(&reCustomer, "customer", "", "Filter by exact customer or display alias (case-insensitive)")
+	reportExpensesCmd.Flags().StringSliceVar(&reUsers, "user", nil, userFlagHelp)
+	reportExpensesCmd.Flags().StringVar(&reLocale, "locale", "de", "Locale for amounts: de|en")
+}
+
+// filterExpenses keeps the expenses of customer (any when empty) recorded by
+// users (any when empty).
+func filterExpenses(exps []journal.Expense, customer string, users []string) []journal.Expense {
+	out := make([]journal.Expense, 0, len(exps))
+	for _, x := range exps {
+		if customer != "" && !customerMatches(customer, x.Customer) {
+			continue
+		}
+		if len(users) > 0 && len(filterUsers([]Entry{{User: x.User}}, users)) == 0 {
+			continue
+		}
+		out = append(out, x)
+	}
+	return out
+}
+
+// printExpenseReport prints one line per expense, then the billable and
+// non-billable totals per customer and currency.
+func printExpenseReport(w io.Writer, exps []journal.Expense, locale string) {
+	if len(exps) == 0 {
+		fmt.Fprintln(w, "No expenses.")
+		return
+	}
+	loc := parserLocation()
+	type key struct {
+		customer, currency string
+		billable           bool
+	}
+	totals := map[key]float64{}
+	for _, x := range exps {
+		label := entryLabel(Entry{Customer: DisplayCustomer(x.Customer), Project: x.Project})
+		if label == "" {
+			label = "(no customer)"
+		}
+		flag := ""
+		if !x.Billable {
+			flag = "  (non-billable)"
+		}
+		fmt.Fprintf(w, "  %s  %s%-24s%s %s%12s%s  %s%s\n", x.Date.In(loc).Format("2006-01-02"),
+			ansiLabel, label, ansiReset, ansiHours, reporting.FormatMoney(x.Amount, x.Currency, locale), ansiReset, x.Description, flag)
+		totals[key{x.Customer, x.Currency, x.Billable}] += x.Amount
+	}
+	keys := make([]key, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].customer != keys[j].customer {
+			return keys[i].customer < keys[j].customer
+		}
+		if keys[i].billable != keys[j].billable {
+			return keys[i].billable
+		}
+		return keys[i].currency < keys[j].currency
+	})
+	fmt.Fprintf(w, "\n%sTotals:%s\n", ansiHeading, ansiReset)
+	for _, k := range keys {
+		name := DisplayCustomer(k.customer)
+		if name == "" {
+			name = "(no customer)"
+		}
+		kind := "billable"
+		if !k.billable {
+			kind = "non-billable"
+		}
+		fmt.Fprintf(w, "  %s%-24s%s %s%12s%s  %s\n", ansiLabel, name, ansiReset, ansiHours, reporting.FormatMoney(roundCents(totals[k]), k.currency, locale), ansiReset, kind)
+	}
+}
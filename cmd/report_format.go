@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"tt/internal/reporting"
 )
 
-// formatGroups renders aggregated groups in a compact, week-like table style and returns the string.
+// formatGroups renders aggregated groups (with o.Detailed, per entry) in a compact, week-like table style and returns the string.
 // It intentionally uses the subtle ANSI palette defined elsewhere in the package for consistent styling.
-func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detailed bool) string {
+func (o reportOptions) formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry) string {
 	var b strings.Builder
 
 	const labelW = 30
@@ -40,9 +42,9 @@ func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detai
 		v := agg[k]
 
 		// Build display name
-		name := k.Customer
+		name := o.reportCustomer(k.Customer)
 		if k.Project != "" {
-			name = fmt.Sprintf("%s / %s", k.Customer, k.Project)
+			name = fmt.Sprintf("%s / %s", name, k.Project)
 		}
 		if name == "" {
 			name = "(unknown)"
@@ -59,7 +61,7 @@ func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detai
 		b.WriteString(fmt.Sprintf("  %s%-*s%s %s%*.2fh%s\n", labelCol, labelW, name, reset, hoursCol, hoursW, hours, reset))
 
 		// Notes: either detailed per-entry or merged
-		if detailed {
+		if o.Detailed {
 			entries := groupEntries[k]
 			sort.Slice(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
 			for _, e := range entries {
@@ -67,7 +69,7 @@ func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detai
 					continue
 				}
 				for _, n := range e.Notes {
-					norm := normalizeNote(n)
+					norm := reporting.NormalizeNote(n)
 					if norm == "" {
 						continue
 					}
@@ -76,17 +78,18 @@ func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detai
 			}
 		} else {
 			entries := groupEntries[k]
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
 			notes := []string{}
 			for _, e := range entries {
 				for _, n := range e.Notes {
-					norm := normalizeNote(n)
+					norm := reporting.NormalizeNote(n)
 					if norm != "" {
 						notes = append(notes, norm)
 					}
 				}
 			}
-			notes = dedupeStrings(notes)
-			merged := mergeNotesForDisplay(notes, 80)
+			notes = reporting.DedupeStrings(notes)
+			merged := reporting.MergeNotes(notes, 80)
 			if merged != "" {
 				// put merged notes on next indented line
 				b.WriteString(fmt.Sprintf("    %s- %s%s\n", notesCol, merged, reset))
@@ -96,8 +99,12 @@ func formatGroups(agg map[aggKey]*aggVal, groupEntries map[aggKey][]Entry, detai
 		// Totals line for the group — align totals under the hours column.
 		// We print the label padded to the same `labelW` then emit the raw/rounded values
 		// starting at the same column where hours appear above.
-		b.WriteString(fmt.Sprintf("  %s%-*s%s %sRaw=%s Rounded=%s (+%dm)\n\n",
-			heading, labelW, "Group total:", reset, hoursCol, fmtHHMM(v.RawMin), fmtHHMM(v.RoundedMin), v.RoundedMin-v.RawMin))
+		amount := ""
+		if v.Amount != nil {
+			amount = " Amount=" + fmtMoney(*v.Amount, o.Locale)
+		}
+		b.WriteString(fmt.Sprintf("  %s%-*s%s %sRaw=%s Rounded=%s (+%dm)%s%s\n\n",
+			heading, labelW, "Group total:", reset, hoursCol, fmtHHMM(v.RawMin), fmtHHMM(v.RoundedMin), v.RoundedMin-v.RawMin, amount, reset))
 	}
 
 	return b.String()
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	riToday   bool
+	riWeek    bool
+	riRange   string
+	riOffline bool
+	riOpen    openMode
+)
+
+// reportIssuesCmd groups tracked time by the GitHub/GitLab issues referenced
+// in entry notes and tags, with issue titles so timesheets can name tickets.
+var reportIssuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Summarize time per referenced GitHub/GitLab issue",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := parseRangeFlags(riToday, riWeek, riRange)
+		noteUsageRange(cmd, from, to)
+		entries, err := loadEntries(from, to)
+		if err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		entries, open, err := applyOpenMode(entries, riOpen, Now())
+		cobra.CheckErr(err)
+		res := newIssueResolver(riOffline)
+		fmt.Printf("%sIssue report:%s %s → %s\n\n", ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		printIssueReport(cmd.OutOrStdout(), entries, res.Title)
+		printOpenNote(cmd.OutOrStdout(), open)
+		if err := res.Save(); err != nil {
+			logger.Warn("failed to save issue title cache", "err", err)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportIssuesCmd)
+	reportIssuesCmd.Flags().BoolVar(&riToday, "today", false, "today only")
+	reportIssuesCmd.Flags().BoolVar(&riWeek, "week", false, "this week (Mon..Sun)")
+	reportIssuesCmd.Flags().StringVar(&riRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	reportIssuesCmd.Flags().BoolVar(&riOffline, "offline", false, "only use cached issue titles")
+	addIncludeOpenFlag(reportIssuesCmd, &riOpen)
+}
+
+// annotateIssueTitles resolves issue titles for report notes and persists
+// newly fetched titles.
+func annotateIssueTitles(entries []Entry) []Entry {
+	res := newIssueResolver(false)
+	out := make([]Entry, len(entries))
+	for i, e := range entries {
+		out[i] = annotateIssueNotes(e, res.Title)
+	}
+	if err := res.Save(); err != nil {
+		logger.Warn("failed to save issue title cache", "err", err)
+	}
+	return out
+}
+
+type issueTotal struct {
+	Ref     issueRef
+	Seconds int64
+}
+
+// aggregateIssues sums finished entries per referenced issue. An entry that
+// references several issues is split evenly between them so totals still add
+// up to tracked time (in units of the configured precision); entries
+// without references count as unlinked.
+func aggregateIssues(entries []Entry) ([]issueTotal, int64) {
+	byKey := map[string]*issueTotal{}
+	var unlinked int64
+	unit := int64(1)
+	if durationPrecision() == precisionMinutes {
+		unit = 60
+	}
+	for _, e := range entries {
+		sec := entrySeconds(e)
+		if sec <= 0 {
+			continue
+		}
+		refs := entryIssueRefs(e)
+		if len(refs) == 0 {
+			unlinked += sec
+			continue
+		}
+		units, n := sec/unit, int64(len(refs))
+		share, rest := units/n, units%n
+		for i, ref := range refs {
+			t, ok := byKey[ref.Key()]
+			if !ok {
+				t = &issueTotal{Ref: ref}
+				byKey[ref.Key()] = t
+			}
+			t.Seconds += share * unit
+			if int64(i) < rest {
+				t.Seconds += unit
+			}
+		}
+	}
+	out := make([]issueTotal, 0, len(byKey))
+	for _, t := range byKey {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Seconds != out[j].Seconds {
+			return out[i].Seconds > out[j].Seconds
+		}
+		return out[i].Ref.Key() < out[j].Ref.Key()
+	})
+	return out, unlinked
+}
+
+func printIssueReport(w io.Writer, entries []Entry, title func(issueRef) string) {
+	issues, unlinked := aggregateIssues(entries)
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "No entries reference issues.")
+	}
+	var total int64
+	for _, it := range issues {
+		fmt.Fprintf(w, "%8s  %s  %s\n", fmtSecHHMM(it.Seconds), it.Ref.Key(), title(it.Ref))
+		total += it.Seconds
+	}
+	fmt.Fprintf(w, "\nTOTAL: %s across %d issue(s); unlinked: %s\n", fmtSecHHMM(total), len(issues), fmtSecHHMM(unlinked))
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+// Number and date format of the table, markdown and CSV week reports. A
+// preset picks the separators and date layout; the other keys override it.
+// Without any of them the reports keep 1234.50 and 2025-10-13. tt report
+// week --locale de|en picks the preset for one run.
+//
+//	locale:
+//	  preset: de              # de: 1.234,50 13.10.2025 | en: 1,234.50 | iso (default)
+//	  decimal: ","
+//	  thousands: "."          # "" for none
+//	  date_layout: 02.01.2006 # Go time layout
+
+// reportLocale is the configured number and date format; preset, when not
+// empty, replaces locale.preset.
+func reportLocale(preset string) (reporting.Locale, error) {
+	if preset == "" {
+		preset = viper.GetString("locale.preset")
+	}
+	l, err := reporting.LocaleFor(preset)
+	if err != nil {
+		return l, fmt.Errorf("locale: %w", err)
+	}
+	if viper.IsSet("locale.decimal") {
+		l.Decimal = viper.GetString("locale.decimal")
+	}
+	if viper.IsSet("locale.thousands") {
+		l.Thousands = viper.GetString("locale.thousands")
+	}
+	if viper.IsSet("locale.date_layout") {
+		l.DateLayout = viper.GetString("locale.date_layout")
+	}
+	return l, nil
+}
+
+// labelLocale is the de|en locale of weekday labels: preset when
+// it names one, else locale.preset when that does, else de.
+func labelLocale(preset string) string {
+	for _, name := range []string{preset, viper.GetString("locale.preset")} {
+		if name = strings.ToLower(name); name == "de" || name == "en" {
+			return name
+		}
+	}
+	return "de"
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+func TestReportLocaleConfig(t *testing.T) {
+	t.Cleanup(func() { viper.Set("locale", nil) })
+	if l, err := reportLocale(""); err != nil || l != (reporting.Locale{}) || labelLocale("") != "de" {
+		t.Fatalf("unconfigured = %+v, %v, labels %s", l, err, labelLocale(""))
+	}
+
+	viper.Set("locale", map[string]any{"preset": "en", "thousands": "", "date_layout": "02/01/2006"})
+	l, err := reportLocale("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Number(1234.5, 2) + " " + l.Date("2025-10-13"); got != "1234.50 13/10/2025" {
+		t.Errorf("en with overrides = %q", got)
+	}
+	if labelLocale("") != "en" || labelLocale("de") != "de" || labelLocale("iso") != "en" {
+		t.Errorf("labels follow neither --locale nor locale.preset")
+	}
+	// --locale replaces the preset; the overrides still apply
+	if l, _ = reportLocale("de"); l.Decimal != "," || l.Thousands != "" {
+		t.Errorf("--locale de = %+v", l)
+	}
+	if _, err := reportLocale("fr"); err == nil {
+		t.Error("unknown preset accepted")
+	}
+}
+
+func TestReportLocaleFormatsAmounts(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("rates", map[string]any{"currency": "EUR", "customers": map[string]any{"acme": map[string]any{"rate": 1000}}})
+	viper.Set("locale", map[string]any{"preset": "iso", "decimal": ",", "thousands": "'"})
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("rates", nil)
+		viper.Set("locale", nil)
+	})
+
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 13, h, m, 0, 0, time.UTC) }
+	end := at(10, 30)
+	policy := rounding.Policy{QuantumSec: 900, Level: rounding.LevelEntry, Strategy: "up"}
+	agg := newWeekAggregator(time.UTC, policy, at(20, 0))
+	if err := agg.add(Entry{ID: "e1", Start: at(9, 0), End: &end, Customer: "acme", Billable: true}); err != nil {
+		t.Fatal(err)
+	}
+	numbers, err := reportLocale("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := agg.Days(at(0, 0), at(23, 0), labelLocale(""), 80)
+	doc := newWeekReportDoc(at(0, 0), at(23, 0), "UTC", days, 5400, 5400, openEntries{}, policy, reporting.Issues{})
+	priceWeek(&doc, 0, labelLocale(""), numbers)
+
+	for _, format := range []string{"table", "markdown"} {
+		r, err := reporting.New(format, reporting.Options{Locale: numbers})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := r.Render(&buf, doc); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		// hours and amounts share the configured separators
+		if !strings.Contains(out, "1,50h") || !strings.Contains(out, "1'500,00 €") || strings.Contains(out, "1.500") {
+			t.Errorf("%s:\n%s", format, out)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+)
+
+// roundingRow is one group of a --show-rounding breakdown.
+type roundingRow struct {
+	Label   string
+	Raw     int64 // tracked seconds
+	Rounded int64 // seconds after rounding, as reported
+}
+
+// printRoundingBreakdown prints raw and rounded seconds and their delta per
+// group and the total gain (or loss) rounding adds, so the rounding policy
+// can be checked against the tracked time (`--show-rounding`).
+func printRoundingBreakdown(w io.Writer, policy string, rows []roundingRow) {
+	const labelW = 34
+	fmt.Fprintf(w, "%sRounding breakdown:%s %s\n", ansiHeading, ansiReset, policy)
+	fmt.Fprintf(w, "  %-*s %10s %10s %12s\n", labelW, "Group", "raw s", "rounded s", "delta")
+	var raw, rounded int64
+	for _, r := range rows {
+		label := r.Label
+		if len(label) > labelW {
+			label = label[:labelW-3] + "..."
+		}
+		fmt.Fprintf(w, "  %-*s %10d %10d %12s\n", labelW, label, r.Raw, r.Rounded, fmtSignedSeconds(r.Rounded-r.Raw))
+		raw += r.Raw
+		rounded += r.Rounded
+	}
+	verdict := "no change"
+	switch {
+	case rounded > raw:
+		verdict = "gain"
+	case rounded < raw:
+		verdict = "loss"
+	}
+	fmt.Fprintf(w, "  %s%-*s%s %10d %10d %12s (%s)\n", ansiHeading, labelW, "Total", ansiReset, raw, rounded, fmtSignedSeconds(rounded-raw), verdict)
+}
+
+// fmtSignedSeconds renders a signed duration like "+7m30s", "-1h02m00s" or
+// "±0s".
+func fmtSignedSeconds(sec int64) string {
+	if sec == 0 {
+		return "±0s"
+	}
+	sign := "+"
+	if sec < 0 {
+		sign = "-"
+		sec = -sec
+	}
+	h, m, s := sec/3600, sec%3600/60, sec%60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%s%dh%02dm%02ds", sign, h, m, s)
+	case m > 0:
+		return fmt.Sprintf("%s%dm%02ds", sign, m, s)
+	default:
+		return fmt.Sprintf("%s%ds", sign, s)
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+var reportSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of `tt report week --format json`",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(reporting.WeekSchema)
+		return err
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportSchemaCmd)
+}
+
+// newWeekReportDoc assembles the document the week renderers work on. Issue
+// lists are never null.
+func newWeekReportDoc(from, to time.Time, tz string, days []reporting.Day, weekTotal, rawTotal int64, open openEntries, policy rounding.Policy, issues reporting.Issues) reporting.Week {
+	issues.Normalize()
+	return reporting.Week{
+		SchemaVersion:      reporting.WeekSchemaVersion,
+		Week:               fmtWeekLabel(from, to),
+		Range:              reporting.Range{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")},
+		Timezone:           tz,
+		Days:               days,
+		WeekSeconds:        weekTotal,
+		WeekSecondsRounded: weekTotal,
+		WeekSecondsRaw:     rawTotal,
+		Provisional:        open.Provisional(),
+		Rounding: reporting.Rounding{
+			Level:          policy.Level,
+			Strategy:       policy.Strategy,
+			QuantumMinutes: policy.Quantum() / 60,
+			MinimumMinutes: policy.MinimumSec / 60,
+		},
+		Issues: issues,
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+// checkAgainstSchema walks doc alongside schema and reports emitted keys the
+// schema does not describe and required keys that are missing.
+func checkAgainstSchema(t *testing.T, path string, doc interface{}, schema map[string]interface{}) {
+	t.Helper()
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		for k, val := range v {
+			sub, ok := props[k].(map[string]interface{})
+			if !ok {
+				t.Errorf("%s.%s emitted but not in schema", path, k)
+				continue
+			}
+			checkAgainstSchema(t, path+"."+k, val, sub)
+		}
+		req, _ := schema["required"].([]interface{})
+		for _, r := range req {
+			if _, ok := v[r.(string)]; !ok {
+				t.Errorf("%s.%s required by schema but not emitted", path, r)
+			}
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for _, it := range v {
+			checkAgainstSchema(t, path+"[]", it, items)
+		}
+	case nil:
+		t.Errorf("%s is null", path)
+	}
+}
+
+func TestWeekReportDocMatchesSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(reporting.WeekSchema, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	var buf bytes.Buffer
+	reportSchemaCmd.SetOut(&buf)
+	if err := reportSchemaCmd.RunE(reportSchemaCmd, nil); err != nil || !bytes.Equal(buf.Bytes(), reporting.WeekSchema) {
+		t.Fatalf("report schema printed %d bytes, err %v", buf.Len(), err)
+	}
+
+	at := func(day, h int) time.Time { return time.Date(2025, 10, day, h, 0, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	policy := rounding.Policy{QuantumSec: 900, Level: rounding.LevelEntry}
+	agg := newWeekAggregator(time.UTC, policy, at(19, 12))
+	for _, e := range []Entry{
+		{ID: "e1", Start: at(13, 9), End: end(at(13, 10)), Customer: "Acme", Project: "web", Billable: true, Notes: []string{"login"}},
+		{ID: "e2", Start: at(14, 9), End: end(at(14, 11)), Customer: "Globex"},
+	} {
+		if err := agg.add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	from, to := at(13, 0), at(19, 0)
+	days := agg.Days(from, to, "en", 80)
+	doc := newWeekReportDoc(from, to, "UTC", days, 3*3600, 3*3600, openEntries{}, policy, reporting.Issues{})
+	raw, rounded, delta := int64(1), int64(2), int64(1)
+	doc.Rounding.RawSeconds, doc.Rounding.RoundedSeconds, doc.Rounding.DeltaSeconds = &raw, &rounded, &delta
+	priceWeek(&doc, 100, "en", reporting.Locales["en"])
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	checkAgainstSchema(t, "report", got, schema)
+	if got["schema_version"] != float64(reporting.WeekSchemaVersion) {
+		t.Errorf("schema_version = %v; want %d", got["schema_version"], reporting.WeekSchemaVersion)
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rtToday bool
+	rtWeek  bool
+	rtRange string
+	rtOpen  openMode
+)
+
+// reportTasksCmd joins tracked time back to Taskwarrior tasks via the
+// task:<UUID> tag and prints one line per task with its description.
+var reportTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Summarize time per Taskwarrior task (task:<UUID> tags)",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := parseRangeFlags(rtToday, rtWeek, rtRange)
+		noteUsageRange(cmd, from, to)
+		entries, err := loadEntries(from, to)
+		if err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		entries, open, err := applyOpenMode(entries, rtOpen, Now())
+		cobra.CheckErr(err)
+		fmt.Printf("%sTask report:%s %s → %s\n\n", ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		printTaskReport(cmd.OutOrStdout(), entries, taskDescription)
+		printOpenNote(cmd.OutOrStdout(), open)
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportTasksCmd)
+	reportTasksCmd.Flags().BoolVar(&rtToday, "today", false, "today only")
+	reportTasksCmd.Flags().BoolVar(&rtWeek, "week", false, "this week (Mon..Sun)")
+	reportTasksCmd.Flags().StringVar(&rtRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	addIncludeOpenFlag(reportTasksCmd, &rtOpen)
+}
+
+type taskTotal struct {
+	UUID     string
+	Seconds  int64
+	Customer string
+	Project  string
+	Note     string // first note seen; fallback description
+}
+
+// aggregateTasks sums finished entries per task UUID. Seconds of entries
+// without a task tag are returned separately.
+func aggregateTasks(entries []Entry) ([]taskTotal, int64) {
+	byUUID := map[string]*taskTotal{}
+	var untagged int64
+	for _, e := range entries {
+		sec := entrySeconds(e)
+		if sec <= 0 {
+			continue
+		}
+		uuid := taskUUIDFromTags(e.Tags)
+		if uuid == "" {
+			untagged += sec
+			continue
+		}
+		t, ok := byUUID[uuid]
+		if !ok {
+			t = &taskTotal{UUID: uuid, Customer: e.Customer, Project: e.Project}
+			byUUID[uuid] = t
+		}
+		t.Seconds += sec
+		if t.Note == "" && len(e.Notes) > 0 {
+			t.Note = e.Notes[0]
+		}
+	}
+	out := make([]taskTotal, 0, len(byUUID))
+	for _, t := range byUUID {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Seconds != out[j].Seconds {
+			return out[i].Seconds > out[j].Seconds
+		}
+		return out[i].UUID < out[j].UUID
+	})
+	return out, untagged
+}
+
+// printTaskReport prints task totals, resolving descriptions with describe
+// and falling back to the entry note when Taskwarrior is unavailable.
+func printTaskReport(w io.Writer, entries []Entry, describe func(string) (string, error)) {
+	tasks, untagged := aggregateTasks(entries)
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "No entries tagged task:<UUID>.")
+	}
+	var total int64
+	for _, t := range tasks {
+		desc, err := describe(t.UUID)
+		if err != nil || desc == "" {
+			desc = t.Note
+		}
+		short := t.UUID
+		if len(short) > 8 {
+			short = short[:8]
+		}
+		fmt.Fprintf(w, "%8s  %s  %s  (%s / %s)\n", fmtSecHHMM(t.Seconds), short, desc, t.Customer, t.Project)
+		total += t.Seconds
+	}
+	fmt.Fprintf(w, "\nTOTAL: %s across %d task(s); untagged: %s\n", fmtSecHHMM(total), len(tasks), fmtSecHHMM(untagged))
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/spf13/viper"
 )
 
 // helper to strip ANSI escape sequences for predictable assertions
@@ -107,7 +112,7 @@ func TestFormatGroups_TableDriven(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			out := formatGroups(tc.agg, tc.groupEntries, tc.detailed)
+			out := reportOptions{Detailed: tc.detailed}.formatGroups(tc.agg, tc.groupEntries)
 			clean := stripANSI(out)
 
 			// Check expected substrings
@@ -125,3 +130,162 @@ func TestFormatGroups_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintRoundingBreakdown(t *testing.T) {
+	var b strings.Builder
+	printRoundingBreakdown(&b, "per entry, strategy=up quantum=15m", []roundingRow{
+		{Label: reportOptions{}.aggKeyLabel(aggKey{Customer: "Acme", Project: "Portal", Activity: "dev"}), Raw: 3000, Rounded: 3600},
+		{Label: reportOptions{}.aggKeyLabel(aggKey{Customer: "Globex"}), Raw: 1830, Rounded: 1800},
+	})
+	out := stripANSI(b.String())
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	for i, want := range [][]string{
+		{"Rounding breakdown:", "quantum=15m"},
+		{"raw s", "rounded s", "delta"},
+		{"Acme / Portal [dev]", "3000", "3600", "+10m00s"},
+		{"Globex", "1830", "1800", "-30s"},
+		{"Total", "4830", "5400", "+9m30s", "(gain)"},
+	} {
+		if !containsAll(lines[i], want...) {
+			t.Errorf("line %d = %q, want %v", i, lines[i], want)
+		}
+	}
+	for sec, want := range map[int64]string{0: "±0s", 3725: "+1h02m05s", -45: "-45s"} {
+		if got := fmtSignedSeconds(sec); got != want {
+			t.Errorf("fmtSignedSeconds(%d) = %q, want %q", sec, got, want)
+		}
+	}
+}
+
+func TestReportOptionsConcurrent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("customers.display", map[string]string{"Acme Corporation": "Acme"})
+	resetConfigCaches()
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("customers.display", nil)
+		resetConfigCaches()
+	})
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	if err := writeEvents([]Event{
+		NewStartEvent("s1", "Acme Corporation", "Portal", "dev", boolPtr(true), "", nil, day),
+		NewStopEvent("s2", day.Add(time.Hour)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs with different options, and cache resets, side by side; each
+	// output must reflect only its own options.
+	cases := []struct {
+		o    reportOptions
+		want string
+	}{
+		{reportOptions{By: "customer"}, "Acme"},
+		{reportOptions{By: "customer", Legal: true}, "Acme Corporation"},
+		{reportOptions{By: "customer,project"}, "Acme / Portal"},
+		{reportOptions{By: "customer,project", Legal: true}, "Acme Corporation / Portal"},
+	}
+	errs := make(chan error, 4*len(cases))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, c := range cases {
+			wg.Add(1)
+			go func(o reportOptions, want string) {
+				defer wg.Done()
+				resetConfigCaches()
+				var buf bytes.Buffer
+				if err := o.run(&buf, day, day.Add(23*time.Hour)); err != nil {
+					errs <- err
+					return
+				}
+				var groups []string
+				for _, line := range strings.Split(stripANSI(buf.String()), "\n") {
+					if line = strings.TrimSpace(line); strings.HasPrefix(line, "Acme") {
+						groups = append(groups, strings.SplitN(line, "  ", 2)[0])
+					}
+				}
+				if len(groups) != 1 || groups[0] != want {
+					errs <- fmt.Errorf("%+v: groups %q, want %q", o, groups, want)
+				}
+			}(c.o, c.want)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestReportAggregator_StreamedEntries(t *testing.T) {
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+
+	a := newReportAggregator(reportOptions{By: "customer"}, nil, getRounding().Policy(), at(31, 12, 0))
+	// Entries arrive per day file: an entry held open over midnight comes
+	// after the next day's entries; notes must still come out in start order.
+	for _, e := range []Entry{
+		{ID: "b", Start: at(7, 9, 0), End: end(at(7, 10, 0)), Customer: "Acme", Notes: []string{"second", "first"}},
+		{ID: "a", Start: at(6, 23, 0), End: end(at(7, 1, 0)), Customer: "Acme", Notes: []string{"first"}},
+		{ID: "c", Start: at(20, 9, 0), End: end(at(20, 9, 30)), Customer: "Globex"},
+		{ID: "r", Start: at(31, 9, 0), Customer: "Acme"},
+	} {
+		if err := a.add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.loaded != 3 || a.considered != 3 || a.totalRawSec != 3*3600+30*60 {
+		t.Fatalf("loaded %d considered %d raw %d", a.loaded, a.considered, a.totalRawSec)
+	}
+	if len(a.open.IDs) != 1 || a.open.IDs[0] != "r" || a.open.Provisional() {
+		t.Fatalf("open = %+v", a.open)
+	}
+	acme := a.groupEntries[aggKey{Customer: "Acme"}]
+	if len(acme) != 2 {
+		t.Fatalf("Acme notes kept = %+v", acme)
+	}
+	// "first" is dated by entry a, which started before b
+	out := stripANSI(reportOptions{By: "customer"}.formatGroups(a.agg, a.groupEntries))
+	if !strings.Contains(out, "- first • second") {
+		t.Fatalf("notes:\n%s", out)
+	}
+}
+
+func TestReportMonthStreamsDayFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	at := func(day, h int) time.Time { return time.Date(2025, 10, day, h, 0, 0, 0, time.UTC) }
+	oldNow := Now
+	Now = func() time.Time { return at(31, 12) }
+	t.Cleanup(func() { Now = oldNow })
+	for _, ev := range []Event{
+		NewStartEvent("s1", "Acme", "", "", boolPtr(true), "kickoff", nil, at(1, 9)),
+		NewStopEvent("x1", at(1, 11)),
+		NewStartEvent("s2", "Acme", "", "", boolPtr(true), "release", nil, at(15, 22)),
+		NewStopEvent("x2", at(16, 1)), // in the next day's file
+		NewStartEvent("s3", "Globex", "", "", boolPtr(true), "", nil, at(31, 9)),
+	} {
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := reportOptions{By: "customer"}.run(&buf, at(1, 0), at(31, 23))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := stripANSI(buf.String())
+	if !containsAll(out, "Loaded entries: 2", "Acme", "kickoff • release", "TOTAL: 5h00m raw", "1 running entry not counted") || strings.Contains(out, "Globex") {
+		t.Fatalf("month report:\n%s", out)
+	}
+	err = reportOptions{By: "customer", Open: openError}.run(&buf, at(1, 0), at(31, 23))
+	if err == nil || !strings.Contains(err.Error(), "s3") {
+		t.Fatalf("--include-open=error: %v", err)
+	}
+}
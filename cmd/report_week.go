@@ -2,16 +2,19 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+	"tt/internal/targets"
+	"tt/internal/worktime"
 )
 
 var (
@@ -22,32 +25,20 @@ var (
 	rwRoundFlag      int
 	rwCustomerFilter string
 	rwTagFilters     []string
-	rwIncludeOpen    bool
+	rwUsers          []string
+	rwWhere          []string
+	rwIncludeOpen    openMode
 	rwNotesWrap      int
 	rwLocale         string
 	rwExportTempo    string
 	rwTempoRounded   bool
+	rwTempoRaw       bool
+	rwShowRounding   bool
+	rwMoney          bool
+	rwRateOverride   float64
+	rwLegalNames     bool
 )
 
-// Types used across functions (moved to package-level to avoid visibility issues)
-type outNoteGroup struct {
-	Customer    string   `json:"customer"`
-	Project     string   `json:"project,omitempty"`
-	Seconds     int64    `json:"seconds"`
-	SecRounded  int64    `json:"secondsRounded"`
-	Notes       []string `json:"notes"`
-	NotesMerged string   `json:"notesMerged"`
-}
-
-type outDay struct {
-	Date              string         `json:"date"`
-	Weekday           string         `json:"weekday"`
-	Groups            []outNoteGroup `json:"groups"`
-	DaySeconds        int64          `json:"daySeconds"`
-	DaySecondsRounded int64          `json:"daySecondsRounded"`
-	Flags             []string       `json:"flags"`
-}
-
 var reportWeekCmd = &cobra.Command{
 	Use:   "week",
 	Short: "Report this ISO week (Mon–Sun) grouped by day and customer/project",
@@ -59,9 +50,14 @@ var reportWeekCmd = &cobra.Command{
 		}
 		loc, err := time.LoadLocation(tzName)
 		if err != nil {
-			fmt.Printf("Warning: failed to load timezone %q, using Local\n", tzName)
+			logger.Warn("failed to load timezone, using Local", "err", err, "timezone", tzName)
 			loc = time.Local
 		}
+		numbers, err := reportLocale(rwLocale)
+		cobra.CheckErr(err)
+		labels := labelLocale(rwLocale)
+		renderer, err := reporting.New(rwFormatFlag, reporting.Options{Palette: ansiPalette(), TempoRaw: rwTempoRaw, Locale: numbers})
+		cobra.CheckErr(err)
 
 		// Resolve range: --from & --to override --week. If none given, current ISO week.
 		var from, to time.Time
@@ -86,318 +82,92 @@ var reportWeekCmd = &cobra.Command{
 		// Normalize from/to to date boundaries in target loc
 		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
 		to = time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, loc)
-
-		// Load entries that intersect the window. The existing loadEntries expects times in local zone;
-		// ensure we pass times in the same location as viper timezone to get proper files.
-		entries, err := loadEntries(from, to)
-		if err != nil {
-			fmt.Printf("Warning: failed to load some entries: %v\n", err)
-		}
-
-		// Apply basic filters: customer (case-insensitive exact) and tags (AND)
-		filtered := make([]Entry, 0, len(entries))
-		for _, e := range entries {
-			if rwCustomerFilter != "" {
-				if !strings.EqualFold(strings.TrimSpace(e.Customer), strings.TrimSpace(rwCustomerFilter)) {
-					continue
-				}
-			}
-			if len(rwTagFilters) > 0 {
-				etags := make([]string, 0, len(e.Tags))
-				for _, t := range e.Tags {
-					etags = append(etags, strings.ToLower(strings.TrimSpace(t)))
-				}
-				ok := true
-				for _, rt := range rwTagFilters {
-					if !containsString(etags, strings.ToLower(strings.TrimSpace(rt))) {
-						ok = false
-						break
-					}
-				}
-				if !ok {
-					continue
-				}
-			}
-			filtered = append(filtered, e)
-		}
+		noteUsageRange(cmd, from, to)
+
+		// One rounding policy from the config (rounding.*); --round only
+		// overrides its quantum (divisions per hour, 4 -> 15 minutes).
+		policy := getRounding().Policy()
+		if cmd.Flags().Changed("round") && rwRoundFlag > 0 {
+			policy.QuantumSec = int64(3600 / rwRoundFlag)
+		}
+		quantumMin := policy.Quantum() / 60
+
+		// Stream entries day file by day file into the aggregator so long ranges
+		// aggregate incrementally instead of materializing every entry first.
+		agg := newWeekAggregator(loc, policy, Now().UTC())
+		agg.customer = rwCustomerFilter
+		agg.tags = rwTagFilters
+		agg.users = rwUsers
+		agg.where, err = parseWhere(rwWhere)
+		cobra.CheckErr(err)
+		agg.open = openMode(rwIncludeOpen.String())
+		if err := streamEntries(from, to, agg.add); err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		open := openEntries{Mode: agg.open, IDs: agg.openIDs, At: agg.now}
+		cobra.CheckErr(open.Err())
 
 		// If no entries, show simple message
-		if len(filtered) == 0 {
+		if agg.matched == 0 {
 			fmt.Println("No entries in range.")
 			return
 		}
 
-		// Prepare split-per-day segments in target timezone.
-		type seg struct {
-			Day      string // YYYY-MM-DD in loc
-			Start    time.Time
-			End      time.Time
-			Seconds  int64
-			EntryID  string
-			Customer string
-			Project  string
-			Notes    []string
-			Tags     []string
-		}
-
-		var segments []seg
-		var badEntries []string // zero/negative durations or missing customer
-		for _, e := range filtered {
-			// Determine end time: if nil and include-open, set to now UTC
-			var end time.Time
-			if e.End == nil {
-				if rwIncludeOpen {
-					end = time.Now().UTC()
-				} else {
-					// skip running entries unless include-open
-					badEntries = append(badEntries, fmt.Sprintf("%s (running)", e.ID))
-					continue
-				}
-			} else {
-				end = *e.End
-			}
-			start := e.Start
-
-			if !end.After(start) {
-				badEntries = append(badEntries, fmt.Sprintf("%s (zero/negative)", e.ID))
-				continue
-			}
-
-			// Convert to local tz for splitting/grouping
-			startLoc := start.In(loc)
-			endLoc := end.In(loc)
-
-			// iterate day boundaries from startLoc to endLoc
-			curStart := startLoc
-			for curStart.Before(endLoc) {
-				// compute midnight of next day
-				y, m, d := curStart.Date()
-				nextMidnight := time.Date(y, m, d, 0, 0, 0, 0, loc).Add(24 * time.Hour)
-				segEnd := endLoc
-				if nextMidnight.Before(endLoc) {
-					segEnd = nextMidnight
-				}
-				seconds := int64(segEnd.Sub(curStart).Seconds())
-				dayKey := curStart.Format("2006-01-02")
-				cust := e.Customer
-				if strings.TrimSpace(cust) == "" {
-					cust = "(unknown)"
-				}
-				segments = append(segments, seg{
-					Day:      dayKey,
-					Start:    curStart,
-					End:      segEnd,
-					Seconds:  seconds,
-					EntryID:  e.ID,
-					Customer: cust,
-					Project:  e.Project,
-					Notes:    e.Notes,
-					Tags:     e.Tags,
-				})
-				curStart = segEnd
-			}
-		}
-
-		// Compute quantum early for per-entry rounding (rwRoundFlag is divisions-per-hour).
-		if rwRoundFlag <= 0 {
-			// default changed to 4 -> 15 minute quantum (per new requirement)
-			rwRoundFlag = 4
-		}
-		quantumMinLocal := 60 / rwRoundFlag
-		if quantumMinLocal <= 0 {
-			quantumMinLocal = 15
-		}
-		quantumSecLocal := int64(quantumMinLocal * 60)
-
-		// Distribute per-entry rounding: round up each entry's total seconds to the quantum,
-		// then allocate the rounded total across its segments proportionally (floor allocations,
-		// remainder goes to the last segment). This preserves per-entry round-up semantics while
-		// ensuring per-day and per-group sums match per-entry rounded totals.
-		// Build map entryID -> slice of indices into segments.
-		entryIndices := map[string][]int{}
-		for i := range segments {
-			entryIndices[segments[i].EntryID] = append(entryIndices[segments[i].EntryID], i)
-		}
-		for _, idxs := range entryIndices {
-			// compute total raw seconds for this entry
-			var total int64 = 0
-			for _, idx := range idxs {
-				total += segments[idx].Seconds
-			}
-			if total <= 0 {
-				continue
-			}
-			rounded := roundUpSecondsToQuantum(total, quantumSecLocal)
-			if rounded == total {
-				continue
-			}
-			// allocate rounded seconds across segments
-			var sumAllocated int64 = 0
-			for j, idx := range idxs {
-				if j == len(idxs)-1 {
-					// last segment gets the remainder to ensure totals match
-					segments[idx].Seconds = rounded - sumAllocated
-				} else {
-					alloc := (segments[idx].Seconds * rounded) / total
-					segments[idx].Seconds = alloc
-					sumAllocated += alloc
-				}
-			}
-		}
-
-		// Detect overlaps per day
-		overlapsByDay := map[string]map[string]struct{}{} // day -> set of entryIDs involved
-		overlapRanges := []string{}
-		// group segments per day for overlap detection
-		segByDay := map[string][]seg{}
-		for _, s := range segments {
-			segByDay[s.Day] = append(segByDay[s.Day], s)
-		}
-		for day, segs := range segByDay {
-			sort.Slice(segs, func(i, j int) bool { return segs[i].Start.Before(segs[j].Start) })
-			for i := 1; i < len(segs); i++ {
-				prev := segs[i-1]
-				cur := segs[i]
-				if cur.Start.Before(prev.End) {
-					if _, ok := overlapsByDay[day]; !ok {
-						overlapsByDay[day] = map[string]struct{}{}
-					}
-					overlapsByDay[day][prev.EntryID] = struct{}{}
-					overlapsByDay[day][cur.EntryID] = struct{}{}
-					overlapRanges = append(overlapRanges, fmt.Sprintf("%s entry ids %s, %s %s–%s",
-						day, prev.EntryID, cur.EntryID, prev.Start.Format("15:04"), cur.End.Format("15:04")))
-				}
-			}
-		}
-
-		// Aggregate per (day, customer, project)
-		type groupKey struct {
-			Day      string
-			Customer string
-			Project  string
-		}
-		type groupVal struct {
-			Seconds int64
-			Notes   []string
-		}
-		groups := map[groupKey]*groupVal{}
-		dayTotals := map[string]int64{}
-		weekTotal := int64(0)
-
-		for _, s := range segments {
-			k := groupKey{Day: s.Day, Customer: s.Customer, Project: s.Project}
-			if _, ok := groups[k]; !ok {
-				groups[k] = &groupVal{Seconds: 0, Notes: []string{}}
-			}
-			groups[k].Seconds += s.Seconds
-			// append notes preserving chronological order
-			for _, n := range s.Notes {
-				normalized := normalizeNote(n)
-				if normalized != "" {
-					groups[k].Notes = append(groups[k].Notes, normalized)
-				}
+		outDays := agg.Days(from, to, labels, rwNotesWrap)
+		var weekTotal int64
+		for _, d := range outDays {
+			weekTotal += d.DaySeconds
+		}
+		overlapRanges := agg.OverlapRanges()
+		badEntries := agg.badEntries
+		missingNotes := weekMissingNotes(outDays)
+		minimumApplied := agg.minimumApplied(outDays)
+
+		doc := newWeekReportDoc(from, to, tzName, outDays, weekTotal, agg.RawTotal(), open, policy, reporting.Issues{
+			Overlaps:       overlapRanges,
+			BadEntries:     badEntries,
+			OpenEntries:    agg.openIDs,
+			MissingNotes:   missingNotes,
+			MinimumApplied: minimumApplied,
+			WorkingTime:    worktimeIssues(agg.worked, loadWorktimeRules(), from, to, loc),
+		})
+		if rwMoney {
+			priceWeek(&doc, rwRateOverride, labels, numbers)
+		}
+		doc.Targets = weekTargets(agg.spans, loadTargets(), from, to, loc)
+		// Formats read by people show display aliases; json, csv and tempo
+		// are exports and keep the full customer names.
+		if !rwLegalNames && (rwFormatFlag == "table" || rwFormatFlag == "markdown" || rwFormatFlag == "html") {
+			doc.Days = displayWeekCustomers(doc.Days)
+		}
+		// The text formats print provisional totals and the rounding
+		// breakdown after the report; the others carry them in the document.
+		text := rwFormatFlag == "table" || rwFormatFlag == "markdown"
+		if rwShowRounding && !text {
+			var raw, rounded int64
+			for _, r := range weekRoundingRows(outDays) {
+				raw += r.Raw
+				rounded += r.Rounded
 			}
-			dayTotals[s.Day] += s.Seconds
-			weekTotal += s.Seconds
-		}
-
-		// Display rounding: flag rwRoundFlag is divisions-per-hour (e.g., 6 -> 10 min quantum)
-		if rwRoundFlag <= 0 {
-			rwRoundFlag = 6 // default per spec (10-minute granularity)
-		}
-		quantumMin := 60 / rwRoundFlag
-		if quantumMin <= 0 {
-			quantumMin = 10
+			delta := rounded - raw
+			doc.Rounding.RawSeconds, doc.Rounding.RoundedSeconds, doc.Rounding.DeltaSeconds = &raw, &rounded, &delta
 		}
-		quantumSec := int64(quantumMin * 60)
-
-		outDays := []outDay{}
-
-		// Prepare ordered list of days from 'from' to 'to'
-		days := []time.Time{}
-		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
-			days = append(days, d)
-		}
-
-		// For each day build output groups
-		for _, d := range days {
-			dayKey := d.In(loc).Format("2006-01-02")
-			weekdayLabel := weekdayLabelFor(d.In(loc).Weekday(), rwLocale)
-			og := outDay{Date: dayKey, Weekday: weekdayLabel, Groups: []outNoteGroup{}}
-			// collect groups for this day and sort by customer/project
-			keys := []groupKey{}
-			for k := range groups {
-				if k.Day == dayKey {
-					keys = append(keys, k)
-				}
-			}
-			sort.Slice(keys, func(i, j int) bool {
-				if keys[i].Customer != keys[j].Customer {
-					return keys[i].Customer < keys[j].Customer
-				}
-				return keys[i].Project < keys[j].Project
-			})
-			daySec := int64(0)
-			daySecRounded := int64(0)
-			dayFlags := []string{}
-			for _, k := range keys {
-				v := groups[k]
-				// dedupe and normalize notes
-				notesDedup := dedupeStrings(v.Notes)
-				merged := mergeNotesForDisplay(notesDedup, rwNotesWrap)
-				roundedSec := roundSecondsToQuantum(v.Seconds, quantumSec)
-				og.Groups = append(og.Groups, outNoteGroup{
-					Customer:    k.Customer,
-					Project:     k.Project,
-					Seconds:     v.Seconds,
-					SecRounded:  roundedSec,
-					Notes:       notesDedup,
-					NotesMerged: merged,
-				})
-				daySec += v.Seconds
-				daySecRounded += roundedSec
-			}
-			// flags: overlap?
-			if od, ok := overlapsByDay[dayKey]; ok && len(od) > 0 {
-				dayFlags = append(dayFlags, "overlap")
-			}
-			if daySec == 0 && len(og.Groups) == 0 {
-				// skip empty days unless format json requires them; we'll include empty days with ok flag
-				og.Flags = []string{"ok"}
-			} else {
-				og.Flags = dayFlags
-			}
-			og.DaySeconds = daySec
-			og.DaySecondsRounded = daySecRounded
-			outDays = append(outDays, og)
+		cobra.CheckErr(renderer.Render(os.Stdout, doc))
+		if text && open.Provisional() {
+			fmt.Println()
+			printOpenNote(os.Stdout, open)
 		}
-
-		// Render based on format
-		switch rwFormatFlag {
-		case "json":
-			out := map[string]interface{}{
-				"week":               fmtWeekLabel(from, to),
-				"range":              map[string]string{"from": from.Format("2006-01-02"), "to": to.Format("2006-01-02")},
-				"timezone":           tzName,
-				"days":               outDays,
-				"weekSeconds":        weekTotal,
-				"weekSecondsRounded": roundSecondsToQuantum(weekTotal, quantumSec),
-				"issues": map[string]interface{}{
-					"overlaps":   overlapRanges,
-					"badEntries": badEntries,
-				},
-			}
-			j, _ := json.MarshalIndent(out, "", "  ")
-			fmt.Println(string(j))
-		case "markdown":
-			printMarkdownReport(from, to, tzName, outDays, weekTotal, quantumSec, overlapRanges, badEntries)
-		default:
-			printTableReport(from, to, tzName, outDays, weekTotal, quantumSec, overlapRanges, badEntries)
+		if rwShowRounding && text {
+			fmt.Println()
+			printRoundingBreakdown(os.Stdout, fmt.Sprintf("level=%s strategy=%s quantum=%dm", policy.Level, policy.Strategy, quantumMin), weekRoundingRows(doc.Days))
 		}
 
 		// Tempo export if requested
 		if rwExportTempo != "" {
-			err := writeTempoExport(rwExportTempo, outDays, weekTotal, quantumSec, rwTempoRounded)
+			err := checkMissingNotes(os.Stdout, "tempo export", missingNotes)
+			if err == nil {
+				err = writeTempoExport(rwExportTempo, outDays, rwTempoRaw)
+			}
 			if err != nil {
 				fmt.Printf("Failed to write tempo export: %v\n", err)
 			} else {
@@ -414,15 +184,164 @@ func init() {
 	reportWeekCmd.Flags().StringVar(&rwWeekFlag, "week", "", "ISO week, e.g. 2025-W41 (default = current ISO week)")
 	reportWeekCmd.Flags().StringVar(&rwFromFlag, "from", "", "Start date YYYY-MM-DD (overrides --week if both --from and --to set)")
 	reportWeekCmd.Flags().StringVar(&rwToFlag, "to", "", "End date YYYY-MM-DD (overrides --week if both --from and --to set)")
-	reportWeekCmd.Flags().StringVar(&rwFormatFlag, "format", "table", "Output format: table|json|markdown")
-	reportWeekCmd.Flags().IntVar(&rwRoundFlag, "round", 4, "Rounding divisions-per-hour (e.g., 4 -> 15-minute quantum). Default 4")
-	reportWeekCmd.Flags().StringVar(&rwCustomerFilter, "customer", "", "Filter by exact customer (case-insensitive)")
+	reportWeekCmd.Flags().StringVar(&rwFormatFlag, "format", "table", "Output format: "+strings.Join(reporting.Formats(), "|"))
+	reportWeekCmd.Flags().IntVar(&rwRoundFlag, "round", 0, "Rounding divisions-per-hour (e.g., 4 -> 15-minute quantum); default: rounding.quantum_min")
+	reportWeekCmd.Flags().StringVar(&rwCustomerFilter, "customer", "", "Filter by exact customer or display alias (case-insensitive)")
 	reportWeekCmd.Flags().StringArrayVar(&rwTagFilters, "tag", []string{}, "Filter by tag (repeatable; AND logic)")
-	reportWeekCmd.Flags().BoolVar(&rwIncludeOpen, "include-open", false, "Include entries without end time (treat end = now)")
+	reportWeekCmd.Flags().StringSliceVar(&rwUsers, "user", nil, userFlagHelp)
+	reportWeekCmd.Flags().StringArrayVar(&rwWhere, "where", nil, whereFlagHelp)
+	addIncludeOpenFlag(reportWeekCmd, &rwIncludeOpen)
 	reportWeekCmd.Flags().IntVar(&rwNotesWrap, "notes-wrap", 80, "Wrap merged notes to N columns (0 = no wrap)")
-	reportWeekCmd.Flags().StringVar(&rwLocale, "locale", "de", "Locale for weekday labels: de|en")
+	reportWeekCmd.Flags().StringVar(&rwLocale, "locale", "", "Locale for weekday labels, amounts, numbers and dates: de|en|iso (default: locale.preset; labels de)")
+	reportWeekCmd.Flags().BoolVar(&rwMoney, "money", false, "show the value of billable time per group and for the week (config: rates)")
+	reportWeekCmd.Flags().Float64Var(&rwRateOverride, "rate-override", 0, "with --money: price all billable time at this hourly rate instead of the configured rates")
 	reportWeekCmd.Flags().StringVar(&rwExportTempo, "export-tempo", "", "Write Tempo JSON export to path")
 	reportWeekCmd.Flags().BoolVar(&rwTempoRounded, "tempo-rounded", false, "When exporting to Tempo use rounded seconds instead of raw")
+	_ = reportWeekCmd.Flags().MarkDeprecated("tempo-rounded", "the Tempo export uses the rounded seconds of the report; use --tempo-raw for tracked seconds")
+	reportWeekCmd.Flags().BoolVar(&rwTempoRaw, "tempo-raw", false, "When exporting to Tempo use the tracked (unrounded) seconds")
+	reportWeekCmd.Flags().BoolVar(&rwLegalNames, "legal-names", false, "show full customer names instead of their display aliases (customers.display)")
+	reportWeekCmd.Flags().BoolVar(&rwShowRounding, "show-rounding", false, "print raw vs rounded seconds and the delta per day group and for the week")
+}
+
+// ---------- Aggregation ----------
+
+// weekAggregator applies the report filters and the open-entry mode, then
+// folds the matching entries into the shared day aggregation
+// (reporting.Aggregator) configured from the customer timezones and
+// minimums, canonical projects and the duration precision.
+type weekAggregator struct {
+	*reporting.Aggregator
+	now time.Time
+
+	// filters
+	customer string
+	tags     []string
+	users    []string
+	where    []whereClause
+	open     openMode // running entries: skip, now or error
+	openIDs  []string // running entries seen
+
+	matched    int      // entries passing the filters
+	badEntries []string // zero/negative durations or running entries
+
+	// worked collects the finished entries per user for the working-time
+	// rules; only the user filter applies, since the limits concern all of
+	// a person's work.
+	worked map[string][]worktime.Span
+
+	// spans collects the work for the hour targets, filtered like worked;
+	// running entries count only with --include-open=now.
+	spans []targets.Span
+}
+
+func newWeekAggregator(loc *time.Location, policy rounding.Policy, now time.Time) *weekAggregator {
+	return &weekAggregator{
+		Aggregator: reporting.NewAggregator(reportingConfig(loc, policy, now)),
+		now:        now,
+	}
+}
+
+// reportingConfig configures the shared aggregation from the config:
+// customers.<name>.timezone and minimum_billable_min, canonical projects and
+// precision.
+func reportingConfig(loc *time.Location, policy rounding.Policy, now time.Time) reporting.Config {
+	return reporting.Config{
+		Location:         loc,
+		Policy:           policy,
+		Now:              now,
+		CustomerLocation: func(c string) *time.Location { return customerLocation(c, loc) },
+		CustomerPolicy:   func(c string) rounding.Policy { return customerPolicy(policy, c) },
+		CanonicalProject: CanonicalProject,
+		Truncate:         truncatePrecision,
+	}
+}
+
+// matches applies the customer (case-insensitive exact), tag (AND), user and
+// --where field filters.
+func (a *weekAggregator) matches(e Entry) bool {
+	if len(a.users) > 0 && len(filterUsers([]Entry{e}, a.users)) == 0 {
+		return false
+	}
+	if !matchesWhere(e, a.where) {
+		return false
+	}
+	if a.customer != "" {
+		if !customerMatches(a.customer, e.Customer) {
+			return false
+		}
+	}
+	if len(a.tags) > 0 {
+		etags := make([]string, 0, len(e.Tags))
+		for _, t := range e.Tags {
+			etags = append(etags, strings.ToLower(strings.TrimSpace(t)))
+		}
+		for _, rt := range a.tags {
+			if !containsString(etags, strings.ToLower(strings.TrimSpace(rt))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// add filters one entry and folds it into the aggregation. It never fails;
+// the error return lets it be used directly as a streamEntries callback.
+func (a *weekAggregator) add(e Entry) error {
+	if e.End != nil && (len(a.users) == 0 || len(filterUsers([]Entry{e}, a.users)) > 0) {
+		if a.worked == nil {
+			a.worked = map[string][]worktime.Span{}
+		}
+		a.worked[e.User] = append(a.worked[e.User], entryWorkSpans(e, *e.End)...)
+	}
+	if (e.End != nil || a.open == openNow) && (len(a.users) == 0 || len(filterUsers([]Entry{e}, a.users)) > 0) {
+		a.spans = append(a.spans, targetSpans([]Entry{e}, a.now)...)
+	}
+	if !a.matches(e) {
+		return nil
+	}
+	a.matched++
+
+	// Running entries count up to now only with --include-open=now; with
+	// error the command fails after streaming.
+	if e.End == nil {
+		a.openIDs = append(a.openIDs, e.ID)
+		if a.open != openNow {
+			if a.open != openError {
+				a.badEntries = append(a.badEntries, fmt.Sprintf("%s (running)", e.ID))
+			}
+			return nil
+		}
+	}
+	if err := a.Add(e.journalEntry()); err != nil {
+		a.badEntries = append(a.badEntries, fmt.Sprintf("%s (%v)", e.ID, err))
+	}
+	return nil
+}
+
+// minimumApplied lists the entries raised to the minimum billable time, or at
+// aggregate level the day groups whose total was.
+func (a *weekAggregator) minimumApplied(days []reporting.Day) []string {
+	var out []string
+	for _, u := range a.MinimumApplied(days) {
+		out = append(out, minimumLabel(u.Label(), u.RawSeconds, u.Policy))
+	}
+	return out
+}
+
+// weekRoundingRows lists, per day group, the tracked seconds against the
+// reported (per-entry rounded) seconds for --show-rounding.
+func weekRoundingRows(days []reporting.Day) []roundingRow {
+	var rows []roundingRow
+	for _, d := range days {
+		for _, g := range d.Groups {
+			label := d.Date + " " + g.Customer
+			if g.Project != "" {
+				label += " / " + g.Project
+			}
+			rows = append(rows, roundingRow{Label: label, Raw: g.SecondsRaw, Rounded: g.Seconds})
+		}
+	}
+	return rows
 }
 
 // ---------- Helper functions ----------
@@ -488,89 +407,13 @@ func containsString(ss []string, s string) bool {
 	return false
 }
 
-func normalizeNote(s string) string {
-	// Trim, collapse whitespace and newlines into single spaces
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return ""
-	}
-	// collapse internal whitespace
-	f := strings.Fields(s)
-	return strings.Join(f, " ")
-}
-
-func dedupeStrings(arr []string) []string {
-	seen := map[string]struct{}{}
-	out := []string{}
-	for _, s := range arr {
-		if s == "" {
-			continue
-		}
-		if _, ok := seen[s]; ok {
-			continue
-		}
-		seen[s] = struct{}{}
-		out = append(out, s)
-	}
-	return out
-}
-
-func mergeNotesForDisplay(notes []string, wrapCols int) string {
-	if len(notes) == 0 {
-		return ""
-	}
-	joined := strings.Join(notes, " • ")
-	if wrapCols <= 0 {
-		return joined
-	}
-	// simple wrap: insert newline when line exceeds wrapCols at space
-	var b strings.Builder
-	lineLen := 0
-	words := strings.Fields(joined)
-	for i, w := range words {
-		if i > 0 {
-			// space before word
-			lineLen++
-			if lineLen > wrapCols {
-				b.WriteString("\n")
-				lineLen = 0
-			} else {
-				b.WriteByte(' ')
-			}
-		}
-		b.WriteString(w)
-		lineLen += len(w)
-	}
-	return b.String()
-}
-
-func roundSecondsToQuantum(sec int64, quantumSec int64) int64 {
-	if quantumSec <= 0 {
-		return sec
-	}
-	if sec <= 0 {
-		return 0
-	}
-	rem := sec % quantumSec
-	if rem*2 >= quantumSec {
-		return ((sec / quantumSec) + 1) * quantumSec
-	}
-	return (sec / quantumSec) * quantumSec
-}
-
-// roundUpSecondsToQuantum always rounds up to the next quantum (unless already aligned).
-// This is used for per-entry rounding semantics (each entry rounded up to N-minute intervals).
+// roundUpSecondsToQuantum rounds sec up to the next quantum (unless already
+// aligned), i.e. the default policy of the rounding pipeline.
 func roundUpSecondsToQuantum(sec int64, quantumSec int64) int64 {
 	if quantumSec <= 0 {
 		return sec
 	}
-	if sec <= 0 {
-		return 0
-	}
-	if sec%quantumSec == 0 {
-		return sec
-	}
-	return ((sec / quantumSec) + 1) * quantumSec
+	return rounding.Policy{Strategy: "up", QuantumSec: quantumSec}.Round(sec)
 }
 
 func fmtWeekLabel(from, to time.Time) string {
@@ -579,159 +422,21 @@ func fmtWeekLabel(from, to time.Time) string {
 	return fmt.Sprintf("%d-W%02d", y, w)
 }
 
-func weekdayLabelFor(wd time.Weekday, locale string) string {
-	en := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
-	de := []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"}
-	if strings.ToLower(locale) == "en" {
-		return en[int(wd)]
-	}
-	return de[int(wd)]
-}
-
-func printTableReport(from, to time.Time, tz string, days []outDay, weekTotal int64, quantumSec int64, overlaps []string, badEntries []string) {
-	// Header (heading color)
-	fmt.Printf("%sWoche %s%s  %s\n\n", ansiHeading, fmtWeekLabel(from, to), ansiReset, tz)
-	totalHours := float64(weekTotal) / 3600.0
-
-	// Column widths for table-like layout
-	const labelWidth = 30
-	const hoursWidth = 7
-
-	for _, d := range days {
-		// Day header: heading color
-		fmt.Printf("%s%s %s%s\n", ansiHeading, d.Weekday, d.Date, ansiReset)
-
-		// If no groups, print a friendly empty hint (dim)
-		if len(d.Groups) == 0 {
-			fmt.Printf("  %s(no entries)%s\n", ansiDim, ansiReset)
-		}
-
-		for _, g := range d.Groups {
-			hours := float64(g.Seconds) / 3600.0
-			// Compose left label: "Customer / Project" or "(unknown)"
-			label := g.Customer
-			if g.Project != "" {
-				label = fmt.Sprintf("%s / %s", g.Customer, g.Project)
-			}
-			// Truncate label if too long
-			if len(label) > labelWidth-2 {
-				label = label[:labelWidth-5] + "..."
-			}
-			// Colored customer/project label, hours in green, notes muted
-			fmt.Printf("  %s%-*s%s %s%*.2fh%s\n", ansiLabel, labelWidth, label, ansiReset, ansiHours, hoursWidth, hours, ansiReset)
-			// Notes (wrapped are already produced by mergeNotesForDisplay); show on next line indented and muted
-			if g.NotesMerged != "" {
-				lines := strings.Split(g.NotesMerged, "\n")
-				for _, ln := range lines {
-					fmt.Printf("    %s- %s%s\n", ansiNotes, ln, ansiReset)
-				}
-			}
-		}
-
-		// Day subtotal: subtotal color; mark overlap if present using alert color
-		dayHours := float64(d.DaySeconds) / 3600.0
-		overlapMark := ""
-		if len(d.Flags) > 0 {
-			overlapMark = fmt.Sprintf(" %s! overlap%s", ansiOverlap, ansiReset)
-		}
-		fmt.Printf("\n  %sTagessumme:%s %s%*.2fh%s%s\n\n", ansiHeading, ansiReset, ansiWarn, hoursWidth, dayHours, ansiReset, overlapMark)
-	}
-
-	// Weekly total emphasized: heading + hours color
-	fmt.Printf("%sWochensumme:%s %s%.2fh%s\n", ansiHeading, ansiReset, ansiHours, totalHours, ansiReset)
-
-	// Footer hints: overlaps and data issues, colored
-	if len(overlaps) > 0 || len(badEntries) > 0 {
-		fmt.Printf("\n%sHinweise:%s\n", ansiHeading, ansiReset)
-		for _, o := range overlaps {
-			fmt.Printf("  %s! overlap:%s %s\n", ansiOverlap, ansiReset, o)
-		}
-		if len(badEntries) > 0 {
-			fmt.Printf("  %sData issues:%s %d entries\n", ansiWarn, ansiReset, len(badEntries))
-			for _, be := range badEntries {
-				fmt.Printf("    - %s\n", be)
-			}
-		}
-	}
-}
-
-func printMarkdownReport(from, to time.Time, tz string, days []outDay, weekTotal int64, quantumSec int64, overlaps []string, badEntries []string) {
-	fmt.Printf("# Woche %s (%s–%s) · %s\n\n", fmtWeekLabel(from, to), from.Format("2006-01-02"), to.Format("2006-01-02"), tz)
-	for _, d := range days {
-		fmt.Printf("## %s %s\n\n", d.Weekday, d.Date)
-		for _, g := range d.Groups {
-			h := float64(g.Seconds) / 3600.0
-			if g.Project != "" {
-				fmt.Printf("- **%s / %s** — %.2fh\n\n  %s\n", g.Customer, g.Project, h, g.NotesMerged)
-			} else {
-				fmt.Printf("- **%s** — %.2fh\n\n  %s\n", g.Customer, h, g.NotesMerged)
-			}
-		}
-		fmt.Printf("\n")
-	}
-	fmt.Printf("\n**Wochensumme:** %.2fh\n\n", float64(weekTotal)/3600.0)
-	if len(overlaps) > 0 || len(badEntries) > 0 {
-		fmt.Println("Hinweise:")
-		for _, o := range overlaps {
-			fmt.Printf("- ! overlap: %s\n", o)
-		}
-		if len(badEntries) > 0 {
-			fmt.Printf("- Data issues (%d):\n", len(badEntries))
-			for _, be := range badEntries {
-				fmt.Printf("  - %s\n", be)
-			}
-		}
-	}
-}
-
-func writeTempoExport(path string, days []outDay, weekTotal int64, quantumSec int64, tempoRounded bool) error {
-	type tempoWL struct {
-		Date             string                 `json:"date"`
-		StartTime        string                 `json:"startTime"`
-		TimeSpentSeconds int64                  `json:"timeSpentSeconds"`
-		Description      string                 `json:"description"`
-		Attributes       map[string]interface{} `json:"attributes"`
-	}
-	var out []tempoWL
-	for _, d := range days {
-		for _, g := range d.Groups {
-			seconds := g.Seconds
-			if tempoRounded {
-				seconds = g.SecRounded
-			}
-			if seconds <= 0 {
-				continue
-			}
-			desc := g.NotesMerged
-			if len(desc) > 250 {
-				desc = desc[:250]
-			}
-			attr := map[string]interface{}{}
-			attr["customer"] = g.Customer
-			if g.Project != "" {
-				attr["project"] = g.Project
-			}
-			attr["tags"] = []string{} // tag information not preserved at this aggregated level in current implementation
-			out = append(out, tempoWL{
-				Date:             d.Date,
-				StartTime:        "09:00",
-				TimeSpentSeconds: seconds,
-				Description:      fmt.Sprintf("%s (customer: %s, project: %s)", desc, g.Customer, g.Project),
-				Attributes:       attr,
-			})
-		}
-	}
-	// ensure directory exists
+// writeTempoExport writes the Tempo worklogs of days to path: the rounded
+// seconds of each group, or the tracked seconds when raw is set.
+func writeTempoExport(path string, days []reporting.Day, raw bool) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 	w := bufio.NewWriter(f)
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(out); err != nil {
+	if err := (reporting.Tempo{Raw: raw}).Render(w, reporting.Week{Days: days}); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
 		return err
 	}
-	return w.Flush()
+	return f.Close()
 }
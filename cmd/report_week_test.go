@@ -4,8 +4,14 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
 )
 
 func TestParseISOWeek(t *testing.T) {
@@ -88,62 +94,31 @@ func TestPerEntryRoundUpTo15Minutes(t *testing.T) {
 	}
 }
 
-func TestDedupeNormalizeMergeNotes(t *testing.T) {
-	// normalizeNote
-	in := "  API  \n  scaffolding\t"
-	got := normalizeNote(in)
-	if got != "API scaffolding" {
-		t.Fatalf("normalizeNote: got %q want %q", got, "API scaffolding")
-	}
-
-	// dedupeStrings
-	arr := []string{"a", "a", "b", "", "b", "c"}
-	dd := dedupeStrings(arr)
-	if len(dd) != 3 || dd[0] != "a" || dd[1] != "b" || dd[2] != "c" {
-		t.Fatalf("dedupeStrings result unexpected: %v", dd)
-	}
-
-	// mergeNotesForDisplay with wrapping
-	notes := []string{"API scaffolding", "Standup + deploy"}
-	mergedNoWrap := mergeNotesForDisplay(notes, 0)
-	if mergedNoWrap != "API scaffolding • Standup + deploy" {
-		t.Fatalf("mergeNotesForDisplay no-wrap unexpected: %q", mergedNoWrap)
-	}
-	mergedWrap := mergeNotesForDisplay(notes, 10)
-	// wrapped output should contain either newline or still contain the bullet separator
-	if mergedWrap == "" {
-		t.Fatalf("mergeNotesForDisplay wrap returned empty string")
-	}
-	if !containsString([]string{mergedWrap}, mergedWrap) {
-		t.Fatalf("unexpected mergedWrap: %q", mergedWrap)
-	}
-}
-
 func TestWriteTempoExport_Simple(t *testing.T) {
 	tmp := t.TempDir()
 	outPath := filepath.Join(tmp, "tempo.json")
 
-	// create a sample outDay with one group
-	day := outDay{
+	// create a sample day with one group
+	day := reporting.Day{
 		Date:    "2025-10-06",
 		Weekday: "Mo",
-		Groups: []outNoteGroup{
+		Groups: []reporting.Group{
 			{
-				Customer:    "ACME",
-				Project:     "WebApp",
-				Seconds:     14400,
-				SecRounded:  14400,
-				Notes:       []string{"API scaffolding"},
-				NotesMerged: "API scaffolding",
+				Customer:       "ACME",
+				Project:        "WebApp",
+				Seconds:        14400,
+				SecondsRounded: 14400,
+				Notes:          []string{"API scaffolding"},
+				NotesMerged:    "API scaffolding",
 			},
 		},
 		DaySeconds:        14400,
 		DaySecondsRounded: 14400,
 		Flags:             nil,
 	}
-	days := []outDay{day}
+	days := []reporting.Day{day}
 
-	err := writeTempoExport(outPath, days, 14400, 600, false)
+	err := writeTempoExport(outPath, days, false)
 	if err != nil {
 		t.Fatalf("writeTempoExport failed: %v", err)
 	}
@@ -185,3 +160,157 @@ func TestWriteTempoExport_Simple(t *testing.T) {
 		}
 	}
 }
+
+func TestWeekAggregator_StreamedEntries(t *testing.T) {
+	loc := time.UTC
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, loc) }
+	end := func(t time.Time) *time.Time { return &t }
+
+	agg := newWeekAggregator(loc, rounding.Policy{QuantumSec: 15 * 60}, at(12, 12, 0))
+	// Entries arrive per day file, so a later-written add for the 6th can come
+	// after entries of the 7th; notes must still come out chronologically.
+	stream := []Entry{
+		{ID: "b", Start: at(6, 11, 0), End: end(at(6, 11, 20)), Customer: "Acme", Notes: []string{"second"}},
+		{ID: "c", Start: at(7, 23, 0), End: end(at(8, 1, 0)), Customer: "Acme", Notes: []string{"night"}},
+		{ID: "a", Start: at(6, 9, 0), End: end(at(6, 9, 50)), Customer: "Acme", Notes: []string{"first"}},
+		{ID: "d", Start: at(6, 11, 10), End: end(at(6, 11, 40)), Customer: "Other"},
+		{ID: "r", Start: at(9, 9, 0), Customer: "Acme"},
+	}
+	for _, e := range stream {
+		if err := agg.add(e); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	days := agg.Days(at(6, 0, 0), at(12, 0, 0), "en", 0)
+	if len(days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(days))
+	}
+	mon := days[0]
+	if mon.Groups[0].Customer != "Acme" || mon.Groups[0].NotesMerged != "first • second" {
+		t.Fatalf("unexpected Monday group: %+v", mon.Groups[0])
+	}
+	// 50m -> 60m, 20m -> 30m after per-entry round-up.
+	if mon.Groups[0].Seconds != 90*60 {
+		t.Fatalf("Monday Acme seconds = %d; want %d", mon.Groups[0].Seconds, 90*60)
+	}
+	if mon.Groups[0].SecondsRaw != 70*60 {
+		t.Fatalf("Monday Acme raw seconds = %d; want %d", mon.Groups[0].SecondsRaw, 70*60)
+	}
+	rows := weekRoundingRows(days)
+	if len(rows) != 4 || rows[0] != (roundingRow{Label: "2025-10-06 Acme", Raw: 70 * 60, Rounded: 90 * 60}) {
+		t.Fatalf("rounding rows = %+v", rows)
+	}
+	if len(mon.Flags) != 1 || mon.Flags[0] != "overlap" {
+		t.Fatalf("expected overlap flag on Monday, got %v", mon.Flags)
+	}
+	// Entry c spans midnight: 1h on the 7th, 1h on the 8th.
+	if days[1].DaySeconds != 3600 || days[2].DaySeconds != 3600 {
+		t.Fatalf("midnight split = %d/%d; want 3600/3600", days[1].DaySeconds, days[2].DaySeconds)
+	}
+	if agg.Total() != 90*60+30*60+2*3600 {
+		t.Fatalf("total = %d", agg.Total())
+	}
+	if len(agg.badEntries) != 1 || agg.badEntries[0] != "r (running)" {
+		t.Fatalf("badEntries = %v", agg.badEntries)
+	}
+	if got := agg.OverlapRanges(); len(got) != 1 {
+		t.Fatalf("overlapRanges = %v", got)
+	}
+}
+
+func TestWeekAggregator_CustomerTimezone(t *testing.T) {
+	viper.Set("customers.globex.timezone", "America/New_York")
+	t.Cleanup(func() { viper.Set("customers.globex.timezone", "") })
+
+	at := func(day, h int) time.Time { return time.Date(2025, 10, day, h, 0, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	agg := newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 60}, at(12, 12))
+	// 02:00–04:00 UTC on the 7th is the evening of the 6th in New York
+	// (EDT, UTC-4); Acme stays on the report's UTC days.
+	for _, e := range []Entry{
+		{ID: "g", Start: at(7, 2), End: end(at(7, 4)), Customer: "Globex"},
+		{ID: "a", Start: at(7, 2), End: end(at(7, 3)), Customer: "Acme"},
+	} {
+		if err := agg.add(e); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	days := agg.Days(at(6, 0), at(7, 0), "en", 0)
+	if len(days) != 2 || len(days[0].Groups) != 1 || len(days[1].Groups) != 1 {
+		t.Fatalf("days = %+v", days)
+	}
+	g := days[0].Groups[0]
+	if g.Customer != "Globex" || g.Seconds != 2*3600 || g.Timezone != "America/New_York" {
+		t.Fatalf("Globex group = %+v", g)
+	}
+	if a := days[1].Groups[0]; a.Customer != "Acme" || a.Timezone != "" {
+		t.Fatalf("Acme group = %+v", a)
+	}
+	// Overlaps are still checked on the report's days.
+	if len(days[1].Flags) != 1 || days[1].Flags[0] != "overlap" {
+		t.Fatalf("flags = %v; want overlap on the 7th", days[1].Flags)
+	}
+
+	// A customer day outside the range is added rather than dropped.
+	agg = newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 60}, at(12, 12))
+	_ = agg.add(Entry{ID: "g", Start: at(7, 2), End: end(at(7, 4)), Customer: "Globex"})
+	days = agg.Days(at(7, 0), at(7, 0), "en", 0)
+	if len(days) != 2 || days[0].Date != "2025-10-06" || days[0].DaySeconds != 2*3600 {
+		t.Fatalf("days = %+v", days)
+	}
+}
+
+func TestWeekAggregator_Precision(t *testing.T) {
+	t.Cleanup(func() { viper.Set("precision", "") })
+	at := func(h, m, sec int) time.Time { return time.Date(2025, 10, 6, h, m, sec, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{ID: "a", Start: at(9, 0, 0), End: end(at(9, 2, 40)), Customer: "Acme"},
+		{ID: "b", Start: at(10, 0, 0), End: end(at(10, 0, 45)), Customer: "Acme"},
+	}
+	for _, c := range []struct {
+		precision string
+		raw       int64
+	}{{"minutes", 120}, {"seconds", 205}} {
+		viper.Set("precision", c.precision)
+		agg := newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 60, Level: rounding.LevelAggregate}, at(12, 0, 0))
+		for _, e := range ents {
+			_ = agg.add(e)
+		}
+		days := agg.Days(at(0, 0, 0), at(0, 0, 0), "en", 0)
+		if g := days[0].Groups; len(g) != 1 || g[0].SecondsRaw != c.raw || g[0].Seconds != (c.raw+59)/60*60 {
+			t.Fatalf("%s: groups = %+v; want raw %d", c.precision, g, c.raw)
+		}
+	}
+}
+
+func TestWeekAggregator_CustomerMinimum(t *testing.T) {
+	viper.Set("customers.acme.minimum_billable_min", 30)
+	t.Cleanup(func() { viper.Set("customers.acme.minimum_billable_min", nil) })
+
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 13, h, m, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	for _, level := range []string{rounding.LevelEntry, rounding.LevelAggregate} {
+		agg := newWeekAggregator(time.UTC, rounding.Policy{QuantumSec: 900, Level: level}, at(20, 0))
+		for _, e := range []Entry{
+			{ID: "a1", Start: at(9, 0), End: end(at(9, 5)), Customer: "Acme"},
+			{ID: "g1", Start: at(10, 0), End: end(at(10, 5)), Customer: "Globex"},
+		} {
+			if err := agg.add(e); err != nil {
+				t.Fatalf("add: %v", err)
+			}
+		}
+		days := agg.Days(at(0, 0), at(0, 0), "en", 0)
+		groups := days[0].Groups
+		if groups[0].Seconds != 1800 || !groups[0].MinimumApplied {
+			t.Errorf("%s: Acme = %ds minimum=%v; want 1800s at the minimum", level, groups[0].Seconds, groups[0].MinimumApplied)
+		}
+		if groups[1].Seconds != 900 || groups[1].MinimumApplied {
+			t.Errorf("%s: Globex = %ds minimum=%v; want 900s without minimum", level, groups[1].Seconds, groups[1].MinimumApplied)
+		}
+		if got := agg.minimumApplied(days); len(got) != 1 || !strings.Contains(got[0], "Acme") {
+			t.Errorf("%s: minimumApplied = %q; want the Acme unit", level, got)
+		}
+	}
+}
@@ -19,7 +19,10 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	if args := expandAliasShorthand(os.Args[1:]); len(args) > 0 && args[0] != os.Args[1] {
+		rootCmd.SetArgs(args)
+	}
+	cobra.CheckErr(executeCounted())
 }
 
 func init() {
@@ -52,6 +55,11 @@ func initConfig() {
 	viper.SetDefault("timezone", "Europe/Berlin")
 	// Safe read; if missing, proceed with defaults
 	_ = viper.ReadInConfig()
+	cobra.CheckErr(applyProfile())
+	initLogging()
+	if dryRun {
+		Writer = newPreviewEventWriter(os.Stdout)
+	}
 }
 
 func mustParseTimeLocal(s string) time.Time {
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// scheduleCmd runs periodic jobs in the foreground. It is meant to be kept
+// alive by a service manager (systemd user unit, launchd agent) or a tmux
+// pane; cron users can call the job commands directly instead.
+//
+// Config (~/.tt/config.yaml):
+//
+//	schedule:
+//	  daily_summary: "18:00"   # post `tt notify daily` every day at 18:00
+//	  weekly_digest: "fri 16:00" # post `tt notify weekly` every Friday at 16:00
+//	push:
+//	  retry_min: 5             # retry queued pushes (see outbox.go); 0 = off
+//	timer_alerts:
+//	  every_min: 120           # announce running timers (see timer_alerts.go)
+//	working_time:
+//	  enabled: true            # break and daily-limit reminders (see worktime.go)
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run scheduled jobs (daily summary, weekly digest, push retries, timer alerts) in the foreground",
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run configured jobs until interrupted",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		at := viper.GetString("schedule.daily_summary")
+		weekly := viper.GetString("schedule.weekly_digest")
+		retry := outboxRetryInterval()
+		alerts, rules := loadTimerAlertConfig(), loadWorktimeRules()
+		if at == "" && weekly == "" && retry <= 0 && !alerts.enabled() && !rules.Enabled() {
+			return fmt.Errorf("no jobs configured: set schedule.daily_summary (e.g. \"18:00\"), schedule.weekly_digest, push.retry_min, timer_alerts or working_time")
+		}
+		if at != "" {
+			if _, err := time.Parse("15:04", at); err != nil {
+				return fmt.Errorf("schedule.daily_summary: want HH:MM, got %q", at)
+			}
+		}
+		var digestDay time.Weekday
+		var digestAt string
+		if weekly != "" {
+			var err error
+			if digestDay, digestAt, err = parseWeeklyAt(weekly); err != nil {
+				return fmt.Errorf("schedule.weekly_digest: %v", err)
+			}
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		if retry > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "Retrying queued pushes every %s.\n", retry)
+			go runOutboxRetries(ctx, cmd.OutOrStdout(), retry)
+		}
+		if alerts.enabled() || rules.Enabled() {
+			fmt.Fprintln(cmd.OutOrStdout(), "Announcing running timers (timer_alerts) and working-time limits (working_time).")
+			go runTimerAlerts(ctx, cmd.OutOrStdout(), alerts, rules, time.Minute)
+		}
+		if weekly != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Weekly digest scheduled on %s at %s.\n", digestDay, digestAt)
+			go runDaily(ctx, digestAt, func(ctx context.Context, t time.Time) {
+				if t.Weekday() != digestDay {
+					return
+				}
+				year, week := t.ISOWeek()
+				from, to := isoWeekRange(year, week, t.Location())
+				if err := postWeeklyDigest(ctx, cmd.OutOrStdout(), from, to, false); err != nil {
+					logger.Warn("schedule: weekly digest", "err", err)
+				}
+			})
+		}
+		if at == "" {
+			<-ctx.Done()
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Daily summary scheduled at %s; next run %s.\n", at,
+			nextDailyRun(nowLocal(), at).Format("2006-01-02 15:04"))
+		return runDaily(ctx, at, func(ctx context.Context, t time.Time) {
+			if err := postDailySummary(ctx, cmd.OutOrStdout(), t, false); err != nil {
+				logger.Warn("schedule: daily summary", "err", err)
+			}
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+}
+
+// nextDailyRun returns the next occurrence of the HH:MM wall-clock time at or
+// after now, in now's location.
+func nextDailyRun(now time.Time, hhmm string) time.Time {
+	t, _ := time.Parse("15:04", hhmm)
+	next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if next.Before(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, t.Hour(), t.Minute(), 0, 0, now.Location())
+	}
+	return next
+}
+
+// runDaily calls job at HH:MM every day until ctx is canceled.
+func runDaily(ctx context.Context, hhmm string, job func(context.Context, time.Time)) error {
+	for {
+		next := nextDailyRun(nowLocal(), hhmm)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+			job(ctx, next)
+		}
+	}
+}
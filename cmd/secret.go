@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/secrets"
+)
+
+// secretsService is the keyring service name the secrets are stored under.
+const secretsService = "tt"
+
+// secretConfigKeys are the config keys holding credentials; each accepts a
+// "keyring:<name>" reference instead of the plaintext value.
+var secretConfigKeys = []string{
+	"harvest.token",
+	"redmine.api_key",
+	"invoice_ninja.token",
+	"caldav.password",
+	"issues.github.token",
+	"issues.gitlab.token",
+	"notify.matrix.token",
+	"notify.discord.webhook_url",
+	"serve.token",
+	"submit.email.password",
+}
+
+// newSecretStore is the store lookup, replaceable in tests.
+var newSecretStore = defaultSecretStore
+
+// secretCmd manages secrets in the OS keyring (or the encrypted file):
+//
+//	secrets:
+//	  backend: auto   # auto | keyring | file
+//	  file: ~/.tt/secrets.enc
+//	harvest:
+//	  token: keyring:harvest_token
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Store API tokens in the OS keyring instead of the config",
+}
+
+var secretSetCmd = &cobra.Command{
+	Use:     "set <name>",
+	Short:   "Store a secret read from stdin",
+	Example: "  tt secret set harvest_token        # then: harvest.token: keyring:harvest_token",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newSecretStore()
+		if err != nil {
+			return err
+		}
+		value, err := readSecretValue(cmd.InOrStdin(), cmd.ErrOrStderr(), args[0])
+		if err != nil {
+			return err
+		}
+		if err := store.Set(args[0], value); err != nil {
+			return err
+		}
+		cmd.Printf("Stored %s in the %s; reference it in the config as %s%s\n", args[0], store.Name(), secrets.Prefix, args[0])
+		return nil
+	},
+}
+
+var secretGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "Print a stored secret",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newSecretStore()
+		if err != nil {
+			return err
+		}
+		v, err := store.Get(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), v)
+		return nil
+	},
+}
+
+var secretRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Delete a stored secret",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newSecretStore()
+		if err != nil {
+			return err
+		}
+		if err := store.Delete(args[0]); err != nil {
+			return err
+		}
+		cmd.Printf("Removed %s from the %s\n", args[0], store.Name())
+		return nil
+	},
+}
+
+var secretCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report which credential config keys are plaintext or keyring references",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		printSecretCheck(cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(secretCmd)
+	secretCmd.AddCommand(secretSetCmd, secretGetCmd, secretRmCmd, secretCheckCmd)
+}
+
+// defaultSecretStore picks the backend from secrets.backend: the OS keyring
+// when available ("auto", the default) and otherwise the encrypted file,
+// whose passphrase comes from TT_SECRETS_PASSPHRASE.
+func defaultSecretStore() (secrets.Store, error) {
+	backend := strings.ToLower(viper.GetString("secrets.backend"))
+	if backend == "" {
+		backend = "auto"
+	}
+	switch backend {
+	case "keyring":
+		return secrets.NewKeyring(secretsService)
+	case "auto":
+		if secrets.KeyringTool() != "" {
+			return secrets.NewKeyring(secretsService)
+		}
+	case "file":
+	default:
+		return nil, fmt.Errorf("unknown secrets.backend %q (want auto, keyring or file)", backend)
+	}
+	path := viper.GetString("secrets.file")
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, ".tt", "secrets.enc")
+	} else if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[2:])
+	}
+	pass := os.Getenv("TT_SECRETS_PASSPHRASE")
+	if pass == "" {
+		return nil, fmt.Errorf("no OS keyring available; set TT_SECRETS_PASSPHRASE to use the encrypted file %s", path)
+	}
+	return secrets.NewFile(path, pass)
+}
+
+// configSecret returns the config value of key, resolving a
+// "keyring:<name>" reference. A reference that cannot be resolved yields ""
+// with a warning, so callers report the credential as missing.
+func configSecret(key string) string {
+	v := viper.GetString(key)
+	name, ok := secrets.Ref(v)
+	if !ok {
+		return v
+	}
+	store, err := newSecretStore()
+	if err == nil {
+		v, err = store.Get(name)
+	}
+	if err != nil {
+		logger.Warn(key, "err", err)
+		return ""
+	}
+	return v
+}
+
+func readSecretValue(in io.Reader, prompt io.Writer, name string) (string, error) {
+	if f, ok := in.(*os.File); ok {
+		if st, err := f.Stat(); err == nil && st.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprintf(prompt, "Value for %s: ", name)
+		}
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	value := strings.TrimRight(line, "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("empty secret")
+	}
+	return value, nil
+}
+
+func printSecretCheck(w io.Writer) {
+	for _, k := range secretConfigKeys {
+		v := viper.GetString(k)
+		switch name, ref := secrets.Ref(v); {
+		case v == "":
+			continue
+		case !ref:
+			fmt.Fprintf(w, "%-28s plaintext in config (tt secret set <name>, then %s%s)\n", k, secrets.Prefix, strings.ReplaceAll(k, ".", "_"))
+		case configSecretResolves(name):
+			fmt.Fprintf(w, "%-28s %s%s ok\n", k, secrets.Prefix, name)
+		default:
+			fmt.Fprintf(w, "%-28s %s%s MISSING\n", k, secrets.Prefix, name)
+		}
+	}
+}
+
+func configSecretResolves(name string) bool {
+	store, err := newSecretStore()
+	if err != nil {
+		return false
+	}
+	_, err = store.Get(name)
+	return err == nil
+}
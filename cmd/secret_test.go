@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/secrets"
+)
+
+func TestConfigSecretResolvesKeyringReferences(t *testing.T) {
+	store, err := secrets.NewFile(filepath.Join(t.TempDir(), "secrets.enc"), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := newSecretStore
+	newSecretStore = func() (secrets.Store, error) { return store, nil }
+	t.Cleanup(func() {
+		newSecretStore = old
+		viper.Set("harvest.token", "")
+		viper.Set("redmine.api_key", "")
+		viper.Set("serve.token", "")
+		viper.Set("submit.email.password", "")
+	})
+	if err := store.Set("harvest_token", "h-123"); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("harvest.token", "keyring:harvest_token")
+	viper.Set("redmine.api_key", "plain-key")
+	viper.Set("serve.token", "keyring:missing")
+
+	if got := configSecret("harvest.token"); got != "h-123" {
+		t.Errorf("harvest.token = %q", got)
+	}
+	if got := configSecret("redmine.api_key"); got != "plain-key" {
+		t.Errorf("plaintext value should pass through, got %q", got)
+	}
+	// the form the submit docs show
+	if err := store.Set("smtp", "mail-pw"); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("submit.email.password", "keyring:smtp")
+	if got := configSecret("submit.email.password"); got != "mail-pw" {
+		t.Errorf("submit.email.password = %q", got)
+	}
+	if got := configSecret("serve.token"); got != "" {
+		t.Errorf("unresolvable reference should be empty, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	printSecretCheck(&buf)
+	out := buf.String()
+	for _, want := range []string{"harvest.token", "keyring:harvest_token ok", "redmine.api_key", "plaintext", "keyring:missing MISSING"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("check output missing %q:\n%s", want, out)
+		}
+	}
+
+	v, err := readSecretValue(strings.NewReader("tok\n"), &buf, "x")
+	if err != nil || v != "tok" {
+		t.Errorf("readSecretValue = %q, %v", v, err)
+	}
+}
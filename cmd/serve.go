@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	serveAddr  string
+	serveToken string
+)
+
+// serveCmd runs a small read-only HTTP server over the local journal.
+//
+// Endpoints:
+//
+//	GET /calendar.ics?from=YYYY-MM-DD&to=YYYY-MM-DD&token=...
+//	    iCalendar feed of finished entries (default: the last 30 days).
+//
+// Every request must carry the configured token (serve.token or --token) so
+// the feed URL can be handed to a calendar app without exposing the journal
+// to anyone on the network.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a read-only HTTP API (iCal feed) over the journal",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := serveToken
+		if token == "" {
+			token = configSecret("serve.token")
+		}
+		if token == "" {
+			return fmt.Errorf("a token is required: set serve.token in the config or pass --token")
+		}
+		addr := serveAddr
+		if !cmd.Flags().Changed("addr") && viper.GetString("serve.addr") != "" {
+			addr = viper.GetString("serve.addr")
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Serving on http://%s/calendar.ics?token=…\n", addr)
+		srv := &http.Server{Addr: addr, Handler: newServeMux(token), ReadHeaderTimeout: 10 * time.Second}
+		return srv.ListenAndServe()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7731", "listen address (config: serve.addr)")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "access token required on every request (config: serve.token)")
+}
+
+func newServeMux(token string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/calendar.ics", requireToken(token, http.HandlerFunc(serveCalendar)))
+	return mux
+}
+
+// requireToken rejects requests whose token query parameter does not match.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.URL.Query().Get("token")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+const serveDefaultCalendarDays = 30
+
+func serveCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	now := Now().In(parserLocation())
+	from, to := now.AddDate(0, 0, -serveDefaultCalendarDays), now
+	var err error
+	if s := r.URL.Query().Get("from"); s != "" {
+		if from, err = time.ParseInLocation("2006-01-02", s, parserLocation()); err != nil {
+			http.Error(w, "invalid from: want YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := r.URL.Query().Get("to"); s != "" {
+		if to, err = time.ParseInLocation("2006-01-02", s, parserLocation()); err != nil {
+			http.Error(w, "invalid to: want YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "to is before from", http.StatusBadRequest)
+		return
+	}
+
+	ents, err := finishedEntries(from, to)
+	if err != nil {
+		logger.Warn("serve: load entries", "err", err)
+		http.Error(w, "failed to load entries", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := writeICalendar(w, "tt", ents, Now()); err != nil {
+		logger.Warn("serve: write calendar", "err", err)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	long := strings.Repeat("x", 100)
+	ents := []Entry{{
+		ID: "e1", Start: start, End: &end, Customer: "Acme", Project: "Portal", Activity: "dev",
+		Notes: []string{"fix, deploy; done", long}, Tags: []string{"ops"},
+	}}
+	var b strings.Builder
+	if err := writeICalendar(&b, "tt", ents, start); err != nil {
+		t.Fatalf("writeICalendar: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:e1\r\n",
+		"DTSTART:20251006T090000Z\r\n",
+		"DTEND:20251006T100000Z\r\n",
+		"SUMMARY:Acme / Portal (dev)\r\n",
+		`DESCRIPTION:fix\, deploy\; done\n`,
+		"CATEGORIES:ops\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line not folded (%d octets): %q", len(line), line)
+		}
+	}
+}
+
+func TestServeCalendar(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	day := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		NewStartEvent("s1", "Acme", "Portal", "dev", boolPtr(true), "", nil, day),
+		NewStopEvent("s2", day.Add(time.Hour)),
+	} {
+		if err := writeEvent(ev); err != nil {
+			t.Fatalf("writeEvent: %v", err)
+		}
+	}
+	oldNow := Now
+	Now = func() time.Time { return day.Add(2 * time.Hour) }
+	t.Cleanup(func() { Now = oldNow })
+
+	srv := httptest.NewServer(newServeMux("secret"))
+	defer srv.Close()
+
+	cases := []struct {
+		name   string
+		query  string
+		status int
+		body   string
+	}{
+		{"missing token", "from=2025-10-06&to=2025-10-06", http.StatusUnauthorized, ""},
+		{"wrong token", "token=nope", http.StatusUnauthorized, ""},
+		{"bad from", "token=secret&from=06.10.2025", http.StatusBadRequest, ""},
+		{"feed", "token=secret&from=2025-10-06&to=2025-10-06", http.StatusOK, "UID:s1\r\n"},
+		{"default window", "token=secret", http.StatusOK, "SUMMARY:Acme / Portal (dev)\r\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/calendar.ics?" + tc.query)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tc.status)
+			}
+			if tc.body == "" {
+				return
+			}
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+				t.Fatalf("content type = %q", ct)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if !strings.Contains(string(body), tc.body) {
+				t.Fatalf("body missing %q:\n%s", tc.body, body)
+			}
+		})
+	}
+}
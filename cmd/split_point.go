@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// splitPoint computes the split instant of e from after (a duration from
+// the start, e.g. 1h30m) or ratio (left/right shares, e.g. 60/40); exactly
+// one is set. The result must lie strictly inside e, as the parser requires.
+func splitPoint(e Entry, after, ratio string) (time.Time, error) {
+	if e.End == nil {
+		return time.Time{}, fmt.Errorf("entry %s is still running; stop it before splitting", e.ID)
+	}
+	var t time.Time
+	if after != "" {
+		d, err := parseDuration(after)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --after: %w", err)
+		}
+		t = e.Start.Add(d)
+	} else {
+		left, right, err := parseSplitRatio(ratio)
+		if err != nil {
+			return time.Time{}, err
+		}
+		span := e.End.Sub(e.Start)
+		t = e.Start.Add(time.Duration(float64(span) * left / (left + right))).Truncate(time.Second)
+	}
+	if !t.After(e.Start) || !t.Before(*e.End) {
+		loc := parserLocation()
+		return time.Time{}, fmt.Errorf("split point %s is outside entry %s (%s–%s)",
+			t.In(loc).Format("15:04:05"), e.ID, e.Start.In(loc).Format("15:04"), e.End.In(loc).Format("15:04"))
+	}
+	return t, nil
+}
+
+// parseSplitRatio parses "left/right" shares such as "60/40" or "2/1".
+func parseSplitRatio(s string) (float64, float64, error) {
+	l, r, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid --ratio %q: expected left/right, e.g. 60/40", s)
+	}
+	left, errL := strconv.ParseFloat(strings.TrimSpace(l), 64)
+	right, errR := strconv.ParseFloat(strings.TrimSpace(r), 64)
+	if errL != nil || errR != nil || left <= 0 || right <= 0 {
+		return 0, 0, fmt.Errorf("invalid --ratio %q: both shares must be positive numbers", s)
+	}
+	return left, right, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestSplitPoint(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	start := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2*time.Hour + 30*time.Minute)
+	e := Entry{ID: "e1", Start: start, End: &end}
+
+	for _, c := range []struct {
+		after, ratio string
+		want         string
+	}{
+		{after: "1h30m", want: "10:30:00"},
+		{after: "45", want: "09:45:00"}, // plain minutes
+		{ratio: "60/40", want: "10:30:00"},
+		{ratio: "1/2", want: "09:50:00"},
+		{ratio: "1/6", want: "09:21:25"}, // truncated to seconds
+	} {
+		got, err := splitPoint(e, c.after, c.ratio)
+		if err != nil || got.Format("15:04:05") != c.want {
+			t.Errorf("after=%q ratio=%q: %v, %v; want %s", c.after, c.ratio, got, err, c.want)
+		}
+	}
+
+	for _, c := range []struct{ after, ratio, want string }{
+		{after: "3h", want: "outside entry e1"},
+		{after: "0m", want: "outside entry e1"},
+		{after: "soon", want: "invalid --after"},
+		{ratio: "60", want: "expected left/right"},
+		{ratio: "0/100", want: "positive"},
+	} {
+		if _, err := splitPoint(e, c.after, c.ratio); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("after=%q ratio=%q: err = %v; want %q", c.after, c.ratio, err, c.want)
+		}
+	}
+
+	running := Entry{ID: "e2", Start: start}
+	if _, err := splitPoint(running, "1h", ""); err == nil || !strings.Contains(err.Error(), "running") {
+		t.Errorf("running entry: err = %v", err)
+	}
+}
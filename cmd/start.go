@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -17,10 +18,12 @@ var (
 )
 
 var startCmd = &cobra.Command{
-	Use:   "start [customer] [project]",
+	Use:   "start [customer] [project] | start <n>",
 	Short: "Start tracking time (creates a running entry)",
+	Long:  "Start tracking time. A single number starts that favorite from `tt fav`; -a and -b override its activity and billable flag.",
 	Args:  cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		args = aliasArgs(startAlias, args)
 		customer, project := "", ""
 		if len(args) > 0 {
 			customer = args[0]
@@ -28,23 +31,33 @@ var startCmd = &cobra.Command{
 		if len(args) > 1 {
 			project = args[1]
 		}
+		activity, billable := startActivity, boolPtr(startBillable)
+		if len(args) == 1 && isFavoriteNumber(args[0]) {
+			n, _ := strconv.Atoi(args[0])
+			fav, err := favoriteAt(n)
+			cobra.CheckErr(err)
+			customer, project = fav.Customer, fav.Project
+			if activity == "" {
+				activity = fav.Activity
+			}
+			if !cmd.Flags().Changed("billable") {
+				billable = boolPtr(fav.Billable)
+			}
+		} else if !cmd.Flags().Changed("billable") {
+			billable = boolPtr(billableFor(customer, project))
+		}
+		mustCheckActivity(activity)
+
 		// Determine timestamp: either provided via --at or Now provider (injected for tests).
 		// Accept flexible/relative expressions (e.g. "now-30m", "+15m", "14:30") by trying the
 		// flexible parser first (same parsing used by `add`/ParseFlexibleRange). If that fails,
 		// fall back to the legacy absolute parser.
 		ts := Now()
 		if startAt != "" {
-			// Try flexible parsing which understands durations and now-anchored forms.
-			if st, _, cons, err := ParseFlexibleRange([]string{startAt}, Now()); err == nil && cons > 0 && !st.IsZero() {
-				ts = st
-			} else {
-				// Maintain backward compatibility with existing absolute formats.
-				ts = mustParseTimeLocal(startAt)
-			}
+			ts = parseAtFlag(startAt)
 		}
 		id := IDGen()
-		billable := boolPtr(startBillable)
-		ev := NewStartEvent(id, customer, project, startActivity, billable, startNote, startTags, ts)
+		ev := NewStartEvent(id, customer, project, activity, billable, startNote, startTags, ts)
 
 		// If user provided --for, schedule an auto-stop by adding meta["auto_stop"] with RFC3339 time.
 		if startFor != "" {
@@ -71,7 +84,7 @@ var startCmd = &cobra.Command{
 			fmt.Printf("%sNOTE: a running entry was detected prior to this start (it was NOT stopped):%s\n", ansiWarn, ansiReset)
 			// Provide a concise summary of the running entry: customer/project, activity,
 			// start time and current duration.
-			durMin := int(Now().Sub(prev.Start).Minutes())
+			durMin := int(entryElapsed(*prev, Now()).Minutes())
 			fmt.Printf("%s%s / %s [%s]%s started=%s duration=%s\n",
 				ansiLabel, prev.Customer, prev.Project, prev.Activity, ansiReset,
 				formatTS(prev.Start), fmtHHMM(durMin))
@@ -89,9 +102,11 @@ var startCmd = &cobra.Command{
 
 func init() {
 	startCmd.Flags().StringVarP(&startActivity, "activity", "a", "", "activity (design, workshop, docs, travel, etc.)")
-	startCmd.Flags().BoolVarP(&startBillable, "billable", "b", true, "mark as billable (default true)")
+	startCmd.Flags().BoolVarP(&startBillable, "billable", "b", true, "mark as billable (default: billable_defaults rule, else true)")
 	startCmd.Flags().StringSliceVarP(&startTags, "tag", "t", []string{}, "add tag(s)")
 	startCmd.Flags().StringVarP(&startNote, "note", "n", "", "note for this entry")
 	startCmd.Flags().StringVar(&startAt, "at", "", "custom start time (accepts same formats as 'add', including relative expressions like 'now-30m' or '+15m')")
 	startCmd.Flags().StringVar(&startFor, "for", "", "auto-stop after duration (e.g. 25m)")
+	_ = startCmd.RegisterFlagCompletionFunc("activity", activityFlagCompletion)
+	_ = startCmd.RegisterFlagCompletionFunc("tag", tagFlagCompletion)
 }
@@ -3,12 +3,16 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"tt/internal/journal"
+	"tt/internal/targets"
 )
 
 var statusCmd = &cobra.Command{
@@ -28,9 +32,12 @@ var statusCmd = &cobra.Command{
 		fmt.Println()
 		// Active session
 		if active != nil {
-			mins := int(now.Sub(active.Start).Minutes())
+			mins := int(entryElapsed(*active, now).Minutes())
 			fmt.Println("Active session:")
 			fmt.Printf("  Started: %s  (%s elapsed)\n", active.Start.Format("2006-01-02 15:04:05"), fmtHHMM(mins))
+			if p := journal.OpenPause(active.Pauses); p != nil {
+				fmt.Printf("  Paused since %s\n", p.Start.In(parserLocation()).Format("15:04"))
+			}
 			if active.Customer != "" || active.Project != "" || active.Activity != "" {
 				fmt.Printf("  %s / %s  [%s]  billable=%v\n", active.Customer, active.Project, active.Activity, active.Billable)
 			}
@@ -69,12 +76,117 @@ var statusCmd = &cobra.Command{
 			fmt.Println("No previous closed entry found in the last 7 days.")
 		}
 		fmt.Println()
+
+		if tc := loadTargets(); tc.Enabled() {
+			// from the day before Monday, for an entry running over midnight
+			ents, err := loadEntries(weekMonday(now).AddDate(0, 0, -1), now)
+			if err != nil {
+				return err
+			}
+			printTargets(os.Stdout, targets.Measure(targetSpans(ents, now), tc, now, now.Location()), now)
+			fmt.Println()
+		}
+
+		if statusWeek {
+			monday := weekMonday(now)
+			ents, err := loadEntries(monday, now)
+			if err != nil {
+				return err
+			}
+			renderWeekGlance(os.Stdout, weekDayTotals(ents, monday, now), monday, now)
+			fmt.Println()
+		}
 		return nil
 	},
 }
 
+var statusWeek bool
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusWeek, "week", false, "append this week's daily totals (Mon…Sun) as a sparkline")
+}
+
+// sparkBlocks are the sparkline levels, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// weekMonday returns 00:00 of the Monday of t's week in t's location.
+func weekMonday(t time.Time) time.Time {
+	wd := int(t.Weekday())
+	if wd == 0 {
+		wd = 7
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()-(wd-1), 0, 0, 0, 0, t.Location())
+}
+
+// weekDayTotals returns the tracked minutes per day Mon..Sun of the week
+// starting at monday. Entries are split at local midnight; a running entry
+// counts up to now.
+func weekDayTotals(ents []Entry, monday, now time.Time) [7]int {
+	var out [7]int
+	for _, e := range ents {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		for d := 0; d < 7; d++ {
+			dayStart := monday.AddDate(0, 0, d)
+			dayEnd := dayStart.AddDate(0, 0, 1)
+			st, en := e.Start, end
+			if st.Before(dayStart) {
+				st = dayStart
+			}
+			if en.After(dayEnd) {
+				en = dayEnd
+			}
+			if en.After(st) {
+				out[d] += int(en.Sub(st).Minutes())
+			}
+		}
+	}
+	return out
+}
+
+// renderWeekGlance prints a sparkline of the week (scaled to the busiest day
+// or the daily target, whichever is larger) and the per-day totals; today is
+// marked with *.
+//
+//	Week 2025-W42  ▅▇▆▃▁··  total 28h15m
+//	  Mon 6h30m  Tue 7h45m  Wed 7h00m  Thu 5h00m  Fri* 2h00m  Sat -  Sun -
+func renderWeekGlance(w io.Writer, totals [7]int, monday, now time.Time) {
+	scale := dailyTargetMinutes()
+	sum := 0
+	for _, m := range totals {
+		sum += m
+		if m > scale {
+			scale = m
+		}
+	}
+	var spark strings.Builder
+	for _, m := range totals {
+		if m <= 0 || scale == 0 {
+			spark.WriteRune('·')
+			continue
+		}
+		i := m * (len(sparkBlocks) - 1) / scale
+		spark.WriteRune(sparkBlocks[i])
+	}
+	year, week := monday.ISOWeek()
+	fmt.Fprintf(w, "%sWeek %d-W%02d%s  %s  total %s\n", ansiHeading, year, week, ansiReset, spark.String(), fmtHHMM(sum))
+	cells := make([]string, 7)
+	for d, m := range totals {
+		day := monday.AddDate(0, 0, d)
+		name := day.Format("Mon")
+		if day.Year() == now.Year() && day.YearDay() == now.YearDay() {
+			name += "*"
+		}
+		val := "-"
+		if m > 0 {
+			val = fmtHHMM(m)
+		}
+		cells[d] = name + " " + val
+	}
+	fmt.Fprintln(w, "  "+strings.Join(cells, "  "))
 }
 
 // findActiveAndLast reconstructs entries from journal events between from..to (inclusive).
@@ -86,6 +198,8 @@ func findActiveAndLast(from, to time.Time) (*Entry, *Entry, error) {
 	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
 	to = time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, to.Location())
 
+	// In a shared journal only the current user's events make up "my" timer.
+	me := currentUser()
 	var events []Event
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
 		p := journalPathFor(d)
@@ -99,11 +213,12 @@ func findActiveAndLast(from, to time.Time) (*Entry, *Entry, error) {
 				continue
 			}
 			var ev Event
-			if err := json.Unmarshal([]byte(line), &ev); err == nil {
+			if err := json.Unmarshal([]byte(line), &ev); err == nil && (ev.User == "" || ev.User == me) {
 				events = append(events, ev)
 			}
 		}
 	}
+	events = dropUndone(events)
 	// sort events by timestamp
 	sort.Slice(events, func(i, j int) bool { return events[i].TS.Before(events[j].TS) })
 
@@ -118,6 +233,7 @@ func findActiveAndLast(from, to time.Time) (*Entry, *Entry, error) {
 				// close previous
 				end := ev.TS
 				current.End = &end
+				journal.EndPause(current.Pauses, ev.TS)
 				// copy to lastClosed
 				cpy := *current
 				lastClosed = &cpy
@@ -144,10 +260,19 @@ func findActiveAndLast(from, to time.Time) (*Entry, *Entry, error) {
 			if current != nil {
 				current.Notes = append(current.Notes, ev.Note)
 			}
+		case "pause":
+			if current != nil && journal.OpenPause(current.Pauses) == nil {
+				current.Pauses = append(current.Pauses, journal.Interval{Start: ev.TS})
+			}
+		case "resume":
+			if current != nil {
+				journal.EndPause(current.Pauses, ev.TS)
+			}
 		case "stop":
 			if current != nil {
 				end := ev.TS
 				current.End = &end
+				journal.EndPause(current.Pauses, ev.TS)
 				cpy := *current
 				lastClosed = &cpy
 				current = nil
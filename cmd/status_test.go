@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWeekGlance(t *testing.T) {
+	viper.Set("targets.daily_hours", 8)
+	oldHeading, oldReset := ansiHeading, ansiReset
+	ansiHeading, ansiReset = "", ""
+	t.Cleanup(func() { ansiHeading, ansiReset = oldHeading, oldReset })
+
+	now := time.Date(2025, 10, 17, 11, 0, 0, 0, time.UTC) // Friday
+	monday := weekMonday(now)
+	if !monday.Equal(time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("weekMonday = %v", monday)
+	}
+	if sun := weekMonday(time.Date(2025, 10, 19, 23, 0, 0, 0, time.UTC)); !sun.Equal(monday) {
+		t.Fatalf("Sunday belongs to the same week, got %v", sun)
+	}
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{Start: monday.Add(9 * time.Hour), End: end(monday.Add(17 * time.Hour))},                                   // Mon 8h
+		{Start: monday.Add(-time.Hour), End: end(monday.Add(time.Hour))},                                           // previous Sunday: 1h on Mon
+		{Start: monday.AddDate(0, 0, 2).Add(22 * time.Hour), End: end(monday.AddDate(0, 0, 3).Add(2 * time.Hour))}, // Wed 2h, Thu 2h
+		{Start: now.Add(-90 * time.Minute)},                                                                        // running: Fri 1h30m
+	}
+	totals := weekDayTotals(ents, monday, now)
+	if want := [7]int{540, 0, 120, 120, 90, 0, 0}; totals != want {
+		t.Fatalf("totals = %v, want %v", totals, want)
+	}
+
+	var buf bytes.Buffer
+	renderWeekGlance(&buf, totals, monday, now)
+	want := "Week 2025-W42  █·▂▂▂··  total 14h30m\n" +
+		"  Mon 9h00m  Tue -  Wed 2h00m  Thu 2h00m  Fri* 1h30m  Sat -  Sun -\n"
+	if buf.String() != want {
+		t.Errorf("glance =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
@@ -17,13 +17,7 @@ var stopCmd = &cobra.Command{
 
 		// Allow overriding via --at (supports same flexible formats as start/add).
 		if stopAt != "" {
-			// Try flexible parsing which understands durations and now-anchored forms.
-			if st, _, cons, err := ParseFlexibleRange([]string{stopAt}, Now()); err == nil && cons > 0 && !st.IsZero() {
-				ts = st
-			} else {
-				// Maintain backward compatibility with existing absolute formats.
-				ts = mustParseTimeLocal(stopAt)
-			}
+			ts = parseAtFlag(stopAt)
 		}
 
 		// Attempt to reconstruct the running entry that was active at ts so we can
@@ -39,6 +33,14 @@ var stopCmd = &cobra.Command{
 
 		// Print a detailed summary of what was stopped (or note that none was found).
 		fmt.Println(FormatStopResultFromEntry(running, ts))
+
+		if stopFeedbackEnabled() && running != nil {
+			if sum, err := buildStopSummary(running.ID, ts); err == nil {
+				for _, l := range sum.lines() {
+					fmt.Println("  " + l)
+				}
+			}
+		}
 	},
 }
 
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+)
+
+var (
+	submitDestination string
+	submitForce       bool
+)
+
+// submitCmd groups the submission workflows.
+var submitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Check, report, push and mark a period as submitted",
+}
+
+// submitWeekCmd replaces the weekly routine of verifying the journal,
+// checking the report, pushing it and remembering that it was sent.
+//
+// Config (~/.tt/config.yaml):
+//
+//	submit:
+//	  destination: harvest         # harvest | redmine | tempo | email
+//	  tempo_dir: ~/.tt/submissions # tempo: where <week>-tempo.json is written
+//	  email:
+//	    smtp: smtp.example.org:587
+//	    from: me@example.org
+//	    to: [office@example.org]
+//	    username: me               # optional; PLAIN auth
+//	    password: keyring:smtp     # or TT_SMTP_PASSWORD in the environment
+//
+// harvest and redmine use their push configuration (see tt push).
+var submitWeekCmd = &cobra.Command{
+	Use:   "week [YYYY-Www]",
+	Short: "Verify, lint, report and push an ISO week, then record it as submitted",
+	Long: `Submit week runs the weekly submission in one go and stops at the first
+failing step:
+
+  1. verify the hash chain of the week's journal files (as tt audit verify),
+  2. lint: running entries fail, overlaps and bad entries are listed,
+     billable groups without notes follow lint.missing_notes,
+  3. print the week report,
+  4. push to submit.destination (or --to): harvest, redmine, tempo or email,
+  5. append a "submitted" marker event for the week.
+
+A week that already has a marker is refused unless --force is given. With
+the global --dry-run the push only lists what it would send and the marker
+is printed instead of written. The week defaults to the current ISO week.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loc := parserLocation()
+		year, week := Now().In(loc).ISOWeek()
+		if len(args) == 1 {
+			y, w, err := parseISOWeek(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid week %q: %v", args[0], err)
+			}
+			year, week = y, w
+		}
+		dest := submitDestination
+		if dest == "" {
+			dest = viper.GetString("submit.destination")
+		}
+		from, to := isoWeekRange(year, week, loc)
+		return submitWeek(cmd.Context(), cmd.OutOrStdout(), from, to, dest, submitForce, dryRun)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(submitCmd)
+	submitCmd.AddCommand(submitWeekCmd)
+	submitWeekCmd.Flags().StringVar(&submitDestination, "to", "", "destination: harvest|redmine|tempo|email (default: submit.destination)")
+	submitWeekCmd.Flags().BoolVar(&submitForce, "force", false, "submit again although the week is already marked as submitted")
+}
+
+// submitSendMail sends the email destination's message; tests replace it.
+var submitSendMail = smtp.SendMail
+
+// submitWeek runs the submission steps for the week from..to (Monday 00:00 to
+// Sunday 23:59:59) and writes progress to w.
+func submitWeek(ctx context.Context, w io.Writer, from, to time.Time, dest string, force, dry bool) error {
+	label := fmtWeekLabel(from, to)
+	push, err := submitPusher(dest)
+	if err != nil {
+		return err
+	}
+	if prev, err := findSubmission(label, from); err != nil {
+		return err
+	} else if prev != nil && !force {
+		return fmt.Errorf("%s was already submitted to %s on %s (event %s); pass --force to submit again",
+			label, prev.Meta["destination"], prev.TS.In(from.Location()).Format("2006-01-02 15:04"), prev.ID)
+	}
+
+	fmt.Fprintf(w, "1/5 Verify %s journal files\n", label)
+	var failed []string
+	for _, path := range journalPaths(from, to) {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		var diag bytes.Buffer
+		if !verifyDay(path, &diag) {
+			io.Copy(w, &diag)
+			failed = append(failed, filepath.Base(path))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("hash chain broken in %s (see tt audit verify / tt audit repair)", strings.Join(failed, ", "))
+	}
+	fmt.Fprintln(w, "  OK")
+
+	fmt.Fprintln(w, "2/5 Lint")
+	policy := getRounding().Policy()
+	agg := newWeekAggregator(from.Location(), policy, Now().UTC())
+	agg.open = openError
+	if err := streamEntries(from, to, agg.add); err != nil {
+		return err
+	}
+	if len(agg.openIDs) > 0 {
+		return fmt.Errorf("running entries in %s: %s (stop them first)", label, strings.Join(agg.openIDs, ", "))
+	}
+	if agg.matched == 0 {
+		return fmt.Errorf("no entries in %s", label)
+	}
+	days := agg.Days(from, to, "de", 80) // the tt report week defaults
+	issues := reporting.Issues{
+		Overlaps:       agg.OverlapRanges(),
+		BadEntries:     agg.badEntries,
+		MissingNotes:   weekMissingNotes(days),
+		MinimumApplied: agg.minimumApplied(days),
+	}
+	for _, o := range issues.Overlaps {
+		fmt.Fprintf(w, "  warning: overlap %s\n", o)
+	}
+	for _, b := range issues.BadEntries {
+		fmt.Fprintf(w, "  warning: bad entry %s\n", b)
+	}
+	if err := checkMissingNotes(w, "submit", issues.MissingNotes); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "  OK")
+
+	fmt.Fprintln(w, "3/5 Report")
+	var weekTotal int64
+	for _, d := range days {
+		weekTotal += d.DaySeconds
+	}
+	doc := newWeekReportDoc(from, to, from.Location().String(), days, weekTotal, agg.RawTotal(), openEntries{}, policy, issues)
+	numbers, err := reportLocale("")
+	if err != nil {
+		return err
+	}
+	renderer, err := reporting.New("table", reporting.Options{Locale: numbers})
+	if err != nil {
+		return err
+	}
+	var report bytes.Buffer
+	if err := renderer.Render(&report, doc); err != nil {
+		return err
+	}
+	w.Write(report.Bytes())
+
+	fmt.Fprintf(w, "4/5 Push to %s\n", dest)
+	if err := push(ctx, w, submission{Label: label, From: from, To: to, Days: days, Report: report.String()}, dry); err != nil {
+		return fmt.Errorf("push to %s: %w", dest, err)
+	}
+
+	fmt.Fprintln(w, "5/5 Mark submitted")
+	ev := Event{ID: IDGen(), Type: "submitted", TS: Now(), Meta: map[string]string{
+		"period":      label,
+		"from":        from.Format("2006-01-02"),
+		"to":          to.Format("2006-01-02"),
+		"destination": dest,
+		"seconds":     strconv.FormatInt(weekTotal, 10),
+	}}
+	if err := writeEvent(ev); err != nil {
+		return fmt.Errorf("failed to write submitted event: %w", err)
+	}
+	fmt.Fprintf(w, "Submitted %s (%s) to %s.\n", label, fmtDuration(time.Duration(weekTotal)*time.Second), dest)
+	return nil
+}
+
+// submission is what a destination sends.
+type submission struct {
+	Label    string
+	From, To time.Time
+	Days     []reporting.Day
+	Report   string // the rendered table report
+}
+
+type submitPush func(ctx context.Context, w io.Writer, s submission, dry bool) error
+
+// submitPusher returns the push step of dest, checking its configuration
+// before anything else runs.
+func submitPusher(dest string) (submitPush, error) {
+	switch dest {
+	case "harvest":
+		cfg, err := loadHarvestConfig()
+		if err != nil {
+			return nil, err
+		}
+		if cfg.AccountID == "" || cfg.Token == "" {
+			return nil, fmt.Errorf("harvest.account_id and harvest.token (or HARVEST_ACCESS_TOKEN) must be configured")
+		}
+		return func(ctx context.Context, w io.Writer, s submission, dry bool) error {
+			ents, err := finishedEntries(s.From, s.To)
+			if err != nil {
+				return err
+			}
+			return pushHarvest(ctx, w, cfg, ents, s.From, s.To, dry)
+		}, nil
+	case "redmine":
+		cfg, err := loadRedmineConfig()
+		if err != nil {
+			return nil, err
+		}
+		if cfg.URL == "" || cfg.APIKey == "" {
+			return nil, fmt.Errorf("redmine.url and redmine.api_key (or REDMINE_API_KEY) must be configured")
+		}
+		return func(ctx context.Context, w io.Writer, s submission, dry bool) error {
+			ents, err := finishedEntries(s.From, s.To)
+			if err != nil {
+				return err
+			}
+			return pushRedmine(ctx, w, cfg, ents, s.From, s.To, dry)
+		}, nil
+	case "tempo":
+		return submitTempo, nil
+	case "email":
+		return submitEmail, nil
+	case "":
+		return nil, errors.New("no destination: set submit.destination or pass --to harvest|redmine|tempo|email")
+	}
+	return nil, fmt.Errorf("unknown destination %q (want harvest|redmine|tempo|email)", dest)
+}
+
+// submitTempo writes the Tempo worklogs of the week to
+// submit.tempo_dir/<week>-tempo.json.
+func submitTempo(_ context.Context, w io.Writer, s submission, dry bool) error {
+	dir := viper.GetString("submit.tempo_dir")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(home, ".tt", "submissions")
+	}
+	dir = expandHome(dir)
+	path := filepath.Join(dir, s.Label+"-tempo.json")
+	if dry {
+		fmt.Fprintf(w, "  would write %s\n", path)
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := writeTempoExport(path, s.Days, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  wrote %s\n", path)
+	return nil
+}
+
+// submitEmail mails the table report to submit.email.to.
+func submitEmail(_ context.Context, w io.Writer, s submission, dry bool) error {
+	addr := viper.GetString("submit.email.smtp")
+	from := viper.GetString("submit.email.from")
+	to := viper.GetStringSlice("submit.email.to")
+	if addr == "" || from == "" || len(to) == 0 {
+		return errors.New("submit.email.smtp, submit.email.from and submit.email.to must be configured")
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: Time report %s\r\n", from, strings.Join(to, ", "), s.Label)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(s.Report, "\n", "\r\n"))
+	if dry {
+		fmt.Fprintf(w, "  would mail the report to %s via %s\n", strings.Join(to, ", "), addr)
+		return nil
+	}
+
+	if err := submitSendMail(addr, smtpAuth(addr), from, to, msg.Bytes()); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  mailed the report to %s\n", strings.Join(to, ", "))
+	return nil
+}
+
+// smtpAuth is PLAIN auth for submit.email.username on the server addr, or
+// nil without a username.
+func smtpAuth(addr string) smtp.Auth {
+	user := viper.GetString("submit.email.username")
+	if user == "" {
+		return nil
+	}
+	pass := configSecret("submit.email.password")
+	if pass == "" {
+		pass = os.Getenv("TT_SMTP_PASSWORD")
+	}
+	host, _, _ := strings.Cut(addr, ":")
+	return smtp.PlainAuth("", user, pass, host)
+}
+
+// mailNotice mails a notify message to "to" through the submit.email SMTP
+// server; the subject is the message's first line.
+func mailNotice(to []string, text string) error {
+	addr := viper.GetString("submit.email.smtp")
+	from := viper.GetString("submit.email.from")
+	if addr == "" || from == "" {
+		return errors.New("notify.email needs submit.email.smtp and submit.email.from")
+	}
+	subject, _, _ := strings.Cut(text, "\n")
+	subject = strings.ReplaceAll(subject, "**", "")
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\n", from, strings.Join(to, ", "), subject)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(text, "\n", "\r\n"))
+	return submitSendMail(addr, smtpAuth(addr), from, to, msg.Bytes())
+}
+
+// findSubmission returns the latest "submitted" marker for period, looking
+// at the day files from the period's start to today.
+func findSubmission(period string, since time.Time) (*Event, error) {
+	var found *Event
+	err := newEventTail(since).read(journalPaths(since, Now().In(parserLocation())), func(_ []byte, ev Event) {
+		if ev.Type == "submitted" && ev.Meta["period"] == period {
+			e := ev
+			found = &e
+		}
+	})
+	return found, err
+}
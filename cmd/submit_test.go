@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func setupSubmitWeek(t *testing.T) (time.Time, time.Time) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Set("timezone", "UTC")
+	oldNow, oldID := Now, IDGen
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("submit", nil)
+		Now, IDGen = oldNow, oldID
+	})
+	from, to := isoWeekRange(2025, 41, time.UTC)
+	Now = func() time.Time { return to.Add(time.Hour) }
+	n := 0
+	IDGen = func() string { n++; return fmt.Sprintf("s%d", n) }
+	mon := from.Add(9 * time.Hour)
+	if err := writeEvents([]Event{
+		NewStartEvent("a1", "Acme", "Web", "", boolPtr(true), "API work", nil, mon),
+		NewStopEvent("a2", mon.Add(2*time.Hour)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return from, to
+}
+
+func TestSubmitWeekTempo(t *testing.T) {
+	from, to := setupSubmitWeek(t)
+	dir := t.TempDir()
+	viper.Set("submit.tempo_dir", dir)
+
+	var out bytes.Buffer
+	if err := submitWeek(context.Background(), &out, from, to, "tempo", false, false); err != nil {
+		t.Fatalf("submit: %v\n%s", err, out.String())
+	}
+	for _, want := range []string{"1/5 Verify 2025-W41", "Acme", "Submitted 2025-W41 (2h00m) to tempo."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2025-W41-tempo.json")); err != nil {
+		t.Errorf("tempo export: %v", err)
+	}
+
+	prev, err := findSubmission("2025-W41", from)
+	if err != nil || prev == nil {
+		t.Fatalf("marker = %v, %v; want the submitted event", prev, err)
+	}
+	if prev.Meta["destination"] != "tempo" || prev.Meta["seconds"] != "7200" {
+		t.Errorf("marker meta = %v", prev.Meta)
+	}
+
+	err = submitWeek(context.Background(), &out, from, to, "tempo", false, false)
+	if err == nil || !strings.Contains(err.Error(), "already submitted") {
+		t.Fatalf("second submit err = %v; want already submitted", err)
+	}
+	if err := submitWeek(context.Background(), &out, from, to, "tempo", true, false); err != nil {
+		t.Fatalf("--force: %v", err)
+	}
+}
+
+func TestSubmitWeekRefusesRunningEntries(t *testing.T) {
+	from, to := setupSubmitWeek(t)
+	if err := writeEvent(NewStartEvent("r1", "Acme", "Web", "", nil, "", nil, from.Add(30*time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err := submitWeek(context.Background(), &out, from, to, "tempo", false, false)
+	if err == nil || !strings.Contains(err.Error(), "running entries") {
+		t.Fatalf("err = %v; want running entries", err)
+	}
+	if prev, _ := findSubmission("2025-W41", from); prev != nil {
+		t.Errorf("failed submission wrote a marker: %+v", prev)
+	}
+}
+
+func TestSubmitWeekEmail(t *testing.T) {
+	from, to := setupSubmitWeek(t)
+	viper.Set("submit.email.smtp", "smtp.example.org:587")
+	viper.Set("submit.email.from", "me@example.org")
+	viper.Set("submit.email.to", []string{"office@example.org"})
+	old := submitSendMail
+	t.Cleanup(func() { submitSendMail = old })
+	var sent string
+	submitSendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		sent = fmt.Sprintf("%s %s %v\n%s", addr, from, to, msg)
+		return nil
+	}
+
+	var out bytes.Buffer
+	if err := submitWeek(context.Background(), &out, from, to, "email", false, false); err != nil {
+		t.Fatalf("submit: %v\n%s", err, out.String())
+	}
+	for _, want := range []string{"smtp.example.org:587 me@example.org [office@example.org]", "Subject: Time report 2025-W41", "Acme"} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("mail lacks %q:\n%s", want, sent)
+		}
+	}
+}
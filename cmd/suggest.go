@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/suggest"
+)
+
+var (
+	suggestAt    string
+	suggestWhy   bool
+	suggestLimit int
+)
+
+// suggestCmd ranks likely combos for a moment from time-of-day and weekday
+// patterns in the history (see internal/suggest).
+//
+// Config (~/.tt/config.yaml), shared with tt fav and the TUI suggestions:
+//
+//	suggest:
+//	  lookback_days: 30            # history window (default 30)
+//	  recency_half_life_days: 7    # age at which recency counts half (default 7)
+//	  rank:                        # tt fav and the TUI (default: frequency only)
+//	    frequency: 1
+//	    recency: 0.5
+//	    weekday: 0
+//	    time_of_day: 0
+//	  score:                       # tt suggest (defaults below)
+//	    frequency: 0.35
+//	    recency: 0.15
+//	    time_of_day: 0.30
+//	    weekday: 0.20
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest likely customer/project/activity combos for a time",
+	Example: `  tt suggest
+  tt suggest --at "14:00 tuesday" --why`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		at, err := parseSuggestAt(suggestAt)
+		if err != nil {
+			return err
+		}
+		cfg := suggestConfig()
+		ents, err := loadEntries(at.Add(-cfg.Lookback), Now())
+		if err != nil {
+			return err
+		}
+		decisions := loadCompletionDecisions()
+		uses := make([]suggest.Use, 0, len(ents))
+		for _, e := range ents {
+			if e.Start.After(at) || decisions.isCustomerArchived(e.Customer) {
+				continue
+			}
+			uses = append(uses, suggest.Use{
+				Combo: suggest.Combo{Customer: e.Customer, Project: e.Project, Activity: e.Activity, Billable: e.Billable},
+				Start: e.Start,
+			})
+		}
+		printSuggestions(cmd.OutOrStdout(), cfg.Score(uses, at), at, suggestLimit, suggestWhy)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+	suggestCmd.Flags().StringVar(&suggestAt, "at", "", `moment to suggest for, e.g. "14:00 tuesday" (default now)`)
+	suggestCmd.Flags().BoolVar(&suggestWhy, "why", false, "explain the ranking")
+	suggestCmd.Flags().IntVarP(&suggestLimit, "limit", "n", 5, "number of suggestions")
+}
+
+// suggestConfig reads the suggest.* config over suggest.DefaultConfig; unset
+// keys keep their defaults.
+func suggestConfig() suggest.Config {
+	cfg := suggest.DefaultConfig()
+	if days := viper.GetInt("suggest.lookback_days"); days > 0 {
+		cfg.Lookback = time.Duration(days) * 24 * time.Hour
+	}
+	if days := viper.GetFloat64("suggest.recency_half_life_days"); days > 0 {
+		cfg.RecencyHalfLife = time.Duration(days * float64(24*time.Hour))
+	}
+	weights := func(prefix string, w *suggest.Weights) {
+		for key, f := range map[string]*float64{
+			"frequency":   &w.Frequency,
+			"recency":     &w.Recency,
+			"time_of_day": &w.TimeOfDay,
+			"weekday":     &w.Weekday,
+		} {
+			if k := prefix + "." + key; viper.IsSet(k) {
+				*f = max(0, viper.GetFloat64(k))
+			}
+		}
+	}
+	weights("suggest.rank", &cfg.RankWeights)
+	weights("suggest.score", &cfg.ScoreWeights)
+	return cfg
+}
+
+// parseSuggestAt accepts a day (weekday, date word or date) and a time of
+// day in either order: "14:00 tuesday", "tuesday 14:00", "14:00". A weekday
+// means its most recent occurrence; a missing part defaults to now's.
+func parseSuggestAt(s string) (time.Time, error) {
+	loc := parserLocation()
+	anchor := Now().In(loc)
+	var rest []string
+	for _, t := range strings.Fields(s) {
+		if !looksLikeDateWord(t) && !looksLikeDate(t) {
+			rest = append(rest, t)
+			continue
+		}
+		var day time.Time
+		var err error
+		if looksLikeDateWord(t) {
+			day, err = resolveDateWord(t, anchor, loc)
+		} else {
+			day, err = mustParseTimeFlexible(t, loc)
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --at: %w", err)
+		}
+		anchor = time.Date(day.Year(), day.Month(), day.Day(), anchor.Hour(), anchor.Minute(), 0, 0, loc)
+	}
+	if len(rest) == 0 {
+		return anchor, nil
+	}
+	st, _, _, err := ParseFlexibleRange(rest, anchor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --at: %w", err)
+	}
+	return st, nil
+}
+
+func printSuggestions(w io.Writer, list []suggest.Scored, at time.Time, limit int, why bool) {
+	if len(list) == 0 {
+		fmt.Fprintln(w, "No suggestions (nothing tracked in the last 30 days).")
+		return
+	}
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	fmt.Fprintf(w, "%sSuggestions for %s:%s\n", ansiHeading, at.Format("Mon 2006-01-02 15:04"), ansiReset)
+	for i, s := range list {
+		fmt.Fprintf(w, "%2d  %s / %s [%s]  score %.2f\n", i+1, s.Customer, s.Project, s.Activity, s.Score)
+		if !why {
+			continue
+		}
+		for _, c := range s.Components {
+			fmt.Fprintf(w, "      %-11s %.2f × %.2f  %s\n", c.Name, c.Value, c.Weight, c.Reason)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/suggest"
+)
+
+func TestParseSuggestAt(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	now := time.Date(2025, 10, 16, 10, 0, 0, 0, time.UTC) // Thursday
+	oldNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() { Now = oldNow })
+
+	tuesday2pm := time.Date(2025, 10, 14, 14, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"", now},
+		{"14:00 tuesday", tuesday2pm},
+		{"tuesday 14:00", tuesday2pm},
+		{"14:00", time.Date(2025, 10, 16, 14, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		got, err := parseSuggestAt(tc.in)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("parseSuggestAt(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+	if _, err := parseSuggestAt("teatime"); err == nil {
+		t.Error("want error for unparsable --at")
+	}
+}
+
+func TestPrintSuggestionsWhy(t *testing.T) {
+	at := time.Date(2025, 10, 14, 14, 0, 0, 0, time.UTC)
+	uses := []suggest.Use{
+		{Combo: suggest.Combo{Customer: "Acme", Project: "Portal", Activity: "dev"}, Start: at.AddDate(0, 0, -7)},
+	}
+	var buf bytes.Buffer
+	printSuggestions(&buf, suggest.Score(uses, at), at, 5, true)
+	out := buf.String()
+	for _, want := range []string{"1  Acme / Portal [dev]", "time-of-day", "1 of 1 uses on a Tuesday", "last used 7d ago"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestSuggestConfig(t *testing.T) {
+	viper.Set("suggest.lookback_days", 90)
+	viper.Set("suggest.recency_half_life_days", 3)
+	viper.Set("suggest.rank.recency", 0.5)
+	viper.Set("suggest.score.weekday", 0)
+	viper.Set("suggest.score.time_of_day", -1) // negative weights count as 0
+	t.Cleanup(func() { viper.Set("suggest", nil) })
+
+	cfg := suggestConfig()
+	def := suggest.DefaultConfig()
+	if cfg.Lookback != 90*24*time.Hour || cfg.RecencyHalfLife != 72*time.Hour {
+		t.Fatalf("windows = %v, %v", cfg.Lookback, cfg.RecencyHalfLife)
+	}
+	if want := (suggest.Weights{Frequency: 1, Recency: 0.5}); cfg.RankWeights != want {
+		t.Fatalf("rank weights = %+v; want %+v", cfg.RankWeights, want)
+	}
+	if want := (suggest.Weights{Frequency: def.ScoreWeights.Frequency, Recency: def.ScoreWeights.Recency}); cfg.ScoreWeights != want {
+		t.Fatalf("score weights = %+v; want %+v", cfg.ScoreWeights, want)
+	}
+
+	viper.Set("suggest", nil)
+	if got := suggestConfig(); got != def {
+		t.Fatalf("unset config = %+v; want the defaults", got)
+	}
+}
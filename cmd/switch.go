@@ -19,17 +19,16 @@ var switchCmd = &cobra.Command{
 	Short: "Stop current and immediately start a new entry",
 	Args:  cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		// Check the activity before anything is stopped.
+		mustCheckActivity(switchActivity)
+
 		// Determine timestamp: either provided via --at or Now provider (injected for tests).
 		// Accept flexible/relative expressions (e.g. "now-30m", "+15m", "14:30") by trying the
 		// flexible parser first (same parsing used by `add`/ParseFlexibleRange). If that fails,
 		// fall back to the legacy absolute parser.
 		ts := Now()
 		if switchAt != "" {
-			if st, _, cons, err := ParseFlexibleRange([]string{switchAt}, Now()); err == nil && cons > 0 && !st.IsZero() {
-				ts = st
-			} else {
-				ts = mustParseTimeLocal(switchAt)
-			}
+			ts = parseAtFlag(switchAt)
 		}
 
 		// Reconstruct the running entry at the switch time so we can report what was stopped.
@@ -43,6 +42,7 @@ var switchCmd = &cobra.Command{
 		}
 
 		// start
+		args = aliasArgs(switchAlias, args)
 		customer, project := "", ""
 		if len(args) > 0 {
 			customer = args[0]
@@ -52,6 +52,9 @@ var switchCmd = &cobra.Command{
 		}
 		id := IDGen()
 		billable := boolPtr(switchBillable)
+		if !cmd.Flags().Changed("billable") {
+			billable = boolPtr(billableFor(customer, project))
+		}
 		ev := NewStartEvent(id, customer, project, switchActivity, billable, switchNote, switchTags, ts)
 		if err := Writer.WriteEvent(ev); err != nil {
 			cobra.CheckErr(err)
@@ -64,8 +67,10 @@ var switchCmd = &cobra.Command{
 
 func init() {
 	switchCmd.Flags().StringVarP(&switchActivity, "activity", "a", "", "activity for new entry")
-	switchCmd.Flags().BoolVarP(&switchBillable, "billable", "b", true, "mark as billable (default true)")
+	switchCmd.Flags().BoolVarP(&switchBillable, "billable", "b", true, "mark as billable (default: billable_defaults rule, else true)")
 	switchCmd.Flags().StringSliceVarP(&switchTags, "tag", "t", []string{}, "add tag(s)")
 	switchCmd.Flags().StringVarP(&switchNote, "note", "n", "", "note for new entry")
 	switchCmd.Flags().StringVar(&switchAt, "at", "", "custom switch time (accepts same formats as 'add', including relative expressions like 'now-30m' or '+15m')")
+	_ = switchCmd.RegisterFlagCompletionFunc("activity", activityFlagCompletion)
+	_ = switchCmd.RegisterFlagCompletionFunc("tag", tagFlagCompletion)
 }
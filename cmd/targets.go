@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/reporting"
+	"tt/internal/targets"
+)
+
+// Hour targets: a daily and a weekly target overall and per customer. tt
+// status shows today's and this week's progress, tt report week the week's
+// (and days past their daily target), the TUI dashboard a progress section;
+// overtime is shown in the warning colour.
+//
+// Config (~/.tt/config.yaml):
+//
+//	targets:
+//	  daily_hours: 8     # default 8; 0 disables it
+//	  weekly_hours: 40
+//	  customers:
+//	    acme:
+//	      weekly_hours: 16
+//	      daily_hours: 4
+
+func loadTargets() targets.Config {
+	c := targets.Config{Goal: targets.Goal{
+		Daily:  time.Duration(dailyTargetMinutes()) * time.Minute,
+		Weekly: hoursSetting("targets.weekly_hours"),
+	}}
+	for name := range viper.GetStringMap("targets.customers") {
+		g := targets.Goal{
+			Daily:  hoursSetting("targets.customers." + name + ".daily_hours"),
+			Weekly: hoursSetting("targets.customers." + name + ".weekly_hours"),
+		}
+		if g.Daily <= 0 && g.Weekly <= 0 {
+			continue
+		}
+		if c.Customers == nil {
+			c.Customers = map[string]targets.Goal{}
+		}
+		c.Customers[name] = g
+	}
+	return c
+}
+
+// hoursSetting is a config value in hours as a duration; unset or negative
+// is 0.
+func hoursSetting(key string) time.Duration {
+	h := viper.GetFloat64(key)
+	if h <= 0 {
+		return 0
+	}
+	return time.Duration(h * float64(time.Hour))
+}
+
+// targetSpans are the work periods of ents by canonical customer; pauses
+// are left out and running entries end at now.
+func targetSpans(ents []Entry, now time.Time) []targets.Span {
+	var out []targets.Span
+	for _, e := range ents {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		customer := CanonicalCustomer(e.Customer)
+		for _, s := range entryWorkSpans(e, end) {
+			out = append(out, targets.Span{Customer: customer, Start: s.Start, End: s.End})
+		}
+	}
+	return out
+}
+
+// printTargets lists progress towards the targets, one line each with a
+// bar; targets in overtime are shown in the warning colour.
+//
+//	Targets:
+//	  today        ████████░░░░  5h20m of 8h, 2h40m left
+//	  this week    ████████████  42h of 40h, 2h overtime
+func printTargets(w io.Writer, progress []targets.Progress, now time.Time) {
+	if len(progress) == 0 {
+		return
+	}
+	width := 0
+	for _, p := range progress {
+		width = max(width, len(p.Label(now)))
+	}
+	fmt.Fprintf(w, "%sTargets:%s\n", ansiHeading, ansiReset)
+	for _, p := range progress {
+		color := ansiHours
+		if p.Overtime() > 0 {
+			color = ansiWarn
+		}
+		fmt.Fprintf(w, "  %-*s  %s%s%s  %s\n", width, p.Label(now), color, p.Bar(12), ansiReset, p.Describe())
+	}
+}
+
+// weekTargets is the progress in from..to for tt report week: each day with
+// a daily target, then each ISO week lying wholly within the range with a
+// weekly one (partial weeks were not loaded in full).
+func weekTargets(spans []targets.Span, c targets.Config, from, to time.Time, loc *time.Location) []reporting.Target {
+	if !c.Enabled() {
+		return nil
+	}
+	var days, weeks []reporting.Target
+	from = from.In(loc)
+	for d := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !d.After(to); d = d.AddDate(0, 0, 1) {
+		for _, p := range targets.Measure(spans, c, d, loc) {
+			if p.Period == targets.Week && (!p.Start.Equal(d) || p.Start.AddDate(0, 0, 7).After(to.Add(time.Second))) {
+				continue
+			}
+			t := reporting.Target{
+				Customer:        p.Customer,
+				Period:          string(p.Period),
+				Date:            p.Start.Format("2006-01-02"),
+				TargetSeconds:   int64(p.Target / time.Second),
+				Seconds:         int64(p.Worked / time.Second),
+				OvertimeSeconds: int64(p.Overtime() / time.Second),
+			}
+			if p.Period == targets.Week {
+				weeks = append(weeks, t)
+			} else {
+				days = append(days, t)
+			}
+		}
+	}
+	return append(weeks, days...)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+	"tt/internal/targets"
+)
+
+func TestTargetsConfigAndWeekProgress(t *testing.T) {
+	viper.Set("targets", map[string]any{
+		"daily_hours":  6,
+		"weekly_hours": 12,
+		"customers": map[string]any{
+			"acme":   map[string]any{"weekly_hours": 4.5},
+			"globex": map[string]any{"daily_hours": 0},
+		},
+	})
+	oldHours, oldWarn, oldHeading, oldReset := ansiHours, ansiWarn, ansiHeading, ansiReset
+	ansiHours, ansiWarn, ansiHeading, ansiReset = "", "", "", ""
+	t.Cleanup(func() {
+		viper.Set("targets", nil)
+		ansiHours, ansiWarn, ansiHeading, ansiReset = oldHours, oldWarn, oldHeading, oldReset
+	})
+	c := loadTargets()
+	if c.Daily != 6*time.Hour || c.Weekly != 12*time.Hour || len(c.Customers) != 1 || c.Customers["acme"].Weekly != 4*time.Hour+30*time.Minute {
+		t.Fatalf("targets = %+v", c)
+	}
+
+	monday := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	at := func(day, h int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(h) * time.Hour) }
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{Customer: "Acme", Start: at(0, 8), End: end(at(0, 15)),
+			Pauses: []journal.Interval{{Start: at(0, 12), End: end(at(0, 13))}}}, // Mon 6h
+		{Customer: "Globex", Start: at(1, 9), End: end(at(1, 13))}, // Tue 4h
+		{Customer: "Globex", Start: at(2, 9)},                      // Wed, running: 3h at noon
+	}
+	now := at(2, 12)
+	spans := targetSpans(ents, now)
+
+	var buf bytes.Buffer
+	printTargets(&buf, targets.Measure(spans, c, now, time.UTC), now)
+	want := "Targets:\n" +
+		"  today           ██████░░░░░░  3h of 6h, 3h left\n" +
+		"  this week       ████████████  13h of 12h, 1h overtime\n" +
+		"  acme this week  ████████████  6h of 4h30m, 1h30m overtime\n"
+	if buf.String() != want {
+		t.Errorf("status targets =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// tt report week: the whole week, and each day; finished entries only
+	// unless running ones are included.
+	got := weekTargets(targetSpans(ents[:2], now), c, monday, at(6, 24).Add(-time.Second), time.UTC)
+	var lines []string
+	for _, tg := range got {
+		lines = append(lines, tg.Label()+" "+fmtSecHHMM(tg.Seconds)+"/"+fmtSecHHMM(tg.TargetSeconds)+" +"+fmtSecHHMM(tg.OvertimeSeconds))
+	}
+	wantLines := []string{
+		"week 2025-W41 10h00m/12h00m +0m",
+		"acme week 2025-W41 6h00m/4h30m +1h30m",
+		"2025-10-06 6h00m/6h00m +0m",
+		"2025-10-07 4h00m/6h00m +0m",
+	}
+	if len(lines) != 9 || strings.Join(lines[:4], "\n") != strings.Join(wantLines, "\n") {
+		t.Errorf("week targets =\n%s", strings.Join(lines, "\n"))
+	}
+	// a range that does not cover a whole week reports no weekly progress
+	if got := weekTargets(spans, c, at(1, 0), at(2, 24).Add(-time.Second), time.UTC); len(got) != 2 || got[0].Period != "day" {
+		t.Errorf("partial week targets = %+v", got)
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Taskwarrior linkage. Entries tracked for a Taskwarrior task carry the tag
+// task:<UUID>. The installed on-modify hook starts a tt entry when a task is
+// started (`task 12 start`) and stops it when the task is stopped or done.
+//
+// Task projects map onto tt as customer.project ("acme.portal" → customer
+// acme, project portal); tasks without a project use taskwarrior.customer.
+
+const taskTagPrefix = "task:"
+
+var (
+	taskHooksDir   string
+	taskHooksForce bool
+)
+
+var importTaskHooksCmd = &cobra.Command{
+	Use:   "task-hooks",
+	Short: "Taskwarrior hooks that start/stop tt with tasks",
+}
+
+var importTaskHooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the Taskwarrior on-modify hook",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := taskHooksDir
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+			dir = filepath.Join(home, ".task", "hooks")
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		path, err := installTaskHook(dir, exe, taskHooksForce)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Installed %s\n", path)
+		return nil
+	},
+}
+
+// importTaskHooksOnModifyCmd is invoked by the installed hook script with the
+// original and modified task as two JSON lines on stdin.
+var importTaskHooksOnModifyCmd = &cobra.Command{
+	Use:    "on-modify",
+	Short:  "Taskwarrior on-modify hook entry point",
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTaskOnModify(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importTaskHooksCmd)
+	importTaskHooksCmd.AddCommand(importTaskHooksInstallCmd, importTaskHooksOnModifyCmd)
+	importTaskHooksInstallCmd.Flags().StringVar(&taskHooksDir, "hooks-dir", "", "Taskwarrior hooks directory (default ~/.task/hooks)")
+	importTaskHooksInstallCmd.Flags().BoolVar(&taskHooksForce, "force", false, "overwrite an existing hook script")
+}
+
+const taskHookName = "on-modify.tt"
+
+// installTaskHook writes an executable hook script forwarding to exe.
+func installTaskHook(dir, exe string, force bool) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, taskHookName)
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	script := "#!/bin/sh\n" +
+		"# Installed by `tt import task-hooks install`: start/stop tt with Taskwarrior tasks.\n" +
+		"exec " + shellQuote(exe) + " import task-hooks on-modify\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// taskwarriorTask holds the task fields tt looks at.
+type taskwarriorTask struct {
+	UUID        string `json:"uuid"`
+	Description string `json:"description"`
+	Project     string `json:"project"`
+	Status      string `json:"status"`
+	Start       string `json:"start"`
+}
+
+type taskHookAction int
+
+const (
+	taskHookNone taskHookAction = iota
+	taskHookStart
+	taskHookStop
+)
+
+// taskHookActionFor derives what tt should do for a task modification.
+func taskHookActionFor(orig, mod taskwarriorTask) taskHookAction {
+	running := mod.Start != "" && mod.Status == "pending"
+	switch {
+	case running && orig.Start == "":
+		return taskHookStart
+	case orig.Start != "" && !running:
+		return taskHookStop
+	}
+	return taskHookNone
+}
+
+// runTaskOnModify implements the hook protocol: echo the modified task back
+// unchanged, then print feedback lines. It never rejects the modification;
+// tt failures are reported as feedback instead.
+func runTaskOnModify(in io.Reader, out io.Writer) error {
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	var lines []string
+	for len(lines) < 2 && sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) < 2 {
+		return fmt.Errorf("on-modify hook expects two JSON lines on stdin")
+	}
+	fmt.Fprintln(out, lines[1])
+
+	var orig, mod taskwarriorTask
+	if err := json.Unmarshal([]byte(lines[0]), &orig); err != nil {
+		fmt.Fprintf(out, "tt: cannot parse task: %v\n", err)
+		return nil
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &mod); err != nil {
+		fmt.Fprintf(out, "tt: cannot parse task: %v\n", err)
+		return nil
+	}
+	if msg, err := applyTaskHook(taskHookActionFor(orig, mod), mod); err != nil {
+		fmt.Fprintf(out, "tt: %v\n", err)
+	} else if msg != "" {
+		fmt.Fprintf(out, "tt: %s\n", msg)
+	}
+	return nil
+}
+
+func applyTaskHook(action taskHookAction, task taskwarriorTask) (string, error) {
+	if action == taskHookNone {
+		return "", nil
+	}
+	tag := taskTagPrefix + task.UUID
+	ts := Now()
+	running, _ := LastOpenEntryAt(ts)
+
+	if action == taskHookStop {
+		if running == nil || !hasTag(running.Tags, tag) {
+			return "", nil
+		}
+		if err := writeEvent(NewStopEvent(IDGen(), ts)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("stopped %s / %s", running.Customer, running.Project), nil
+	}
+
+	customer, project := taskCustomerProject(task.Project)
+	if customer == "" {
+		return "", fmt.Errorf("task has no project and taskwarrior.customer is not set; not tracking")
+	}
+	var evs []Event
+	if running != nil {
+		evs = append(evs, NewStopEvent(IDGen(), ts))
+	}
+	evs = append(evs, NewStartEvent(IDGen(), customer, project, viper.GetString("taskwarrior.activity"),
+		boolPtr(true), task.Description, []string{tag}, ts))
+	if err := writeEvents(evs); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("started %s / %s", customer, project), nil
+}
+
+// taskCustomerProject splits a Taskwarrior project into customer and project.
+func taskCustomerProject(p string) (string, string) {
+	if p == "" {
+		return viper.GetString("taskwarrior.customer"), ""
+	}
+	customer, project, _ := strings.Cut(p, ".")
+	return customer, project
+}
+
+// taskUUIDFromTags returns the UUID of the first task:<UUID> tag, if any.
+func taskUUIDFromTags(tags []string) string {
+	for _, t := range tags {
+		if strings.HasPrefix(t, taskTagPrefix) {
+			return strings.TrimPrefix(t, taskTagPrefix)
+		}
+	}
+	return ""
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// taskDescription looks up a task's description via the task CLI. It is a
+// variable so tests can avoid depending on an installed Taskwarrior.
+var taskDescription = func(uuid string) (string, error) {
+	out, err := exec.Command("task", "rc.hooks=off", "rc.verbose=nothing", "_get", uuid+".description").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestTaskHookActionFor(t *testing.T) {
+	cases := []struct {
+		name      string
+		orig, mod taskwarriorTask
+		want      taskHookAction
+	}{
+		{"start", taskwarriorTask{Status: "pending"}, taskwarriorTask{Status: "pending", Start: "20251006T090000Z"}, taskHookStart},
+		{"stop", taskwarriorTask{Status: "pending", Start: "x"}, taskwarriorTask{Status: "pending"}, taskHookStop},
+		{"done while running", taskwarriorTask{Status: "pending", Start: "x"}, taskwarriorTask{Status: "completed", Start: "x"}, taskHookStop},
+		{"edit while running", taskwarriorTask{Status: "pending", Start: "x"}, taskwarriorTask{Status: "pending", Start: "x"}, taskHookNone},
+		{"edit idle", taskwarriorTask{Status: "pending"}, taskwarriorTask{Status: "pending"}, taskHookNone},
+	}
+	for _, tc := range cases {
+		if got := taskHookActionFor(tc.orig, tc.mod); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestInstallTaskHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	path, err := installTaskHook(dir, "/opt/my tt/tt", false)
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read hook: %v", err)
+	}
+	if !strings.Contains(string(b), "exec '/opt/my tt/tt' import task-hooks on-modify") {
+		t.Fatalf("unexpected hook script:\n%s", b)
+	}
+	if info, _ := os.Stat(path); info.Mode()&0o100 == 0 {
+		t.Fatalf("hook not executable: %v", info.Mode())
+	}
+	if _, err := installTaskHook(dir, "/usr/bin/tt", false); err == nil {
+		t.Fatal("expected error when hook exists without --force")
+	}
+	if _, err := installTaskHook(dir, "/usr/bin/tt", true); err != nil {
+		t.Fatalf("force install: %v", err)
+	}
+}
+
+func TestTaskOnModifyStartsAndStops(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	oldNow, oldID := Now, IDGen
+	n := 0
+	Now = func() time.Time { return now }
+	IDGen = func() string { n++; return fmt.Sprintf("id%d", n) }
+	t.Cleanup(func() { Now, IDGen = oldNow, oldID })
+
+	idle := `{"uuid":"u-1","description":"Write docs","project":"acme.portal","status":"pending"}`
+	started := `{"uuid":"u-1","description":"Write docs","project":"acme.portal","status":"pending","start":"20251006T090000Z"}`
+
+	var out bytes.Buffer
+	if err := runTaskOnModify(strings.NewReader(idle+"\n"+started+"\n"), &out); err != nil {
+		t.Fatalf("start hook: %v", err)
+	}
+	if lines := strings.Split(out.String(), "\n"); lines[0] != started || !strings.Contains(lines[1], "started acme / portal") {
+		t.Fatalf("unexpected hook output:\n%s", out.String())
+	}
+
+	now = now.Add(45 * time.Minute)
+	out.Reset()
+	if err := runTaskOnModify(strings.NewReader(started+"\n"+idle+"\n"), &out); err != nil {
+		t.Fatalf("stop hook: %v", err)
+	}
+	ents, err := loadEntries(now, now)
+	if err != nil {
+		t.Fatalf("loadEntries: %v", err)
+	}
+	if len(ents) != 1 || ents[0].End == nil || durationMinutes(ents[0]) != 45 ||
+		taskUUIDFromTags(ents[0].Tags) != "u-1" || ents[0].Notes[0] != "Write docs" {
+		t.Fatalf("unexpected entries: %+v", ents)
+	}
+}
+
+func TestPrintTaskReport(t *testing.T) {
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	mk := func(tag string, min int, note string) Entry {
+		end := start.Add(time.Duration(min) * time.Minute)
+		return Entry{Start: start, End: &end, Customer: "acme", Project: "portal", Tags: []string{tag}, Notes: []string{note}}
+	}
+	ents := []Entry{
+		mk("task:aaaaaaaa-1111", 30, "from note"),
+		mk("task:bbbbbbbb-2222", 90, ""),
+		mk("task:aaaaaaaa-1111", 15, ""),
+		mk("other", 20, ""),
+	}
+	describe := func(uuid string) (string, error) {
+		if uuid == "bbbbbbbb-2222" {
+			return "Fix login", nil
+		}
+		return "", errors.New("task not installed")
+	}
+	var out bytes.Buffer
+	printTaskReport(&out, ents, describe)
+	got := out.String()
+	for _, want := range []string{
+		"   1h30m  bbbbbbbb  Fix login  (acme / portal)",
+		"     45m  aaaaaaaa  from note  (acme / portal)",
+		"TOTAL: 2h15m across 2 task(s); untagged: 20m",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "bbbbbbbb") > strings.Index(got, "aaaaaaaa") {
+		t.Errorf("tasks not sorted by time:\n%s", got)
+	}
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	templateDate  string
+	templateForce bool
+	templateYes   bool
+)
+
+// templateCmd manages day templates: a named set of entries (times of day
+// plus customer/project/activity) captured from one day and replayed as add
+// events on another. Templates live in the config:
+//
+//	templates:
+//	  standard-friday:
+//	    - {start: "09:00", end: "12:00", customer: acme, project: portal, activity: dev, billable: true}
+//	    - {start: "13:00", end: "15:30", customer: acme, project: ops, activity: support, billable: true}
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Save a day's entries as a template and apply it to other days",
+}
+
+var templateSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the finished entries of a day (default today) as a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		day, err := templateDay()
+		if err != nil {
+			return err
+		}
+		if viper.IsSet(templateKey(name)) && !templateForce {
+			return fmt.Errorf("template %q exists; use --force to overwrite", name)
+		}
+		ents, err := finishedEntries(day, day)
+		if err != nil {
+			return err
+		}
+		items := templateFromEntries(ents)
+		if len(items) == 0 {
+			return fmt.Errorf("no finished entries on %s", day.Format("2006-01-02"))
+		}
+		if err := saveTemplate(name, items); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Saved template %q with %d entries from %s.\n", name, len(items), day.Format("2006-01-02"))
+		return nil
+	},
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Create a template's entries on a day (default today)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		items, err := loadTemplate(name)
+		if err != nil {
+			return err
+		}
+		day, err := templateDay()
+		if err != nil {
+			return err
+		}
+		evs, err := templateEvents(items, day)
+		if err != nil {
+			return err
+		}
+		existing, _ := loadEntries(day, day)
+		return applyTemplate(cmd.InOrStdin(), cmd.OutOrStdout(), name, day, evs, existing, templateYes)
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved templates",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names := make([]string, 0)
+		for name := range viper.GetStringMap("templates") {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No templates.")
+			return
+		}
+		for _, name := range names {
+			items, _ := loadTemplate(name)
+			total := 0
+			for _, it := range items {
+				total += it.minutes()
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%-20s  %d entries  %s\n", name, len(items), fmtHHMM(total))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateSaveCmd, templateApplyCmd, templateListCmd)
+	templateCmd.PersistentFlags().StringVar(&templateDate, "date", "", "day to save from / apply to (YYYY-MM-DD, today, yesterday, weekday)")
+	templateSaveCmd.Flags().BoolVar(&templateForce, "force", false, "overwrite an existing template")
+	templateApplyCmd.Flags().BoolVarP(&templateYes, "yes", "y", false, "do not ask for confirmation")
+}
+
+// templateItem is one entry of a day template. Times are local HH:MM.
+type templateItem struct {
+	Start    string   `mapstructure:"start" yaml:"start"`
+	End      string   `mapstructure:"end" yaml:"end"`
+	Customer string   `mapstructure:"customer" yaml:"customer,omitempty"`
+	Project  string   `mapstructure:"project" yaml:"project,omitempty"`
+	Activity string   `mapstructure:"activity" yaml:"activity,omitempty"`
+	Billable bool     `mapstructure:"billable" yaml:"billable"`
+	Note     string   `mapstructure:"note" yaml:"note,omitempty"`
+	Tags     []string `mapstructure:"tags" yaml:"tags,omitempty"`
+}
+
+func (it templateItem) minutes() int {
+	st, err1 := time.Parse("15:04", it.Start)
+	en, err2 := time.Parse("15:04", it.End)
+	if err1 != nil || err2 != nil || !en.After(st) {
+		return 0
+	}
+	return int(en.Sub(st) / time.Minute)
+}
+
+func templateKey(name string) string { return "templates." + name }
+
+// templateDay resolves --date (default today) to midnight in the parser
+// location.
+func templateDay() (time.Time, error) {
+	loc := parserLocation()
+	now := Now().In(loc)
+	if templateDate == "" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), nil
+	}
+	if looksLikeDateWord(templateDate) {
+		return resolveDateWord(templateDate, now, loc)
+	}
+	d, err := time.ParseInLocation("2006-01-02", templateDate, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q: want YYYY-MM-DD, today, yesterday or a weekday", templateDate)
+	}
+	return d, nil
+}
+
+// templateFromEntries turns a day's entries into template items. Entries
+// crossing midnight are cut at the end of their start day.
+func templateFromEntries(ents []Entry) []templateItem {
+	loc := parserLocation()
+	items := make([]templateItem, 0, len(ents))
+	for _, e := range ents {
+		if e.End == nil {
+			continue
+		}
+		st, en := e.Start.In(loc), e.End.In(loc)
+		if en.YearDay() != st.YearDay() || en.Year() != st.Year() {
+			en = time.Date(st.Year(), st.Month(), st.Day(), 23, 59, 0, 0, loc)
+		}
+		items = append(items, templateItem{
+			Start:    st.Format("15:04"),
+			End:      en.Format("15:04"),
+			Customer: e.Customer,
+			Project:  e.Project,
+			Activity: e.Activity,
+			Billable: e.Billable,
+			Note:     strings.Join(e.Notes, "; "),
+			Tags:     e.Tags,
+		})
+	}
+	return items
+}
+
+func saveTemplate(name string, items []templateItem) error {
+	// store plain maps so the YAML config stays readable
+	raw := make([]map[string]interface{}, 0, len(items))
+	for _, it := range items {
+		m := map[string]interface{}{"start": it.Start, "end": it.End, "billable": it.Billable}
+		for k, v := range map[string]string{"customer": it.Customer, "project": it.Project, "activity": it.Activity, "note": it.Note} {
+			if v != "" {
+				m[k] = v
+			}
+		}
+		if len(it.Tags) > 0 {
+			m["tags"] = it.Tags
+		}
+		raw = append(raw, m)
+	}
+	viper.Set(templateKey(name), raw)
+	return saveViperConfig()
+}
+
+func loadTemplate(name string) ([]templateItem, error) {
+	if !viper.IsSet(templateKey(name)) {
+		return nil, fmt.Errorf("template %q not found (see tt template list)", name)
+	}
+	var items []templateItem
+	if err := viper.UnmarshalKey(templateKey(name), &items); err != nil {
+		return nil, fmt.Errorf("template %q: %w", name, err)
+	}
+	return items, nil
+}
+
+// templateEvents builds one add event per item on day. The events are filed
+// under day (TS = entry start) so range reads of that day pick them up.
+func templateEvents(items []templateItem, day time.Time) ([]Event, error) {
+	loc := day.Location()
+	at := func(hhmm string) (time.Time, error) {
+		t, err := time.Parse("15:04", hhmm)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid template time %q: want HH:MM", hhmm)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
+	}
+	evs := make([]Event, 0, len(items))
+	for _, it := range items {
+		st, err := at(it.Start)
+		if err != nil {
+			return nil, err
+		}
+		en, err := at(it.End)
+		if err != nil {
+			return nil, err
+		}
+		if !en.After(st) {
+			return nil, fmt.Errorf("template entry %s-%s: end must be after start", it.Start, it.End)
+		}
+		ev := NewAddEvent(IDGen(), it.Customer, it.Project, it.Activity, boolPtr(it.Billable), it.Note, it.Tags, st, en)
+		ev.TS = st
+		evs = append(evs, ev)
+	}
+	return evs, nil
+}
+
+// applyTemplate shows the planned entries, warns about overlaps with
+// existing ones and writes the events after confirmation (or with yes).
+func applyTemplate(in io.Reader, out io.Writer, name string, day time.Time, evs []Event, existing []Entry, yes bool) error {
+	fmt.Fprintf(out, "Template %q on %s:\n", name, day.Format("Mon 2006-01-02"))
+	overlaps := 0
+	for _, ev := range evs {
+		st, en := addEventRange(ev)
+		fmt.Fprintf(out, "  %s-%s  %s / %s [%s]\n", st.Format("15:04"), en.Format("15:04"), ev.Customer, ev.Project, ev.Activity)
+		for _, e := range existing {
+			if e.Start.Before(en) && (e.End == nil || e.End.After(st)) {
+				overlaps++
+				break
+			}
+		}
+	}
+	if overlaps > 0 {
+		fmt.Fprintf(out, "Warning: %d of these overlap entries already on %s.\n", overlaps, day.Format("2006-01-02"))
+	}
+	if !yes {
+		fmt.Fprintf(out, "Create %d entries? (yes/no): ", len(evs))
+		resp, _ := bufio.NewReader(in).ReadString('\n')
+		resp = strings.TrimSpace(strings.ToLower(resp))
+		if resp != "y" && resp != "yes" {
+			fmt.Fprintln(out, "Aborted by user.")
+			return nil
+		}
+	}
+	if err := writeEvents(evs); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Created %d entries.\n", len(evs))
+	return nil
+}
+
+// addEventRange returns the start/end encoded in an add event's ref.
+func addEventRange(ev Event) (time.Time, time.Time) {
+	a, b, _ := strings.Cut(ev.Ref, "..")
+	st, _ := time.Parse(time.RFC3339, a)
+	en, _ := time.Parse(time.RFC3339, b)
+	loc := parserLocation()
+	return st.In(loc), en.In(loc)
+}
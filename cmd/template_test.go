@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestTemplateSaveAndApply(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", ""); viper.Set("templates", nil) })
+
+	src := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Time, h, m int) time.Time {
+		return d.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	if err := writeEvents([]Event{
+		NewStartEvent("s1", "Acme", "Portal", "dev", boolPtr(true), "ticket=ABC-1", []string{"focus"}, at(src, 9, 0)),
+		NewStopEvent("s2", at(src, 12, 0)),
+		NewStartEvent("s3", "Acme", "Ops", "support", boolPtr(false), "", nil, at(src, 13, 0)),
+		NewStopEvent("s4", at(src, 15, 30)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ents, err := finishedEntries(src, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := saveTemplate("standard-friday", templateFromEntries(ents)); err != nil {
+		t.Fatal(err)
+	}
+	items, err := loadTemplate("standard-friday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Start != "09:00" || items[1].End != "15:30" || !items[0].Billable || items[1].Billable {
+		t.Fatalf("saved template = %+v", items)
+	}
+
+	dst := time.Date(2025, 10, 17, 0, 0, 0, 0, time.UTC)
+	evs, err := templateEvents(items, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := applyTemplate(strings.NewReader("no\n"), &out, "standard-friday", dst, evs, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := loadEntries(dst, dst); len(got) != 0 || !strings.Contains(out.String(), "Aborted") {
+		t.Fatalf("declined apply wrote %d entries:\n%s", len(got), out.String())
+	}
+
+	out.Reset()
+	if err := applyTemplate(strings.NewReader("yes\n"), &out, "standard-friday", dst, evs, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadEntries(dst, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 entries on %s, got %+v", dst.Format("2006-01-02"), got)
+	}
+	if !got[0].Start.Equal(at(dst, 9, 0)) || !got[1].End.Equal(at(dst, 15, 30)) {
+		t.Errorf("applied times = %v-%v, %v-%v", got[0].Start, got[0].End, got[1].Start, got[1].End)
+	}
+	if got[0].Fields["ticket"] != "ABC-1" || len(got[0].Tags) != 1 {
+		t.Errorf("note/tags not carried over: %+v", got[0])
+	}
+
+	// applying again warns about the overlap
+	out.Reset()
+	evs, _ = templateEvents(items, dst)
+	if err := applyTemplate(strings.NewReader(""), &out, "standard-friday", dst, evs, got, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Warning: 2 of these overlap") {
+		t.Errorf("missing overlap warning:\n%s", out.String())
+	}
+}
+
+func TestTemplateEventsRejectsBadTimes(t *testing.T) {
+	day := time.Date(2025, 10, 17, 0, 0, 0, 0, time.UTC)
+	for _, it := range []templateItem{
+		{Start: "9", End: "12:00"},
+		{Start: "12:00", End: "09:00"},
+	} {
+		if _, err := templateEvents([]templateItem{it}, day); err == nil {
+			t.Errorf("templateEvents(%+v) = nil error", it)
+		}
+	}
+}
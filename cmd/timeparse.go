@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
@@ -22,6 +24,8 @@ import (
 //   ParseFlexibleRange([]string{"9-12"}, Now())
 //   ParseFlexibleRange([]string{"13:00", "+45m"}, Now())
 //   ParseFlexibleRange([]string{"now-30m"}, Now())
+//   ParseFlexibleRange([]string{"lastweek"}, Now())     // also week:2025-W41, lastmonth, q3
+//   ParseFlexibleRange([]string{"yesterday", "workday"}, Now()) // shorthands from config
 //
 // Returned `consumed` is how many tokens were consumed from the input slice to form the range.
 // Callers can use it to advance through positional args (e.g., customer/project following the range).
@@ -29,7 +33,18 @@ import (
 // ParseFlexibleRange tries to construct a start and end time from a slice of command-line tokens.
 // It accepts a variety of patterns described above. The `anchor` parameter is used as the reference
 // time (usually Now()) when resolving "today"/"yesterday"/weekday/durations without explicit date.
+//
+// Errors are *TimeParseError values carrying the offending token and, where a
+// small rewrite of the input parses, "did you mean" suggestions.
 func ParseFlexibleRange(tokens []string, anchor time.Time) (start time.Time, end time.Time, consumed int, err error) {
+	start, end, consumed, err = parseFlexibleRange(tokens, anchor)
+	if err != nil && len(tokens) > 0 {
+		err = newTimeParseError(tokens, anchor, err)
+	}
+	return start, end, consumed, err
+}
+
+func parseFlexibleRange(tokens []string, anchor time.Time) (start time.Time, end time.Time, consumed int, err error) {
 	if len(tokens) == 0 {
 		return time.Time{}, time.Time{}, 0, fmt.Errorf("no time tokens provided")
 	}
@@ -41,6 +56,33 @@ func ParseFlexibleRange(tokens []string, anchor time.Time) (start time.Time, end
 	// helper to consume n tokens and return error-wrapped
 	retErr := func(e error) (time.Time, time.Time, int, error) { return time.Time{}, time.Time{}, 0, e }
 
+	// Shorthands from config (shorthands.workday: "09:00-17:00"), optionally
+	// preceded by a day: "workday", "yesterday workday", "2025-10-17 workday".
+	if name := strings.ToLower(tokens[0]); isShorthand(name) {
+		st, en, err := expandShorthand(name, anchor)
+		if err != nil {
+			return retErr(err)
+		}
+		return st, en, 1, nil
+	}
+	if len(tokens) >= 2 && isShorthand(strings.ToLower(tokens[1])) && (looksLikeDateWord(tokens[0]) || looksLikeDate(tokens[0])) {
+		var day time.Time
+		var err error
+		if looksLikeDateWord(tokens[0]) {
+			day, err = resolveDateWord(tokens[0], anchor, loc)
+		} else {
+			day, err = mustParseTimeFlexible(tokens[0], loc)
+		}
+		if err != nil {
+			return retErr(err)
+		}
+		st, en, err := expandShorthand(strings.ToLower(tokens[1]), day)
+		if err != nil {
+			return retErr(err)
+		}
+		return st, en, 2, nil
+	}
+
 	// Handle single-token duration (e.g. "+30m" or "45m"). For convenience we treat a
 	// lone duration as an anchor forward relative to `anchor` (consistent with "+45m"
 	// being a time offset). This keeps behavior predictable for CLI usage.
@@ -53,6 +95,15 @@ func ParseFlexibleRange(tokens []string, anchor time.Time) (start time.Time, end
 		return anchor.Add(d), time.Time{}, 1, nil
 	}
 
+	// Pattern 0: calendar period tokens ("lastweek", "week:2025-W41", "q3", ...)
+	// covering whole days; end is midnight after the last day.
+	if st, en, ok, err := resolvePeriodToken(tokens[0], anchor, loc); ok {
+		if err != nil {
+			return retErr(err)
+		}
+		return st, en, 1, nil
+	}
+
 	// Pattern 1: single token that contains a dash (range shorthand) e.g., "9-12", "now-30m", "2h-now"
 	if strings.Contains(tokens[0], "-") {
 		s := tokens[0]
@@ -167,7 +218,7 @@ func ParseFlexibleRange(tokens []string, anchor time.Time) (start time.Time, end
 		return st, time.Time{}, 1, nil
 	}
 
-	return retErr(fmt.Errorf("unrecognized time tokens: %v", tokens))
+	return retErr(errUnrecognizedTime)
 }
 
 // mustParseTimeFlexible attempts to parse common date/time formats, using the configured timezone (loc).
@@ -204,6 +255,78 @@ func mustParseTimeFlexible(s string, loc *time.Location) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("cannot parse absolute time/date: %s", s)
 }
 
+// defaultShorthands apply unless the config defines the same name.
+var defaultShorthands = map[string]string{"workday": "09:00-17:00"}
+
+// shorthands returns the configured range shorthands (config key
+// "shorthands", name -> range expression) merged over the defaults.
+func shorthands() map[string]string {
+	out := map[string]string{}
+	for k, v := range defaultShorthands {
+		out[k] = v
+	}
+	for k, v := range viper.GetStringMapString("shorthands") {
+		out[strings.ToLower(k)] = v
+	}
+	return out
+}
+
+func isShorthand(name string) bool {
+	_, ok := shorthands()[name]
+	return ok
+}
+
+// expandShorthand parses the shorthand's range expression on day's date. The
+// expression must describe a complete range and may not use other shorthands.
+func expandShorthand(name string, day time.Time) (time.Time, time.Time, error) {
+	expr := shorthands()[name]
+	toks := strings.Fields(expr)
+	for _, t := range toks {
+		if isShorthand(strings.ToLower(t)) {
+			return time.Time{}, time.Time{}, fmt.Errorf("shorthand %q: cannot refer to shorthand %q", name, t)
+		}
+	}
+	if len(toks) == 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("shorthand %q is empty", name)
+	}
+	st, en, consumed, err := parseFlexibleRange(toks, day)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("shorthand %q (%s): %w", name, expr, err)
+	}
+	if consumed != len(toks) || en.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("shorthand %q (%s) must be a full range like 09:00-17:00", name, expr)
+	}
+	return st, en, nil
+}
+
+// parseAtFlag resolves an --at value: the flexible forms first ("now-30m",
+// "14:30", "+15m"), then the absolute formats. On failure it exits with the
+// parser's error, including its suggestions.
+func parseAtFlag(s string) time.Time {
+	st, _, cons, err := ParseFlexibleRange([]string{s}, Now())
+	if err == nil && cons > 0 && !st.IsZero() {
+		return st
+	}
+	if t, perr := mustParseTimeFlexible(s, parserLocation()); perr == nil {
+		return t
+	}
+	var pe *TimeParseError
+	if errors.As(err, &pe) {
+		// --at takes one token; multi-token rewrites do not apply
+		kept := pe.Suggestions[:0]
+		for _, sg := range pe.Suggestions {
+			if !strings.Contains(sg, " ") {
+				kept = append(kept, sg)
+			}
+		}
+		pe.Suggestions = kept
+	} else if err == nil {
+		err = fmt.Errorf("cannot parse time: %s", s)
+	}
+	cobra.CheckErr(err)
+	return time.Time{}
+}
+
 // resolveDashRangeSides takes left/right substrings (trimmed) and returns start/end.
 // Left or right may be durations, times, weekdays, "now" or empty.
 func resolveDashRangeSides(left, right string, anchor time.Time, loc *time.Location) (time.Time, time.Time, error) {
@@ -415,6 +538,23 @@ func parserLocation() *time.Location {
 	return time.Local
 }
 
+// customerLocation returns customers.<name>.timezone for customer, or def
+// when none is set or it does not load (tt doctor reports those). Reports
+// and exports bucket that customer's entries by its local dates; storage
+// stays UTC.
+func customerLocation(customer string, def *time.Location) *time.Location {
+	c := strings.ToLower(strings.TrimSpace(customer))
+	if c == "" {
+		return def
+	}
+	if tz := viper.GetString("customers." + c + ".timezone"); tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			return l
+		}
+	}
+	return def
+}
+
 // looksLikeTime returns true if the token resembles HH:MM or HH:MM:SS or H or H: suffixes.
 var timeOnlyRe = regexp.MustCompile(`^\d{1,2}(:\d{2}(:\d{2})?)?$`)
 
@@ -550,6 +690,108 @@ func resolveDateWord(s string, anchor time.Time, loc *time.Location) (time.Time,
 	}
 }
 
+//
+// Calendar periods
+//
+
+var (
+	periodWeekRe    = regexp.MustCompile(`^week:(?:(\d{4})-?)?w?(\d{1,2})$`)
+	periodMonthRe   = regexp.MustCompile(`^month:(\d{4})-(\d{1,2})$`)
+	periodQuarterRe = regexp.MustCompile(`^(?:(\d{4})-)?q([1-4])(?::(\d{4}))?$`)
+)
+
+// resolvePeriodToken resolves calendar period tokens relative to anchor:
+//
+//	thisweek, lastweek, week:2025-W41, week:41   ISO weeks (Mon..Sun)
+//	thismonth, lastmonth, month:2025-10
+//	thisquarter, lastquarter, q3, q3:2024, 2024-q3
+//	thisyear, lastyear
+//
+// start is midnight of the first day and end midnight after the last day.
+// ok reports whether s is a period token at all; err is set for tokens of
+// the right shape with out-of-range values (e.g. week:2025-W60).
+func resolvePeriodToken(s string, anchor time.Time, loc *time.Location) (start, end time.Time, ok bool, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	a := anchor.In(loc)
+	day := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, loc)
+	weekStart := func(d time.Time) time.Time {
+		wd := int(d.Weekday())
+		if wd == 0 {
+			wd = 7
+		}
+		return d.AddDate(0, 0, -(wd - 1))
+	}
+	monthStart := time.Date(a.Year(), a.Month(), 1, 0, 0, 0, 0, loc)
+	quarter := func(year, q int) (time.Time, time.Time, bool, error) {
+		st := time.Date(year, time.Month((q-1)*3+1), 1, 0, 0, 0, 0, loc)
+		return st, st.AddDate(0, 3, 0), true, nil
+	}
+	curQ := (int(a.Month())-1)/3 + 1
+
+	switch s {
+	case "thisweek":
+		st := weekStart(day)
+		return st, st.AddDate(0, 0, 7), true, nil
+	case "lastweek":
+		st := weekStart(day).AddDate(0, 0, -7)
+		return st, st.AddDate(0, 0, 7), true, nil
+	case "thismonth":
+		return monthStart, monthStart.AddDate(0, 1, 0), true, nil
+	case "lastmonth":
+		return monthStart.AddDate(0, -1, 0), monthStart, true, nil
+	case "thisquarter":
+		return quarter(a.Year(), curQ)
+	case "lastquarter":
+		if curQ == 1 {
+			return quarter(a.Year()-1, 4)
+		}
+		return quarter(a.Year(), curQ-1)
+	case "thisyear":
+		st := time.Date(a.Year(), 1, 1, 0, 0, 0, 0, loc)
+		return st, st.AddDate(1, 0, 0), true, nil
+	case "lastyear":
+		st := time.Date(a.Year()-1, 1, 1, 0, 0, 0, 0, loc)
+		return st, st.AddDate(1, 0, 0), true, nil
+	}
+
+	if m := periodWeekRe.FindStringSubmatch(s); m != nil {
+		year, _ := a.ISOWeek()
+		if m[1] != "" {
+			year, _ = strconv.Atoi(m[1])
+		}
+		week, _ := strconv.Atoi(m[2])
+		// ISO years have 52 or 53 weeks; Dec 28 is always in the last one.
+		if _, last := time.Date(year, 12, 28, 0, 0, 0, 0, loc).ISOWeek(); week < 1 || week > last {
+			return time.Time{}, time.Time{}, true, fmt.Errorf("week %d out of range for %d (1..%d)", week, year, last)
+		}
+		st, _ := isoWeekRange(year, week, loc)
+		return st, st.AddDate(0, 0, 7), true, nil
+	}
+	if m := periodMonthRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, true, fmt.Errorf("month %d out of range (1..12)", month)
+		}
+		st := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+		return st, st.AddDate(0, 1, 0), true, nil
+	}
+	if m := periodQuarterRe.FindStringSubmatch(s); m != nil {
+		if m[1] != "" && m[3] != "" {
+			return time.Time{}, time.Time{}, true, fmt.Errorf("quarter %q names the year twice", s)
+		}
+		year := a.Year()
+		for _, y := range []string{m[1], m[3]} {
+			if y != "" {
+				year, _ = strconv.Atoi(y)
+			}
+		}
+		q, _ := strconv.Atoi(m[2])
+		return quarter(year, q)
+	}
+	return time.Time{}, time.Time{}, false, nil
+}
+
 // weekdayFromString returns *time.Weekday for strings like "mon", "monday", "tue", etc.
 // Returns nil when unrecognized.
 func weekdayFromString(s string) *time.Weekday {
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errUnrecognizedTime is the cause when no pattern matches the tokens at all.
+var errUnrecognizedTime = errors.New("not a recognized time, range or date")
+
+// errInvalidEndTime is the cause when `tt add <start> <end>` has a bad end.
+var errInvalidEndTime = errors.New("not a valid end time")
+
+// TimeParseError is returned by ParseFlexibleRange. Token is the first token
+// the parser could not make sense of; Suggestions are rewrites of the input
+// that do parse, ready to paste back into the command line.
+type TimeParseError struct {
+	Token       string
+	Tokens      []string
+	Err         error
+	Suggestions []string
+}
+
+func (e *TimeParseError) Error() string {
+	msg := fmt.Sprintf("cannot parse %q: %v", e.Token, e.Err)
+	if len(e.Suggestions) == 0 {
+		return msg
+	}
+	quoted := make([]string, len(e.Suggestions))
+	for i, s := range e.Suggestions {
+		quoted[i] = strconv.Quote(s)
+	}
+	return msg + "; did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+func (e *TimeParseError) Unwrap() error { return e.Err }
+
+// maxTimeSuggestions keeps the error line readable.
+const maxTimeSuggestions = 3
+
+func newTimeParseError(tokens []string, anchor time.Time, err error) *TimeParseError {
+	tok := tokens[0]
+	if errors.Is(err, errUnrecognizedTime) || errors.Is(err, errInvalidEndTime) {
+		tok = offendingTimeToken(tokens, anchor)
+	}
+	return &TimeParseError{
+		Token:       tok,
+		Tokens:      tokens,
+		Err:         err,
+		Suggestions: suggestTimeTokens(tokens, anchor),
+	}
+}
+
+// offendingTimeToken picks the first token no single-token rule accepts,
+// falling back to the first token. Other errors (a bad dashed range, end
+// before start) are about the first token anyway.
+func offendingTimeToken(tokens []string, anchor time.Time) string {
+	loc := parserLocation()
+	for _, t := range tokens {
+		if t == "now" || looksLikeTime(t) || looksLikeDuration(t) || looksLikeDateWord(t) || isAbsoluteDate(t) {
+			continue
+		}
+		if _, _, ok, _ := resolvePeriodToken(t, anchor, loc); ok {
+			continue
+		}
+		if strings.Contains(t, "-") {
+			if _, _, err := resolveDashRangeToken(t, anchor, loc); err == nil {
+				continue
+			}
+		}
+		return t
+	}
+	return tokens[0]
+}
+
+var (
+	// 9.30, 9h30 -> 9:30
+	altTimeSepRe = regexp.MustCompile(`\b(\d{1,2})[.h](\d{2})\b`)
+	// 9am, 5:30pm
+	ampmRe = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+)
+
+// timeWords are the words the parser understands, for typo correction.
+var timeWords = []string{
+	"today", "yesterday", "tomorrow", "now",
+	"mon", "tue", "wed", "thu", "fri", "sat", "sun",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"thisweek", "lastweek", "thismonth", "lastmonth", "thisquarter", "lastquarter", "thisyear", "lastyear",
+}
+
+// suggestTimeTokens proposes corrected inputs for tokens that failed to
+// parse: other separators (9.00, 9h00, en dashes, "9 to 17"), am/pm, typos
+// in date words, "last week" written apart, and a date word missing its
+// times. Only candidates that actually parse are returned.
+func suggestTimeTokens(tokens []string, anchor time.Time) []string {
+	var cands [][]string
+
+	norm := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		t = strings.ToLower(strings.TrimSpace(t))
+		t = strings.NewReplacer("–", "-", "—", "-").Replace(t)
+		t = altTimeSepRe.ReplaceAllString(t, "$1:$2")
+		var parts []string
+		for _, p := range strings.Split(t, "-") {
+			if m := ampmRe.FindStringSubmatch(p); m != nil {
+				h, _ := strconv.Atoi(m[1])
+				if m[3] == "pm" && h < 12 {
+					h += 12
+				} else if m[3] == "am" && h == 12 {
+					h = 0
+				}
+				mins := m[2]
+				if mins == "" {
+					mins = "00"
+				}
+				p = fmt.Sprintf("%02d:%s", h, mins)
+			}
+			if w, ok := correctTimeWord(p); ok {
+				p = w
+			}
+			parts = append(parts, p)
+		}
+		norm = append(norm, strings.Join(parts, "-"))
+	}
+	cands = append(cands, norm)
+
+	// "9 to 17", "last week"
+	for i := 0; i+1 < len(norm); i++ {
+		switch {
+		case i+2 < len(norm) && (norm[i+1] == "to" || norm[i+1] == "until" || norm[i+1] == "bis"):
+			joined := append(append(append([]string{}, norm[:i]...), norm[i]+"-"+norm[i+2]), norm[i+3:]...)
+			cands = append(cands, joined)
+		case norm[i] == "last" || norm[i] == "this":
+			if w, ok := correctTimeWord(norm[i] + norm[i+1]); ok {
+				joined := append(append(append([]string{}, norm[:i]...), w), norm[i+2:]...)
+				cands = append(cands, joined)
+			}
+		}
+	}
+
+	// a date word on its own needs times: "yesterday 9 17"
+	if len(norm) >= 1 && looksLikeDateWord(norm[0]) && (len(norm) == 1 || !looksLikeTime(norm[1])) {
+		cands = append(cands, append([]string{norm[0], "9", "17"}, norm[1:]...))
+	}
+
+	orig := strings.Join(tokens, " ")
+	seen := map[string]bool{orig: true}
+	var out []string
+	for _, c := range cands {
+		s := strings.Join(c, " ")
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		if _, _, _, err := parseFlexibleRange(c, anchor); err != nil {
+			continue
+		}
+		out = append(out, s)
+		if len(out) == maxTimeSuggestions {
+			break
+		}
+	}
+	return out
+}
+
+// correctTimeWord returns s if it is a parser word, else the word within
+// edit distance 2 of s (1 for short words). ok is false when nothing is close.
+func correctTimeWord(s string) (string, bool) {
+	if len(s) < 3 || strings.ContainsAny(s, "0123456789:+") {
+		return "", false
+	}
+	words := timeWords
+	for name := range shorthands() {
+		words = append(words[:len(words):len(words)], name)
+	}
+	best, bestDist := "", 3
+	for _, w := range words {
+		if w == s {
+			return s, true
+		}
+		limit := 2
+		if len(w) <= 4 {
+			limit = 1
+		}
+		if d := editDistance(s, w); d <= limit && d < bestDist {
+			best, bestDist = w, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseFlexibleRangeSuggestions(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	anchor := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		tokens []string
+		token  string
+		want   []string
+	}{
+		{[]string{"9.00-17.00"}, "9.00-17.00", []string{"9:00-17:00"}},
+		{[]string{"9am-5:30pm"}, "9am-5:30pm", []string{"09:00-17:30"}},
+		{[]string{"yesterady", "9", "17"}, "yesterady", []string{"yesterday 9 17"}},
+		{[]string{"last", "week"}, "last", []string{"lastweek"}},
+		{[]string{"yesterday"}, "yesterday", []string{"yesterday 9 17"}},
+		{[]string{"banana"}, "banana", nil},
+	}
+	for _, tc := range cases {
+		_, _, _, err := ParseFlexibleRange(tc.tokens, anchor)
+		var pe *TimeParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%q: want *TimeParseError, got %v", tc.tokens, err)
+			continue
+		}
+		if pe.Token != tc.token {
+			t.Errorf("%q: token = %q, want %q", tc.tokens, pe.Token, tc.token)
+		}
+		if !reflect.DeepEqual(pe.Suggestions, tc.want) {
+			t.Errorf("%q: suggestions = %q, want %q (error: %v)", tc.tokens, pe.Suggestions, tc.want, err)
+		}
+	}
+
+	// "9" alone parses as a start time; add reports the bad end token with
+	// suggestions built from all its arguments.
+	pe := newTimeParseError([]string{"9", "to", "17", "acme"}, anchor, errInvalidEndTime)
+	if pe.Token != "to" || !reflect.DeepEqual(pe.Suggestions, []string{"9-17 acme"}) {
+		t.Errorf("add end token: got %q, %q", pe.Token, pe.Suggestions)
+	}
+}
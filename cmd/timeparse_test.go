@@ -72,6 +72,46 @@ func TestParseFlexibleRange_TableDriven(t *testing.T) {
 			wantEn:   time.Date(2025, 10, 10, 11, 0, 0, 0, time.UTC),
 			wantCons: 1,
 		},
+		{
+			name:     "lastweek period",
+			tokens:   []string{"lastweek", "acme"},
+			wantSt:   time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC),
+			wantEn:   time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC),
+			wantCons: 1,
+		},
+		{
+			name:     "ISO week period",
+			tokens:   []string{"week:2025-W41"},
+			wantSt:   time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC),
+			wantEn:   time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC),
+			wantCons: 1,
+		},
+		{
+			name:     "lastmonth period",
+			tokens:   []string{"lastmonth"},
+			wantSt:   time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			wantEn:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantCons: 1,
+		},
+		{
+			name:     "quarter of anchor year",
+			tokens:   []string{"q3"},
+			wantSt:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantEn:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantCons: 1,
+		},
+		{
+			name:     "quarter with year",
+			tokens:   []string{"2024-Q4"},
+			wantSt:   time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantEn:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantCons: 1,
+		},
+		{
+			name:    "week out of range",
+			tokens:  []string{"week:2025-W53"},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -109,3 +149,89 @@ func TestParseFlexibleRange_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRangeFlagsPeriods(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	t.Cleanup(func() { viper.Set("timezone", "") })
+	oldNow := Now
+	t.Cleanup(func() { Now = oldNow })
+	Now = func() time.Time { return time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC) }
+
+	day := func(m time.Month, d int) string {
+		return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+	cases := []struct {
+		rng      string
+		from, to string
+	}{
+		{"lastweek", day(10, 6), day(10, 12)},
+		{"lastmonth", day(9, 1), day(9, 30)},
+		{"q3", day(7, 1), day(9, 30)},
+		{"week:41..thisweek", day(10, 6), day(10, 19)},
+		{"2025-09-15T00:00..lastweek", day(9, 15), day(10, 12)},
+	}
+	for _, tc := range cases {
+		from, to := parseRangeFlags(false, false, tc.rng)
+		if got := from.Format("2006-01-02"); got != tc.from {
+			t.Errorf("%s: from = %s, want %s", tc.rng, got, tc.from)
+		}
+		if got := to.Format("2006-01-02"); got != tc.to {
+			t.Errorf("%s: to = %s, want %s", tc.rng, got, tc.to)
+		}
+	}
+}
+
+func TestParseRangeFlagsUseConfiguredTimezone(t *testing.T) {
+	// Sunday 22:00 UTC is already Monday noon in Kiritimati (UTC+14)
+	viper.Set("timezone", "Pacific/Kiritimati")
+	oldNow, oldLocal := Now, time.Local
+	time.Local = time.UTC
+	Now = func() time.Time { return time.Date(2025, 10, 19, 22, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now, time.Local = oldNow, oldLocal
+	})
+
+	span := func(from, to time.Time) string { return from.Format("2006-01-02") + ".." + to.Format("2006-01-02") }
+	if got := span(parseRangeFlags(true, false, "")); got != "2025-10-20..2025-10-20" {
+		t.Errorf("--today = %s", got)
+	}
+	if got := span(parseRangeFlags(false, true, "")); got != "2025-10-20..2025-10-26" {
+		t.Errorf("--week = %s", got)
+	}
+	if got := span(parseRangeFlags(false, false, "lastweek")); got != "2025-10-13..2025-10-19" {
+		t.Errorf("lastweek = %s", got)
+	}
+}
+
+func TestParseFlexibleRangeShorthands(t *testing.T) {
+	viper.Set("timezone", "UTC")
+	viper.Set("shorthands", map[string]interface{}{"Morning": "9-12", "late": "18:00 +2h", "loop": "morning"})
+	t.Cleanup(func() { viper.Set("timezone", ""); viper.Set("shorthands", nil) })
+	anchor := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	at := func(d, h int) time.Time { return time.Date(2025, 10, d, h, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		tokens   []string
+		st, en   time.Time
+		consumed int
+	}{
+		{[]string{"workday", "acme", "proj"}, at(14, 9), at(14, 17), 1},
+		{[]string{"yesterday", "workday", "acme"}, at(13, 9), at(13, 17), 2},
+		{[]string{"2025-10-10", "morning"}, at(10, 9), at(10, 12), 2},
+		{[]string{"late"}, at(14, 18), at(14, 20), 1},
+	}
+	for _, tc := range cases {
+		st, en, consumed, err := ParseFlexibleRange(tc.tokens, anchor)
+		if err != nil {
+			t.Errorf("%q: %v", tc.tokens, err)
+			continue
+		}
+		if !st.Equal(tc.st) || !en.Equal(tc.en) || consumed != tc.consumed {
+			t.Errorf("%q = %v..%v (%d), want %v..%v (%d)", tc.tokens, st, en, consumed, tc.st, tc.en, tc.consumed)
+		}
+	}
+	if _, _, _, err := ParseFlexibleRange([]string{"loop"}, anchor); err == nil {
+		t.Error("a shorthand referring to another shorthand should fail")
+	}
+}
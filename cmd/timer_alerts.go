@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/desktop"
+	ui "tt/internal/tui"
+	"tt/internal/worktime"
+)
+
+// Timer alerts announce a running entry at fixed intervals ("You've been on
+// acme/portal for 2h") as a desktop notification, so a forgotten timer is
+// noticed early. tt schedule run checks every minute; a running TUI checks
+// as well and shows the announcement in its status line. The last
+// announcement is kept in ~/.tt/notify/timer-alert.json, so whichever
+// process gets there first sends it and restarts do not repeat it.
+//
+// Config (~/.tt/config.yaml):
+//
+//	timer_alerts:
+//	  every_min: 120     # every 2h of a running entry (0 or unset: off)
+//	  activities:
+//	    meeting: 60      # meetings after every hour, even with every_min off
+
+// timerAlertConfig holds the announcement intervals.
+type timerAlertConfig struct {
+	Every      time.Duration
+	Activities map[string]time.Duration // lower-case activity -> interval
+}
+
+func loadTimerAlertConfig() timerAlertConfig {
+	cfg := timerAlertConfig{
+		Every:      time.Duration(viper.GetInt("timer_alerts.every_min")) * time.Minute,
+		Activities: map[string]time.Duration{},
+	}
+	for a := range viper.GetStringMap("timer_alerts.activities") {
+		if min := viper.GetInt("timer_alerts.activities." + a); min > 0 {
+			cfg.Activities[strings.ToLower(a)] = time.Duration(min) * time.Minute
+		}
+	}
+	return cfg
+}
+
+// enabled reports whether any interval is configured.
+func (c timerAlertConfig) enabled() bool {
+	return c.Every > 0 || len(c.Activities) > 0
+}
+
+// interval is the announcement interval for activity; 0 means none.
+func (c timerAlertConfig) interval(activity string) time.Duration {
+	if d, ok := c.Activities[strings.ToLower(strings.TrimSpace(activity))]; ok {
+		return d
+	}
+	return c.Every
+}
+
+// timerAlertState is the last announcement sent.
+type timerAlertState struct {
+	Entry string `json:"entry"`
+	Count int    `json:"count"` // intervals announced
+}
+
+var timerAlertMu sync.Mutex
+
+func timerAlertStatePath() string {
+	return filepath.Join(filepath.Dir(ui.DefaultNoticePath()), "timer-alert.json")
+}
+
+// dueTimerAlert returns the announcement due for active at now, or "" when
+// none is: active is finished, has no interval, or its latest interval was
+// announced already. A due announcement is recorded as sent. After a long
+// gap only the latest interval is announced.
+func dueTimerAlert(cfg timerAlertConfig, active *Entry, now time.Time) (string, error) {
+	if active == nil || active.End != nil {
+		return "", nil
+	}
+	iv := cfg.interval(active.Activity)
+	if iv <= 0 {
+		return "", nil
+	}
+	n := int(entryElapsed(*active, now) / iv)
+	if n == 0 {
+		return "", nil
+	}
+	timerAlertMu.Lock()
+	defer timerAlertMu.Unlock()
+	path := timerAlertStatePath()
+	var st timerAlertState
+	if b, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(b, &st) // a damaged state only risks a repeat
+	}
+	if st.Entry == active.ID && st.Count >= n {
+		return "", nil
+	}
+	b, _ := json.Marshal(timerAlertState{Entry: active.ID, Count: n})
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return "", err
+	}
+	label := entryLabel(*active)
+	if active.Activity != "" {
+		label += " (" + active.Activity + ")"
+	}
+	return fmt.Sprintf("You've been on %s for %s", label, strings.TrimSuffix(fmtDuration(time.Duration(n)*iv), "00m")), nil
+}
+
+// announceTimer shows msg as a desktop notification; when that is not
+// possible it is written to w instead.
+func announceTimer(ctx context.Context, w io.Writer, msg string) {
+	if err := desktop.Notify(ctx, "tt", msg); err != nil {
+		fmt.Fprintf(w, "%s (%v)\n", msg, err)
+	}
+}
+
+// dueAlerts returns the timer announcement and the working-time reminder
+// due at now.
+func dueAlerts(cfg timerAlertConfig, rules worktime.Rules, now time.Time) ([]string, error) {
+	var out []string
+	if cfg.enabled() {
+		active, _, err := findActiveAndLast(now.AddDate(0, 0, -7), now)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := dueTimerAlert(cfg, active, now)
+		if err != nil {
+			return nil, err
+		}
+		if msg != "" {
+			out = append(out, msg)
+		}
+	}
+	if rules.Enabled() {
+		// a stretch may have started yesterday
+		ents, err := loadEntries(now.AddDate(0, 0, -1), now)
+		if err != nil {
+			return out, err
+		}
+		own := ents[:0]
+		for _, e := range ents {
+			if ownEntry(e) {
+				own = append(own, e)
+			}
+		}
+		msg, err := dueWorktimeAlert(rules, own, now)
+		if err != nil {
+			return out, err
+		}
+		if msg != "" {
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+// checkTimerAlert announces what is due now.
+func checkTimerAlert(ctx context.Context, w io.Writer, cfg timerAlertConfig, rules worktime.Rules) {
+	msgs, err := dueAlerts(cfg, rules, Now())
+	if err != nil {
+		logger.Warn("schedule: timer alerts", "err", err)
+	}
+	for _, msg := range msgs {
+		announceTimer(ctx, w, msg)
+	}
+}
+
+// runTimerAlerts checks for due announcements every interval until ctx is
+// canceled.
+func runTimerAlerts(ctx context.Context, w io.Writer, cfg timerAlertConfig, rules worktime.Rules, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			checkTimerAlert(ctx, w, cfg, rules)
+		}
+	}
+}
+
+// timerAlerts implements the TUI's TimerAlerts: due announcements and
+// working-time reminders go to the desktop and the dashboard's status line.
+type timerAlerts struct {
+	cfg   timerAlertConfig
+	rules worktime.Rules
+}
+
+func (a timerAlerts) DueAlert(ctx context.Context, _ ui.Entry, now time.Time) string {
+	msgs, _ := dueAlerts(a.cfg, a.rules, now)
+	for _, msg := range msgs {
+		_ = desktop.Notify(ctx, "tt", msg) // the status line shows it either way
+	}
+	return strings.Join(msgs, " · ")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestDueTimerAlert(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("timer_alerts", map[string]any{"every_min": 120, "activities": map[string]any{"Meeting": 60}})
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("timer_alerts", nil)
+	})
+	cfg := loadTimerAlertConfig()
+	if !cfg.enabled() || cfg.interval("meeting") != time.Hour || cfg.interval("dev") != 2*time.Hour {
+		t.Fatalf("config = %+v", cfg)
+	}
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	dev := &Entry{ID: "s1", Start: start, Customer: "acme", Project: "portal"}
+	due := func(e *Entry, after time.Duration) string {
+		t.Helper()
+		msg, err := dueTimerAlert(cfg, e, start.Add(after))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+
+	if msg := due(dev, 119*time.Minute); msg != "" {
+		t.Fatalf("announced before 2h: %q", msg)
+	}
+	if msg := due(dev, 2*time.Hour+time.Minute); msg != "You've been on acme/portal for 2h" {
+		t.Fatalf("at 2h: %q", msg)
+	}
+	if msg := due(dev, 3*time.Hour); msg != "" {
+		t.Fatalf("repeated: %q", msg)
+	}
+	// after a gap only the latest interval is announced, once
+	if msg := due(dev, 6*time.Hour+30*time.Minute); msg != "You've been on acme/portal for 6h" {
+		t.Fatalf("at 6h30: %q", msg)
+	}
+	if msg := due(dev, 6*time.Hour+31*time.Minute); msg != "" {
+		t.Fatalf("repeated after gap: %q", msg)
+	}
+
+	meeting := &Entry{ID: "s2", Start: start, Customer: "acme", Activity: "meeting"}
+	if msg := due(meeting, 90*time.Minute); msg != "You've been on acme (meeting) for 1h" {
+		t.Fatalf("meeting: %q", msg)
+	}
+	end := start.Add(5 * time.Hour)
+	if msg := due(&Entry{ID: "s3", Start: start, End: &end}, 5*time.Hour); msg != "" {
+		t.Fatalf("finished entry announced: %q", msg)
+	}
+}
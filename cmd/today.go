@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/reporting"
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Show today's entries, the running entry and the time left to the daily target",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := Now().In(parserLocation())
+		dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		// Load from yesterday so an entry running across midnight is included.
+		ents, err := loadEntries(dayStart.AddDate(0, 0, -1), now)
+		if err != nil {
+			return err
+		}
+		renderToday(os.Stdout, todayEntries(ents, dayStart, now), dayStart, now)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(todayCmd)
+}
+
+// todayEntry is an entry of the current user touching today, with the part
+// of it tracked today.
+type todayEntry struct {
+	Entry
+	Min int
+}
+
+// todayEntries returns the current user's entries overlapping the day from
+// dayStart up to now, in start order. A running entry counts up to now.
+func todayEntries(ents []Entry, dayStart, now time.Time) []todayEntry {
+	var out []todayEntry
+	for _, e := range ents {
+		if !ownEntry(e) {
+			continue
+		}
+		end := now
+		if e.End != nil && e.End.Before(now) {
+			end = *e.End
+		}
+		st := e.Start
+		if st.Before(dayStart) {
+			st = dayStart
+		}
+		if !end.After(st) {
+			continue
+		}
+		out = append(out, todayEntry{Entry: e, Min: int(end.Sub(st).Minutes())})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+// renderToday prints the day's entries with their durations, marking the
+// running one, and a total line with the remaining daily target.
+//
+//	Today 2025-10-14 (Tue)
+//	  08:00–09:00  1h00m  Acme/Ops  · standup
+//	  09:00–now    0h45m  Acme/Portal [dev]  (running)
+//	Today: 1h45m · 6h15m left to the 8h00m target
+func renderToday(w io.Writer, ents []todayEntry, dayStart, now time.Time) {
+	fmt.Fprintf(w, "%sToday %s (%s)%s\n", ansiHeading, dayStart.Format("2006-01-02"), dayStart.Format("Mon"), ansiReset)
+	if len(ents) == 0 {
+		fmt.Fprintln(w, "  No entries yet.")
+	}
+	total := 0
+	for _, e := range ents {
+		total += e.Min
+		start := e.Start.In(now.Location()).Format("15:04")
+		if e.Start.Before(dayStart) {
+			start = e.Start.In(now.Location()).Format("Jan 02 15:04")
+		}
+		end := "now  "
+		if e.End != nil {
+			end = e.End.In(now.Location()).Format("15:04")
+		}
+		what := entryLabel(e.Entry)
+		if e.Activity != "" {
+			what += " [" + e.Activity + "]"
+		}
+		line := fmt.Sprintf("  %s–%s  %s%-5s%s  %s", start, end, ansiHours, fmtHHMM(e.Min), ansiReset, what)
+		if e.End == nil {
+			line += "  " + ansiWarn + "(running)" + ansiReset
+		}
+		if notes := reporting.DedupeStrings(e.Notes); len(notes) > 0 {
+			line += "  · " + strings.Join(notes, "; ")
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, stopSummary{TodayMin: total, Target: dailyTargetMinutes()}.lines()[0])
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestToday(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("targets.daily_hours", 8)
+	oldNow := Now
+	oldHeading, oldHours, oldWarn, oldReset := ansiHeading, ansiHours, ansiWarn, ansiReset
+	ansiHeading, ansiHours, ansiWarn, ansiReset = "", "", "", ""
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = oldNow
+		ansiHeading, ansiHours, ansiWarn, ansiReset = oldHeading, oldHours, oldWarn, oldReset
+	})
+	day := time.Date(2025, 10, 14, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	Now = func() time.Time { return at(9, 45) }
+	night := NewAddEvent("n1", "Acme", "Ops", "", boolPtr(true), "", nil, day.Add(-time.Hour), at(0, 30)) // across midnight: 30m today
+	night.TS = day.Add(-time.Hour)
+	if err := writeEvents([]Event{
+		night,
+		NewStartEvent("e1", "Acme", "Ops", "", boolPtr(true), "standup", nil, at(8, 0)),
+		NewStopEvent("e2", at(9, 0)),
+		NewStartEvent("a1", "Acme", "Portal", "dev", boolPtr(true), "", nil, at(9, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := loadEntries(day.AddDate(0, 0, -1), Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	renderToday(&buf, todayEntries(ents, day, Now()), day, Now())
+	want := "Today 2025-10-14 (Tue)\n" +
+		"  Oct 13 23:00–00:30  30m    Acme/Ops\n" +
+		"  08:00–09:00  1h00m  Acme/Ops  · standup\n" +
+		"  09:00–now    45m    Acme/Portal [dev]  (running)\n" +
+		"Today: 2h15m · 5h45m left to the 8h00m target\n"
+	if buf.String() != want {
+		t.Errorf("today =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	renderToday(&buf, nil, day, Now())
+	if want := "Today 2025-10-14 (Tue)\n  No entries yet.\nToday: 0m · 8h00m left to the 8h00m target\n"; buf.String() != want {
+		t.Errorf("empty day =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
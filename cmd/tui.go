@@ -2,13 +2,14 @@ package cmd
 
 import (
 	"context"
-	"log"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"tt/internal/journal"
 	ui "tt/internal/tui"
 )
 
@@ -23,7 +24,7 @@ import (
 var tuiCmd = &cobra.Command{
 	Use:   "tui",
 	Short: "Interactive terminal UI (space: start/stop, n: note, q/Esc: quit)",
-	Long:  "Launch the Bubble Tea TUI for tt. Dashboard with live status; auto-refresh on journal changes. Keys: space=start/stop, n=note, q/Esc=quit.",
+	Long:  "Launch the Bubble Tea TUI for tt. Dashboard with live status; auto-refresh on journal changes. Keys: space=start/stop, n=note, !=error log, q/Esc=quit. Set tui.error_log: true to also append failed writes to ~/.tt/tui.log.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Step 1: Wire the internal TUI app model with stubbed services.
 		svcs := ui.Services{
@@ -31,11 +32,28 @@ var tuiCmd = &cobra.Command{
 			Writer:  stubWriter{},
 			Watch:   ui.NewFSNotifyJournalWatch("", 0),
 			Config:  stubConfig{},
+
+			Candidates: completionCandidates{},
+			Feedback:   stopFeedback{},
+			Activities: activityPolicy{},
+			Billable:   billableDefaults{},
+			Notices:    ui.NewTouchFileNotifier(""),
+		}
+		ranking := suggestConfig()
+		svcs.Ranking = &ranking
+		if tc := loadTargets(); tc.Enabled() {
+			svcs.Targets = &tc
+		}
+		if cfg, rules := loadTimerAlertConfig(), loadWorktimeRules(); cfg.enabled() || rules.Enabled() {
+			svcs.Alerts = timerAlerts{cfg: cfg, rules: rules}
+		}
+		if viper.GetBool("tui.error_log") {
+			svcs.Errors = ui.FileErrorLog{Path: ui.DefaultErrorLogPath()}
 		}
 		m := ui.NewAppModel(svcs)
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
-			log.Printf("tui exited with error: %v", err)
+			logger.Error("tui exited with error", "err", err)
 			return err
 		}
 		return nil
@@ -56,19 +74,11 @@ func (stubJournal) LoadEntries(ctx context.Context, from, to time.Time) ([]ui.En
 	if err != nil {
 		return nil, err
 	}
+	marks, _ := activeMarks() // badges are best effort
+	decisions := loadCompletionDecisions()
 	out := make([]ui.Entry, 0, len(ents))
 	for _, e := range ents {
-		out = append(out, ui.Entry{
-			ID:       e.ID,
-			Start:    e.Start,
-			End:      e.End,
-			Customer: e.Customer,
-			Project:  e.Project,
-			Activity: e.Activity,
-			Billable: e.Billable,
-			Notes:    e.Notes,
-			Tags:     e.Tags,
-		})
+		out = append(out, uiEntry(e, marks, decisions))
 	}
 	return out, nil
 }
@@ -78,39 +88,47 @@ func (stubJournal) FindActiveAndLast(ctx context.Context, from, to time.Time) (*
 	if err != nil {
 		return nil, nil, err
 	}
+	marks, _ := activeMarks()
+	decisions := loadCompletionDecisions()
 	var au *ui.Entry
 	var lu *ui.Entry
 	if a != nil {
-		x := ui.Entry{
-			ID:       a.ID,
-			Start:    a.Start,
-			End:      a.End,
-			Customer: a.Customer,
-			Project:  a.Project,
-			Activity: a.Activity,
-			Billable: a.Billable,
-			Notes:    a.Notes,
-			Tags:     a.Tags,
-		}
+		x := uiEntry(*a, marks, decisions)
 		au = &x
 	}
 	if l != nil {
-		x := ui.Entry{
-			ID:       l.ID,
-			Start:    l.Start,
-			End:      l.End,
-			Customer: l.Customer,
-			Project:  l.Project,
-			Activity: l.Activity,
-			Billable: l.Billable,
-			Notes:    l.Notes,
-			Tags:     l.Tags,
-		}
+		x := uiEntry(*l, marks, decisions)
 		lu = &x
 	}
 	return au, lu, nil
 }
 
+// uiEntry converts e for the TUI, with the customer's display alias, the
+// badge of its pin or follow-up flag in marks and whether the customer is
+// archived in decisions.
+func uiEntry(e Entry, marks map[string]journal.Mark, decisions completionDecisions) ui.Entry {
+	x := ui.Entry{
+		ID:       e.ID,
+		Start:    e.Start,
+		End:      e.End,
+		Customer: e.Customer,
+		Project:  e.Project,
+		Activity: e.Activity,
+		Billable: e.Billable,
+		Notes:    e.Notes,
+		Tags:     e.Tags,
+		Archived: decisions.isCustomerArchived(e.Customer),
+		Paused:   e.journalEntry().Paused(Now()),
+	}
+	if label := DisplayCustomer(e.Customer); label != e.Customer {
+		x.CustomerLabel = label
+	}
+	if m, ok := marks[e.ID]; ok {
+		x.Mark = markBadge(m)
+	}
+	return x
+}
+
 // stubWriter writes events using the same logic as the CLI, preserving hashes and format.
 type stubWriter struct{}
 
@@ -163,11 +181,80 @@ func (stubConfig) Timezone() *time.Location {
 	return loc
 }
 
+// CandidateLookback reads tui.candidate_lookback_days (default 90); values
+// <= 0 disable the bound.
+func (stubConfig) CandidateLookback() time.Duration {
+	days := 90
+	if viper.IsSet("tui.candidate_lookback_days") {
+		days = viper.GetInt("tui.candidate_lookback_days")
+	}
+	if days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 func (stubConfig) Rounding() ui.RoundingConfig {
 	r := getRounding()
 	return ui.RoundingConfig{
 		Strategy:     r.Strategy,
 		QuantumMin:   r.QuantumMin,
 		MinimumEntry: r.MinimumEntry,
+		Level:        r.Level,
 	}
 }
+
+// completionCandidates serves form candidates from the same sources as shell
+// completion: customers/projects approved in completion review plus those
+// observed in the journal since the lookback bound, without archived
+// customers and their projects.
+type completionCandidates struct{}
+
+func (completionCandidates) Candidates(ctx context.Context, since time.Time) ([]string, []string, error) {
+	idx, err := BuildCompletionIndexSince("", since)
+	if err != nil {
+		return nil, nil, err
+	}
+	decisions := loadCompletionDecisions()
+
+	custSet := map[string]string{}
+	projSet := map[string]string{}
+	addTo := func(set map[string]string, name string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		key := strings.ToLower(name)
+		if _, ok := set[key]; !ok {
+			set[key] = name
+		}
+	}
+
+	for _, c := range decisions.allowedCustomers() {
+		if !decisions.isCustomerArchived(c) {
+			addTo(custSet, canonicalForCompletion(c))
+		}
+	}
+	for _, c := range idx.SortedCustomerCanonicals() {
+		if decisions.isCustomerIgnored(c) || decisions.isCustomerArchived(c) {
+			continue
+		}
+		addTo(custSet, c)
+		for _, p := range idx.SortedProjects(c) {
+			if !decisions.isProjectIgnored(c, p) {
+				addTo(projSet, p)
+			}
+		}
+		for _, p := range decisions.allowedProjects(c) {
+			addTo(projSet, p)
+		}
+	}
+	for _, p := range idx.SortedProjects("") {
+		addTo(projSet, p)
+	}
+	for _, p := range decisions.allowedProjects("") {
+		addTo(projSet, p)
+	}
+
+	return sortedValues(custSet), sortedValues(projSet), nil
+}
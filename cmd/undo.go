@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tt/internal/journal"
+)
+
+// undoLookbackDays bounds how far back tt undo looks for events to take back.
+const undoLookbackDays = 7
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [n]",
+	Short: "Take back the last n journal events (default 1), append-only",
+	Long: "Take back the last n events you wrote (start, stop, add, note, amend, ...) by appending an undo event " +
+		"that tombstones them; the journal is not edited, so the hash chain stays intact. Entries, status and " +
+		"reports are rebuilt as if the events had never been written. Events of the last " +
+		strconv.Itoa(undoLookbackDays) + " days can be undone, most recently written first. " +
+		"Use tt --dry-run undo to see what would be taken back.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n := 1
+		if len(args) == 1 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil || v < 1 {
+				return fmt.Errorf("n must be a positive number of events, got %q", args[0])
+			}
+			n = v
+		}
+		targets, err := lastEvents(n)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("nothing to undo in the last %d days", undoLookbackDays)
+		}
+		if err := writeEvents(undoEvents(targets)); err != nil {
+			return fmt.Errorf("failed to write undo event: %w", err)
+		}
+		printUndone(cmd.OutOrStdout(), targets)
+		if len(targets) < n {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Only %d of %d events found in the last %d days.\n", len(targets), n, undoLookbackDays)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+}
+
+// undoTarget is an event to take back and the day file it was written to.
+type undoTarget struct {
+	Event journal.Event
+	Path  string
+}
+
+// lastEvents returns the current user's n most recently written events that
+// are not undone yet, newest first: day files from today backwards, each
+// from its end.
+func lastEvents(n int) ([]undoTarget, error) {
+	me := currentUser()
+	now := Now().In(parserLocation())
+	var out []undoTarget
+	for i := 0; i <= undoLookbackDays && len(out) < n; i++ {
+		day := now.AddDate(0, 0, -i)
+		path := journalPaths(day, day)[0]
+		evs, err := journal.ReadUndoable(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		for j := len(evs) - 1; j >= 0 && len(out) < n; j-- {
+			if evs[j].User != "" && evs[j].User != me {
+				continue
+			}
+			out = append(out, undoTarget{Event: evs[j], Path: path})
+		}
+	}
+	return out, nil
+}
+
+// undoEvents returns one undo event per day file of targets. A tombstone
+// only applies within its file, so targets of an earlier day are undone by
+// an event at the end of that day.
+func undoEvents(targets []undoTarget) []Event {
+	var order []string
+	ids := map[string][]string{}
+	for _, t := range targets {
+		if _, ok := ids[t.Path]; !ok {
+			order = append(order, t.Path)
+		}
+		ids[t.Path] = append(ids[t.Path], t.Event.ID)
+	}
+	now := Now()
+	var out []Event
+	for _, path := range order {
+		ts := now
+		if journalPaths(ts, ts)[0] != path {
+			day, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(filepath.Base(path), ".jsonl"), parserLocation())
+			if err == nil {
+				ts = day.Add(24*time.Hour - time.Second)
+			}
+		}
+		out = append(out, Event{ID: IDGen(), Type: journal.UndoType, TS: ts, Ref: strings.Join(ids[path], ",")})
+	}
+	return out
+}
+
+// dropUndone is journal.DropUndone for events read by the CLI itself.
+func dropUndone(events []Event) []Event {
+	undone := map[string]bool{}
+	for _, ev := range events {
+		for _, id := range journal.UndoTargets(journal.Event{Type: ev.Type, Ref: ev.Ref}) {
+			undone[id] = true
+		}
+	}
+	out := events[:0:0]
+	for _, ev := range events {
+		if ev.Type != journal.UndoType && !undone[ev.ID] {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// printUndone lists the events taken back.
+func printUndone(w io.Writer, targets []undoTarget) {
+	noun := "event"
+	if len(targets) != 1 {
+		noun = "events"
+	}
+	fmt.Fprintf(w, "Undid %d %s:\n", len(targets), noun)
+	loc := parserLocation()
+	for _, t := range targets {
+		ev := t.Event
+		var what []string
+		if label := strings.Trim(ev.Customer+"/"+ev.Project, "/"); label != "" {
+			what = append(what, label)
+		}
+		if ev.Note != "" {
+			what = append(what, fmt.Sprintf("%q", ev.Note))
+		}
+		if ev.Ref != "" && ev.Type != "add" {
+			what = append(what, "ref "+ev.Ref)
+		}
+		line := fmt.Sprintf("  %-8s %s  %s", ev.Type, ev.TS.In(loc).Format("2006-01-02 15:04"), ev.ID)
+		if len(what) > 0 {
+			line += "  " + strings.Join(what, " ")
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestUndoTombstonesLastEvents(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 8, 10, 10, 0, 0, time.UTC)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = prevNow
+	})
+
+	yesterday := now.AddDate(0, 0, -1)
+	for _, ev := range []Event{
+		NewStartEvent("y1", "Acme", "portal", "", nil, "", nil, yesterday.Add(-2*time.Hour)),
+		NewStopEvent("y2", yesterday.Add(-time.Hour)),
+		NewStartEvent("s1", "Acme", "portal", "", nil, "", nil, now.Add(-70*time.Minute)),
+		NewStopEvent("s2", now.Add(-10*time.Minute)),
+		NewStartEvent("s3", "Globex", "", "", nil, "typo", nil, now.Add(-5*time.Minute)),
+	} {
+		if err := writeEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		undoCmd.SetOut(&out)
+		undoCmd.SetErr(&out)
+		if err := undoCmd.RunE(undoCmd, args); err != nil {
+			t.Fatalf("undo %v: %v", args, err)
+		}
+		return stripANSI(out.String())
+	}
+
+	// The fat-fingered start is taken back; the stopped entry stands.
+	if out := run(); !strings.HasPrefix(out, "Undid 1 event:\n  start    2025-10-08 10:05  s3  Globex \"typo\"") {
+		t.Fatalf("undo = %q", out)
+	}
+	active, last, err := findActiveAndLast(now, now)
+	if err != nil || active != nil || last == nil || last.ID != "s1" {
+		t.Fatalf("after undo: active=%+v last=%+v err=%v", active, last, err)
+	}
+
+	// Undoing the stop resumes the entry; an undone event is not undone twice.
+	run()
+	if active, _, _ := findActiveAndLast(now, now); active == nil || active.ID != "s1" {
+		t.Fatalf("after undoing the stop: active=%+v", active)
+	}
+
+	// Reaching back into yesterday writes the tombstone into that day's file.
+	if out := run("3"); !strings.Contains(out, "Undid 3 events:") || !strings.Contains(out, "y2") {
+		t.Fatalf("undo 3 = %q", out)
+	}
+	ents, err := loadEntries(yesterday, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ents) != 0 {
+		t.Fatalf("entries after undo = %+v", ents)
+	}
+	if err := undoCmd.RunE(undoCmd, nil); err == nil {
+		t.Fatal("undo with nothing left succeeded")
+	}
+	if err := undoCmd.RunE(undoCmd, []string{"0"}); err == nil {
+		t.Fatal("undo 0 succeeded")
+	}
+}
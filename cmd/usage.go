@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Usage statistics count, per command, how often it ran and how many days
+// its reports covered, so users can see which workflows they rely on. They
+// are off unless usage.enabled is set, stay in ~/.tt/usage.json and are
+// never sent anywhere.
+//
+// Config (~/.tt/config.yaml):
+//
+//	usage:
+//	  enabled: true
+
+// usageStats is the content of ~/.tt/usage.json.
+type usageStats struct {
+	Since    time.Time                `json:"since"`
+	Commands map[string]*commandUsage `json:"commands"`
+}
+
+// commandUsage is what is recorded for one command path, e.g. "report week".
+type commandUsage struct {
+	Count     int       `json:"count"`
+	Last      time.Time `json:"last"`
+	Ranged    int       `json:"ranged,omitempty"`     // runs with a resolved date range
+	RangeDays int       `json:"range_days,omitempty"` // days covered by those runs
+}
+
+// usageRun is what one run reports to the usage stats; executeCounted puts
+// it into the context of the command.
+type usageRun struct {
+	rangeDays int // days of the resolved date range; 0 when it has none
+}
+
+type usageRunKey struct{}
+
+// noteUsageRange records the days from..to (inclusive) that cmd resolved for
+// its report. Commands run outside executeCounted (tests) record nothing.
+func noteUsageRange(cmd *cobra.Command, from, to time.Time) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return
+	}
+	if run, ok := ctx.Value(usageRunKey{}).(*usageRun); ok {
+		a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+		run.rangeDays = int(b.Sub(a).Hours()/24) + 1
+	}
+}
+
+// executeCounted executes the root command and counts the command that ran
+// once it finished successfully. Counting here rather than in a
+// PersistentPostRun hook also covers subcommands with hooks of their own,
+// which cobra runs instead of the root's.
+func executeCounted() error {
+	run := &usageRun{}
+	cmd, err := rootCmd.ExecuteContextC(context.WithValue(context.Background(), usageRunKey{}, run))
+	if err != nil {
+		return err
+	}
+	if err := recordUsage(cmd, run.rangeDays); err != nil {
+		logger.Warn("usage stats", "err", err)
+	}
+	return nil
+}
+
+func usageStatsPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".tt", "usage.json")
+}
+
+func loadUsageStats() (usageStats, error) {
+	st := usageStats{Commands: map[string]*commandUsage{}}
+	b, err := os.ReadFile(usageStatsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(b, &st); err != nil {
+		return st, fmt.Errorf("%s: %w", usageStatsPath(), err)
+	}
+	if st.Commands == nil {
+		st.Commands = map[string]*commandUsage{}
+	}
+	return st, nil
+}
+
+func (st usageStats) save() error {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(usageStatsPath()), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(usageStatsPath(), append(b, '\n'), 0o644)
+}
+
+// usageCommandName is the command path without the binary, e.g.
+// "report week"; "" for commands that are not counted (the root, help and
+// shell completion requests).
+func usageCommandName(cmd *cobra.Command) string {
+	name := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	switch {
+	case name == "", name == "help", strings.HasPrefix(name, cobra.ShellCompRequestCmd), strings.HasPrefix(name, cobra.ShellCompNoDescRequestCmd):
+		return ""
+	}
+	return name
+}
+
+// recordUsage counts a finished run of cmd over rangeDays days (0 for none)
+// when usage.enabled is set.
+func recordUsage(cmd *cobra.Command, rangeDays int) error {
+	if !viper.GetBool("usage.enabled") {
+		return nil
+	}
+	name := usageCommandName(cmd)
+	if name == "" {
+		return nil
+	}
+	st, err := loadUsageStats()
+	if err != nil {
+		return err
+	}
+	now := Now()
+	if st.Since.IsZero() {
+		st.Since = now
+	}
+	u := st.Commands[name]
+	if u == nil {
+		u = &commandUsage{}
+		st.Commands[name] = u
+	}
+	u.Count++
+	u.Last = now
+	if rangeDays > 0 {
+		u.Ranged++
+		u.RangeDays += rangeDays
+	}
+	return st.save()
+}
+
+var (
+	statsUsage bool
+	statsReset bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local statistics about how you use tt (--usage)",
+	Long: "tt stats --usage lists how often each command ran and the average range of its reports, from " +
+		"~/.tt/usage.json. Recording is opt-in (usage.enabled: true) and strictly local; nothing is sent anywhere. " +
+		"--reset deletes the recorded statistics.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !statsUsage {
+			return cmd.Help()
+		}
+		if statsReset {
+			if err := os.Remove(usageStatsPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Usage statistics deleted.")
+			return nil
+		}
+		st, err := loadUsageStats()
+		if err != nil {
+			return err
+		}
+		printUsageStats(cmd.OutOrStdout(), st, viper.GetBool("usage.enabled"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().BoolVar(&statsUsage, "usage", false, "show how often each command ran (usage.enabled records it locally)")
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "with --usage: delete the recorded statistics")
+}
+
+// printUsageStats lists the commands by count, most used first.
+func printUsageStats(w io.Writer, st usageStats, enabled bool) {
+	if !enabled {
+		fmt.Fprintln(w, "Usage statistics are off; set usage.enabled: true to record them (locally, in ~/.tt/usage.json).")
+		if len(st.Commands) == 0 {
+			return
+		}
+	}
+	if len(st.Commands) == 0 {
+		fmt.Fprintln(w, "No commands recorded yet.")
+		return
+	}
+	names := make([]string, 0, len(st.Commands))
+	total := 0
+	for name, u := range st.Commands {
+		names = append(names, name)
+		total += u.Count
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := st.Commands[names[i]], st.Commands[names[j]]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return names[i] < names[j]
+	})
+	loc := parserLocation()
+	fmt.Fprintf(w, "%sUsage since %s (%d runs):%s\n", ansiHeading, st.Since.In(loc).Format("2006-01-02"), total, ansiReset)
+	for _, name := range names {
+		u := st.Commands[name]
+		line := fmt.Sprintf("  %-24s %5d  last %s", name, u.Count, u.Last.In(loc).Format("Jan 02"))
+		if u.Ranged > 0 {
+			line += fmt.Sprintf("  avg range %.1f days", float64(u.RangeDays)/float64(u.Ranged))
+		}
+		fmt.Fprintln(w, line)
+	}
+}
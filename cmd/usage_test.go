@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestUsageStatsRecordLocally(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+	prevNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("usage.enabled", false)
+		Now = prevNow
+	})
+
+	// Off by default: nothing is written.
+	if err := recordUsage(lsCmd, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(usageStatsPath()); !os.IsNotExist(err) {
+		t.Fatalf("usage.json written while off: %v", err)
+	}
+
+	viper.Set("usage.enabled", true)
+	for _, days := range []int{7, 14} {
+		if err := recordUsage(reportWeekCmd, days); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := recordUsage(statusCmd, 0); err != nil {
+		t.Fatal(err)
+	}
+	if usageCommandName(rootCmd) != "" {
+		t.Fatal("the root command is counted")
+	}
+
+	st, err := loadUsageStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := st.Commands["report week"]; u == nil || u.Count != 2 || u.Ranged != 2 || u.RangeDays != 21 {
+		t.Fatalf("report week = %+v", u)
+	}
+
+	var out bytes.Buffer
+	printUsageStats(&out, st, true)
+	want := "Usage since 2025-10-08 (3 runs):\n" +
+		"  report week                  2  last Oct 08  avg range 10.5 days\n" +
+		"  status                       1  last Oct 08\n"
+	if got := stripANSI(out.String()); got != want {
+		t.Fatalf("stats =\n%s\nwant\n%s", got, want)
+	}
+
+	out.Reset()
+	printUsageStats(&out, st, false)
+	if !strings.HasPrefix(out.String(), "Usage statistics are off") || !strings.Contains(out.String(), "report week") {
+		t.Fatalf("off = %q", out.String())
+	}
+}
+
+func TestExecuteCountedRecordsRange(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("usage.enabled", true)
+	prevNow := Now
+	Now = func() time.Time { return time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC) }
+	// a hook of its own makes cobra skip the root's persistent hooks
+	lsCmd.PersistentPostRun = func(*cobra.Command, []string) {}
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("usage.enabled", false)
+		Now = prevNow
+		lsCmd.PersistentPostRun = nil
+		lsRange = ""
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"ls", "--range", "2025-10-06T00:00..2025-10-12T23:59"})
+	if err := executeCounted(); err != nil {
+		t.Fatal(err)
+	}
+	st, err := loadUsageStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := st.Commands["ls"]; u == nil || u.Count != 1 || u.Ranged != 1 || u.RangeDays != 7 {
+		t.Fatalf("ls = %+v (output %q)", u, out.String())
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"tt/internal/rounding"
+)
+
+// Shared journals: every event records the user who wrote it, so a small team
+// can point their ~/.tt/journal at one synced tree. The user comes from the
+// `user` config key, falling back to the OS login name. Entries written before
+// users were recorded have no user and count as everyone's.
+
+// currentUser returns the name recorded on new events.
+func currentUser() string {
+	if u := strings.TrimSpace(viper.GetString("user")); u != "" {
+		return u
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// ownEntry reports whether e belongs to the current user for commands that
+// act on "the running entry" (status, stop, note).
+func ownEntry(e Entry) bool {
+	return e.User == "" || e.User == currentUser()
+}
+
+// filterUsers keeps entries recorded by one of users (case-insensitive);
+// "me" stands for the current user. An empty list keeps everything.
+func filterUsers(ents []Entry, users []string) []Entry {
+	if len(users) == 0 {
+		return ents
+	}
+	want := map[string]bool{}
+	for _, u := range users {
+		u = strings.TrimSpace(u)
+		if strings.EqualFold(u, "me") {
+			u = currentUser()
+		}
+		if u != "" {
+			want[strings.ToLower(u)] = true
+		}
+	}
+	out := ents[:0:0]
+	for _, e := range ents {
+		if want[strings.ToLower(e.User)] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+var (
+	ruToday bool
+	ruWeek  bool
+	ruRange string
+	ruOpen  openMode
+)
+
+// reportUsersCmd breaks tracked time down per user and customer.
+var reportUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Summarize time per user (shared journals)",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := parseRangeFlags(ruToday, ruWeek, ruRange)
+		noteUsageRange(cmd, from, to)
+		entries, err := loadEntries(from, to)
+		if err != nil {
+			logger.Warn("failed to load some entries", "err", err)
+		}
+		entries, open, err := applyOpenMode(entries, ruOpen, Now())
+		cobra.CheckErr(err)
+		if len(entries) == 0 {
+			fmt.Println("No entries.")
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%sUsers:%s %s → %s\n\n", ansiHeading, ansiReset, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		printUserReport(cmd.OutOrStdout(), entries, getRounding())
+		printOpenNote(cmd.OutOrStdout(), open)
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportUsersCmd)
+	reportUsersCmd.Flags().BoolVar(&ruToday, "today", false, "today only")
+	reportUsersCmd.Flags().BoolVar(&ruWeek, "week", false, "this week (Mon..Sun)")
+	reportUsersCmd.Flags().StringVar(&ruRange, "range", "", "custom range A..B (ISO or YYYY-MM-DDTHH:MM)")
+	addIncludeOpenFlag(reportUsersCmd, &ruOpen)
+}
+
+type userTotal struct {
+	User               string
+	RawMin, RoundedMin int
+	BillableMin        int
+	Customers          map[string]int // customer -> raw minutes
+	tally              rounding.Tally
+	billableSec        int64
+	customerSec        map[string]int64
+}
+
+// aggregateUsers sums finished entries per user, sorted by raw time
+// descending. Entries without a user are reported as "(unknown)".
+func aggregateUsers(ents []Entry, r Rounding) []userTotal {
+	by := map[string]*userTotal{}
+	for _, e := range ents {
+		sec := entrySeconds(e)
+		if sec <= 0 {
+			continue
+		}
+		name := e.User
+		if name == "" {
+			name = "(unknown)"
+		}
+		t, ok := by[name]
+		if !ok {
+			t = &userTotal{User: name, Customers: map[string]int{}, customerSec: map[string]int64{}}
+			by[name] = t
+		}
+		t.tally.Add(customerPolicy(r.Policy(), e.Customer), sec)
+		if e.Billable {
+			t.billableSec += sec
+		}
+		t.customerSec[e.Customer] += sec
+	}
+	out := make([]userTotal, 0, len(by))
+	for _, t := range by {
+		// minutes are taken of the summed seconds, so precision applies per entry only
+		t.RawMin = int(t.tally.Raw / 60)
+		t.RoundedMin = int(t.tally.Rounded(r.Policy()) / 60)
+		t.BillableMin = int(t.billableSec / 60)
+		for c, s := range t.customerSec {
+			t.Customers[c] = int(s / 60)
+		}
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].RawMin != out[j].RawMin {
+			return out[i].RawMin > out[j].RawMin
+		}
+		return out[i].User < out[j].User
+	})
+	return out
+}
+
+func printUserReport(w io.Writer, ents []Entry, r Rounding) {
+	totals := aggregateUsers(ents, r)
+	if len(totals) == 0 {
+		fmt.Fprintln(w, "No finished entries in the selected range.")
+		return
+	}
+	sum := 0
+	for _, t := range totals {
+		fmt.Fprintf(w, "%-20s  %s raw  %s rounded  %s billable\n", t.User, fmtHHMM(t.RawMin), fmtHHMM(t.RoundedMin), fmtHHMM(t.BillableMin))
+		customers := make([]string, 0, len(t.Customers))
+		for c := range t.Customers {
+			customers = append(customers, c)
+		}
+		sort.Strings(customers)
+		for _, c := range customers {
+			label := c
+			if label == "" {
+				label = "(no customer)"
+			}
+			fmt.Fprintf(w, "    %-16s  %s\n", label, fmtHHMM(t.Customers[c]))
+		}
+		sum += t.RawMin
+	}
+	fmt.Fprintf(w, "TOTAL: %s\n", fmtHHMM(sum))
+}
+
+// userFlagHelp is shared by the --user flags on listing and report commands.
+const userFlagHelp = "only entries recorded by this user (repeatable or comma-separated; \"me\" = current user)"
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriterStampsUserAndReportsPerUser(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("user", "alice")
+	t.Cleanup(func() { viper.Set("timezone", ""); viper.Set("user", "") })
+
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	bobStart := NewStartEvent("b1", "Globex", "Ops", "dev", boolPtr(false), "", nil, at(9, 30))
+	bobStart.User = "bob"
+	bobStop := NewStopEvent("b2", at(10, 0))
+	bobStop.User = "bob"
+	evs := []Event{
+		NewStartEvent("a1", "Acme", "Portal", "dev", boolPtr(true), "", nil, at(9, 0)),
+		bobStart,
+		NewStopEvent("a2", at(11, 0)),
+		bobStop,
+	}
+	if err := writeEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := loadEntries(day, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ents) != 2 {
+		t.Fatalf("want 2 entries, got %+v", ents)
+	}
+	users := map[string]int{}
+	for _, e := range ents {
+		users[e.User] = durationMinutes(e)
+	}
+	if users["alice"] != 120 || users["bob"] != 30 {
+		t.Fatalf("per-user minutes = %v, want alice 120, bob 30", users)
+	}
+
+	if got := filterUsers(ents, []string{"me"}); len(got) != 1 || got[0].User != "alice" {
+		t.Errorf("--user me = %+v", got)
+	}
+	if got := filterUsers(ents, []string{"BOB"}); len(got) != 1 || got[0].User != "bob" {
+		t.Errorf("--user BOB = %+v", got)
+	}
+	if got := filterUsers(ents, nil); len(got) != 2 {
+		t.Errorf("no --user should keep all, got %d", len(got))
+	}
+
+	var buf bytes.Buffer
+	printUserReport(&buf, ents, Rounding{Strategy: "nearest", QuantumMin: 1})
+	out := buf.String()
+	if !strings.Contains(out, "alice") || strings.Index(out, "alice") > strings.Index(out, "bob") {
+		t.Errorf("want alice listed before bob:\n%s", out)
+	}
+	if !strings.Contains(out, "TOTAL: 2h30m") {
+		t.Errorf("missing total:\n%s", out)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var weekOpen openMode
+
+var weekCmd = &cobra.Command{
+	Use:   "week",
+	Short: "Show this ISO week's per-day totals and the week sum",
+	Long: `Prints one line per day of the current ISO week (Mon–Sun) with the tracked
+time and the week sum: a quick glance without the customer/project groups,
+rounding and notes of "tt report week". A running entry counts up to now
+with --include-open (the totals are then provisional) and is left out, with
+a note, otherwise.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := Now().In(parserLocation())
+		monday := weekMonday(now)
+		ents, err := loadEntries(monday, now)
+		if err != nil {
+			return err
+		}
+		ents, open, err := applyOpenMode(ents, weekOpen, now)
+		if err != nil {
+			return err
+		}
+		renderWeekTable(cmd.OutOrStdout(), weekDayTotals(ents, monday, now), monday, now, len(open.IDs) > 0)
+		printOpenNote(cmd.OutOrStdout(), open)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(weekCmd)
+	addIncludeOpenFlag(weekCmd, &weekOpen)
+}
+
+// renderWeekTable prints the week's per-day totals (minutes) and their sum;
+// today is marked, and flagged as running when an entry is still open.
+//
+//	Week 2025-W42  2025-10-13 → 2025-10-19
+//	  Mon 13  9h00m
+//	  Tue 14  -
+//	  …
+//	  Fri 17  1h30m  ← today (running)
+//	  Total   14h30m
+func renderWeekTable(w io.Writer, totals [7]int, monday, now time.Time, running bool) {
+	year, week := monday.ISOWeek()
+	fmt.Fprintf(w, "%sWeek %d-W%02d%s  %s → %s\n", ansiHeading, year, week, ansiReset,
+		monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02"))
+	sum := 0
+	for d, m := range totals {
+		day := monday.AddDate(0, 0, d)
+		sum += m
+		val, shown := "-", "-"
+		if m > 0 {
+			val = fmtHHMM(m)
+			shown = ansiHours + val + ansiReset
+		}
+		line := fmt.Sprintf("  %s  %s", day.Format("Mon 02"), shown)
+		if day.Year() == now.Year() && day.YearDay() == now.YearDay() {
+			line += strings.Repeat(" ", max(0, 6-len(val))) + "  ← today"
+			if running {
+				line += " (running)"
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "  %sTotal%s   %s%s%s\n", ansiHeading, ansiReset, ansiHours, fmtHHMM(sum), ansiReset)
+}
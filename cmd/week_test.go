@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWeekTable(t *testing.T) {
+	oldHeading, oldHours, oldReset := ansiHeading, ansiHours, ansiReset
+	ansiHeading, ansiHours, ansiReset = "", "", ""
+	t.Cleanup(func() { ansiHeading, ansiHours, ansiReset = oldHeading, oldHours, oldReset })
+
+	now := time.Date(2025, 10, 17, 11, 0, 0, 0, time.UTC) // Friday
+	var buf bytes.Buffer
+	renderWeekTable(&buf, [7]int{540, 0, 120, 120, 90, 0, 0}, weekMonday(now), now, true)
+	want := "Week 2025-W42  2025-10-13 → 2025-10-19\n" +
+		"  Mon 13  9h00m\n" +
+		"  Tue 14  -\n" +
+		"  Wed 15  2h00m\n" +
+		"  Thu 16  2h00m\n" +
+		"  Fri 17  1h30m   ← today (running)\n" +
+		"  Sat 18  -\n" +
+		"  Sun 19  -\n" +
+		"  Total   14h30m\n"
+	if buf.String() != want {
+		t.Errorf("table =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWeekCmdWritesToCommandOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	now := time.Date(2025, 10, 17, 11, 0, 0, 0, time.UTC) // Friday
+	oldNow := Now
+	Now = func() time.Time { return now }
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		Now = oldNow
+		weekCmd.SetOut(nil)
+	})
+	if err := writeEvents([]Event{
+		NewStartEvent("s1", "Acme", "", "", boolPtr(true), "", nil, now.Add(-3*time.Hour)),
+		NewStopEvent("x1", now.Add(-time.Hour)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	weekCmd.SetOut(&buf)
+	if err := weekCmd.RunE(weekCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := stripANSI(buf.String()); !strings.Contains(out, "Fri 17  2h00m") || !strings.Contains(out, "Total   2h00m") {
+		t.Fatalf("output:\n%s", out)
+	}
+}
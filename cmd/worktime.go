@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/worktime"
+)
+
+// Working-time rules warn about long stretches without a break and long
+// days (by default the German limits: a 30m break after 6h, at most 10h a
+// day). tt report week lists the violations under its hints; tt schedule
+// run and the TUI remind you live, next to the timer alerts. Nothing is
+// blocked.
+//
+// Config (~/.tt/config.yaml):
+//
+//	working_time:
+//	  enabled: true
+//	  max_stretch_min: 360   # optional overrides of the defaults
+//	  min_break_min: 30
+//	  max_day_min: 600
+
+func loadWorktimeRules() worktime.Rules {
+	if !viper.GetBool("working_time.enabled") {
+		return worktime.Rules{}
+	}
+	r := worktime.Defaults
+	for key, d := range map[string]*time.Duration{
+		"working_time.max_stretch_min": &r.MaxStretch,
+		"working_time.min_break_min":   &r.MinBreak,
+		"working_time.max_day_min":     &r.MaxDay,
+	} {
+		if viper.IsSet(key) {
+			*d = time.Duration(viper.GetInt(key)) * time.Minute
+		}
+	}
+	return r
+}
+
+// worktimeSpans are the work periods of ents; running entries end at now,
+// or are left out when now is zero.
+func worktimeSpans(ents []Entry, now time.Time) []worktime.Span {
+	out := make([]worktime.Span, 0, len(ents))
+	for _, e := range ents {
+		switch {
+		case e.End != nil:
+			out = append(out, entryWorkSpans(e, *e.End)...)
+		case !now.IsZero():
+			out = append(out, entryWorkSpans(e, now)...)
+		}
+	}
+	return out
+}
+
+// entryWorkSpans splits e, ending at end, at its pauses: a pause counts as
+// a break.
+func entryWorkSpans(e Entry, end time.Time) []worktime.Span {
+	var out []worktime.Span
+	start := e.Start
+	for _, p := range e.Pauses {
+		if !p.Start.Before(end) {
+			break
+		}
+		if p.Start.After(start) {
+			out = append(out, worktime.Span{Start: start, End: p.Start})
+		}
+		if p.End == nil || !p.End.Before(end) {
+			return out
+		}
+		if p.End.After(start) {
+			start = *p.End
+		}
+	}
+	if end.After(start) {
+		out = append(out, worktime.Span{Start: start, End: end})
+	}
+	return out
+}
+
+// worktimeIssues describes the violations per user on the days from..to,
+// for the report hints. Users are named only when there are several.
+func worktimeIssues(spans map[string][]worktime.Span, r worktime.Rules, from, to time.Time, loc *time.Location) []string {
+	if !r.Enabled() {
+		return nil
+	}
+	users := make([]string, 0, len(spans))
+	for u := range spans {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	var out []string
+	for _, u := range users {
+		for _, v := range worktime.Check(spans[u], r, loc) {
+			if v.Day.Before(from) || v.Day.After(to) {
+				continue
+			}
+			desc := v.Describe(r, loc)
+			if len(users) > 1 && u != "" {
+				desc = u + " " + desc
+			}
+			out = append(out, desc)
+		}
+	}
+	return out
+}
+
+// worktimeAlertState lists the violations already announced.
+type worktimeAlertState struct {
+	Announced []string `json:"announced"`
+}
+
+func worktimeAlertStatePath() string {
+	return filepath.Join(filepath.Dir(timerAlertStatePath()), "worktime-alert.json")
+}
+
+// dueWorktimeAlert returns a reminder for the latest violation of the
+// current stretch or day while an entry runs, or "" when there is none or
+// it was announced already. A due reminder is recorded as sent.
+func dueWorktimeAlert(r worktime.Rules, ents []Entry, now time.Time) (string, error) {
+	running := false
+	for _, e := range ents {
+		if e.End == nil {
+			running = true
+		}
+	}
+	if !r.Enabled() || !running {
+		return "", nil
+	}
+	vs := worktime.Check(worktimeSpans(ents, now), r, parserLocation())
+	var due *worktime.Violation
+	for i := range vs {
+		if now.Sub(vs[i].At) < 24*time.Hour {
+			due = &vs[i]
+		}
+	}
+	if due == nil {
+		return "", nil
+	}
+	timerAlertMu.Lock()
+	defer timerAlertMu.Unlock()
+	path := worktimeAlertStatePath()
+	var st worktimeAlertState
+	if b, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(b, &st)
+	}
+	for _, k := range st.Announced {
+		if k == due.Key() {
+			return "", nil
+		}
+	}
+	st.Announced = append(st.Announced, due.Key())
+	if n := len(st.Announced); n > 20 {
+		st.Announced = st.Announced[n-20:]
+	}
+	b, _ := json.Marshal(st)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return "", err
+	}
+	worked := strings.TrimSuffix(fmtDuration(due.Worked), "00m")
+	if due.Kind == worktime.LongDay {
+		return fmt.Sprintf("Working time: %s today, more than %s", worked, strings.TrimSuffix(fmtDuration(r.MaxDay), "00m")), nil
+	}
+	return fmt.Sprintf("Working time: %s without a %s break, time for one", worked, strings.TrimSuffix(fmtDuration(r.MinBreak), "00m")), nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"tt/internal/journal"
+	"tt/internal/worktime"
+)
+
+func TestWorktimeRulesAndAlerts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("timezone", "UTC")
+	viper.Set("working_time", map[string]any{"enabled": true, "max_day_min": 540})
+	t.Cleanup(func() {
+		viper.Set("timezone", "")
+		viper.Set("working_time", nil)
+	})
+	r := loadWorktimeRules()
+	if r.MaxStretch != 6*time.Hour || r.MinBreak != 30*time.Minute || r.MaxDay != 9*time.Hour {
+		t.Fatalf("rules = %+v", r)
+	}
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 6, h, m, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	ents := []Entry{
+		{ID: "s1", Start: at(8, 0), End: end(at(11, 0))},
+		{ID: "s2", Start: at(11, 10)}, // running; the 10m pause is no break
+	}
+	due := func(now time.Time) string {
+		t.Helper()
+		msg, err := dueWorktimeAlert(r, ents, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	if msg := due(at(13, 0)); msg != "" {
+		t.Fatalf("reminded after 4h50m: %q", msg)
+	}
+	if msg := due(at(14, 15)); msg != "Working time: 6h05m without a 30m break, time for one" {
+		t.Fatalf("at 14:15: %q", msg)
+	}
+	if msg := due(at(14, 30)); msg != "" {
+		t.Fatalf("repeated: %q", msg)
+	}
+	if msg := due(at(17, 20)); msg != "Working time: 9h10m today, more than 9h" {
+		t.Fatalf("at 17:20: %q", msg)
+	}
+	// nothing is due without a running entry
+	ents[1].End = end(at(17, 30))
+	if msg := due(at(18, 0)); msg != "" {
+		t.Fatalf("reminded after stopping: %q", msg)
+	}
+
+	// the report names users only in a shared journal
+	spans := map[string][]worktime.Span{"": worktimeSpans(ents, time.Time{})}
+	got := worktimeIssues(spans, r, at(0, 0), at(23, 59), time.UTC)
+	if len(got) != 2 || got[0] != "2025-10-06 08:00: 9h20m without a 30m break (limit 6h)" || got[1] != "2025-10-06: 9h20m worked, more than 9h" {
+		t.Fatalf("issues = %q", got)
+	}
+	spans["bob"] = []worktime.Span{{Start: at(9, 0), End: at(19, 0)}}
+	if got := worktimeIssues(spans, r, at(0, 0), at(23, 59), time.UTC); len(got) != 4 || !strings.HasPrefix(got[2], "bob 2025-10-06 09:00") {
+		t.Fatalf("shared issues = %q", got)
+	}
+	if got := worktimeIssues(spans, worktime.Rules{}, at(0, 0), at(23, 59), time.UTC); got != nil {
+		t.Fatalf("disabled rules reported %q", got)
+	}
+}
+
+func TestPausedTimeIsABreak(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 6, h, m, 0, 0, time.UTC) }
+	end := func(t time.Time) *time.Time { return &t }
+	e := Entry{ID: "s1", Start: at(8, 0), End: end(at(16, 0)), Pauses: []journal.Interval{
+		{Start: at(12, 0), End: end(at(12, 45))},
+		{Start: at(15, 30)}, // open: paused until the stop
+	}}
+	if got := entryElapsed(e, at(18, 0)); got != 7*time.Hour-15*time.Minute {
+		t.Fatalf("elapsed = %v", got)
+	}
+	if got := entrySeconds(e); got != int64((7*time.Hour-15*time.Minute)/time.Second) {
+		t.Fatalf("entrySeconds = %d", got)
+	}
+	spans := entryWorkSpans(e, *e.End)
+	if len(spans) != 2 || !spans[0].End.Equal(at(12, 0)) || !spans[1].Start.Equal(at(12, 45)) || !spans[1].End.Equal(at(15, 30)) {
+		t.Fatalf("spans = %+v", spans)
+	}
+	if vs := worktime.Check(spans, worktime.Defaults, time.UTC); len(vs) != 0 {
+		t.Fatalf("the 45m pause is a break: %+v", vs)
+	}
+}
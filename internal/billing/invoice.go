@@ -0,0 +1,181 @@
+package billing
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Invoice is one customer's bill for a period: sections of line items (one
+// per project, plus expenses), their subtotals, tax and the total.
+type Invoice struct {
+	Number   string    `json:"number"`
+	Customer string    `json:"customer"`
+	Issuer   string    `json:"issuer,omitempty"` // sender block, one line per address line
+	Issued   string    `json:"issued"`           // YYYY-MM-DD
+	Due      string    `json:"due,omitempty"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Currency string    `json:"currency"`
+	Locale   string    `json:"locale"`
+	Sections []Section `json:"sections"`
+	Subtotal float64   `json:"subtotal"` // net
+	TaxRate  float64   `json:"tax_rate"` // percent
+	Tax      float64   `json:"tax"`
+	Total    float64   `json:"total"`
+}
+
+// Section groups the line items of one project, or the expenses.
+type Section struct {
+	Title    string  `json:"title"`
+	Lines    []Line  `json:"lines"`
+	Hours    float64 `json:"hours"`
+	Subtotal float64 `json:"subtotal"`
+}
+
+// Line is one line item: billable hours of a day at a rate, or an expense
+// (quantity 1, the amount as rate).
+type Line struct {
+	Date        string  `json:"date"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit,omitempty"` // "h" for time
+	Rate        float64 `json:"rate"`
+	Amount      float64 `json:"amount"`
+}
+
+// Add appends l to the section titled title, creating it after the
+// existing ones.
+func (inv *Invoice) Add(title string, l Line) {
+	for i := range inv.Sections {
+		if inv.Sections[i].Title == title {
+			inv.Sections[i].Lines = append(inv.Sections[i].Lines, l)
+			return
+		}
+	}
+	inv.Sections = append(inv.Sections, Section{Title: title, Lines: []Line{l}})
+}
+
+// Finish prices the line items and sums them up: section subtotals, the net
+// subtotal, taxRate percent of tax on it and the total. Amounts are rounded
+// to cents per line, so the sums match what the lines show.
+func (inv *Invoice) Finish(taxRate float64) {
+	inv.Subtotal = 0
+	for i := range inv.Sections {
+		s := &inv.Sections[i]
+		s.Hours, s.Subtotal = 0, 0
+		for j := range s.Lines {
+			l := &s.Lines[j]
+			l.Amount = RoundCents(l.Quantity * l.Rate)
+			if l.Unit == "h" {
+				s.Hours += l.Quantity
+			}
+			s.Subtotal += l.Amount
+		}
+		s.Hours = RoundCents(s.Hours)
+		s.Subtotal = RoundCents(s.Subtotal)
+		inv.Subtotal += s.Subtotal
+	}
+	inv.Subtotal = RoundCents(inv.Subtotal)
+	inv.TaxRate = taxRate
+	inv.Tax = RoundCents(inv.Subtotal * taxRate / 100)
+	inv.Total = RoundCents(inv.Subtotal + inv.Tax)
+}
+
+// Empty reports whether the invoice has no line items.
+func (inv Invoice) Empty() bool { return len(inv.Sections) == 0 }
+
+// FormatMoney formats amount in the invoice's currency and locale.
+func (inv Invoice) FormatMoney(amount float64) string {
+	return FormatMoney(amount, inv.Currency, inv.Locale)
+}
+
+// Formats are the output formats of Render.
+var Formats = []string{"markdown", "json", "html"}
+
+// Render writes inv as markdown, json or html.
+func Render(w io.Writer, inv Invoice, format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "markdown", "md":
+		return renderMarkdown(w, inv)
+	case "json":
+		b, err := json.MarshalIndent(inv, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case "html":
+		return htmlTemplate.Execute(w, inv)
+	}
+	return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, "|"))
+}
+
+func renderMarkdown(w io.Writer, inv Invoice) error {
+	fmt.Fprintf(w, "# Invoice %s\n\n", inv.Number)
+	if inv.Issuer != "" {
+		fmt.Fprintf(w, "%s\n\n", strings.ReplaceAll(strings.TrimSpace(inv.Issuer), "\n", "  \n"))
+	}
+	fmt.Fprintf(w, "**Customer:** %s  \n**Period:** %s – %s  \n**Date:** %s", inv.Customer, inv.From, inv.To, inv.Issued)
+	if inv.Due != "" {
+		fmt.Fprintf(w, "  \n**Due:** %s", inv.Due)
+	}
+	fmt.Fprint(w, "\n\n")
+	for _, s := range inv.Sections {
+		fmt.Fprintf(w, "## %s\n\n", s.Title)
+		fmt.Fprintln(w, "| Date | Description | Quantity | Rate | Amount |")
+		fmt.Fprintln(w, "|---|---|---:|---:|---:|")
+		for _, l := range s.Lines {
+			fmt.Fprintf(w, "| %s | %s | %.2f%s | %s | %s |\n", l.Date, strings.ReplaceAll(l.Description, "|", `\|`),
+				l.Quantity, l.Unit, inv.FormatMoney(l.Rate), inv.FormatMoney(l.Amount))
+		}
+		hours := ""
+		if s.Hours > 0 {
+			hours = fmt.Sprintf("%.2fh", s.Hours)
+		}
+		fmt.Fprintf(w, "| | **Subtotal %s** | %s | | **%s** |\n\n", s.Title, hours, inv.FormatMoney(s.Subtotal))
+	}
+	fmt.Fprintf(w, "**Net:** %s  \n", inv.FormatMoney(inv.Subtotal))
+	fmt.Fprintf(w, "**Tax (%g%%):** %s  \n", inv.TaxRate, inv.FormatMoney(inv.Tax))
+	_, err := fmt.Fprintf(w, "**Total:** %s\n", inv.FormatMoney(inv.Total))
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"lines": func(s string) []string { return strings.Split(strings.TrimSpace(s), "\n") },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{if eq .Locale "en"}}en{{else}}de{{end}}">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Number}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { padding: 0.2em 0.6em; text-align: left; vertical-align: top; }
+td.num, th.num { text-align: right; }
+.issuer { white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>Invoice {{.Number}}</h1>
+{{with .Issuer}}<p class="issuer">{{range $i, $l := lines .}}{{if $i}}<br>{{end}}{{$l}}{{end}}</p>
+{{end}}<p><strong>Customer:</strong> {{.Customer}}<br>
+<strong>Period:</strong> {{.From}} – {{.To}}<br>
+<strong>Date:</strong> {{.Issued}}{{with .Due}}<br>
+<strong>Due:</strong> {{.}}{{end}}</p>
+{{range .Sections}}<h2>{{.Title}}</h2>
+<table>
+<tr><th>Date</th><th>Description</th><th class="num">Quantity</th><th class="num">Rate</th><th class="num">Amount</th></tr>
+{{range .Lines}}<tr><td>{{.Date}}</td><td>{{.Description}}</td><td class="num">{{printf "%.2f" .Quantity}}{{.Unit}}</td><td class="num">{{$.FormatMoney .Rate}}</td><td class="num">{{$.FormatMoney .Amount}}</td></tr>
+{{end}}<tr><th></th><th>Subtotal {{.Title}}</th><th class="num">{{if .Hours}}{{printf "%.2f" .Hours}}h{{end}}</th><th></th><th class="num">{{$.FormatMoney .Subtotal}}</th></tr>
+</table>
+{{end}}<table>
+<tr><th>Net</th><td class="num">{{.FormatMoney .Subtotal}}</td></tr>
+<tr><th>Tax ({{.TaxRate}}%)</th><td class="num">{{.FormatMoney .Tax}}</td></tr>
+<tr><th>Total</th><td class="num"><strong>{{.FormatMoney .Total}}</strong></td></tr>
+</table>
+</body>
+</html>
+`))
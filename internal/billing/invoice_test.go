@@ -0,0 +1,79 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleInvoice() Invoice {
+	inv := Invoice{Number: "ACME-2025-10", Customer: "Acme", Issued: "2025-11-03", Due: "2025-11-17",
+		From: "2025-10-01", To: "2025-10-31", Currency: "EUR", Locale: "de", Issuer: "Jane Doe\nMain St 1"}
+	inv.Add("portal", Line{Date: "2025-10-06", Description: "login | review", Quantity: 1.5, Unit: "h", Rate: 140})
+	inv.Add("Expenses", Line{Date: "2025-10-06", Description: "train ticket", Quantity: 1, Rate: 49.9})
+	inv.Add("portal", Line{Date: "2025-10-07", Description: "deploy", Quantity: 0.25, Unit: "h", Rate: 140})
+	inv.Finish(19)
+	return inv
+}
+
+func TestInvoiceFinish(t *testing.T) {
+	inv := sampleInvoice()
+	if len(inv.Sections) != 2 || inv.Sections[0].Title != "portal" || len(inv.Sections[0].Lines) != 2 {
+		t.Fatalf("sections = %+v", inv.Sections)
+	}
+	portal := inv.Sections[0]
+	if portal.Hours != 1.75 || portal.Subtotal != 245 || portal.Lines[0].Amount != 210 {
+		t.Fatalf("portal = %+v", portal)
+	}
+	if inv.Sections[1].Hours != 0 || inv.Sections[1].Subtotal != 49.9 {
+		t.Fatalf("expenses = %+v", inv.Sections[1])
+	}
+	// 294.90 net, 19% tax 56.031 -> 56.03
+	if inv.Subtotal != 294.9 || inv.Tax != 56.03 || inv.Total != 350.93 {
+		t.Fatalf("totals = %v + %v = %v", inv.Subtotal, inv.Tax, inv.Total)
+	}
+}
+
+func TestRenderInvoice(t *testing.T) {
+	inv := sampleInvoice()
+	var md bytes.Buffer
+	if err := Render(&md, inv, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Invoice ACME-2025-10",
+		"Jane Doe  \nMain St 1",
+		"| 2025-10-06 | login \\| review | 1.50h | 140,00 € | 210,00 € |",
+		"| | **Subtotal portal** | 1.75h | | **245,00 €** |",
+		"**Tax (19%):** 56,03 €",
+		"**Total:** 350,93 €",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown lacks %q:\n%s", want, md.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := Render(&js, inv, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var back Invoice
+	if err := json.Unmarshal(js.Bytes(), &back); err != nil || back.Total != inv.Total || len(back.Sections) != 2 {
+		t.Fatalf("json round trip = %+v, %v", back, err)
+	}
+
+	var html bytes.Buffer
+	if err := Render(&html, inv, "html"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<title>Invoice ACME-2025-10</title>", "Jane Doe<br>Main St 1", "login | review", "<strong>350,93 €</strong>"} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("html lacks %q:\n%s", want, html.String())
+		}
+	}
+
+	if err := Render(&html, inv, "pdf"); err == nil || !strings.Contains(err.Error(), "markdown|json|html") {
+		t.Fatalf("unknown format err = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package billing
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencySymbols are the currencies written with a symbol; others use
+// their ISO code.
+var currencySymbols = map[string]string{"EUR": "€", "USD": "$", "GBP": "£", "JPY": "¥"}
+
+// FormatMoney formats amount in currency for locale: "en" writes €1,234.50,
+// anything else the German 1.234,50 €. Currencies without a symbol keep
+// their code (CHF 1,234.50 / 1.234,50 CHF).
+func FormatMoney(amount float64, currency, locale string) string {
+	if strings.EqualFold(locale, "en") {
+		return PlaceCurrency(FormatNumber(RoundCents(amount), 2, ".", ","), currency, true)
+	}
+	return PlaceCurrency(FormatNumber(RoundCents(amount), 2, ",", "."), currency, false)
+}
+
+// FormatNumber formats v with prec decimals, the decimal separator decimal
+// ("" is ".") and thousands between groups of three digits ("" for none).
+func FormatNumber(v float64, prec int, decimal, thousands string) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', prec, 64)
+	digits, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		if decimal == "" {
+			decimal = "."
+		}
+		b.WriteString(decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// PlaceCurrency writes currency's symbol, or its code, next to the
+// formatted number num: before it when first (€1,234.50, CHF 1,234.50),
+// after it otherwise (1.234,50 €). A minus sign stays in front.
+func PlaceCurrency(num, currency string, first bool) string {
+	sign := ""
+	if rest, ok := strings.CutPrefix(num, "-"); ok {
+		sign, num = "-", rest
+	}
+	code := strings.ToUpper(currency)
+	sym, ok := currencySymbols[code]
+	switch {
+	case first && ok:
+		return sign + sym + num
+	case first:
+		return sign + code + " " + num
+	case ok:
+		return sign + num + " " + sym
+	}
+	return sign + num + " " + code
+}
+
+// RoundCents rounds v half away from zero to whole cents.
+func RoundCents(v float64) float64 {
+	if v < 0 {
+		return -RoundCents(-v)
+	}
+	return float64(int64(v*100+0.5)) / 100
+}
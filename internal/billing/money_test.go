@@ -0,0 +1,23 @@
+package billing
+
+import "testing"
+
+func TestFormatMoney(t *testing.T) {
+	for _, tc := range []struct {
+		amount           float64
+		currency, locale string
+		want             string
+	}{
+		{1234.5, "EUR", "de", "1.234,50 €"},
+		{1234.5, "EUR", "en", "€1,234.50"},
+		{1234567.891, "usd", "en", "$1,234,567.89"},
+		{0.5, "CHF", "de", "0,50 CHF"},
+		{999, "CHF", "en", "CHF 999.00"},
+		{-12.3, "EUR", "de", "-12,30 €"},
+		{-0.001, "EUR", "de", "0,00 €"},
+	} {
+		if got := FormatMoney(tc.amount, tc.currency, tc.locale); got != tc.want {
+			t.Errorf("FormatMoney(%v, %s, %s) = %q; want %q", tc.amount, tc.currency, tc.locale, got, tc.want)
+		}
+	}
+}
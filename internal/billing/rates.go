@@ -0,0 +1,72 @@
+// Package billing prices tracked time: it resolves hourly rates from the
+// rates hierarchy (customer project, customer, default), formats amounts in
+// a currency and builds invoices with line items, subtotals and tax.
+package billing
+
+import "strings"
+
+// Rates is the rates config:
+//
+//	rates:
+//	  currency: EUR
+//	  default: 90
+//	  customers:
+//	    acme:
+//	      rate: 120
+//	      projects:
+//	        portal: 140
+//
+// Customer and project names are matched case-insensitively.
+type Rates struct {
+	Currency  string
+	Default   *float64
+	Customers map[string]CustomerRates // lower-case customer
+}
+
+// CustomerRates are one customer's rate and project rates.
+type CustomerRates struct {
+	Rate     *float64
+	Projects map[string]float64 // lower-case project
+}
+
+// RateSource says which level of the hierarchy a rate came from.
+type RateSource string
+
+const (
+	SourceProject  RateSource = "project"
+	SourceCustomer RateSource = "customer"
+	SourceDefault  RateSource = "default"
+)
+
+// Rate returns the hourly rate for customer/project and whether any rate
+// applies. The most specific rate wins: customer project, then customer,
+// then default.
+func (r Rates) Rate(customer, project string) (float64, bool) {
+	rate, src := r.Resolve(customer, project)
+	return rate, src != ""
+}
+
+// Resolve is Rate with the level the rate came from; "" when none applies.
+func (r Rates) Resolve(customer, project string) (float64, RateSource) {
+	c, ok := r.Customers[strings.ToLower(customer)]
+	if ok && customer != "" {
+		if rate, ok := c.Projects[strings.ToLower(project)]; ok && project != "" {
+			return rate, SourceProject
+		}
+		if c.Rate != nil {
+			return *c.Rate, SourceCustomer
+		}
+	}
+	if r.Default != nil {
+		return *r.Default, SourceDefault
+	}
+	return 0, ""
+}
+
+// CurrencyOrDefault is the configured currency, EUR when unset.
+func (r Rates) CurrencyOrDefault() string {
+	if r.Currency != "" {
+		return r.Currency
+	}
+	return "EUR"
+}
@@ -0,0 +1,36 @@
+package billing
+
+import "testing"
+
+func TestRatesResolve(t *testing.T) {
+	def, acme := 90.0, 120.0
+	r := Rates{
+		Default: &def,
+		Customers: map[string]CustomerRates{
+			"acme":   {Rate: &acme, Projects: map[string]float64{"portal": 140}},
+			"globex": {Projects: map[string]float64{"app": 100}},
+		},
+	}
+	for _, tc := range []struct {
+		customer, project string
+		want              float64
+		src               RateSource
+	}{
+		{"ACME", "Portal", 140, SourceProject},
+		{"acme", "other", 120, SourceCustomer},
+		{"acme", "", 120, SourceCustomer},
+		{"Globex", "app", 100, SourceProject},
+		{"globex", "web", 90, SourceDefault}, // no customer rate: falls back to the default
+		{"", "portal", 90, SourceDefault},
+	} {
+		if got, src := r.Resolve(tc.customer, tc.project); got != tc.want || src != tc.src {
+			t.Errorf("Resolve(%q, %q) = %v %s; want %v %s", tc.customer, tc.project, got, src, tc.want, tc.src)
+		}
+	}
+	if _, ok := (Rates{}).Rate("acme", "portal"); ok {
+		t.Error("empty rates resolved a rate")
+	}
+	if c := (Rates{}).CurrencyOrDefault(); c != "EUR" {
+		t.Errorf("currency = %q", c)
+	}
+}
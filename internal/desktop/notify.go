@@ -0,0 +1,66 @@
+// Package desktop shows desktop notifications through the platform's own
+// tools: notify-send (libnotify) on Linux and the BSDs, osascript on macOS
+// and a PowerShell toast on Windows. Nothing is linked in; when the tool is
+// missing Notify returns an error and callers fall back to their own output.
+package desktop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnsupported is returned on platforms without a known notifier.
+var ErrUnsupported = errors.New("desktop notifications are not supported on " + runtime.GOOS)
+
+// command builds the notifier invocation; tests replace it.
+var command = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// Notify shows a notification with title and body.
+func Notify(ctx context.Context, title, body string) error {
+	name, args, err := notifier(runtime.GOOS, title, body)
+	if err != nil {
+		return err
+	}
+	out, err := command(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// notifier returns the command showing a notification on goos.
+func notifier(goos, title, body string) (string, []string, error) {
+	switch goos {
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return "notify-send", []string{"--app-name=tt", title, body}, nil
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleString(body), appleString(title))
+		return "osascript", []string{"-e", script}, nil
+	case "windows":
+		script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null;` +
+			`$x = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02);` +
+			`$t = $x.GetElementsByTagName('text'); $t.Item(0).InnerText = ` + psString(title) + `; $t.Item(1).InnerText = ` + psString(body) + `;` +
+			`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('tt').Show([Windows.UI.Notifications.ToastNotification]::new($x))`
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}, nil
+	}
+	return "", nil, ErrUnsupported
+}
+
+// appleString quotes s as an AppleScript string literal.
+func appleString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// psString quotes s as a single-quoted PowerShell string literal.
+func psString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,45 @@
+package desktop
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNotifier(t *testing.T) {
+	name, args, err := notifier("linux", "tt", "You've been on acme/portal for 2h")
+	if err != nil || name != "notify-send" || args[len(args)-1] != "You've been on acme/portal for 2h" {
+		t.Fatalf("linux = %s %q, %v", name, args, err)
+	}
+	name, args, _ = notifier("darwin", `say "hi"`, `a\b`)
+	if name != "osascript" || args[1] != `display notification "a\\b" with title "say \"hi\""` {
+		t.Fatalf("darwin = %s %q", name, args)
+	}
+	_, args, _ = notifier("windows", "tt", "it's late")
+	if !strings.Contains(args[len(args)-1], "'it''s late'") {
+		t.Fatalf("windows = %q", args)
+	}
+	if _, _, err := notifier("plan9", "tt", "x"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("plan9 err = %v", err)
+	}
+}
+
+func TestNotifyReportsToolOutput(t *testing.T) {
+	if _, _, err := notifier(runtime.GOOS, "", ""); err != nil {
+		t.Skip(err)
+	}
+	orig := command
+	t.Cleanup(func() { command = orig })
+	command = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sh", "-c", "echo no display >&2; exit 1")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	if err := Notify(context.Background(), "tt", "x"); err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package idle reports how long the user has been idle (no keyboard or mouse
+// input) through the platform's own tools: xprintidle on X11, Mutter's
+// IdleMonitor over D-Bus on GNOME Wayland and ioreg on macOS. Like
+// internal/desktop nothing is linked in; a Command source runs any tool that
+// prints the idle time in milliseconds, for compositors without one of those.
+package idle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by Detect when no idle source is known for the
+// platform or session.
+var ErrUnsupported = errors.New("idle detection is not supported on " + runtime.GOOS)
+
+// Source reports the current idle time.
+type Source interface {
+	Idle(ctx context.Context) (time.Duration, error)
+}
+
+// command builds a tool invocation; tests replace it.
+var command = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// Command is a Source running Name with Args and parsing its output with
+// Parse. A nil Parse reads a plain number of milliseconds.
+type Command struct {
+	Name  string
+	Args  []string
+	Parse func(out string) (time.Duration, error)
+}
+
+// Idle runs the command and parses its output.
+func (c Command) Idle(ctx context.Context) (time.Duration, error) {
+	out, err := command(ctx, c.Name, c.Args...).Output()
+	if err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) && len(ee.Stderr) > 0 {
+			return 0, fmt.Errorf("%s: %w: %s", c.Name, err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return 0, fmt.Errorf("%s: %w", c.Name, err)
+	}
+	parse := c.Parse
+	if parse == nil {
+		parse = parseMillis
+	}
+	d, err := parse(string(out))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", c.Name, err)
+	}
+	return d, nil
+}
+
+// Detect returns the idle source of the current platform and session.
+func Detect() (Source, error) {
+	return detect(runtime.GOOS, os.Getenv)
+}
+
+// detect picks the source for goos; getenv tells X11 and Wayland sessions
+// apart.
+func detect(goos string, getenv func(string) string) (Source, error) {
+	switch goos {
+	case "darwin":
+		return Command{Name: "ioreg", Args: []string{"-c", "IOHIDSystem", "-d", "4"}, Parse: parseIOReg}, nil
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		// XWayland sets DISPLAY too, but only sees input to X clients.
+		if getenv("WAYLAND_DISPLAY") != "" {
+			return Command{Name: "gdbus", Args: []string{
+				"call", "--session",
+				"--dest", "org.gnome.Mutter.IdleMonitor",
+				"--object-path", "/org/gnome/Mutter/IdleMonitor/Core",
+				"--method", "org.gnome.Mutter.IdleMonitor.GetIdletime",
+			}, Parse: parseGVariantUint}, nil
+		}
+		if getenv("DISPLAY") != "" {
+			return Command{Name: "xprintidle"}, nil
+		}
+		return nil, errors.New("idle detection needs a graphical session (neither WAYLAND_DISPLAY nor DISPLAY is set)")
+	}
+	return nil, ErrUnsupported
+}
+
+// parseMillis reads a plain number of milliseconds (xprintidle).
+func parseMillis(out string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("want milliseconds, got %q", strings.TrimSpace(out))
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+var gvariantUint = regexp.MustCompile(`\(uint64 (\d+),\)`)
+
+// parseGVariantUint reads gdbus's "(uint64 12345,)" reply in milliseconds.
+func parseGVariantUint(out string) (time.Duration, error) {
+	m := gvariantUint.FindStringSubmatch(out)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected reply %q", strings.TrimSpace(out))
+	}
+	return parseMillis(m[1])
+}
+
+var ioregIdle = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// parseIOReg reads HIDIdleTime (nanoseconds) from ioreg's listing.
+func parseIOReg(out string) (time.Duration, error) {
+	m := ioregIdle.FindStringSubmatch(out)
+	if m == nil {
+		return 0, errors.New("no HIDIdleTime in ioreg output")
+	}
+	ns, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ns), nil
+}
@@ -0,0 +1,74 @@
+package idle
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetect(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	src, err := detect("linux", env(map[string]string{"DISPLAY": ":0", "WAYLAND_DISPLAY": "wayland-0"}))
+	if err != nil || src.(Command).Name != "gdbus" {
+		t.Fatalf("wayland = %+v, %v", src, err)
+	}
+	if src, _ := detect("linux", env(map[string]string{"DISPLAY": ":0"})); src.(Command).Name != "xprintidle" {
+		t.Fatalf("x11 = %+v", src)
+	}
+	if _, err := detect("linux", env(nil)); err == nil || !strings.Contains(err.Error(), "graphical session") {
+		t.Fatalf("headless err = %v", err)
+	}
+	if src, _ := detect("darwin", env(nil)); src.(Command).Name != "ioreg" {
+		t.Fatalf("darwin = %+v", src)
+	}
+	if _, err := detect("plan9", env(nil)); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("plan9 err = %v", err)
+	}
+}
+
+func TestParsers(t *testing.T) {
+	for _, tc := range []struct {
+		parse func(string) (time.Duration, error)
+		out   string
+		want  time.Duration
+	}{
+		{parseMillis, "61500\n", 61500 * time.Millisecond},
+		{parseGVariantUint, "(uint64 12000,)\n", 12 * time.Second},
+		{parseIOReg, `    | |   "HIDIdleTime" = 3000000000` + "\n", 3 * time.Second},
+	} {
+		if got, err := tc.parse(tc.out); err != nil || got != tc.want {
+			t.Errorf("parse(%q) = %v, %v; want %v", tc.out, got, err, tc.want)
+		}
+	}
+	if _, err := parseMillis("no X"); err == nil {
+		t.Error("parseMillis accepted garbage")
+	}
+	if _, err := parseIOReg(""); err == nil {
+		t.Error("parseIOReg accepted empty output")
+	}
+}
+
+func TestCommandIdle(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	orig := command
+	t.Cleanup(func() { command = orig })
+	command = func(ctx context.Context, name string, _ ...string) *exec.Cmd {
+		if name == "broken" {
+			return exec.CommandContext(ctx, "sh", "-c", "echo no display >&2; exit 1")
+		}
+		return exec.CommandContext(ctx, "sh", "-c", "echo 90000")
+	}
+	if d, err := (Command{Name: "xprintidle"}).Idle(context.Background()); err != nil || d != 90*time.Second {
+		t.Fatalf("idle = %v, %v", d, err)
+	}
+	if _, err := (Command{Name: "broken"}).Idle(context.Background()); err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+// Package index keeps reconstructed journal entries in a bbolt database so
+// range queries over years of day files do not re-parse them. Each day file
+// is recorded with its size, modification time and SHA-256: an unchanged
+// size and mtime answer from the index without reading the file, a changed
+// mtime with the same contents only refreshes the record, and anything else
+// is re-parsed.
+package index
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+
+	"tt/internal/journal"
+)
+
+// formatVersion is bumped whenever journal.Entry, the record layout or how
+// the parser reconstructs entries changes; an index with another version is emptied on Open.
+const formatVersion = "3"
+
+// ErrBusy is returned by Open when another process holds the database.
+var ErrBusy = errors.New("index in use by another process")
+
+var (
+	metaBucket  = []byte("meta")
+	filesBucket = []byte("files")
+	versionKey  = []byte("version")
+)
+
+// Index answers journal.Parser.ParseFile from the database; set it as the
+// parser's Cache. It is safe for concurrent use. Records of re-parsed files
+// are written in one transaction by Flush (or Close).
+type Index struct {
+	Path string
+
+	db *bolt.DB
+
+	mu      sync.Mutex
+	pending map[string]*record // journal path -> record; nil deletes it
+
+	// counters for tests and diagnostics
+	hits   int
+	misses int
+}
+
+// record is the stored value of one journal file.
+type record struct {
+	Size     int64
+	ModTime  int64 // UnixNano
+	Hash     string
+	Location string // parser location the entries were built with
+	Strict   bool
+	Entries  []journal.Entry
+}
+
+// Open opens (creating if needed) the index database at path. Another
+// process holding the database makes Open fail with ErrBusy after timeout
+// rather than block; callers then parse without the index.
+func Open(path string, timeout time.Duration) (*Index, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if errors.Is(err, bolterrors.ErrTimeout) {
+		return nil, ErrBusy
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+		if string(meta.Get(versionKey)) != formatVersion {
+			if tx.Bucket(filesBucket) != nil {
+				if err := tx.DeleteBucket(filesBucket); err != nil {
+					return err
+				}
+			}
+			if err := meta.Put(versionKey, []byte(formatVersion)); err != nil {
+				return err
+			}
+		}
+		_, err = tx.CreateBucketIfNotExists(filesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Index{Path: path, db: db, pending: map[string]*record{}}, nil
+}
+
+// ParseFile behaves like p.ParseFile but answers from the index when the
+// journal file is unchanged since it was last parsed with the same settings.
+func (ix *Index) ParseFile(p *journal.Parser, path string) ([]journal.Entry, error) {
+	if p == nil {
+		p = journal.NewParser("")
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			ix.forget(path)
+		}
+		return nil, err
+	}
+	loc := journal.LocationName(p.Location)
+	rec, _ := ix.lookup(path)
+	usable := rec != nil && rec.Location == loc && rec.Strict == p.Strict
+	if usable && rec.Size == fi.Size() && rec.ModTime == fi.ModTime().UnixNano() {
+		ix.count(true)
+		return journal.CopyEntries(rec.Entries), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hash := hashOf(data)
+	if usable && rec.Hash == hash {
+		// touched but not changed: keep the entries, remember the new stat
+		ix.count(true)
+		ix.put(path, &record{Size: fi.Size(), ModTime: fi.ModTime().UnixNano(), Hash: hash, Location: loc, Strict: p.Strict, Entries: rec.Entries})
+		return journal.CopyEntries(rec.Entries), nil
+	}
+	ix.count(false)
+	ents, err := p.ParseBytes(data, path)
+	if err != nil {
+		return nil, err
+	}
+	ix.put(path, &record{Size: fi.Size(), ModTime: fi.ModTime().UnixNano(), Hash: hash, Location: loc, Strict: p.Strict, Entries: journal.CopyEntries(ents)})
+	return ents, nil
+}
+
+// Status reports how many of paths the index would answer as they are now,
+// regardless of the parser settings they were indexed with. It neither
+// parses nor changes the index. Files that cannot be read are skipped.
+func (ix *Index) Status(paths []string) journal.CacheStatus {
+	var st journal.CacheStatus
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		rec, _ := ix.lookup(path)
+		switch {
+		case rec == nil:
+			st.Missing++
+		case rec.Size == fi.Size() && rec.ModTime == fi.ModTime().UnixNano():
+			st.Fresh++
+		default:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if rec.Hash == hashOf(data) {
+				st.Fresh++
+			} else {
+				st.Stale++
+			}
+		}
+	}
+	return st
+}
+
+// Len is the number of journal files in the index.
+func (ix *Index) Len() int {
+	n := 0
+	ix.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(filesBucket).Stats().KeyN
+		return nil
+	})
+	return n
+}
+
+// Flush writes the records of files parsed (or removed) since the last
+// Flush in a single transaction.
+func (ix *Index) Flush() error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if len(ix.pending) == 0 {
+		return nil
+	}
+	err := ix.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(filesBucket)
+		for path, rec := range ix.pending {
+			if rec == nil {
+				if err := b.Delete([]byte(path)); err != nil {
+					return err
+				}
+				continue
+			}
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(rec); err != nil {
+				return err
+			}
+			if err := b.Put([]byte(path), buf.Bytes()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	ix.pending = map[string]*record{}
+	return nil
+}
+
+// Close flushes pending records and closes the database.
+func (ix *Index) Close() error {
+	return errors.Join(ix.Flush(), ix.db.Close())
+}
+
+// Stats returns how many ParseFile calls were answered from the index and
+// how many re-parsed their file.
+func (ix *Index) Stats() (hits, misses int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.hits, ix.misses
+}
+
+// lookup returns the record of path: a pending one first, then the stored
+// one. A record that does not decode is treated as missing.
+func (ix *Index) lookup(path string) (*record, error) {
+	ix.mu.Lock()
+	rec, ok := ix.pending[path]
+	ix.mu.Unlock()
+	if ok {
+		return rec, nil
+	}
+	err := ix.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(filesBucket).Get([]byte(path))
+		if v == nil {
+			return nil
+		}
+		var r record
+		if err := gob.NewDecoder(bytes.NewReader(v)).Decode(&r); err != nil {
+			return err
+		}
+		rec = &r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (ix *Index) put(path string, rec *record) {
+	ix.mu.Lock()
+	ix.pending[path] = rec
+	ix.mu.Unlock()
+}
+
+// forget drops the record of a journal file that no longer exists.
+func (ix *Index) forget(path string) {
+	if rec, _ := ix.lookup(path); rec != nil {
+		ix.put(path, nil)
+	}
+}
+
+func (ix *Index) count(hit bool) {
+	ix.mu.Lock()
+	if hit {
+		ix.hits++
+	} else {
+		ix.misses++
+	}
+	ix.mu.Unlock()
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tt/internal/journal"
+)
+
+func TestIndex_HitsTouchesAndReparses(t *testing.T) {
+	dir := t.TempDir()
+	jpath := filepath.Join(dir, "2025-01-01.jsonl")
+	day := `{"id":"s1","type":"start","ts":"2025-01-01T09:00:00Z","customer":"ACME","note":"one"}
+{"id":"st1","type":"stop","ts":"2025-01-01T10:00:00Z"}
+`
+	if err := os.WriteFile(jpath, []byte(day), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(dir, "cache", "index.db")
+	p := journal.NewParser("UTC")
+
+	ix, err := Open(dbPath, time.Second)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	p.Cache = ix
+	ents, err := p.ParseFile(jpath)
+	if err != nil || len(ents) != 1 || ents[0].Customer != "ACME" || ents[0].Source != jpath {
+		t.Fatalf("ParseFile = %+v, %v", ents, err)
+	}
+	if err := ix.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Reopened, the unchanged file is answered without parsing.
+	ix, err = Open(dbPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ix.Close()
+	if ix.Len() != 1 {
+		t.Fatalf("Len = %d", ix.Len())
+	}
+	cached, err := parseIndexed(ix, p, jpath)
+	if err != nil || len(cached) != 1 || !cached[0].End.Equal(*ents[0].End) {
+		t.Fatalf("cached = %+v, %v", cached, err)
+	}
+	cached[0].Notes[0] = "mutated"
+	again, _ := parseIndexed(ix, p, jpath)
+	if again[0].Notes[0] != "one" {
+		t.Fatalf("index aliased caller slices: %v", again[0].Notes)
+	}
+	if h, m := ix.Stats(); h != 2 || m != 0 {
+		t.Fatalf("hits=%d misses=%d", h, m)
+	}
+
+	// A new mtime with the same contents is still a hit.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(jpath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if st := ix.Status([]string{jpath}); st.Fresh != 1 {
+		t.Fatalf("Status after touch = %+v", st)
+	}
+	if _, err := ix.ParseFile(p, jpath); err != nil {
+		t.Fatal(err)
+	}
+	if h, m := ix.Stats(); h != 3 || m != 0 {
+		t.Fatalf("after touch hits=%d misses=%d", h, m)
+	}
+
+	// Appending changes the file and forces a re-parse.
+	f, err := os.OpenFile(jpath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"id":"a1","type":"amend","ts":"2025-01-01T11:00:00Z","ref":"s1","customer":"Globex"}` + "\n")
+	f.Close()
+	if st := ix.Status([]string{jpath}); st.Stale != 1 {
+		t.Fatalf("Status after append = %+v", st)
+	}
+	amended, err := parseIndexed(ix, p, jpath)
+	if err != nil || len(amended) != 1 || amended[0].Customer != "Globex" {
+		t.Fatalf("amended = %+v, %v", amended, err)
+	}
+	if _, m := ix.Stats(); m != 1 {
+		t.Fatalf("misses = %d", m)
+	}
+
+	// Another timezone is not answered from entries built for UTC.
+	if _, err := ix.ParseFile(journal.NewParser("Europe/Berlin"), jpath); err != nil {
+		t.Fatal(err)
+	}
+	if _, m := ix.Stats(); m != 2 {
+		t.Fatalf("misses after timezone change = %d", m)
+	}
+
+	// A removed day file is dropped from the index.
+	os.Remove(jpath)
+	if _, err := ix.ParseFile(p, jpath); !os.IsNotExist(err) {
+		t.Fatalf("removed file: err = %v", err)
+	}
+	if err := ix.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if ix.Len() != 0 {
+		t.Fatalf("Len after removal = %d", ix.Len())
+	}
+}
+
+// parseIndexed is ix.ParseFile without the journal's carry-over records.
+func parseIndexed(ix *Index, p *journal.Parser, path string) ([]journal.Entry, error) {
+	ents, err := ix.ParseFile(p, path)
+	var out []journal.Entry
+	for _, e := range ents {
+		if e.Carried == nil {
+			out = append(out, e)
+		}
+	}
+	return out, err
+}
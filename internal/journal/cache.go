@@ -0,0 +1,245 @@
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheFormatVersion is bumped whenever Entry, the cache layout or how entries
+// are reconstructed changes so stale cache files are discarded instead of
+// decoded into the wrong shape.
+const cacheFormatVersion = 5
+
+// EntryCache keeps reconstructed entries in one gob file per month so repeated
+// loads (TUI cold start, long-range reports) skip JSON decoding and correction
+// replay. Each cached day is validated by the SHA-256 of the journal file
+// contents; any edit to the file invalidates just that day.
+//
+// An EntryCache is safe for concurrent use. Call Flush to persist months that
+// were updated.
+type EntryCache struct {
+	Dir string
+
+	mu     sync.Mutex
+	months map[string]*monthCache
+	dirty  map[string]bool
+
+	// counters for tests and diagnostics
+	hits   int
+	misses int
+}
+
+// monthCache is the on-disk payload of a single month file.
+type monthCache struct {
+	Version int
+	Files   map[string]cachedFile // journal path -> parsed entries
+}
+
+type cachedFile struct {
+	Hash     string
+	Location string // parser location the entries were built with
+	Strict   bool
+	Entries  []Entry
+}
+
+// NewEntryCache returns a cache storing its month files below dir.
+func NewEntryCache(dir string) *EntryCache {
+	return &EntryCache{
+		Dir:    dir,
+		months: map[string]*monthCache{},
+		dirty:  map[string]bool{},
+	}
+}
+
+// ParseFile behaves like p.ParseFile but answers from the cache when the
+// journal file is unchanged since it was last parsed with the same settings.
+func (c *EntryCache) ParseFile(p *Parser, path string) ([]Entry, error) {
+	if p == nil {
+		p = NewParser("")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	loc := LocationName(p.Location)
+	key := monthKeyFor(path)
+
+	c.mu.Lock()
+	mc := c.loadMonthLocked(key)
+	if cf, ok := mc.Files[path]; ok && cf.Hash == hash && cf.Location == loc && cf.Strict == p.Strict {
+		c.hits++
+		c.mu.Unlock()
+		return CopyEntries(cf.Entries), nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	ents, err := p.ParseBytes(data, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	mc.Files[path] = cachedFile{Hash: hash, Location: loc, Strict: p.Strict, Entries: CopyEntries(ents)}
+	c.dirty[key] = true
+	c.mu.Unlock()
+	return ents, nil
+}
+
+// Flush writes every month touched since the last Flush. Files are replaced
+// atomically so a concurrent reader never sees a partial cache.
+func (c *EntryCache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.dirty) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	var errs []error
+	for key := range c.dirty {
+		if err := c.writeMonthLocked(key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(c.dirty, key)
+	}
+	return errors.Join(errs...)
+}
+
+// CacheStatus counts journal files by cache state; see EntryCache.Status.
+type CacheStatus struct {
+	Fresh   int // cached and unchanged since
+	Stale   int // changed since they were cached; re-parsed on the next load
+	Missing int // not cached yet
+}
+
+// Status reports how many of paths the cache would answer as they are now,
+// regardless of the parser settings they were cached with. It neither
+// parses nor changes the cache. Files that cannot be read are skipped.
+func (c *EntryCache) Status(paths []string) CacheStatus {
+	var st CacheStatus
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		cf, ok := c.loadMonthLocked(monthKeyFor(path)).Files[path]
+		sum := sha256.Sum256(data)
+		switch {
+		case !ok:
+			st.Missing++
+		case cf.Hash == hex.EncodeToString(sum[:]):
+			st.Fresh++
+		default:
+			st.Stale++
+		}
+	}
+	return st
+}
+
+func (c *EntryCache) loadMonthLocked(key string) *monthCache {
+	if mc, ok := c.months[key]; ok {
+		return mc
+	}
+	mc := &monthCache{Version: cacheFormatVersion, Files: map[string]cachedFile{}}
+	if f, err := os.Open(c.monthPath(key)); err == nil {
+		var disk monthCache
+		// A corrupt or outdated cache is not an error; it is simply rebuilt.
+		if gob.NewDecoder(f).Decode(&disk) == nil && disk.Version == cacheFormatVersion && disk.Files != nil {
+			mc = &disk
+		}
+		f.Close()
+	}
+	c.months[key] = mc
+	return mc
+}
+
+func (c *EntryCache) writeMonthLocked(key string) error {
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(tmp).Encode(c.months[key]); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.monthPath(key))
+}
+
+func (c *EntryCache) monthPath(key string) string {
+	return filepath.Join(c.Dir, key+".gob")
+}
+
+// monthKeyFor derives the YYYY-MM bucket from a day file name, falling back
+// to a shared bucket for files that do not follow the journal layout.
+func monthKeyFor(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	if t, err := time.Parse("2006-01-02", name); err == nil {
+		return t.Format("2006-01")
+	}
+	return "misc"
+}
+
+// LocationName is the name a parser location is cached under; nil means
+// time.Local.
+func LocationName(loc *time.Location) string {
+	if loc == nil {
+		return time.Local.String()
+	}
+	return loc.String()
+}
+
+// CopyEntries returns a copy whose slices and times do not alias the cached
+// entries, so callers may change Notes/Tags/Pauses without corrupting the
+// cache.
+func CopyEntries(in []Entry) []Entry {
+	out := make([]Entry, len(in))
+	for i, e := range in {
+		e.Notes = append([]string(nil), e.Notes...)
+		e.Tags = append([]string(nil), e.Tags...)
+		e.noteTimes = append([]time.Time(nil), e.noteTimes...)
+		if e.End != nil {
+			end := *e.End
+			e.End = &end
+		}
+		e.Pauses = copyIntervals(e.Pauses)
+		if e.Carried != nil {
+			e.Carried = append([]Event(nil), e.Carried...)
+		}
+		out[i] = e
+	}
+	return out
+}
+
+func copyIntervals(in []Interval) []Interval {
+	if in == nil {
+		return nil
+	}
+	out := make([]Interval, len(in))
+	for i, iv := range in {
+		if iv.End != nil {
+			end := *iv.End
+			iv.End = &end
+		}
+		out[i] = iv
+	}
+	return out
+}
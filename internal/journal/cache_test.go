@@ -0,0 +1,145 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEntryCache_HitMissAndInvalidation(t *testing.T) {
+	dir := t.TempDir()
+	jpath := filepath.Join(dir, "2025-01-01.jsonl")
+	day := `{"id":"s1","type":"start","ts":"2025-01-01T09:00:00Z","customer":"ACME","note":"one"}
+{"id":"p1","type":"pause","ts":"2025-01-01T09:15:00Z"}
+{"id":"r1","type":"resume","ts":"2025-01-01T09:30:00Z"}
+{"id":"st1","type":"stop","ts":"2025-01-01T10:00:00Z"}
+`
+	if err := os.WriteFile(jpath, []byte(day), 0o644); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	p := NewParser("UTC")
+
+	c := NewEntryCache(cacheDir)
+	ents, err := parseCached(c, p, jpath)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(ents) != 1 || ents[0].Customer != "ACME" || ents[0].Source != jpath {
+		t.Fatalf("unexpected entries: %+v", ents)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "2025-01.gob")); err != nil {
+		t.Fatalf("expected month cache file: %v", err)
+	}
+
+	// A fresh cache reads the month file from disk and hits.
+	c2 := NewEntryCache(cacheDir)
+	cached, err := parseCached(c2, p, jpath)
+	if err != nil {
+		t.Fatalf("ParseFile (cached): %v", err)
+	}
+	if c2.hits != 1 || c2.misses != 0 {
+		t.Fatalf("expected cache hit, got hits=%d misses=%d", c2.hits, c2.misses)
+	}
+	if len(cached) != 1 || !cached[0].Start.Equal(ents[0].Start) || !cached[0].End.Equal(*ents[0].End) {
+		t.Fatalf("cached entries differ: %+v vs %+v", cached, ents)
+	}
+	// Mutating the returned slices must not leak into the cache.
+	cached[0].Notes[0] = "mutated"
+	*cached[0].Pauses[0].End = cached[0].Pauses[0].End.Add(time.Hour)
+	cached[0].Pauses[0].Start = cached[0].Start
+	again, _ := parseCached(c2, p, jpath)
+	if again[0].Notes[0] != "one" {
+		t.Fatalf("cache aliased caller slices: %v", again[0].Notes)
+	}
+	if ps := again[0].Pauses; len(ps) != 1 || ps[0].Start.Minute() != 15 || ps[0].End.Minute() != 30 {
+		t.Fatalf("cache aliased caller pauses: %+v", ps)
+	}
+
+	// Appending to the journal changes its hash and forces a re-parse.
+	f, err := os.OpenFile(jpath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	f.WriteString(`{"id":"a1","type":"amend","ts":"2025-01-01T11:00:00Z","ref":"s1","customer":"Globex"}` + "\n")
+	f.Close()
+
+	updated, err := parseCached(c2, p, jpath)
+	if err != nil {
+		t.Fatalf("ParseFile (updated): %v", err)
+	}
+	if c2.misses != 1 || updated[0].Customer != "Globex" {
+		t.Fatalf("expected invalidation, misses=%d entries=%+v", c2.misses, updated)
+	}
+
+	// Different parser settings must not reuse entries built for another timezone.
+	if _, err := c2.ParseFile(NewParser("Europe/Berlin"), jpath); err != nil {
+		t.Fatalf("ParseFile (tz): %v", err)
+	}
+	if c2.misses != 2 {
+		t.Fatalf("expected miss for different location, misses=%d", c2.misses)
+	}
+}
+
+func TestEntryCache_CorruptFileIsRebuilt(t *testing.T) {
+	dir := t.TempDir()
+	jpath := filepath.Join(dir, "2025-02-03.jsonl")
+	if err := os.WriteFile(jpath, []byte(`{"id":"s1","type":"start","ts":"2025-02-03T09:00:00Z"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "2025-02.gob"), []byte("not gob"), 0o644); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+
+	c := NewEntryCache(cacheDir)
+	ents, err := parseCached(c, NewParser("UTC"), jpath)
+	if err != nil || len(ents) != 1 {
+		t.Fatalf("ParseFile with corrupt cache: ents=%v err=%v", ents, err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func TestEntryCache_Status(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "2025-01-01.jsonl")
+	b := filepath.Join(dir, "2025-01-02.jsonl")
+	for _, p := range []string{a, b} {
+		if err := os.WriteFile(p, []byte(`{"id":"s1","type":"start","ts":"2025-01-01T09:00:00Z","customer":"ACME"}`+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	c := NewEntryCache(cacheDir)
+	if _, err := c.ParseFile(NewParser("UTC"), a); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "2025-01-03.jsonl")
+	if st := NewEntryCache(cacheDir).Status([]string{a, b, missing}); st != (CacheStatus{Fresh: 1, Missing: 1}) {
+		t.Fatalf("status = %+v", st)
+	}
+	f, _ := os.OpenFile(a, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString(`{"id":"x1","type":"stop","ts":"2025-01-01T10:00:00Z"}` + "\n")
+	f.Close()
+	if st := NewEntryCache(cacheDir).Status([]string{a, b}); st != (CacheStatus{Stale: 1, Missing: 1}) {
+		t.Fatalf("status after edit = %+v", st)
+	}
+}
+
+// parseCached is c.ParseFile without the carry-over records.
+func parseCached(c FileCache, p *Parser, path string) ([]Entry, error) {
+	ents, err := c.ParseFile(p, path)
+	return withoutCarried(ents), err
+}
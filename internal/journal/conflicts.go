@@ -0,0 +1,108 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrCorrectionConflict wraps the ParseError a strict parser returns for
+// contradictory correction events; the details are in ParseError.Conflicts.
+var ErrCorrectionConflict = errors.New("conflicting corrections")
+
+// CorrectionConflict describes correction events whose combined effect is
+// not what either intended. The parser still applies last-write-wins unless
+// it is strict.
+type CorrectionConflict struct {
+	EntryID string
+	Events  []string // IDs of the conflicting correction events, in ts order
+	Reason  string
+}
+
+func (c CorrectionConflict) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.EntryID, c.Reason, strings.Join(c.Events, " → "))
+}
+
+// CorrectionConflicts parses the journal file at path and returns the
+// correction conflicts found while applying its corrections. Unless the
+// parser is strict they do not fail the parse, so this lists all of them.
+func (p *Parser) CorrectionConflicts(path string) ([]CorrectionConflict, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	_, conflicts, err := p.parse(f, path)
+	return conflicts, err
+}
+
+// conflictTracker follows the correction chain of each entry while
+// applyCorrections runs:
+//
+//   - bounds: amends whose start/end values, taken together, leave an entry
+//     ending at or before its start;
+//   - superseded: an amend, split or merge targeting an entry that an
+//     earlier split or merge already replaced, so it silently does nothing.
+type conflictTracker struct {
+	replacedBy map[string]Event    // entry ID -> split/merge that replaced it
+	boundsBy   map[string][]string // entry ID -> amends that set its bounds
+	found      []CorrectionConflict
+}
+
+func newConflictTracker() *conflictTracker {
+	return &conflictTracker{replacedBy: map[string]Event{}, boundsBy: map[string][]string{}}
+}
+
+// replaced records that ev removed the entries ids from the effective view.
+func (t *conflictTracker) replaced(ids []string, ev Event) {
+	for _, id := range ids {
+		t.replacedBy[id] = ev
+	}
+}
+
+// missing records a conflict when the target id of ev was replaced earlier.
+func (t *conflictTracker) missing(id string, ev Event) *CorrectionConflict {
+	by, ok := t.replacedBy[id]
+	if !ok {
+		return nil
+	}
+	return t.add(CorrectionConflict{
+		EntryID: id,
+		Events:  []string{by.ID, ev.ID},
+		Reason:  fmt.Sprintf("%s targets the entry after %s %s replaced it", ev.Type, by.Type, by.ID),
+	})
+}
+
+// amended records the bound changes of amend ev to e (already applied) and a
+// conflict when they leave e ending at or before its start.
+func (t *conflictTracker) amended(e *Entry, ev Event) *CorrectionConflict {
+	if ev.Meta["start"] == "" && ev.Meta["end"] == "" {
+		return nil
+	}
+	t.boundsBy[e.ID] = append(t.boundsBy[e.ID], ev.ID)
+	if e.End == nil || e.Start.Before(*e.End) {
+		return nil
+	}
+	return t.add(CorrectionConflict{
+		EntryID: e.ID,
+		Events:  append([]string(nil), t.boundsBy[e.ID]...),
+		Reason: fmt.Sprintf("amended bounds end at %s, not after the start %s",
+			e.End.Format(time.RFC3339), e.Start.Format(time.RFC3339)),
+	})
+}
+
+func (t *conflictTracker) add(c CorrectionConflict) *CorrectionConflict {
+	t.found = append(t.found, c)
+	return &t.found[len(t.found)-1]
+}
+
+// conflictError is the strict-mode error for c.
+func conflictError(path string, c *CorrectionConflict) *ParseError {
+	return &ParseError{
+		Path:      path,
+		Err:       fmt.Errorf("%w: %s", ErrCorrectionConflict, c),
+		Conflicts: []CorrectionConflict{*c},
+	}
+}
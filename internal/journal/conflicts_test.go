@@ -0,0 +1,59 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCorrectionConflicts(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"e1","type":"add","ts":"2025-08-01T09:00:00Z","ref":"2025-08-01T09:00:00Z..2025-08-01T10:00:00Z","customer":"Acme"}`,
+		`{"id":"e2","type":"add","ts":"2025-08-01T11:00:00Z","ref":"2025-08-01T11:00:00Z..2025-08-01T12:00:00Z","customer":"Acme"}`,
+		// two amends that each look fine but leave e1 ending before it starts
+		`{"id":"a1","type":"amend","ts":"2025-08-01T13:00:00Z","ref":"e1","meta":{"end":"2025-08-01T09:30:00Z"}}`,
+		`{"id":"a2","type":"amend","ts":"2025-08-01T13:05:00Z","ref":"e1","meta":{"start":"2025-08-01T09:45:00Z"}}`,
+		// a later amend of e2 after a split replaced it
+		`{"id":"s1","type":"split","ts":"2025-08-01T13:10:00Z","ref":"e2","meta":{"split_at":"2025-08-01T11:30:00Z"}}`,
+		`{"id":"a3","type":"amend","ts":"2025-08-01T13:15:00Z","ref":"e2","note":"late fix"}`,
+		// refining the same bound twice is not a conflict
+		`{"id":"a4","type":"amend","ts":"2025-08-01T13:20:00Z","ref":"s1.L","meta":{"end":"2025-08-01T11:20:00Z"}}`,
+		`{"id":"a5","type":"amend","ts":"2025-08-01T13:25:00Z","ref":"s1.L","meta":{"end":"2025-08-01T11:25:00Z"}}`,
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "2025-08-01.jsonl")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewParser("UTC")
+	got, err := p.CorrectionConflicts(path)
+	if err != nil {
+		t.Fatalf("CorrectionConflicts: %v", err)
+	}
+	want := []string{
+		"e1: amended bounds end at 2025-08-01T09:30:00Z, not after the start 2025-08-01T09:45:00Z (a1 → a2)",
+		"e2: amend targets the entry after split s1 replaced it (s1 → a3)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("conflicts = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("conflict %d = %q; want %q", i, got[i], want[i])
+		}
+	}
+
+	// non-strict parsing still applies last-write-wins
+	if _, err := p.ParseFile(path); err != nil {
+		t.Fatalf("non-strict parse: %v", err)
+	}
+
+	p.Strict = true
+	_, err = p.ParseFile(path)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrCorrectionConflict) || len(pe.Conflicts) != 1 || pe.Conflicts[0].EntryID != "e1" {
+		t.Fatalf("strict parse error = %v (%+v)", err, pe)
+	}
+}
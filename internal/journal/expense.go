@@ -0,0 +1,129 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expense is a reimbursable cost recorded next to the time entries, built
+// from an "expense" event. The event's Ref holds the amount ("49.90 EUR") so
+// the hash chain covers it; Note holds the description.
+type Expense struct {
+	ID          string
+	Date        time.Time // the event's TS
+	Amount      float64
+	Currency    string // ISO code, upper case; "" when none was given
+	Description string
+	Customer    string
+	Project     string
+	Billable    bool
+	Tags        []string
+	User        string
+	Source      string // optional path where the expense originated
+}
+
+// ErrInvalidAmount is returned for expense amounts ParseAmount rejects.
+var ErrInvalidAmount = errors.New("invalid amount; expected e.g. 49.90EUR, 49,90 EUR or 12")
+
+// ParseAmount parses an amount with an optional currency code before or
+// after it: "49.90EUR", "49,90 EUR", "EUR 49.90" or "12". A comma is a
+// decimal separator; thousands separators are not accepted.
+func ParseAmount(s string) (float64, string, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != ',' && r != '-' })
+	num, cur := s, ""
+	switch {
+	case i == 0:
+		j := strings.IndexFunc(s, func(r rune) bool { return (r >= '0' && r <= '9') || r == '-' })
+		if j < 0 {
+			return 0, "", ErrInvalidAmount
+		}
+		cur, num = s[:j], s[j:]
+	case i > 0:
+		num, cur = s[:i], s[i:]
+	}
+	cur = strings.ToUpper(strings.TrimSpace(cur))
+	if cur != "" && (len(cur) != 3 || strings.IndexFunc(cur, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0) {
+		return 0, "", ErrInvalidAmount
+	}
+	v, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(num), ",", ".", 1), 64)
+	if err != nil || v <= 0 {
+		return 0, "", ErrInvalidAmount
+	}
+	return v, cur, nil
+}
+
+// FormatAmount is the Ref of an expense event, e.g. "49.90 EUR".
+func FormatAmount(amount float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%.2f %s", amount, strings.ToUpper(currency)))
+}
+
+// ParseExpenseFile returns the expenses recorded in the journal file path.
+func (p *Parser) ParseExpenseFile(path string) ([]Expense, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	exps, err := p.ParseExpenses(f)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok && pe.Path == "" {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	for i := range exps {
+		exps[i].Source = path
+	}
+	return exps, nil
+}
+
+// ParseExpenses returns the expenses of the JSONL events in r, ordered by
+// date. Expense events with a malformed amount are skipped, or reported in
+// strict mode.
+func (p *Parser) ParseExpenses(r io.Reader) ([]Expense, error) {
+	if p == nil {
+		p = NewParser("")
+	}
+	events, err := p.readEvents(r, "")
+	if err != nil {
+		return nil, err
+	}
+	var out []Expense
+	for _, ev := range events {
+		if ev.Type != "expense" {
+			continue
+		}
+		amount, cur, err := ParseAmount(ev.Ref)
+		if err != nil {
+			if p.Strict {
+				return nil, &ParseError{Err: fmt.Errorf("expense %s: %w", ev.ID, err)}
+			}
+			continue
+		}
+		billable := true
+		if ev.Billable != nil {
+			billable = *ev.Billable
+		}
+		out = append(out, Expense{
+			ID:          ev.ID,
+			Date:        ev.TS,
+			Amount:      amount,
+			Currency:    cur,
+			Description: ev.Note,
+			Customer:    ev.Customer,
+			Project:     ev.Project,
+			Billable:    billable,
+			Tags:        ev.Tags,
+			User:        ev.User,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
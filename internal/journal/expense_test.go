@@ -0,0 +1,66 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want float64
+		cur  string
+	}{
+		{"49.90EUR", 49.90, "EUR"},
+		{"49,90 eur", 49.90, "EUR"},
+		{"USD 12", 12, "USD"},
+		{"7.5", 7.5, ""},
+	} {
+		v, cur, err := ParseAmount(tc.in)
+		if err != nil || v != tc.want || cur != tc.cur {
+			t.Errorf("ParseAmount(%q) = %v %q %v; want %v %q", tc.in, v, cur, err, tc.want, tc.cur)
+		}
+	}
+	for _, in := range []string{"", "EUR", "-5EUR", "0", "1,234.50", "12 euros", "12€"} {
+		if _, _, err := ParseAmount(in); err == nil {
+			t.Errorf("ParseAmount(%q) accepted", in)
+		}
+	}
+	if got := FormatAmount(49.9, "eur"); got != "49.90 EUR" {
+		t.Errorf("FormatAmount = %q", got)
+	}
+}
+
+func TestParseExpenses(t *testing.T) {
+	src := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-10-06T09:00:00Z","customer":"acme"}`,
+		`{"id":"x2","type":"expense","ts":"2025-10-06T15:00:00Z","customer":"acme","billable":false,"note":"lunch","ref":"12.00 EUR"}`,
+		`{"id":"x1","type":"expense","ts":"2025-10-06T12:00:00Z","customer":"acme","project":"portal","note":"train ticket","ref":"49.90 EUR","user":"ana"}`,
+		`{"id":"x3","type":"expense","ts":"2025-10-06T13:00:00Z","ref":"lots"}`,
+		`{"id":"s1e","type":"stop","ts":"2025-10-06T17:00:00Z"}`,
+	}, "\n")
+	exps, err := NewParser("UTC").ParseExpenses(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exps) != 2 {
+		t.Fatalf("expenses = %+v", exps)
+	}
+	if x := exps[0]; x.ID != "x1" || x.Amount != 49.90 || x.Currency != "EUR" || x.Project != "portal" || !x.Billable || x.Description != "train ticket" || x.User != "ana" {
+		t.Fatalf("first = %+v", x)
+	}
+	if exps[1].Billable {
+		t.Fatalf("second billable: %+v", exps[1])
+	}
+
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(src))
+	if err != nil || len(ents) != 1 {
+		t.Fatalf("expenses leaked into entries: %+v, %v", ents, err)
+	}
+
+	strict := NewParser("UTC")
+	strict.Strict = true
+	if _, err := strict.ParseExpenses(strings.NewReader(src)); err == nil || !strings.Contains(err.Error(), "x3") {
+		t.Fatalf("strict err = %v", err)
+	}
+}
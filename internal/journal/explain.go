@@ -0,0 +1,183 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrEntryNotFound is returned by Explain when no file holds the entry.
+var ErrEntryNotFound = errors.New("entry not found")
+
+// HistoryStep is one event in an entry's history (see Parser.Explain).
+type HistoryStep struct {
+	Event  Event
+	Before []Entry // the targets before a correction; empty for the base event
+	After  []Entry // the entries it produced; empty when the parser skipped it
+}
+
+// Explain returns the history of entry id from the first of paths holding
+// it: the base event (start or add) of each entry it derives from and, in
+// order, every amend, split and merge of those entries, of id itself and of
+// the entries that replaced it, with snapshots before and after. Steps are
+// reconstructed exactly as parsing applies them, one correction at a time.
+func (p *Parser) Explain(paths []string, id string) ([]HistoryStep, error) {
+	if p == nil {
+		p = NewParser("")
+	}
+	// skipped corrections are part of the history, not errors
+	lenient := *p
+	lenient.Strict = false
+	lenient.Cache = nil
+	lenient.KeepSuperseded = false
+
+	for _, path := range paths {
+		steps, ok, err := lenient.explainFile(path, id)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if ok {
+			return steps, nil
+		}
+	}
+	return nil, ErrEntryNotFound
+}
+
+// explainFile replays the file at path and returns the steps relevant to
+// id; ok is false when the file never holds id.
+func (p *Parser) explainFile(path, id string) ([]HistoryStep, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+	events, err := p.readEvents(f, path)
+	if err != nil {
+		return nil, false, err
+	}
+	base, corrections, _, err := p.baseEntries(events, path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	byID := map[string]Event{}
+	for _, ev := range events {
+		byID[ev.ID] = ev
+	}
+	var (
+		steps   []HistoryStep
+		touches [][]string // per step: the entry IDs it targeted or produced
+	)
+	seen := map[string]bool{}
+	sources := map[string][]string{}  // produced entry -> targets it replaced
+	produced := map[string][]string{} // target -> entries that replaced it
+	for _, e := range base {
+		seen[e.ID] = true
+		steps = append(steps, HistoryStep{Event: byID[e.ID], After: []Entry{e}})
+		touches = append(touches, []string{e.ID})
+	}
+
+	state := base
+	for _, ev := range corrections {
+		before := entriesByID(state)
+		next, _, err := applyCorrections(p, path, state, []Event{ev})
+		if err != nil {
+			return nil, false, err
+		}
+		after := entriesByID(next)
+
+		step := HistoryStep{Event: ev}
+		for _, t := range correctionTargets(ev) {
+			if e, ok := before[t]; ok {
+				step.Before = append(step.Before, e)
+			}
+		}
+		var out []string
+		switch ev.Type {
+		case "amend":
+			out = correctionTargets(ev)
+		case "split":
+			out = []string{ev.ID + ".L", ev.ID + ".R"}
+		case "merge":
+			out = []string{ev.ID}
+		}
+		for _, o := range out {
+			e, ok := after[o]
+			if !ok {
+				continue
+			}
+			step.After = append(step.After, e)
+			seen[o] = true
+			if ev.Type == "amend" {
+				continue
+			}
+			for _, b := range step.Before {
+				sources[o] = append(sources[o], b.ID)
+				produced[b.ID] = append(produced[b.ID], o)
+			}
+		}
+		steps = append(steps, step)
+		touches = append(touches, append(correctionTargets(ev), out...))
+		state = next
+	}
+	if !seen[id] {
+		return nil, false, nil
+	}
+
+	// the lineage of id: its ancestors and the entries that replaced it
+	lineage := map[string]bool{}
+	var walk func(id string, next map[string][]string)
+	walk = func(id string, next map[string][]string) {
+		for _, n := range next[id] {
+			if !lineage[n] {
+				lineage[n] = true
+				walk(n, next)
+			}
+		}
+	}
+	lineage[id] = true
+	walk(id, sources)
+	walk(id, produced)
+
+	var out []HistoryStep
+	for i, st := range steps {
+		for _, t := range touches[i] {
+			if lineage[t] {
+				out = append(out, st)
+				break
+			}
+		}
+	}
+	return out, true, nil
+}
+
+// correctionTargets returns the entry IDs a correction event targets.
+func correctionTargets(ev Event) []string {
+	if ev.Type == "merge" {
+		var out []string
+		for _, t := range strings.Split(ev.Meta["targets"], ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+	if ev.Ref != "" {
+		return []string{ev.Ref}
+	}
+	if t := ev.Meta["target"]; t != "" {
+		return []string{t}
+	}
+	return nil
+}
+
+func entriesByID(ents []Entry) map[string]Entry {
+	m := make(map[string]Entry, len(ents))
+	for _, e := range ents {
+		m[e.ID] = e
+	}
+	return m
+}
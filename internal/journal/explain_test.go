@@ -0,0 +1,67 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	dir := t.TempDir()
+	day := strings.Join([]string{
+		`{"id":"e1","type":"add","ts":"2025-08-01T09:00:00Z","ref":"2025-08-01T09:00:00Z..2025-08-01T11:00:00Z","customer":"Acme"}`,
+		`{"id":"e2","type":"add","ts":"2025-08-01T12:00:00Z","ref":"2025-08-01T12:00:00Z..2025-08-01T13:00:00Z","customer":"Acme"}`,
+		`{"id":"a1","type":"amend","ts":"2025-08-01T14:00:00Z","ref":"e1","meta":{"end":"2025-08-01T11:30:00Z"}}`,
+		`{"id":"s1","type":"split","ts":"2025-08-01T14:05:00Z","ref":"e1","meta":{"split_at":"2025-08-01T10:00:00Z"}}`,
+		`{"id":"a2","type":"amend","ts":"2025-08-01T14:10:00Z","ref":"s1.R","note":"review"}`,
+		`{"id":"a3","type":"amend","ts":"2025-08-01T14:15:00Z","ref":"e2","note":"unrelated"}`,
+		`{"id":"m1","type":"merge","ts":"2025-08-01T14:20:00Z","meta":{"targets":"s1.L,e2"}}`,
+		`{"id":"a4","type":"amend","ts":"2025-08-01T14:25:00Z","ref":"e1","note":"too late"}`,
+	}, "\n")
+	path := filepath.Join(dir, "2025-08-01.jsonl")
+	if err := os.WriteFile(path, []byte(day), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{filepath.Join(dir, "2025-08-02.jsonl"), path}
+
+	steps, err := NewParser("UTC").Explain(paths, "s1.R")
+	if err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+	var got []string
+	for _, st := range steps {
+		got = append(got, st.Event.ID)
+	}
+	// the base and corrections of the ancestor e1, the split and the amend
+	// of s1.R; not the merge of its sibling s1.L
+	if want := "e1 a1 s1 a2 a4"; strings.Join(got, " ") != want {
+		t.Fatalf("steps = %v; want %s", got, want)
+	}
+	if end := steps[1].After[0].End.Format("15:04"); end != "11:30" || steps[1].Before[0].End.Format("15:04") != "11:00" {
+		t.Errorf("amend snapshots: before %v after %v", steps[1].Before[0].End, end)
+	}
+	if len(steps[2].After) != 2 || steps[2].After[1].ID != "s1.R" {
+		t.Errorf("split after = %+v", steps[2].After)
+	}
+	if len(steps[4].After) != 0 {
+		t.Errorf("amend of the replaced e1 should be skipped, got %+v", steps[4].After)
+	}
+
+	steps, err = NewParser("UTC").Explain(paths, "m1")
+	if err != nil {
+		t.Fatalf("Explain m1: %v", err)
+	}
+	got = got[:0]
+	for _, st := range steps {
+		got = append(got, st.Event.ID)
+	}
+	if want := "e1 e2 a1 s1 a3 m1 a4"; strings.Join(got, " ") != want {
+		t.Fatalf("merge steps = %v; want %s", got, want)
+	}
+
+	if _, err := NewParser("UTC").Explain(paths, "nope"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("unknown id: err = %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// canonicalPayload is the part of an event the hash chain covers, in the
+// field order the CLI has always hashed (Meta is not covered).
+type canonicalPayload struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	TS       string   `json:"ts"`
+	User     string   `json:"user,omitempty"`
+	Customer string   `json:"customer,omitempty"`
+	Project  string   `json:"project,omitempty"`
+	Activity string   `json:"activity,omitempty"`
+	Billable *bool    `json:"billable,omitempty"`
+	Note     string   `json:"note,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Ref      string   `json:"ref,omitempty"`
+	PrevHash string   `json:"prev_hash,omitempty"`
+}
+
+// EventHash returns the hash tt writes for ev: the hex SHA-256 of its
+// canonical JSON payload, chained through ev.PrevHash. Writers outside the
+// CLI use it to produce journals that tt audit verify accepts.
+func EventHash(ev Event) string {
+	b, _ := json.Marshal(canonicalPayload{
+		ID:       ev.ID,
+		Type:     ev.Type,
+		TS:       ev.TS.Format(time.RFC3339Nano),
+		User:     ev.User,
+		Customer: ev.Customer,
+		Project:  ev.Project,
+		Activity: ev.Activity,
+		Billable: ev.Billable,
+		Note:     ev.Note,
+		Tags:     ev.Tags,
+		Ref:      ev.Ref,
+		PrevHash: ev.PrevHash,
+	})
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
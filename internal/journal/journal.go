@@ -2,11 +2,14 @@ package journal
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -16,7 +19,7 @@ import (
 // This mirrors the structure used across the repository for journal files.
 type Event struct {
 	ID       string            `json:"id"`
-	Type     string            `json:"type"` // start|stop|add|amend|split|merge|pause|resume|note
+	Type     string            `json:"type"` // start|stop|add|amend|split|merge|pause|resume|note|expense|pin|follow-up|unpin|undo
 	TS       time.Time         `json:"ts"`
 	User     string            `json:"user,omitempty"`
 	Customer string            `json:"customer,omitempty"`
@@ -42,20 +45,117 @@ type Entry struct {
 	Billable bool
 	Notes    []string
 	Tags     []string
-	Source   string // optional path where the entry originated
+	User     string     // user who started or added the entry (empty in single-user journals)
+	Source   string     // optional path where the entry originated
+	Pauses   []Interval // pause..resume spans while the entry ran, in order
+
+	// Carried is set only on the carry-over records ParseBytes returns, one
+	// per user: the stop, pause, resume and note events of the file that
+	// found no entry of theirs running in it, up to and including the user's
+	// first start there. They continue an entry started in an earlier day
+	// file, e.g. a timer running over midnight; ParseFilesStream applies them
+	// to it. ParseFile and ParseReader leave these records out.
+	Carried []Event
+
+	// SupersededBy is set only by a parser with KeepSuperseded: the ID of the
+	// amend, split or merge event that replaced this version of the entry.
+	// Empty for the effective entries.
+	SupersededBy string
+
+	// noteTimes holds when each of Notes was recorded (parallel to Notes)
+	// while the parser reconstructs entries, so merges keep notes in event
+	// order. Entries built elsewhere leave it empty.
+	noteTimes []time.Time
+}
+
+// addNote appends a note recorded at ts.
+func (e *Entry) addNote(note string, ts time.Time) {
+	e.Notes = append(e.Notes, note)
+	e.noteTimes = append(e.noteTimes, ts)
+}
+
+// noteTime is when the i-th note was recorded; the entry's start for notes
+// the parser did not time.
+func (e Entry) noteTime(i int) time.Time {
+	if i < len(e.noteTimes) && len(e.noteTimes) == len(e.Notes) {
+		return e.noteTimes[i]
+	}
+	return e.Start
+}
+
+// Interval is a span of time; End is nil while it is still open.
+type Interval struct {
+	Start time.Time
+	End   *time.Time
+}
+
+// Paused returns how long e was paused up to until, or up to its end when
+// that is earlier. Open pauses count as paused through that point.
+func (e Entry) Paused(until time.Time) time.Duration {
+	return e.PausedBetween(e.Start, until)
+}
+
+// PausedBetween returns how much of from..to e was paused, counting only
+// its own span.
+func (e Entry) PausedBetween(from, to time.Time) time.Duration {
+	var d time.Duration
+	for _, p := range e.pausesWithin(from, to) {
+		d += p.End.Sub(p.Start)
+	}
+	return d
+}
+
+// pausesWithin returns the pauses of e clipped to from..to and to e's own
+// span; open pauses end at to (or e's end). All returned pauses are closed.
+func (e Entry) pausesWithin(from, to time.Time) []Interval {
+	if from.Before(e.Start) {
+		from = e.Start
+	}
+	if e.End != nil && e.End.Before(to) {
+		to = *e.End
+	}
+	var out []Interval
+	for _, p := range e.Pauses {
+		st, en := p.Start, to
+		if p.End != nil && p.End.Before(en) {
+			en = *p.End
+		}
+		if st.Before(from) {
+			st = from
+		}
+		if en.After(st) {
+			out = append(out, Interval{Start: st, End: &en})
+		}
+	}
+	return out
 }
 
 // Parser configures how journal files are parsed.
 type Parser struct {
 	Location *time.Location // timezone (if empty, Local is used)
 	Strict   bool           // if true, parsing errors abort with an error
+	Cache    FileCache      // optional; when set, ParseFile answers unchanged files from it
+
+	// KeepSuperseded makes the parser also return every version of an entry
+	// that a correction replaced, as it was just before the correction, with
+	// SupersededBy set; the effective entries are returned as usual. Such
+	// parses bypass Cache.
+	KeepSuperseded bool
+}
+
+// FileCache answers Parser.ParseFile for journal files that did not change
+// since they were last parsed; see EntryCache and internal/index. It returns
+// what ParseBytes does, carry-over records included.
+type FileCache interface {
+	ParseFile(p *Parser, path string) ([]Entry, error)
 }
 
 // ParseError represents a parsing error with optional file/line context.
 type ParseError struct {
-	Path string
-	Line int
-	Err  error
+	Path      string
+	Line      int
+	Err       error
+	Conflicts []CorrectionConflict // set for ErrCorrectionConflict
 }
 
 func (p *ParseError) Error() string {
@@ -74,6 +174,9 @@ func (p *ParseError) Error() string {
 	return fmt.Sprintf("parse error: %v", p.Err)
 }
 
+// Unwrap returns the underlying error, e.g. ErrCorrectionConflict.
+func (p *ParseError) Unwrap() error { return p.Err }
+
 var ErrInvalidRef = errors.New("invalid ref format; expected startISO..endISO")
 
 // NewParser returns a Parser. If timezone is empty or invalid, local timezone is used.
@@ -90,13 +193,43 @@ func NewParser(timezone string) *Parser {
 // ParseFile opens the given path and parses it as a journal JSONL file.
 // The returned entries will have the Entry.Source set to the provided path.
 func (p *Parser) ParseFile(path string) ([]Entry, error) {
-	f, err := os.Open(path)
+	if p.Cache != nil && !p.KeepSuperseded {
+		ents, err := p.Cache.ParseFile(p, path)
+		return withoutCarried(ents), err
+	}
+	ents, err := p.parseFileCarried(path)
+	return withoutCarried(ents), err
+}
+
+// parseFileCarried is ParseFile keeping the carry-over records.
+func (p *Parser) parseFileCarried(path string) ([]Entry, error) {
+	if p.Cache != nil && !p.KeepSuperseded {
+		return p.Cache.ParseFile(p, path)
+	}
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
+	return p.ParseBytes(data, path)
+}
 
-	ents, err := p.parseReaderWithPath(f, path)
+// withoutCarried drops the carry-over records (Entry.Carried) of ents.
+func withoutCarried(ents []Entry) []Entry {
+	out := ents[:0:0]
+	for _, e := range ents {
+		if e.Carried == nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ParseBytes parses data, the contents of the journal file at path, without
+// consulting p.Cache. Entry.Source is set to path; caches use it to parse the
+// files they read themselves. Unlike ParseFile it returns the file's
+// carry-over records (Entry.Carried) first.
+func (p *Parser) ParseBytes(data []byte, path string) ([]Entry, error) {
+	ents, err := p.parseReaderWithPath(bytes.NewReader(data), path)
 	if err != nil {
 		// if it's a ParseError and has no Path set, attach it
 		if pe, ok := err.(*ParseError); ok && pe.Path == "" {
@@ -115,7 +248,8 @@ func (p *Parser) ParseFile(path string) ([]Entry, error) {
 // ParseReader parses entries from an io.Reader containing JSONL events.
 // If the receiver (p) is nil, a default parser (local timezone, non-strict) is used.
 func (p *Parser) ParseReader(r io.Reader) ([]Entry, error) {
-	return p.parseReaderWithPath(r, "")
+	ents, err := p.parseReaderWithPath(r, "")
+	return withoutCarried(ents), err
 }
 
 // parseReaderWithPath is the internal implementation that can attach a path to ParseErrors.
@@ -123,9 +257,40 @@ func (p *Parser) ParseReader(r io.Reader) ([]Entry, error) {
 // and also collects correction events (amend/split/merge). After building base entries it applies
 // corrections in chronological order to produce the effective view without mutating historical events.
 func (p *Parser) parseReaderWithPath(r io.Reader, path string) ([]Entry, error) {
+	ents, _, err := p.parse(r, path)
+	return ents, err
+}
+
+// parse is parseReaderWithPath that also returns the correction conflicts
+// found (see conflictTracker).
+func (p *Parser) parse(r io.Reader, path string) ([]Entry, []CorrectionConflict, error) {
 	if p == nil {
 		p = NewParser("")
 	}
+	events, err := p.readEvents(r, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseEntries, corrections, carried, err := p.baseEntries(events, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// apply corrections (amend/split/merge) in chronological order
+	finalEntries, conflicts, err := applyCorrections(p, path, baseEntries, corrections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// sort final entries by start time (then ID) for deterministic output
+	sortEntries(finalEntries)
+
+	return append(carried, finalEntries...), conflicts, nil
+}
+
+// readEvents decodes the JSONL events of r, drops undone ones (see
+// DropUndone) and sorts the rest chronologically.
+func (p *Parser) readEvents(r io.Reader, path string) ([]Event, error) {
 	scanner := bufio.NewScanner(r)
 	// keep default buffer; should be sufficient for typical JSONL lines in this project
 	var events []Event
@@ -151,29 +316,68 @@ func (p *Parser) parseReaderWithPath(r io.Reader, path string) ([]Entry, error)
 		return nil, err
 	}
 
-	// Sort events chronologically to ensure deterministic reconstruction.
-	sort.Slice(events, func(i, j int) bool { return events[i].TS.Before(events[j].TS) })
+	events = DropUndone(events)
 
+	// Sort events chronologically to ensure deterministic reconstruction;
+	// events with the same timestamp keep their order in the file.
+	sort.SliceStable(events, func(i, j int) bool { return events[i].TS.Before(events[j].TS) })
+	return events, nil
+}
+
+// baseEntries builds the entries of the start/stop/add/note events and
+// returns them with the correction events, still to be applied, and the
+// carry-over records of the file (see Entry.Carried).
+func (p *Parser) baseEntries(events []Event, path string) ([]Entry, []Event, []Entry, error) {
 	var baseEntries []Entry
-	var current *Entry
+	// Running entries per user: in a shared journal one user's start or stop
+	// must not end another user's entry. Legacy events without a user all
+	// share the "" slot.
+	running := map[string]*Entry{}
+	openFor := func(user string) (string, *Entry) {
+		if cur := running[user]; cur != nil {
+			return user, cur
+		}
+		// an entry started before users were recorded is still ended by
+		// the first user-tagged stop or note
+		if cur := running[""]; cur != nil {
+			return "", cur
+		}
+		return user, nil
+	}
 
 	// collect correction events to apply after base reconstruction
 	var corrections []Event
 
+	// events for an entry running since an earlier file, per user, until
+	// the user's first start here (or a legacy start without user)
+	carried := map[string][]Event{}
+	started := map[string]bool{}
+	carry := func(ev Event) bool {
+		if started[ev.User] || started[""] {
+			return false
+		}
+		carried[ev.User] = append(carried[ev.User], ev)
+		return true
+	}
+
 	for _, ev := range events {
 		switch ev.Type {
 		case "start":
-			if current != nil {
+			if slot, current := openFor(ev.User); current != nil {
 				// auto-stop previous at this event timestamp
-				cur := ev.TS
-				current.End = &cur
+				continueEntry(current, Event{Type: "stop", TS: ev.TS})
 				baseEntries = append(baseEntries, *current)
+				delete(running, slot)
+			} else {
+				// it also auto-stops an entry running since an earlier file
+				carry(ev)
 			}
+			started[ev.User] = true
 			billable := true
 			if ev.Billable != nil {
 				billable = *ev.Billable
 			}
-			current = &Entry{
+			current := &Entry{
 				ID:       ev.ID,
 				Start:    ev.TS,
 				Customer: ev.Customer,
@@ -182,20 +386,21 @@ func (p *Parser) parseReaderWithPath(r io.Reader, path string) ([]Entry, error)
 				Billable: billable,
 				Notes:    []string{},
 				Tags:     ev.Tags,
+				User:     ev.User,
 			}
 			if ev.Note != "" {
-				current.Notes = append(current.Notes, ev.Note)
+				current.addNote(ev.Note, ev.TS)
 			}
-		case "note":
-			if current != nil {
-				current.Notes = append(current.Notes, ev.Note)
+			running[ev.User] = current
+		case "note", "pause", "resume", "stop":
+			slot, current := openFor(ev.User)
+			if current == nil {
+				carry(ev)
+				continue
 			}
-		case "stop":
-			if current != nil {
-				cur := ev.TS
-				current.End = &cur
+			if continueEntry(current, ev) {
 				baseEntries = append(baseEntries, *current)
-				current = nil
+				delete(running, slot)
 			}
 		case "add":
 			billable := true
@@ -217,45 +422,88 @@ func (p *Parser) parseReaderWithPath(r io.Reader, path string) ([]Entry, error)
 						Billable: billable,
 						Notes:    []string{ev.Note},
 						Tags:     ev.Tags,
+						User:     ev.User,
+
+						noteTimes: []time.Time{ev.TS},
 					})
 				} else {
 					pe := &ParseError{Path: path, Err: ErrInvalidRef}
 					if p.Strict {
-						return nil, pe
+						return nil, nil, nil, pe
 					}
 					// otherwise ignore this malformed add
 				}
 			} else {
 				pe := &ParseError{Path: path, Err: ErrInvalidRef}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, nil, pe
 				}
 			}
 		case "amend", "split", "merge":
 			// collect and apply later
 			corrections = append(corrections, ev)
 		default:
-			// ignore other event types (pause/resume etc.)
+			// ignore other event types (expense, marks etc.)
 		}
 	}
 
-	// if a start is still open at EOF, keep it open (no end)
-	if current != nil {
-		baseEntries = append(baseEntries, *current)
+	// if a start is still open at EOF, keep it open (no end); in start
+	// order, not the map's
+	open := make([]Entry, 0, len(running))
+	for _, current := range running {
+		open = append(open, *current)
 	}
+	sortEntries(open)
+	baseEntries = append(baseEntries, open...)
 
-	// apply corrections (amend/split/merge) in chronological order
-	finalEntries, err := applyCorrections(p, path, baseEntries, corrections)
-	if err != nil {
-		return nil, err
+	users := make([]string, 0, len(carried))
+	for u := range carried {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	var carriedEntries []Entry
+	for _, u := range users {
+		carriedEntries = append(carriedEntries, Entry{User: u, Carried: carried[u]})
+	}
+	return baseEntries, corrections, carriedEntries, nil
+}
+
+// continueEntry applies a note, pause, resume or stop event to the running
+// entry e and reports whether it ended e.
+func continueEntry(e *Entry, ev Event) bool {
+	switch ev.Type {
+	case "note":
+		e.addNote(ev.Note, ev.TS)
+	case "pause":
+		// a pause of an already paused entry keeps the earlier start
+		if OpenPause(e.Pauses) == nil {
+			e.Pauses = append(e.Pauses, Interval{Start: ev.TS})
+		}
+	case "resume":
+		EndPause(e.Pauses, ev.TS)
+	case "stop":
+		end := ev.TS
+		e.End = &end
+		EndPause(e.Pauses, ev.TS)
+		return true
 	}
+	return false
+}
 
-	// sort final entries by start time for deterministic output
-	sort.Slice(finalEntries, func(i, j int) bool {
-		return finalEntries[i].Start.Before(finalEntries[j].Start)
-	})
+// OpenPause returns the last of pauses while it is still open (the entry is
+// paused), else nil.
+func OpenPause(pauses []Interval) *Interval {
+	if n := len(pauses); n > 0 && pauses[n-1].End == nil {
+		return &pauses[n-1]
+	}
+	return nil
+}
 
-	return finalEntries, nil
+// EndPause closes the open pause of pauses, if any, at ts.
+func EndPause(pauses []Interval, ts time.Time) {
+	if p := OpenPause(pauses); p != nil {
+		p.End = &ts
+	}
 }
 
 // applyCorrections applies append-only correction events to a slice of base entries.
@@ -274,11 +522,21 @@ func (p *Parser) parseReaderWithPath(r io.Reader, path string) ([]Entry, error)
 //   - merge: meta["targets"] contains a comma-separated list of entry IDs to merge.
 //     A new entry with ID ev.ID is created spanning min(start) .. max(end) of targets.
 //     Targets are removed from the effective view. ev.Customer/Project/Activity/Billable
-//     override if present; otherwise first non-empty from targets is used. Notes are concatenated.
+//     override if present; otherwise first non-empty from targets is used. Notes are combined
+//     in the order they were recorded, without duplicates (see MergeEntries).
+//
+// Corrections are applied in timestamp order, ties in the order given, and
+// the result is sorted by start and ID, so the same events always produce
+// the same entries.
+//
+// With p.KeepSuperseded the entries a correction replaces (or, for amend,
+// the target as it was before) are returned too, with SupersededBy set to the
+// correction's ID.
 //
 // Errors encountered while parsing correction metadata will cause ParseError when p.Strict=true,
-// otherwise problematic correction events are skipped.
-func applyCorrections(p *Parser, path string, base []Entry, corrections []Event) ([]Entry, error) {
+// otherwise problematic correction events are skipped. Contradictory corrections (see
+// conflictTracker) are returned as conflicts and, when strict, fail with ErrCorrectionConflict.
+func applyCorrections(p *Parser, path string, base []Entry, corrections []Event) ([]Entry, []CorrectionConflict, error) {
 	// build map id -> *Entry for easy updates; copy values so we can take addresses
 	entryMap := make(map[string]*Entry, len(base))
 	for i := range base {
@@ -286,6 +544,21 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 		entryMap[e.ID] = &e
 	}
 
+	conflicts := newConflictTracker()
+
+	// versions replaced by corrections, returned with p.KeepSuperseded
+	var superseded []Entry
+	supersede := func(e *Entry, ev Event) {
+		if p.KeepSuperseded {
+			old := CopyEntries([]Entry{*e})[0]
+			old.SupersededBy = ev.ID
+			superseded = append(superseded, old)
+		}
+	}
+
+	corrections = append([]Event(nil), corrections...)
+	sort.SliceStable(corrections, func(i, j int) bool { return corrections[i].TS.Before(corrections[j].TS) })
+
 	// helper to remove an id from map
 	removeIDs := func(ids []string) {
 		for _, id := range ids {
@@ -304,36 +577,43 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			if target == "" {
 				pe := &ParseError{Path: path, Err: errors.New("amend event missing target")}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
 			ent, ok := entryMap[target]
 			if !ok {
+				if c := conflicts.missing(target, ev); c != nil && p.Strict {
+					return nil, nil, conflictError(path, c)
+				}
 				// nothing to amend
 				pe := &ParseError{Path: path, Err: fmt.Errorf("amend target not found: %s", target)}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
+			supersede(ent, ev)
 			// apply time adjustments from meta
 			if ev.Meta != nil {
 				if s, ok := ev.Meta["start"]; ok && s != "" {
 					if t, err := time.Parse(time.RFC3339, s); err == nil {
 						ent.Start = t
 					} else if p.Strict {
-						return nil, &ParseError{Path: path, Err: err}
+						return nil, nil, &ParseError{Path: path, Err: err}
 					}
 				}
 				if e, ok := ev.Meta["end"]; ok && e != "" {
 					if t, err := time.Parse(time.RFC3339, e); err == nil {
 						ent.End = &t
 					} else if p.Strict {
-						return nil, &ParseError{Path: path, Err: err}
+						return nil, nil, &ParseError{Path: path, Err: err}
 					}
 				}
 			}
+			if c := conflicts.amended(ent, ev); c != nil && p.Strict {
+				return nil, nil, conflictError(path, c)
+			}
 			// override metadata fields if present on amend event
 			if ev.Customer != "" {
 				ent.Customer = ev.Customer
@@ -353,7 +633,7 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			}
 			// append note if provided
 			if ev.Note != "" {
-				ent.Notes = append(ent.Notes, ev.Note)
+				ent.addNote(ev.Note, ev.TS)
 			}
 		case "split":
 			target := ev.Ref
@@ -363,22 +643,25 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			if target == "" {
 				pe := &ParseError{Path: path, Err: errors.New("split event missing target")}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
 			ent, ok := entryMap[target]
 			if !ok {
+				if c := conflicts.missing(target, ev); c != nil && p.Strict {
+					return nil, nil, conflictError(path, c)
+				}
 				pe := &ParseError{Path: path, Err: fmt.Errorf("split target not found: %s", target)}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
 			if ent.End == nil {
 				pe := &ParseError{Path: path, Err: fmt.Errorf("split target has no end: %s", target)}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
@@ -389,21 +672,21 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			if splitAtStr == "" {
 				pe := &ParseError{Path: path, Err: errors.New("split event missing split_at")}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
 			splitAt, err := time.Parse(time.RFC3339, splitAtStr)
 			if err != nil {
 				if p.Strict {
-					return nil, &ParseError{Path: path, Err: err}
+					return nil, nil, &ParseError{Path: path, Err: err}
 				}
 				continue
 			}
 			if !(ent.Start.Before(splitAt) && splitAt.Before(*ent.End)) {
 				pe := &ParseError{Path: path, Err: fmt.Errorf("split_at not within entry bounds for target %s", target)}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
@@ -421,6 +704,8 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 				Billable: ent.Billable,
 				Notes:    []string{},
 				Tags:     ent.Tags,
+				User:     ent.User,
+				Pauses:   ent.pausesWithin(ent.Start, splitAt),
 			}
 			right := Entry{
 				ID:       rightID,
@@ -432,6 +717,8 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 				Billable: ent.Billable,
 				Notes:    []string{},
 				Tags:     ent.Tags,
+				User:     ent.User,
+				Pauses:   ent.pausesWithin(splitAt, *ent.End),
 			}
 			// allow overrides on split event
 			if ev.Customer != "" {
@@ -453,14 +740,16 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			// attach left/right notes from meta if provided, otherwise inherit none
 			if ev.Meta != nil {
 				if ln := ev.Meta["left_note"]; ln != "" {
-					left.Notes = append(left.Notes, ln)
+					left.addNote(ln, ev.TS)
 				}
 				if rn := ev.Meta["right_note"]; rn != "" {
-					right.Notes = append(right.Notes, rn)
+					right.addNote(rn, ev.TS)
 				}
 			}
 			// remove original and add new ones
+			supersede(ent, ev)
 			removeIDs([]string{target})
+			conflicts.replaced([]string{target}, ev)
 			entryMap[left.ID] = &left
 			entryMap[right.ID] = &right
 		case "merge":
@@ -468,7 +757,7 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			if ev.Meta == nil {
 				pe := &ParseError{Path: path, Err: errors.New("merge event missing targets")}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
@@ -476,7 +765,7 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 			if targStr == "" {
 				pe := &ParseError{Path: path, Err: errors.New("merge event missing targets")}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
@@ -489,144 +778,295 @@ func applyCorrections(p *Parser, path string, base []Entry, corrections []Event)
 				}
 				if e, ok := entryMap[tid]; ok {
 					found = append(found, e)
+				} else if c := conflicts.missing(tid, ev); c != nil && p.Strict {
+					return nil, nil, conflictError(path, c)
 				}
 			}
 			if len(found) == 0 {
 				pe := &ParseError{Path: path, Err: fmt.Errorf("merge: no targets found for event %s", ev.ID)}
 				if p.Strict {
-					return nil, pe
+					return nil, nil, pe
 				}
 				continue
 			}
 
-			// Harden validation: disallow merging entries that span different customers or projects
-			// unless the merge event explicitly provides an override (ev.Customer/ev.Project).
-			// We treat only differing non-empty values as a conflict; if all are empty or identical it's fine.
-			if ev.Customer == "" {
-				custSet := map[string]struct{}{}
-				for _, e := range found {
-					if e.Customer != "" {
-						custSet[e.Customer] = struct{}{}
-					}
-				}
-				if len(custSet) > 1 {
-					pe := &ParseError{Path: path, Err: fmt.Errorf("merge targets have conflicting customers; provide an override via event customer")}
-					if p.Strict {
-						return nil, pe
-					}
-					// In non-strict mode, skip the problematic merge
-					continue
-				}
-			}
-			if ev.Project == "" {
-				projSet := map[string]struct{}{}
-				for _, e := range found {
-					if e.Project != "" {
-						projSet[e.Project] = struct{}{}
-					}
-				}
-				if len(projSet) > 1 {
-					pe := &ParseError{Path: path, Err: fmt.Errorf("merge targets have conflicting projects; provide an override via event project")}
-					if p.Strict {
-						return nil, pe
-					}
-					// In non-strict mode, skip the problematic merge
-					continue
-				}
-			}
-
-			// compute min start and max end
-			minStart := found[0].Start
-			var maxEnd *time.Time
+			targets := make([]Entry, 0, len(found))
 			for _, e := range found {
-				if e.Start.Before(minStart) {
-					minStart = e.Start
-				}
-				if e.End != nil {
-					if maxEnd == nil || e.End.After(*maxEnd) {
-						// copy value
-						t := *e.End
-						maxEnd = &t
-					}
-				}
-			}
-			merged := Entry{
-				ID:       ev.ID,
-				Start:    minStart,
-				End:      maxEnd,
-				Customer: "",
-				Project:  "",
-				Activity: "",
-				Billable: false,
-				Notes:    []string{},
-				Tags:     []string{},
+				targets = append(targets, *e)
 			}
-			// choose metadata: event overrides, otherwise first non-empty from targets
-			if ev.Customer != "" {
-				merged.Customer = ev.Customer
-			} else {
-				for _, e := range found {
-					if e.Customer != "" {
-						merged.Customer = e.Customer
-						break
-					}
-				}
-			}
-			if ev.Project != "" {
-				merged.Project = ev.Project
-			} else {
-				for _, e := range found {
-					if e.Project != "" {
-						merged.Project = e.Project
-						break
-					}
-				}
-			}
-			if ev.Activity != "" {
-				merged.Activity = ev.Activity
-			} else {
-				for _, e := range found {
-					if e.Activity != "" {
-						merged.Activity = e.Activity
-						break
-					}
-				}
-			}
-			// billable: event override else any target billable true
-			if ev.Billable != nil {
-				merged.Billable = *ev.Billable
-			} else {
-				for _, e := range found {
-					if e.Billable {
-						merged.Billable = true
-						break
-					}
+			merged, err := MergeEntries(ev, targets)
+			if err != nil {
+				pe := &ParseError{Path: path, Err: err}
+				if p.Strict {
+					return nil, nil, pe
 				}
-			}
-			// combine notes and tags
-			for _, e := range found {
-				merged.Notes = append(merged.Notes, e.Notes...)
-				merged.Tags = append(merged.Tags, e.Tags...)
-			}
-			if ev.Note != "" {
-				merged.Notes = append(merged.Notes, ev.Note)
+				// In non-strict mode, skip the problematic merge
+				continue
 			}
 			// remove targets and insert merged
 			var targetsToRemove []string
 			for _, e := range found {
+				supersede(e, ev)
 				targetsToRemove = append(targetsToRemove, e.ID)
 			}
 			removeIDs(targetsToRemove)
+			conflicts.replaced(targetsToRemove, ev)
 			entryMap[merged.ID] = &merged
 		}
 	}
 
-	// produce final slice from map
-	var out []Entry
+	// produce final slice from map, after the superseded versions so each
+	// entry's versions end with the effective one
+	out := superseded
 	for _, e := range entryMap {
 		out = append(out, *e)
 	}
-	return out, nil
+	sortEntries(out)
+	return out, conflicts.found, nil
+}
+
+// sortEntries orders entries by start, then ID; versions of the same entry
+// (see Parser.KeepSuperseded) keep the order they were produced in.
+func sortEntries(ents []Entry) {
+	sort.SliceStable(ents, func(i, j int) bool {
+		if !ents[i].Start.Equal(ents[j].Start) {
+			return ents[i].Start.Before(ents[j].Start)
+		}
+		return ents[i].ID < ents[j].ID
+	})
+}
+
+// ErrMergeConflict is returned by MergeEntries when the targets disagree on
+// customer or project and the merge event does not override it.
+var ErrMergeConflict = errors.New("merge targets conflict")
+
+// MergeEntries builds the entry a merge event ev produces from its targets,
+// as the parser does when applying it. Only differing non-empty customers or
+// projects conflict, and only when ev does not override them. The targets'
+// notes are combined in the order they were recorded (by target start for
+// entries not built by the parser), each note once, followed by ev.Note.
+func MergeEntries(ev Event, targets []Entry) (Entry, error) {
+	if len(targets) == 0 {
+		return Entry{}, fmt.Errorf("merge: no targets found for event %s", ev.ID)
+	}
+	// Harden validation: disallow merging entries that span different customers or projects
+	// unless the merge event explicitly provides an override (ev.Customer/ev.Project).
+	if ev.Customer == "" {
+		if vals := distinctNonEmpty(targets, func(e Entry) string { return e.Customer }); len(vals) > 1 {
+			return Entry{}, fmt.Errorf("%w: customers %s", ErrMergeConflict, strings.Join(vals, ", "))
+		}
+	}
+	if ev.Project == "" {
+		if vals := distinctNonEmpty(targets, func(e Entry) string { return e.Project }); len(vals) > 1 {
+			return Entry{}, fmt.Errorf("%w: projects %s", ErrMergeConflict, strings.Join(vals, ", "))
+		}
+	}
+
+	// compute min start and max end
+	minStart := targets[0].Start
+	var maxEnd *time.Time
+	for _, e := range targets {
+		if e.Start.Before(minStart) {
+			minStart = e.Start
+		}
+		if e.End != nil {
+			if maxEnd == nil || e.End.After(*maxEnd) {
+				// copy value
+				t := *e.End
+				maxEnd = &t
+			}
+		}
+	}
+	merged := Entry{
+		ID:    ev.ID,
+		Start: minStart,
+		End:   maxEnd,
+		Notes: []string{},
+		Tags:  []string{},
+		User:  targets[0].User,
+	}
+	// choose metadata: event overrides, otherwise first non-empty from targets
+	merged.Customer = firstNonEmpty(ev.Customer, targets, func(e Entry) string { return e.Customer })
+	merged.Project = firstNonEmpty(ev.Project, targets, func(e Entry) string { return e.Project })
+	merged.Activity = firstNonEmpty(ev.Activity, targets, func(e Entry) string { return e.Activity })
+	// billable: event override else any target billable true
+	if ev.Billable != nil {
+		merged.Billable = *ev.Billable
+	} else {
+		for _, e := range targets {
+			if e.Billable {
+				merged.Billable = true
+				break
+			}
+		}
+	}
+	// combine notes, tags and pauses
+	for _, e := range targets {
+		merged.Tags = append(merged.Tags, e.Tags...)
+		merged.Pauses = append(merged.Pauses, e.Pauses...)
+	}
+	sort.SliceStable(merged.Pauses, func(i, j int) bool { return merged.Pauses[i].Start.Before(merged.Pauses[j].Start) })
+	for _, n := range mergedNotes(targets) {
+		merged.addNote(n.text, n.ts)
+	}
+	if ev.Note != "" && !slices.Contains(merged.Notes, ev.Note) {
+		merged.addNote(ev.Note, ev.TS)
+	}
+	return merged, nil
+}
+
+type timedNote struct {
+	text string
+	ts   time.Time
+}
+
+// mergedNotes returns the non-empty notes of targets ordered by when they
+// were recorded (ties keep the targets' order), each text once at its first
+// occurrence.
+func mergedNotes(targets []Entry) []timedNote {
+	var notes []timedNote
+	for _, e := range targets {
+		for i, n := range e.Notes {
+			notes = append(notes, timedNote{text: n, ts: e.noteTime(i)})
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool { return notes[i].ts.Before(notes[j].ts) })
+	seen := map[string]bool{}
+	out := notes[:0]
+	for _, n := range notes {
+		if n.text == "" || seen[n.text] {
+			continue
+		}
+		seen[n.text] = true
+		out = append(out, n)
+	}
+	return out
+}
+
+// distinctNonEmpty returns the distinct non-empty values of field over
+// entries, in first-seen order.
+func distinctNonEmpty(entries []Entry, field func(Entry) string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, e := range entries {
+		if v := field(e); v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// firstNonEmpty returns override, or else the first non-empty value of field
+// over entries.
+func firstNonEmpty(override string, entries []Entry, field func(Entry) string) string {
+	if override != "" {
+		return override
+	}
+	for _, e := range entries {
+		if v := field(e); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ParseFilesStream parses the given journal files in order and emits each
+// file's entries before opening the next one, so consumers aggregating long
+// ranges (e.g. a year of day files) hold at most one day of entries at a time.
+// Entries are ordered by start within a file, not across files.
+//
+// Events of a later file continue an entry still running at the end of an
+// earlier one (see Entry.Carried): a timer running over midnight is paused,
+// resumed and stopped by the events of the following days. Such an entry is
+// held back until it ends or the last file is read.
+//
+// Missing files are skipped. Files that fail to parse are skipped unless the
+// parser is strict, in which case the error is delivered and streaming stops.
+// Cancel ctx to stop early; both channels are closed when streaming ends.
+func (p *Parser) ParseFilesStream(ctx context.Context, paths []string) (<-chan Entry, <-chan error) {
+	out := make(chan Entry)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		emit := func(e Entry) bool {
+			select {
+			case out <- e:
+				return true
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return false
+			}
+		}
+		// entries running at the end of an earlier file, per user
+		running := map[string]*Entry{}
+		for _, path := range paths {
+			ents, err := p.parseFileCarried(path)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) || !p.Strict {
+					continue
+				}
+				errc <- err
+				return
+			}
+			for _, e := range ents {
+				if e.Carried != nil {
+					if done := continueRunning(running, e); done != nil && !emit(*done) {
+						return
+					}
+					continue
+				}
+				if e.End == nil && e.SupersededBy == "" {
+					e := e
+					running[e.User] = &e
+					continue
+				}
+				if !emit(e) {
+					return
+				}
+			}
+		}
+		open := make([]Entry, 0, len(running))
+		for _, e := range running {
+			open = append(open, *e)
+		}
+		sortEntries(open)
+		for _, e := range open {
+			if !emit(e) {
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
+
+// continueRunning applies the carry-over record c to the entry of its user
+// running since an earlier file, like the parser's openFor: a user's events
+// also continue a legacy entry without user. It returns the entry once c
+// ends it (a stop, or a start that auto-stops it).
+func continueRunning(running map[string]*Entry, c Entry) *Entry {
+	slot := c.User
+	if running[slot] == nil {
+		slot = ""
+	}
+	e := running[slot]
+	if e == nil {
+		return nil
+	}
+	for _, ev := range c.Carried {
+		if ev.Type == "start" {
+			ev = Event{Type: "stop", TS: ev.TS}
+		}
+		if continueEntry(e, ev) {
+			delete(running, slot)
+			return e
+		}
+	}
+	return nil
 }
 
 // ParseStream parses entries and returns a channel that emits entries as they are reconstructed.
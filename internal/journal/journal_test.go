@@ -1,8 +1,11 @@
 package journal
 
 import (
+	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -181,6 +184,87 @@ func TestParseStream_EmitsEntries(t *testing.T) {
 	}
 }
 
+func TestParseFilesStream_PerFileAndSkipsMissing(t *testing.T) {
+	dir := t.TempDir()
+	day1 := filepath.Join(dir, "2025-01-06.jsonl")
+	day2 := filepath.Join(dir, "2025-01-07.jsonl")
+	os.WriteFile(day1, []byte(`{"id":"s1","type":"start","ts":"2025-01-06T09:00:00Z","customer":"A"}`+"\n"+`{"id":"x1","type":"stop","ts":"2025-01-06T10:00:00Z"}`+"\n"), 0o644)
+	os.WriteFile(day2, []byte(`{"id":"s2","type":"start","ts":"2025-01-07T09:00:00Z","customer":"B"}`+"\n"), 0o644)
+
+	p := NewParser("UTC")
+	ch, errc := p.ParseFilesStream(context.Background(), []string{day1, filepath.Join(dir, "missing.jsonl"), day2})
+	var got []string
+	for e := range ch {
+		got = append(got, e.Customer)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+	if strings.Join(got, ",") != "A,B" {
+		t.Fatalf("streamed customers = %v; want [A B]", got)
+	}
+}
+
+func TestParseFilesStream_EntryRunningOverMidnight(t *testing.T) {
+	dir := t.TempDir()
+	day1 := filepath.Join(dir, "2025-01-06.jsonl")
+	day2 := filepath.Join(dir, "2025-01-07.jsonl")
+	os.WriteFile(day1, []byte(strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-01-06T23:00:00Z","user":"ann","customer":"A"}`,
+		`{"id":"s2","type":"start","ts":"2025-01-06T22:00:00Z","user":"bob","customer":"B"}`,
+	}, "\n")+"\n"), 0o644)
+	os.WriteFile(day2, []byte(strings.Join([]string{
+		`{"id":"p1","type":"pause","ts":"2025-01-07T00:15:00Z","user":"ann"}`,
+		`{"id":"r1","type":"resume","ts":"2025-01-07T00:45:00Z","user":"ann"}`,
+		`{"id":"n1","type":"note","ts":"2025-01-07T00:50:00Z","user":"ann","note":"late"}`,
+		`{"id":"x1","type":"stop","ts":"2025-01-07T01:00:00Z","user":"ann"}`,
+		`{"id":"r2","type":"resume","ts":"2025-01-07T01:30:00Z","user":"ann"}`, // nothing running: ignored
+		`{"id":"s3","type":"start","ts":"2025-01-07T08:00:00Z","user":"bob","customer":"C"}`,
+	}, "\n")+"\n"), 0o644)
+
+	p := NewParser("UTC")
+	// on its own the second day has only bob's new entry
+	if ents, err := p.ParseFile(day2); err != nil || len(ents) != 1 || ents[0].ID != "s3" {
+		t.Fatalf("ParseFile(day2) = %+v, %v", ents, err)
+	}
+	ch, errc := p.ParseFilesStream(context.Background(), []string{day1, day2})
+	got := map[string]Entry{}
+	for e := range ch {
+		got[e.ID] = e
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	ann, bob := got["s1"], got["s2"]
+	if len(got) != 3 || ann.End == nil || ann.End.Hour() != 1 || ann.Paused(*ann.End) != 30*time.Minute ||
+		strings.Join(ann.Notes, ",") != "late" {
+		t.Fatalf("ann = %+v", ann)
+	}
+	// bob's start on the next day auto-stops his entry like within a file
+	if bob.End == nil || bob.End.Hour() != 8 || got["s3"].End != nil {
+		t.Fatalf("bob = %+v, next = %+v", bob, got["s3"])
+	}
+}
+
+func TestParseFilesStream_Cancel(t *testing.T) {
+	dir := t.TempDir()
+	day := filepath.Join(dir, "2025-01-06.jsonl")
+	os.WriteFile(day, []byte(`{"id":"s1","type":"add","ts":"2025-01-06T09:00:00Z","ref":"2025-01-06T08:00:00Z..2025-01-06T09:00:00Z"}`+"\n"+
+		`{"id":"s2","type":"add","ts":"2025-01-06T10:00:00Z","ref":"2025-01-06T09:00:00Z..2025-01-06T10:00:00Z"}`+"\n"), 0o644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, errc := NewParser("UTC").ParseFilesStream(ctx, []string{day})
+	<-ch
+	cancel()
+	// Nobody receives the second entry, so the producer must observe the
+	// cancellation and report it.
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	for range ch {
+	}
+}
+
 func TestOutOfOrderEventsSorted(t *testing.T) {
 	// stop appears before start; parser must sort by TS to reconstruct correctly
 	input := strings.Join([]string{
@@ -202,7 +286,7 @@ func TestOutOfOrderEventsSorted(t *testing.T) {
 
 func TestUnknownAndOrphanNoteIgnored(t *testing.T) {
 	// Orphan note (no current running entry) should be ignored.
-	// Unknown types like pause/resume are ignored by reconstruction.
+	// A pause outside an entry and a resume without a pause change nothing.
 	input := strings.Join([]string{
 		`{"id":"n0","type":"note","ts":"2025-01-08T08:30:00Z","note":"ignored"}`,
 		`{"id":"p1","type":"pause","ts":"2025-01-08T08:45:00Z"}`,
@@ -225,6 +309,57 @@ func TestUnknownAndOrphanNoteIgnored(t *testing.T) {
 	}
 }
 
+func TestPauseResumeExcludedFromDuration(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-01-08T09:00:00Z","customer":"acme"}`,
+		`{"id":"p1","type":"pause","ts":"2025-01-08T10:00:00Z"}`,
+		`{"id":"p2","type":"pause","ts":"2025-01-08T10:05:00Z"}`,
+		`{"id":"r1","type":"resume","ts":"2025-01-08T10:30:00Z"}`,
+		`{"id":"p3","type":"pause","ts":"2025-01-08T11:45:00Z"}`,
+		`{"id":"st1","type":"stop","ts":"2025-01-08T12:00:00Z"}`,
+		`{"id":"s2","type":"start","ts":"2025-01-08T13:00:00Z","customer":"globex","user":"ana"}`,
+		`{"id":"p4","type":"pause","ts":"2025-01-08T13:30:00Z","user":"ana"}`,
+	}, "\n")
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+	if err != nil || len(ents) != 2 {
+		t.Fatalf("ents = %+v, %v", ents, err)
+	}
+	e := ents[0]
+	if len(e.Pauses) != 2 || !e.Pauses[0].Start.Equal(mustParse(t, "2025-01-08T10:00:00Z")) || !e.Pauses[1].End.Equal(*e.End) {
+		t.Fatalf("pauses = %+v", e.Pauses)
+	}
+	if got := e.Paused(mustParse(t, "2025-01-09T00:00:00Z")); got != 45*time.Minute {
+		t.Fatalf("paused = %v", got)
+	}
+	// The running entry is paused from 13:30 on.
+	if got := ents[1].Paused(mustParse(t, "2025-01-08T14:00:00Z")); ents[1].End != nil || got != 30*time.Minute {
+		t.Fatalf("running paused = %v (%+v)", got, ents[1])
+	}
+}
+
+func TestPausesFollowSplitAndMerge(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-01-08T09:00:00Z","customer":"acme"}`,
+		`{"id":"p1","type":"pause","ts":"2025-01-08T09:50:00Z"}`,
+		`{"id":"r1","type":"resume","ts":"2025-01-08T10:20:00Z"}`,
+		`{"id":"st1","type":"stop","ts":"2025-01-08T12:00:00Z"}`,
+		`{"id":"sp","type":"split","ts":"2025-01-08T13:00:00Z","ref":"s1","meta":{"split_at":"2025-01-08T10:00:00Z"}}`,
+	}, "\n")
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+	if err != nil || len(ents) != 2 {
+		t.Fatalf("ents = %+v, %v", ents, err)
+	}
+	left, right := ents[0], ents[1]
+	if left.Paused(*left.End) != 10*time.Minute || right.Paused(*right.End) != 20*time.Minute {
+		t.Fatalf("split pauses = %+v / %+v", left.Pauses, right.Pauses)
+	}
+
+	merged, err := MergeEntries(Event{ID: "m"}, []Entry{right, left})
+	if err != nil || len(merged.Pauses) != 2 || merged.Paused(*merged.End) != 30*time.Minute || !merged.Pauses[0].Start.Before(merged.Pauses[1].Start) {
+		t.Fatalf("merged = %+v, %v", merged.Pauses, err)
+	}
+}
+
 func TestMultipleStartsAutoStop(t *testing.T) {
 	// Second start auto-stops the first entry at its timestamp
 	input := strings.Join([]string{
@@ -744,3 +879,150 @@ func TestMergeValidationCustomerProject(t *testing.T) {
 		t.Fatalf("expected original targets removed after successful merge with overrides; got A=%v B=%v", foundA, foundB)
 	}
 }
+
+func TestParseReader_PerUserRunningEntries(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"legacy","type":"start","ts":"2025-01-01T08:00:00Z","customer":"ACME"}`,
+		`{"id":"a1","type":"start","ts":"2025-01-01T09:00:00Z","user":"alice","customer":"ACME"}`,
+		`{"id":"b1","type":"start","ts":"2025-01-01T09:15:00Z","user":"bob","customer":"Globex"}`,
+		`{"id":"an","type":"note","ts":"2025-01-01T09:30:00Z","user":"alice","note":"alice note"}`,
+		`{"id":"as","type":"stop","ts":"2025-01-01T10:00:00Z","user":"alice"}`,
+	}, "\n")
+
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader error: %v", err)
+	}
+	byID := map[string]Entry{}
+	for _, e := range ents {
+		byID[e.ID] = e
+	}
+	if len(byID) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", ents)
+	}
+	// an entry from before users were recorded is ended by the next start,
+	// whoever writes it; after that bob's start must not touch alice's entry
+	if e := byID["legacy"]; e.End == nil || !e.End.Equal(mustParse(t, "2025-01-01T09:00:00Z")) {
+		t.Errorf("legacy entry should end at alice's start, got %v", e.End)
+	}
+	a := byID["a1"]
+	if a.User != "alice" || a.End == nil || !a.End.Equal(mustParse(t, "2025-01-01T10:00:00Z")) {
+		t.Errorf("alice entry = %+v", a)
+	}
+	if len(a.Notes) != 1 || a.Notes[0] != "alice note" {
+		t.Errorf("alice notes = %v", a.Notes)
+	}
+	if b := byID["b1"]; b.User != "bob" || b.End != nil {
+		t.Errorf("bob's entry must keep running, got %+v", b)
+	}
+}
+
+func TestMergeNotesDedupedInEventOrder(t *testing.T) {
+	base := []string{
+		`{"id":"s1","type":"start","ts":"2025-03-11T09:00:00Z","customer":"acme","note":"kickoff"}`,
+		`{"id":"n1","type":"note","ts":"2025-03-11T09:30:00Z","note":"review"}`,
+		`{"id":"st1","type":"stop","ts":"2025-03-11T10:00:00Z"}`,
+		`{"id":"s2","type":"start","ts":"2025-03-11T10:00:00Z","customer":"acme","note":"review"}`,
+		`{"id":"n2","type":"note","ts":"2025-03-11T10:30:00Z","note":"deployed"}`,
+		`{"id":"st2","type":"stop","ts":"2025-03-11T11:00:00Z"}`,
+		`{"id":"am","type":"amend","ts":"2025-03-11T12:00:00Z","ref":"s1","note":"follow-up"}`,
+	}
+	want := []string{"kickoff", "review", "deployed", "follow-up", "merged"}
+	for _, targets := range []string{"s1,s2", "s2,s1"} {
+		input := strings.Join(append(base,
+			`{"id":"mg","type":"merge","ts":"2025-03-11T13:00:00Z","note":"merged","meta":{"targets":"`+targets+`"}}`), "\n")
+		ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+		if err != nil || len(ents) != 1 {
+			t.Fatalf("targets %s: ents = %+v, %v", targets, ents, err)
+		}
+		if !reflect.DeepEqual(ents[0].Notes, want) {
+			t.Fatalf("targets %s: notes = %q; want %q", targets, ents[0].Notes, want)
+		}
+	}
+}
+
+func TestSameTimestampEventsKeepFileOrder(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"b","type":"add","ts":"2025-03-12T12:00:00Z","ref":"2025-03-12T09:00:00Z..2025-03-12T10:00:00Z","customer":"acme","note":"retro"}`,
+		`{"id":"a","type":"add","ts":"2025-03-12T12:00:00Z","ref":"2025-03-12T09:00:00Z..2025-03-12T10:00:00Z","customer":"acme","note":"retro"}`,
+		`{"id":"am1","type":"amend","ts":"2025-03-12T13:00:00Z","ref":"a","note":"first"}`,
+		`{"id":"am2","type":"amend","ts":"2025-03-12T13:00:00Z","ref":"a","note":"second"}`,
+		`{"id":"am3","type":"amend","ts":"2025-03-12T13:00:00Z","ref":"a","note":"third"}`,
+	}, "\n")
+	for i := 0; i < 20; i++ {
+		ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+		if err != nil || len(ents) != 2 {
+			t.Fatalf("ents = %+v, %v", ents, err)
+		}
+		if ents[0].ID != "a" || ents[1].ID != "b" {
+			t.Fatalf("entries with the same start not ordered by ID: %s, %s", ents[0].ID, ents[1].ID)
+		}
+		if got := ents[0].Notes; !reflect.DeepEqual(got, []string{"retro", "first", "second", "third"}) {
+			t.Fatalf("amend notes = %q", got)
+		}
+	}
+}
+
+func TestUndoSkipsTombstonedEvents(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-03-13T09:00:00Z","customer":"acme"}`,
+		`{"id":"st1","type":"stop","ts":"2025-03-13T10:00:00Z"}`,
+		`{"id":"s2","type":"start","ts":"2025-03-13T10:05:00Z","customer":"oops"}`,
+		`{"id":"am","type":"amend","ts":"2025-03-13T10:06:00Z","ref":"s1","customer":"globex"}`,
+		`{"id":"u1","type":"undo","ts":"2025-03-13T10:07:00Z","ref":"s2, am"}`,
+	}, "\n")
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+	if err != nil || len(ents) != 1 {
+		t.Fatalf("ents = %+v, %v", ents, err)
+	}
+	if ents[0].ID != "s1" || ents[0].Customer != "acme" || ents[0].End == nil {
+		t.Fatalf("entry = %+v", ents[0])
+	}
+}
+
+func TestKeepSupersededReturnsOriginals(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-03-14T09:00:00Z","customer":"acme","note":"first"}`,
+		`{"id":"st1","type":"stop","ts":"2025-03-14T11:00:00Z"}`,
+		`{"id":"a2","type":"add","ts":"2025-03-14T12:00:00Z","ref":"2025-03-14T11:00:00Z..2025-03-14T12:00:00Z","customer":"acme","note":"second"}`,
+		`{"id":"am","type":"amend","ts":"2025-03-14T13:00:00Z","ref":"s1","project":"portal"}`,
+		`{"id":"sp","type":"split","ts":"2025-03-14T14:00:00Z","ref":"s1","meta":{"split_at":"2025-03-14T10:00:00Z"}}`,
+		`{"id":"mg","type":"merge","ts":"2025-03-14T15:00:00Z","meta":{"targets":"sp.R,a2"}}`,
+	}, "\n")
+
+	plain, err := NewParser("UTC").ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser("UTC")
+	p.KeepSuperseded = true
+	all, err := p.ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var effective []string
+	superseded := map[string][]string{} // entry id -> superseding event ids
+	for _, e := range all {
+		if e.SupersededBy == "" {
+			effective = append(effective, e.ID)
+			continue
+		}
+		superseded[e.ID] = append(superseded[e.ID], e.SupersededBy)
+	}
+	var want []string
+	for _, e := range plain {
+		want = append(want, e.ID)
+	}
+	if !reflect.DeepEqual(effective, want) {
+		t.Fatalf("effective = %v; want %v", effective, want)
+	}
+	wantSup := map[string][]string{"s1": {"am", "sp"}, "sp.R": {"mg"}, "a2": {"mg"}}
+	if !reflect.DeepEqual(superseded, wantSup) {
+		t.Fatalf("superseded = %v; want %v", superseded, wantSup)
+	}
+	// versions are snapshots from before each correction, in order
+	if all[0].ID != "s1" || all[0].Project != "" || all[1].ID != "s1" || all[1].Project != "portal" {
+		t.Fatalf("s1 versions = %+v, %+v", all[0], all[1])
+	}
+}
@@ -0,0 +1,174 @@
+package journaltest
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"tt/internal/journal"
+)
+
+// Project is a customer/project pair fixtures book time on.
+type Project struct {
+	Customer string
+	Project  string
+	Billable bool
+}
+
+// DefaultProjects are used when FixtureConfig.Projects is empty.
+var DefaultProjects = []Project{
+	{"Acme", "Portal", true},
+	{"Acme", "Mobile", true},
+	{"Globex", "Ops", true},
+	{"Initech", "Billing", true},
+	{"Internal", "Admin", false},
+}
+
+var (
+	fixtureActivities = []string{"dev", "review", "meeting", "support"}
+	fixtureNotes      = []string{"standup", "code review", "fixed flaky test", "customer call", "deploy", "planning", ""}
+)
+
+// FixtureConfig shapes a generated journal. Zero values pick the defaults
+// noted on each field.
+type FixtureConfig struct {
+	Start    time.Time      // first day (default 2025-01-06, a Monday)
+	Months   int            // length (default 1)
+	Location *time.Location // wall clock of the working days (default UTC)
+	Seed     int64          // the same seed and config give the same events
+	User     string         // stamped on every event (default none)
+	Projects []Project      // default DefaultProjects
+
+	EntriesPerDay int  // average entries per working day (default 5)
+	Weekends      bool // also track Saturdays and Sundays
+
+	// OverlapRate is the share of entries followed by a retroactively
+	// added entry overlapping them (0..1).
+	OverlapRate float64
+	// CorrectionRate is the share of entries that get an amend, split or
+	// merge later the same day (0..1).
+	CorrectionRate float64
+}
+
+// Generate fabricates a journal: on each working day a run of start/stop
+// entries with notes, gaps and the occasional retroactive add, plus the
+// overlaps and corrections asked for. Events are returned in timestamp
+// order; entry IDs are the IDs of their start or add events.
+func Generate(cfg FixtureConfig) []journal.Event {
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := cfg.Start
+	if start.IsZero() {
+		start = time.Date(2025, 1, 6, 0, 0, 0, 0, loc)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	months := cfg.Months
+	if months <= 0 {
+		months = 1
+	}
+	projects := cfg.Projects
+	if len(projects) == 0 {
+		projects = DefaultProjects
+	}
+	perDay := cfg.EntriesPerDay
+	if perDay <= 0 {
+		perDay = 5
+	}
+
+	g := &fixtureGen{rnd: rand.New(rand.NewSource(cfg.Seed)), user: cfg.User, ids: IDs("fx")}
+	end := start.AddDate(0, months, 0)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !cfg.Weekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		g.day(day, projects, perDay, cfg.OverlapRate, cfg.CorrectionRate)
+	}
+	sort.SliceStable(g.events, func(i, j int) bool { return g.events[i].TS.Before(g.events[j].TS) })
+	return g.events
+}
+
+type fixtureGen struct {
+	rnd    *rand.Rand
+	user   string
+	ids    func() string
+	events []journal.Event
+}
+
+// fixtureEntry is an entry generated for a day, kept for later corrections.
+type fixtureEntry struct {
+	id         string
+	start, end time.Time
+	project    Project
+}
+
+func (g *fixtureGen) emit(e journal.Event) {
+	e.User = g.user
+	g.events = append(g.events, e)
+}
+
+func (g *fixtureGen) day(day time.Time, projects []Project, perDay int, overlapRate, correctionRate float64) {
+	n := perDay - 1 + g.rnd.Intn(3) // perDay ± 1
+	if n < 1 {
+		n = 1
+	}
+	t := day.Add(8*time.Hour + time.Duration(g.rnd.Intn(90))*time.Minute)
+	var ents []fixtureEntry
+	for i := 0; i < n && t.Before(day.Add(19*time.Hour)); i++ {
+		p := projects[g.rnd.Intn(len(projects))]
+		d := time.Duration(20+g.rnd.Intn(100)) * time.Minute
+		id := g.ids()
+		billable := p.Billable
+		g.emit(journal.Event{
+			ID: id, Type: "start", TS: t,
+			Customer: p.Customer, Project: p.Project, Activity: fixtureActivities[g.rnd.Intn(len(fixtureActivities))],
+			Billable: &billable, Note: fixtureNotes[g.rnd.Intn(len(fixtureNotes))],
+		})
+		if g.rnd.Intn(4) == 0 {
+			g.emit(journal.Event{ID: g.ids(), Type: "note", TS: t.Add(d / 2), Note: fixtureNotes[g.rnd.Intn(len(fixtureNotes))]})
+		}
+		g.emit(journal.Event{ID: g.ids(), Type: "stop", TS: t.Add(d)})
+		ents = append(ents, fixtureEntry{id: id, start: t, end: t.Add(d), project: p})
+		t = t.Add(d + time.Duration(g.rnd.Intn(30))*time.Minute)
+	}
+	closing := t.Add(time.Hour) // retroactive edits happen after the last stop
+
+	for _, e := range ents {
+		if overlapRate > 0 && g.rnd.Float64() < overlapRate {
+			st := e.end.Add(-time.Duration(5+g.rnd.Intn(20)) * time.Minute)
+			en := st.Add(time.Duration(15+g.rnd.Intn(45)) * time.Minute)
+			id := g.ids()
+			billable := e.project.Billable
+			g.emit(journal.Event{
+				ID: id, Type: "add", TS: closing,
+				Customer: e.project.Customer, Project: e.project.Project, Billable: &billable,
+				Note: "added later", Ref: st.Format(time.RFC3339) + ".." + en.Format(time.RFC3339),
+			})
+			closing = closing.Add(time.Minute)
+		}
+	}
+	for i, e := range ents {
+		if e.id == "" || correctionRate <= 0 || g.rnd.Float64() >= correctionRate {
+			continue
+		}
+		switch g.rnd.Intn(3) {
+		case 0:
+			g.emit(journal.Event{
+				ID: g.ids(), Type: "amend", TS: closing, Ref: e.id, Note: "amended",
+				Meta: map[string]string{"end": e.end.Add(time.Duration(g.rnd.Intn(15)) * time.Minute).Format(time.RFC3339)},
+			})
+		case 1:
+			at := e.start.Add(e.end.Sub(e.start) / 2)
+			g.emit(journal.Event{ID: g.ids(), Type: "split", TS: closing, Ref: e.id, Meta: map[string]string{"split_at": at.Format(time.RFC3339)}})
+		default:
+			if i+1 >= len(ents) || ents[i+1].project != e.project || ents[i+1].id == "" {
+				g.emit(journal.Event{ID: g.ids(), Type: "amend", TS: closing, Ref: e.id, Note: "amended"})
+				break
+			}
+			g.emit(journal.Event{ID: g.ids(), Type: "merge", TS: closing, Meta: map[string]string{"targets": e.id + "," + ents[i+1].id}})
+			ents[i+1].id = ""
+		}
+		closing = closing.Add(time.Minute)
+	}
+}
@@ -0,0 +1,129 @@
+package journaltest
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"tt/internal/journal"
+)
+
+func TestGenerateIsDeterministicAndParses(t *testing.T) {
+	cfg := FixtureConfig{Months: 2, Seed: 42, OverlapRate: 0.2, CorrectionRate: 0.3, User: "alice"}
+	evs := Generate(cfg)
+	if !reflect.DeepEqual(evs, Generate(cfg)) {
+		t.Fatal("same seed and config gave different events")
+	}
+	if reflect.DeepEqual(evs, Generate(FixtureConfig{Months: 2, Seed: 43, OverlapRate: 0.2, CorrectionRate: 0.3})) {
+		t.Fatal("different seeds gave the same events")
+	}
+
+	types := map[string]int{}
+	for i, e := range evs {
+		types[e.Type]++
+		if e.User != "alice" {
+			t.Fatalf("event %s has user %q", e.ID, e.User)
+		}
+		if i > 0 && e.TS.Before(evs[i-1].TS) {
+			t.Fatalf("events out of order at %s", e.ID)
+		}
+		if d := e.TS.Weekday(); d == time.Saturday || d == time.Sunday {
+			t.Fatalf("weekend event %s without Weekends", e.ID)
+		}
+	}
+	for _, typ := range []string{"start", "stop", "note", "add", "amend", "split", "merge"} {
+		if types[typ] == 0 {
+			t.Errorf("no %s events in %v", typ, types)
+		}
+	}
+
+	paths, err := WriteJournal(t.TempDir(), evs, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Jan 6 .. Mar 5 2025: 43 working days
+	if len(paths) != 43 {
+		t.Fatalf("%d day files; want 43", len(paths))
+	}
+	p := journal.NewParser("UTC")
+	p.Strict = true
+	var n int
+	for _, path := range paths {
+		ents, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("strict parse of %s: %v", path, err)
+		}
+		for _, e := range ents {
+			if e.End == nil {
+				t.Fatalf("%s: entry %s left running", path, e.ID)
+			}
+		}
+		n += len(ents)
+	}
+	if n < 43*4 {
+		t.Errorf("%d entries; want about 5 per day", n)
+	}
+}
+
+func TestWriterMatchesJournalFiles(t *testing.T) {
+	clock := NewClock(time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), 30*time.Minute)
+	ids := IDs("t")
+	w := &Writer{}
+	for i := 0; i < 3; i++ {
+		if err := w.WriteEvent(journal.Event{ID: ids(), Type: "note", TS: clock.Now(), Note: "n"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	evs := w.Events()
+	if len(evs) != 3 || evs[0].ID != "t-000001" || !evs[2].TS.Equal(time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("events = %+v", evs)
+	}
+	if evs[0].PrevHash != "" || evs[1].PrevHash != evs[0].Hash || evs[2].Hash != journal.EventHash(evs[2]) {
+		t.Fatalf("events not hash-chained: %+v", evs)
+	}
+
+	dir := t.TempDir()
+	paths, err := w.WriteJournal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dir + "/2025/03/2025-03-03.jsonl"; len(paths) != 1 || paths[0] != want {
+		t.Fatalf("paths = %v; want %s", paths, want)
+	}
+	anchor, _ := os.ReadFile(paths[0] + ".hash")
+	if string(anchor) != evs[2].Hash {
+		t.Errorf("anchor = %q; want the in-memory head %q", anchor, evs[2].Hash)
+	}
+	b, _ := os.ReadFile(paths[0])
+	if got := strings.Count(string(b), "\n"); got != 3 {
+		t.Errorf("%d lines; want 3", got)
+	}
+
+	w.Err = errors.New("disk full")
+	if err := w.WriteEvent(journal.Event{ID: ids(), Type: "stop", TS: clock.Now()}); err == nil || len(w.Events()) != 3 {
+		t.Fatalf("failing write = %v, %d events", err, len(w.Events()))
+	}
+	w.Reset()
+	if len(w.Events()) != 0 {
+		t.Fatal("Reset kept events")
+	}
+}
+
+func BenchmarkParseGeneratedMonth(b *testing.B) {
+	paths, err := WriteJournal(b.TempDir(), Generate(FixtureConfig{Seed: 1, EntriesPerDay: 8, OverlapRate: 0.1, CorrectionRate: 0.2}), time.UTC)
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := journal.NewParser("UTC")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			if _, err := p.ParseFile(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package journal
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Mark kinds: "pin" keeps an entry on the tt pinned list, "follow-up" flags
+// it as waiting on something (a ticket number, an answer from the customer).
+// An "unpin" event clears either.
+const (
+	MarkPin      = "pin"
+	MarkFollowUp = "follow-up"
+	MarkClear    = "unpin"
+)
+
+// Mark is a marker set on an entry by a "pin", "follow-up" or "unpin"
+// event. The event's Ref holds the target entry ID so the hash chain covers
+// it; Note holds the reason.
+type Mark struct {
+	ID      string // the event's ID
+	EntryID string
+	Kind    string // MarkPin, MarkFollowUp or MarkClear
+	Reason  string
+	TS      time.Time
+	User    string
+	Source  string // optional path where the mark originated
+}
+
+// ErrMarkWithoutTarget is reported in strict mode for mark events without a
+// target entry ID.
+var ErrMarkWithoutTarget = errors.New("mark event without target entry id in ref")
+
+// IsMarkEvent reports whether typ is one of the mark event types.
+func IsMarkEvent(typ string) bool {
+	return typ == MarkPin || typ == MarkFollowUp || typ == MarkClear
+}
+
+// ParseMarkFile returns the mark events recorded in the journal file path.
+func (p *Parser) ParseMarkFile(path string) ([]Mark, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	marks, err := p.ParseMarks(f)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok && pe.Path == "" {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	for i := range marks {
+		marks[i].Source = path
+	}
+	return marks, nil
+}
+
+// ParseMarks returns the mark events of the JSONL events in r, including
+// "unpin", ordered by time. Events without a target are skipped, or
+// reported in strict mode.
+func (p *Parser) ParseMarks(r io.Reader) ([]Mark, error) {
+	if p == nil {
+		p = NewParser("")
+	}
+	events, err := p.readEvents(r, "")
+	if err != nil {
+		return nil, err
+	}
+	var out []Mark
+	for _, ev := range events {
+		if !IsMarkEvent(ev.Type) {
+			continue
+		}
+		if ev.Ref == "" {
+			if p.Strict {
+				return nil, &ParseError{Err: ErrMarkWithoutTarget}
+			}
+			continue
+		}
+		out = append(out, Mark{ID: ev.ID, EntryID: ev.Ref, Kind: ev.Type, Reason: ev.Note, TS: ev.TS, User: ev.User})
+	}
+	return out, nil
+}
+
+// ActiveMarks folds mark events, in any order, into the marks currently set:
+// the latest event per entry wins and "unpin" removes the entry. The result
+// is ordered by the time the mark was set, oldest first.
+func ActiveMarks(marks []Mark) []Mark {
+	sorted := append([]Mark(nil), marks...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TS.Before(sorted[j].TS) })
+	latest := map[string]Mark{}
+	for _, m := range sorted {
+		latest[m.EntryID] = m
+	}
+	var out []Mark
+	for _, m := range latest {
+		if m.Kind != MarkClear {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].TS.Equal(out[j].TS) {
+			return out[i].TS.Before(out[j].TS)
+		}
+		return out[i].EntryID < out[j].EntryID
+	})
+	return out
+}
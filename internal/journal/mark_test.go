@@ -0,0 +1,46 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAndFoldMarks(t *testing.T) {
+	src := strings.Join([]string{
+		`{"id":"s1","type":"start","ts":"2025-10-06T09:00:00Z","customer":"acme"}`,
+		`{"id":"m1","type":"follow-up","ts":"2025-10-06T10:00:00Z","ref":"s1","note":"missing ticket number"}`,
+		`{"id":"m2","type":"pin","ts":"2025-10-06T11:00:00Z","ref":"old1","user":"ana"}`,
+		`{"id":"m3","type":"pin","ts":"2025-10-06T11:30:00Z","ref":"old2"}`,
+		`{"id":"m4","type":"unpin","ts":"2025-10-06T12:00:00Z","ref":"old2"}`,
+		`{"id":"m5","type":"pin","ts":"2025-10-06T12:30:00Z"}`,
+		`{"id":"s1e","type":"stop","ts":"2025-10-06T17:00:00Z"}`,
+	}, "\n")
+	marks, err := NewParser("UTC").ParseMarks(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(marks) != 4 || marks[0].Kind != MarkFollowUp || marks[0].Reason != "missing ticket number" || marks[1].User != "ana" {
+		t.Fatalf("marks = %+v", marks)
+	}
+
+	active := ActiveMarks(marks)
+	if len(active) != 2 || active[0].EntryID != "s1" || active[1].EntryID != "old1" {
+		t.Fatalf("active = %+v", active)
+	}
+	// A later pin replaces the follow-up flag of the same entry.
+	again := append(marks, Mark{ID: "m6", EntryID: "s1", Kind: MarkPin, TS: marks[3].TS.Add(1)})
+	if active := ActiveMarks(again); len(active) != 2 || active[1].EntryID != "s1" || active[1].Kind != MarkPin {
+		t.Fatalf("active after re-pin = %+v", active)
+	}
+
+	ents, err := NewParser("UTC").ParseReader(strings.NewReader(src))
+	if err != nil || len(ents) != 1 || len(ents[0].Notes) != 0 {
+		t.Fatalf("marks leaked into entries: %+v, %v", ents, err)
+	}
+
+	strict := NewParser("UTC")
+	strict.Strict = true
+	if _, err := strict.ParseMarks(strings.NewReader(src)); err == nil || !strings.Contains(err.Error(), "without target") {
+		t.Fatalf("strict err = %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package journal
+
+import (
+	"os"
+	"time"
+)
+
+// Recording describes how the entries of a journal file were recorded, for
+// tracking-hygiene reports: which entries were tracked live and which added
+// afterwards, and how long after an entry ended it was corrected.
+type Recording struct {
+	Live        []Entry      // base entries of start events
+	Retro       []Entry      // base entries of add events
+	Corrections []Correction // amend, split and merge events that applied
+}
+
+// Correction is a correction event and its delay: the time from the end of
+// the latest entry it targets to the event. Running targets count from
+// their start; a correction before the end has no delay.
+type Correction struct {
+	Event Event
+	Delay time.Duration
+}
+
+// RecordingFile returns the Recording of the journal file path. Corrections
+// are replayed in order, so a correction of an entry produced by an earlier
+// split or merge counts from that entry's end.
+func (p *Parser) RecordingFile(path string) (Recording, error) {
+	if p == nil {
+		p = NewParser("")
+	}
+	lenient := *p
+	lenient.Strict = false
+	lenient.Cache = nil
+	lenient.KeepSuperseded = false
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Recording{}, err
+	}
+	defer f.Close()
+	events, err := lenient.readEvents(f, path)
+	if err != nil {
+		return Recording{}, err
+	}
+	base, corrections, _, err := lenient.baseEntries(events, path)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	var rec Recording
+	types := map[string]string{}
+	for _, ev := range events {
+		types[ev.ID] = ev.Type
+	}
+	for _, e := range base {
+		switch types[e.ID] {
+		case "start":
+			rec.Live = append(rec.Live, e)
+		case "add":
+			rec.Retro = append(rec.Retro, e)
+		}
+	}
+
+	state := base
+	for _, ev := range corrections {
+		before := entriesByID(state)
+		var last time.Time
+		found := false
+		for _, t := range correctionTargets(ev) {
+			e, ok := before[t]
+			if !ok {
+				continue
+			}
+			end := e.Start
+			if e.End != nil {
+				end = *e.End
+			}
+			if !found || end.After(last) {
+				last = end
+			}
+			found = true
+		}
+		next, _, err := applyCorrections(&lenient, path, state, []Event{ev})
+		if err != nil {
+			return Recording{}, err
+		}
+		state = next
+		if !found {
+			continue // skipped: the targets are not in this file
+		}
+		delay := ev.TS.Sub(last)
+		if delay < 0 {
+			delay = 0
+		}
+		rec.Corrections = append(rec.Corrections, Correction{Event: ev, Delay: delay})
+	}
+	return rec, nil
+}
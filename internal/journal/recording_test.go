@@ -0,0 +1,43 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordingFile(t *testing.T) {
+	day := strings.Join([]string{
+		`{"id":"e1","type":"start","ts":"2025-08-01T09:00:00Z","customer":"Acme"}`,
+		`{"id":"x1","type":"stop","ts":"2025-08-01T11:00:00Z"}`,
+		`{"id":"e2","type":"add","ts":"2025-08-01T18:00:00Z","ref":"2025-08-01T12:00:00Z..2025-08-01T13:00:00Z","customer":"Acme"}`,
+		`{"id":"a1","type":"amend","ts":"2025-08-01T11:30:00Z","ref":"e1","meta":{"end":"2025-08-01T11:15:00Z"}}`,
+		`{"id":"s1","type":"split","ts":"2025-08-01T19:00:00Z","ref":"e2","meta":{"split_at":"2025-08-01T12:30:00Z"}}`,
+		`{"id":"m1","type":"merge","ts":"2025-08-01T20:00:00Z","meta":{"targets":"e1,s1.L"}}`,
+		`{"id":"a2","type":"amend","ts":"2025-08-01T21:00:00Z","ref":"elsewhere","note":"other day"}`,
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "2025-08-01.jsonl")
+	if err := os.WriteFile(path, []byte(day), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := NewParser("UTC").RecordingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.Live) != 1 || rec.Live[0].ID != "e1" || len(rec.Retro) != 1 || rec.Retro[0].ID != "e2" {
+		t.Fatalf("live %+v retro %+v", rec.Live, rec.Retro)
+	}
+	var got []string
+	for _, c := range rec.Corrections {
+		got = append(got, c.Event.ID+"="+c.Delay.String())
+	}
+	// a1 after e1's original end; s1 after e2; m1 after the later of the
+	// amended e1 (11:15) and s1.L (12:30); a2 targets no entry of the file
+	if want := "a1=30m0s s1=6h0m0s m1=7h30m0s"; strings.Join(got, " ") != want {
+		t.Fatalf("corrections = %v; want %s", got, want)
+	}
+	if _, err := NewParser("UTC").RecordingFile(filepath.Join(t.TempDir(), "missing.jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("missing file err = %v", err)
+	}
+}
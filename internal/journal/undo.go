@@ -0,0 +1,86 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// UndoType is the type of an undo event: a tombstone whose Ref lists the IDs
+// of the events it takes back, comma-separated, so the hash chain covers
+// them. The parser skips tombstoned events (and the undo events themselves)
+// before reconstructing entries, expenses or marks. Tombstones apply within
+// their journal file; tt undo writes each into the file of its targets.
+const UndoType = "undo"
+
+// UndoTargets returns the event IDs an undo event tombstones.
+func UndoTargets(ev Event) []string {
+	if ev.Type != UndoType {
+		return nil
+	}
+	var ids []string
+	for _, id := range strings.Split(ev.Ref, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// DropUndone returns events without undo events and the events they
+// tombstone, keeping the order of the rest.
+func DropUndone(events []Event) []Event {
+	var undone map[string]bool
+	for _, ev := range events {
+		if ev.Type != UndoType {
+			continue
+		}
+		if undone == nil {
+			undone = map[string]bool{}
+		}
+		for _, id := range UndoTargets(ev) {
+			undone[id] = true
+		}
+	}
+	if undone == nil {
+		return events
+	}
+	out := make([]Event, 0, len(events))
+	for _, ev := range events {
+		if ev.Type == UndoType || undone[ev.ID] {
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+// ReadUndoable returns the events of the journal file at path that are not
+// undone, in the order they were appended; undo events are left out.
+// Malformed lines are skipped.
+func ReadUndoable(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var events []Event
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var ev Event
+		if json.Unmarshal([]byte(line), &ev) != nil {
+			continue
+		}
+		events = append(events, ev)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return DropUndone(events), nil
+}
@@ -0,0 +1,154 @@
+// Package outbox keeps pushes to remote systems that failed for a later
+// retry. Each queued item is a JSON file in a directory (~/.tt/outbox) so the
+// queue survives restarts and can be inspected by hand.
+package outbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Item is one queued push.
+type Item struct {
+	Key       string          `json:"key"`  // "<kind>:<id>"; queuing the same key again replaces the item
+	Kind      string          `json:"kind"` // the integration that sends it, e.g. harvest
+	Label     string          `json:"label"`
+	Payload   json.RawMessage `json:"payload"`
+	Queued    time.Time       `json:"queued"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	NextTry   time.Time       `json:"next_try"`
+}
+
+// Due reports whether the item's backoff has passed at now.
+func (it Item) Due(now time.Time) bool { return !now.Before(it.NextTry) }
+
+// Failed records a failed attempt at now and schedules the next one.
+func (it *Item) Failed(now time.Time, err error) {
+	it.Attempts++
+	it.LastError = err.Error()
+	it.NextTry = now.Add(Backoff(it.Attempts))
+}
+
+// Backoff returns the wait after the nth failed attempt: one minute,
+// doubling per attempt, at most an hour.
+func Backoff(attempts int) time.Duration {
+	d := time.Minute
+	for i := 1; i < attempts && d < time.Hour; i++ {
+		d *= 2
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	return d
+}
+
+// Store is an outbox directory.
+type Store struct {
+	Dir string
+}
+
+// DefaultDir returns ~/.tt/outbox.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".tt", "outbox")
+	}
+	return filepath.Join(home, ".tt", "outbox")
+}
+
+// path names the item file after a hash of the key; keys hold entry IDs and
+// URLs that are not safe file names.
+func (s Store) path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(h[:8])+".json")
+}
+
+// Put queues it, replacing an item with the same key. The file is replaced
+// atomically.
+func (s Store) Put(it Item) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(it)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.Dir, ".item-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(it.Key))
+}
+
+// Get returns the item queued under key.
+func (s Store) Get(key string) (Item, bool, error) {
+	var it Item
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return it, false, nil
+	}
+	if err != nil {
+		return it, false, err
+	}
+	return it, true, json.Unmarshal(b, &it)
+}
+
+// Remove drops the item queued under key; a missing item is not an error.
+func (s Store) Remove(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns the queued items, oldest first. A missing directory is an
+// empty outbox.
+func (s Store) List() ([]Item, error) {
+	des, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var items []Item
+	for _, de := range des {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.Dir, de.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var it Item
+		if err := json.Unmarshal(b, &it); err != nil {
+			return nil, &os.PathError{Op: "decode", Path: filepath.Join(s.Dir, de.Name()), Err: err}
+		}
+		items = append(items, it)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].Queued.Equal(items[j].Queued) {
+			return items[i].Queued.Before(items[j].Queued)
+		}
+		return items[i].Key < items[j].Key
+	})
+	return items, nil
+}
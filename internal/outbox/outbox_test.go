@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStorePutListRemove(t *testing.T) {
+	s := Store{Dir: t.TempDir() + "/outbox"}
+	if items, err := s.List(); err != nil || len(items) != 0 {
+		t.Fatalf("empty outbox = %v, %v", items, err)
+	}
+	t0 := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	put := func(key string, at time.Time, payload string) {
+		t.Helper()
+		if err := s.Put(Item{Key: key, Kind: "harvest", Queued: at, Payload: json.RawMessage(payload)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put("harvest:b", t0.Add(time.Minute), `{"n":1}`)
+	put("harvest:a/../x", t0, `{"n":2}`)
+	put("harvest:b", t0.Add(time.Minute), `{"n":3}`) // replaces
+
+	items, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Key != "harvest:a/../x" || items[1].Key != "harvest:b" || string(items[1].Payload) != `{"n":3}` {
+		t.Fatalf("items = %+v", items)
+	}
+	if it, ok, err := s.Get("harvest:b"); !ok || err != nil || string(it.Payload) != `{"n":3}` {
+		t.Fatalf("Get = %+v, %v, %v", it, ok, err)
+	}
+
+	if err := s.Remove("harvest:b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove("harvest:b"); err != nil {
+		t.Fatalf("second remove: %v", err)
+	}
+	if items, _ := s.List(); len(items) != 1 {
+		t.Fatalf("after remove: %+v", items)
+	}
+}
+
+func TestItemBackoff(t *testing.T) {
+	now := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	var it Item
+	if !it.Due(now) {
+		t.Fatal("new item not due")
+	}
+	want := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for i, w := range want {
+		it.Failed(now, errors.New("503"))
+		if it.Attempts != i+1 || !it.NextTry.Equal(now.Add(w)) || it.LastError != "503" {
+			t.Fatalf("after %d failures: %+v; want next try in %v", i+1, it, w)
+		}
+	}
+	if it.Due(now.Add(3 * time.Minute)) {
+		t.Error("due before the backoff passed")
+	}
+	if got := Backoff(20); got != time.Hour {
+		t.Errorf("Backoff(20) = %v; want the 1h cap", got)
+	}
+}
@@ -0,0 +1,167 @@
+// Package picker is the interactive entry picker of the correction commands
+// (amend, split, merge): a list with fuzzy filtering (/) that picks one item,
+// or several with space in multi mode. It knows nothing about entries; the
+// caller supplies display strings and gets IDs back.
+package picker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrCancelled is returned by Run when the user leaves without choosing.
+var ErrCancelled = errors.New("selection cancelled")
+
+// Item is one selectable row.
+type Item struct {
+	ID          string
+	Title       string
+	Description string
+	Filter      string // text the fuzzy filter matches against
+}
+
+// Options configure a picker.
+type Options struct {
+	Title  string // list title, e.g. "Select entry to amend"
+	Action string // verb in the help line, e.g. "amend"
+	Multi  bool   // space marks several items; enter returns the marked ones
+}
+
+// Run shows the picker full screen and returns the chosen IDs in list
+// order: exactly one without Multi, at least one with it.
+func Run(items []Item, o Options) ([]string, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no entries available for selection")
+	}
+	res, err := tea.NewProgram(NewModel(items, o), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	final, ok := res.(Model)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type from selector")
+	}
+	if final.cancelled || len(final.chosen) == 0 {
+		return nil, ErrCancelled
+	}
+	return final.chosen, nil
+}
+
+// Model is the Bubble Tea model behind Run.
+type Model struct {
+	list      list.Model
+	opts      Options
+	marked    map[string]bool
+	order     []string // item IDs in list order
+	chosen    []string
+	cancelled bool
+}
+
+// NewModel returns the picker model for items.
+func NewModel(items []Item, o Options) Model {
+	marked := map[string]bool{}
+	listItems := make([]list.Item, 0, len(items))
+	order := make([]string, 0, len(items))
+	for _, it := range items {
+		listItems = append(listItems, row{Item: it, multi: o.Multi, marked: marked})
+		order = append(order, it.ID)
+	}
+	delegate := list.NewDefaultDelegate()
+	delegate.SetSpacing(0)
+
+	l := list.New(listItems, delegate, 0, 0)
+	l.Title = o.Title
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.Styles.Title = l.Styles.Title.Padding(0, 1)
+	return Model{list: l, opts: o, marked: marked, order: order}
+}
+
+// Chosen returns the IDs picked so far (set when the model quits).
+func (m Model) Chosen() []string { return m.chosen }
+
+// row adapts an Item to the list; marks live in the model's shared map so a
+// toggle shows without replacing the item.
+type row struct {
+	Item
+	multi  bool
+	marked map[string]bool
+}
+
+func (r row) Title() string {
+	if !r.multi {
+		return r.Item.Title
+	}
+	if r.marked[r.ID] {
+		return "[x] " + r.Item.Title
+	}
+	return "[ ] " + r.Item.Title
+}
+
+func (r row) Description() string { return r.Item.Description }
+func (r row) FilterValue() string { return r.Filter }
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		if msg.Width > 0 && msg.Height > 2 {
+			m.list.SetSize(msg.Width, msg.Height-2)
+		}
+	case tea.KeyMsg:
+		// While the filter is being typed, keys belong to the filter input.
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case " ":
+			if m.opts.Multi {
+				if r, ok := m.list.SelectedItem().(row); ok {
+					m.marked[r.ID] = !m.marked[r.ID]
+				}
+				return m, nil
+			}
+		case "enter":
+			m.chosen = m.choose()
+			return m, tea.Quit
+		case "q", "esc", "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// choose returns the marked IDs in list order, or the highlighted item when
+// nothing is marked.
+func (m Model) choose() []string {
+	var out []string
+	for _, id := range m.order {
+		if m.marked[id] {
+			out = append(out, id)
+		}
+	}
+	if len(out) == 0 {
+		if r, ok := m.list.SelectedItem().(row); ok {
+			out = []string{r.ID}
+		}
+	}
+	return out
+}
+
+func (m Model) View() string {
+	help := "\nUse up/down to navigate, / to filter, enter to " + m.opts.Action + ", esc to cancel"
+	if m.opts.Multi {
+		help = "\nUse up/down to navigate, / to filter, space to mark, enter to " + m.opts.Action + " the marked entries, esc to cancel"
+	}
+	return m.list.View() + help
+}
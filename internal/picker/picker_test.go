@@ -0,0 +1,75 @@
+package picker
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func items() []Item {
+	return []Item{
+		{ID: "c", Title: "Wed Acme / web", Filter: "c acme web"},
+		{ID: "b", Title: "Tue Globex / api", Filter: "b globex api"},
+		{ID: "a", Title: "Mon Acme / ops", Filter: "a acme ops"},
+	}
+}
+
+func press(m Model, keys ...tea.KeyMsg) Model {
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(Model)
+	}
+	return m
+}
+
+var (
+	down  = tea.KeyMsg{Type: tea.KeyDown}
+	space = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	enter = tea.KeyMsg{Type: tea.KeyEnter}
+	esc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestPickerSingle(t *testing.T) {
+	m := NewModel(items(), Options{Title: "Select entry to split", Action: "split"})
+	m = press(m, down, enter)
+	if got := m.Chosen(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Fatalf("chosen = %v; want [b]", got)
+	}
+	// Space does not mark in single mode.
+	if r := m.list.Items()[0].(row); r.Title() != "Wed Acme / web" {
+		t.Fatalf("title = %q", r.Title())
+	}
+	if m = press(NewModel(items(), Options{}), esc); !m.cancelled || m.Chosen() != nil {
+		t.Fatalf("esc: cancelled = %v, chosen = %v", m.cancelled, m.Chosen())
+	}
+}
+
+func TestPickerMulti(t *testing.T) {
+	m := NewModel(items(), Options{Title: "Select entries to merge", Action: "merge", Multi: true})
+	// Mark the last and the first row; the result keeps list order.
+	m = press(m, down, down, space)
+	if r := m.list.Items()[2].(row); r.Title() != "[x] Mon Acme / ops" {
+		t.Fatalf("marked title = %q", r.Title())
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyUp}, space, enter)
+	if got := m.Chosen(); !reflect.DeepEqual(got, []string{"c", "a"}) {
+		t.Fatalf("chosen = %v; want [c a]", got)
+	}
+
+	// Nothing marked: enter takes the highlighted row.
+	m = press(NewModel(items(), Options{Multi: true}), down, enter)
+	if got := m.Chosen(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Fatalf("chosen = %v; want [b]", got)
+	}
+}
+
+func TestPickerFilterKeepsKeys(t *testing.T) {
+	m := NewModel(items(), Options{Multi: true})
+	m.list.SetSize(80, 20)
+	// While typing the filter, q and space go to the filter input.
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}, space)
+	if m.cancelled || len(m.marked) != 0 {
+		t.Fatalf("filter input leaked keys: cancelled = %v, marked = %v", m.cancelled, m.marked)
+	}
+}
@@ -0,0 +1,467 @@
+// Package query parses and evaluates the filter expressions of tt query:
+//
+//	type=amend and ts>2025-10-01 and customer~acme
+//	not billable or (tags=urgent and duration>=2h)
+//
+// A condition compares a field with a value:
+//
+//   - = and != test equality, case-insensitively; on lists (tags) they
+//     test membership;
+//   - ~ and !~ match a case-insensitive regular expression;
+//   - < <= > >= order times and durations.
+//
+// A bare field tests that it is set (true for booleans). Conditions combine
+// with and, or, not and parentheses; and binds tighter than or. Values
+// containing spaces or parentheses are quoted with ' or ".
+//
+// Time values are dates (2025-10-01), local times (2025-10-01T09:30) or
+// RFC 3339 timestamps. A date stands for the whole day: ts=2025-10-01 is any
+// time that day and ts>2025-10-01 starts the day after.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kind is the type of a field.
+type Kind int
+
+const (
+	String   Kind = iota // string
+	List                 // []string
+	Time                 // time.Time; the zero time is unset
+	Duration             // time.Duration
+	Bool                 // bool
+)
+
+// Schema maps the field names an expression may use to their kinds. A name
+// ending in ".*" covers every field with that prefix, e.g. meta.*.
+type Schema map[string]Kind
+
+func (s Schema) kind(field string) (Kind, bool) {
+	if k, ok := s[field]; ok && !strings.HasSuffix(field, ".*") {
+		return k, true
+	}
+	for name, k := range s {
+		if prefix, ok := strings.CutSuffix(name, "*"); ok && strings.HasPrefix(field, prefix) && len(field) > len(prefix) {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// Names lists the field names, sorted.
+func (s Schema) Names() []string {
+	out := make([]string, 0, len(s))
+	for n := range s {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Record returns the value of a field of the record being filtered: a
+// string, []string, time.Time, time.Duration or bool according to the
+// field's kind, or nil when the record does not have it.
+type Record func(field string) any
+
+// Expr is a parsed expression.
+type Expr struct {
+	root node
+}
+
+// Match reports whether r satisfies the expression. The empty expression
+// matches everything.
+func (e *Expr) Match(r Record) bool {
+	if e == nil || e.root == nil {
+		return true
+	}
+	return e.root.eval(r)
+}
+
+// Parse parses src against schema. Dates and local times in values are read
+// in loc.
+func Parse(src string, schema Schema, loc *time.Location) (*Expr, error) {
+	p := &parser{src: src, schema: schema, loc: loc}
+	p.skipSpace()
+	if p.pos == len(p.src) {
+		return &Expr{}, nil
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return &Expr{root: n}, nil
+}
+
+type node interface {
+	eval(r Record) bool
+}
+
+type andNode struct{ l, r node }
+
+func (n andNode) eval(r Record) bool { return n.l.eval(r) && n.r.eval(r) }
+
+type orNode struct{ l, r node }
+
+func (n orNode) eval(r Record) bool { return n.l.eval(r) || n.r.eval(r) }
+
+type notNode struct{ n node }
+
+func (n notNode) eval(r Record) bool { return !n.n.eval(r) }
+
+// cond is one field comparison; op is empty for an existence test.
+type cond struct {
+	field string
+	kind  Kind
+	op    string
+
+	str string
+	re  *regexp.Regexp
+	t   time.Time
+	day bool // t is a date: compare with the whole day
+	d   time.Duration
+	b   bool
+}
+
+func (c cond) eval(r Record) bool {
+	v := r(c.field)
+	if c.op == "" {
+		return isSet(v)
+	}
+	negated := c.op == "!=" || c.op == "!~"
+	switch c.kind {
+	case String:
+		s, _ := v.(string)
+		return c.matchString(s)
+	case List:
+		l, _ := v.([]string)
+		for _, s := range l {
+			if c.matchString(s) != negated {
+				return !negated
+			}
+		}
+		return negated
+	case Time:
+		t, ok := v.(time.Time)
+		if !ok || t.IsZero() {
+			return negated
+		}
+		return c.compareTime(t)
+	case Duration:
+		d, ok := v.(time.Duration)
+		if !ok {
+			return negated
+		}
+		return compare(c.op, int64(d), int64(c.d))
+	case Bool:
+		b, _ := v.(bool)
+		return (b == c.b) != negated
+	}
+	return false
+}
+
+func (c cond) matchString(s string) bool {
+	switch c.op {
+	case "=":
+		return strings.EqualFold(s, c.str)
+	case "!=":
+		return !strings.EqualFold(s, c.str)
+	case "~":
+		return c.re.MatchString(s)
+	case "!~":
+		return !c.re.MatchString(s)
+	}
+	return false
+}
+
+func (c cond) compareTime(t time.Time) bool {
+	if !c.day {
+		return compare(c.op, t.UnixNano(), c.t.UnixNano())
+	}
+	start, end := c.t, c.t.AddDate(0, 0, 1)
+	in := !t.Before(start) && t.Before(end)
+	switch c.op {
+	case "=":
+		return in
+	case "!=":
+		return !in
+	case "<":
+		return t.Before(start)
+	case "<=":
+		return t.Before(end)
+	case ">":
+		return !t.Before(end)
+	case ">=":
+		return !t.Before(start)
+	}
+	return false
+}
+
+func compare(op string, a, b int64) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+func isSet(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v != ""
+	case []string:
+		return len(v) > 0
+	case time.Time:
+		return !v.IsZero()
+	case time.Duration:
+		return v != 0
+	case bool:
+		return v
+	}
+	return false
+}
+
+type parser struct {
+	src    string
+	pos    int
+	schema Schema
+	loc    *time.Location
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("query: "+format+" (at column %d)", append(args, p.pos+1)...)
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// keyword consumes the keyword w (case-insensitive) if it comes next as a
+// whole word.
+func (p *parser) keyword(w string) bool {
+	p.skipSpace()
+	end := p.pos + len(w)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], w) {
+		return false
+	}
+	if end < len(p.src) && isFieldChar(p.src[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) parseOr() (node, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = orNode{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = andNode{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.src) || p.src[p.pos] != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+		return n, nil
+	}
+	return p.parseCond()
+}
+
+var operators = []string{"!=", "!~", ">=", "<=", "=", "~", ">", "<"}
+
+func (p *parser) parseCond() (node, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && isFieldChar(p.src[p.pos]) {
+		p.pos++
+	}
+	field := strings.ToLower(p.src[start:p.pos])
+	if field == "" {
+		if p.pos == len(p.src) {
+			return nil, p.errorf("expected a condition")
+		}
+		return nil, p.errorf("expected a field, got %q", p.src[p.pos:p.pos+1])
+	}
+	kind, ok := p.schema.kind(field)
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown field %q (valid: %s)", field, strings.Join(p.schema.Names(), ", "))
+	}
+	c := cond{field: field, kind: kind}
+
+	p.skipSpace()
+	for _, op := range operators {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			c.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if c.op == "" {
+		return c, nil
+	}
+	p.skipSpace()
+	valPos := p.pos
+	val, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.pos, valPos = valPos, p.pos // report value errors at the value
+	if err := p.bind(&c, val); err != nil {
+		return nil, err
+	}
+	p.pos = valPos
+	return c, nil
+}
+
+// value reads a quoted or bare value.
+func (p *parser) value() (string, error) {
+	if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+		q := p.src[p.pos]
+		end := strings.IndexByte(p.src[p.pos+1:], q)
+		if end < 0 {
+			return "", p.errorf("unterminated %c", q)
+		}
+		v := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return v, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\n()", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a value")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// bind converts the value for the field's kind and checks the operator.
+func (p *parser) bind(c *cond, val string) error {
+	ordered := c.op == "<" || c.op == "<=" || c.op == ">" || c.op == ">="
+	regex := c.op == "~" || c.op == "!~"
+	switch c.kind {
+	case String, List:
+		if ordered {
+			return p.errorf("%s cannot be compared with %s", c.field, c.op)
+		}
+		c.str = val
+		if regex {
+			re, err := regexp.Compile("(?i)" + val)
+			if err != nil {
+				return p.errorf("bad pattern %q: %v", val, err)
+			}
+			c.re = re
+		}
+	case Time:
+		if regex {
+			return p.errorf("%s is a time; use = or < <= > >=", c.field)
+		}
+		t, day, err := parseTime(val, p.loc)
+		if err != nil {
+			return p.errorf("%s: %v", c.field, err)
+		}
+		c.t, c.day = t, day
+	case Duration:
+		if regex {
+			return p.errorf("%s is a duration; use = or < <= > >=", c.field)
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return p.errorf("%s: bad duration %q (e.g. 90m, 1h30m)", c.field, val)
+		}
+		c.d = d
+	case Bool:
+		if ordered || regex {
+			return p.errorf("%s is true or false; use = or !=", c.field)
+		}
+		switch strings.ToLower(val) {
+		case "true", "yes", "1":
+			c.b = true
+		case "false", "no", "0":
+			c.b = false
+		default:
+			return p.errorf("%s: want true or false, got %q", c.field, val)
+		}
+	}
+	return nil
+}
+
+func parseTime(s string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, true, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("bad time %q (want 2025-10-01, 2025-10-01T09:30 or RFC 3339)", s)
+}
+
+func isFieldChar(b byte) bool {
+	return b == '_' || b == '.' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"type": String, "customer": String, "tags": List, "ts": Time, "end": Time,
+	"duration": Duration, "billable": Bool, "meta.*": String,
+}
+
+func testRecord(vals map[string]any) Record {
+	return func(field string) any { return vals[field] }
+}
+
+func TestMatch(t *testing.T) {
+	rec := testRecord(map[string]any{
+		"type":      "amend",
+		"customer":  "ACME Corp",
+		"tags":      []string{"urgent", "ops"},
+		"ts":        time.Date(2025, 10, 2, 9, 30, 0, 0, time.UTC),
+		"duration":  90 * time.Minute,
+		"billable":  true,
+		"meta.end":  "2025-10-02T10:00:00Z",
+		"meta.note": "",
+	})
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"type=amend", true},
+		{"type=AMEND and customer~acme", true},
+		{"type=amend and ts>2025-10-01 and customer~acme", true},
+		{"type!=amend", false},
+		{"customer~'^acme corp$'", true},
+		{"customer!~acme", false},
+		{`customer="ACME Corp"`, true},
+		{"ts=2025-10-02", true},
+		{"ts>2025-10-02", false},
+		{"ts>=2025-10-02 and ts<2025-10-03", true},
+		{"ts<=2025-10-01", false},
+		{"ts<2025-10-02T10:00", true},
+		{"ts>2025-10-02T09:30:00Z", false},
+		{"duration>=1h30m and duration<2h", true},
+		{"duration>2h", false},
+		{"billable", true},
+		{"not billable", false},
+		{"billable=false", false},
+		{"tags=URGENT", true},
+		{"tags!=urgent", false},
+		{"tags!=billing", true},
+		{"tags~^op", true},
+		{"tags!~^op", false},
+		{"end", false},
+		{"end>2025-10-01", false},
+		{"meta.end and not meta.note", true},
+		{"type=stop or type=amend and billable", true},
+		{"(type=stop or type=amend) and not billable", false},
+		{"not (type=stop)", true},
+		{"NOT type=stop AND customer~corp", true},
+	}
+	for _, tc := range tests {
+		e, err := Parse(tc.expr, testSchema, time.UTC)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.expr, err)
+			continue
+		}
+		if got := e.Match(rec); got != tc.want {
+			t.Errorf("%q matched %v; want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"colour=red", `unknown field "colour"`},
+		{"meta.=x", `unknown field "meta."`},
+		{"type=", "expected a value"},
+		{"type=amend and", "expected a condition"},
+		{"(type=amend", "missing )"},
+		{"type=amend)", `unexpected ")"`},
+		{"customer~'acme", "unterminated '"},
+		{"customer~(", "expected a value"},
+		{"customer~[", "bad pattern"},
+		{"customer>acme", "cannot be compared"},
+		{"ts~2025", "is a time"},
+		{"ts>yesterday", "bad time"},
+		{"duration>2 hours", "bad duration"},
+		{"billable=maybe", "want true or false"},
+	}
+	for _, tc := range tests {
+		_, err := Parse(tc.expr, testSchema, time.UTC)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Parse(%q) = %v; want an error containing %q", tc.expr, err, tc.want)
+		}
+	}
+}
+
+// Dates are days of the given location.
+func TestParseDateInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	e, err := Parse("ts=2025-10-02", testSchema, berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	late := testRecord(map[string]any{"ts": time.Date(2025, 10, 1, 22, 30, 0, 0, time.UTC)}) // 00:30 in Berlin
+	if !e.Match(late) {
+		t.Error("22:30 UTC is Oct 2 in Berlin")
+	}
+}
@@ -0,0 +1,400 @@
+package reporting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tt/internal/journal"
+	"tt/internal/rounding"
+)
+
+// ErrEmptyEntry is returned by Aggregator.Add for an entry that does not end
+// after it starts.
+var ErrEmptyEntry = errors.New("zero/negative")
+
+// Config configures an Aggregator. The hooks let the caller plug in its
+// configuration; nil hooks fall back to Location, Policy, the project as
+// given and second precision.
+type Config struct {
+	Location *time.Location  // report timezone: overlap days and the default day split
+	Policy   rounding.Policy // base rounding policy
+	Now      time.Time       // running entries count up to Now
+
+	CustomerLocation func(customer string) *time.Location
+	CustomerPolicy   func(customer string) rounding.Policy
+	CanonicalProject func(customer, project string) string
+	Truncate         func(sec int64) int64 // duration precision, applied once per entry
+}
+
+// Aggregator folds entries into per-day customer/project groups one entry at
+// a time. It keeps only group totals, notes and per-day intervals for overlap
+// detection, never the entries themselves, so entries can be streamed in.
+// The week report and the TUI report views share it so their totals agree.
+type Aggregator struct {
+	cfg Config
+
+	groups    map[groupKey]*groupVal
+	intervals map[string][]interval // day -> intervals for overlap detection
+	openDays  map[string]bool
+	minimum   []MinimumUnit // entries raised to the minimum billable time
+	total     int64         // sum of entry contributions (rounded entries at entry level)
+	rawTotal  int64
+}
+
+type groupKey struct {
+	Day      string
+	Customer string
+	Project  string
+}
+
+type groupVal struct {
+	Seconds    int64 // entry contributions; rounded further at aggregate level
+	RawSeconds int64 // as tracked
+	Timezone   string
+	Billable   bool
+	Minimum    bool // holds an entry raised to the minimum
+	Notes      []note
+}
+
+// note remembers when a note's entry started so notes can be presented in
+// chronological order even though entries stream in per day file.
+type note struct {
+	At   time.Time
+	Text string
+}
+
+type interval struct {
+	Start, End time.Time
+	EntryID    string
+}
+
+// MinimumUnit is an entry, or at aggregate level a day group, whose total was
+// raised to the minimum billable time.
+type MinimumUnit struct {
+	EntryID    string // empty for a day group
+	Day        string
+	Customer   string
+	Project    string
+	RawSeconds int64
+	Policy     rounding.Policy // the customer's policy
+}
+
+// Label names the unit: "<id> <day> Customer/Project" for an entry,
+// "<day> Customer / Project" for a group.
+func (u MinimumUnit) Label() string {
+	if u.EntryID != "" {
+		label := u.EntryID + " " + u.Day + " " + u.Customer
+		if u.Project != "" {
+			label += "/" + u.Project
+		}
+		return label
+	}
+	label := u.Day + " " + u.Customer
+	if u.Project != "" {
+		label += " / " + u.Project
+	}
+	return label
+}
+
+// NewAggregator returns an empty Aggregator for cfg.
+func NewAggregator(cfg Config) *Aggregator {
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	if cfg.CustomerLocation == nil {
+		cfg.CustomerLocation = func(string) *time.Location { return cfg.Location }
+	}
+	if cfg.CustomerPolicy == nil {
+		cfg.CustomerPolicy = func(string) rounding.Policy { return cfg.Policy }
+	}
+	if cfg.CanonicalProject == nil {
+		cfg.CanonicalProject = func(_, project string) string { return project }
+	}
+	if cfg.Truncate == nil {
+		cfg.Truncate = func(sec int64) int64 { return sec }
+	}
+	return &Aggregator{
+		cfg:       cfg,
+		groups:    map[groupKey]*groupVal{},
+		intervals: map[string][]interval{},
+		openDays:  map[string]bool{},
+	}
+}
+
+// Add splits one entry into per-day segments, less its pauses, rounds the
+// entry (at entry level) and folds the segments into the day groups. A
+// running entry counts up to Config.Now and marks its days provisional;
+// callers that do not want running entries leave them out. Entries that do not end after they start
+// are rejected with ErrEmptyEntry.
+func (a *Aggregator) Add(e journal.Entry) error {
+	end := a.cfg.Now
+	if e.End != nil {
+		end = *e.End
+	}
+	start := e.Start
+	if !end.After(start) {
+		return ErrEmptyEntry
+	}
+
+	// Days are the customer's local dates; overlaps are checked on the
+	// report's days.
+	loc := a.cfg.CustomerLocation(e.Customer)
+	segs := SplitLocalDays(start, end, loc)
+	var total int64
+	for j := range segs {
+		// paused time (pause/resume events) is not tracked time
+		if paused := int64(e.PausedBetween(segs[j].Start, segs[j].End) / time.Second); paused > 0 {
+			segs[j].Raw -= paused
+			segs[j].Seconds -= paused
+		}
+		total += segs[j].Raw
+	}
+	// The precision applies once per entry; the truncated part comes off the
+	// last segments.
+	if cut := total - a.cfg.Truncate(total); cut > 0 {
+		for j := len(segs) - 1; j >= 0 && cut > 0; j-- {
+			d := min(cut, segs[j].Raw)
+			segs[j].Raw -= d
+			segs[j].Seconds -= d
+			cut -= d
+		}
+		total = a.cfg.Truncate(total)
+	}
+	if total == 0 {
+		return nil // under a minute at minute precision, like tt report
+	}
+
+	policy := a.cfg.CustomerPolicy(e.Customer)
+	bumped := !policy.Aggregate() && policy.Bumped(total)
+	if bumped {
+		a.minimum = append(a.minimum, MinimumUnit{EntryID: e.ID, Day: segs[0].Day, Customer: e.Customer, Project: e.Project, RawSeconds: total, Policy: policy})
+	}
+
+	// Entry level: round the entry's total seconds, then allocate the rounded
+	// total across its segments proportionally (floor allocations, remainder
+	// goes to the last segment) so per-day and per-group sums match per-entry
+	// rounded totals. At aggregate level the segments stay raw.
+	if rounded := policy.Entry(total); rounded != total {
+		var allocated int64
+		for j := range segs {
+			if j == len(segs)-1 {
+				segs[j].Seconds = rounded - allocated
+				continue
+			}
+			alloc := (segs[j].Seconds * rounded) / total
+			segs[j].Seconds = alloc
+			allocated += alloc
+		}
+	}
+
+	cust := e.Customer
+	if strings.TrimSpace(cust) == "" {
+		cust = "(unknown)"
+	}
+	for _, sg := range segs {
+		k := groupKey{Day: sg.Day, Customer: cust, Project: a.cfg.CanonicalProject(e.Customer, e.Project)}
+		g, ok := a.groups[k]
+		if !ok {
+			g = &groupVal{}
+			if loc != a.cfg.Location {
+				g.Timezone = loc.String()
+			}
+			a.groups[k] = g
+		}
+		g.Seconds += sg.Seconds
+		g.RawSeconds += sg.Raw
+		g.Billable = g.Billable || e.Billable
+		g.Minimum = g.Minimum || bumped
+		for _, n := range e.Notes {
+			if normalized := NormalizeNote(n); normalized != "" {
+				g.Notes = append(g.Notes, note{At: e.Start, Text: normalized})
+			}
+		}
+		a.total += sg.Seconds
+		a.rawTotal += sg.Raw
+		if e.End == nil {
+			a.openDays[sg.Day] = true
+		}
+	}
+	if loc != a.cfg.Location {
+		segs = SplitLocalDays(start, end, a.cfg.Location)
+	}
+	for _, sg := range segs {
+		a.intervals[sg.Day] = append(a.intervals[sg.Day], interval{Start: sg.Start, End: sg.End, EntryID: e.ID})
+	}
+	return nil
+}
+
+// Total is the sum of the entry contributions added so far: rounded entries
+// at entry level, tracked seconds at aggregate level.
+func (a *Aggregator) Total() int64 { return a.total }
+
+// RawTotal is the tracked seconds added so far.
+func (a *Aggregator) RawTotal() int64 { return a.rawTotal }
+
+// DaySegment is the part of an entry on one local day.
+type DaySegment struct {
+	Day        string // YYYY-MM-DD in the splitting location
+	Start, End time.Time
+	Seconds    int64 // after per-entry rounding
+	Raw        int64
+}
+
+// SplitLocalDays splits [start, end) at the local midnights of loc. Day
+// boundaries follow the wall clock (a DST day has 23 or 25 hours) while
+// segment lengths are absolute time, so an entry across a transition counts
+// what elapsed.
+func SplitLocalDays(start, end time.Time, loc *time.Location) []DaySegment {
+	var segs []DaySegment
+	endLoc := end.In(loc)
+	for cur := start.In(loc); cur.Before(endLoc); {
+		y, m, d := cur.Date()
+		segEnd := endLoc
+		if next := time.Date(y, m, d+1, 0, 0, 0, 0, loc); next.Before(endLoc) {
+			segEnd = next
+		}
+		sec := int64(segEnd.Sub(cur).Seconds())
+		segs = append(segs, DaySegment{Day: cur.Format("2006-01-02"), Start: cur, End: segEnd, Seconds: sec, Raw: sec})
+		cur = segEnd
+	}
+	return segs
+}
+
+// overlapDays returns the days on which at least two segments overlap.
+func (a *Aggregator) overlapDays() map[string]bool {
+	out := map[string]bool{}
+	for day, ivs := range a.intervals {
+		sorted := append([]interval(nil), ivs...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+		for i := 1; i < len(sorted); i++ {
+			if sorted[i].Start.Before(sorted[i-1].End) {
+				out[day] = true
+				break
+			}
+		}
+	}
+	return out
+}
+
+// OverlapRanges describes each overlapping pair of neighbouring segments,
+// ordered by day.
+func (a *Aggregator) OverlapRanges() []string {
+	days := make([]string, 0, len(a.intervals))
+	for day := range a.intervals {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	out := []string{}
+	for _, day := range days {
+		ivs := append([]interval(nil), a.intervals[day]...)
+		sort.SliceStable(ivs, func(i, j int) bool { return ivs[i].Start.Before(ivs[j].Start) })
+		for i := 1; i < len(ivs); i++ {
+			prev, cur := ivs[i-1], ivs[i]
+			if cur.Start.Before(prev.End) {
+				out = append(out, fmt.Sprintf("%s entry ids %s, %s %s–%s",
+					day, prev.EntryID, cur.EntryID, prev.Start.Format("15:04"), cur.End.Format("15:04")))
+			}
+		}
+	}
+	return out
+}
+
+// Days builds the ordered per-day output for from..to (inclusive), plus any
+// day outside it that a customer timezone moved entries onto. Weekdays are
+// labelled in locale and merged notes wrapped at notesWrap columns.
+func (a *Aggregator) Days(from, to time.Time, locale string, notesWrap int) []Day {
+	loc := a.cfg.Location
+	overlaps := a.overlapDays()
+	byDay := map[string][]groupKey{}
+	for k := range a.groups {
+		byDay[k.Day] = append(byDay[k.Day], k)
+	}
+	inRange := map[string]bool{}
+	var dayKeys []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := d.In(loc).Format("2006-01-02")
+		inRange[key] = true
+		dayKeys = append(dayKeys, key)
+	}
+	for dayKey := range byDay {
+		if !inRange[dayKey] {
+			dayKeys = append(dayKeys, dayKey)
+		}
+	}
+	sort.Strings(dayKeys)
+
+	out := []Day{}
+	for _, dayKey := range dayKeys {
+		d, _ := time.ParseInLocation("2006-01-02", dayKey, loc)
+		day := Day{Date: dayKey, Weekday: WeekdayLabel(d.Weekday(), locale), Groups: []Group{}}
+		keys := byDay[dayKey]
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].Customer != keys[j].Customer {
+				return keys[i].Customer < keys[j].Customer
+			}
+			return keys[i].Project < keys[j].Project
+		})
+		var daySec int64
+		for _, k := range keys {
+			v := a.groups[k]
+			// notes in chronological order, then deduped and merged for display
+			sort.SliceStable(v.Notes, func(i, j int) bool { return v.Notes[i].At.Before(v.Notes[j].At) })
+			texts := make([]string, 0, len(v.Notes))
+			for _, n := range v.Notes {
+				texts = append(texts, n.Text)
+			}
+			notes := DedupeStrings(texts)
+			// the group total is rounded once: per entry in Add, or here
+			policy := a.cfg.CustomerPolicy(k.Customer)
+			roundedSec := policy.Total(v.Seconds)
+			day.Groups = append(day.Groups, Group{
+				Customer:       k.Customer,
+				Project:        k.Project,
+				Seconds:        roundedSec,
+				SecondsRounded: roundedSec,
+				SecondsRaw:     v.RawSeconds,
+				Timezone:       v.Timezone,
+				Billable:       v.Billable,
+				MinimumApplied: v.Minimum || (policy.Aggregate() && policy.Bumped(v.Seconds)),
+				Notes:          notes,
+				NotesMerged:    MergeNotes(notes, notesWrap),
+			})
+			daySec += roundedSec
+		}
+		switch {
+		case daySec == 0 && len(day.Groups) == 0:
+			// empty days are included with an ok flag
+			day.Flags = []string{"ok"}
+		case overlaps[dayKey]:
+			day.Flags = []string{"overlap"}
+		default:
+			day.Flags = []string{}
+		}
+		if a.openDays[dayKey] {
+			day.Flags = append(day.Flags, "provisional")
+		}
+		day.DaySeconds = daySec
+		day.DaySecondsRounded = daySec
+		out = append(out, day)
+	}
+	return out
+}
+
+// MinimumApplied lists the entries raised to the minimum billable time, then
+// at aggregate level the groups of days whose total was.
+func (a *Aggregator) MinimumApplied(days []Day) []MinimumUnit {
+	out := append([]MinimumUnit(nil), a.minimum...)
+	for _, d := range days {
+		for _, g := range d.Groups {
+			policy := a.cfg.CustomerPolicy(g.Customer)
+			if !g.MinimumApplied || !policy.Aggregate() {
+				continue
+			}
+			out = append(out, MinimumUnit{Day: d.Date, Customer: g.Customer, Project: g.Project, RawSeconds: g.SecondsRaw, Policy: policy})
+		}
+	}
+	return out
+}
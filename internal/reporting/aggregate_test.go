@@ -0,0 +1,193 @@
+package reporting
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tt/internal/journal"
+	"tt/internal/rounding"
+)
+
+func endAt(t time.Time) *time.Time { return &t }
+
+func TestAggregator_Days(t *testing.T) {
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+	agg := NewAggregator(Config{Location: time.UTC, Policy: rounding.Policy{QuantumSec: 15 * 60}, Now: at(9, 10, 0)})
+	// Entries arrive per day file, so a later-written entry for the 6th can
+	// come after entries of the 7th; notes must still come out in order.
+	for _, e := range []journal.Entry{
+		{ID: "b", Start: at(6, 11, 0), End: endAt(at(6, 11, 20)), Customer: "Acme", Notes: []string{"second"}},
+		{ID: "c", Start: at(7, 23, 0), End: endAt(at(8, 1, 0)), Customer: "Acme", Notes: []string{"night"}},
+		{ID: "a", Start: at(6, 9, 0), End: endAt(at(6, 9, 50)), Customer: "Acme", Notes: []string{" first ", "second"}},
+		{ID: "d", Start: at(6, 11, 10), End: endAt(at(6, 11, 40))},
+		{ID: "r", Start: at(9, 9, 0), Customer: "Acme"},
+	} {
+		if err := agg.Add(e); err != nil {
+			t.Fatalf("Add(%s): %v", e.ID, err)
+		}
+	}
+
+	days := agg.Days(at(6, 0, 0), at(10, 0, 0), "en", 0)
+	if len(days) != 5 || days[0].Weekday != "Mon" {
+		t.Fatalf("days = %+v", days)
+	}
+	mon := days[0]
+	// 50m -> 60m, 20m -> 30m after per-entry round-up.
+	if g := mon.Groups[1]; g.Customer != "Acme" || g.Seconds != 90*60 || g.SecondsRaw != 70*60 || g.NotesMerged != "first • second" {
+		t.Fatalf("Monday Acme = %+v", g)
+	}
+	if g := mon.Groups[0]; g.Customer != "(unknown)" || g.Seconds != 30*60 {
+		t.Fatalf("Monday unknown = %+v", g)
+	}
+	if len(mon.Flags) != 1 || mon.Flags[0] != "overlap" {
+		t.Fatalf("Monday flags = %v; want overlap", mon.Flags)
+	}
+	// Entry c spans midnight: 1h on the 7th, 1h on the 8th.
+	if days[1].DaySeconds != 3600 || days[2].DaySeconds != 3600 {
+		t.Fatalf("midnight split = %d/%d; want 3600/3600", days[1].DaySeconds, days[2].DaySeconds)
+	}
+	// The running entry counts up to Now and marks its day provisional.
+	if days[3].DaySeconds != 3600 || len(days[3].Flags) != 1 || days[3].Flags[0] != "provisional" {
+		t.Fatalf("running day = %+v", days[3])
+	}
+	if days[4].DaySeconds != 0 || days[4].Flags[0] != "ok" {
+		t.Fatalf("empty day = %+v", days[4])
+	}
+	if agg.Total() != 90*60+30*60+2*3600+3600 || agg.RawTotal() != 70*60+30*60+2*3600+3600 {
+		t.Fatalf("totals = %d/%d", agg.Total(), agg.RawTotal())
+	}
+	if got := agg.OverlapRanges(); len(got) != 1 || got[0] != "2025-10-06 entry ids b, d 11:00–11:40" {
+		t.Fatalf("OverlapRanges = %q", got)
+	}
+}
+
+func TestAggregator_Pauses(t *testing.T) {
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+	agg := NewAggregator(Config{Location: time.UTC, Policy: rounding.Policy{QuantumSec: 15 * 60}, Now: at(8, 12, 0)})
+	// Paused 23:30..00:20 across midnight and, while running, from 11:00 on.
+	for _, e := range []journal.Entry{
+		{ID: "n", Start: at(6, 22, 0), End: endAt(at(7, 2, 0)), Customer: "Acme",
+			Pauses: []journal.Interval{{Start: at(6, 23, 30), End: endAt(at(7, 0, 20))}}},
+		{ID: "r", Start: at(8, 9, 0), Customer: "Acme", Pauses: []journal.Interval{{Start: at(8, 11, 0)}}},
+	} {
+		if err := agg.Add(e); err != nil {
+			t.Fatalf("Add(%s): %v", e.ID, err)
+		}
+	}
+	days := agg.Days(at(6, 0, 0), at(8, 0, 0), "en", 0)
+	if got := []int64{days[0].Groups[0].SecondsRaw, days[1].Groups[0].SecondsRaw, days[2].Groups[0].SecondsRaw}; got[0] != 90*60 || got[1] != 100*60 || got[2] != 2*3600 {
+		t.Fatalf("raw seconds per day = %v", got)
+	}
+	// The night entry's 3h10m round up to 3h15m.
+	if got := days[0].Groups[0].Seconds + days[1].Groups[0].Seconds; got != 195*60 || agg.RawTotal() != 190*60+2*3600 {
+		t.Fatalf("rounded = %d, raw total = %d", got, agg.RawTotal())
+	}
+}
+
+func TestAggregator_EmptyEntry(t *testing.T) {
+	at := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	agg := NewAggregator(Config{Location: time.UTC, Now: at})
+	if err := agg.Add(journal.Entry{ID: "z", Start: at, End: endAt(at)}); !errors.Is(err, ErrEmptyEntry) {
+		t.Fatalf("err = %v; want ErrEmptyEntry", err)
+	}
+	if agg.RawTotal() != 0 {
+		t.Fatalf("raw total = %d", agg.RawTotal())
+	}
+}
+
+func TestAggregator_DSTSplit(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	at := func(day, h int) time.Time { return time.Date(2025, 10, day, h, 0, 0, 0, berlin) }
+
+	agg := NewAggregator(Config{Location: berlin, Policy: rounding.Policy{QuantumSec: 60}, Now: at(27, 12)})
+	// The night the clocks go back: 22:00 to 04:00 wall clock is 7h of
+	// absolute time; 2h on the 25th and 5h on the 26th (a 25-hour day).
+	// The evening entry is split at the wall-clock midnight after the
+	// 25-hour day: 4h on the 26th and 2h on the 27th.
+	for _, e := range []journal.Entry{
+		{ID: "night", Start: at(25, 22), End: endAt(at(26, 4)), Customer: "Acme"},
+		{ID: "evening", Start: at(26, 20), End: endAt(at(27, 2)), Customer: "Acme"},
+	} {
+		if err := agg.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	days := agg.Days(at(25, 0), at(27, 0), "en", 0)
+	got := []int64{days[0].DaySeconds, days[1].DaySeconds, days[2].DaySeconds}
+	want := []int64{2 * 3600, 9 * 3600, 2 * 3600}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("day seconds = %v; want %v", got, want)
+		}
+	}
+	if agg.RawTotal() != 13*3600 {
+		t.Fatalf("raw total = %d; want %d", agg.RawTotal(), 13*3600)
+	}
+}
+
+func TestAggregator_Hooks(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+	base := rounding.Policy{QuantumSec: 900}
+	cfg := Config{
+		Location: time.UTC,
+		Policy:   base,
+		Now:      at(12, 0, 0),
+		CustomerLocation: func(c string) *time.Location {
+			if c == "Globex" {
+				return newYork
+			}
+			return time.UTC
+		},
+		CustomerPolicy: func(c string) rounding.Policy {
+			if c == "Acme" {
+				p := base
+				p.MinimumSec = 1800
+				return p
+			}
+			return base
+		},
+		CanonicalProject: func(_, p string) string { return "web" },
+		Truncate:         func(sec int64) int64 { return sec / 60 * 60 },
+	}
+	for _, level := range []string{rounding.LevelEntry, rounding.LevelAggregate} {
+		cfg.Policy.Level = level
+		base.Level = level
+		agg := NewAggregator(cfg)
+		// 02:00 UTC on the 7th is the evening of the 6th in New York.
+		for _, e := range []journal.Entry{
+			{ID: "a1", Start: at(7, 9, 0), End: endAt(at(7, 9, 5).Add(30 * time.Second)), Customer: "Acme", Project: "WEB"},
+			{ID: "g1", Start: at(7, 2, 0), End: endAt(at(7, 2, 5)), Customer: "Globex"},
+		} {
+			if err := agg.Add(e); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		days := agg.Days(at(7, 0, 0), at(7, 0, 0), "en", 0)
+		if len(days) != 2 || days[0].Date != "2025-10-06" || days[0].Groups[0].Timezone != "America/New_York" {
+			t.Fatalf("%s: days = %+v", level, days)
+		}
+		acme := days[1].Groups[0]
+		if acme.Project != "web" || acme.SecondsRaw != 300 || acme.Seconds != 1800 || !acme.MinimumApplied {
+			t.Fatalf("%s: Acme = %+v", level, acme)
+		}
+		units := agg.MinimumApplied(days)
+		if len(units) != 1 || units[0].RawSeconds != 300 || units[0].Policy.MinimumSec != 1800 {
+			t.Fatalf("%s: MinimumApplied = %+v", level, units)
+		}
+		want := "2025-10-07 Acme / web"
+		if level == rounding.LevelEntry {
+			want = "a1 2025-10-07 Acme/WEB"
+		}
+		if got := units[0].Label(); got != want {
+			t.Fatalf("%s: label = %q; want %q", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV writes one row per day group (days without groups are left out).
+// Dates and hours follow Locale; seconds stay plain integers. With a comma
+// as decimal separator the fields are separated by semicolons, as
+// spreadsheets in those locales expect.
+type CSV struct {
+	Locale Locale
+}
+
+var csvHeader = []string{"date", "weekday", "customer", "project", "timezone", "billable", "seconds", "seconds_raw", "hours", "notes"}
+
+func (c CSV) Render(w io.Writer, r Week) error {
+	l := c.Locale
+	cw := csv.NewWriter(w)
+	if l.Decimal == "," {
+		cw.Comma = ';'
+	}
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, d := range r.Days {
+		for _, g := range d.Groups {
+			row := []string{
+				l.Date(d.Date), d.Weekday, g.Customer, g.Project, g.Timezone,
+				strconv.FormatBool(g.Billable),
+				strconv.FormatInt(g.Seconds, 10),
+				strconv.FormatInt(g.SecondsRaw, 10),
+				l.Hours(g.Seconds),
+				strings.Join(g.Notes, "; "),
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,19 @@
+package reporting
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatDuration formats d in whole minutes as 6h, 45m or 6h45m, the way
+// the target and working-time notes write durations.
+func FormatDuration(d time.Duration) string {
+	m := int(d / time.Minute)
+	switch {
+	case m < 60:
+		return fmt.Sprintf("%dm", m)
+	case m%60 == 0:
+		return fmt.Sprintf("%dh", m/60)
+	}
+	return fmt.Sprintf("%dh%02dm", m/60, m%60)
+}
@@ -0,0 +1,20 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                               "0m",
+		45*time.Minute + 30*time.Second: "45m",
+		6 * time.Hour:                   "6h",
+		6*time.Hour + 5*time.Minute:     "6h05m",
+		26*time.Hour + 45*time.Minute:   "26h45m",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q; want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package reporting
+
+import (
+	"html/template"
+	"io"
+)
+
+// HTML writes a standalone page with one table per day.
+type HTML struct{}
+
+var htmlTemplate = template.Must(template.New("week").Funcs(template.FuncMap{"hours": hours}).Parse(`<!DOCTYPE html>
+<html lang="de">
+<head>
+<meta charset="utf-8">
+<title>Woche {{.Week}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { padding: 0.2em 0.6em; text-align: left; vertical-align: top; }
+td.hours, th.hours { text-align: right; }
+.flag { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Woche {{.Week}} ({{.Range.From}}–{{.Range.To}}) · {{.Timezone}}</h1>
+{{range .Days}}<h2>{{.Weekday}} {{.Date}}{{range .Flags}}{{if ne . "ok"}} <span class="flag">{{.}}</span>{{end}}{{end}}</h2>
+<table>
+<tr><th>Kunde / Projekt</th><th class="hours">Stunden</th>{{if $.Money}}<th class="hours">Betrag</th>{{end}}<th>Notizen</th></tr>
+{{range .Groups}}<tr><td>{{.Label}}</td><td class="hours">{{printf "%.2f" (hours .Seconds)}}</td>{{if $.Money}}<td class="hours">{{with .Amount}}{{$.FormatMoney .}}{{end}}</td>{{end}}<td>{{.NotesMerged}}</td></tr>
+{{end}}<tr><th>Tagessumme</th><th class="hours">{{printf "%.2f" (hours .DaySeconds)}}</th>{{if $.Money}}<th></th>{{end}}<th></th></tr>
+</table>
+{{end}}<p><strong>Wochensumme:</strong> {{printf "%.2f" (hours .WeekSeconds)}}h</p>
+{{with .Money}}<p><strong>Betrag:</strong> {{$.FormatMoney .Amount}}{{if .Unpriced}} (no rate: {{range $i, $u := .Unpriced}}{{if $i}}, {{end}}{{$u}}{{end}}){{end}}</p>
+{{end}}{{with .ReportedTargets}}<h2>Ziele</h2>
+<ul>
+{{range .}}<li>{{.Label}}: {{printf "%.2f" (hours .Seconds)}}h of {{printf "%.2f" (hours .TargetSeconds)}}h{{if .OvertimeSeconds}} <span class="flag">{{printf "%.2f" (hours .OvertimeSeconds)}}h overtime</span>{{end}}</li>
+{{end}}</ul>
+{{end}}
+{{with .Issues}}{{if not .Empty}}<h2>Hinweise</h2>
+<ul>
+{{range .Overlaps}}<li>overlap: {{.}}</li>
+{{end}}{{range .MissingNotes}}<li>missing notes (billable): {{.}}</li>
+{{end}}{{range .WorkingTime}}<li>working time: {{.}}</li>
+{{end}}{{range .MinimumApplied}}<li>minimum applied: {{.}}</li>
+{{end}}{{range .BadEntries}}<li>data issue: {{.}}</li>
+{{end}}</ul>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func (HTML) Render(w io.Writer, r Week) error {
+	return htmlTemplate.Execute(w, r)
+}
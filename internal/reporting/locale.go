@@ -0,0 +1,74 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tt/internal/billing"
+)
+
+// Locale formats the hours, amounts and dates of the table, markdown and
+// CSV renderers. The zero value keeps the machine-friendly defaults: 1234.50,
+// 1234.50 € and 2025-10-13.
+type Locale struct {
+	Decimal       string // decimal separator; "" is "."
+	Thousands     string // thousands separator; "" for none
+	DateLayout    string // time layout of dates; "" is 2006-01-02
+	CurrencyFirst bool   // currency before amounts: €1,234.50
+}
+
+// Locales are the named presets.
+var Locales = map[string]Locale{
+	"iso": {},
+	"de":  {Decimal: ",", Thousands: ".", DateLayout: "02.01.2006"},
+	"en":  {Decimal: ".", Thousands: ",", DateLayout: "2006-01-02", CurrencyFirst: true},
+}
+
+// LocaleFor returns the preset name (case-insensitive); "" is iso.
+func LocaleFor(name string) (Locale, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Locale{}, nil
+	}
+	l, ok := Locales[name]
+	if !ok {
+		names := make([]string, 0, len(Locales))
+		for n := range Locales {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Locale{}, fmt.Errorf("unknown locale %q (want %s)", name, strings.Join(names, "|"))
+	}
+	return l, nil
+}
+
+// Number formats v with prec decimals, e.g. 1.234,50 for de.
+func (l Locale) Number(v float64, prec int) string {
+	return billing.FormatNumber(v, prec, l.Decimal, l.Thousands)
+}
+
+// Money formats amount, rounded to cents, in currency, e.g. 1.234,50 € for
+// de and €1,234.50 for en.
+func (l Locale) Money(amount float64, currency string) string {
+	return billing.PlaceCurrency(l.Number(billing.RoundCents(amount), 2), currency, l.CurrencyFirst)
+}
+
+// Hours formats sec as hours with two decimals, without unit.
+func (l Locale) Hours(sec int64) string {
+	return l.Number(hours(sec), 2)
+}
+
+// Date reformats a YYYY-MM-DD date in the locale's layout; anything else is
+// returned as it is.
+func (l Locale) Date(iso string) string {
+	if l.DateLayout == "" {
+		return iso
+	}
+	d, err := time.Parse("2006-01-02", iso)
+	if err != nil {
+		return iso
+	}
+	return d.Format(l.DateLayout)
+}
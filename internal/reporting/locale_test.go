@@ -0,0 +1,43 @@
+package reporting
+
+import "testing"
+
+func TestLocaleNumberAndDate(t *testing.T) {
+	de, err := LocaleFor("DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		l    Locale
+		v    float64
+		want string
+	}{
+		{Locale{}, 1234.5, "1234.50"},
+		{de, 1234.5, "1.234,50"},
+		{de, 1234567.891, "1.234.567,89"},
+		{Locales["en"], 1234.5, "1,234.50"},
+		{de, -0.001, "0,00"},
+		{de, -12, "-12,00"},
+		{Locale{Thousands: "'"}, 999.999, "1'000.00"},
+	}
+	for _, c := range cases {
+		if got := c.l.Number(c.v, 2); got != c.want {
+			t.Errorf("%+v Number(%v) = %q; want %q", c.l, c.v, got, c.want)
+		}
+	}
+	if got := de.Hours(5400); got != "1,50" {
+		t.Errorf("Hours = %q", got)
+	}
+	if got := de.Money(1234.5, "EUR") + " " + Locales["en"].Money(-12.345, "CHF") + " " + (Locale{}).Money(1234.5, "EUR"); got != "1.234,50 € -CHF 12.35 1234.50 €" {
+		t.Errorf("Money = %q", got)
+	}
+	if got := de.Date("2025-10-13"); got != "13.10.2025" {
+		t.Errorf("Date = %q", got)
+	}
+	if got := de.Date("week 2025-W42"); got != "week 2025-W42" {
+		t.Errorf("Date of a non-date = %q", got)
+	}
+	if _, err := LocaleFor("fr"); err == nil {
+		t.Error("LocaleFor(fr) = nil error")
+	}
+}
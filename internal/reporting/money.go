@@ -0,0 +1,26 @@
+package reporting
+
+import "tt/internal/billing"
+
+// Money is the monetary value of a report (--money): the rounded billable
+// hours times the configured rates.
+type Money struct {
+	Currency string   `json:"currency"`
+	Locale   string   `json:"locale"`
+	Amount   float64  `json:"amount"`
+	Unpriced []string `json:"unpriced"` // billable groups without a rate, not in Amount
+	Format   Locale   `json:"-"`        // number format of the amounts
+}
+
+// FormatMoney formats amount in currency for locale (see billing.FormatMoney).
+func FormatMoney(amount float64, currency, locale string) string {
+	return billing.FormatMoney(amount, currency, locale)
+}
+
+// FormatMoney formats amount in the report's currency and number format.
+func (r Week) FormatMoney(amount float64) string {
+	if r.Money == nil {
+		return FormatMoney(amount, "EUR", "de")
+	}
+	return r.Money.Format.Money(amount, r.Money.Currency)
+}
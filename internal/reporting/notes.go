@@ -0,0 +1,67 @@
+package reporting
+
+import (
+	"strings"
+	"time"
+)
+
+// NormalizeNote trims a note and collapses its whitespace and newlines into
+// single spaces.
+func NormalizeNote(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// DedupeStrings drops empty strings and repeats, keeping the first occurrence.
+func DedupeStrings(arr []string) []string {
+	seen := map[string]struct{}{}
+	out := []string{}
+	for _, s := range arr {
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+// MergeNotes joins notes with " • " for display, wrapped at spaces to
+// wrapCols columns (0 = no wrap).
+func MergeNotes(notes []string, wrapCols int) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	joined := strings.Join(notes, " • ")
+	if wrapCols <= 0 {
+		return joined
+	}
+	var b strings.Builder
+	lineLen := 0
+	for i, w := range strings.Fields(joined) {
+		if i > 0 {
+			lineLen++
+			if lineLen > wrapCols {
+				b.WriteString("\n")
+				lineLen = 0
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(w)
+		lineLen += len(w)
+	}
+	return b.String()
+}
+
+// WeekdayLabel is the short weekday name in locale: "en", else German.
+func WeekdayLabel(wd time.Weekday, locale string) string {
+	en := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	de := []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"}
+	if strings.ToLower(locale) == "en" {
+		return en[int(wd)]
+	}
+	return de[int(wd)]
+}
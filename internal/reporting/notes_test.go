@@ -0,0 +1,36 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDedupeNormalizeMergeNotes(t *testing.T) {
+	if got := NormalizeNote("  API  \n  scaffolding\t"); got != "API scaffolding" {
+		t.Fatalf("NormalizeNote: got %q want %q", got, "API scaffolding")
+	}
+
+	dd := DedupeStrings([]string{"a", "a", "b", "", "b", "c"})
+	if len(dd) != 3 || dd[0] != "a" || dd[1] != "b" || dd[2] != "c" {
+		t.Fatalf("DedupeStrings result unexpected: %v", dd)
+	}
+
+	notes := []string{"API scaffolding", "Standup + deploy"}
+	if got := MergeNotes(notes, 0); got != "API scaffolding • Standup + deploy" {
+		t.Fatalf("MergeNotes no-wrap unexpected: %q", got)
+	}
+	wrapped := MergeNotes(notes, 10)
+	if !strings.Contains(wrapped, "\n") || strings.ReplaceAll(wrapped, "\n", " ") != "API scaffolding • Standup + deploy" {
+		t.Fatalf("MergeNotes wrap unexpected: %q", wrapped)
+	}
+}
+
+func TestWeekdayLabel(t *testing.T) {
+	if got := WeekdayLabel(time.Monday, "en"); got != "Mon" {
+		t.Fatalf("en = %q", got)
+	}
+	if got := WeekdayLabel(time.Sunday, "de"); got != "So" {
+		t.Fatalf("de = %q", got)
+	}
+}
@@ -0,0 +1,73 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Renderer writes a week report in one output format.
+type Renderer interface {
+	Render(w io.Writer, r Week) error
+}
+
+// Options configure the renderers built by New; each renderer reads the
+// fields it needs.
+type Options struct {
+	Palette  Palette // table colors (zero value: plain text)
+	TempoRaw bool    // Tempo: tracked instead of rounded seconds
+	Locale   Locale  // table, markdown and CSV: number and date format
+}
+
+// Palette holds the ANSI sequences of the table renderer.
+type Palette struct {
+	Reset, Dim, Heading, Label, Hours, Notes, Warn, Overlap string
+}
+
+var renderers = map[string]func(Options) Renderer{
+	"table":    func(o Options) Renderer { return Table{Palette: o.Palette, Locale: o.Locale} },
+	"markdown": func(o Options) Renderer { return Markdown{Locale: o.Locale} },
+	"json":     func(Options) Renderer { return JSON{} },
+	"csv":      func(o Options) Renderer { return CSV{Locale: o.Locale} },
+	"html":     func(Options) Renderer { return HTML{} },
+	"tempo":    func(o Options) Renderer { return Tempo{Raw: o.TempoRaw} },
+}
+
+// Register adds (or replaces) the renderer for format.
+func Register(format string, f func(Options) Renderer) {
+	renderers[strings.ToLower(format)] = f
+}
+
+// New returns the renderer for format.
+func New(format string, o Options) (Renderer, error) {
+	f, ok := renderers[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats(), "|"))
+	}
+	return f(o), nil
+}
+
+// Formats lists the registered format names, sorted.
+func Formats() []string {
+	out := make([]string, 0, len(renderers))
+	for f := range renderers {
+		out = append(out, f)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// JSON writes the document described by WeekSchema, indented.
+type JSON struct{}
+
+func (JSON) Render(w io.Writer, r Week) error {
+	r.Issues.Normalize()
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
@@ -0,0 +1,175 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func sampleWeek() Week {
+	return Week{
+		SchemaVersion: WeekSchemaVersion,
+		Week:          "2025-W42",
+		Range:         Range{From: "2025-10-13", To: "2025-10-19"},
+		Timezone:      "UTC",
+		Days: []Day{
+			{Date: "2025-10-13", Weekday: "Mo", Flags: []string{"overlap"}, DaySeconds: 9000, Groups: []Group{
+				{Customer: "Acme", Project: "web", Seconds: 5400, SecondsRaw: 5000, Billable: true, Notes: []string{"login", "<b>fix</b>"}, NotesMerged: "login; <b>fix</b>"},
+				{Customer: "Globex", Seconds: 3600, SecondsRaw: 3500},
+			}},
+			{Date: "2025-10-14", Weekday: "Di", Flags: []string{"ok"}},
+		},
+		WeekSeconds: 9000,
+		Issues:      Issues{Overlaps: []string{"2025-10-13 09:00–09:30"}},
+		Targets: []Target{
+			{Period: "week", Date: "2025-10-13", TargetSeconds: 144000, Seconds: 8500},
+			{Customer: "acme", Period: "day", Date: "2025-10-13", TargetSeconds: 3600, Seconds: 5000, OvertimeSeconds: 1400},
+			{Period: "day", Date: "2025-10-14", TargetSeconds: 28800},
+		},
+	}
+}
+
+func render(t *testing.T, format string, o Options) string {
+	t.Helper()
+	r, err := New(format, o)
+	if err != nil {
+		t.Fatalf("New(%q): %v", format, err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, sampleWeek()); err != nil {
+		t.Fatalf("%s: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestTextRenderers(t *testing.T) {
+	table := render(t, "table", Options{})
+	for _, want := range []string{"Woche 2025-W42  UTC", "Acme / web", "1.50h", "! overlap", "Wochensumme: 2.50h", "Hinweise:", "(no entries)",
+		"Ziele:", "week 2025-W42                  2.36h of 40.00h", "acme 2025-10-13                1.39h of 1.00h, 0.39h overtime"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table lacks %q:\n%s", want, table)
+		}
+	}
+	if strings.Contains(table, "2025-10-14 ") {
+		t.Errorf("table lists a day within its target:\n%s", table)
+	}
+	if strings.Contains(table, "\x1b[") {
+		t.Errorf("table with zero palette has ANSI sequences")
+	}
+	if colored := render(t, "table", Options{Palette: Palette{Heading: "\x1b[36m", Reset: "\x1b[0m"}}); !strings.Contains(colored, "\x1b[36mWoche") {
+		t.Errorf("table ignores the palette")
+	}
+
+	md := render(t, "markdown", Options{})
+	for _, want := range []string{"# Woche 2025-W42 (2025-10-13–2025-10-19) · UTC", "- **Acme / web** — 1.50h", "- **Globex** — 1.00h", "**Wochensumme:** 2.50h", "- ! overlap:",
+		"- acme 2025-10-13: 1.39h of 1.00h, **0.39h overtime**"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestLocaleRenderers(t *testing.T) {
+	o := Options{Locale: Locales["de"]}
+	table := render(t, "table", o)
+	for _, want := range []string{"Mo 13.10.2025", "Acme / web                        1,50h", "Wochensumme: 2,50h",
+		"week 2025-W42                  2,36h of 40,00h", "acme 13.10.2025                1,39h of 1,00h, 0,39h overtime"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table lacks %q:\n%s", want, table)
+		}
+	}
+	md := render(t, "markdown", o)
+	for _, want := range []string{"# Woche 2025-W42 (13.10.2025–19.10.2025) · UTC", "## Mo 13.10.2025", "- **Acme / web** — 1,50h", "**Wochensumme:** 2,50h"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, md)
+		}
+	}
+	cr := csv.NewReader(strings.NewReader(render(t, "csv", o)))
+	cr.Comma = ';'
+	rows, err := cr.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(rows[1], "|"); got != "13.10.2025|Mo|Acme|web||true|5400|5000|1,50|login; <b>fix</b>" {
+		t.Errorf("csv row = %s", got)
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(render(t, "json", Options{})), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["schema_version"] != float64(WeekSchemaVersion) {
+		t.Errorf("schema_version = %v", doc["schema_version"])
+	}
+	issues := doc["issues"].(map[string]interface{})
+	if bad, ok := issues["badEntries"].([]interface{}); !ok || len(bad) != 0 {
+		t.Errorf("issues.badEntries = %#v; want []", issues["badEntries"])
+	}
+	if ts, ok := doc["targets"].([]interface{}); !ok || len(ts) != 3 || ts[1].(map[string]interface{})["overtimeSeconds"] != float64(1400) {
+		t.Errorf("targets = %#v", doc["targets"])
+	}
+}
+
+func TestCSVRenderer(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(render(t, "csv", Options{}))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("rows = %q", rows)
+	}
+	if got := strings.Join(rows[1], ","); got != "2025-10-13,Mo,Acme,web,,true,5400,5000,1.50,login; <b>fix</b>" {
+		t.Errorf("row = %s", got)
+	}
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	html := render(t, "html", Options{})
+	if strings.Contains(html, "<b>fix</b>") || !strings.Contains(html, "&lt;b&gt;fix&lt;/b&gt;") {
+		t.Errorf("notes are not escaped:\n%s", html)
+	}
+	for _, want := range []string{"<title>Woche 2025-W42</title>", "<td>Acme / web</td>", `<span class="flag">overlap</span>`, "<li>overlap: 2025-10-13",
+		`<li>acme 2025-10-13: 1.39h of 1.00h <span class="flag">0.39h overtime</span></li>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html lacks %q", want)
+		}
+	}
+}
+
+func TestTempoRenderer(t *testing.T) {
+	for _, c := range []struct {
+		raw  bool
+		want float64
+	}{{false, 5400}, {true, 5000}} {
+		var logs []map[string]interface{}
+		if err := json.Unmarshal([]byte(render(t, "tempo", Options{TempoRaw: c.raw})), &logs); err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) != 2 || logs[0]["timeSpentSeconds"] != c.want {
+			t.Errorf("raw=%v: worklogs = %v", c.raw, logs)
+		}
+	}
+}
+
+type countRenderer struct{}
+
+func (countRenderer) Render(w io.Writer, r Week) error {
+	_, err := io.WriteString(w, strings.Repeat("x", len(r.Days)))
+	return err
+}
+
+func TestRegistry(t *testing.T) {
+	if _, err := New("pdf", Options{}); err == nil || !strings.Contains(err.Error(), "table") {
+		t.Fatalf("New(pdf) err = %v; want unknown format listing the formats", err)
+	}
+	Register("count", func(Options) Renderer { return countRenderer{} })
+	t.Cleanup(func() { delete(renderers, "count") })
+	if got := render(t, "COUNT", Options{}); got != "xx" {
+		t.Errorf("registered renderer wrote %q", got)
+	}
+}
@@ -0,0 +1,58 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Tempo writes one Tempo worklog per day group with the group's rounded
+// seconds, or the tracked seconds when Raw is set.
+type Tempo struct {
+	Raw bool
+}
+
+type tempoWorklog struct {
+	Date             string                 `json:"date"`
+	StartTime        string                 `json:"startTime"`
+	TimeSpentSeconds int64                  `json:"timeSpentSeconds"`
+	Description      string                 `json:"description"`
+	Attributes       map[string]interface{} `json:"attributes"`
+}
+
+func (t Tempo) Render(w io.Writer, r Week) error {
+	var out []tempoWorklog
+	for _, d := range r.Days {
+		for _, g := range d.Groups {
+			seconds := g.Seconds
+			if t.Raw {
+				seconds = g.SecondsRaw
+			}
+			if seconds <= 0 {
+				continue
+			}
+			desc := g.NotesMerged
+			if len(desc) > 250 {
+				desc = desc[:250]
+			}
+			attr := map[string]interface{}{"customer": g.Customer}
+			if g.Project != "" {
+				attr["project"] = g.Project
+			}
+			if g.Timezone != "" {
+				attr["timezone"] = g.Timezone
+			}
+			attr["tags"] = []string{} // tags are not kept at group level
+			out = append(out, tempoWorklog{
+				Date:             d.Date,
+				StartTime:        "09:00",
+				TimeSpentSeconds: seconds,
+				Description:      fmt.Sprintf("%s (customer: %s, project: %s)", desc, g.Customer, g.Project),
+				Attributes:       attr,
+			})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
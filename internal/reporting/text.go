@@ -0,0 +1,178 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Table is the terminal layout: groups per day with notes, day and week
+// sums and the Hinweise footer.
+type Table struct {
+	Palette Palette
+	Locale  Locale
+}
+
+func (t Table) Render(w io.Writer, r Week) error {
+	p, l := t.Palette, t.Locale
+	const labelWidth = 30
+	const hoursWidth = 7
+
+	fmt.Fprintf(w, "%sWoche %s%s  %s\n\n", p.Heading, r.Week, p.Reset, r.Timezone)
+	for _, d := range r.Days {
+		fmt.Fprintf(w, "%s%s %s%s\n", p.Heading, d.Weekday, l.Date(d.Date), p.Reset)
+		if len(d.Groups) == 0 {
+			fmt.Fprintf(w, "  %s(no entries)%s\n", p.Dim, p.Reset)
+		}
+		for _, g := range d.Groups {
+			label := g.Label()
+			if len(label) > labelWidth-2 {
+				label = label[:labelWidth-5] + "..."
+			}
+			tz := ""
+			if g.Timezone != "" {
+				tz = fmt.Sprintf(" %s(%s)%s", p.Dim, g.Timezone, p.Reset)
+			}
+			money := ""
+			if g.Amount != nil {
+				money = "  " + r.FormatMoney(*g.Amount)
+			}
+			fmt.Fprintf(w, "  %s%-*s%s %s%*sh%s%s%s\n", p.Label, labelWidth, label, p.Reset, p.Hours, hoursWidth, l.Hours(g.Seconds), p.Reset, money, tz)
+			// merged notes are already wrapped; one muted line each
+			if g.NotesMerged != "" {
+				for _, ln := range strings.Split(g.NotesMerged, "\n") {
+					fmt.Fprintf(w, "    %s- %s%s\n", p.Notes, ln, p.Reset)
+				}
+			}
+		}
+
+		mark := ""
+		if d.HasFlag("overlap") {
+			mark = fmt.Sprintf(" %s! overlap%s", p.Overlap, p.Reset)
+		}
+		if d.HasFlag("provisional") {
+			mark += fmt.Sprintf(" %s(provisional)%s", p.Warn, p.Reset)
+		}
+		fmt.Fprintf(w, "\n  %sTagessumme:%s %s%*sh%s%s\n\n", p.Heading, p.Reset, p.Warn, hoursWidth, l.Hours(d.DaySeconds), p.Reset, mark)
+	}
+
+	fmt.Fprintf(w, "%sWochensumme:%s %s%sh%s\n", p.Heading, p.Reset, p.Hours, l.Hours(r.WeekSeconds), p.Reset)
+	if m := r.Money; m != nil {
+		fmt.Fprintf(w, "%sBetrag:%s %s%s%s\n", p.Heading, p.Reset, p.Hours, r.FormatMoney(m.Amount), p.Reset)
+		if len(m.Unpriced) > 0 {
+			fmt.Fprintf(w, "  %sno rate (not included): %s%s\n", p.Warn, strings.Join(m.Unpriced, ", "), p.Reset)
+		}
+	}
+	if ts := r.ReportedTargets(); len(ts) > 0 {
+		fmt.Fprintf(w, "%sZiele:%s\n", p.Heading, p.Reset)
+		for _, t := range ts {
+			line := fmt.Sprintf("%sh of %sh", l.Hours(t.Seconds), l.Hours(t.TargetSeconds))
+			if t.OvertimeSeconds > 0 {
+				line = fmt.Sprintf("%s%s, %sh overtime%s", p.Warn, line, l.Hours(t.OvertimeSeconds), p.Reset)
+			}
+			fmt.Fprintf(w, "  %-30s %s\n", t.label(l), line)
+		}
+	}
+
+	is := r.Issues
+	if is.Empty() {
+		return nil
+	}
+	fmt.Fprintf(w, "\n%sHinweise:%s\n", p.Heading, p.Reset)
+	for _, o := range is.Overlaps {
+		fmt.Fprintf(w, "  %s! overlap:%s %s\n", p.Overlap, p.Reset, o)
+	}
+	for _, m := range is.MissingNotes {
+		fmt.Fprintf(w, "  %s! missing notes (billable):%s %s\n", p.Warn, p.Reset, m)
+	}
+	for _, m := range is.WorkingTime {
+		fmt.Fprintf(w, "  %s! working time:%s %s\n", p.Warn, p.Reset, m)
+	}
+	for _, m := range is.MinimumApplied {
+		fmt.Fprintf(w, "  %sminimum applied:%s %s\n", p.Dim, p.Reset, m)
+	}
+	if len(is.BadEntries) > 0 {
+		fmt.Fprintf(w, "  %sData issues:%s %d entries\n", p.Warn, p.Reset, len(is.BadEntries))
+		for _, be := range is.BadEntries {
+			fmt.Fprintf(w, "    - %s\n", be)
+		}
+	}
+	return nil
+}
+
+// Markdown renders one section per day and the Hinweise as a list.
+type Markdown struct {
+	Locale Locale
+}
+
+func (m Markdown) Render(w io.Writer, r Week) error {
+	l := m.Locale
+	fmt.Fprintf(w, "# Woche %s (%s–%s) · %s\n\n", r.Week, l.Date(r.Range.From), l.Date(r.Range.To), r.Timezone)
+	for _, d := range r.Days {
+		fmt.Fprintf(w, "## %s %s\n\n", d.Weekday, l.Date(d.Date))
+		for _, g := range d.Groups {
+			money := ""
+			if g.Amount != nil {
+				money = " · " + r.FormatMoney(*g.Amount)
+			}
+			fmt.Fprintf(w, "- **%s** — %sh%s\n\n  %s\n", g.Label(), l.Hours(g.Seconds), money, g.NotesMerged)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	fmt.Fprintf(w, "\n**Wochensumme:** %sh\n\n", l.Hours(r.WeekSeconds))
+	if m := r.Money; m != nil {
+		fmt.Fprintf(w, "**Betrag:** %s\n\n", r.FormatMoney(m.Amount))
+		if len(m.Unpriced) > 0 {
+			fmt.Fprintf(w, "No rate (not included): %s\n\n", strings.Join(m.Unpriced, ", "))
+		}
+	}
+	if ts := r.ReportedTargets(); len(ts) > 0 {
+		fmt.Fprintln(w, "**Ziele:**")
+		fmt.Fprintln(w)
+		for _, t := range ts {
+			line := fmt.Sprintf("- %s: %sh of %sh", t.label(l), l.Hours(t.Seconds), l.Hours(t.TargetSeconds))
+			if t.OvertimeSeconds > 0 {
+				line += fmt.Sprintf(", **%sh overtime**", l.Hours(t.OvertimeSeconds))
+			}
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w)
+	}
+
+	is := r.Issues
+	if is.Empty() {
+		return nil
+	}
+	fmt.Fprintln(w, "Hinweise:")
+	for _, o := range is.Overlaps {
+		fmt.Fprintf(w, "- ! overlap: %s\n", o)
+	}
+	for _, m := range is.MissingNotes {
+		fmt.Fprintf(w, "- ! missing notes (billable): %s\n", m)
+	}
+	for _, m := range is.WorkingTime {
+		fmt.Fprintf(w, "- ! working time: %s\n", m)
+	}
+	for _, m := range is.MinimumApplied {
+		fmt.Fprintf(w, "- minimum applied: %s\n", m)
+	}
+	if len(is.BadEntries) > 0 {
+		fmt.Fprintf(w, "- Data issues (%d):\n", len(is.BadEntries))
+		for _, be := range is.BadEntries {
+			fmt.Fprintf(w, "  - %s\n", be)
+		}
+	}
+	return nil
+}
+
+// ReportedTargets are the targets the text formats list: the weekly ones,
+// and daily ones only where they were exceeded.
+func (r Week) ReportedTargets() []Target {
+	var out []Target
+	for _, t := range r.Targets {
+		if t.Period == "week" || t.OvertimeSeconds > 0 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
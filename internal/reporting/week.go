@@ -0,0 +1,157 @@
+// Package reporting holds the week report document, the day aggregation
+// that builds its days from journal entries (Aggregator) and the renderers
+// that turn it into table, Markdown, JSON, CSV, HTML or Tempo output.
+// Renderers only read a Week, so a new format never touches the aggregation.
+package reporting
+
+import (
+	_ "embed"
+	"fmt"
+	"time"
+)
+
+// WeekSchemaVersion is the schema_version of the JSON document. It is bumped
+// only for incompatible changes; new optional fields keep the version.
+const WeekSchemaVersion = 1
+
+// WeekSchema is the JSON Schema (draft 2020-12) of Week.
+//
+//go:embed week_schema.json
+var WeekSchema []byte
+
+// Week is a week report: per-day customer/project groups plus totals and
+// issues. Its JSON form is described by WeekSchema.
+type Week struct {
+	SchemaVersion      int      `json:"schema_version"`
+	Week               string   `json:"week"` // ISO week label, e.g. 2025-W41
+	Range              Range    `json:"range"`
+	Timezone           string   `json:"timezone"`
+	Days               []Day    `json:"days"`
+	WeekSeconds        int64    `json:"weekSeconds"`
+	WeekSecondsRounded int64    `json:"weekSecondsRounded"` // same as WeekSeconds, kept for consumers of older output
+	WeekSecondsRaw     int64    `json:"weekSecondsRaw"`
+	Provisional        bool     `json:"provisional"` // totals include running entries
+	Rounding           Rounding `json:"rounding"`
+	Issues             Issues   `json:"issues"`
+	Money              *Money   `json:"money,omitempty"`   // with --money
+	Targets            []Target `json:"targets,omitempty"` // hour targets (targets config)
+}
+
+// Range is the report's first and last date (YYYY-MM-DD).
+type Range struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Day is one date of the report with its groups.
+type Day struct {
+	Date              string   `json:"date"`
+	Weekday           string   `json:"weekday"`
+	Groups            []Group  `json:"groups"`
+	DaySeconds        int64    `json:"daySeconds"`
+	DaySecondsRounded int64    `json:"daySecondsRounded"`
+	Flags             []string `json:"flags"` // ok, overlap, provisional
+}
+
+// Group is the time of one customer/project on a day.
+type Group struct {
+	Customer       string   `json:"customer"`
+	Project        string   `json:"project,omitempty"`
+	Seconds        int64    `json:"seconds"`        // rounded by the rounding pipeline
+	SecondsRounded int64    `json:"secondsRounded"` // same as Seconds, kept for consumers of older output
+	SecondsRaw     int64    `json:"secondsRaw"`
+	Timezone       string   `json:"timezone,omitempty"`       // customers.<name>.timezone the day is bucketed in
+	Billable       bool     `json:"billable"`                 // any entry of the group is billable
+	MinimumApplied bool     `json:"minimumApplied,omitempty"` // an entry (or, at aggregate level, the total) was raised to the minimum
+	Amount         *float64 `json:"amount,omitempty"`         // with --money: Seconds times the rate, for billable groups with one
+	Notes          []string `json:"notes"`
+	NotesMerged    string   `json:"notesMerged"`
+}
+
+// Label is "Customer / Project", or the customer alone.
+func (g Group) Label() string {
+	if g.Project == "" {
+		return g.Customer
+	}
+	return g.Customer + " / " + g.Project
+}
+
+// Rounding describes the rounding policy; the seconds are only set when the
+// rounding breakdown was requested.
+type Rounding struct {
+	Level          string `json:"level"`
+	Strategy       string `json:"strategy"`
+	QuantumMinutes int64  `json:"quantumMinutes"`
+	MinimumMinutes int64  `json:"minimumMinutes"`
+	RawSeconds     *int64 `json:"rawSeconds,omitempty"`
+	RoundedSeconds *int64 `json:"roundedSeconds,omitempty"`
+	DeltaSeconds   *int64 `json:"deltaSeconds,omitempty"`
+}
+
+// Target is the work towards an hour target on a day or in an ISO week of
+// the report, in tracked (unrounded) seconds.
+type Target struct {
+	Customer        string `json:"customer,omitempty"` // empty for the overall target
+	Period          string `json:"period"`             // day or week
+	Date            string `json:"date"`               // the day, or the Monday of the week
+	TargetSeconds   int64  `json:"targetSeconds"`
+	Seconds         int64  `json:"seconds"`
+	OvertimeSeconds int64  `json:"overtimeSeconds"` // worked past the target
+}
+
+// Label names the target, e.g. "week 2025-W41" or "acme 2025-10-07".
+func (t Target) Label() string {
+	return t.label(Locale{})
+}
+
+// label is Label with the day in l's date layout.
+func (t Target) label(l Locale) string {
+	label := l.Date(t.Date)
+	if t.Period == "week" {
+		if d, err := time.Parse("2006-01-02", t.Date); err == nil {
+			y, w := d.ISOWeek()
+			label = fmt.Sprintf("week %d-W%02d", y, w)
+		}
+	}
+	if t.Customer != "" {
+		label = t.Customer + " " + label
+	}
+	return label
+}
+
+// Issues are the report's hints (Hinweise); the lists are never null in JSON
+// once Normalize ran.
+type Issues struct {
+	Overlaps       []string `json:"overlaps"`
+	BadEntries     []string `json:"badEntries"`
+	OpenEntries    []string `json:"openEntries"`
+	MissingNotes   []string `json:"missingNotes"`
+	MinimumApplied []string `json:"minimumApplied"`
+	WorkingTime    []string `json:"workingTime,omitempty"` // working-time limits exceeded (working_time config)
+}
+
+// Normalize replaces nil issue lists with empty ones.
+func (i *Issues) Normalize() {
+	for _, l := range []*[]string{&i.Overlaps, &i.BadEntries, &i.OpenEntries, &i.MissingNotes, &i.MinimumApplied} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+}
+
+// Empty reports whether there is nothing to hint at.
+func (i Issues) Empty() bool {
+	return len(i.Overlaps) == 0 && len(i.BadEntries) == 0 && len(i.MissingNotes) == 0 && len(i.MinimumApplied) == 0 && len(i.WorkingTime) == 0
+}
+
+// HasFlag reports whether the day carries flag.
+func (d Day) HasFlag(flag string) bool {
+	for _, f := range d.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func hours(sec int64) float64 { return float64(sec) / 3600.0 }
@@ -0,0 +1,128 @@
+// Package rounding is the single rounding pipeline of the reports and
+// exports. A Policy rounds a duration to its quantum with a strategy and a
+// per-unit minimum; its Level decides what a unit is:
+//
+//   - entry (default): every entry is rounded, group totals are sums of
+//     rounded entries;
+//   - aggregate: entries are summed raw and each group total is rounded once.
+//
+// Either way a total is rounded exactly once. With the up strategy a rounded
+// total is never below the raw time.
+package rounding
+
+import "strings"
+
+// Levels at which a Policy rounds.
+const (
+	LevelEntry     = "entry"
+	LevelAggregate = "aggregate"
+)
+
+// DefaultQuantumSec is the quantum used when a Policy has none (15 minutes).
+const DefaultQuantumSec = 15 * 60
+
+// Policy describes how durations (in seconds) are rounded.
+type Policy struct {
+	Strategy   string // up (default) | down | nearest
+	QuantumSec int64
+	MinimumSec int64  // minimum per rounded unit; 0 disables
+	Level      string // entry (default) | aggregate
+}
+
+// Quantum returns the effective quantum in seconds.
+func (p Policy) Quantum() int64 {
+	if p.QuantumSec <= 0 {
+		return DefaultQuantumSec
+	}
+	return p.QuantumSec
+}
+
+// Aggregate reports whether group totals, not entries, are rounded.
+func (p Policy) Aggregate() bool {
+	return strings.EqualFold(p.Level, LevelAggregate)
+}
+
+// Round rounds one unit (an entry or a group total, depending on Level).
+// Zero and negative durations round to 0. With nearest, a remainder of at
+// least half the quantum (counted in whole minutes for minute quanta, so 7m
+// of a 15m quantum) rounds up.
+func (p Policy) Round(sec int64) int64 {
+	if sec <= 0 {
+		return 0
+	}
+	q := p.Quantum()
+	switch strings.ToLower(p.Strategy) {
+	case "down":
+		sec = sec / q * q
+	case "nearest":
+		half := q / 2
+		if q%60 == 0 && half >= 60 {
+			half = half / 60 * 60
+		}
+		if sec%q >= half {
+			sec = (sec/q + 1) * q
+		} else {
+			sec = sec / q * q
+		}
+	default: // up
+		if sec%q != 0 {
+			sec = (sec/q + 1) * q
+		}
+	}
+	if p.MinimumSec > 0 && sec < p.MinimumSec {
+		sec = p.MinimumSec
+	}
+	return sec
+}
+
+// Bumped reports whether Round raises sec to the minimum, i.e. the unit is
+// billed at MinimumSec rather than at its rounded duration.
+func (p Policy) Bumped(sec int64) bool {
+	if p.MinimumSec <= 0 || sec <= 0 {
+		return false
+	}
+	q := p
+	q.MinimumSec = 0
+	return q.Round(sec) < p.MinimumSec
+}
+
+// Entry returns what an entry contributes to a rounded total: its rounded
+// duration at entry level and its raw duration at aggregate level.
+func (p Policy) Entry(sec int64) int64 {
+	if sec <= 0 {
+		return 0
+	}
+	if p.Aggregate() {
+		return sec
+	}
+	return p.Round(sec)
+}
+
+// Total finishes a group total built from Entry contributions: it rounds the
+// sum at aggregate level and returns it unchanged at entry level.
+func (p Policy) Total(sum int64) int64 {
+	if p.Aggregate() {
+		return p.Round(sum)
+	}
+	return sum
+}
+
+// Tally accumulates the entries of one group.
+type Tally struct {
+	Raw  int64 // tracked seconds
+	part int64 // sum of Entry contributions
+}
+
+// Add adds one entry's duration.
+func (t *Tally) Add(p Policy, sec int64) {
+	if sec <= 0 {
+		return
+	}
+	t.Raw += sec
+	t.part += p.Entry(sec)
+}
+
+// Rounded returns the group's rounded total under p.
+func (t Tally) Rounded(p Policy) int64 {
+	return p.Total(t.part)
+}
@@ -0,0 +1,106 @@
+package rounding
+
+import "testing"
+
+func TestRoundStrategies(t *testing.T) {
+	cases := []struct {
+		strategy string
+		sec      int64
+		want     int64
+	}{
+		{"up", 1, 900},
+		{"up", 900, 900},
+		{"up", 901, 1800},
+		{"down", 899, 0},
+		{"down", 1799, 900},
+		{"nearest", 7 * 60, 900},
+		{"nearest", 7*60 - 1, 0},
+		{"nearest", 22 * 60, 1800},
+		{"", 0, 0},
+		{"", -60, 0},
+	}
+	for _, c := range cases {
+		p := Policy{Strategy: c.strategy, QuantumSec: 900}
+		if got := p.Round(c.sec); got != c.want {
+			t.Errorf("%s.Round(%d) = %d, want %d", c.strategy, c.sec, got, c.want)
+		}
+	}
+	if got := (Policy{MinimumSec: 1800}).Round(60); got != 1800 {
+		t.Errorf("minimum: Round(60) = %d, want 1800", got)
+	}
+	p := Policy{QuantumSec: 900, MinimumSec: 1800}
+	for sec, want := range map[int64]bool{0: false, 60: true, 900: true, 901: false, 3600: false} {
+		if got := p.Bumped(sec); got != want {
+			t.Errorf("Bumped(%d) = %v, want %v", sec, got, want)
+		}
+	}
+	if (Policy{QuantumSec: 900}).Bumped(60) {
+		t.Errorf("Bumped without minimum = true")
+	}
+}
+
+func TestTallyLevels(t *testing.T) {
+	entries := []int64{5 * 60, 5 * 60, 5 * 60} // three 5m entries
+	for _, c := range []struct {
+		level string
+		want  int64
+	}{
+		{LevelEntry, 3 * 900},
+		{LevelAggregate, 900},
+	} {
+		p := Policy{QuantumSec: 900, Level: c.level}
+		var tl Tally
+		for _, e := range entries {
+			tl.Add(p, e)
+		}
+		if tl.Raw != 900 {
+			t.Errorf("%s: Raw = %d, want 900", c.level, tl.Raw)
+		}
+		if got := tl.Rounded(p); got != c.want {
+			t.Errorf("%s: Rounded = %d, want %d", c.level, got, c.want)
+		}
+	}
+}
+
+// TestRoundingInvariants checks, for many entry sets, that rounding up never
+// yields less than the raw time and that entry- and aggregate-level totals
+// stay within the expected distance of the raw time for every strategy.
+func TestRoundingInvariants(t *testing.T) {
+	seed := int64(1)
+	next := func() int64 { // small deterministic LCG
+		seed = (seed*6364136223846793005 + 1442695040888963407) & (1<<62 - 1)
+		return seed >> 20
+	}
+	for _, q := range []int64{60, 6 * 60, 900, 3600} {
+		for i := 0; i < 300; i++ {
+			n := int(next()%6) + 1
+			entries := make([]int64, n)
+			for j := range entries {
+				entries[j] = next()%(4*3600) + 1
+			}
+			for _, strategy := range []string{"up", "down", "nearest"} {
+				for _, level := range []string{LevelEntry, LevelAggregate} {
+					p := Policy{Strategy: strategy, QuantumSec: q, Level: level}
+					var tl Tally
+					for _, e := range entries {
+						tl.Add(p, e)
+					}
+					got := tl.Rounded(p)
+					units := int64(1)
+					if level == LevelEntry {
+						units = int64(n)
+					}
+					if got%q != 0 {
+						t.Fatalf("q=%d %s/%s %v: total %d not a multiple of the quantum", q, strategy, level, entries, got)
+					}
+					if strategy == "up" && (got < tl.Raw || got-tl.Raw >= units*q) {
+						t.Fatalf("q=%d up/%s %v: rounded %d vs raw %d", q, level, entries, got, tl.Raw)
+					}
+					if d := got - tl.Raw; d <= -units*q || d >= units*q {
+						t.Fatalf("q=%d %s/%s %v: rounded %d drifts more than %d quanta from raw %d", q, strategy, level, entries, got, units, tl.Raw)
+					}
+				}
+			}
+		}
+	}
+}
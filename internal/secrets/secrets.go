@@ -0,0 +1,276 @@
+// Package secrets stores API tokens outside the YAML config. The OS keyring
+// is used through its command line tools (security on macOS, secret-tool on
+// Linux); elsewhere, or when configured, an AES-GCM encrypted file keyed by a
+// passphrase is the fallback. Config values of the form "keyring:<name>"
+// refer to a stored secret.
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Prefix marks a config value as a reference to a stored secret.
+const Prefix = "keyring:"
+
+// ErrNotFound is returned when a secret does not exist in the store.
+var ErrNotFound = errors.New("secret not found")
+
+// Store gets, sets and deletes named secrets.
+type Store interface {
+	Name() string
+	Get(name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}
+
+// Ref returns the secret name of a "keyring:<name>" value.
+func Ref(value string) (string, bool) {
+	if !strings.HasPrefix(value, Prefix) {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(value, Prefix))
+	return name, name != ""
+}
+
+// ---- OS keyring ----
+
+// runCommand runs a keyring tool with stdin and returns its stdout. It is a
+// variable so tests can fake the tools.
+var runCommand = func(stdin, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var out, errb bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errb
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(errb.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+// lookPath is exec.LookPath, replaceable in tests.
+var lookPath = exec.LookPath
+
+type keyring struct {
+	service string
+	tool    string // "security" or "secret-tool"
+}
+
+// KeyringTool returns the keyring command line tool of this OS, or "" when
+// none is installed.
+func KeyringTool() string {
+	tool := ""
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "linux", "freebsd", "openbsd", "netbsd":
+		tool = "secret-tool"
+	}
+	if tool == "" {
+		return ""
+	}
+	if _, err := lookPath(tool); err != nil {
+		return ""
+	}
+	return tool
+}
+
+// NewKeyring returns the OS keyring store for service, or an error when no
+// keyring tool is available.
+func NewKeyring(service string) (Store, error) {
+	tool := KeyringTool()
+	if tool == "" {
+		return nil, fmt.Errorf("no OS keyring available (install secret-tool, or use the encrypted file)")
+	}
+	return keyring{service: service, tool: tool}, nil
+}
+
+func (k keyring) Name() string { return "keyring (" + k.tool + ")" }
+
+func (k keyring) Get(name string) (string, error) {
+	var out string
+	var err error
+	if k.tool == "security" {
+		out, err = runCommand("", k.tool, "find-generic-password", "-s", k.service, "-a", name, "-w")
+	} else {
+		out, err = runCommand("", k.tool, "lookup", "service", k.service, "account", name)
+	}
+	if err != nil || out == "" {
+		// both tools exit non-zero when the item does not exist
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+func (k keyring) Set(name, value string) error {
+	if k.tool == "security" {
+		// -w last without a value makes security prompt for the secret
+		// (and its confirmation) on stdin, so it never appears in the
+		// argument list that ps shows to other users
+		_, err := runCommand(value+"\n"+value+"\n", k.tool, "add-generic-password", "-U", "-s", k.service, "-a", name, "-w")
+		return err
+	}
+	_, err := runCommand(value, k.tool, "store", "--label", k.service+" "+name, "service", k.service, "account", name)
+	return err
+}
+
+func (k keyring) Delete(name string) error {
+	if _, err := k.Get(name); err != nil {
+		return err
+	}
+	if k.tool == "security" {
+		_, err := runCommand("", k.tool, "delete-generic-password", "-s", k.service, "-a", name)
+		return err
+	}
+	_, err := runCommand("", k.tool, "clear", "service", k.service, "account", name)
+	return err
+}
+
+// ---- encrypted file ----
+
+// kdfIterations is the PBKDF2-SHA256 work factor; tests lower it.
+var kdfIterations = 600_000
+
+type fileStore struct {
+	path       string
+	passphrase string
+}
+
+// fileFormat is the on-disk form; Data is the AES-GCM sealed JSON object of
+// name -> secret.
+type fileFormat struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// NewFile returns a store encrypting all secrets in one file with a key
+// derived from passphrase.
+func NewFile(path, passphrase string) (Store, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("encrypted secrets file needs a passphrase")
+	}
+	return fileStore{path: path, passphrase: passphrase}, nil
+}
+
+func (f fileStore) Name() string { return "encrypted file (" + f.path + ")" }
+
+func (f fileStore) Get(name string) (string, error) {
+	all, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := all[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return v, nil
+}
+
+func (f fileStore) Set(name, value string) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	all[name] = value
+	return f.save(all)
+}
+
+func (f fileStore) Delete(name string) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	delete(all, name)
+	return f.save(all)
+}
+
+func (f fileStore) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, f.passphrase, salt, kdfIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (f fileStore) load() (map[string]string, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ff fileFormat
+	if err := json.Unmarshal(b, &ff); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+	gcm, err := f.aead(ff.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, ff.Nonce, ff.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: cannot decrypt (wrong passphrase?)", f.path)
+	}
+	all := map[string]string{}
+	if err := json.Unmarshal(plain, &all); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+	return all, nil
+}
+
+func (f fileStore) save(all map[string]string) error {
+	plain, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	ff := fileFormat{Version: 1, Salt: make([]byte, 16)}
+	if _, err := rand.Read(ff.Salt); err != nil {
+		return err
+	}
+	gcm, err := f.aead(ff.Salt)
+	if err != nil {
+		return err
+	}
+	ff.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(ff.Nonce); err != nil {
+		return err
+	}
+	ff.Data = gcm.Seal(nil, ff.Nonce, plain, nil)
+	b, err := json.Marshal(ff)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	kdfIterations = 1000
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	s, err := NewFile(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("harvest_token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get on a missing file = %v, want ErrNotFound", err)
+	}
+	if err := s.Set("harvest_token", "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("redmine_key", "r3d"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := s.Get("harvest_token"); err != nil || v != "abc123" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+
+	wrong, _ := NewFile(path, "wrong")
+	if _, err := wrong.Get("harvest_token"); err == nil || !strings.Contains(err.Error(), "decrypt") {
+		t.Errorf("wrong passphrase: %v", err)
+	}
+	if err := s.Delete("harvest_token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("harvest_token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted secret still readable: %v", err)
+	}
+	if v, _ := s.Get("redmine_key"); v != "r3d" {
+		t.Errorf("other secret lost: %q", v)
+	}
+	if _, err := NewFile(path, ""); err == nil {
+		t.Error("empty passphrase should fail")
+	}
+}
+
+func TestKeyringUsesOSTool(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake covers secret-tool only")
+	}
+	items := map[string]string{}
+	var calls []string
+	oldRun, oldLook := runCommand, lookPath
+	t.Cleanup(func() { runCommand, lookPath = oldRun, oldLook })
+	lookPath = func(string) (string, error) { return "/usr/bin/secret-tool", nil }
+	runCommand = func(stdin, name string, args ...string) (string, error) {
+		calls = append(calls, args[0])
+		account := args[len(args)-1]
+		switch args[0] {
+		case "store":
+			items[account] = stdin
+		case "lookup":
+			v, ok := items[account]
+			if !ok {
+				return "", errors.New("exit status 1")
+			}
+			return v + "\n", nil
+		case "clear":
+			delete(items, account)
+		}
+		return "", nil
+	}
+
+	k, err := NewKeyring("tt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Set("tempo_token", "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := k.Get("tempo_token"); err != nil || v != "s3cret" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+	if err := k.Delete("tempo_token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Get("tempo_token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "store,lookup,lookup,clear,lookup" {
+		t.Errorf("tool calls = %s", got)
+	}
+
+	if name, ok := Ref("keyring:tempo_token"); !ok || name != "tempo_token" {
+		t.Errorf("Ref = %q, %v", name, ok)
+	}
+	if _, ok := Ref("plain"); ok {
+		t.Error("plain value is not a reference")
+	}
+}
+
+func TestKeyringKeepsSecretOutOfArgs(t *testing.T) {
+	type call struct {
+		stdin string
+		args  []string
+	}
+	var calls []call
+	oldRun := runCommand
+	t.Cleanup(func() { runCommand = oldRun })
+	runCommand = func(stdin, name string, args ...string) (string, error) {
+		calls = append(calls, call{stdin, append([]string{name}, args...)})
+		return "", nil
+	}
+	for _, tool := range []string{"security", "secret-tool"} {
+		calls = nil
+		if err := (keyring{service: "tt", tool: tool}).Set("tempo_token", "s3cret-value"); err != nil {
+			t.Fatal(err)
+		}
+		if len(calls) != 1 {
+			t.Fatalf("%s: calls = %+v", tool, calls)
+		}
+		for _, a := range calls[0].args {
+			if strings.Contains(a, "s3cret-value") {
+				t.Errorf("%s: secret in the argument list: %q", tool, calls[0].args)
+			}
+		}
+		if !strings.HasPrefix(calls[0].stdin, "s3cret-value") {
+			t.Errorf("%s: stdin = %q; want the secret", tool, calls[0].stdin)
+		}
+		if tool == "security" && calls[0].args[len(calls[0].args)-1] != "-w" {
+			t.Errorf("security: -w is not the last argument: %q", calls[0].args)
+		}
+	}
+}
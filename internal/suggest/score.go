@@ -0,0 +1,195 @@
+package suggest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Weights weigh the components of a score. Each component is in [0,1], so
+// with weights adding up to 1 a score is in [0,1] as well; only their ratios
+// matter for the order.
+type Weights struct {
+	Frequency float64
+	Recency   float64
+	TimeOfDay float64
+	Weekday   float64
+}
+
+// frequencyOnly reports whether w orders by count alone.
+func (w Weights) frequencyOnly() bool {
+	return w.Recency == 0 && w.TimeOfDay == 0 && w.Weekday == 0
+}
+
+// Config tunes the rankings.
+type Config struct {
+	// Lookback is the history window callers load uses from.
+	Lookback time.Duration
+	// RecencyHalfLife is the age at which the recency component drops to 0.5.
+	RecencyHalfLife time.Duration
+	// RankWeights order favorites (Rank: tt fav, the TUI suggestions). The
+	// default is frequency only, ties broken by recency.
+	RankWeights Weights
+	// ScoreWeights order the suggestions for a moment (Score: tt suggest).
+	ScoreWeights Weights
+}
+
+// DefaultConfig returns the built-in rankings.
+func DefaultConfig() Config {
+	return Config{
+		Lookback:        Lookback,
+		RecencyHalfLife: 7 * 24 * time.Hour,
+		RankWeights:     Weights{Frequency: 1},
+		ScoreWeights:    Weights{Frequency: 0.35, Recency: 0.15, TimeOfDay: 0.30, Weekday: 0.20},
+	}
+}
+
+// timeOfDayWindow is how close a past start must be to the requested time of
+// day to count as "around" it.
+const timeOfDayWindow = 90 * time.Minute
+
+// Component is one weighted part of a score with a human explanation.
+type Component struct {
+	Name   string  // frequency|recency|time-of-day|weekday
+	Value  float64 // in [0,1] before weighting
+	Weight float64
+	Reason string
+}
+
+// Scored is a combo ranked for a moment in time.
+type Scored struct {
+	Ranked
+	Score      float64
+	Components []Component
+}
+
+// Score ranks combos for the moment at with the default config.
+func Score(uses []Use, at time.Time) []Scored {
+	return DefaultConfig().Score(uses, at)
+}
+
+// Score ranks combos for the moment at: besides overall frequency and
+// recency it rewards combos usually started around at's time of day and on
+// at's weekday, weighed by ScoreWeights. Times are compared in at's
+// location. Ties fall back to the Rank order.
+func (c Config) Score(uses []Use, at time.Time) []Scored {
+	return c.score(Rank(uses), uses, at, c.ScoreWeights)
+}
+
+// Rank orders combos like Rank, then, unless RankWeights are frequency
+// only, by their score for the moment at under RankWeights, e.g. to let
+// recent combos overtake ones that were frequent weeks ago.
+func (c Config) Rank(uses []Use, at time.Time, seeds ...Use) []Ranked {
+	ranked := Rank(uses, seeds...)
+	if c.RankWeights.frequencyOnly() {
+		return ranked
+	}
+	scored := c.score(ranked, uses, at, c.RankWeights)
+	out := make([]Ranked, len(scored))
+	for i, s := range scored {
+		out[i] = s.Ranked
+	}
+	return out
+}
+
+// score weighs the components of each ranked combo with w and sorts them by
+// score, stable on the given order.
+func (c Config) score(ranked []Ranked, uses []Use, at time.Time, w Weights) []Scored {
+	if len(ranked) == 0 {
+		return nil
+	}
+	halfLife := c.RecencyHalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultConfig().RecencyHalfLife
+	}
+	type pattern struct{ nearTime, sameDay int }
+	patterns := map[string]*pattern{}
+	loc := at.Location()
+	atMin := at.Hour()*60 + at.Minute()
+	for _, u := range uses {
+		k := u.Key()
+		p, ok := patterns[k]
+		if !ok {
+			p = &pattern{}
+			patterns[k] = p
+		}
+		st := u.Start.In(loc)
+		diff := st.Hour()*60 + st.Minute() - atMin
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > 12*60 { // wrap around midnight
+			diff = 24*60 - diff
+		}
+		if time.Duration(diff)*time.Minute <= timeOfDayWindow {
+			p.nearTime++
+		}
+		if st.Weekday() == at.Weekday() {
+			p.sameDay++
+		}
+	}
+
+	maxCount := 0
+	for _, r := range ranked {
+		maxCount = max(maxCount, r.Count)
+	}
+	out := make([]Scored, 0, len(ranked))
+	for _, r := range ranked {
+		p := patterns[r.Key()]
+		if p == nil { // a seed without uses
+			p = &pattern{}
+		}
+		age := at.Sub(r.LastSeen)
+		if age < 0 {
+			age = 0
+		}
+		comps := []Component{
+			{
+				Name: "frequency", Weight: w.Frequency,
+				Value:  ratio(r.Count, maxCount),
+				Reason: fmt.Sprintf("used %d× in the window (top combo %d×)", r.Count, maxCount),
+			},
+			{
+				Name: "recency", Weight: w.Recency,
+				Value:  math.Pow(0.5, float64(age)/float64(halfLife)),
+				Reason: "last used " + ageString(age) + " ago",
+			},
+			{
+				Name: "time-of-day", Weight: w.TimeOfDay,
+				Value:  ratio(p.nearTime, r.Count),
+				Reason: fmt.Sprintf("%d of %d uses started around %s", p.nearTime, r.Count, at.Format("15:04")),
+			},
+			{
+				Name: "weekday", Weight: w.Weekday,
+				Value:  ratio(p.sameDay, r.Count),
+				Reason: fmt.Sprintf("%d of %d uses on a %s", p.sameDay, r.Count, at.Weekday()),
+			},
+		}
+		s := Scored{Ranked: r, Components: comps}
+		for _, c := range comps {
+			s.Score += c.Value * c.Weight
+		}
+		out = append(out, s)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func ageString(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
@@ -0,0 +1,83 @@
+// Package suggest ranks customer/project/activity combinations from tracked
+// history. Rank orders by frequency and recency; the TUI's start/switch
+// suggestions and the CLI's `tt fav` share it so both number favorites the
+// same way. Score additionally weighs time-of-day and weekday patterns for a
+// given moment and explains each component (`tt suggest --why`). Config
+// tunes both: the weights, the lookback window and the recency half-life.
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Lookback is the default history window the rankings are computed over.
+const Lookback = 30 * 24 * time.Hour
+
+// Combo is a customer/project/activity combination with its billable flag.
+type Combo struct {
+	Customer string
+	Project  string
+	Activity string
+	Billable bool
+}
+
+// Key identifies a combo case- and whitespace-insensitively. Billable is not
+// part of the key.
+func (c Combo) Key() string {
+	norm := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+	return norm(c.Customer) + "||" + norm(c.Project) + "||" + norm(c.Activity)
+}
+
+// Use is one observation of a combo, typically an entry and its start.
+type Use struct {
+	Combo
+	Start time.Time
+}
+
+// Ranked is a combo with its usage statistics.
+type Ranked struct {
+	Combo
+	Count    int
+	LastSeen time.Time
+}
+
+// Rank orders combos by frequency, then recency. Seeds (such as the active
+// and last entry) are included even when they do not occur in uses, without
+// adding to the count. The billable flag of a combo is the one first seen.
+// Config.Rank weighs recency and patterns in as configured.
+func Rank(uses []Use, seeds ...Use) []Ranked {
+	stats := map[string]*Ranked{}
+	var order []string
+	observe := func(u Use, count int) {
+		k := u.Key()
+		r, ok := stats[k]
+		if !ok {
+			r = &Ranked{Combo: u.Combo, LastSeen: u.Start}
+			stats[k] = r
+			order = append(order, k)
+		} else if u.Start.After(r.LastSeen) {
+			r.LastSeen = u.Start
+		}
+		r.Count += count
+	}
+	for _, s := range seeds {
+		observe(s, 0)
+	}
+	for _, u := range uses {
+		observe(u, 1)
+	}
+
+	out := make([]Ranked, 0, len(order))
+	for _, k := range order {
+		out = append(out, *stats[k])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].LastSeen.After(out[j].LastSeen)
+	})
+	return out
+}
@@ -0,0 +1,117 @@
+package suggest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankFrequencyThenRecency(t *testing.T) {
+	day := time.Date(2025, 10, 13, 9, 0, 0, 0, time.UTC)
+	use := func(c, p, a string, billable bool, h int) Use {
+		return Use{Combo: Combo{Customer: c, Project: p, Activity: a, Billable: billable}, Start: day.Add(time.Duration(h) * time.Hour)}
+	}
+	uses := []Use{
+		use("Acme", "Portal", "dev", true, 0),
+		use("Globex", "Ops", "support", false, 1),
+		use("acme ", "portal", "DEV", false, 2), // same combo, different spelling
+		use("Initech", "Web", "", true, 3),
+	}
+	seed := use("Umbrella", "Lab", "", true, -48)
+
+	got := Rank(uses, seed)
+	want := []struct {
+		customer string
+		count    int
+	}{
+		{"Acme", 2},
+		{"Initech", 1}, // more recent than Globex
+		{"Globex", 1},
+		{"Umbrella", 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Rank returned %d combos, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Customer != w.customer || got[i].Count != w.count {
+			t.Errorf("rank %d = %s (%d), want %s (%d)", i+1, got[i].Customer, got[i].Count, w.customer, w.count)
+		}
+	}
+	if !got[0].Billable {
+		t.Error("billable should come from the first observation")
+	}
+	if !got[0].LastSeen.Equal(day.Add(2 * time.Hour)) {
+		t.Errorf("LastSeen = %v", got[0].LastSeen)
+	}
+}
+
+func TestScorePrefersTimeOfDayAndWeekday(t *testing.T) {
+	// Mondays 09:00 standups, weekday afternoons of dev (more frequent overall).
+	mon := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	var uses []Use
+	standup := Combo{Customer: "Acme", Project: "Internal", Activity: "meeting"}
+	dev := Combo{Customer: "Acme", Project: "Portal", Activity: "dev", Billable: true}
+	for w := 0; w < 2; w++ {
+		week := mon.AddDate(0, 0, 7*w)
+		uses = append(uses, Use{Combo: standup, Start: week.Add(9 * time.Hour)})
+		for d := 0; d < 5; d++ {
+			uses = append(uses, Use{Combo: dev, Start: week.AddDate(0, 0, d).Add(14 * time.Hour)})
+		}
+	}
+
+	at := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC) // Monday morning
+	got := Score(uses, at)
+	if len(got) != 2 || got[0].Activity != "meeting" {
+		t.Fatalf("Monday 09:00 should rank the standup first, got %+v", got)
+	}
+	if len(got[0].Components) != 4 || got[0].Components[2].Value != 1 || got[0].Components[3].Value != 1 {
+		t.Errorf("standup components = %+v", got[0].Components)
+	}
+
+	at = time.Date(2025, 10, 21, 14, 30, 0, 0, time.UTC) // Tuesday afternoon
+	got = Score(uses, at)
+	if got[0].Activity != "dev" {
+		t.Fatalf("Tuesday 14:30 should rank dev first, got %+v", got)
+	}
+	for _, c := range got[0].Components {
+		if c.Reason == "" {
+			t.Errorf("component %s has no reason", c.Name)
+		}
+	}
+	if got[0].Score <= got[1].Score || got[0].Score > 1 {
+		t.Errorf("scores = %.2f, %.2f", got[0].Score, got[1].Score)
+	}
+
+	if Score(nil, at) != nil {
+		t.Error("Score(nil) should be nil")
+	}
+}
+
+func TestConfigRankWeighsRecency(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	stale := Combo{Customer: "Acme", Project: "Old"}
+	fresh := Combo{Customer: "Acme", Project: "New"}
+	var uses []Use
+	for i := 0; i < 5; i++ {
+		uses = append(uses, Use{Combo: stale, Start: now.AddDate(0, 0, -25+i)})
+	}
+	uses = append(uses, Use{Combo: fresh, Start: now.AddDate(0, 0, -2)}, Use{Combo: fresh, Start: now.AddDate(0, 0, -1)})
+	seed := Use{Combo: Combo{Customer: "Umbrella"}, Start: now.AddDate(0, 0, -60)}
+
+	// The default is frequency only, like Rank.
+	cfg := DefaultConfig()
+	if got := cfg.Rank(uses, now, seed); len(got) != 3 || got[0].Project != "Old" || got[2].Customer != "Umbrella" {
+		t.Fatalf("default rank = %+v", got)
+	}
+	cfg.RankWeights = Weights{Frequency: 0.5, Recency: 0.5}
+	got := cfg.Rank(uses, now, seed)
+	if len(got) != 3 || got[0].Project != "New" || got[1].Project != "Old" || got[2].Customer != "Umbrella" {
+		t.Fatalf("recency-weighted rank = %+v", got)
+	}
+
+	// A shorter half-life punishes age harder in Score too.
+	cfg.ScoreWeights = Weights{Frequency: 0.2, Recency: 0.8}
+	cfg.RecencyHalfLife = 24 * time.Hour
+	if s := cfg.Score(uses, now); s[0].Project != "New" || s[0].Components[1].Value != 0.5 || s[0].Components[1].Weight != 0.8 {
+		t.Fatalf("score = %+v", s)
+	}
+}
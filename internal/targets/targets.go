@@ -0,0 +1,222 @@
+// Package targets measures tracked time against hour targets: a daily and a
+// weekly target overall and per customer. tt status, tt report week and the
+// TUI dashboard all show the same Progress; past its target the remainder
+// turns into overtime, which they show as a warning. Nothing is blocked.
+package targets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tt/internal/reporting"
+)
+
+// Goal is a daily and a weekly target. A zero target is not measured.
+type Goal struct {
+	Daily, Weekly time.Duration
+}
+
+// Config is the overall goal plus goals per customer, keyed by customer name
+// (matched case-insensitively).
+type Config struct {
+	Goal
+	Customers map[string]Goal
+}
+
+// Enabled reports whether any target is set.
+func (c Config) Enabled() bool {
+	if c.Daily > 0 || c.Weekly > 0 {
+		return true
+	}
+	for _, g := range c.Customers {
+		if g.Daily > 0 || g.Weekly > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Span is a period of work for a customer; a running entry ends now.
+type Span struct {
+	Customer   string
+	Start, End time.Time
+}
+
+// Period is what a target is measured over.
+type Period string
+
+const (
+	Day  Period = "day"
+	Week Period = "week" // ISO week, Monday to Sunday
+)
+
+// Progress is the work towards one target.
+type Progress struct {
+	Customer string // "" for the overall target
+	Period   Period
+	Start    time.Time // local midnight the day or week starts at
+	Target   time.Duration
+	Worked   time.Duration
+}
+
+// Remaining is the time left to the target, 0 once it is reached.
+func (p Progress) Remaining() time.Duration {
+	if p.Worked >= p.Target {
+		return 0
+	}
+	return p.Target - p.Worked
+}
+
+// Overtime is the time worked past the target.
+func (p Progress) Overtime() time.Duration {
+	if p.Worked <= p.Target {
+		return 0
+	}
+	return p.Worked - p.Target
+}
+
+// Fraction is Worked relative to Target, 1 at the target and above 1 in
+// overtime.
+func (p Progress) Fraction() float64 {
+	if p.Target <= 0 {
+		return 0
+	}
+	return float64(p.Worked) / float64(p.Target)
+}
+
+// Label names the target, e.g. "today", "this week" or "acme this week" for
+// progress measured at now; other days and weeks are named by their date.
+func (p Progress) Label(now time.Time) string {
+	now = now.In(p.Start.Location())
+	var label string
+	switch p.Period {
+	case Day:
+		label = p.Start.Format("Mon 2006-01-02")
+		if sameDay(p.Start, now) {
+			label = "today"
+		}
+	default:
+		y, w := p.Start.ISOWeek()
+		label = fmt.Sprintf("week %d-W%02d", y, w)
+		if sameDay(p.Start, weekStart(now)) {
+			label = "this week"
+		}
+	}
+	if p.Customer != "" {
+		label = p.Customer + " " + label
+	}
+	return label
+}
+
+// Describe summarises the progress, e.g. "6h30m of 8h, 1h30m left" or
+// "42h of 40h, 2h overtime".
+func (p Progress) Describe() string {
+	s := reporting.FormatDuration(p.Worked) + " of " + reporting.FormatDuration(p.Target)
+	if ot := p.Overtime(); ot > 0 {
+		return s + ", " + reporting.FormatDuration(ot) + " overtime"
+	}
+	if p.Remaining() == 0 {
+		return s + ", reached"
+	}
+	return s + ", " + reporting.FormatDuration(p.Remaining()) + " left"
+}
+
+// Bar draws the progress as width cells of █ and ░; overtime fills it.
+func (p Progress) Bar(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := int(p.Fraction()*float64(width) + 0.5)
+	if n > width {
+		n = width
+	}
+	return strings.Repeat("█", n) + strings.Repeat("░", width-n)
+}
+
+// Measure returns the progress on the day and the ISO week containing t,
+// calendar days in loc: the overall targets first (day, then week), then
+// each customer with a goal by name. Spans are clipped to the day or week;
+// overlapping spans count once. Customers of spans are matched against
+// c.Customers case-insensitively; their progress is named as configured.
+func Measure(spans []Span, c Config, t time.Time, loc *time.Location) []Progress {
+	day := midnight(t, loc)
+	week := weekStart(day)
+	var out []Progress
+	add := func(customer string, g Goal, spans []Span) {
+		if g.Daily > 0 {
+			out = append(out, Progress{Customer: customer, Period: Day, Start: day, Target: g.Daily,
+				Worked: worked(spans, day, day.AddDate(0, 0, 1))})
+		}
+		if g.Weekly > 0 {
+			out = append(out, Progress{Customer: customer, Period: Week, Start: week, Target: g.Weekly,
+				Worked: worked(spans, week, week.AddDate(0, 0, 7))})
+		}
+	}
+	add("", c.Goal, spans)
+
+	names := make([]string, 0, len(c.Customers))
+	for name := range c.Customers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var own []Span
+		for _, s := range spans {
+			if strings.EqualFold(strings.TrimSpace(s.Customer), strings.TrimSpace(name)) {
+				own = append(own, s)
+			}
+		}
+		add(name, c.Customers[name], own)
+	}
+	return out
+}
+
+// worked is the time spans cover within from..to, overlaps counted once.
+func worked(spans []Span, from, to time.Time) time.Duration {
+	var in []Span
+	for _, s := range spans {
+		if s.Start.Before(from) {
+			s.Start = from
+		}
+		if s.End.After(to) {
+			s.End = to
+		}
+		if s.End.After(s.Start) {
+			in = append(in, s)
+		}
+	}
+	sort.Slice(in, func(i, j int) bool { return in[i].Start.Before(in[j].Start) })
+	var total time.Duration
+	var end time.Time
+	for _, s := range in {
+		if s.Start.Before(end) {
+			if !s.End.After(end) {
+				continue
+			}
+			s.Start = end
+		}
+		total += s.End.Sub(s.Start)
+		end = s.End
+	}
+	return total
+}
+
+func midnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// weekStart is the Monday 00:00 of the ISO week of day, a local midnight.
+func weekStart(day time.Time) time.Time {
+	wd := int(day.Weekday())
+	if wd == 0 {
+		wd = 7
+	}
+	return time.Date(day.Year(), day.Month(), day.Day()-(wd-1), 0, 0, 0, 0, day.Location())
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,63 @@
+package targets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeasure(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, berlin) }
+	spans := []Span{
+		{"Acme", at(5, 22, 0), at(6, 2, 0)}, // Sunday night: 2h last week, 2h on Monday
+		{"Acme", at(6, 9, 0), at(6, 13, 0)},
+		{"Globex", at(6, 12, 0), at(6, 14, 0)}, // overlaps Acme for 1h
+		{"acme", at(7, 8, 0), at(7, 17, 0)},
+		{"Acme", at(8, 8, 0), at(8, 10, 30)}, // running, ended at now by the caller
+	}
+	c := Config{
+		Goal:      Goal{Daily: 8 * time.Hour, Weekly: 18 * time.Hour},
+		Customers: map[string]Goal{"acme": {Weekly: 16 * time.Hour}, "initech": {Daily: time.Hour}},
+	}
+	now := at(8, 10, 30)
+	got := Measure(spans, c, now, berlin)
+	want := []struct {
+		label, desc string
+		overtime    bool
+	}{
+		{"today", "2h30m of 8h, 5h30m left", false},
+		{"this week", "18h30m of 18h, 30m overtime", true},
+		{"acme this week", "17h30m of 16h, 1h30m overtime", true},
+		{"initech today", "0m of 1h, 1h left", false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("progress = %+v", got)
+	}
+	for i, w := range want {
+		p := got[i]
+		if l := p.Label(now); l != w.label {
+			t.Errorf("#%d label %q; want %q", i, l, w.label)
+		}
+		if d := p.Describe(); d != w.desc {
+			t.Errorf("#%d %q; want %q", i, d, w.desc)
+		}
+		if (p.Overtime() > 0) != w.overtime {
+			t.Errorf("#%d overtime %v", i, p.Overtime())
+		}
+	}
+	if b := got[0].Bar(8); b != "███░░░░░" {
+		t.Errorf("bar = %q", b)
+	}
+	if b := got[1].Bar(4); b != "████" {
+		t.Errorf("overtime bar = %q", b)
+	}
+	if l := got[0].Label(at(9, 8, 0)); l != "Wed 2025-10-08" {
+		t.Errorf("label next day = %q", l)
+	}
+	if got := Measure(spans, Config{}, now, berlin); got != nil || (Config{}).Enabled() {
+		t.Errorf("no targets, progress %+v", got)
+	}
+}
@@ -3,12 +3,17 @@ package tui
 import (
 	"context"
 	"fmt"
-	"sort"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"tt/internal/rounding"
+	"tt/internal/suggest"
+	"tt/internal/targets"
 )
 
 // Services aggregates the dependencies the TUI needs. Provide your concrete
@@ -18,6 +23,40 @@ type Services struct {
 	Writer  EventWriter
 	Watch   JournalWatch
 	Config  ConfigService
+
+	// Candidates optionally supplies customer/project completion candidates
+	// for the start/switch form. When nil the form walks the journal itself.
+	Candidates CandidateSource
+
+	// Feedback optionally summarises the day after a stop (nil: none).
+	Feedback StopFeedback
+
+	// Activities optionally checks the form's activity against the allowed
+	// list (nil: any activity).
+	Activities ActivityPolicy
+
+	// Billable optionally supplies per customer/project billable defaults
+	// (nil: the form keeps the billable flag of the active or last entry).
+	Billable BillableDefaults
+
+	// Notices optionally reports writes of other tt processes as they
+	// happen (nil: only the journal watch refreshes the dashboard).
+	Notices EventNotifier
+
+	// Alerts optionally announces a long-running timer (nil: none).
+	Alerts TimerAlerts
+
+	// Errors optionally persists failed writes, e.g. to ~/.tt/tui.log
+	// (nil: they are only kept in memory for the ! view).
+	Errors ErrorSink
+
+	// Ranking optionally tunes the suggestion ranking, shared with tt fav
+	// (nil: suggest.DefaultConfig()).
+	Ranking *suggest.Config
+
+	// Targets optionally sets hour targets; the dashboard then shows today's
+	// and this week's progress (nil or none enabled: no Targets section).
+	Targets *targets.Config
 }
 
 // JournalService loads entries from the append-only JSONL journal and can
@@ -48,13 +87,67 @@ type JournalWatch interface {
 type ConfigService interface {
 	Timezone() *time.Location
 	Rounding() RoundingConfig
+	// CandidateLookback bounds how far back completion candidates are loaded.
+	CandidateLookback() time.Duration
+}
+
+// CandidateSource returns unique customer and project names observed since
+// the given time (zero means no bound), typically backed by the CLI's
+// completion index so the TUI and shell completion agree.
+type CandidateSource interface {
+	Candidates(ctx context.Context, since time.Time) (customers, projects []string, err error)
+}
+
+// StopFeedback returns a one-line summary shown in the status bar after a
+// stop (entry duration, today's total, remaining to the daily target), or ""
+// when feedback is disabled.
+type StopFeedback interface {
+	StopSummary(ctx context.Context) string
+}
+
+// ActivityPolicy checks an activity before the form starts an entry. A
+// non-empty warning is shown after the start; an error keeps the form open.
+type ActivityPolicy interface {
+	CheckActivity(activity string) (warning string, err error)
+}
+
+// BillableDefaults returns the configured billable default for a
+// customer/project; ok is false when no rule applies.
+type BillableDefaults interface {
+	DefaultBillable(ctx context.Context, customer, project string) (billable, ok bool)
+}
+
+// TimerAlerts returns the announcement due for the running entry at now
+// ("You've been on acme/portal for 2h"), or "" when none is. It is asked
+// about once a minute and is responsible for not repeating itself.
+type TimerAlerts interface {
+	DueAlert(ctx context.Context, active Entry, now time.Time) string
 }
 
+// timerAlertCheckEvery is how often the dashboard asks Alerts.
+const timerAlertCheckEvery = time.Minute
+
 // RoundingConfig mirrors the CLI's rounding configuration.
 type RoundingConfig struct {
 	Strategy     string // up|down|nearest
 	QuantumMin   int
 	MinimumEntry int
+	Level        string // entry|aggregate
+}
+
+// Policy is r as a policy of the shared rounding pipeline, so the dashboard
+// rounds exactly like the reports.
+func (r RoundingConfig) Policy() rounding.Policy {
+	q := r.QuantumMin
+	if q <= 0 {
+		q = 15
+	}
+	return rounding.Policy{
+		Strategy:   r.Strategy,
+		QuantumSec: int64(q) * 60,
+		MinimumSec: int64(r.MinimumEntry) * 60,
+		Level:      r.Level,
+	}
 }
 
 // Domain types used by the dashboard and views.
@@ -69,6 +162,21 @@ type Entry struct {
 	Billable bool
 	Notes    []string
 	Tags     []string
+	Mark     string        // pin or follow-up badge (tt pin), e.g. "follow-up: missing ticket"; empty when unmarked
+	Archived bool          // the customer is archived: left out of suggestions
+	Paused   time.Duration // time paused (tt daemon) when the entry was loaded; left out of durations
+
+	// CustomerLabel is the customer as shown on screen, e.g. a short display
+	// alias; empty shows Customer. Writes always use Customer.
+	CustomerLabel string
+}
+
+// customerLabel returns the customer of e as shown on screen.
+func (e Entry) customerLabel() string {
+	if e.CustomerLabel != "" {
+		return e.CustomerLabel
+	}
+	return e.Customer
 }
 
 type StartParams struct {
@@ -127,6 +235,7 @@ func (m appModel) Init() tea.Cmd {
 		tickEvery(time.Second),
 		m.dashboard.Init(),
 		listenJournal(m.services.Watch),
+		listenNotices(m.services.Notices),
 	)
 }
 
@@ -164,9 +273,15 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, tickEvery(time.Second))
 
 	case fsChangeMsg:
+		// Keep listening on the same subscription for the next change.
 		var cmd tea.Cmd
 		m.dashboard, cmd = m.dashboard.Update(msg)
-		return m, cmd
+		return m, tea.Batch(cmd, waitJournal(msg.changes))
+
+	case noticeMsg:
+		var cmd tea.Cmd
+		m.dashboard, cmd = m.dashboard.Update(msg)
+		return m, tea.Batch(cmd, waitNotice(msg.notices))
 
 	default:
 		var cmd tea.Cmd
@@ -219,6 +334,10 @@ type dashboardModel struct {
 	err    error
 	loaded bool
 
+	// week holds this week's entries for the Targets section; loaded with
+	// the status only when targets are set.
+	week []Entry
+
 	// Note input mode state
 	noteMode bool
 	noteBuf  string
@@ -234,7 +353,27 @@ type dashboardModel struct {
 	timelineLoaded    bool
 	timelineErr       error
 
+	// Week jump input (g) while the timelines are shown.
+	jumpMode bool
+	jumpBuf  string
+
+	// showRounded adds rounded durations (ConfigService.Rounding) next to the
+	// raw ones in the Last section and the timeline totals.
+	showRounded bool
+
 	status string // simple transient status line (e.g., errors)
+
+	// errors are the recent failed writes, oldest first, shown in full
+	// (with the payload) while showErrors is toggled on with !.
+	errors     []ErrorRecord
+	showErrors bool
+
+	// alertCheckedAt is when Alerts was last asked.
+	alertCheckedAt time.Time
+
+	// generation counts journal reloads; status results from an older
+	// generation are stale and dropped when they arrive out of order.
+	generation uint64
 }
 
 func newDashboardModel(svcs Services) dashboardModel {
@@ -242,7 +381,13 @@ func newDashboardModel(svcs Services) dashboardModel {
 }
 
 func (d dashboardModel) Init() tea.Cmd {
-	return loadStatus(d.svcs.Journal)
+	return loadStatus(d.svcs.Journal, d.targets(), d.generation)
+}
+
+// reload bumps the journal generation and loads status for it.
+func (d *dashboardModel) reload() tea.Cmd {
+	d.generation++
+	return loadStatus(d.svcs.Journal, d.targets(), d.generation)
 }
 
 func (d *dashboardModel) setSize(w, h int) {
@@ -252,29 +397,52 @@ func (d *dashboardModel) setSize(w, h int) {
 // isCapturing indicates the dashboard is currently capturing text input
 // or in selection form so global shortcuts (like q/esc/space) should not interfere.
 func (d dashboardModel) isCapturing() bool {
-	return d.noteMode || d.formMode
+	return d.noteMode || d.formMode || d.jumpMode
 }
 
 func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case statusLoadedMsg:
+		if msg.generation != d.generation {
+			return d, nil
+		}
 		d.active = msg.active
 		d.last = msg.last
+		d.week = msg.week
 		d.err = msg.err
 		d.loaded = true
 		return d, nil
 
 	case fsChangeMsg:
 		// Reload on external changes.
-		return d, loadStatus(d.svcs.Journal)
+		return d, d.reload()
+
+	case noticeMsg:
+		// Another tt process wrote: refresh now and say what it was.
+		d.status = RenderStatus("info", "Journal updated: "+msg.notice.Summary)
+		return d, d.reload()
 
 	case tickMsg:
-		// Re-render for elapsed time updates.
+		// Re-render for elapsed time updates; ask for a due timer alert
+		// once a minute while an entry runs.
+		now := time.Time(msg)
+		if d.svcs.Alerts == nil || d.active == nil || d.active.End != nil || now.Sub(d.alertCheckedAt) < timerAlertCheckEvery {
+			return d, nil
+		}
+		d.alertCheckedAt = now
+		return d, dueAlert(d.svcs.Alerts, *d.active, now)
+
+	case timerAlertMsg:
+		if msg != "" {
+			d.status = RenderStatus("warn", string(msg))
+		}
 		return d, nil
 
 	case startDoneMsg:
 		if msg.err != nil {
-			d.status = RenderStatus("err", "Failed to start: "+msg.err.Error())
+			d.recordError(msg.writeResult, "Failed to "+msg.op)
+		} else if msg.warning != "" {
+			d.status = RenderStatus("warn", "Started · "+msg.warning)
 		} else {
 			d.status = RenderStatus("ok", "Started")
 		}
@@ -285,15 +453,19 @@ func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 
 	case stopDoneMsg:
 		if msg.err != nil {
-			d.status = RenderStatus("err", "Failed to stop: "+msg.err.Error())
+			d.recordError(msg.writeResult, "Failed to stop")
 		} else {
-			d.status = RenderStatus("ok", "Stopped")
+			text := "Stopped"
+			if msg.summary != "" {
+				text += " · " + msg.summary
+			}
+			d.status = RenderStatus("ok", text)
 		}
 		return d, nil
 
 	case noteSavedMsg:
 		if msg.err != nil {
-			d.status = RenderStatus("err", "Failed to save note: "+msg.err.Error())
+			d.recordError(msg.writeResult, "Failed to save note")
 		} else {
 			d.status = RenderStatus("ok", "Note saved")
 		}
@@ -305,6 +477,17 @@ func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 		d.timelineLoaded = true
 		return d, nil
 
+	case candidatesLoadedMsg, suggestMsg:
+		// Background results for the form; drop them if it was closed meanwhile.
+		if d.formMode && d.form != nil {
+			m, cmd := d.form.Update(msg)
+			if fm, ok := m.(*formModel); ok {
+				d.form = fm
+			}
+			return d, cmd
+		}
+		return d, nil
+
 	case formCancelledMsg:
 		// Close the form modal when the submodel signals cancellation.
 		d.formMode = false
@@ -319,7 +502,7 @@ func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 				text := d.noteBuf
 				d.noteMode = false
 				d.noteBuf = ""
-				return d, tea.Batch(saveNote(d.svcs.Writer, text), loadStatus(d.svcs.Journal))
+				return d, tea.Batch(saveNote(d.svcs.Writer, text), d.reload())
 			case tea.KeyEsc:
 				d.noteMode = false
 				d.noteBuf = ""
@@ -340,6 +523,35 @@ func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 			}
 		}
 
+		// Week jump input: a week, date or month, Enter jumps to it.
+		if d.jumpMode {
+			switch msg.Type {
+			case tea.KeyEnter:
+				week, err := parseWeekJump(d.jumpBuf, time.Now(), d.timezone())
+				if err != nil {
+					d.status = RenderStatus("err", err.Error())
+					return d, nil
+				}
+				d.jumpMode = false
+				d.jumpBuf = ""
+				d.status = ""
+				d.timelineWeekStart = week
+				return d, d.loadTimelines()
+			case tea.KeyEsc:
+				d.jumpMode = false
+				d.jumpBuf = ""
+				return d, nil
+			case tea.KeyBackspace, tea.KeyCtrlH:
+				d.jumpBuf = backspace(d.jumpBuf)
+				return d, nil
+			default:
+				if len(msg.Runes) > 0 {
+					d.jumpBuf += string(msg.Runes)
+				}
+				return d, nil
+			}
+		}
+
 		// Start/Switch form mode: delegate to the editable form submodel when active.
 		if d.formMode && d.form != nil {
 			// Let the form handle navigation/typing; it will emit messages (e.g., formCancelledMsg or startDoneMsg)
@@ -358,17 +570,65 @@ func (d dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 		case " ":
 			// Toggle start/stop
 			if d.active != nil && d.active.End == nil {
-				return d, tea.Batch(stopEntry(d.svcs.Writer), loadStatus(d.svcs.Journal))
+				return d, tea.Batch(stopEntry(d.svcs.Writer, d.svcs.Feedback), d.reload())
 			}
-			return d, tea.Batch(startEntry(d.svcs.Writer, d.last), loadStatus(d.svcs.Journal))
+			return d, tea.Batch(startEntry(d.svcs.Writer, d.last), d.reload())
 		case "n":
 			// Enter note input mode
 			d.noteMode = true
 			d.noteBuf = ""
 			return d, nil
 		case "s":
-			// Open start/switch form with quick suggestions
+			// Open start/switch form with quick suggestions; candidates load async.
 			d.openForm()
+			return d, d.form.Init()
+		case "t":
+			d.showTimelines = !d.showTimelines
+			if !d.showTimelines {
+				return d, nil
+			}
+			if d.timelineWeekStart.IsZero() {
+				d.timelineWeekStart = weekStartOf(time.Now(), d.timezone())
+			}
+			return d, d.loadTimelines()
+		case "h", "<", "l", ">":
+			if !d.showTimelines {
+				return d, nil
+			}
+			step := 7
+			if msg.String() == "h" || msg.String() == "<" {
+				step = -7
+			}
+			d.timelineWeekStart = d.timelineWeekStart.AddDate(0, 0, step)
+			return d, d.loadTimelines()
+		case "H", "[", "L", "]":
+			if !d.showTimelines {
+				return d, nil
+			}
+			delta := 1
+			if msg.String() == "H" || msg.String() == "[" {
+				delta = -1
+			}
+			d.timelineWeekStart = monthWeekStart(d.timelineWeekStart, delta, d.timezone())
+			return d, d.loadTimelines()
+		case "g":
+			if !d.showTimelines {
+				return d, nil
+			}
+			d.jumpMode = true
+			d.jumpBuf = ""
+			return d, nil
+		case "G":
+			if !d.showTimelines {
+				return d, nil
+			}
+			d.timelineWeekStart = weekStartOf(time.Now(), d.timezone())
+			return d, d.loadTimelines()
+		case "r":
+			d.showRounded = !d.showRounded
+			return d, nil
+		case "!":
+			d.showErrors = !d.showErrors
 			return d, nil
 		default:
 			return d, nil
@@ -396,12 +656,16 @@ func (d dashboardModel) View() string {
 			endStr = d.active.End.Format("15:04:05")
 			elapsed = d.active.End.Sub(d.active.Start)
 		}
+		elapsed -= d.active.Paused
 		kv := [][2]string{
 			{"When", fmt.Sprintf("%s → %s", d.active.Start.Format("15:04:05"), endStr)},
-			{"What", fmt.Sprintf("%s/%s [%s]", emptyDash(d.active.Customer), emptyDash(d.active.Project), emptyDash(d.active.Activity))},
+			{"What", fmt.Sprintf("%s/%s [%s]", emptyDash(d.active.customerLabel()), emptyDash(d.active.Project), emptyDash(d.active.Activity))},
 			{"Billable", fmt.Sprintf("%v", d.active.Billable)},
 			{"Elapsed", fmtHHMMSS(int(elapsed.Seconds()))},
 		}
+		if d.active.Mark != "" {
+			kv = append(kv, [2]string{"Marked", StatusWarnStyle.Render(d.active.Mark)})
+		}
 		activeLines = RenderKeyValueList(kv, max(20, d.width-6))
 	} else {
 		activeLines = MutedStyle.Render("No active session.")
@@ -416,10 +680,16 @@ func (d dashboardModel) View() string {
 		}
 		kv := [][2]string{
 			{"When", fmt.Sprintf("%s → %s", d.last.Start.Format("15:04:05"), endStr)},
-			{"What", fmt.Sprintf("%s/%s [%s]", emptyDash(d.last.Customer), emptyDash(d.last.Project), emptyDash(d.last.Activity))},
+			{"What", fmt.Sprintf("%s/%s [%s]", emptyDash(d.last.customerLabel()), emptyDash(d.last.Project), emptyDash(d.last.Activity))},
 			{"Billable", fmt.Sprintf("%v", d.last.Billable)},
 			{"Duration", fmtHHMMSS(durationSeconds(*d.last))},
 		}
+		if p, ok := d.roundingPolicy(); ok {
+			kv = append(kv, [2]string{"Rounded", fmtHHMMSS(int(p.Round(int64(durationSeconds(*d.last)))))})
+		}
+		if d.last.Mark != "" {
+			kv = append(kv, [2]string{"Marked", StatusWarnStyle.Render(d.last.Mark)})
+		}
 		lastLines = RenderKeyValueList(kv, max(20, d.width-6))
 	} else {
 		lastLines = MutedStyle.Render("No previous entry in the recent window.")
@@ -428,6 +698,9 @@ func (d dashboardModel) View() string {
 	// Compose sections
 	activeSec := RenderSection("Active", activeLines, d.width)
 	lastSec := RenderSection("Last", lastLines, d.width)
+	if t := d.renderTargets(time.Now()); t != "" {
+		lastSec += "\n" + t
+	}
 
 	// Note input overlay or form overlay
 	extra := ""
@@ -450,6 +723,11 @@ func (d dashboardModel) View() string {
 		statusLine = "\n" + d.status
 	}
 
+	// The error log replaces the timelines and suggestions while open.
+	if d.showErrors {
+		return activeSec + "\n" + lastSec + "\n" + d.renderErrors() + statusLine
+	}
+
 	// If the timelines view is toggled on, render it instead of the quick suggestions.
 	if d.showTimelines {
 		body := ""
@@ -458,17 +736,23 @@ func (d dashboardModel) View() string {
 		} else if d.timelineErr != nil {
 			body = SectionBoxStyle.Render(fmt.Sprintf("Error loading timelines: %v", d.timelineErr))
 		} else {
-			tz := d.svcs.Config.Timezone()
-			if tz == nil {
-				tz = time.Local
-			}
+			tz := d.timezone()
 			// Compute week range for the header: Monday → Sunday
 			weekStart := d.timelineWeekStart.In(tz)
 			weekEnd := d.timelineWeekStart.AddDate(0, 0, 6).In(tz)
-			weekRange := fmt.Sprintf("%s → %s", weekStart.Format("2006-01-02"), weekEnd.Format("2006-01-02"))
+			year, wk := weekStart.ISOWeek()
+			weekRange := fmt.Sprintf("%s → %s (%d-W%02d)", weekStart.Format("2006-01-02"), weekEnd.Format("2006-01-02"), year, wk)
 			// Render a compact week-range header above the timeline section.
 			rangeHeader := SectionTitleStyle.Render("Week: "+weekRange) + "\n"
-			body = rangeHeader + RenderWeekTimeline(d.timelineEntries, d.timelineWeekStart, tz, d.width)
+			if p, ok := d.roundingPolicy(); ok {
+				body = rangeHeader + RenderWeekTimelineRounded(d.timelineEntries, d.timelineWeekStart, tz, d.width, p)
+			} else {
+				body = rangeHeader + RenderWeekTimeline(d.timelineEntries, d.timelineWeekStart, tz, d.width)
+			}
+		}
+		if d.jumpMode {
+			j := fmt.Sprintf("Week: %s\n(2025-W41, W41, a date or a month like 2025-10; Enter to jump, Esc to cancel)", d.jumpBuf)
+			body = RenderSection("Jump to week", j, d.width) + "\n" + body
 		}
 		return activeSec + "\n" + lastSec + "\n" + body + statusLine
 	}
@@ -484,6 +768,12 @@ func (d dashboardModel) hints() []Hint {
 			{Key: "Esc", Text: "cancel"},
 		}
 	}
+	if d.jumpMode {
+		return []Hint{
+			{Key: "Enter", Text: "jump"},
+			{Key: "Esc", Text: "cancel"},
+		}
+	}
 	if d.formMode {
 		return []Hint{
 			{Key: "Tab", Text: "next field"},
@@ -492,6 +782,12 @@ func (d dashboardModel) hints() []Hint {
 			{Key: "Esc", Text: "cancel"},
 		}
 	}
+	if d.showErrors {
+		return []Hint{
+			{Key: "!", Text: "close errors"},
+			{Key: "q", Text: "quit"},
+		}
+	}
 	if d.showTimelines {
 		// When timelines are visible, expose navigation keys.
 		return []Hint{
@@ -501,6 +797,10 @@ func (d dashboardModel) hints() []Hint {
 			{Key: "t", Text: "timelines"},
 			{Key: "h / <", Text: "prev week"},
 			{Key: "l / >", Text: "next week"},
+			{Key: "H / L", Text: "prev/next month"},
+			{Key: "g", Text: "go to week"},
+			{Key: "G", Text: "this week"},
+			{Key: "r", Text: "raw/rounded"},
 			{Key: "q", Text: "quit"},
 		}
 	}
@@ -509,53 +809,284 @@ func (d dashboardModel) hints() []Hint {
 		{Key: "n", Text: "note"},
 		{Key: "s", Text: "start/switch"},
 		{Key: "t", Text: "timelines"},
+		{Key: "r", Text: "raw/rounded"},
+		{Key: "!", Text: "errors"},
 		{Key: "q", Text: "quit"},
 	}
 }
 
+// recordError keeps a failed write for the ! view, hands it to the error
+// sink and shows its first line in the status bar.
+func (d *dashboardModel) recordError(res writeResult, prefix string) {
+	rec := res.record(time.Now())
+	d.errors = append(d.errors, rec)
+	if len(d.errors) > maxErrorRecords {
+		d.errors = d.errors[len(d.errors)-maxErrorRecords:]
+	}
+	msg, _, _ := strings.Cut(rec.Message, "\n")
+	text := prefix + ": " + msg + " (! for details)"
+	if d.svcs.Errors != nil {
+		if err := d.svcs.Errors.RecordError(rec); err != nil {
+			text += " · not logged: " + err.Error()
+		}
+	}
+	d.status = RenderStatus("err", text)
+}
+
+// renderErrors lists the recorded errors, newest first, with their full
+// message and payload.
+func (d dashboardModel) renderErrors() string {
+	title := fmt.Sprintf("Errors (%d)", len(d.errors))
+	if len(d.errors) == 0 {
+		return RenderSection(title, MutedStyle.Render("No failed writes this session."), d.width)
+	}
+	var b strings.Builder
+	for i := len(d.errors) - 1; i >= 0; i-- {
+		r := d.errors[i]
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(EmphStyle.Render(r.Time.In(d.timezone()).Format("15:04:05")+" "+r.Op) + "\n" + r.Message)
+		if r.Payload != "" {
+			b.WriteString("\n" + MutedStyle.Render("payload: "+r.Payload))
+		}
+	}
+	return RenderSection(title, b.String(), d.width)
+}
+
+// targets returns the configured hour targets, nil when none is set.
+func (d dashboardModel) targets() *targets.Config {
+	if d.svcs.Targets == nil || !d.svcs.Targets.Enabled() {
+		return nil
+	}
+	return d.svcs.Targets
+}
+
+// renderTargets shows the progress towards the hour targets at now, the
+// running entry counted up to now; targets in overtime are shown as a
+// warning. It is "" without targets.
+func (d dashboardModel) renderTargets(now time.Time) string {
+	tc := d.targets()
+	if tc == nil {
+		return ""
+	}
+	var spans []targets.Span
+	for _, e := range d.week {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		// pauses are only known as a total: leave them out at the end
+		if end = end.Add(-e.Paused); end.After(e.Start) {
+			spans = append(spans, targets.Span{Customer: e.Customer, Start: e.Start, End: end})
+		}
+	}
+	progress := targets.Measure(spans, *tc, now, d.timezone())
+	width := 0
+	for _, p := range progress {
+		width = max(width, len(p.Label(now)))
+	}
+	lines := make([]string, 0, len(progress))
+	for _, p := range progress {
+		bar := StatusOKStyle.Render(p.Bar(16))
+		desc := p.Describe()
+		if p.Overtime() > 0 {
+			bar, desc = StatusWarnStyle.Render(p.Bar(16)), StatusWarnStyle.Render(desc)
+		}
+		lines = append(lines, fmt.Sprintf("%-*s  %s  %s", width, p.Label(now), bar, desc))
+	}
+	return RenderSection("Targets", strings.Join(lines, "\n"), d.width)
+}
+
+// timezone is the configured timezone, time.Local without one.
+func (d dashboardModel) timezone() *time.Location {
+	if d.svcs.Config != nil {
+		if tz := d.svcs.Config.Timezone(); tz != nil {
+			return tz
+		}
+	}
+	return time.Local
+}
+
+// roundingPolicy returns the configured rounding policy while rounded
+// durations are toggled on.
+func (d dashboardModel) roundingPolicy() (rounding.Policy, bool) {
+	if !d.showRounded || d.svcs.Config == nil {
+		return rounding.Policy{}, false
+	}
+	return d.svcs.Config.Rounding().Policy(), true
+}
+
+// loadTimelines marks the timeline stale and loads the selected week.
+func (d *dashboardModel) loadTimelines() tea.Cmd {
+	d.timelineLoaded = false
+	return loadWeekEntries(d.svcs.Journal, d.timelineWeekStart)
+}
+
+// weekStartOf returns the Monday 00:00 of t's ISO week in tz.
+func weekStartOf(t time.Time, tz *time.Location) time.Time {
+	t = t.In(tz)
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, tz)
+}
+
+// monthWeekStart returns the first week of the month delta months from the
+// one weekStart belongs to. Like ISO weeks, a week belongs to the month of
+// its Thursday, so paging never lands on the week it started from.
+func monthWeekStart(weekStart time.Time, delta int, tz *time.Location) time.Time {
+	thu := weekStart.In(tz).AddDate(0, 0, 3)
+	return firstWeekOf(thu.Year(), thu.Month()+time.Month(delta), tz)
+}
+
+// firstWeekOf returns the Monday of the first week whose Thursday falls in
+// the month.
+func firstWeekOf(year int, month time.Month, tz *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, tz)
+	week := weekStartOf(first, tz)
+	if week.AddDate(0, 0, 3).Month() != first.Month() {
+		week = week.AddDate(0, 0, 7)
+	}
+	return week
+}
+
+// parseWeekJump returns the Monday of the week s names: an ISO week
+// (2025-W41, or W41 in the current ISO year), a date (2025-10-08) or a month
+// (2025-10, its first week as in firstWeekOf).
+func parseWeekJump(s string, now time.Time, tz *time.Location) (time.Time, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if m := isoWeekRe.FindStringSubmatch(s); m != nil {
+		year, _ := now.In(tz).ISOWeek()
+		if m[1] != "" {
+			year, _ = strconv.Atoi(m[1])
+		}
+		wk, _ := strconv.Atoi(m[2])
+		// January 4th is always in week 1.
+		week := weekStartOf(time.Date(year, time.January, 4, 0, 0, 0, 0, tz), tz).AddDate(0, 0, 7*(wk-1))
+		if y, w := week.ISOWeek(); wk < 1 || y != year || w != wk {
+			return time.Time{}, fmt.Errorf("%d has no week %d", year, wk)
+		}
+		return week, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, tz); err == nil {
+		return weekStartOf(t, tz), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", s, tz); err == nil {
+		return firstWeekOf(t.Year(), t.Month(), tz), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot jump to %q: want a week like 2025-W41, a date or a month like 2025-10", s)
+}
+
+var isoWeekRe = regexp.MustCompile(`^(?:(\d{4})-?)?W(\d{1,2})$`)
+
 // ---------- Commands / messages ----------
 
 type tickMsg time.Time
-type fsChangeMsg struct{}
+
+// fsChangeMsg signals a (debounced) journal change. It carries the watch
+// subscription so the app can keep listening without re-creating the watcher.
+type fsChangeMsg struct {
+	changes <-chan struct{}
+}
+
+// noticeMsg carries a notice of another process's write and the
+// subscription it came from.
+type noticeMsg struct {
+	notice  EventNotice
+	notices <-chan EventNotice
+}
 type statusLoadedMsg struct {
-	active *Entry
-	last   *Entry
-	err    error
+	active     *Entry
+	last       *Entry
+	week       []Entry // this week's entries, with targets
+	err        error
+	generation uint64
 }
 
-type startDoneMsg struct{ err error }
-type stopDoneMsg struct{ err error }
-type noteSavedMsg struct{ err error }
+type startDoneMsg struct {
+	writeResult
+	warning string // e.g. an activity outside the allowed list
+}
+type stopDoneMsg struct {
+	writeResult
+	summary string
+}
+type noteSavedMsg struct{ writeResult }
+
+// timerAlertMsg carries a due timer announcement ("" when none is due).
+type timerAlertMsg string
 
 func tickEvery(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg { return tickMsg(t) })
 }
 
+// listenJournal subscribes to the watch once for the lifetime of the program
+// and waits for the first change.
 func listenJournal(w JournalWatch) tea.Cmd {
 	if w == nil {
 		return nil
 	}
+	return waitJournal(w.Changes(context.Background()))
+}
+
+// waitJournal blocks until the next change on an existing subscription.
+// Bursts are coalesced by the watch, so at most one message is pending.
+func waitJournal(changes <-chan struct{}) tea.Cmd {
+	if changes == nil {
+		return nil
+	}
 	return func() tea.Msg {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-		for range w.Changes(ctx) {
-			// Return on first change; Bubble Tea will re-run this cmd if queued again.
-			return fsChangeMsg{}
+		if _, ok := <-changes; !ok {
+			return nil
 		}
+		return fsChangeMsg{changes: changes}
+	}
+}
+
+// listenNotices subscribes to the notifier once for the lifetime of the
+// program and waits for the first notice.
+func listenNotices(n EventNotifier) tea.Cmd {
+	if n == nil {
+		return nil
+	}
+	return waitNotice(n.Notices(context.Background()))
+}
+
+// waitNotice blocks until the next notice on an existing subscription.
+func waitNotice(notices <-chan EventNotice) tea.Cmd {
+	if notices == nil {
 		return nil
 	}
+	return func() tea.Msg {
+		n, ok := <-notices
+		if !ok {
+			return nil
+		}
+		return noticeMsg{notice: n, notices: notices}
+	}
 }
 
-func loadStatus(j JournalService) tea.Cmd {
+func loadStatus(j JournalService, tc *targets.Config, generation uint64) tea.Cmd {
 	return func() tea.Msg {
 		if j == nil {
 			// No service provided; return empty state
-			return statusLoadedMsg{}
+			return statusLoadedMsg{generation: generation}
 		}
 		now := time.Now()
 		from := now.AddDate(0, 0, -7)
 		active, last, err := j.FindActiveAndLast(context.Background(), from, now)
-		return statusLoadedMsg{active: active, last: last, err: err}
+		msg := statusLoadedMsg{active: active, last: last, err: err, generation: generation}
+		if err == nil && tc != nil {
+			// eight days back cover this week in any timezone, including
+			// an entry running over Monday midnight
+			msg.week, msg.err = j.LoadEntries(context.Background(), now.AddDate(0, 0, -8), now)
+		}
+		return msg
+	}
+}
+
+func dueAlert(a TimerAlerts, active Entry, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return timerAlertMsg(a.DueAlert(context.Background(), active, now))
 	}
 }
 
@@ -571,22 +1102,22 @@ func startEntry(w EventWriter, last *Entry) tea.Cmd {
 			p.Activity = last.Activity
 			p.Billable = last.Billable
 		}
-		if err := w.Start(context.Background(), p); err != nil {
-			return startDoneMsg{err: err}
-		}
-		return startDoneMsg{}
+		return startDoneMsg{writeResult: runWrite("start", p, func() error {
+			return w.Start(context.Background(), p)
+		})}
 	}
 }
 
-func stopEntry(w EventWriter) tea.Cmd {
+func stopEntry(w EventWriter, f StopFeedback) tea.Cmd {
 	return func() tea.Msg {
 		if w == nil {
 			return stopDoneMsg{}
 		}
-		if err := w.Stop(context.Background()); err != nil {
-			return stopDoneMsg{err: err}
+		res := runWrite("stop", nil, func() error { return w.Stop(context.Background()) })
+		if res.err != nil || f == nil {
+			return stopDoneMsg{writeResult: res}
 		}
-		return stopDoneMsg{}
+		return stopDoneMsg{writeResult: res, summary: f.StopSummary(context.Background())}
 	}
 }
 
@@ -598,10 +1129,9 @@ func saveNote(w EventWriter, text string) tea.Cmd {
 		if text == "" {
 			return noteSavedMsg{}
 		}
-		if err := w.Note(context.Background(), text); err != nil {
-			return noteSavedMsg{err: err}
-		}
-		return noteSavedMsg{}
+		return noteSavedMsg{runWrite("note", map[string]string{"note": text}, func() error {
+			return w.Note(context.Background(), text)
+		})}
 	}
 }
 
@@ -621,6 +1151,12 @@ func (d *dashboardModel) openForm() {
 
 	// Create the editable form and seed it with the last entry values.
 	f := NewStartSwitchForm(d.svcs.Writer, d.last, defBill, sugs)
+	f.SetCandidateSource(d.svcs.Candidates)
+	f.SetActivityPolicy(d.svcs.Activities)
+	f.SetBillableDefaults(d.svcs.Billable)
+	if d.svcs.Config != nil {
+		f.SetCandidateLookback(d.svcs.Config.CandidateLookback())
+	}
 	// If a session is currently running, treat the form as a "switch".
 	if d.active != nil && d.active.End == nil {
 		f.SetMode("switch")
@@ -654,9 +1190,13 @@ func (d dashboardModel) buildSuggestions() []suggestion {
 		return out
 	}
 
-	// Look back a reasonable window and build frequency + recency stats of combos.
+	// Look back the configured window and rank combos as `tt fav` does.
+	cfg := suggest.DefaultConfig()
+	if d.svcs.Ranking != nil {
+		cfg = *d.svcs.Ranking
+	}
 	now := time.Now()
-	from := now.AddDate(0, 0, -30)
+	from := now.Add(-cfg.Lookback)
 
 	ents, err := d.svcs.Journal.LoadEntries(context.Background(), from, now)
 	if err != nil || len(ents) == 0 {
@@ -672,81 +1212,37 @@ func (d dashboardModel) buildSuggestions() []suggestion {
 		return nil
 	}
 
-	type stat struct {
-		s        suggestion
-		count    int
-		lastSeen time.Time
+	useOf := func(e Entry) suggest.Use {
+		return suggest.Use{
+			Combo: suggest.Combo{
+				Customer: e.Customer,
+				Project:  e.Project,
+				Activity: e.Activity,
+				Billable: e.Billable,
+			},
+			Start: e.Start,
+		}
 	}
-
-	stats := map[string]*stat{}
-	keyOf := func(c, p, a string) string {
-		return strings.ToLower(strings.TrimSpace(c)) + "||" +
-			strings.ToLower(strings.TrimSpace(p)) + "||" +
-			strings.ToLower(strings.TrimSpace(a))
+	// Seed with active and last so they are offered even outside the window.
+	// Archived customers are never suggested.
+	var seeds []suggest.Use
+	if d.active != nil && !d.active.Archived {
+		seeds = append(seeds, useOf(*d.active))
 	}
-
-	// Seed with last and active to bias sorting later.
-	seed := func(e *Entry) {
-		if e == nil {
-			return
-		}
-		k := keyOf(e.Customer, e.Project, e.Activity)
-		if _, ok := stats[k]; !ok {
-			stats[k] = &stat{
-				s: suggestion{
-					Customer: e.Customer,
-					Project:  e.Project,
-					Activity: e.Activity,
-					Billable: e.Billable,
-				},
-				count:    0,
-				lastSeen: e.Start,
-			}
-		} else {
-			if e.Start.After(stats[k].lastSeen) {
-				stats[k].lastSeen = e.Start
-			}
-		}
+	if d.last != nil && !d.last.Archived {
+		seeds = append(seeds, useOf(*d.last))
 	}
-	seed(d.active)
-	seed(d.last)
-
+	uses := make([]suggest.Use, 0, len(ents))
 	for _, e := range ents {
-		k := keyOf(e.Customer, e.Project, e.Activity)
-		if _, ok := stats[k]; !ok {
-			stats[k] = &stat{
-				s: suggestion{
-					Customer: e.Customer,
-					Project:  e.Project,
-					Activity: e.Activity,
-					Billable: e.Billable,
-				},
-				count:    1,
-				lastSeen: e.Start,
-			}
-		} else {
-			stats[k].count++
-			if e.Start.After(stats[k].lastSeen) {
-				stats[k].lastSeen = e.Start
-			}
+		if !e.Archived {
+			uses = append(uses, useOf(e))
 		}
 	}
 
-	// Convert to slice and sort by frequency then recency.
-	list := make([]stat, 0, len(stats))
-	for _, v := range stats {
-		list = append(list, *v)
-	}
-	sort.Slice(list, func(i, j int) bool {
-		if list[i].count != list[j].count {
-			return list[i].count > list[j].count
-		}
-		return list[i].lastSeen.After(list[j].lastSeen)
-	})
-
-	out := make([]suggestion, 0, len(list))
-	for _, it := range list {
-		out = append(out, it.s)
+	ranked := cfg.Rank(uses, now, seeds...)
+	out := make([]suggestion, 0, len(ranked))
+	for _, r := range ranked {
+		out = append(out, suggestion(r.Combo))
 	}
 	return out
 }
@@ -800,10 +1296,9 @@ func startWith(w EventWriter, s suggestion) tea.Cmd {
 			Activity: s.Activity,
 			Billable: s.Billable,
 		}
-		if err := w.Start(context.Background(), p); err != nil {
-			return startDoneMsg{err: err}
-		}
-		return startDoneMsg{}
+		return startDoneMsg{writeResult: runWrite("start", p, func() error {
+			return w.Start(context.Background(), p)
+		})}
 	}
 }
 
@@ -818,10 +1313,9 @@ func switchWith(w EventWriter, s suggestion) tea.Cmd {
 			Activity: s.Activity,
 			Billable: s.Billable,
 		}
-		if err := w.Switch(context.Background(), p); err != nil {
-			return startDoneMsg{err: err}
-		}
-		return startDoneMsg{}
+		return startDoneMsg{writeResult: runWrite("switch", p, func() error {
+			return w.Switch(context.Background(), p)
+		})}
 	}
 }
 
@@ -831,7 +1325,7 @@ func durationSeconds(e Entry) int {
 	if e.End == nil {
 		return 0
 	}
-	return int(e.End.Sub(e.Start).Seconds())
+	return int((e.End.Sub(e.Start) - e.Paused).Seconds())
 }
 
 func fmtHHMMSS(totalSec int) string {
@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tt/internal/targets"
+)
+
+type countingJournal struct {
+	active  *Entry
+	entries []Entry
+}
+
+func (j *countingJournal) LoadEntries(context.Context, time.Time, time.Time) ([]Entry, error) {
+	return j.entries, nil
+}
+
+func (j *countingJournal) FindActiveAndLast(context.Context, time.Time, time.Time) (*Entry, *Entry, error) {
+	return j.active, nil, nil
+}
+
+func TestDashboardDropsStaleStatusGenerations(t *testing.T) {
+	d := newDashboardModel(Services{Journal: &countingJournal{}})
+
+	// Two changes arrive in quick succession; each schedules a reload.
+	d, first := d.Update(fsChangeMsg{})
+	d, second := d.Update(fsChangeMsg{})
+	if d.generation != 2 {
+		t.Fatalf("generation = %d; want 2", d.generation)
+	}
+
+	stale := first().(statusLoadedMsg)
+	stale.active = &Entry{Customer: "stale"}
+	fresh := second().(statusLoadedMsg)
+	fresh.active = &Entry{Customer: "fresh"}
+
+	// The newer result lands first, then the older one arrives late.
+	d, _ = d.Update(fresh)
+	d, _ = d.Update(stale)
+	if d.active == nil || d.active.Customer != "fresh" {
+		t.Fatalf("active = %+v; want result of the latest generation", d.active)
+	}
+}
+
+type roundingConfig struct{}
+
+func (roundingConfig) Timezone() *time.Location { return time.UTC }
+func (roundingConfig) Rounding() RoundingConfig {
+	return RoundingConfig{QuantumMin: 15, Level: "entry"}
+}
+func (roundingConfig) CandidateLookback() time.Duration { return 0 }
+
+func TestDashboardRoundedToggle(t *testing.T) {
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(50 * time.Minute)
+	d := newDashboardModel(Services{Journal: &countingJournal{}, Config: roundingConfig{}})
+	d, _ = d.Update(statusLoadedMsg{last: &Entry{Customer: "Acme", Start: start, End: &end}})
+	if strings.Contains(d.View(), "Rounded") {
+		t.Fatal("rounded duration shown before toggling")
+	}
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	d, _ = d.Update(key("r"))
+	if v := d.View(); !strings.Contains(v, "Rounded") || !strings.Contains(v, "1h00m00s") {
+		t.Fatalf("view lacks the rounded duration:\n%s", v)
+	}
+	d, _ = d.Update(key("r"))
+	if strings.Contains(d.View(), "Rounded") {
+		t.Fatal("rounded duration still shown after toggling back")
+	}
+}
+
+func TestWaitJournalKeepsSubscription(t *testing.T) {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	msg, ok := waitJournal(ch)().(fsChangeMsg)
+	if !ok || msg.changes != (<-chan struct{})(ch) {
+		t.Fatalf("expected fsChangeMsg carrying the subscription, got %#v", msg)
+	}
+	close(ch)
+	if got := waitJournal(ch)(); got != nil {
+		t.Fatalf("expected nil after the watch closed, got %#v", got)
+	}
+}
+
+func TestFSNotifyWatchCoalescesSustainedBursts(t *testing.T) {
+	root := t.TempDir()
+	w := NewFSNotifyJournalWatch(root, 60*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := w.Changes(ctx)
+	time.Sleep(100 * time.Millisecond) // let the watcher register the root
+
+	// Write continuously for longer than maxWait; plain debouncing would stay
+	// silent until the burst ends.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 40; i++ {
+			_ = os.WriteFile(filepath.Join(root, "f"+strconv.Itoa(i)+".jsonl"), []byte("{}\n"), 0o644)
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	received := 0
+	duringBurst := 0
+	timeout := time.After(1500 * time.Millisecond)
+	for {
+		select {
+		case <-changes:
+			received++
+			select {
+			case <-done:
+			default:
+				duringBurst++
+			}
+		case <-timeout:
+			if duringBurst == 0 {
+				t.Fatalf("expected a notification during the burst (maxWait), got none")
+			}
+			if received > 20 {
+				t.Fatalf("expected coalesced notifications, got %d for 40 writes", received)
+			}
+			return
+		}
+	}
+}
+
+type failingWriter struct{ EventWriter }
+
+func (failingWriter) Start(context.Context, StartParams) error {
+	return errors.New("journal is read-only\nopen /x/2025-10-06.jsonl: permission denied")
+}
+
+func (failingWriter) Stop(context.Context) error { panic("writer bug") }
+
+func TestDashboardErrorLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "tui.log")
+	d := newDashboardModel(Services{Journal: &countingJournal{}, Writer: failingWriter{}, Errors: FileErrorLog{Path: logPath}})
+	last := &Entry{Customer: "Acme", Project: "Web", Billable: true}
+	d.setSize(200, 40)
+	d, _ = d.Update(statusLoadedMsg{last: last})
+	d, _ = d.Update(startEntry(d.svcs.Writer, last)())
+	if !strings.Contains(d.status, "Failed to start: journal is read-only (! for details)") || strings.Contains(d.status, "permission denied") {
+		t.Fatalf("status = %q; want the first line", d.status)
+	}
+	// a panicking writer is reported like an error
+	d, _ = d.Update(stopEntry(d.svcs.Writer, nil)())
+	if len(d.errors) != 2 || d.errors[1].Op != "stop" || d.errors[1].Message != "panic: writer bug" {
+		t.Fatalf("errors = %+v", d.errors)
+	}
+
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	v := d.View()
+	if !strings.Contains(v, "Errors (2)") || !strings.Contains(v, "permission denied") || !strings.Contains(v, `"Customer":"Acme"`) ||
+		strings.Index(v, "writer bug") > strings.Index(v, "read-only") {
+		t.Fatalf("error view, newest first, lacks details:\n%s", v)
+	}
+
+	b, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); !strings.Contains(got, "start failed: journal is read-only") || !strings.Contains(got, `    payload: {"Customer":"Acme","Project":"Web"`) || !strings.Contains(got, "stop failed: panic: writer bug") {
+		t.Fatalf("tui.log =\n%s", got)
+	}
+}
+
+type fakeAlerts struct{ asked int }
+
+func (a *fakeAlerts) DueAlert(_ context.Context, active Entry, _ time.Time) string {
+	a.asked++
+	return "You've been on " + active.Customer + " for 2h"
+}
+
+func TestDashboardTimerAlerts(t *testing.T) {
+	alerts := &fakeAlerts{}
+	d := newDashboardModel(Services{Journal: &countingJournal{}, Alerts: alerts})
+	now := time.Date(2025, 10, 6, 11, 0, 0, 0, time.UTC)
+	if _, cmd := d.Update(tickMsg(now)); cmd != nil {
+		t.Fatal("asked for an alert without a running entry")
+	}
+	d, _ = d.Update(statusLoadedMsg{active: &Entry{Customer: "acme", Start: now.Add(-2 * time.Hour)}})
+	d, cmd := d.Update(tickMsg(now))
+	if cmd == nil {
+		t.Fatal("no alert check for the running entry")
+	}
+	d, _ = d.Update(cmd())
+	if !strings.Contains(d.status, "You've been on acme for 2h") {
+		t.Fatalf("status = %q", d.status)
+	}
+	// at most once a minute
+	if _, cmd := d.Update(tickMsg(now.Add(30 * time.Second))); cmd != nil || alerts.asked != 1 {
+		t.Fatalf("asked %d times within a minute", alerts.asked)
+	}
+}
+
+func TestDashboardSuggestionsSkipArchived(t *testing.T) {
+	now := time.Now()
+	j := &countingJournal{entries: []Entry{
+		{Customer: "Oldco", Project: "legacy", Start: now.Add(-time.Hour), Archived: true},
+		{Customer: "Oldco", Project: "legacy", Start: now.Add(-2 * time.Hour), Archived: true},
+		{Customer: "Acme", Project: "portal", Start: now.Add(-3 * time.Hour)},
+	}}
+	d := newDashboardModel(Services{Journal: j})
+	d.last = &j.entries[0]
+	got := d.buildSuggestions()
+	if len(got) != 1 || got[0].Customer != "Acme" {
+		t.Fatalf("suggestions = %+v; want only Acme", got)
+	}
+}
+
+func TestParseWeekJump(t *testing.T) {
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	for _, tc := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"2025-W41", day(2025, 10, 6)},
+		{"2025w1", day(2024, 12, 30)}, // week 1 starts in the previous year
+		{"W02", day(2025, 1, 6)},
+		{"2020-W53", day(2020, 12, 28)},
+		{"2025-10-12", day(2025, 10, 6)},
+		{"2025-10", day(2025, 9, 29)}, // Thursday 2 October
+		{"2026-02", day(2026, 2, 2)},  // 1 February is a Sunday
+	} {
+		if got, err := parseWeekJump(tc.in, now, time.UTC); err != nil || !got.Equal(tc.want) {
+			t.Errorf("parseWeekJump(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{"2025-W53", "W0", "next", ""} {
+		if _, err := parseWeekJump(in, now, time.UTC); err == nil {
+			t.Errorf("parseWeekJump(%q) accepted", in)
+		}
+	}
+}
+
+func TestDashboardTimelineWeekStepping(t *testing.T) {
+	d := newDashboardModel(Services{Journal: &countingJournal{}})
+	d, _ = d.Update(statusLoadedMsg{})
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// h does nothing while the timelines are closed.
+	if d, _ = d.Update(key("h")); !d.timelineWeekStart.IsZero() {
+		t.Fatalf("h moved the closed timelines to %v", d.timelineWeekStart)
+	}
+	// t opens the timelines on the current week, h steps back a week.
+	d, cmd := d.Update(key("t"))
+	if !d.showTimelines || cmd == nil || d.timelineWeekStart.Weekday() != time.Monday {
+		t.Fatalf("timelines = %v, week start = %v", d.showTimelines, d.timelineWeekStart)
+	}
+	week := d.timelineWeekStart
+	d, _ = d.Update(cmd())
+	d, _ = d.Update(key("h"))
+	if !d.timelineWeekStart.Equal(week.AddDate(0, 0, -7)) || d.timelineLoaded {
+		t.Fatalf("week start = %v, loaded = %v; want the previous week loading", d.timelineWeekStart, d.timelineLoaded)
+	}
+	d, _ = d.Update(key(">"))
+	if !d.timelineWeekStart.Equal(week) {
+		t.Fatalf("> = %v; want %v", d.timelineWeekStart, week)
+	}
+	if d, _ = d.Update(key("t")); d.showTimelines {
+		t.Fatal("t did not close the timelines")
+	}
+}
+
+func TestDashboardTimelineJumps(t *testing.T) {
+	d := newDashboardModel(Services{Journal: &countingJournal{}})
+	d, _ = d.Update(statusLoadedMsg{})
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	d, _ = d.Update(key("t"))
+	current := d.timelineWeekStart
+
+	// g opens the jump input, which captures keys until Enter.
+	d, _ = d.Update(key("g"))
+	if !d.isCapturing() || !strings.Contains(d.View(), "Jump to week") {
+		t.Fatalf("jump input not open:\n%s", d.View())
+	}
+	for _, r := range "2025-W4" {
+		d, _ = d.Update(key(string(r)))
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if want := time.Date(2025, 1, 20, 0, 0, 0, 0, time.Local); !d.timelineWeekStart.Equal(want) || d.jumpMode {
+		t.Fatalf("week start = %v, jumpMode = %v; want %v", d.timelineWeekStart, d.jumpMode, want)
+	}
+
+	// ] pages to the first week of February, H back to January's, which
+	// starts on 30 December.
+	d, _ = d.Update(key("]"))
+	if want := time.Date(2025, 2, 3, 0, 0, 0, 0, time.Local); !d.timelineWeekStart.Equal(want) {
+		t.Fatalf("next month = %v; want %v", d.timelineWeekStart, want)
+	}
+	d, _ = d.Update(key("H"))
+	if want := time.Date(2024, 12, 30, 0, 0, 0, 0, time.Local); !d.timelineWeekStart.Equal(want) {
+		t.Fatalf("previous month = %v; want %v", d.timelineWeekStart, want)
+	}
+	d, _ = d.Update(key("["))
+	if want := time.Date(2024, 12, 2, 0, 0, 0, 0, time.Local); !d.timelineWeekStart.Equal(want) {
+		t.Fatalf("December = %v; want %v", d.timelineWeekStart, want)
+	}
+
+	// A bad jump keeps the input open; G returns to the current week.
+	d, _ = d.Update(key("g"))
+	d, _ = d.Update(key("x"))
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !d.jumpMode || !strings.Contains(d.status, "cannot jump") {
+		t.Fatalf("bad jump: jumpMode = %v, status = %q", d.jumpMode, d.status)
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	d, _ = d.Update(key("G"))
+	if !d.timelineWeekStart.Equal(current) {
+		t.Fatalf("G = %v; want %v", d.timelineWeekStart, current)
+	}
+}
+
+func TestDashboardTargets(t *testing.T) {
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC) // Wednesday
+	end := now.Add(-time.Hour)
+	j := &countingJournal{entries: []Entry{
+		{Customer: "Acme", Start: now.Add(-4 * time.Hour), End: &end},                                               // 3h today
+		{Customer: "acme", Start: now.Add(-30 * time.Minute), Paused: 10 * time.Minute},                             // running: 20m
+		{Customer: "Globex", Start: now.AddDate(0, 0, -2).Add(-3 * time.Hour), End: ptrTime(now.AddDate(0, 0, -2))}, // Monday 3h
+	}}
+	if d := newDashboardModel(Services{Journal: j}); d.renderTargets(now) != "" {
+		t.Fatal("targets section without targets")
+	}
+	tc := &targets.Config{
+		Goal:      targets.Goal{Daily: 3 * time.Hour, Weekly: 40 * time.Hour},
+		Customers: map[string]targets.Goal{"acme": {Daily: 4 * time.Hour}},
+	}
+	d := newDashboardModel(Services{Journal: j, Config: roundingConfig{}, Targets: tc})
+	msg := d.Init()().(statusLoadedMsg)
+	if len(msg.week) != 3 {
+		t.Fatalf("week entries not loaded with targets: %+v", msg)
+	}
+	d, _ = d.Update(msg)
+	out := d.renderTargets(now)
+	for _, want := range []string{"Targets", "today", "3h20m of 3h, 20m overtime", "this week", "6h20m of 40h, 33h40m left", "acme today", "3h20m of 4h, 40m left"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
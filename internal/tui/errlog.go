@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrorRecord is a failed journal write: the operation, the full error
+// message and the parameters it was called with.
+type ErrorRecord struct {
+	Time    time.Time
+	Op      string // start, switch, stop or note
+	Message string
+	Payload string // the parameters as JSON; empty for stop
+}
+
+// ErrorSink persists error records outside the TUI, so they survive a
+// crash or quit.
+type ErrorSink interface {
+	RecordError(r ErrorRecord) error
+}
+
+// maxErrorRecords bounds the errors the dashboard keeps for the ! view.
+const maxErrorRecords = 50
+
+// DefaultErrorLogPath returns ~/.tt/tui.log.
+func DefaultErrorLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".tt", "tui.log")
+	}
+	return filepath.Join(home, ".tt", "tui.log")
+}
+
+// FileErrorLog appends error records to a text file. Each record is written
+// and the file closed at once, so nothing is buffered when the TUI dies.
+type FileErrorLog struct {
+	Path string
+}
+
+// RecordError appends r as a line, followed by an indented payload line.
+func (l FileErrorLog) RecordError(r ErrorRecord) error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s failed: %s\n", r.Time.Format(time.RFC3339), r.Op, r.Message)
+	if r.Payload != "" {
+		fmt.Fprintf(&b, "    payload: %s\n", r.Payload)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeResult is the outcome of a journal write, carried by the done
+// messages so a failure can be logged with what was written.
+type writeResult struct {
+	op      string
+	payload any
+	err     error
+}
+
+// runWrite calls fn and turns a panic in it into an error, so a failing
+// writer cannot take the TUI (and the terminal state) down with it.
+func runWrite(op string, payload any, fn func() error) (res writeResult) {
+	res = writeResult{op: op, payload: payload}
+	defer func() {
+		if p := recover(); p != nil {
+			res.err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	res.err = fn()
+	return res
+}
+
+// record turns a failed write into an ErrorRecord.
+func (r writeResult) record(now time.Time) ErrorRecord {
+	rec := ErrorRecord{Time: now, Op: r.op, Message: r.err.Error()}
+	if r.payload != nil {
+		if b, err := json.Marshal(r.payload); err == nil {
+			rec.Payload = string(b)
+		} else {
+			rec.Payload = fmt.Sprintf("%+v", r.payload)
+		}
+	}
+	return rec
+}
@@ -59,6 +59,29 @@ type formModel struct {
 
 	// debounce tracking for live suggestions
 	debounceID int
+
+	// asynchronous candidate loading (see Init)
+	candidates CandidateSource
+	lookback   time.Duration
+	loading    bool
+
+	// activity check on submit (see SetActivityPolicy) and its last error
+	activities  ActivityPolicy
+	activityErr string
+
+	// per customer/project billable defaults (see SetBillableDefaults)
+	billRules BillableDefaults
+}
+
+// defaultCandidateLookback bounds how far back the form looks for
+// customer/project candidates when no ConfigService value is available.
+const defaultCandidateLookback = 90 * 24 * time.Hour
+
+// candidatesLoadedMsg carries candidates loaded in the background by Init.
+type candidatesLoadedMsg struct {
+	customers []string
+	projects  []string
+	err       error
 }
 
 // NewStartSwitchForm constructs a form model wired to the provided writer.
@@ -118,14 +141,13 @@ func NewStartSwitchForm(w EventWriter, last *Entry, defaultBillable bool, sugges
 		defaultBill:   defaultBillable,
 		suggestions:   suggestions,
 		writer:        w,
+		lookback:      defaultCandidateLookback,
+		loading:       true,
 	}
 
-	// Preload completion candidates synchronously so the UI can use them immediately.
-	// This reads unique customer/project strings from the journal root (~/.tt/journal).
-	fm.loadCandidatesFromJournal()
-
-	// If the journal provided no candidates, seed from the suggestions slice
-	// and the provided last entry to avoid an empty completion set (helps new users).
+	// Journal candidates are loaded asynchronously from Init. Until they arrive,
+	// seed from the suggestions slice and the provided last entry so completion
+	// is usable immediately (and for new users with an empty journal).
 	if len(fm.custCandidates) == 0 {
 		cset := map[string]struct{}{}
 		for _, s := range suggestions {
@@ -175,10 +197,30 @@ func NewStartSwitchForm(w EventWriter, last *Entry, defaultBillable bool, sugges
 	return fm
 }
 
-// Init implements tea.Model.Init
+// Init implements tea.Model.Init. It keeps the textinput cursor blinking and
+// starts loading completion candidates in the background.
 func (f *formModel) Init() tea.Cmd {
-	// No async startup required; keep textinput blinking cursor alive.
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, f.loadCandidatesCmd())
+}
+
+// loadCandidatesCmd returns a command that loads customer/project candidates
+// within the lookback window. A configured CandidateSource (backed by the
+// CLI's completion index) is preferred; otherwise the journal tree is walked,
+// skipping day files older than the window.
+func (f *formModel) loadCandidatesCmd() tea.Cmd {
+	src := f.candidates
+	var since time.Time
+	if f.lookback > 0 {
+		since = time.Now().Add(-f.lookback)
+	}
+	return func() tea.Msg {
+		if src != nil {
+			custs, projs, err := src.Candidates(context.Background(), since)
+			return candidatesLoadedMsg{customers: custs, projects: projs, err: err}
+		}
+		custs, projs := loadCandidatesFromJournal(DefaultJournalRoot(), since)
+		return candidatesLoadedMsg{customers: custs, projects: projs}
+	}
 }
 
 // suggestMsg is emitted when a debounced suggestion computation completes.
@@ -192,6 +234,19 @@ type suggestMsg struct {
 // allows Tab/Shift-Tab to move focus. Enter on last input submits the form.
 func (f *formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case candidatesLoadedMsg:
+		f.loading = false
+		// Keep the seeded candidates when loading failed or found nothing.
+		if msg.err == nil {
+			if len(msg.customers) > 0 {
+				f.custCandidates = msg.customers
+			}
+			if len(msg.projects) > 0 {
+				f.projCandidates = msg.projects
+			}
+		}
+		return f, nil
+
 	case tea.WindowSizeMsg:
 		f.width = msg.Width
 		// propagate width to inputs for nicer wrapping
@@ -406,6 +461,14 @@ func (f *formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// If Enter pressed and focused on last field, submit.
 			if k == "enter" && f.focused == len(f.inputs)-1 {
+				// A rejected activity keeps the form open on the activity field.
+				if err := f.checkActivity(); err != nil {
+					f.activityErr = err.Error()
+					f.inputs[f.focused].Blur()
+					f.focused = 2
+					f.inputs[f.focused].Focus()
+					return f, nil
+				}
 				// Submit
 				return f, f.submitCmd()
 			}
@@ -484,7 +547,9 @@ func (f *formModel) View() string {
 	// Suggestions status: show counts to help debug visibility of dropdown/matches.
 	// When focused on customer (0) or project (1), show matches vs total candidates and whether the dropdown is open.
 	var suggStatus string
-	if f.focused == 0 {
+	if f.loading {
+		suggStatus = "Loading candidates…"
+	} else if f.focused == 0 {
 		total := len(f.custCandidates)
 		matches := len(f.matchList.Items())
 		if f.listOpen {
@@ -538,12 +603,16 @@ func (f *formModel) View() string {
 		}
 	}
 	renderLine("Activity", f.activityInput)
+	if f.activityErr != "" {
+		b.WriteString(StatusErrStyle.Render(f.activityErr))
+		b.WriteString("\n")
+	}
 	renderLine("Tags", f.tagsInput)
 	renderLine("Note", f.noteInput)
 
 	// Billable hint & instructions
 	b.WriteString("\n")
-	b.WriteString(MutedStyle.Render(fmt.Sprintf("Billable: %v  (toggle with 'b' in dashboard form mode)", f.defaultBill)))
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("Billable: %v  (toggle with 'b' in dashboard form mode)", f.billable())))
 	b.WriteString("\n\n")
 	b.WriteString(MutedStyle.Render("Tab: next field • Shift+Tab: prev • Enter on Note: submit • Esc: cancel • Ctrl+Space: complete"))
 
@@ -600,15 +669,20 @@ func (f *formModel) submitCmd() tea.Cmd {
 		Customer: cust,
 		Project:  proj,
 		Activity: act,
-		Billable: f.defaultBill,
+		Billable: f.billable(),
 		Tags:     tags,
 		Note:     note,
 	}
 
+	var warning string
+	if f.activities != nil {
+		warning, _ = f.activities.CheckActivity(act)
+	}
+
 	// Return command that performs Start on writer.
 	return func() tea.Msg {
 		if f.writer == nil {
-			return startDoneMsg{}
+			return startDoneMsg{warning: warning}
 		}
 		// If mode == "switch" and there is a Switch method expected, call Switch.
 		// Use Switch for active->new transitions; still report startDoneMsg for dashboard.
@@ -621,22 +695,20 @@ func (f *formModel) submitCmd() tea.Cmd {
 				Tags:     p.Tags,
 				Note:     p.Note,
 			}
-			if err := f.writer.Switch(context.Background(), sp); err != nil {
-				return startDoneMsg{err: err}
-			}
-			return startDoneMsg{}
+			return startDoneMsg{warning: warning, writeResult: runWrite("switch", sp, func() error {
+				return f.writer.Switch(context.Background(), sp)
+			})}
 		}
-		if err := f.writer.Start(context.Background(), p); err != nil {
-			return startDoneMsg{err: err}
-		}
-		return startDoneMsg{}
+		return startDoneMsg{warning: warning, writeResult: runWrite("start", p, func() error {
+			return f.writer.Start(context.Background(), p)
+		})}
 	}
 }
 
-// Helper: load unique candidates from journal files
-func (f *formModel) loadCandidatesFromJournal() {
-	// Load unique customers and projects from ~/.tt/journal (non-strict, best-effort).
-	root := DefaultJournalRoot()
+// loadCandidatesFromJournal collects unique customer and project strings from
+// the journal below root (non-strict, best-effort). Day files dated before
+// since are skipped without being opened; a zero since disables the bound.
+func loadCandidatesFromJournal(root string, since time.Time) ([]string, []string) {
 	custSet := map[string]struct{}{}
 	projSet := map[string]struct{}{}
 
@@ -650,6 +722,9 @@ func (f *formModel) loadCandidatesFromJournal() {
 		if !strings.HasSuffix(info.Name(), ".jsonl") {
 			return nil
 		}
+		if !since.IsZero() && journalFileBefore(info, since) {
+			return nil
+		}
 		fh, err := os.Open(path)
 		if err != nil {
 			return nil
@@ -691,8 +766,17 @@ func (f *formModel) loadCandidatesFromJournal() {
 	}
 	sort.Strings(projs)
 
-	f.custCandidates = custs
-	f.projCandidates = projs
+	return custs, projs
+}
+
+// journalFileBefore reports whether a journal file lies entirely before since.
+// Day files are named YYYY-MM-DD.jsonl; other files fall back to their mtime.
+func journalFileBefore(info os.FileInfo, since time.Time) bool {
+	name := strings.TrimSuffix(info.Name(), ".jsonl")
+	if day, err := time.ParseInLocation("2006-01-02", name, since.Location()); err == nil {
+		return day.AddDate(0, 0, 1).Before(since)
+	}
+	return info.ModTime().Before(since)
 }
 
 func filterPrefix(list []string, prefix string) []string {
@@ -794,6 +878,45 @@ func (f *formModel) SetMode(m string)          { f.mode = m }
 func (f *formModel) SetDefaultBillable(b bool) { f.defaultBill = b }
 func (f *formModel) SetWriter(w EventWriter)   { f.writer = w }
 
+// SetCandidateSource configures where Init loads completion candidates from.
+func (f *formModel) SetCandidateSource(src CandidateSource) { f.candidates = src }
+
+// SetCandidateLookback bounds candidate loading to the given window; zero or
+// negative values load the whole journal.
+func (f *formModel) SetCandidateLookback(d time.Duration) { f.lookback = d }
+
+// SetActivityPolicy configures the activity check run on submit; nil accepts
+// any activity.
+func (f *formModel) SetActivityPolicy(p ActivityPolicy) { f.activities = p }
+
+// SetBillableDefaults configures the rules that override the default
+// billable flag for the entered customer/project; nil disables them.
+func (f *formModel) SetBillableDefaults(r BillableDefaults) { f.billRules = r }
+
+// billable is the flag the form submits: the rule for the entered
+// customer/project when one matches, else the form default.
+func (f *formModel) billable() bool {
+	if f.billRules != nil {
+		cust := strings.TrimSpace(f.customerInput.Value())
+		proj := strings.TrimSpace(f.projectInput.Value())
+		if b, ok := f.billRules.DefaultBillable(context.Background(), cust, proj); ok {
+			return b
+		}
+	}
+	return f.defaultBill
+}
+
+// checkActivity runs the activity policy; an error means the activity is
+// rejected.
+func (f *formModel) checkActivity() error {
+	f.activityErr = ""
+	if f.activities == nil {
+		return nil
+	}
+	_, err := f.activities.CheckActivity(strings.TrimSpace(f.activityInput.Value()))
+	return err
+}
+
 // small helpers
 // min moved to internal/tui/style.go; use min(...) from there.
 // This placeholder indicates the helper was intentionally removed from this file.
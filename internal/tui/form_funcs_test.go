@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -119,8 +120,9 @@ func TestLoadCandidatesFromJournalReadsJsonl(t *testing.T) {
 		t.Fatalf("writefile: %v", err)
 	}
 
-	// Construct form which will call loadCandidatesFromJournal() in constructor.
+	// Candidates are loaded by the command returned from Init; run it directly.
 	f := NewStartSwitchForm(nil, nil, false, nil)
+	f.Update(f.loadCandidatesCmd()())
 	// Candidates should include the two customers and projects we wrote.
 	foundA := false
 	foundB := false
@@ -269,3 +271,73 @@ func TestCtrlSpaceCompletion(t *testing.T) {
 		t.Fatalf("expected first project match to start with 'mobil', got %q", firstP)
 	}
 }
+
+type fakeCandidateSource struct {
+	since     time.Time
+	customers []string
+	projects  []string
+}
+
+func (s *fakeCandidateSource) Candidates(_ context.Context, since time.Time) ([]string, []string, error) {
+	s.since = since
+	return s.customers, s.projects, nil
+}
+
+func TestCandidatesLoadAsynchronously(t *testing.T) {
+	last := &Entry{Customer: "Seed", Project: "seed:proj"}
+	f := NewStartSwitchForm(nil, last, false, nil)
+	f.width = 80
+
+	// Before the load completes the form is usable with seeded candidates.
+	if !f.loading {
+		t.Fatalf("expected form to be loading after construction")
+	}
+	if !reflect.DeepEqual(f.custCandidates, []string{"Seed"}) {
+		t.Fatalf("seeded customers = %v; want [Seed]", f.custCandidates)
+	}
+	if out := f.View(); !strings.Contains(out, "Loading candidates") {
+		t.Fatalf("View missing loading indicator; got:\n%s", out)
+	}
+
+	src := &fakeCandidateSource{customers: []string{"Acme", "Beta"}, projects: []string{"web"}}
+	f.SetCandidateSource(src)
+	f.SetCandidateLookback(48 * time.Hour)
+	f.Update(f.loadCandidatesCmd()())
+
+	if f.loading {
+		t.Fatalf("expected loading to be cleared after candidatesLoadedMsg")
+	}
+	if !reflect.DeepEqual(f.custCandidates, src.customers) || !reflect.DeepEqual(f.projCandidates, src.projects) {
+		t.Fatalf("candidates = %v / %v; want %v / %v", f.custCandidates, f.projCandidates, src.customers, src.projects)
+	}
+	if d := time.Since(src.since); d < 47*time.Hour || d > 49*time.Hour {
+		t.Fatalf("source asked for since=%v; want ~48h ago", src.since)
+	}
+}
+
+func TestLoadCandidatesFromJournalSkipsOldDays(t *testing.T) {
+	root := t.TempDir()
+	write := func(day time.Time, customer string) {
+		dir := filepath.Join(root, day.Format("2006"), day.Format("01"))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdirall: %v", err)
+		}
+		line := `{"customer":"` + customer + `","project":"p-` + customer + `"}` + "\n"
+		if err := os.WriteFile(filepath.Join(dir, day.Format("2006-01-02")+".jsonl"), []byte(line), 0o644); err != nil {
+			t.Fatalf("writefile: %v", err)
+		}
+	}
+	now := time.Now()
+	write(now, "Recent")
+	write(now.AddDate(0, 0, -40), "Old")
+
+	custs, projs := loadCandidatesFromJournal(root, now.AddDate(0, 0, -30))
+	if !reflect.DeepEqual(custs, []string{"Recent"}) || !reflect.DeepEqual(projs, []string{"p-Recent"}) {
+		t.Fatalf("bounded load = %v / %v; want only Recent", custs, projs)
+	}
+
+	custs, _ = loadCandidatesFromJournal(root, time.Time{})
+	if !reflect.DeepEqual(custs, []string{"Old", "Recent"}) {
+		t.Fatalf("unbounded load = %v; want [Old Recent]", custs)
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strconv"
 	"testing"
@@ -239,3 +240,74 @@ func TestSuggestMsgHandlingAndListSelection(t *testing.T) {
 		t.Fatalf("suggestMsg with stale id should be ignored but opened list")
 	}
 }
+
+// fakeActivities rejects every activity but "design".
+type fakeActivities struct{}
+
+func (fakeActivities) CheckActivity(a string) (string, error) {
+	if a == "design" || a == "" {
+		return "", nil
+	}
+	return "", fmt.Errorf("activity %q not allowed", a)
+}
+
+func TestSubmitRejectedActivityKeepsForm(t *testing.T) {
+	f := newTestForm()
+	fw := &fakeWriter{}
+	f.SetWriter(fw)
+	f.SetActivityPolicy(fakeActivities{})
+	f.customerInput.SetValue("acme")
+	f.activityInput.SetValue("gaming")
+	f.focused = len(f.inputs) - 1
+
+	_, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatalf("rejected activity submitted the form")
+	}
+	if f.focused != 2 || f.activityErr == "" {
+		t.Fatalf("focused = %d, activityErr = %q; want activity field with error", f.focused, f.activityErr)
+	}
+
+	f.activityInput.SetValue("design")
+	f.focused = len(f.inputs) - 1
+	_, cmd = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("allowed activity did not submit")
+	}
+	if msg, ok := cmd().(startDoneMsg); !ok || msg.err != nil || !fw.startCalled {
+		t.Fatalf("submit = %#v, startCalled = %v", msg, fw.startCalled)
+	}
+	if f.activityErr != "" {
+		t.Fatalf("activityErr not cleared: %q", f.activityErr)
+	}
+}
+
+// fakeBillable marks customer "internal" non-billable.
+type fakeBillable struct{}
+
+func (fakeBillable) DefaultBillable(ctx context.Context, customer, project string) (bool, bool) {
+	if customer == "internal" {
+		return false, true
+	}
+	return false, false
+}
+
+func TestSubmitUsesBillableDefaults(t *testing.T) {
+	f := newTestForm()
+	fw := &fakeWriter{}
+	f.SetWriter(fw)
+	f.SetDefaultBillable(true)
+	f.SetBillableDefaults(fakeBillable{})
+
+	f.customerInput.SetValue("internal")
+	f.submitCmd()()
+	if fw.startParams == nil || fw.startParams.Billable {
+		t.Fatalf("internal: Billable = %v; want false from rule", fw.startParams)
+	}
+
+	f.customerInput.SetValue("acme")
+	f.submitCmd()()
+	if !fw.startParams.Billable {
+		t.Fatalf("acme: Billable = false; want form default true")
+	}
+}
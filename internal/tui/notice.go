@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// EventNotice tells the TUI that a tt process appended journal events. The
+// CLI writes it to a touch file right after each write, so the dashboard can
+// refresh at once and say what changed instead of waiting for the debounced
+// journal watch.
+type EventNotice struct {
+	ID      string    `json:"id"`   // last event written
+	Type    string    `json:"type"` // its type
+	Summary string    `json:"summary"`
+	Count   int       `json:"count"` // events in the write
+	PID     int       `json:"pid"`
+	Written time.Time `json:"written"`
+}
+
+// EventNotifier delivers notices written by other processes.
+type EventNotifier interface {
+	Notices(ctx context.Context) <-chan EventNotice
+}
+
+// DefaultNoticePath returns ~/.tt/notify/last-event.json.
+func DefaultNoticePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".tt", "notify", "last-event.json")
+	}
+	return filepath.Join(home, ".tt", "notify", "last-event.json")
+}
+
+// WriteNotice replaces the notice at path atomically (temp file and rename),
+// so a watcher never reads a partial notice.
+func WriteNotice(path string, n EventNotice) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".notice-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadNotice reads the notice at path.
+func ReadNotice(path string) (EventNotice, error) {
+	var n EventNotice
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return n, err
+	}
+	err = json.Unmarshal(b, &n)
+	return n, err
+}
+
+// TouchFileNotifier implements EventNotifier by watching the notice file's
+// directory with fsnotify. Notices of this process (the TUI's own writes)
+// are dropped.
+type TouchFileNotifier struct {
+	path string
+	pid  int
+}
+
+// NewTouchFileNotifier watches path, or DefaultNoticePath when empty.
+func NewTouchFileNotifier(path string) *TouchFileNotifier {
+	if path == "" {
+		path = DefaultNoticePath()
+	}
+	return &TouchFileNotifier{path: path, pid: os.Getpid()}
+}
+
+// Notices emits each new notice until ctx is canceled; the channel closes
+// when watching fails. A slow receiver only gets the latest notice.
+func (n *TouchFileNotifier) Notices(ctx context.Context) <-chan EventNotice {
+	out := make(chan EventNotice, 1)
+	go func() {
+		defer close(out)
+		dir := filepath.Dir(n.path)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Printf("event notices: %v", err)
+			return
+		}
+		w, err := fsnotify.NewWatcher()
+		if err != nil {
+			log.Printf("event notices: %v", err)
+			return
+		}
+		defer w.Close()
+		if err := w.Add(dir); err != nil {
+			log.Printf("event notices: %v", err)
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if ev.Name != n.path || ev.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+					continue
+				}
+				notice, err := ReadNotice(n.path)
+				if err != nil || notice.PID == n.pid {
+					continue
+				}
+				// replace a notice the receiver has not taken yet
+				select {
+				case <-out:
+				default:
+				}
+				out <- notice
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("event notices: %v", err)
+			}
+		}
+	}()
+	return out
+}
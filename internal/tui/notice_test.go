@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTouchFileNotifierSkipsOwnNotices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify", "last-event.json")
+	n := NewTouchFileNotifier(path)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notices := n.Notices(ctx)
+	time.Sleep(50 * time.Millisecond) // let the watcher start
+
+	if err := WriteNotice(path, EventNotice{ID: "own", PID: os.Getpid()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteNotice(path, EventNotice{ID: "e1", Type: "start", Summary: "start acme/web", Count: 1, PID: os.Getpid() + 1}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-notices:
+		if got.ID != "e1" || got.Summary != "start acme/web" {
+			t.Fatalf("notice = %+v; want e1 from the other process", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no notice received")
+	}
+}
+
+func TestDashboardRefreshesOnNotice(t *testing.T) {
+	d := newDashboardModel(Services{Journal: &countingJournal{}})
+	d, cmd := d.Update(noticeMsg{notice: EventNotice{Summary: "stop acme/web"}})
+	if cmd == nil {
+		t.Fatal("notice did not schedule a reload")
+	}
+	if d.generation != 1 {
+		t.Fatalf("generation = %d; want 1", d.generation)
+	}
+	if !strings.Contains(d.status, "stop acme/web") {
+		t.Fatalf("status = %q; want the notice summary", d.status)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"tt/internal/rounding"
 )
 
 // dayStat is a small shared struct used by the week timeline renderer.
@@ -32,9 +34,22 @@ type dayStat struct {
 //   - billable: Good
 //   - non-billable: Warn
 //
-// The renderer also shows per-day totals (hours and count) on the right of each
-// row and a small legend at the bottom.
+// Blocks wide enough carry their project as label. Each row ends with the
+// customer's week total (hours and count); where entries overlap in a column a
+// row of dots under it shows how many. A Total row gives each day's hours
+// under its column, and a small legend closes the view.
 func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location, width int) string {
+	return renderWeekTimeline(entries, weekStart, tz, width, nil)
+}
+
+// RenderWeekTimelineRounded is RenderWeekTimeline with each customer's week
+// total also rounded under policy (raw → rounded). At entry level every
+// entry's part of the week is rounded, at aggregate level the total.
+func RenderWeekTimelineRounded(entries []Entry, weekStart time.Time, tz *time.Location, width int, policy rounding.Policy) string {
+	return renderWeekTimeline(entries, weekStart, tz, width, &policy)
+}
+
+func renderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location, width int, policy *rounding.Policy) string {
 	if tz == nil {
 		tz = time.Local
 	}
@@ -45,6 +60,7 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 	// Group entries by customer, and bucket per day.
 	customers := map[string][7]dayStat{}
 	customerSet := map[string]struct{}{}
+	tallies := map[string]*rounding.Tally{} // per customer, for rounded totals
 
 	// Helper to add an entry into appropriate day buckets (may split across days).
 	for _, e := range entries {
@@ -73,6 +89,11 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 		}
 
 		// For each day the entry intersects, add intersection duration.
+		cust := e.customerLabel()
+		if cust == "" {
+			cust = "-"
+		}
+		entrySecs := 0
 		for d := 0; d < 7; d++ {
 			dayStart := weekStart.AddDate(0, 0, d)
 			dayEnd := dayStart.AddDate(0, 0, 1)
@@ -83,10 +104,7 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 				continue
 			}
 			secs := int(segEnd.Sub(segStart).Seconds())
-			cust := e.Customer
-			if cust == "" {
-				cust = "-"
-			}
+			entrySecs += secs
 			customerSet[cust] = struct{}{}
 			arr := customers[cust]
 			ds := arr[d]
@@ -96,6 +114,12 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 			arr[d] = ds
 			customers[cust] = arr
 		}
+		if policy != nil && entrySecs > 0 {
+			if tallies[cust] == nil {
+				tallies[cust] = &rounding.Tally{}
+			}
+			tallies[cust].Add(*policy, int64(entrySecs))
+		}
 	}
 
 	// Build sorted list of customers to have stable rendering.
@@ -105,15 +129,42 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 	}
 	sort.Strings(custList)
 
+	// Week totals right of each row: "  4h30m → 4h45m (3)".
+	summaries := map[string]string{}
+	weekSecs := 0
+	for _, cust := range custList {
+		custSecs, custCnt := 0, 0
+		for d := 0; d < 7; d++ {
+			custSecs += customers[cust][d].secs
+			custCnt += customers[cust][d].cnt
+		}
+		weekSecs += custSecs
+		summary := "  " + fmtDurationShort(custSecs)
+		if t := tallies[cust]; t != nil {
+			summary += " → " + fmtDurationShort(int(t.Rounded(*policy)))
+		}
+		if custCnt > 0 {
+			summary += fmt.Sprintf(" (%d)", custCnt)
+		} else {
+			summary += " (-)"
+		}
+		summaries[cust] = summary
+	}
+	weekTotal := "  " + fmtDurationShort(weekSecs)
+	summaryList := []string{weekTotal}
+	for _, sum := range summaries {
+		summaryList = append(summaryList, sum)
+	}
+
 	// Layout calculation
 	minLeft := 12
 	maxLeft := 28
 	leftW := min(maxLeft, max(minLeft, longestLen(append(custList, "Customer"))+2))
 	// Reserve spacing for day columns and per-day summary area.
-	remaining := width - leftW - 2
+	remaining := width - leftW - longestLen(summaryList) - 3
 	if remaining < 14 {
 		// small terminal: fall back to compact text list
-		return renderCompactWeek(customers, custList, weekStart, tz, width)
+		return renderCompactWeek(customers, custList, weekStart, tz, width, roundedTotals(tallies, policy))
 	}
 	dayW := remaining / 7
 	if dayW < 6 {
@@ -133,168 +184,211 @@ func RenderWeekTimeline(entries []Entry, weekStart time.Time, tz *time.Location,
 	}
 	b.WriteString("\n")
 
-	// For each customer build a row
+	// For each customer build a row, plus a density row under it when
+	// entries overlap in a column.
+	dense := false
 	for _, cust := range custList {
 		// Customer column
 		custName := ListItemStyle.Render(cust)
 		b.WriteString(padRight(custName, leftW))
 
-		// For each day render a mini-timeline
+		var cells [7]dayCell
 		for d := 0; d < 7; d++ {
-			ds := customers[cust][d]
-			// Build base cell of spaces to color
-			cell := make([]rune, dayW)
-			for i := range cell {
-				cell[i] = ' '
-			}
-
-			// For each entry affecting that day, overlay colored segments
-			// We render in order so later segments overwrite earlier ones.
-			dayStart := weekStart.AddDate(0, 0, d)
-			dayEnd := dayStart.AddDate(0, 0, 1)
-			// sort entries by start to make rendering predictable
-			sort.SliceStable(ds.ents, func(i, j int) bool {
-				return ds.ents[i].Start.Before(ds.ents[j].Start)
-			})
-
-			for _, e := range ds.ents {
-				est := maxTime(e.Start.In(tz), dayStart)
-				eet := dayEnd
-				if e.End != nil {
-					eet = minTime(e.End.In(tz), dayEnd)
-				} else {
-					// running entry clipped to now or dayEnd
-					now := time.Now().In(tz)
-					if now.Before(dayEnd) {
-						eet = minTime(now, dayEnd)
-					}
-				}
-				// Map to columns
-				relStart := est.Sub(dayStart).Seconds()
-				relEnd := eet.Sub(dayStart).Seconds()
-				startCol := int((relStart / 86400.0) * float64(dayW))
-				endCol := int((relEnd / 86400.0) * float64(dayW))
-				if startCol < 0 {
-					startCol = 0
-				}
-				if endCol > dayW {
-					endCol = dayW
-				}
-				if endCol <= startCol {
-					endCol = min(startCol+1, dayW)
-				}
-				// Choose color
-				var bg lipgloss.Color
-				if e.End == nil {
-					bg = ColorAccent
-				} else if e.Billable {
-					bg = ColorGood
-				} else {
-					bg = ColorWarn
-				}
-				// Fill
-				for i := startCol; i < endCol; i++ {
-					cell[i] = '█' // solid block for better visibility
-				}
-				// Convert runes to string then style segment
-				seg := string(cell)
-				st := lipgloss.NewStyle().Background(bg).Foreground(ColorInverseFg).Render(seg)
-				// Because we rendered into the same cell array, we will render once after coloring decisions.
-				// To keep it simple, we will use a single background per cell below (see end of loop).
-				_ = st // no-op here; actual rendering uses per-character style per color below
-			}
+			cells[d] = buildDayCell(customers[cust][d].ents, weekStart.AddDate(0, 0, d), tz, dayW)
+			b.WriteString(cells[d].render())
+		}
 
-			// Instead of per-entry style complexity, build colored spans by scanning entries again,
-			// but with simpler approach: create a slice of bg colors per column, default to SectionBg.
-			bgCols := make([]lipgloss.Color, dayW)
-			for i := range bgCols {
-				bgCols[i] = ColorSectionBg
-			}
-			for _, e := range ds.ents {
-				est := maxTime(e.Start.In(tz), dayStart)
-				eet := dayEnd
-				if e.End != nil {
-					eet = minTime(e.End.In(tz), dayEnd)
-				} else {
-					now := time.Now().In(tz)
-					if now.Before(dayEnd) {
-						eet = minTime(now, dayEnd)
-					}
-				}
-				relStart := est.Sub(dayStart).Seconds()
-				relEnd := eet.Sub(dayStart).Seconds()
-				startCol := int((relStart / 86400.0) * float64(dayW))
-				endCol := int((relEnd / 86400.0) * float64(dayW))
-				if startCol < 0 {
-					startCol = 0
-				}
-				if endCol > dayW {
-					endCol = dayW
-				}
-				if endCol <= startCol {
-					endCol = min(startCol+1, dayW)
-				}
-				var bg lipgloss.Color
-				if e.End == nil {
-					bg = ColorAccent
-				} else if e.Billable {
-					bg = ColorGood
-				} else {
-					bg = ColorWarn
-				}
-				for i := startCol; i < endCol; i++ {
-					bgCols[i] = bg
-				}
-			}
+		// After the 7 day columns, the customer's week total.
+		b.WriteString(" " + MutedStyle.Render(summaries[cust]))
+		b.WriteString("\n")
 
-			// Now build the cell rendering by grouping consecutive same-bg runs for efficient styling.
-			var cellBuilder strings.Builder
-			i := 0
-			for i < dayW {
-				j := i + 1
-				for j < dayW && bgCols[j] == bgCols[i] {
-					j++
-				}
-				spanLen := j - i
-				span := strings.Repeat(" ", spanLen)
-				st := lipgloss.NewStyle().Background(bgCols[i]).Render(span)
-				cellBuilder.WriteString(st)
-				i = j
-			}
-			// Append the day cell
-			b.WriteString(cellBuilder.String())
+		if row, ok := densityRow(cells); ok {
+			dense = true
+			b.WriteString(strings.Repeat(" ", leftW) + MutedStyle.Render(row) + "\n")
 		}
+	}
 
-		// After 7 day columns, append per-day totals summary (simple aggregation right of row)
-		// Format: "  Mon: 4h30m (2) Tue: 2h (1) ..." or condensed totals.
-		// We'll show totals for the week aggregated.
-		weekSecs := 0
-		weekCnt := 0
-		for d := 0; d < 7; d++ {
-			ds := customers[cust][d]
-			weekSecs += ds.secs
-			weekCnt += ds.cnt
+	// Per-day totals over all customers under each column.
+	b.WriteString(padRight(EmphStyle.Render("Total"), leftW))
+	for d := 0; d < 7; d++ {
+		secs := 0
+		for _, cust := range custList {
+			secs += customers[cust][d].secs
 		}
-		summary := "  " + fmtDurationShort(weekSecs)
-		if weekCnt > 0 {
-			summary += fmt.Sprintf(" (%d)", weekCnt)
-		} else {
-			summary += " (-)"
+		label := "-"
+		if secs > 0 {
+			label = fmtDurationShort(secs)
 		}
-		b.WriteString(" " + MutedStyle.Render(summary))
-		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(centerText(label, dayW)))
 	}
+	b.WriteString(" " + EmphStyle.Render(weekTotal) + "\n")
 
 	// Legend
 	b.WriteString("\n")
 	legend := buildLegend()
 	b.WriteString(legend)
+	if dense {
+		b.WriteString(MutedStyle.Render("· : ⁝ 2/3/4+ overlapping entries"))
+	}
 
 	return RenderSection("Week timelines", b.String(), width)
 }
 
 // ---------- Helpers ----------
 
+// minLabelCols is the narrowest block that gets a project label.
+const minLabelCols = 3
+
+// densityDots mark columns covered by 2, 3 and 4 or more entries.
+var densityDots = []rune{'·', ':', '⁝'}
+
+// dayCell is one day of a customer row: per column its color, its text
+// (project labels) and how many entries cover it.
+type dayCell struct {
+	bg    []lipgloss.Color
+	text  []rune
+	depth []int
+}
+
+// buildDayCell draws the entries of the day starting at dayStart into a cell
+// dayW wide. Entries are drawn in start order, so a later one covers an
+// earlier one; each keeps its project as label when its visible block is at
+// least minLabelCols wide.
+func buildDayCell(ents []Entry, dayStart time.Time, tz *time.Location, dayW int) dayCell {
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	c := dayCell{bg: make([]lipgloss.Color, dayW), text: make([]rune, dayW), depth: make([]int, dayW)}
+	owner := make([]int, dayW) // index of the entry drawn on top, -1 for none
+	for i := range c.bg {
+		c.bg[i] = ColorSectionBg
+		c.text[i] = ' '
+		owner[i] = -1
+	}
+	// sort entries by start to make rendering predictable
+	ents = append([]Entry(nil), ents...)
+	sort.SliceStable(ents, func(i, j int) bool {
+		return ents[i].Start.Before(ents[j].Start)
+	})
+	cols := make([][2]int, len(ents))
+	for k, e := range ents {
+		est := maxTime(e.Start.In(tz), dayStart)
+		eet := dayEnd
+		if e.End != nil {
+			eet = minTime(e.End.In(tz), dayEnd)
+		} else {
+			// running entry clipped to now or dayEnd
+			now := time.Now().In(tz)
+			if now.Before(dayEnd) {
+				eet = minTime(now, dayEnd)
+			}
+		}
+		startCol, endCol := dayColumns(est, eet, dayStart, dayEnd, dayW)
+		cols[k] = [2]int{startCol, endCol}
+		bg := entryColor(e)
+		for i := startCol; i < endCol; i++ {
+			c.bg[i] = bg
+			c.depth[i]++
+			owner[i] = k
+		}
+	}
+	for k, e := range ents {
+		// the longest run of columns still showing this entry
+		bestStart, bestLen := 0, 0
+		for i := cols[k][0]; i < cols[k][1]; {
+			if owner[i] != k {
+				i++
+				continue
+			}
+			j := i
+			for j < cols[k][1] && owner[j] == k {
+				j++
+			}
+			if j-i > bestLen {
+				bestStart, bestLen = i, j-i
+			}
+			i = j
+		}
+		if bestLen < minLabelCols || e.Project == "" {
+			continue
+		}
+		copy(c.text[bestStart:bestStart+bestLen], []rune(truncateLabel(e.Project, bestLen)))
+	}
+	return c
+}
+
+// render styles the cell, one span per run of the same color.
+func (c dayCell) render() string {
+	var b strings.Builder
+	for i := 0; i < len(c.bg); {
+		j := i + 1
+		for j < len(c.bg) && c.bg[j] == c.bg[i] {
+			j++
+		}
+		st := lipgloss.NewStyle().Background(c.bg[i]).Foreground(ColorInverseFg)
+		b.WriteString(st.Render(string(c.text[i:j])))
+		i = j
+	}
+	return b.String()
+}
+
+// densityRow returns the dots under the columns of cells where entries
+// overlap, and false when none do.
+func densityRow(cells [7]dayCell) (string, bool) {
+	var b strings.Builder
+	overlap := false
+	for _, c := range cells {
+		for _, n := range c.depth {
+			if n < 2 {
+				b.WriteRune(' ')
+				continue
+			}
+			overlap = true
+			b.WriteRune(densityDots[min(n, len(densityDots)+1)-2])
+		}
+	}
+	return strings.TrimRight(b.String(), " "), overlap
+}
+
+// entryColor is the block color of e: running, billable or non-billable.
+func entryColor(e Entry) lipgloss.Color {
+	switch {
+	case e.End == nil:
+		return ColorAccent
+	case e.Billable:
+		return ColorGood
+	default:
+		return ColorWarn
+	}
+}
+
+// truncateLabel shortens s to w columns, marking the cut with "…".
+func truncateLabel(s string, w int) string {
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	return string(r[:w-1]) + "…"
+}
+
+// dayColumns maps the segment [st, en) of the day [dayStart, dayEnd) to the
+// half-open column range of a cell dayW wide (at least one column). The
+// columns span the day's real length, 23h or 25h on a DST change, so the
+// wall-clock day always fills the cell.
+func dayColumns(st, en, dayStart, dayEnd time.Time, dayW int) (int, int) {
+	daySec := dayEnd.Sub(dayStart).Seconds()
+	startCol := int((st.Sub(dayStart).Seconds() / daySec) * float64(dayW))
+	endCol := int((en.Sub(dayStart).Seconds() / daySec) * float64(dayW))
+	if startCol < 0 {
+		startCol = 0
+	}
+	if endCol > dayW {
+		endCol = dayW
+	}
+	if endCol <= startCol {
+		endCol = min(startCol+1, dayW)
+	}
+	return startCol, endCol
+}
+
 func maxTime(a, b time.Time) time.Time {
 	if a.After(b) {
 		return a
@@ -366,7 +460,20 @@ func buildLegend() string {
 	return b.String()
 }
 
-func renderCompactWeek(customers map[string][7]dayStat, custList []string, weekStart time.Time, tz *time.Location, width int) string {
+// roundedTotals returns each customer's rounded week total, nil without a
+// policy.
+func roundedTotals(tallies map[string]*rounding.Tally, policy *rounding.Policy) map[string]int {
+	if policy == nil {
+		return nil
+	}
+	out := map[string]int{}
+	for cust, t := range tallies {
+		out[cust] = int(t.Rounded(*policy))
+	}
+	return out
+}
+
+func renderCompactWeek(customers map[string][7]dayStat, custList []string, weekStart time.Time, tz *time.Location, width int, rounded map[string]int) string {
 	var b strings.Builder
 	for _, cust := range custList {
 		// header
@@ -380,6 +487,13 @@ func renderCompactWeek(customers map[string][7]dayStat, custList []string, weekS
 			s := fmt.Sprintf("  %s: %s (%d)\n", dayLabel, fmtDurationShort(ds.secs), ds.cnt)
 			b.WriteString(MutedStyle.Render(s))
 		}
+		if r, ok := rounded[cust]; ok {
+			weekSecs := 0
+			for d := 0; d < 7; d++ {
+				weekSecs += customers[cust][d].secs
+			}
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("  Week: %s → %s\n", fmtDurationShort(weekSecs), fmtDurationShort(r))))
+		}
 		b.WriteString("\n")
 	}
 	return b.String()
@@ -236,3 +236,101 @@ func TestRenderWeekTimelineRunningEntryLegend(t *testing.T) {
 		t.Fatalf("expected legend to include 'running' label; got:\n%s", out)
 	}
 }
+
+func TestDayColumnsDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	// 2025-03-30 has 23 hours: an entry to midnight fills the cell, and the
+	// wall-clock afternoon starts past the middle of the real day.
+	dayStart := time.Date(2025, 3, 30, 0, 0, 0, 0, berlin)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	noon := time.Date(2025, 3, 30, 12, 0, 0, 0, berlin)
+	if s, e := dayColumns(noon, dayEnd, dayStart, dayEnd, 23); s != 11 || e != 23 {
+		t.Fatalf("spring-forward columns = %d..%d; want 11..23", s, e)
+	}
+	// 2025-10-26 has 25 hours.
+	dayStart = time.Date(2025, 10, 26, 0, 0, 0, 0, berlin)
+	dayEnd = dayStart.AddDate(0, 0, 1)
+	if s, e := dayColumns(dayStart, time.Date(2025, 10, 26, 4, 0, 0, 0, berlin), dayStart, dayEnd, 25); s != 0 || e != 5 {
+		t.Fatalf("fall-back columns = %d..%d; want 0..5", s, e)
+	}
+}
+
+func TestRenderWeekTimelineRounded(t *testing.T) {
+	weekStart := time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)
+	start := weekStart.Add(9 * time.Hour)
+	entries := []Entry{
+		{ID: "a", Start: start, End: ptrTime(start.Add(50 * time.Minute)), Customer: "Acme"},
+		{ID: "b", Start: start.Add(24 * time.Hour), End: ptrTime(start.Add(24*time.Hour + 20*time.Minute)), Customer: "Acme"},
+	}
+	// Entry level rounds each entry up: 50m -> 1h, 20m -> 30m.
+	entry := RoundingConfig{QuantumMin: 15, Level: "entry"}.Policy()
+	if out := RenderWeekTimelineRounded(entries, weekStart, time.UTC, 140, entry); !strings.Contains(out, "1h10m → 1h30m") {
+		t.Fatalf("entry level: expected raw → rounded total; got:\n%s", out)
+	}
+	// Aggregate level rounds the week total once: 70m -> 1h15m.
+	aggregate := RoundingConfig{QuantumMin: 15, Level: "aggregate"}.Policy()
+	if out := RenderWeekTimelineRounded(entries, weekStart, time.UTC, 20, aggregate); !strings.Contains(out, "Week: 1h10m → 1h15m") {
+		t.Fatalf("aggregate level (compact): expected week line; got:\n%s", out)
+	}
+	if out := RenderWeekTimeline(entries, weekStart, time.UTC, 140); strings.Contains(out, "→") {
+		t.Fatalf("raw timeline shows rounded totals:\n%s", out)
+	}
+}
+
+func TestRenderWeekTimelineCustomerLabel(t *testing.T) {
+	weekStart := time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)
+	start := weekStart.Add(9 * time.Hour)
+	entries := []Entry{{ID: "e1", Start: start, End: ptrTime(start.Add(time.Hour)), Customer: "ACME Corporation GmbH", CustomerLabel: "ACME", Billable: true}}
+	out := RenderWeekTimeline(entries, weekStart, time.UTC, 140)
+	if !strings.Contains(out, "ACME") || strings.Contains(out, "Corporation") {
+		t.Fatalf("expected the customer label instead of the name; got:\n%s", out)
+	}
+}
+
+func TestRenderWeekTimelineLabelsTotalsAndDensity(t *testing.T) {
+	weekStart := time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		// Monday 06:00-20:00 is wide enough for a label.
+		{ID: "a", Start: weekStart.Add(6 * time.Hour), End: ptrTime(weekStart.Add(20 * time.Hour)), Customer: "Acme", Project: "Website", Billable: true},
+		// Tuesday 09:00-11:00 and 10:00-12:00 overlap.
+		{ID: "b", Start: weekStart.Add(33 * time.Hour), End: ptrTime(weekStart.Add(35 * time.Hour)), Customer: "Acme", Project: "Ops"},
+		{ID: "c", Start: weekStart.Add(34 * time.Hour), End: ptrTime(weekStart.Add(36 * time.Hour)), Customer: "Acme", Project: "Ops"},
+		{ID: "d", Start: weekStart.Add(57 * time.Hour), End: ptrTime(weekStart.Add(58 * time.Hour)), Customer: "Beta", Project: "Infra"},
+	}
+	out := RenderWeekTimeline(entries, weekStart, time.UTC, 140)
+	lines := strings.Split(out, "\n")
+	row := func(prefix string) string {
+		for _, l := range lines {
+			if strings.Contains(l, prefix) {
+				return l
+			}
+		}
+		t.Fatalf("no line with %q in:\n%s", prefix, out)
+		return ""
+	}
+	if !strings.Contains(row("Acme"), "Website") {
+		t.Fatalf("expected the Website label in the Acme row; got:\n%s", out)
+	}
+	if strings.Contains(out, "Infra") {
+		t.Fatalf("1h block is too narrow for a label; got:\n%s", out)
+	}
+	total := row("Total")
+	if !strings.Contains(total, "14h00m") || !strings.Contains(total, "4h00m") || !strings.Contains(total, "1h00m") || !strings.Contains(total, "19h00m") {
+		t.Fatalf("expected day totals 14h00m, 4h00m, 1h00m and week 19h00m; got %q", total)
+	}
+	if !strings.Contains(out, "·") || !strings.Contains(out, "overlapping entries") {
+		t.Fatalf("expected a density row and its legend; got:\n%s", out)
+	}
+
+	// 09:00-11:00 over 06:00-20:00, one column per hour.
+	c := buildDayCell([]Entry{entries[0], {Start: weekStart.Add(9 * time.Hour), End: ptrTime(weekStart.Add(11 * time.Hour))}}, weekStart, time.UTC, 24)
+	if c.depth[9] != 2 || c.depth[12] != 1 || c.depth[21] != 0 || string(c.text[11:18]) != "Website" {
+		t.Fatalf("depth = %v, text = %q", c.depth, string(c.text))
+	}
+	if got := truncateLabel("Website", 4); got != "Web…" {
+		t.Fatalf("truncateLabel = %q", got)
+	}
+}
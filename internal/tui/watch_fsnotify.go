@@ -16,12 +16,15 @@ import (
 type FSNotifyJournalWatch struct {
 	root     string
 	debounce time.Duration
+	maxWait  time.Duration
 }
 
 // NewFSNotifyJournalWatch creates a new filesystem watcher rooted at the
 // provided path. If root is empty, it defaults to ~/.tt/journal.
 // Debounce controls how quickly bursty events are coalesced into a single
-// notification (default 250ms if <= 0).
+// notification (default 250ms if <= 0). During a sustained burst (e.g. an
+// import writing hundreds of events) a notification is still emitted at least
+// every 4×debounce so the UI does not stall until the burst ends.
 func NewFSNotifyJournalWatch(root string, debounce time.Duration) *FSNotifyJournalWatch {
 	if root == "" {
 		root = DefaultJournalRoot()
@@ -32,6 +35,7 @@ func NewFSNotifyJournalWatch(root string, debounce time.Duration) *FSNotifyJourn
 	return &FSNotifyJournalWatch{
 		root:     root,
 		debounce: debounce,
+		maxWait:  4 * debounce,
 	}
 }
 
@@ -78,14 +82,28 @@ func (w *FSNotifyJournalWatch) Changes(ctx context.Context) <-chan struct{} {
 		}
 
 		// Debounce logic: coalesce multiple events into a single notification.
+		// The quiet period restarts on every event but is capped by maxWait,
+		// measured from the first event of the current burst.
 		var (
-			timer   *time.Timer
-			pending bool
+			timer        *time.Timer
+			pending      bool
+			burstStarted time.Time
 		)
 		trigger := func() {
+			now := time.Now()
+			if !pending {
+				burstStarted = now
+			}
+			wait := w.debounce
+			if left := w.maxWait - now.Sub(burstStarted); left < wait {
+				wait = left
+			}
+			if wait < 0 {
+				wait = 0
+			}
 			// Initialize or reset the timer
 			if timer == nil {
-				timer = time.NewTimer(w.debounce)
+				timer = time.NewTimer(wait)
 				pending = true
 				return
 			}
@@ -96,7 +114,7 @@ func (w *FSNotifyJournalWatch) Changes(ctx context.Context) <-chan struct{} {
 				default:
 				}
 			}
-			timer.Reset(w.debounce)
+			timer.Reset(wait)
 			pending = true
 		}
 		notify := func() {
@@ -0,0 +1,172 @@
+// Package worktime checks tracked time against working-time rules like the
+// EU and German limits: a break of at least 30 minutes after at most 6 hours
+// of work, and at most 10 hours of work a day. Findings are warnings for
+// reports and live reminders; nothing is blocked.
+package worktime
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"tt/internal/reporting"
+)
+
+// Rules are the limits to check. A zero limit is not checked.
+type Rules struct {
+	MaxStretch time.Duration // longest work without a break
+	MinBreak   time.Duration // shortest pause that counts as a break
+	MaxDay     time.Duration // most work per calendar day
+}
+
+// Defaults are the German Arbeitszeitgesetz limits (6h, 30m, 10h).
+var Defaults = Rules{MaxStretch: 6 * time.Hour, MinBreak: 30 * time.Minute, MaxDay: 10 * time.Hour}
+
+// Enabled reports whether any limit is set.
+func (r Rules) Enabled() bool {
+	return r.MaxStretch > 0 || r.MaxDay > 0
+}
+
+// Span is a period of work; a running entry ends now.
+type Span struct {
+	Start, End time.Time
+}
+
+// Kind names the rule a Violation breaks.
+type Kind string
+
+const (
+	NoBreak Kind = "break" // MaxStretch passed without a MinBreak pause
+	LongDay Kind = "day"   // MaxDay passed
+)
+
+// Violation is one broken limit.
+type Violation struct {
+	Kind   Kind
+	Day    time.Time     // local midnight of the day At falls on
+	At     time.Time     // when the limit was passed
+	Since  time.Time     // start of the stretch (NoBreak) or day (LongDay)
+	Worked time.Duration // work in the whole stretch or day
+}
+
+// Key identifies v across checks of a growing journal, e.g. to announce it
+// only once while the stretch or day goes on.
+func (v Violation) Key() string {
+	return string(v.Kind) + "@" + v.Since.UTC().Format(time.RFC3339)
+}
+
+// Describe is a one-line summary of v under r in loc, e.g.
+// "2025-10-06 09:00: 6h45m without a 30m break (limit 6h)" for a stretch
+// starting at 09:00.
+func (v Violation) Describe(r Rules, loc *time.Location) string {
+	if v.Kind == LongDay {
+		return fmt.Sprintf("%s: %s worked, more than %s", v.Day.In(loc).Format("2006-01-02"), reporting.FormatDuration(v.Worked), reporting.FormatDuration(r.MaxDay))
+	}
+	return fmt.Sprintf("%s: %s without a %s break (limit %s)", v.Since.In(loc).Format("2006-01-02 15:04"),
+		reporting.FormatDuration(v.Worked), reporting.FormatDuration(r.MinBreak), reporting.FormatDuration(r.MaxStretch))
+}
+
+// Check returns the violations in spans, ordered by At. Overlapping spans
+// count once. A stretch of work ends at a pause of at least r.MinBreak and
+// may run over midnight; days are calendar days in loc.
+func Check(spans []Span, r Rules, loc *time.Location) []Violation {
+	merged := merge(spans)
+	var out []Violation
+	if r.MaxStretch > 0 {
+		out = append(out, stretches(merged, r, loc)...)
+	}
+	if r.MaxDay > 0 {
+		out = append(out, days(merged, r, loc)...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
+
+// merge sorts spans and joins the overlapping ones; empty spans are dropped.
+func merge(spans []Span) []Span {
+	var in []Span
+	for _, s := range spans {
+		if s.End.After(s.Start) {
+			in = append(in, s)
+		}
+	}
+	sort.Slice(in, func(i, j int) bool { return in[i].Start.Before(in[j].Start) })
+	var out []Span
+	for _, s := range in {
+		if n := len(out); n > 0 && !s.Start.After(out[n-1].End) {
+			if s.End.After(out[n-1].End) {
+				out[n-1].End = s.End
+			}
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func stretches(merged []Span, r Rules, loc *time.Location) []Violation {
+	var out []Violation
+	var v *Violation
+	var worked time.Duration
+	var since time.Time
+	for i, s := range merged {
+		if i == 0 || s.Start.Sub(merged[i-1].End) >= r.MinBreak {
+			if v != nil {
+				v.Worked = worked
+				out = append(out, *v)
+			}
+			v, worked, since = nil, 0, s.Start
+		}
+		d := s.End.Sub(s.Start)
+		if v == nil && worked+d > r.MaxStretch {
+			at := s.Start.Add(r.MaxStretch - worked)
+			v = &Violation{Kind: NoBreak, Day: midnight(at, loc), At: at, Since: since}
+		}
+		worked += d
+	}
+	if v != nil {
+		v.Worked = worked
+		out = append(out, *v)
+	}
+	return out
+}
+
+func days(merged []Span, r Rules, loc *time.Location) []Violation {
+	var out []Violation
+	var day time.Time
+	var worked time.Duration
+	var v *Violation
+	flush := func() {
+		if v != nil {
+			v.Worked = worked
+			out = append(out, *v)
+		}
+	}
+	for _, s := range merged {
+		// split at local midnights
+		for start := s.Start; start.Before(s.End); {
+			d0 := midnight(start, loc)
+			end := d0.AddDate(0, 0, 1)
+			if s.End.Before(end) {
+				end = s.End
+			}
+			if !d0.Equal(day) {
+				flush()
+				day, worked, v = d0, 0, nil
+			}
+			d := end.Sub(start)
+			if v == nil && worked+d > r.MaxDay {
+				v = &Violation{Kind: LongDay, Day: d0, At: start.Add(r.MaxDay - worked), Since: d0}
+			}
+			worked += d
+			start = end
+		}
+	}
+	flush()
+	return out
+}
+
+func midnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,55 @@
+package worktime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, berlin) }
+	spans := []Span{
+		// Oct 6: 09:00-12:00, 12:15-16:00 (15m pause is no break), 16:30-20:00
+		{at(6, 9, 0), at(6, 12, 0)},
+		{at(6, 11, 0), at(6, 11, 30)}, // overlap counts once
+		{at(6, 12, 15), at(6, 16, 0)},
+		{at(6, 16, 30), at(6, 20, 0)},
+		// Oct 7: 5h, 45m break, 5h
+		{at(7, 8, 0), at(7, 13, 0)},
+		{at(7, 13, 45), at(7, 18, 45)},
+		// night shift over midnight
+		{at(8, 22, 0), at(9, 5, 0)},
+	}
+	got := Check(spans, Defaults, berlin)
+	want := []struct {
+		kind   Kind
+		at     time.Time
+		worked time.Duration
+		desc   string
+	}{
+		{NoBreak, at(6, 15, 15), 6*time.Hour + 45*time.Minute, "2025-10-06 09:00: 6h45m without a 30m break (limit 6h)"},
+		{LongDay, at(6, 19, 45), 10*time.Hour + 15*time.Minute, "2025-10-06: 10h15m worked, more than 10h"},
+		{NoBreak, at(9, 4, 0), 7 * time.Hour, "2025-10-08 22:00: 7h without a 30m break (limit 6h)"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %+v", got)
+	}
+	for i, w := range want {
+		v := got[i]
+		if v.Kind != w.kind || !v.At.Equal(w.at) || v.Worked != w.worked {
+			t.Errorf("#%d = %s at %v worked %v; want %s at %v worked %v", i, v.Kind, v.At, v.Worked, w.kind, w.at, w.worked)
+		}
+		if d := v.Describe(Defaults, berlin); d != w.desc {
+			t.Errorf("#%d described %q; want %q", i, d, w.desc)
+		}
+	}
+	if got[0].Key() == got[2].Key() {
+		t.Error("distinct stretches share a key")
+	}
+	if v := Check(spans, Rules{}, berlin); v != nil {
+		t.Errorf("no rules, violations %+v", v)
+	}
+}
@@ -0,0 +1,50 @@
+package tt_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"tt/pkg/tt"
+)
+
+func Example() {
+	dir, err := os.MkdirTemp("", "journal")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	j, err := tt.OpenJournal(dir, tt.Options{Timezone: "UTC"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	err = j.WriteEvents([]tt.Event{
+		{Type: "start", TS: day.Add(9 * time.Hour), Customer: "Acme", Project: "Portal", Note: "standup"},
+		{Type: "stop", TS: day.Add(10*time.Hour + 20*time.Minute)},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ents, err := j.QueryEntries(ctx, tt.Query{From: day, To: day.AddDate(0, 0, 1)})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range ents {
+		fmt.Println(e.Customer, e.Project, e.Duration(time.Now()))
+	}
+
+	rep, err := j.Report(ctx, day, day.AddDate(0, 0, 6), tt.ReportOptions{Rounding: tt.Rounding{QuantumMinutes: 15}})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(time.Duration(rep.Seconds) * time.Second)
+	// Output:
+	// Acme Portal 1h20m0s
+	// 1h30m0s
+}
@@ -0,0 +1,104 @@
+package tt
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Query selects entries. Zero fields do not filter.
+type Query struct {
+	// From and To bound the entries by overlap: an entry matches when it
+	// ends after From and starts before To. Running entries count as ending
+	// now. Without both, the whole journal is read.
+	From, To time.Time
+	Customer string   // case-insensitive
+	Project  string   // case-insensitive
+	Tags     []string // all of them, case-insensitive
+	User     string
+	Billable *bool
+}
+
+// QueryEntries returns the entries matching q, ordered by start.
+func (j *Journal) QueryEntries(ctx context.Context, q Query) ([]Entry, error) {
+	paths, err := j.dayFiles(q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var out []Entry
+	ents, errc := j.parser.ParseFilesStream(ctx, paths)
+	for e := range ents {
+		if q.matches(entryFrom(e), now) {
+			out = append(out, entryFrom(e))
+		}
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Start.Before(out[b].Start) })
+	return out, nil
+}
+
+func (q Query) matches(e Entry, now time.Time) bool {
+	if !q.From.IsZero() || !q.To.IsZero() {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		if !q.From.IsZero() && !end.After(q.From) {
+			return false
+		}
+		if !q.To.IsZero() && !e.Start.Before(q.To) {
+			return false
+		}
+	}
+	if q.Customer != "" && !strings.EqualFold(e.Customer, q.Customer) {
+		return false
+	}
+	if q.Project != "" && !strings.EqualFold(e.Project, q.Project) {
+		return false
+	}
+	if q.User != "" && e.User != q.User {
+		return false
+	}
+	if q.Billable != nil && e.Billable != *q.Billable {
+		return false
+	}
+	for _, want := range q.Tags {
+		found := false
+		for _, t := range e.Tags {
+			if strings.EqualFold(t, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// dayFiles lists the day files that can hold entries overlapping from..to:
+// those of the days in range plus the day before, whose entries may run
+// past midnight. Without both bounds it lists every day file.
+func (j *Journal) dayFiles(from, to time.Time) ([]string, error) {
+	if from.IsZero() || to.IsZero() {
+		paths, err := filepath.Glob(filepath.Join(j.dir, "*", "*", "*.jsonl"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		return paths, nil
+	}
+	var paths []string
+	day := from.In(j.loc)
+	day = time.Date(day.Year(), day.Month(), day.Day()-1, 0, 0, 0, 0, j.loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		paths = append(paths, j.dayPath(day))
+	}
+	return paths, nil
+}
@@ -0,0 +1,185 @@
+package tt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"tt/internal/reporting"
+	"tt/internal/rounding"
+)
+
+// Rounding is a rounding policy. The zero value reports exact seconds.
+type Rounding struct {
+	Strategy       string // up (default), down or nearest
+	QuantumMinutes int    // 0: no rounding
+	MinimumMinutes int    // minimum per rounded unit; 0 disables
+	Level          string // entry (default): round each entry; aggregate: round each day group
+}
+
+func (r Rounding) policy() rounding.Policy {
+	if r.QuantumMinutes <= 0 {
+		return rounding.Policy{Strategy: "down", QuantumSec: 1}
+	}
+	return rounding.Policy{
+		Strategy:   r.Strategy,
+		QuantumSec: int64(r.QuantumMinutes) * 60,
+		MinimumSec: int64(r.MinimumMinutes) * 60,
+		Level:      r.Level,
+	}
+}
+
+// ReportOptions configure Report.
+type ReportOptions struct {
+	Rounding Rounding
+	// IncludeRunning counts running entries up to Now; otherwise they are
+	// only listed in Report.OpenEntries.
+	IncludeRunning bool
+	Now            time.Time // default time.Now()
+	Locale         string    // weekday labels: de (default, like tt report) or en
+	NotesWrap      int       // wrap merged notes at this many columns; 0: no wrapping
+	Filter         Query     // Customer, Project, Tags, User and Billable select entries; From and To are ignored
+}
+
+// Report is a per-day summary of from..to, grouped by customer and project
+// the way tt report week groups it.
+type Report struct {
+	From, To    string // first and last day, YYYY-MM-DD
+	Timezone    string
+	Days        []ReportDay
+	Seconds     int64 // rounded
+	RawSeconds  int64 // as tracked
+	Provisional bool  // running entries are counted
+
+	Overlaps    []string // days with overlapping entries
+	BadEntries  []string // entries left out, with the reason
+	OpenEntries []string // IDs of running entries
+
+	week reporting.Week
+}
+
+// ReportDay is one day of a Report.
+type ReportDay struct {
+	Date    string // YYYY-MM-DD
+	Weekday string
+	Groups  []ReportGroup
+	Seconds int64
+	Flags   []string // ok (empty day), overlap, provisional
+}
+
+// ReportGroup is the time of one customer/project on a day.
+type ReportGroup struct {
+	Customer   string
+	Project    string
+	Seconds    int64
+	RawSeconds int64
+	Billable   bool
+	Notes      []string
+}
+
+// Report summarizes the entries that start on the days from..to
+// (inclusive, in the journal's timezone).
+func (j *Journal) Report(ctx context.Context, from, to time.Time, opts ReportOptions) (*Report, error) {
+	from = startOfDay(from, j.loc)
+	to = startOfDay(to, j.loc)
+	if to.Before(from) {
+		return nil, fmt.Errorf("report range %s..%s ends before it starts", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	policy := opts.Rounding.policy()
+	agg := reporting.NewAggregator(reporting.Config{Location: j.loc, Policy: policy, Now: now})
+
+	var paths []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		paths = append(paths, j.dayPath(d))
+	}
+	var issues reporting.Issues
+	provisional := false
+	ents, errc := j.parser.ParseFilesStream(ctx, paths)
+	for e := range ents {
+		if !opts.Filter.matches(entryFrom(e), now) {
+			continue
+		}
+		if e.End == nil {
+			issues.OpenEntries = append(issues.OpenEntries, e.ID)
+			if !opts.IncludeRunning {
+				continue
+			}
+			provisional = true
+		}
+		if err := agg.Add(e); err != nil {
+			issues.BadEntries = append(issues.BadEntries, fmt.Sprintf("%s (%v)", e.ID, err))
+		}
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+
+	days := agg.Days(from, to, opts.Locale, opts.NotesWrap)
+	issues.Overlaps = agg.OverlapRanges()
+	issues.Normalize()
+	var total int64
+	for _, d := range days {
+		total += d.DaySeconds
+	}
+	y, w := from.ISOWeek()
+	week := reporting.Week{
+		SchemaVersion:      reporting.WeekSchemaVersion,
+		Week:               fmt.Sprintf("%d-W%02d", y, w),
+		Range:              reporting.Range{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")},
+		Timezone:           j.loc.String(),
+		Days:               days,
+		WeekSeconds:        total,
+		WeekSecondsRounded: total,
+		WeekSecondsRaw:     agg.RawTotal(),
+		Provisional:        provisional,
+		Rounding: reporting.Rounding{
+			Level:          policy.Level,
+			Strategy:       policy.Strategy,
+			QuantumMinutes: policy.Quantum() / 60,
+			MinimumMinutes: policy.MinimumSec / 60,
+		},
+		Issues: issues,
+	}
+	return reportFrom(week), nil
+}
+
+func reportFrom(w reporting.Week) *Report {
+	r := &Report{
+		From: w.Range.From, To: w.Range.To, Timezone: w.Timezone,
+		Seconds: w.WeekSeconds, RawSeconds: w.WeekSecondsRaw, Provisional: w.Provisional,
+		Overlaps: w.Issues.Overlaps, BadEntries: w.Issues.BadEntries, OpenEntries: w.Issues.OpenEntries,
+		week: w,
+	}
+	for _, d := range w.Days {
+		rd := ReportDay{Date: d.Date, Weekday: d.Weekday, Seconds: d.DaySeconds, Flags: d.Flags}
+		for _, g := range d.Groups {
+			rd.Groups = append(rd.Groups, ReportGroup{
+				Customer: g.Customer, Project: g.Project,
+				Seconds: g.Seconds, RawSeconds: g.SecondsRaw, Billable: g.Billable, Notes: g.Notes,
+			})
+		}
+		r.Days = append(r.Days, rd)
+	}
+	return r
+}
+
+// Render writes the report in one of the CLI's report formats: table,
+// markdown, json (the documented tt report week --format json document),
+// csv, html or tempo.
+func (r *Report) Render(w io.Writer, format string) error {
+	rd, err := reporting.New(format, reporting.Options{})
+	if err != nil {
+		return err
+	}
+	return rd.Render(w, r.week)
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
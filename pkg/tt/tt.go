@@ -0,0 +1,177 @@
+// Package tt is the public Go API of the tt time tracker. Editors, bots and
+// other Go programs use it to read and write a tt journal directly instead of
+// shelling out to the CLI:
+//
+//	j, err := tt.OpenJournal("", tt.Options{Timezone: "Europe/Berlin"})
+//	...
+//	err = j.WriteEvent(tt.Event{Type: "start", Customer: "Acme", Project: "Portal"})
+//	ents, err := j.QueryEntries(ctx, tt.Query{From: monday, To: monday.AddDate(0, 0, 7)})
+//	rep, err := j.Report(ctx, monday, sunday, tt.ReportOptions{})
+//
+// The package has its own types rather than exposing the internal ones; they
+// change only as described by APIVersion. Journals written through it are
+// indistinguishable from the CLI's and pass tt audit verify. CLI-only
+// behaviour (event hooks, TUI notices, configuration files) is not applied.
+package tt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"tt/internal/journal"
+)
+
+// APIVersion is the semantic version of this package's API. Minor versions
+// add types, fields and functions; a major version is bumped for anything
+// that breaks existing callers.
+const APIVersion = "1.1.0"
+
+// Event is one immutable journal line. Type is one of start, stop, add,
+// amend, split, merge, pause, resume or note; Ref and Meta carry the
+// type-specific details (e.g. "startISO..endISO" for add, the target entry ID
+// for amend). Hash and PrevHash are set by the writer.
+type Event struct {
+	ID       string            `json:"id"`
+	Type     string            `json:"type"`
+	TS       time.Time         `json:"ts"`
+	User     string            `json:"user,omitempty"`
+	Customer string            `json:"customer,omitempty"`
+	Project  string            `json:"project,omitempty"`
+	Activity string            `json:"activity,omitempty"`
+	Billable *bool             `json:"billable,omitempty"`
+	Note     string            `json:"note,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Ref      string            `json:"ref,omitempty"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	PrevHash string            `json:"prev_hash,omitempty"`
+	Hash     string            `json:"hash,omitempty"`
+}
+
+// Entry is a time entry reconstructed from the events, with all corrections
+// applied. End is nil while the entry is running.
+type Entry struct {
+	ID       string     `json:"id"`
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`
+	Customer string     `json:"customer"`
+	Project  string     `json:"project,omitempty"`
+	Activity string     `json:"activity,omitempty"`
+	Billable bool       `json:"billable"`
+	Notes    []string   `json:"notes,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
+	User     string     `json:"user,omitempty"`
+	Pauses   []Interval `json:"pauses,omitempty"` // pause..resume spans, left out of Duration (since 1.1.0)
+}
+
+// Interval is a span of time; End is nil while it is still open.
+type Interval struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Duration is the entry's length, up to now for a running entry, less its
+// pauses.
+func (e Entry) Duration(now time.Time) time.Duration {
+	if e.End != nil {
+		now = *e.End
+	}
+	return now.Sub(e.Start) - e.journalEntry().Paused(now)
+}
+
+// journalEntry returns the span and pauses of e in the internal type.
+func (e Entry) journalEntry() journal.Entry {
+	je := journal.Entry{Start: e.Start, End: e.End}
+	for _, p := range e.Pauses {
+		je.Pauses = append(je.Pauses, journal.Interval{Start: p.Start, End: p.End})
+	}
+	return je
+}
+
+func entryFrom(e journal.Entry) Entry {
+	out := Entry{
+		ID: e.ID, Start: e.Start, End: e.End,
+		Customer: e.Customer, Project: e.Project, Activity: e.Activity, Billable: e.Billable,
+		Notes: e.Notes, Tags: e.Tags, User: e.User,
+	}
+	for _, p := range e.Pauses {
+		out.Pauses = append(out.Pauses, Interval{Start: p.Start, End: p.End})
+	}
+	return out
+}
+
+// Options configure OpenJournal. The zero value uses the local timezone and
+// leaves events without a user.
+type Options struct {
+	// Timezone (IANA name) decides which day file an event goes to and in
+	// which days reports are bucketed; empty means the local timezone.
+	Timezone string
+	// User is stamped on written events that have none.
+	User string
+	// Strict makes queries and reports fail on malformed day files instead
+	// of skipping them.
+	Strict bool
+}
+
+// Journal is a tt journal directory. It is safe for concurrent use; writes
+// through one Journal are serialized.
+type Journal struct {
+	dir    string
+	loc    *time.Location
+	user   string
+	parser *journal.Parser
+
+	mu     sync.Mutex
+	lastID int64
+}
+
+// DefaultDir returns the CLI's journal directory, ~/.tt/journal.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".tt", "journal"), nil
+}
+
+// OpenJournal opens the journal in dir (empty: DefaultDir). The directory is
+// created on the first write if it does not exist yet.
+func OpenJournal(dir string, opts Options) (*Journal, error) {
+	if dir == "" {
+		d, err := DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = d
+	}
+	if fi, err := os.Stat(dir); err == nil && !fi.IsDir() {
+		return nil, fmt.Errorf("journal %s is not a directory", dir)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	loc := time.Local
+	if opts.Timezone != "" {
+		l, err := time.LoadLocation(opts.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("timezone %q: %w", opts.Timezone, err)
+		}
+		loc = l
+	}
+	p := &journal.Parser{Location: loc, Strict: opts.Strict}
+	return &Journal{dir: dir, loc: loc, user: opts.User, parser: p}, nil
+}
+
+// Dir is the journal directory.
+func (j *Journal) Dir() string { return j.dir }
+
+// Location is the journal's timezone.
+func (j *Journal) Location() *time.Location { return j.loc }
+
+// dayPath is the day file of t: <dir>/YYYY/MM/YYYY-MM-DD.jsonl.
+func (j *Journal) dayPath(t time.Time) string {
+	t = t.In(j.loc)
+	return filepath.Join(j.dir, t.Format("2006"), t.Format("01"), t.Format("2006-01-02")+".jsonl")
+}
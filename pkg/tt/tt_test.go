@@ -0,0 +1,173 @@
+package tt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"tt/internal/journal"
+	"tt/internal/journal/journaltest"
+)
+
+func openTest(t *testing.T) *Journal {
+	t.Helper()
+	j, err := OpenJournal(t.TempDir(), Options{Timezone: "UTC", User: "ada", Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func at(day, h, m int) time.Time { return time.Date(2025, 10, day, h, m, 0, 0, time.UTC) }
+
+func TestWriteQueryReport(t *testing.T) {
+	j := openTest(t)
+	yes := true
+	evs := []Event{
+		{Type: "start", TS: at(6, 9, 0), Customer: "Acme", Project: "Portal", Billable: &yes, Note: "standup"},
+		{Type: "stop", TS: at(6, 10, 30)},
+		{Type: "add", TS: at(6, 18, 0), Customer: "Globex", Ref: at(6, 11, 0).Format(time.RFC3339) + ".." + at(6, 12, 0).Format(time.RFC3339)},
+		{Type: "add", TS: at(7, 23, 59), Customer: "Acme", Project: "Portal", Tags: []string{"ops"}, Ref: at(7, 23, 0).Format(time.RFC3339) + ".." + at(8, 0, 30).Format(time.RFC3339)},
+	}
+	if err := j.WriteEvents(evs); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := j.QueryEntries(context.Background(), Query{From: at(6, 0, 0), To: at(7, 0, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ents) != 2 || ents[0].Customer != "Acme" || ents[0].Duration(time.Time{}) != 90*time.Minute || ents[0].User != "ada" || ents[1].Customer != "Globex" {
+		t.Fatalf("Oct 6 entries = %+v", ents)
+	}
+	// the entry across midnight is found from its second day
+	ents, err = j.QueryEntries(context.Background(), Query{From: at(8, 0, 0), To: at(9, 0, 0), Tags: []string{"OPS"}})
+	if err != nil || len(ents) != 1 || !ents[0].Start.Equal(at(7, 23, 0)) || ents[0].End == nil {
+		t.Fatalf("Oct 8 entries = %+v, %v", ents, err)
+	}
+	if ents, _ := j.QueryEntries(context.Background(), Query{Customer: "acme"}); len(ents) != 2 {
+		t.Fatalf("all Acme entries = %+v", ents)
+	}
+
+	rep, err := j.Report(context.Background(), at(6, 0, 0), at(12, 0, 0), ReportOptions{Rounding: Rounding{QuantumMinutes: 15}, Locale: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.From != "2025-10-06" || rep.To != "2025-10-12" || len(rep.Days) != 7 || rep.Days[0].Weekday != "Mon" {
+		t.Fatalf("report range = %s..%s, %d days", rep.From, rep.To, len(rep.Days))
+	}
+	if d := rep.Days[0]; d.Seconds != 9000 || len(d.Groups) != 2 || d.Groups[0].Notes[0] != "standup" {
+		t.Fatalf("Oct 6 = %+v", d)
+	}
+	if rep.Seconds != 9000+5400 || rep.RawSeconds != 9000+5400 {
+		t.Fatalf("totals = %d / %d", rep.Seconds, rep.RawSeconds)
+	}
+	var out bytes.Buffer
+	if err := rep.Render(&out, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Week        string `json:"week"`
+		WeekSeconds int64  `json:"weekSeconds"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil || doc.Week != "2025-W41" || doc.WeekSeconds != rep.Seconds {
+		t.Fatalf("json report = %s (%v)", out.String(), err)
+	}
+}
+
+// Events written through the API chain like the CLI's: every hash verifies
+// and the anchor holds the last one, also across separate writes.
+func TestWriteEventsChainsHashes(t *testing.T) {
+	j := openTest(t)
+	for i := 0; i < 3; i++ {
+		if err := j.WriteEvent(Event{Type: "note", TS: at(6, 9, i), Note: "n"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := j.dayPath(at(6, 0, 0))
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	prev, ids := "", map[string]bool{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e journal.Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.PrevHash != prev || journal.EventHash(e) != e.Hash || !strings.HasPrefix(e.ID, "tt_") || ids[e.ID] {
+			t.Fatalf("bad chain link %+v (prev %s)", e, prev)
+		}
+		prev, ids[e.ID] = e.Hash, true
+	}
+	anchor, _ := os.ReadFile(path + ".hash")
+	if len(ids) != 3 || string(anchor) != prev {
+		t.Fatalf("%d events, anchor %s; want 3 and %s", len(ids), anchor, prev)
+	}
+}
+
+func TestWriteEventsRejectsInvalidEvents(t *testing.T) {
+	j := openTest(t)
+	for _, evs := range [][]Event{
+		{{Type: "note", TS: at(6, 9, 0)}, {TS: at(6, 9, 1)}},
+		{{Type: "add", TS: at(6, 9, 0), Ref: "yesterday"}},
+	} {
+		if err := j.WriteEvents(evs); !errors.Is(err, ErrInvalidEvent) {
+			t.Errorf("WriteEvents(%+v) = %v; want ErrInvalidEvent", evs, err)
+		}
+	}
+	if _, err := os.Stat(j.dayPath(at(6, 0, 0))); !os.IsNotExist(err) {
+		t.Fatalf("rejected batch was partly written: %v", err)
+	}
+}
+
+func TestOpenJournalErrors(t *testing.T) {
+	if _, err := OpenJournal(t.TempDir(), Options{Timezone: "Mars/Olympus"}); err == nil {
+		t.Error("unknown timezone accepted")
+	}
+	file := t.TempDir() + "/journal"
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenJournal(file, Options{}); err == nil {
+		t.Error("a file accepted as journal directory")
+	}
+}
+
+// Queries read journals the generator lays out like ~/.tt/journal.
+func TestQueryGeneratedJournal(t *testing.T) {
+	dir := t.TempDir()
+	evs := journaltest.Generate(journaltest.FixtureConfig{Seed: 7, CorrectionRate: 0.2})
+	if _, err := journaltest.WriteJournal(dir, evs, time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	j, err := OpenJournal(dir, Options{Timezone: "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := j.QueryEntries(context.Background(), Query{})
+	if err != nil || len(all) < 20 {
+		t.Fatalf("%d entries, %v", len(all), err)
+	}
+	week, err := j.QueryEntries(context.Background(), Query{From: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)})
+	if err != nil || len(week) == 0 || len(week) >= len(all) {
+		t.Fatalf("week entries = %d of %d, %v", len(week), len(all), err)
+	}
+}
+
+func TestEntryDurationLessPauses(t *testing.T) {
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC)
+	resume := start.Add(time.Hour)
+	e := Entry{Start: start, Pauses: []Interval{{Start: start.Add(30 * time.Minute), End: &resume}, {Start: start.Add(2 * time.Hour)}}}
+	if got := e.Duration(start.Add(3 * time.Hour)); got != 90*time.Minute {
+		t.Fatalf("Duration = %v", got)
+	}
+}
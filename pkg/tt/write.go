@@ -0,0 +1,133 @@
+package tt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"tt/internal/journal"
+)
+
+// ErrInvalidEvent is returned (wrapped) for events that cannot be written.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// WriteEvent appends e to the journal (see WriteEvents).
+func (j *Journal) WriteEvent(e Event) error {
+	return j.WriteEvents([]Event{e})
+}
+
+// WriteEvents appends evs to their day files in order, extending each day's
+// hash chain and anchor exactly like the CLI. Missing fields are filled in:
+// ID ("tt_<unix nanos>", as the CLI does), TS (now) and User
+// (Options.User). Every event is checked before anything is written.
+func (j *Journal) WriteEvents(evs []Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var order []string
+	byPath := map[string][]journal.Event{}
+	for i, e := range evs {
+		if e.Type == "" {
+			return fmt.Errorf("event %d: %w: missing type", i+1, ErrInvalidEvent)
+		}
+		if e.Type == "add" {
+			if err := checkAddRef(e.Ref); err != nil {
+				return fmt.Errorf("event %d: %w: %v", i+1, ErrInvalidEvent, err)
+			}
+		}
+		if e.ID == "" {
+			e.ID = j.nextID()
+		}
+		if e.TS.IsZero() {
+			e.TS = time.Now().In(j.loc)
+		}
+		if e.User == "" {
+			e.User = j.user
+		}
+		p := j.dayPath(e.TS)
+		if _, ok := byPath[p]; !ok {
+			order = append(order, p)
+		}
+		byPath[p] = append(byPath[p], journal.Event(e))
+	}
+
+	var lines bytes.Buffer
+	for _, p := range order {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		prev, err := readAnchor(p)
+		if err != nil {
+			return err
+		}
+		lines.Reset()
+		enc := json.NewEncoder(&lines)
+		for _, e := range byPath[p] {
+			e.PrevHash = prev
+			e.Hash = journal.EventHash(e)
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+			prev = e.Hash
+		}
+		f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(lines.Bytes()); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p+".hash", []byte(prev), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextID is the CLI's ID scheme, kept unique within this Journal even when
+// the clock does not advance between events.
+func (j *Journal) nextID() string {
+	n := time.Now().UnixNano()
+	if n <= j.lastID {
+		n = j.lastID + 1
+	}
+	j.lastID = n
+	return "tt_" + strconv.FormatInt(n, 10)
+}
+
+// readAnchor returns the last hash of the day file at p; a day without one
+// starts a new chain.
+func readAnchor(p string) (string, error) {
+	b, err := os.ReadFile(p + ".hash")
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return string(bytes.TrimSpace(b)), err
+}
+
+// checkAddRef checks the "startISO..endISO" range of an add event.
+func checkAddRef(ref string) error {
+	before, after, ok := strings.Cut(ref, "..")
+	if !ok {
+		return journal.ErrInvalidRef
+	}
+	start, err := time.Parse(time.RFC3339, before)
+	if err != nil {
+		return journal.ErrInvalidRef
+	}
+	end, err := time.Parse(time.RFC3339, after)
+	if err != nil || !end.After(start) {
+		return journal.ErrInvalidRef
+	}
+	return nil
+}